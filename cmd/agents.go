@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/rnwolfe/mine/internal/agents"
@@ -21,14 +22,20 @@ var (
 	agentsLinkAgent string
 	agentsLinkCopy  bool
 	agentsLinkForce bool
+	agentsLinkProj  bool
+	agentsLinkDest  string
+	agentsLinkDevc  bool
 
 	agentsUnlinkAgent string
 
-	agentsAdoptAgent  string
-	agentsAdoptDryRun bool
-	agentsAdoptCopy   bool
+	agentsAdoptAgent   string
+	agentsAdoptDryRun  bool
+	agentsAdoptCopy    bool
+	agentsAdoptResolve string
 
-	agentsDiffAgent string
+	agentsDiffAgent     string
+	agentsDiffApply     bool
+	agentsDiffDirection string
 )
 
 func init() {
@@ -44,16 +51,23 @@ func init() {
 	agentsLinkCmd.Flags().StringVar(&agentsLinkAgent, "agent", "", "Link only a specific agent (e.g. claude, codex)")
 	agentsLinkCmd.Flags().BoolVar(&agentsLinkCopy, "copy", false, "Copy files instead of creating symlinks")
 	agentsLinkCmd.Flags().BoolVar(&agentsLinkForce, "force", false, "Overwrite existing files without requiring adopt first")
+	agentsLinkCmd.Flags().BoolVar(&agentsLinkProj, "project", false, "Link the current project's instructions into its root")
+	agentsLinkCmd.Flags().StringVar(&agentsLinkDest, "dest", "", "Link into this directory as if it were the home directory")
+	agentsLinkCmd.Flags().BoolVar(&agentsLinkDevc, "devcontainer", false, "With --dest, copy files so they work without the store mounted")
 
 	agentsUnlinkCmd.Flags().StringVar(&agentsUnlinkAgent, "agent", "", "Unlink only a specific agent (e.g. claude, codex)")
 
 	agentsAdoptCmd.Flags().StringVar(&agentsAdoptAgent, "agent", "", "Adopt only from a specific agent (e.g. claude, codex)")
 	agentsAdoptCmd.Flags().BoolVar(&agentsAdoptDryRun, "dry-run", false, "Show what would be imported without making changes")
 	agentsAdoptCmd.Flags().BoolVar(&agentsAdoptCopy, "copy", false, "Import files but don't replace originals with symlinks")
+	agentsAdoptCmd.Flags().StringVar(&agentsAdoptResolve, "resolve", "", "Resolve conflicts without prompting: ours, theirs, or both")
 
 	agentsDiffCmd.Flags().StringVar(&agentsDiffAgent, "agent", "", "Diff only a specific agent's links (e.g. claude, codex)")
+	agentsDiffCmd.Flags().BoolVar(&agentsDiffApply, "apply", false, "Reconcile diverged copies after showing the diff")
+	agentsDiffCmd.Flags().StringVar(&agentsDiffDirection, "direction", agents.ApplyFromStore, "With --apply, which side wins: store or target")
 
 	agentsCmd.AddCommand(agentsSyncCmd)
+	agentsSyncCmd.AddCommand(agentsSyncInitCmd)
 	agentsSyncCmd.AddCommand(agentsSyncRemoteCmd)
 	agentsSyncCmd.AddCommand(agentsSyncPushCmd)
 	agentsSyncCmd.AddCommand(agentsSyncPullCmd)
@@ -86,7 +100,18 @@ var agentsLinkCmd = &cobra.Command{
 	Long: `Create symlinks from the canonical agents store to each detected agent's
 expected configuration locations. Only config types that exist in the store are linked
 (e.g. skips skills/ if it is empty). Use --copy to create file copies instead of
-symlinks. Use --force to overwrite existing non-symlink files.`,
+symlinks. Use --force to overwrite existing non-symlink files.
+
+With --project, link the current project's instructions from
+projects/<name>/AGENTS.md in the store into the project root as CLAUDE.md,
+AGENTS.md, and .cursorrules instead.
+
+With --dest <dir>, link into dir as if it were the home directory — e.g. a
+devcontainer's home mounted on the host — so agents inside get the same
+configs. Symlinks there point at this machine's store, which only resolves
+inside the container when the store is mounted at the same path; add
+--devcontainer to copy files instead, and keep them current with mine agents
+watch. --agent may name an agent that's only installed in the container.`,
 	RunE: hook.Wrap("agents.link", runAgentsLink),
 }
 
@@ -108,7 +133,12 @@ for developers who already have agent configs in place.
 
 Use --dry-run to preview what would be imported without making any changes.
 Use --copy to import files into the store without replacing originals with symlinks.
-Use --agent to limit adoption to a specific agent.`,
+Use --agent to limit adoption to a specific agent.
+
+When an agent's file conflicts with the store, adopt shows the diff and asks how
+to resolve it: keep the store's version (ours), take the agent's (theirs),
+keep both, or merge the two in $EDITOR. Use --resolve ours|theirs|both to
+answer for every conflict without prompting.`,
 	RunE: hook.Wrap("agents.adopt", runAgentsAdopt),
 }
 
@@ -128,7 +158,12 @@ replaced with a regular file or directory.
 Symlinked files always match canonical (they share the same inode), so no diff is
 shown for healthy symlinks.
 
-Use --agent to limit the diff to a specific agent.`,
+Use --agent to limit the diff to a specific agent.
+
+With --apply, diverged copy-mode links are reconciled after the diff is shown.
+--direction picks the winner: store (the default) overwrites each copy with the
+store's version; target saves each copy's version to the store. Replaced
+symlinks aren't touched — adopt or relink them instead.`,
 	RunE: hook.Wrap("agents.diff", runAgentsDiff),
 }
 
@@ -243,8 +278,18 @@ func runAgentsLink(_ *cobra.Command, _ []string) error {
 
 	opts := agents.LinkOptions{
 		Agent: agentsLinkAgent,
-		Copy:  agentsLinkCopy,
+		Copy:  agentsLinkCopy || agentsLinkDevc,
 		Force: agentsLinkForce,
+		Dest:  agentsLinkDest,
+	}
+	if agentsLinkDevc && agentsLinkDest == "" {
+		return fmt.Errorf("--devcontainer needs --dest <dir>: the container home to link into")
+	}
+	if agentsLinkProj {
+		if agentsLinkDest != "" {
+			return fmt.Errorf("--dest can't be combined with --project")
+		}
+		return runAgentsLinkProject(opts)
 	}
 
 	actions, err := agents.Link(opts)
@@ -271,6 +316,9 @@ func runAgentsLink(_ *cobra.Command, _ []string) error {
 	if createdCount > 0 {
 		ui.Ok(fmt.Sprintf("%d link(s) configured", createdCount))
 	}
+	if agentsLinkDevc && createdCount > 0 {
+		fmt.Printf("  Keep the copies current with %s\n", ui.Accent.Render("mine agents watch"))
+	}
 	fmt.Println()
 	return nil
 }
@@ -325,10 +373,20 @@ func runAgentsAdopt(_ *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	resolve, err := agentsAdoptResolver(agentsAdoptResolve)
+	if err != nil {
+		return err
+	}
+	projects, err := agentsRegisteredProjects()
+	if err != nil {
+		return err
+	}
 	opts := agents.AdoptOptions{
-		Agent:  agentsAdoptAgent,
-		DryRun: agentsAdoptDryRun,
-		Copy:   agentsAdoptCopy,
+		Agent:    agentsAdoptAgent,
+		DryRun:   agentsAdoptDryRun,
+		Copy:     agentsAdoptCopy,
+		Resolve:  resolve,
+		Projects: projects,
 	}
 
 	items, err := agents.Adopt(opts)
@@ -351,6 +409,7 @@ func runAgentsAdopt(_ *cobra.Command, _ []string) error {
 	}
 
 	importedCount := 0
+	mergedCount := 0
 	conflictCount := 0
 	skippedCount := 0
 	alreadyManagedCount := 0
@@ -365,6 +424,8 @@ func runAgentsAdopt(_ *cobra.Command, _ []string) error {
 			switch item.Status {
 			case "imported":
 				importedCount++
+			case "merged":
+				mergedCount++
 			case "skipped":
 				skippedCount++
 			case "already-managed":
@@ -394,12 +455,15 @@ func runAgentsAdopt(_ *cobra.Command, _ []string) error {
 			}
 			ui.Ok(fmt.Sprintf("%d item(s) imported, %s", importedCount, modeStr))
 		}
+		if mergedCount > 0 {
+			ui.Ok(fmt.Sprintf("%d conflict(s) resolved", mergedCount))
+		}
 		if conflictCount > 0 {
-			fmt.Printf("  %s %d conflict(s) skipped — edit %s manually, then re-run\n",
+			fmt.Printf("  %s %d conflict(s) skipped — edit the store files manually, or re-run with %s\n",
 				ui.Warning.Render(ui.IconWarn), conflictCount,
-				ui.Accent.Render("instructions/AGENTS.md"))
+				ui.Accent.Render("--resolve ours|theirs|both"))
 		}
-		if importedCount == 0 && conflictCount == 0 {
+		if importedCount == 0 && mergedCount == 0 && conflictCount == 0 {
 			fmt.Println(ui.Muted.Render("  All configs already managed — nothing to import."))
 		}
 	}
@@ -410,19 +474,34 @@ func runAgentsAdopt(_ *cobra.Command, _ []string) error {
 
 // printAdoptItem prints a single adoption result row.
 func printAdoptItem(item agents.AdoptItem, dryRun bool) {
+	// Project instructions are listed by project and file.
+	subject, kind := item.Agent, item.Kind
+	if item.Project != "" {
+		subject, kind = item.Project, filepath.Base(item.SourcePath)
+	}
 	switch {
 	case item.Conflict:
+		reason := "(store has different content)"
+		if item.Err != nil {
+			reason = item.Err.Error()
+		}
 		fmt.Printf("  %-10s %-14s %s %s\n",
-			item.Agent, item.Kind,
+			subject, kind,
 			ui.Warning.Render(ui.IconWarn+"conflict"),
-			ui.Muted.Render("(store has different content)"))
+			ui.Muted.Render(reason))
+	case item.Status == "merged":
+		fmt.Printf("  %-10s %-14s %s %s %s\n",
+			subject, kind,
+			ui.Success.Render(ui.IconOk+"merged"),
+			ui.Muted.Render(ui.IconArrow),
+			ui.Muted.Render(item.StoreRel))
 	case item.Status == "already-managed":
 		fmt.Printf("  %-10s %-14s %s\n",
-			item.Agent, item.Kind,
+			subject, kind,
 			ui.Muted.Render(ui.IconOk+"already managed"))
 	case item.Err != nil:
 		fmt.Printf("  %-10s %-14s %s %s\n",
-			item.Agent, item.Kind,
+			subject, kind,
 			ui.Warning.Render(ui.IconWarn+"skipped"),
 			ui.Muted.Render(item.Err.Error()))
 	default:
@@ -431,11 +510,15 @@ func printAdoptItem(item agents.AdoptItem, dryRun bool) {
 			verb = "would import"
 		}
 		statusStr := ui.Success.Render(ui.IconOk + verb)
+		dest := item.StoreRel
+		if item.DuplicateOf != "" {
+			dest += " (shared with " + item.DuplicateOf + ")"
+		}
 		fmt.Printf("  %-10s %-14s %s %s %s\n",
-			item.Agent, item.Kind,
+			subject, kind,
 			statusStr,
 			ui.Muted.Render(ui.IconArrow),
-			ui.Muted.Render(item.StoreRel))
+			ui.Muted.Render(dest))
 	}
 }
 
@@ -455,6 +538,9 @@ func printLinkAction(a agents.LinkAction) {
 			modeStr = "copy"
 		}
 		statusStr = ui.Success.Render(ui.IconOk + a.Status + " (" + modeStr + ")")
+		if a.Mode == "render" {
+			statusStr = ui.Success.Render(ui.IconOk + a.Status)
+		}
 		fmt.Printf("  %-10s %-10s %s %s\n", a.Agent, a.Source, ui.Muted.Render(ui.IconArrow), statusStr)
 	}
 }
@@ -475,6 +561,7 @@ var agentsSyncCmd = &cobra.Command{
 	Short: "Sync agent configs with a git remote",
 	Long: `Back up and sync your canonical agent config store with a git remote.
 
+  mine agents sync init <url>     Clone or connect the store to a remote
   mine agents sync remote <url>   Set the remote repository URL
   mine agents sync remote         Show the current remote URL
   mine agents sync push           Push store to remote
@@ -482,6 +569,19 @@ var agentsSyncCmd = &cobra.Command{
 	RunE: hook.Wrap("agents.sync", runAgentsSyncHelp),
 }
 
+var agentsSyncInitCmd = &cobra.Command{
+	Use:   "init <url>",
+	Short: "Set up syncing the agents store with a git remote",
+	Long: `Connect the agents store to a git remote so every machine shares the same
+instructions, skills, and commands.
+
+On a machine without a store, the remote is cloned into place (an empty remote
+is scaffolded like mine agents init). Otherwise the existing store is pointed at
+the remote — push it with mine agents sync push.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("agents.sync.init", runAgentsSyncInit),
+}
+
 var agentsSyncRemoteCmd = &cobra.Command{
 	Use:   "remote [url]",
 	Short: "Get or set the sync remote URL",
@@ -513,6 +613,7 @@ func runAgentsSyncHelp(_ *cobra.Command, _ []string) error {
 	fmt.Println()
 	fmt.Println("  Sync your agent configs with a git remote.")
 	fmt.Println()
+	fmt.Printf("  %s     Clone or connect to a remote\n", ui.Accent.Render("mine agents sync init"))
 	fmt.Printf("  %s   Set or show the remote URL\n", ui.Accent.Render("mine agents sync remote"))
 	fmt.Printf("  %s          Push store to remote\n", ui.Accent.Render("mine agents sync push"))
 	fmt.Printf("  %s          Pull from remote\n", ui.Accent.Render("mine agents sync pull"))
@@ -520,6 +621,27 @@ func runAgentsSyncHelp(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func runAgentsSyncInit(_ *cobra.Command, args []string) error {
+	result, err := agents.SyncInit(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	switch {
+	case result.Cloned && !result.Scaffolded:
+		ui.Ok(fmt.Sprintf("Agents store cloned from %s", args[0]))
+		fmt.Printf("  Next: %s, then %s\n", ui.Accent.Render("mine agents detect"), ui.Accent.Render("mine agents link"))
+	case result.Cloned:
+		ui.Ok(fmt.Sprintf("Remote %s was empty — started a fresh store", args[0]))
+		fmt.Printf("  Share it: %s\n", ui.Accent.Render("mine agents sync push"))
+	default:
+		ui.Ok(fmt.Sprintf("Agents store will sync with %s", args[0]))
+		fmt.Printf("  Share it: %s\n", ui.Accent.Render("mine agents sync push"))
+	}
+	fmt.Println()
+	return nil
+}
+
 func runAgentsSyncRemote(_ *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		url := agents.SyncRemoteURL()
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+)
+
+// adoptIsTTY reports whether adopt can prompt. Injectable for testing.
+var adoptIsTTY = tui.IsTTY
+
+// adoptDiffShown caps how many diff lines the conflict prompt prints.
+const adoptDiffShown = 40
+
+// agentsAdoptResolver returns how adopt settles conflicts: with the
+// --resolve strategy, by asking when stdin is a terminal, or not at all.
+func agentsAdoptResolver(strategy string) (agents.ConflictResolver, error) {
+	switch strategy {
+	case "":
+		if !adoptIsTTY() {
+			return nil, nil
+		}
+		reader := bufio.NewReader(os.Stdin)
+		return func(item agents.AdoptItem) ([]byte, bool, error) {
+			return promptAdoptConflict(reader, item)
+		}, nil
+	case agents.ResolveOurs, agents.ResolveTheirs, agents.ResolveBoth:
+		return func(item agents.AdoptItem) ([]byte, bool, error) {
+			content, err := agents.ResolveContent(item, strategy)
+			return content, err == nil, err
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid --resolve %q — use %s, %s, or %s",
+			strategy, agents.ResolveOurs, agents.ResolveTheirs, agents.ResolveBoth)
+	}
+}
+
+// promptAdoptConflict shows how an agent's file differs from the store and
+// asks how to resolve it. Skipping (or end of input) leaves the conflict.
+func promptAdoptConflict(reader *bufio.Reader, item agents.AdoptItem) ([]byte, bool, error) {
+	fmt.Println()
+	fmt.Printf("  %s %s %s differs from %s\n",
+		ui.Warning.Render(ui.IconWarn+"conflict"),
+		ui.Accent.Render(item.Agent),
+		ui.Muted.Render(item.SourcePath),
+		ui.Accent.Render(item.StoreRel))
+
+	lines, err := agents.ConflictDiff(item)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, line := range lines {
+		if i == adoptDiffShown {
+			fmt.Println(ui.Muted.Render(fmt.Sprintf("    … %d more line(s)", len(lines)-i)))
+			break
+		}
+		fmt.Println("    " + formatDiffLine(line))
+	}
+
+	both := "keep both"
+	if item.Kind == "mcp" {
+		both = "combine servers"
+	}
+	for {
+		fmt.Printf("  [o]urs keep store · [t]heirs take %s's · [b]oth %s · [e]dit merge in $EDITOR · [s]kip: ",
+			item.Agent, both)
+		line, readErr := reader.ReadString('\n')
+		choice := strings.ToLower(strings.TrimSpace(line))
+
+		switch choice {
+		case "o", "ours":
+			content, err := agents.ResolveContent(item, agents.ResolveOurs)
+			return content, err == nil, err
+		case "t", "theirs":
+			content, err := agents.ResolveContent(item, agents.ResolveTheirs)
+			return content, err == nil, err
+		case "b", "both":
+			content, err := agents.ResolveContent(item, agents.ResolveBoth)
+			return content, err == nil, err
+		case "e", "edit":
+			content, err := editAdoptMerge(item)
+			if err != nil {
+				fmt.Printf("  %s\n", ui.Muted.Render(err.Error()))
+				continue
+			}
+			return content, true, nil
+		case "s", "skip":
+			return nil, false, nil
+		}
+		if readErr == io.EOF {
+			return nil, false, nil
+		}
+		fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("%q isn't a choice", choice)))
+	}
+}
+
+// editAdoptMerge opens the store's and agent's content, merged with
+// conflict markers, in $EDITOR and returns the result.
+func editAdoptMerge(item agents.AdoptItem) ([]byte, error) {
+	editor := os.Getenv("EDITOR")
+	if strings.TrimSpace(editor) == "" {
+		return nil, fmt.Errorf("$EDITOR is not set — choose another option, or set it with: export EDITOR=vim")
+	}
+
+	merged, err := agents.MergeMarkers(item)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(os.TempDir(), "mine-adopt-*"+filepath.Ext(item.StoreRel))
+	if err != nil {
+		return nil, fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	if _, err := tmp.Write(merged); err != nil {
+		_ = tmp.Close()
+		return nil, fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("closing temp file: %w", err)
+	}
+
+	if err := runEditor(editor, tmpPath); err != nil {
+		return nil, fmt.Errorf("editor exited with an error — nothing merged: %w", err)
+	}
+	content, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading temp file after edit: %w", err)
+	}
+	if agents.HasConflictMarkers(content) {
+		return nil, fmt.Errorf("conflict markers remain — resolve every <<<<<<< block, or choose another option")
+	}
+	return content, nil
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+// setupAdoptConflictEnv gives the store and claude different instructions
+// and returns claude's pending adopt item.
+func setupAdoptConflictEnv(t *testing.T) agents.AdoptItem {
+	t.Helper()
+	storeDir, homeDir := setupAgentsAdoptEnv(t)
+	claudeDir := filepath.Join(homeDir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(claudeDir, "CLAUDE.md"), []byte("# Rules\n\nUse spaces.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(storeDir, "instructions", "AGENTS.md"), []byte("# Rules\n\nUse tabs.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := agents.ReadManifest()
+	m.Agents = []agents.Agent{{Name: "claude", Detected: true, ConfigDir: claudeDir}}
+	if err := agents.WriteManifest(m); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := agents.Adopt(agents.AdoptOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range items {
+		if item.Kind == "instruction" {
+			return item
+		}
+	}
+	t.Fatal("no instruction item")
+	return agents.AdoptItem{}
+}
+
+func TestRunAgentsAdopt_ResolveFlag(t *testing.T) {
+	item := setupAdoptConflictEnv(t)
+	agentsAdoptAgent, agentsAdoptDryRun, agentsAdoptCopy = "", false, false
+	agentsAdoptResolve = agents.ResolveBoth
+	t.Cleanup(func() { agentsAdoptResolve = "" })
+
+	out := captureStdout(t, func() {
+		if err := runAgentsAdopt(nil, nil); err != nil {
+			t.Errorf("runAgentsAdopt: %v", err)
+		}
+	})
+	if !strings.Contains(out, "merged") || !strings.Contains(out, "1 conflict(s) resolved") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	data, _ := os.ReadFile(item.StoreAbs)
+	if !strings.Contains(string(data), "Use tabs.") || !strings.Contains(string(data), "Use spaces.") {
+		t.Errorf("store instructions = %q, want both versions", data)
+	}
+}
+
+func TestRunAgentsAdopt_InvalidResolve(t *testing.T) {
+	setupAgentsAdoptEnv(t)
+	agentsAdoptResolve = "mine"
+	t.Cleanup(func() { agentsAdoptResolve = "" })
+
+	if err := runAgentsAdopt(nil, nil); err == nil || !strings.Contains(err.Error(), "invalid --resolve") {
+		t.Errorf("error = %v, want invalid --resolve", err)
+	}
+}
+
+func TestPromptAdoptConflict_Choices(t *testing.T) {
+	item := setupAdoptConflictEnv(t)
+
+	var content []byte
+	var ok bool
+	out := captureStdout(t, func() {
+		var err error
+		content, ok, err = promptAdoptConflict(bufio.NewReader(strings.NewReader("x\ntheirs\n")), item)
+		if err != nil {
+			t.Errorf("promptAdoptConflict: %v", err)
+		}
+	})
+	if !ok || string(content) != "# Rules\n\nUse spaces.\n" {
+		t.Errorf("content = %q, ok = %v; want claude's", content, ok)
+	}
+	if !strings.Contains(out, "-Use tabs.") || !strings.Contains(out, "+Use spaces.") || !strings.Contains(out, `"x" isn't a choice`) {
+		t.Errorf("unexpected prompt output:\n%s", out)
+	}
+
+	captureStdout(t, func() {
+		_, ok, _ = promptAdoptConflict(bufio.NewReader(strings.NewReader("")), item)
+	})
+	if ok {
+		t.Error("end of input should skip the conflict")
+	}
+}
+
+func TestPromptAdoptConflict_EditInEditor(t *testing.T) {
+	item := setupAdoptConflictEnv(t)
+	t.Setenv("EDITOR", "fake-editor")
+
+	calls := 0
+	orig := runEditor
+	t.Cleanup(func() { runEditor = orig })
+	runEditor = func(_, path string) error {
+		calls++
+		if calls == 1 {
+			return nil // save without resolving the markers
+		}
+		return os.WriteFile(path, []byte("# Rules\n\nUse tabs, width 4.\n"), 0o600)
+	}
+
+	var content []byte
+	var ok bool
+	out := captureStdout(t, func() {
+		content, ok, _ = promptAdoptConflict(bufio.NewReader(strings.NewReader("e\ne\n")), item)
+	})
+	if !ok || string(content) != "# Rules\n\nUse tabs, width 4.\n" {
+		t.Errorf("content = %q, ok = %v", content, ok)
+	}
+	if !strings.Contains(out, "conflict markers remain") {
+		t.Errorf("leftover markers not reported:\n%s", out)
+	}
+}
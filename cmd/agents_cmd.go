@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentsCmdAddAgents []string
+
+	agentsCmdLinkAgent string
+	agentsCmdLinkCopy  bool
+	agentsCmdLinkForce bool
+)
+
+var agentsCmdCmd = &cobra.Command{
+	Use:     "cmd",
+	Aliases: []string{"commands"},
+	Short:   "Manage slash-command templates for every agent",
+	Long: `Keep reusable prompt commands in the store's commands/ directory and link
+them into each agent that supports custom slash commands:
+
+  claude     ~/.claude/commands/
+  codex      ~/.codex/prompts/
+  gemini     ~/.gemini/commands/ (converted to TOML, $ARGUMENTS becomes {{args}})
+  opencode   ~/.config/opencode/command/
+  cursor     ~/.cursor/commands/
+
+A command is a markdown prompt. Its front matter may give a description and
+limit it to certain agents:
+
+  ---
+  description: Review the staged changes
+  agents: [claude, codex]
+  ---
+
+  mine agents cmd add <name>    Scaffold a new command
+  mine agents cmd list          List commands and who gets them
+  mine agents cmd link          Link commands into every detected agent`,
+	RunE: hook.Wrap("agents.cmd", runAgentsCmdList),
+}
+
+var agentsCmdAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Scaffold a new command in the store",
+	Long: `Create commands/<name>.md in the store, ready to edit. Use $ARGUMENTS where
+the text typed after the slash command should go.
+
+Name must be lowercase letters, digits, and hyphens (1-64 chars).`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("agents.cmd.add", runAgentsCmdAdd),
+}
+
+var agentsCmdListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List commands in the store",
+	Args:    cobra.NoArgs,
+	RunE:    hook.Wrap("agents.cmd.list", runAgentsCmdList),
+}
+
+var agentsCmdLinkCmd = &cobra.Command{
+	Use:   "link",
+	Short: "Link commands into each detected agent that supports them",
+	Long: `Link the store's commands into every detected agent's commands directory.
+Agents get only the commands meant for them, in their own format. mine agents
+link does this as well.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("agents.cmd.link", runAgentsCmdLink),
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsCmdCmd)
+	agentsCmdCmd.AddCommand(agentsCmdAddCmd)
+	agentsCmdCmd.AddCommand(agentsCmdListCmd)
+	agentsCmdCmd.AddCommand(agentsCmdLinkCmd)
+
+	agentsCmdAddCmd.Flags().StringSliceVar(&agentsCmdAddAgents, "agents", nil, "Agents the command is for (default: all)")
+
+	agentsCmdLinkCmd.Flags().StringVar(&agentsCmdLinkAgent, "agent", "", "Link only a specific agent (e.g. claude, gemini)")
+	agentsCmdLinkCmd.Flags().BoolVar(&agentsCmdLinkCopy, "copy", false, "Copy files instead of creating symlinks")
+	agentsCmdLinkCmd.Flags().BoolVar(&agentsCmdLinkForce, "force", false, "Overwrite existing files without requiring adopt first")
+}
+
+func runAgentsCmdAdd(_ *cobra.Command, args []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	name := args[0]
+	result, err := agents.AddCommand(name, agentsCmdAddAgents...)
+	if err != nil {
+		return fmt.Errorf("adding command: %w", err)
+	}
+	rel, err := filepath.Rel(agents.Dir(), result.File)
+	if err != nil {
+		rel = result.File
+	}
+
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Command %s created", ui.Accent.Render(name)))
+	fmt.Printf("  Location: %s\n", ui.Muted.Render(rel))
+	fmt.Println()
+	fmt.Printf("  Next: edit %s, then %s\n", ui.Accent.Render(rel), ui.Accent.Render("mine agents cmd link"))
+	fmt.Println()
+	return nil
+}
+
+func runAgentsCmdList(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No agents store yet."))
+		fmt.Printf("  Run %s first.\n", ui.Accent.Render("mine agents init"))
+		fmt.Println()
+		return nil
+	}
+
+	commands, err := agents.ListCommands()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(commands) == 0 {
+		fmt.Println(ui.Muted.Render("  No commands yet."))
+		fmt.Printf("  Add one with %s\n", ui.Accent.Render("mine agents cmd add <name>"))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %s\n", ui.KeyStyle.Render(fmt.Sprintf("Commands (%d):", len(commands))))
+	width := 16
+	for _, c := range commands {
+		if len(c.Name)+1 > width {
+			width = len(c.Name) + 1
+		}
+	}
+	for _, c := range commands {
+		line := fmt.Sprintf("    %-*s", width, "/"+c.Name)
+		if len(c.Agents) > 0 {
+			line += "  " + ui.Accent.Render("["+strings.Join(c.Agents, ", ")+"]")
+		}
+		if c.Description != "" {
+			line += "  " + ui.Muted.Render(c.Description)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	fmt.Printf("  %s\n", ui.Muted.Render("Linked to "+strings.Join(agents.CommandAgents(), ", ")+" unless limited with agents:"))
+	fmt.Println()
+	return nil
+}
+
+func runAgentsCmdLink(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No agents store yet."))
+		fmt.Printf("  Run %s first.\n", ui.Accent.Render("mine agents init"))
+		fmt.Println()
+		return nil
+	}
+
+	actions, err := agents.LinkCommands(agents.LinkOptions{
+		Agent: agentsCmdLinkAgent,
+		Copy:  agentsCmdLinkCopy,
+		Force: agentsCmdLinkForce,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(actions) == 0 {
+		fmt.Println(ui.Muted.Render("  Nothing to link — add a command with " + ui.Accent.Render("mine agents cmd add") +
+			ui.Muted.Render(" and make sure agents are detected.")))
+		fmt.Println()
+		return nil
+	}
+
+	linked := 0
+	for _, a := range actions {
+		printLinkAction(a)
+		if a.Err == nil {
+			linked++
+		}
+	}
+	fmt.Println()
+	if linked > 0 {
+		ui.Ok(fmt.Sprintf("Commands linked for %d agent(s)", linked))
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAgentsCmd_AddListLink(t *testing.T) {
+	_, claudeDir := setupAgentsLinkEnv(t)
+	t.Cleanup(func() { agentsCmdAddAgents = nil })
+
+	agentsCmdAddAgents = []string{"claude"}
+	captureStdout(t, func() {
+		if err := runAgentsCmdAdd(nil, []string{"review"}); err != nil {
+			t.Fatalf("runAgentsCmdAdd: %v", err)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := runAgentsCmdList(nil, nil); err != nil {
+			t.Fatalf("runAgentsCmdList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "/review") || !strings.Contains(out, "[claude]") {
+		t.Errorf("unexpected list output:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runAgentsCmdLink(nil, nil); err != nil {
+			t.Fatalf("runAgentsCmdLink: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Commands linked") {
+		t.Errorf("unexpected link output:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(claudeDir, "commands", "review.md")); err != nil {
+		t.Errorf("review.md not linked for claude: %v", err)
+	}
+}
+
+func TestRunAgentsCmdList_Empty(t *testing.T) {
+	setupAgentsLinkEnv(t)
+
+	out := captureStdout(t, func() {
+		if err := runAgentsCmdList(nil, nil); err != nil {
+			t.Fatalf("runAgentsCmdList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No commands yet") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
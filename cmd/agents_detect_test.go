@@ -98,13 +98,13 @@ func TestRunAgentsDetect_Idempotent(t *testing.T) {
 		}
 	})
 
-	// Manifest should still have exactly 4 agents (one per registry entry), not duplicates.
+	// Manifest should still have exactly 8 agents (one per registry entry), not duplicates.
 	m, err := agents.ReadManifest()
 	if err != nil {
 		t.Fatalf("ReadManifest: %v", err)
 	}
-	if len(m.Agents) != 4 {
-		t.Errorf("manifest.Agents count = %d after two detects, want 4 (no duplicates)", len(m.Agents))
+	if len(m.Agents) != 8 {
+		t.Errorf("manifest.Agents count = %d after two detects, want 8 (no duplicates)", len(m.Agents))
 	}
 }
 
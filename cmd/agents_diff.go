@@ -10,6 +10,9 @@ import (
 )
 
 func runAgentsDiff(_ *cobra.Command, _ []string) error {
+	if agentsDiffApply && agentsDiffDirection != agents.ApplyFromStore && agentsDiffDirection != agents.ApplyFromTarget {
+		return fmt.Errorf("invalid --direction %q — use %s or %s", agentsDiffDirection, agents.ApplyFromStore, agents.ApplyFromTarget)
+	}
 	if !agents.IsInitialized() {
 		fmt.Println()
 		fmt.Println(ui.Muted.Render("  No agents store yet."))
@@ -47,12 +50,63 @@ func runAgentsDiff(_ *cobra.Command, _ []string) error {
 	fmt.Println()
 	if diffCount == 0 {
 		ui.Ok("All links match canonical store — nothing to sync")
+	} else if agentsDiffApply {
+		return applyAgentsDiff(entries, agentsDiffDirection)
 	} else {
 		fmt.Printf("  %s %d link(s) differ from canonical store\n",
 			ui.Warning.Render(ui.IconWarn), diffCount)
 		fmt.Printf("  Run %s to restore symlinks.\n", ui.Accent.Render("mine agents link --force"))
+		fmt.Printf("  Reconcile copies with %s\n", ui.Accent.Render("mine agents diff --apply [--direction store|target]"))
+	}
+	fmt.Println()
+	return nil
+}
+
+// applyAgentsDiff reconciles each diverged copy in direction and reports
+// the outcome. Taking targets into the store applies at most one copy per
+// source, so two edited copies never silently overwrite each other.
+func applyAgentsDiff(entries []agents.DiffEntry, direction string) error {
+	applied, failed := 0, 0
+	updatedFrom := map[string]string{} // store source → target it was taken from
+	for _, e := range entries {
+		if e.State != agents.LinkHealthDiverged {
+			continue
+		}
+		var err error
+		if from, ok := updatedFrom[e.Link.Source]; ok && direction == agents.ApplyFromTarget {
+			err = fmt.Errorf("%s was just taken from %s — run mine agents diff again", e.Link.Source, from)
+		} else {
+			err = agents.ApplyDiff(e.Link, direction)
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("  %s%s %s\n", ui.Error.Render(ui.IconError), e.Link.Target, ui.Muted.Render(err.Error()))
+			continue
+		}
+		applied++
+		if direction == agents.ApplyFromTarget {
+			updatedFrom[e.Link.Source] = e.Link.Target
+			fmt.Printf("  %s%s %s %s\n", ui.Success.Render(ui.IconOk), e.Link.Target, ui.Muted.Render(ui.IconArrow), e.Link.Source)
+		} else {
+			fmt.Printf("  %s%s %s %s\n", ui.Success.Render(ui.IconOk), e.Link.Source, ui.Muted.Render(ui.IconArrow), e.Link.Target)
+		}
 	}
+
 	fmt.Println()
+	switch {
+	case applied == 0 && failed == 0:
+		fmt.Println(ui.Muted.Render("  No diverged copies to apply — replaced links need " +
+			"mine agents adopt or mine agents link --force."))
+	case applied > 0:
+		ui.Ok(fmt.Sprintf("Reconciled %d copy link(s)", applied))
+		if direction == agents.ApplyFromTarget {
+			fmt.Printf("  Save the store changes: %s\n", ui.Accent.Render("mine agents snapshot"))
+		}
+	}
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d link(s) could not be reconciled", failed)
+	}
 	return nil
 }
 
@@ -200,3 +200,73 @@ func TestRunAgentsDiff_AgentFilter(t *testing.T) {
 		t.Errorf("expected gemini target to be absent from filtered diff output, got:\n%s", out)
 	}
 }
+
+func TestRunAgentsDiff_Apply(t *testing.T) {
+	agentsTestEnv(t)
+	captureStdout(t, func() {
+		if err := runAgentsInit(nil, nil); err != nil {
+			t.Fatalf("runAgentsInit: %v", err)
+		}
+	})
+
+	sourcePath := filepath.Join(agents.Dir(), "instructions", "AGENTS.md")
+	if err := os.WriteFile(sourcePath, []byte("canonical\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	claudeDir := t.TempDir()
+	geminiDir := t.TempDir()
+	claudeTarget := filepath.Join(claudeDir, "CLAUDE.md")
+	geminiTarget := filepath.Join(geminiDir, "GEMINI.md")
+	for _, p := range []string{claudeTarget, geminiTarget} {
+		if err := os.WriteFile(p, []byte(filepath.Base(p)+" edit\n"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	m := &agents.Manifest{
+		Agents: []agents.Agent{
+			{Name: "claude", Detected: true, ConfigDir: claudeDir},
+			{Name: "gemini", Detected: true, ConfigDir: geminiDir},
+		},
+		Links: []agents.LinkEntry{
+			{Source: "instructions/AGENTS.md", Target: claudeTarget, Agent: "claude", Mode: "copy"},
+			{Source: "instructions/AGENTS.md", Target: geminiTarget, Agent: "gemini", Mode: "copy"},
+		},
+	}
+	if err := agents.WriteManifest(m); err != nil {
+		t.Fatalf("WriteManifest: %v", err)
+	}
+
+	run := func(direction string) (string, error) {
+		agentsDiffApply, agentsDiffDirection = true, direction
+		defer func() { agentsDiffApply, agentsDiffDirection = false, agents.ApplyFromStore }()
+		var err error
+		out := captureStdout(t, func() { err = runAgentsDiff(nil, nil) })
+		return out, err
+	}
+
+	if _, err := run("sideways"); err == nil {
+		t.Error("--direction sideways = nil, want error")
+	}
+
+	// Taking both copies into the store: only the first applies.
+	out, err := run(agents.ApplyFromTarget)
+	if err == nil {
+		t.Errorf("second copy of one source applied, want an error; output:\n%s", out)
+	}
+	data, _ := os.ReadFile(sourcePath)
+	if string(data) != "CLAUDE.md edit\n" {
+		t.Errorf("store = %q, want the claude copy", data)
+	}
+
+	out, err = run(agents.ApplyFromStore)
+	if err != nil {
+		t.Fatalf("--apply --direction store: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Reconciled 1") {
+		t.Errorf("expected 'Reconciled 1' in output, got:\n%s", out)
+	}
+	data, _ = os.ReadFile(geminiTarget)
+	if string(data) != "CLAUDE.md edit\n" {
+		t.Errorf("gemini copy = %q, want the store's content", data)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var agentsDoctorFix bool
+
+func init() {
+	agentsCmd.AddCommand(agentsDoctorCmd)
+	agentsDoctorCmd.Flags().BoolVar(&agentsDoctorFix, "fix", false, "Repair what can be fixed without losing changes")
+}
+
+var agentsDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Find broken, overwritten, and stale agent links",
+	Long: `Check every managed link and the installed agents, and report what needs attention:
+
+  - symlinks whose destination is gone, or links that were deleted
+  - links an agent updater replaced with a regular file
+  - manifest entries whose source is no longer in the store
+  - agents installed since the last mine agents detect
+
+With --fix, mine repairs what it safely can: it recreates missing links, relinks
+overwritten files that still match the store, drops entries for deleted sources,
+and links new agents. Files with changes that aren't in the store are never
+overwritten — doctor tells you how to keep or discard them.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("agents.doctor", runAgentsDoctor),
+}
+
+func runAgentsDoctor(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	findings, err := agents.Diagnose(agentsDoctorFix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(findings) == 0 {
+		ui.Ok("All agent links look healthy.")
+		fmt.Println()
+		return nil
+	}
+
+	remaining, fixable := 0, 0
+	for _, f := range findings {
+		printAgentsFinding(f)
+		if !f.Fixed {
+			remaining++
+			if f.Fixable && f.FixErr == nil {
+				fixable++
+			}
+		}
+	}
+	fmt.Println()
+
+	if remaining == 0 {
+		ui.Ok(fmt.Sprintf("Fixed %d problem(s).", len(findings)))
+		fmt.Println()
+		return nil
+	}
+	if fixable > 0 {
+		fmt.Printf("  Repair %d of them: %s\n", fixable, ui.Accent.Render("mine agents doctor --fix"))
+		fmt.Println()
+	}
+	return fmt.Errorf("%d agent link problem(s) need attention", remaining)
+}
+
+// printAgentsFinding prints one doctor finding with its fix outcome or hint.
+func printAgentsFinding(f agents.Finding) {
+	icon := ui.Warning.Render(ui.IconWarn)
+	if f.Fixed {
+		icon = ui.Success.Render(ui.IconOk)
+	}
+	subject := f.Agent
+	if f.Target != "" {
+		subject = strings.TrimSpace(subject + " " + ui.Muted.Render(f.Target))
+	}
+	fmt.Printf("  %s %s %s\n", icon, ui.KeyStyle.Render(fmt.Sprintf("%-15s", f.Check)), subject)
+
+	indent := strings.Repeat(" ", 20)
+	fmt.Printf("%s%s\n", indent, f.Message)
+	switch {
+	case f.Fixed:
+		fmt.Printf("%s%s\n", indent, ui.Muted.Render("→ fixed"))
+	case f.FixErr != nil:
+		fmt.Printf("%s%s\n", indent, ui.Muted.Render("→ fix failed: "+f.FixErr.Error()))
+		fmt.Printf("%s%s\n", indent, ui.Muted.Render("→ "+f.Hint))
+	case f.Hint != "":
+		fmt.Printf("%s%s\n", indent, ui.Muted.Render("→ "+f.Hint))
+	}
+}
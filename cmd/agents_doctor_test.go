@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAgentsDoctor_ReportsAndFixes(t *testing.T) {
+	_, claudeDir := setupAgentsLinkEnv(t)
+	t.Setenv("PATH", t.TempDir())
+	captureStdout(t, func() {
+		if err := runAgentsLink(nil, nil); err != nil {
+			t.Fatalf("runAgentsLink: %v", err)
+		}
+	})
+	instr := filepath.Join(claudeDir, "CLAUDE.md")
+	if err := os.Remove(instr); err != nil {
+		t.Fatal(err)
+	}
+
+	agentsDoctorFix = false
+	var err error
+	out := captureStdout(t, func() { err = runAgentsDoctor(nil, nil) })
+	if err == nil || !strings.Contains(out, "missing") || !strings.Contains(out, "mine agents doctor --fix") {
+		t.Errorf("err = %v, output:\n%s", err, out)
+	}
+
+	agentsDoctorFix = true
+	t.Cleanup(func() { agentsDoctorFix = false })
+	out = captureStdout(t, func() { err = runAgentsDoctor(nil, nil) })
+	if err != nil || !strings.Contains(out, "Fixed 1 problem") {
+		t.Errorf("err = %v, output:\n%s", err, out)
+	}
+	if _, err := os.Lstat(instr); err != nil {
+		t.Errorf("CLAUDE.md not relinked: %v", err)
+	}
+}
+
+func TestRunAgentsDoctor_Healthy(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	t.Setenv("PATH", t.TempDir())
+
+	out := captureStdout(t, func() {
+		if err := runAgentsDoctor(nil, nil); err != nil {
+			t.Errorf("runAgentsDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "healthy") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
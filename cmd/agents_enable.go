@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	agentsCmd.AddCommand(agentsDisableCmd)
+	agentsCmd.AddCommand(agentsEnableCmd)
+}
+
+var agentsDisableCmd = &cobra.Command{
+	Use:   "disable <agent> [asset]",
+	Short: "Temporarily detach an agent's links without forgetting them",
+	Long: `Remove an agent's links and keep mine agents link from recreating them, while
+the manifest remembers each link and its mode. Bring them back with
+mine agents enable.
+
+Asset limits it to one kind of link: instructions, skills, commands, settings,
+or mcp (default: all of them). Copies are only removed while they match the
+store — reconcile edited copies with mine agents diff first. Project links are
+left alone.
+
+Agents that keep MCP servers in their settings file share one link for both,
+so disabling either detaches it. Codex MCP servers are merged into its own
+config rather than linked; disabling mcp stops further merges but leaves the
+servers already there.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: hook.Wrap("agents.disable", runAgentsDisable),
+}
+
+var agentsEnableCmd = &cobra.Command{
+	Use:   "enable <agent> [asset]",
+	Short: "Re-link what mine agents disable detached",
+	Long: `Clear what mine agents disable set for an agent and link it again. Links come
+back in the mode (symlink or copy) they had when disabled.
+
+Asset re-enables one kind of link (default: everything disabled); the rest stay
+disabled.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: hook.Wrap("agents.enable", runAgentsEnable),
+}
+
+// agentAssetArgs splits disable/enable arguments into agent and asset.
+func agentAssetArgs(args []string) (agent, asset string) {
+	if len(args) > 1 {
+		asset = args[1]
+	}
+	return args[0], asset
+}
+
+func runAgentsDisable(_ *cobra.Command, args []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	agent, asset := agentAssetArgs(args)
+	actions, err := agents.Disable(agent, asset)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	detached := 0
+	for _, a := range actions {
+		if a.Err != nil {
+			fmt.Printf("  %-10s %s %s\n", a.Agent, ui.Warning.Render(ui.IconWarn), ui.Warning.Render(a.Err.Error()))
+			continue
+		}
+		fmt.Printf("  %-10s %s %s\n", a.Agent, ui.Muted.Render(a.Target), ui.Success.Render(ui.IconOk+"detached"))
+		detached++
+	}
+	if len(actions) > 0 {
+		fmt.Println()
+	}
+
+	what := agent
+	if asset != "" && asset != "all" {
+		what = agent + " " + asset
+	}
+	ui.Ok(fmt.Sprintf("Disabled %s — %d link(s) detached", ui.Accent.Render(what), detached))
+	fmt.Printf("  Re-enable with %s\n", ui.Accent.Render(strings.TrimSpace("mine agents enable "+agent+" "+asset)))
+	fmt.Println()
+	return nil
+}
+
+func runAgentsEnable(_ *cobra.Command, args []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	agent, asset := agentAssetArgs(args)
+	actions, err := agents.Enable(agent, asset)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	linked := 0
+	for _, a := range actions {
+		printLinkAction(a)
+		if a.Err == nil {
+			linked++
+		}
+	}
+	if len(actions) > 0 {
+		fmt.Println()
+	}
+
+	what := agent
+	if asset != "" && asset != "all" {
+		what = agent + " " + asset
+	}
+	ui.Ok(fmt.Sprintf("Enabled %s — %d link(s) configured", ui.Accent.Render(what), linked))
+	fmt.Println()
+	return nil
+}
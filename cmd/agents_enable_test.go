@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAgentsDisableEnable(t *testing.T) {
+	_, claudeDir := setupAgentsLinkEnv(t)
+	captureStdout(t, func() {
+		if err := runAgentsLink(nil, nil); err != nil {
+			t.Fatalf("runAgentsLink: %v", err)
+		}
+	})
+	target := filepath.Join(claudeDir, "CLAUDE.md")
+
+	out := captureStdout(t, func() {
+		if err := runAgentsDisable(nil, []string{"claude"}); err != nil {
+			t.Errorf("runAgentsDisable: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Disabled") || !strings.Contains(out, "mine agents enable claude") {
+		t.Errorf("expected disable summary, got:\n%s", out)
+	}
+	if _, err := os.Lstat(target); !os.IsNotExist(err) {
+		t.Errorf("%s still exists after disable", target)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runAgentsEnable(nil, []string{"claude"}); err != nil {
+			t.Errorf("runAgentsEnable: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Enabled") {
+		t.Errorf("expected enable summary, got:\n%s", out)
+	}
+	if _, err := os.Lstat(target); err != nil {
+		t.Errorf("%s not restored: %v", target, err)
+	}
+}
+
+func TestRunAgentsDisable_UnknownAsset(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	err := runAgentsDisable(nil, []string{"claude", "widgets"})
+	if err == nil || !strings.Contains(err.Error(), "unknown asset") {
+		t.Errorf("runAgentsDisable(widgets) = %v, want unknown asset error", err)
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentsExportBootstrap bool
+	agentsExportOutput    string
+)
+
+func init() {
+	agentsCmd.AddCommand(agentsExportCmd)
+	agentsExportCmd.Flags().BoolVar(&agentsExportBootstrap, "bootstrap", false, "Export a shell script that recreates the store and links")
+	agentsExportCmd.Flags().StringVarP(&agentsExportOutput, "output", "o", "", "Write to a file instead of stdout")
+}
+
+var agentsExportCmd = &cobra.Command{
+	Use:   "export --bootstrap",
+	Short: "Export the agents setup for machines without mine",
+	Long: `Export the store and its links for use where mine isn't installed.
+
+With --bootstrap, mine writes a self-contained POSIX shell script that recreates
+the store under ${XDG_DATA_HOME:-~/.local/share}/mine/agents and every agent
+link, in the same mode (symlink or copy). Links into your home directory are
+re-rooted at the new machine's $HOME. Handy for provisioning ephemeral dev
+containers:
+
+  mine agents export --bootstrap -o agents-bootstrap.sh
+  sh agents-bootstrap.sh
+
+  mine agents export --bootstrap | ssh devbox sh
+
+Anything already where a link goes is moved aside to <path>.bak. The script
+also writes the manifest, so installing mine later picks up where it left off.
+Version history isn't included, rendered files (composed instructions, filled-in
+placeholders) are exported as rendered on this machine, and project links are
+skipped — re-link those with mine agents link --project.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("agents.export", runAgentsExport),
+}
+
+func runAgentsExport(_ *cobra.Command, _ []string) error {
+	if !agentsExportBootstrap {
+		return fmt.Errorf("choose what to export: %s", ui.Accent.Render("--bootstrap"))
+	}
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	var w io.Writer = os.Stdout
+	if agentsExportOutput != "" {
+		f, err := os.OpenFile(agentsExportOutput, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+		if err != nil {
+			return fmt.Errorf("creating export file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	summary, err := agents.ExportBootstrap(w)
+	if err != nil {
+		return err
+	}
+
+	if agentsExportOutput != "" {
+		fmt.Printf("  %s Exported %d file(s) and %d link(s) to %s\n", ui.Success.Render("✓"),
+			summary.Files, summary.Links, ui.Accent.Render(agentsExportOutput))
+		if summary.Skipped > 0 {
+			fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("Skipped %d project link(s) — re-link them with mine agents link --project", summary.Skipped)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAgentsExport_RequiresBootstrap(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	agentsExportBootstrap = false
+	if err := runAgentsExport(nil, nil); err == nil || !strings.Contains(err.Error(), "--bootstrap") {
+		t.Errorf("runAgentsExport without --bootstrap = %v, want an error naming it", err)
+	}
+}
+
+func TestRunAgentsExport_WritesScript(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	out := filepath.Join(t.TempDir(), "bootstrap.sh")
+	agentsExportBootstrap, agentsExportOutput = true, out
+	defer func() { agentsExportBootstrap, agentsExportOutput = false, "" }()
+
+	stdout := captureStdout(t, func() {
+		if err := runAgentsExport(nil, nil); err != nil {
+			t.Fatalf("runAgentsExport: %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "Exported") {
+		t.Errorf("expected 'Exported' in output, got:\n%s", stdout)
+	}
+	info, err := os.Stat(out)
+	if err != nil {
+		t.Fatalf("script not written: %v", err)
+	}
+	if info.Mode()&0o100 == 0 {
+		t.Errorf("script mode = %v, want executable", info.Mode())
+	}
+	data, _ := os.ReadFile(out)
+	if !strings.HasPrefix(string(data), "#!/bin/sh\n") || !strings.Contains(string(data), "instructions/AGENTS.md") {
+		t.Errorf("script doesn't recreate the store:\n%s", data)
+	}
+}
@@ -78,6 +78,11 @@ func setupAgentsAdoptEnv(t *testing.T) (string, string) {
 	t.Helper()
 	agentsTestEnv(t)
 
+	// Never prompt for conflicts, even when tests run in a terminal.
+	origTTY := adoptIsTTY
+	adoptIsTTY = func() bool { return false }
+	t.Cleanup(func() { adoptIsTTY = origTTY })
+
 	captureStdout(t, func() {
 		if err := runAgentsInit(nil, nil); err != nil {
 			t.Fatalf("runAgentsInit: %v", err)
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/ui"
+)
+
+// runAgentsLinkProject links the current project's instructions from the
+// store into its root.
+func runAgentsLinkProject(opts agents.LinkOptions) error {
+	name, root, err := agentsCurrentProject()
+	if err != nil {
+		return err
+	}
+
+	actions, err := agents.LinkProject(name, root, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	linked := 0
+	for _, a := range actions {
+		printLinkAction(a)
+		if a.Err == nil {
+			linked++
+		}
+	}
+	fmt.Println()
+	if linked > 0 {
+		ui.Ok(fmt.Sprintf("%d link(s) configured for %s", linked, ui.Accent.Render(name)))
+	}
+	fmt.Println()
+	return nil
+}
+
+// agentsRegisteredProjects returns every registered project's root by name,
+// for adopt to scan.
+func agentsRegisteredProjects() (map[string]string, error) {
+	db, err := store.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	list, err := proj.NewStore(db.Conn()).List()
+	if err != nil {
+		return nil, err
+	}
+	projects := make(map[string]string, len(list))
+	for _, p := range list {
+		projects[p.Name] = p.Path
+	}
+	return projects, nil
+}
+
+// agentsCurrentProject returns the name and root of the registered project
+// containing the working directory, or the working directory itself when it
+// isn't part of one.
+func agentsCurrentProject() (name, root string, err error) {
+	db, err := store.Open()
+	if err != nil {
+		return "", "", err
+	}
+	defer db.Close()
+
+	p, err := proj.NewStore(db.Conn()).FindForCWD()
+	if err != nil {
+		return "", "", err
+	}
+	if p != nil {
+		return p.Name, p.Path, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", "", fmt.Errorf("getting current directory: %w", err)
+	}
+	return filepath.Base(cwd), cwd, nil
+}
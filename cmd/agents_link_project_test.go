@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+func resetAgentsLinkFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		agentsLinkAgent, agentsLinkCopy, agentsLinkForce, agentsLinkProj = "", false, false, false
+	})
+}
+
+func TestRunAgentsLink_ProjectUsesRegisteredProject(t *testing.T) {
+	storeDir, _ := setupAgentsLinkEnv(t)
+	resetAgentsLinkFlags(t)
+	projDir := registerProject(t, "webapp")
+	sub := filepath.Join(projDir, "src")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+
+	src := filepath.Join(storeDir, "projects", "webapp", "AGENTS.md")
+	if err := os.MkdirAll(filepath.Dir(src), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte("# Webapp\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	agentsLinkProj = true
+	out := captureStdout(t, func() {
+		if err := runAgentsLink(nil, nil); err != nil {
+			t.Fatalf("runAgentsLink: %v", err)
+		}
+	})
+	if !strings.Contains(out, "3 link(s) configured for") {
+		t.Errorf("expected summary in output, got:\n%s", out)
+	}
+
+	// Links land in the project root, not the subdirectory.
+	if dest, err := os.Readlink(filepath.Join(projDir, "CLAUDE.md")); err != nil || dest != src {
+		t.Errorf("CLAUDE.md → %q, %v; want %q", dest, err, src)
+	}
+	m, err := agents.ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range m.Links {
+		if strings.HasPrefix(l.Target, projDir) && l.Project != "webapp" {
+			t.Errorf("link %+v not associated with webapp", l)
+		}
+	}
+}
+
+func TestRunAgentsLink_ProjectMissingInstructions(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsLinkFlags(t)
+	t.Chdir(t.TempDir())
+
+	agentsLinkProj = true
+	err := runAgentsLink(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "AGENTS.md") {
+		t.Fatalf("expected missing-instructions error, got %v", err)
+	}
+}
@@ -397,3 +397,31 @@ func TestRunAgentsLinkUnlink_FullCycle(t *testing.T) {
 		t.Errorf("manifest links = %d after full cycle, want 0", len(m.Links))
 	}
 }
+
+func TestRunAgentsLink_Devcontainer(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	dest := t.TempDir()
+
+	agentsLinkDevc = true
+	defer func() { agentsLinkDevc, agentsLinkDest = false, "" }()
+	if err := runAgentsLink(nil, nil); err == nil || !strings.Contains(err.Error(), "--dest") {
+		t.Errorf("--devcontainer without --dest = %v, want error", err)
+	}
+
+	agentsLinkDest = dest
+	out := captureStdout(t, func() {
+		if err := runAgentsLink(nil, nil); err != nil {
+			t.Errorf("runAgentsLink --devcontainer: %v", err)
+		}
+	})
+	if !strings.Contains(out, "mine agents watch") {
+		t.Errorf("expected watch hint, got:\n%s", out)
+	}
+	info, err := os.Lstat(filepath.Join(dest, ".claude", "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("Lstat: %v", err)
+	}
+	if !info.Mode().IsRegular() {
+		t.Error("CLAUDE.md in dest is not a copy")
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var agentsLintBudget int
+
+func init() {
+	agentsCmd.AddCommand(agentsLintCmd)
+	agentsLintCmd.Flags().IntVar(&agentsLintBudget, "budget", 0, "Token budget per agent (default: agents.token_budget, then 5000)")
+}
+
+var agentsLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check instruction files against a context budget",
+	Long: `Estimate how many tokens each detected agent's instructions cost — the shared
+AGENTS.md plus the agent's fragment, placeholders filled in — along with each
+project's instructions, and report:
+
+  over-budget   instructions larger than the budget, with the biggest section
+                to move into a skill (loaded only when relevant)
+  duplicate     a section repeated across the shared file, fragments, or
+                project instructions
+
+Estimates use about four characters per token, so treat them as a guide. Set
+the budget with --budget or mine config set agents.token_budget <tokens>.
+Exits non-zero when anything is found, so it can run in CI.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("agents.lint", runAgentsLint),
+}
+
+func runAgentsLint(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	budget := agentsLintBudget
+	if budget == 0 {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		budget = cfg.Agents.TokenBudget
+	}
+	if budget == 0 {
+		budget = config.DefaultAgentsTokenBudget
+	}
+
+	result, err := agents.Lint(budget)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(result.Sizes) == 0 {
+		fmt.Println(ui.Muted.Render("  No instructions to lint yet."))
+		fmt.Printf("  Add some to %s\n", ui.Accent.Render("instructions/AGENTS.md"))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %s\n", ui.KeyStyle.Render(fmt.Sprintf("Instruction sizes (budget ~%d tokens):", result.Budget)))
+	for _, s := range result.Sizes {
+		name := s.Agent
+		switch {
+		case s.Project != "":
+			name = "project " + s.Project
+		case name == "":
+			name = "shared"
+		}
+		tokens := fmt.Sprintf("~%d tokens", s.Tokens)
+		if s.Tokens > result.Budget {
+			tokens = ui.Warning.Render(tokens)
+		} else {
+			tokens = ui.Success.Render(tokens)
+		}
+		fmt.Printf("    %-20s %s  %s\n", name, tokens, ui.Muted.Render(strings.Join(s.Sources, " + ")))
+	}
+	fmt.Println()
+
+	if len(result.Findings) == 0 {
+		ui.Ok("Instructions are within budget, with no repeated sections.")
+		fmt.Println()
+		return nil
+	}
+	for _, f := range result.Findings {
+		printAgentsFinding(f)
+	}
+	fmt.Println()
+	return fmt.Errorf("%d instruction lint finding(s)", len(result.Findings))
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+func TestRunAgentsLint(t *testing.T) {
+	setupAgentsLinkEnv(t)
+
+	out := captureStdout(t, func() {
+		if err := runAgentsLint(nil, nil); err != nil {
+			t.Errorf("runAgentsLint: %v", err)
+		}
+	})
+	if !strings.Contains(out, "claude") || !strings.Contains(out, "within budget") {
+		t.Errorf("expected claude within budget, got:\n%s", out)
+	}
+
+	agentsLintBudget = 2
+	defer func() { agentsLintBudget = 0 }()
+	var err error
+	out = captureStdout(t, func() { err = runAgentsLint(nil, nil) })
+	if err == nil {
+		t.Error("runAgentsLint over budget = nil error, want findings")
+	}
+	if !strings.Contains(out, "over-budget") {
+		t.Errorf("expected over-budget finding, got:\n%s", out)
+	}
+}
+
+func TestRunAgentsLint_NoInstructions(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	if err := os.Remove(filepath.Join(agents.Dir(), "instructions", "AGENTS.md")); err != nil {
+		t.Fatal(err)
+	}
+	out := captureStdout(t, func() {
+		if err := runAgentsLint(nil, nil); err != nil {
+			t.Errorf("runAgentsLint: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No instructions to lint") {
+		t.Errorf("expected empty-store message, got:\n%s", out)
+	}
+}
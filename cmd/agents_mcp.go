@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentsMCPAddURL    string
+	agentsMCPAddType   string
+	agentsMCPAddEnv    []string
+	agentsMCPAddHeader []string
+	agentsMCPAddForce  bool
+
+	agentsMCPLinkAgent string
+	agentsMCPLinkCopy  bool
+	agentsMCPLinkForce bool
+)
+
+var agentsMCPCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Manage MCP servers once for every agent",
+	Long: `Keep one canonical MCP server list in the agents store (mcp/.mcp.json) and
+render it into each agent's own MCP config at link time.
+
+  mine agents mcp add <name> -- <command> [args...]   Add a stdio server
+  mine agents mcp add <name> --url <url>              Add a remote server
+  mine agents mcp list                                List servers
+  mine agents mcp rm <name>                           Remove a server
+  mine agents mcp link                                Push servers to every agent`,
+	RunE: hook.Wrap("agents.mcp", runAgentsMCPList),
+}
+
+var agentsMCPAddCmd = &cobra.Command{
+	Use:   "add <name> [-- <command> [args...]]",
+	Short: "Add an MCP server to the canonical list",
+	Long: `Add an MCP server to the canonical list in the agents store.
+
+Give a stdio server's command after --, or a remote server's address with --url:
+
+  mine agents mcp add github --env GITHUB_TOKEN=ghp_xxx -- npx -y @modelcontextprotocol/server-github
+  mine agents mcp add docs --url https://example.com/mcp --header "Authorization=Bearer xxx"
+
+Run mine agents mcp link afterwards to update agents that don't read the list
+directly.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: hook.Wrap("agents.mcp.add", runAgentsMCPAdd),
+}
+
+var agentsMCPListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List MCP servers in the canonical list",
+	Args:    cobra.NoArgs,
+	RunE:    hook.Wrap("agents.mcp.list", runAgentsMCPList),
+}
+
+var agentsMCPRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove"},
+	Short:   "Remove an MCP server from the canonical list",
+	Args:    cobra.ExactArgs(1),
+	RunE:    hook.Wrap("agents.mcp.rm", runAgentsMCPRm),
+}
+
+var agentsMCPLinkCmd = &cobra.Command{
+	Use:   "link",
+	Short: "Render the MCP servers into each detected agent's config",
+	Long: `Deliver the canonical MCP servers to each detected agent in its own format:
+
+  claude, cursor, windsurf, copilot   mcp/.mcp.json linked as the agent's MCP config
+  gemini                              merged into settings.json as mcpServers
+  codex                               [mcp_servers.*] tables in ~/.codex/config.toml
+
+Codex's config.toml holds its other settings too, so mine rewrites only a
+marked block at the end of it. mine agents link does all of this as well.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("agents.mcp.link", runAgentsMCPLink),
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsMCPCmd)
+	agentsMCPCmd.AddCommand(agentsMCPAddCmd)
+	agentsMCPCmd.AddCommand(agentsMCPListCmd)
+	agentsMCPCmd.AddCommand(agentsMCPRmCmd)
+	agentsMCPCmd.AddCommand(agentsMCPLinkCmd)
+
+	agentsMCPAddCmd.Flags().StringVar(&agentsMCPAddURL, "url", "", "URL of a remote (HTTP or SSE) server")
+	agentsMCPAddCmd.Flags().StringVar(&agentsMCPAddType, "type", "", "Transport: stdio, http, or sse (default: stdio, or http with --url)")
+	agentsMCPAddCmd.Flags().StringArrayVar(&agentsMCPAddEnv, "env", nil, "Environment variable KEY=VALUE (repeatable)")
+	agentsMCPAddCmd.Flags().StringArrayVar(&agentsMCPAddHeader, "header", nil, "HTTP header KEY=VALUE for remote servers (repeatable)")
+	agentsMCPAddCmd.Flags().BoolVar(&agentsMCPAddForce, "force", false, "Replace an existing server with the same name")
+
+	agentsMCPLinkCmd.Flags().StringVar(&agentsMCPLinkAgent, "agent", "", "Link only a specific agent (e.g. claude, codex)")
+	agentsMCPLinkCmd.Flags().BoolVar(&agentsMCPLinkCopy, "copy", false, "Copy files instead of creating symlinks")
+	agentsMCPLinkCmd.Flags().BoolVar(&agentsMCPLinkForce, "force", false, "Overwrite existing files without requiring adopt first")
+}
+
+func runAgentsMCPAdd(cmd *cobra.Command, args []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	server := agents.MCPServer{Name: args[0], URL: agentsMCPAddURL, Type: agentsMCPAddType}
+	if rest := args[1:]; len(rest) > 0 {
+		if cmd != nil && cmd.ArgsLenAtDash() != 1 {
+			return fmt.Errorf("put the server command after --: %s",
+				ui.Accent.Render("mine agents mcp add "+args[0]+" -- "+strings.Join(rest, " ")))
+		}
+		server.Command, server.Args = rest[0], rest[1:]
+	}
+	if server.Type == "" && server.URL != "" {
+		server.Type = "http"
+	}
+
+	var err error
+	if server.Env, err = parseKeyValues("--env", agentsMCPAddEnv); err != nil {
+		return err
+	}
+	if server.Headers, err = parseKeyValues("--header", agentsMCPAddHeader); err != nil {
+		return err
+	}
+
+	if err := agents.AddMCPServer(server, agentsMCPAddForce); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("MCP server %s added", ui.Accent.Render(server.Name)))
+	fmt.Printf("  Update your agents: %s\n", ui.Accent.Render("mine agents mcp link"))
+	fmt.Println()
+	return nil
+}
+
+func runAgentsMCPList(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No agents store yet."))
+		fmt.Printf("  Run %s first.\n", ui.Accent.Render("mine agents init"))
+		fmt.Println()
+		return nil
+	}
+
+	servers, err := agents.ListMCPServers()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(servers) == 0 {
+		fmt.Println(ui.Muted.Render("  No MCP servers yet."))
+		fmt.Printf("  Add one: %s\n", ui.Accent.Render("mine agents mcp add <name> -- <command> [args...]"))
+		fmt.Println()
+		return nil
+	}
+	for _, s := range servers {
+		fmt.Printf("  %-20s %s\n", ui.Accent.Render(s.Name), ui.Muted.Render(mcpServerSummary(s)))
+	}
+	fmt.Println()
+	fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d server(s)", len(servers))))
+	fmt.Println()
+	return nil
+}
+
+func runAgentsMCPRm(_ *cobra.Command, args []string) error {
+	if err := agents.RemoveMCPServer(args[0]); err != nil {
+		if errors.Is(err, agents.ErrMCPServerNotFound) {
+			return fmt.Errorf("no MCP server named %q — see %s", args[0], ui.Accent.Render("mine agents mcp list"))
+		}
+		return err
+	}
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("MCP server %s removed", ui.Accent.Render(args[0])))
+	fmt.Printf("  Update your agents: %s\n", ui.Accent.Render("mine agents mcp link"))
+	fmt.Println()
+	return nil
+}
+
+func runAgentsMCPLink(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No agents store yet."))
+		fmt.Printf("  Run %s first.\n", ui.Accent.Render("mine agents init"))
+		fmt.Println()
+		return nil
+	}
+
+	actions, err := agents.LinkMCP(agents.LinkOptions{
+		Agent: agentsMCPLinkAgent,
+		Copy:  agentsMCPLinkCopy,
+		Force: agentsMCPLinkForce,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(actions) == 0 {
+		fmt.Println(ui.Muted.Render("  Nothing to link — add a server with " + ui.Accent.Render("mine agents mcp add") +
+			ui.Muted.Render(" and make sure agents are detected.")))
+		fmt.Println()
+		return nil
+	}
+
+	linked := 0
+	for _, a := range actions {
+		printLinkAction(a)
+		if a.Err == nil {
+			linked++
+		}
+	}
+	fmt.Println()
+	if linked > 0 {
+		ui.Ok(fmt.Sprintf("MCP servers delivered to %d agent config(s)", linked))
+	}
+	fmt.Println()
+	return nil
+}
+
+// mcpServerSummary describes how a server is reached, e.g. "npx -y pkg" or
+// "http https://example.com/mcp".
+func mcpServerSummary(s agents.MCPServer) string {
+	if s.URL != "" {
+		transport := s.Type
+		if transport == "" {
+			transport = "http"
+		}
+		return transport + " " + s.URL
+	}
+	return strings.Join(append([]string{s.Command}, s.Args...), " ")
+}
+
+// parseKeyValues parses repeated KEY=VALUE flag values into a map.
+func parseKeyValues(flag string, values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]string, len(values))
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid %s %q — use KEY=VALUE", flag, v)
+		}
+		m[key] = value
+	}
+	return m, nil
+}
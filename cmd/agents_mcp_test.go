@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+func resetAgentsMCPFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		agentsMCPAddURL, agentsMCPAddType, agentsMCPAddForce = "", "", false
+		agentsMCPAddEnv, agentsMCPAddHeader = nil, nil
+	})
+}
+
+func TestRunAgentsMCPAdd_StdioAndList(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsMCPFlags(t)
+
+	agentsMCPAddEnv = []string{"GITHUB_TOKEN=abc=def"}
+	captureStdout(t, func() {
+		if err := runAgentsMCPAdd(nil, []string{"github", "npx", "-y", "server-github"}); err != nil {
+			t.Fatalf("runAgentsMCPAdd: %v", err)
+		}
+	})
+
+	servers, err := agents.ListMCPServers()
+	if err != nil || len(servers) != 1 {
+		t.Fatalf("ListMCPServers() = %+v, %v", servers, err)
+	}
+	if s := servers[0]; s.Command != "npx" || len(s.Args) != 2 || s.Env["GITHUB_TOKEN"] != "abc=def" {
+		t.Errorf("server = %+v", s)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runAgentsMCPList(nil, nil); err != nil {
+			t.Fatalf("runAgentsMCPList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "github") || !strings.Contains(out, "npx -y server-github") {
+		t.Errorf("unexpected list output:\n%s", out)
+	}
+}
+
+func TestRunAgentsMCPAdd_URLDefaultsToHTTP(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsMCPFlags(t)
+
+	agentsMCPAddURL = "https://example.com/mcp"
+	captureStdout(t, func() {
+		if err := runAgentsMCPAdd(nil, []string{"docs"}); err != nil {
+			t.Fatalf("runAgentsMCPAdd: %v", err)
+		}
+	})
+	servers, _ := agents.ListMCPServers()
+	if len(servers) != 1 || servers[0].Type != "http" {
+		t.Errorf("servers = %+v, want one http server", servers)
+	}
+}
+
+func TestRunAgentsMCPAdd_BadEnv(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsMCPFlags(t)
+
+	agentsMCPAddEnv = []string{"NOVALUE"}
+	if err := runAgentsMCPAdd(nil, []string{"github", "npx"}); err == nil || !strings.Contains(err.Error(), "KEY=VALUE") {
+		t.Fatalf("expected KEY=VALUE error, got %v", err)
+	}
+}
+
+func TestRunAgentsMCPRm_Missing(t *testing.T) {
+	setupAgentsLinkEnv(t)
+
+	err := runAgentsMCPRm(nil, []string{"nope"})
+	if err == nil || !strings.Contains(err.Error(), "mine agents mcp list") {
+		t.Fatalf("expected not-found error with list hint, got %v", err)
+	}
+}
+
+func TestRunAgentsMCPLink_ClaudeSymlink(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsMCPFlags(t)
+	captureStdout(t, func() {
+		if err := runAgentsMCPAdd(nil, []string{"github", "npx"}); err != nil {
+			t.Fatalf("runAgentsMCPAdd: %v", err)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := runAgentsMCPLink(nil, nil); err != nil {
+			t.Fatalf("runAgentsMCPLink: %v", err)
+		}
+	})
+	if !strings.Contains(out, "mcp/.mcp.json") || !strings.Contains(out, "delivered to 1 agent config(s)") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentsReportAgent string
+	agentsReportJSON  bool
+)
+
+func init() {
+	agentsCmd.AddCommand(agentsReportCmd)
+	agentsReportCmd.Flags().StringVar(&agentsReportAgent, "agent", "", "Report only a specific agent (e.g. claude, codex)")
+	agentsReportCmd.Flags().BoolVar(&agentsReportJSON, "json", false, "Output as JSON")
+}
+
+var agentsReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Audit what is linked where, and what isn't linked at all",
+	Long: `Summarize, per agent, every managed link: its store source, mode (symlink or
+copy), health, when its sources last changed, and when mine last linked it.
+Copies and rendered files whose sources changed after linking are flagged
+stale — symlinks always show the store as it is.
+
+The report ends with store content no link delivers, such as a fragment for an
+agent that isn't detected, or agent definitions and rules (kept in the store but
+not linked by mine).
+
+Use --json for scripts and dashboards.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("agents.report", runAgentsReport),
+}
+
+func runAgentsReport(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	report, err := agents.BuildReport(agentsReportAgent)
+	if err != nil {
+		return err
+	}
+	if agentsReportJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	now := time.Now()
+	fmt.Println()
+	fmt.Printf("  %s\n", ui.Title.Render(ui.IconTools+" Agents Report"))
+	stale := 0
+	for _, a := range report.Agents {
+		fmt.Println()
+		detected := ui.Muted.Render("(not detected)")
+		if a.Detected {
+			detected = ui.Muted.Render("(detected)")
+		}
+		fmt.Printf("  %s %s\n", ui.KeyStyle.Render(a.Name), detected)
+		if len(a.Links) == 0 {
+			fmt.Println(ui.Muted.Render("    nothing linked"))
+			continue
+		}
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("    %-28s %-8s %-9s %-16s %s", "SOURCE", "MODE", "STATE", "LINKED", "SOURCE CHANGED")))
+		for _, l := range a.Links {
+			printAgentsReportLink(l, now)
+			if l.Stale {
+				stale++
+			}
+		}
+	}
+
+	fmt.Println()
+	if len(report.Unlinked) > 0 {
+		fmt.Printf("  %s\n", ui.KeyStyle.Render(fmt.Sprintf("Not linked anywhere (%d):", len(report.Unlinked))))
+		for _, item := range report.Unlinked {
+			fmt.Printf("    %s\n", item)
+		}
+		fmt.Println()
+	}
+	if stale > 0 {
+		ui.Warn(fmt.Sprintf("%d stale link(s)", stale))
+		fmt.Printf("  Refresh them with %s\n", ui.Accent.Render("mine agents link"))
+		fmt.Println()
+	}
+	return nil
+}
+
+// printAgentsReportLink prints one link row and its target beneath it.
+func printAgentsReportLink(l agents.ReportLink, now time.Time) {
+	linked, changed := "unknown", "—"
+	if !l.LinkedAt.IsZero() {
+		linked = todoTimeAgo(l.LinkedAt, now)
+	}
+	if !l.Modified.IsZero() {
+		changed = todoTimeAgo(l.Modified, now)
+	}
+	state := fmt.Sprintf("%-9s", l.State)
+	switch {
+	case l.Stale:
+		state = ui.Warning.Render(fmt.Sprintf("%-9s", "stale"))
+	case l.State == agents.LinkHealthLinked:
+		state = ui.Success.Render(state)
+	default:
+		state = ui.Warning.Render(state)
+	}
+	fmt.Printf("    %-28s %-8s %s %-16s %s\n", l.Source, l.Mode, state, linked, changed)
+
+	target := l.Target
+	if l.Project != "" {
+		target += " (project " + l.Project + ")"
+	}
+	fmt.Printf("      %s %s\n", ui.Muted.Render(ui.IconArrow), ui.Muted.Render(target))
+}
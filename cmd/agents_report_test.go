@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+func TestRunAgentsReport(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	captureStdout(t, func() {
+		if err := runAgentsLink(nil, nil); err != nil {
+			t.Fatalf("runAgentsLink: %v", err)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := runAgentsReport(nil, nil); err != nil {
+			t.Fatalf("runAgentsReport: %v", err)
+		}
+	})
+	for _, want := range []string{"Agents Report", "claude", "instructions/AGENTS.md", "symlink"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in report, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunAgentsReport_JSON(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	captureStdout(t, func() {
+		if err := runAgentsLink(nil, nil); err != nil {
+			t.Fatalf("runAgentsLink: %v", err)
+		}
+	})
+
+	agentsReportJSON = true
+	defer func() { agentsReportJSON = false }()
+	out := captureStdout(t, func() {
+		if err := runAgentsReport(nil, nil); err != nil {
+			t.Fatalf("runAgentsReport: %v", err)
+		}
+	})
+	var report agents.Report
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("report isn't JSON: %v\n%s", err, out)
+	}
+	if len(report.Agents) == 0 || len(report.Agents[0].Links) == 0 {
+		t.Errorf("report = %+v, want claude's links", report)
+	}
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentsSkillAddAgents []string
+	agentsSkillAddPath   string
+	agentsSkillAddName   string
+	agentsSkillAddForce  bool
+
+	agentsSkillRmYes bool
+)
+
+var agentsSkillCmd = &cobra.Command{
+	Use:     "skill",
+	Aliases: []string{"skills"},
+	Short:   "Manage the skills library in your agents store",
+	Long: `Manage Agent Skills in the store's skills/ directory. Each skill is a
+skills/<name>/ directory whose SKILL.md front matter holds its name,
+description, and optionally the agents it is for:
+
+  ---
+  name: code-review
+  description: Review a diff for bugs and style problems.
+  agents: [claude, codex]
+  ---
+
+Skills without agents are linked to every agent that supports skills.
+
+  mine agents skill add <name>           Scaffold a new skill
+  mine agents skill add <path|git-url>   Install a skill from elsewhere
+  mine agents skill list                 List skills
+  mine agents skill show <name>          Show a skill's metadata and content
+  mine agents skill rm <name>            Remove a skill`,
+	RunE: hook.Wrap("agents.skill", runAgentsSkillList),
+}
+
+var agentsSkillAddCmd = &cobra.Command{
+	Use:   "add <name|path|git-url>",
+	Short: "Scaffold a skill, or install one from a path or git URL",
+	Long: `Scaffold a new skill, or install an existing one into the store.
+
+A bare name scaffolds skills/<name>/ like mine agents add skill. Anything that
+looks like a path (contains a /, or ends in .md) or a git URL is installed:
+
+  mine agents skill add ./my-skill                    Copy a local skill directory
+  mine agents skill add ~/notes/review.md             Use a markdown file as SKILL.md
+  mine agents skill add https://github.com/acme/skills.git --path pdf
+
+Use --path to pick a skill out of a repository that holds several. The store
+name comes from --name, then the skill's front-matter name, then the directory.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("agents.skill.add", runAgentsSkillAdd),
+}
+
+var agentsSkillListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List skills in the store",
+	Args:    cobra.NoArgs,
+	RunE:    hook.Wrap("agents.skill.list", runAgentsSkillList),
+}
+
+var agentsSkillShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a skill's metadata, files, and SKILL.md",
+	Args:  cobra.ExactArgs(1),
+	RunE:  hook.Wrap("agents.skill.show", runAgentsSkillShow),
+}
+
+var agentsSkillRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove"},
+	Short:   "Remove a skill from the store",
+	Long: `Delete skills/<name>/ from the store. Run mine agents link afterwards to
+update agents that received copies, and mine agents commit to record it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("agents.skill.rm", runAgentsSkillRm),
+}
+
+func init() {
+	agentsCmd.AddCommand(agentsSkillCmd)
+	agentsSkillCmd.AddCommand(agentsSkillAddCmd)
+	agentsSkillCmd.AddCommand(agentsSkillListCmd)
+	agentsSkillCmd.AddCommand(agentsSkillShowCmd)
+	agentsSkillCmd.AddCommand(agentsSkillRmCmd)
+
+	agentsSkillAddCmd.Flags().StringSliceVar(&agentsSkillAddAgents, "agents", nil, "Agents the new skill is for (default: all)")
+	agentsSkillAddCmd.Flags().StringVar(&agentsSkillAddPath, "path", "", "Directory within the source that holds the skill")
+	agentsSkillAddCmd.Flags().StringVar(&agentsSkillAddName, "name", "", "Name to install the skill under")
+	agentsSkillAddCmd.Flags().BoolVar(&agentsSkillAddForce, "force", false, "Replace an existing skill with the same name")
+
+	agentsSkillRmCmd.Flags().BoolVarP(&agentsSkillRmYes, "yes", "y", false, "Skip confirmation prompt")
+}
+
+// isSkillSource reports whether arg names a skill to install rather than
+// one to scaffold.
+func isSkillSource(arg string) bool {
+	return strings.ContainsRune(arg, '/') || strings.HasSuffix(arg, ".md") || agents.IsGitSource(arg)
+}
+
+func runAgentsSkillAdd(_ *cobra.Command, args []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	if !isSkillSource(args[0]) {
+		if agentsSkillAddPath != "" || agentsSkillAddName != "" {
+			return fmt.Errorf("--path and --name apply when installing from a path or git URL")
+		}
+		result, err := agents.AddSkill(args[0], agentsSkillAddAgents...)
+		if err != nil {
+			return fmt.Errorf("adding skill: %w", err)
+		}
+		rel, err := filepath.Rel(agents.Dir(), result.Dir)
+		if err != nil {
+			rel = result.Dir
+		}
+		fmt.Println()
+		ui.Ok(fmt.Sprintf("Skill %s created", ui.Accent.Render(args[0])))
+		fmt.Printf("  Location: %s\n", ui.Muted.Render(rel))
+		fmt.Println()
+		fmt.Printf("  Next: edit %s to describe the skill\n", ui.Accent.Render(rel+"/SKILL.md"))
+		fmt.Println()
+		return nil
+	}
+
+	if len(agentsSkillAddAgents) > 0 {
+		return fmt.Errorf("--agents applies when scaffolding — set agents: in the installed skill's SKILL.md instead")
+	}
+	skill, err := agents.InstallSkill(args[0], agents.InstallOptions{
+		Path:    agentsSkillAddPath,
+		Name:    agentsSkillAddName,
+		Replace: agentsSkillAddForce,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Skill %s installed", ui.Accent.Render(skill.Name)))
+	fmt.Printf("  Location: %s\n", ui.Muted.Render("skills/"+skill.Name))
+	if skill.Description != "" {
+		fmt.Printf("  %s\n", ui.Muted.Render(skill.Description))
+	}
+	fmt.Println()
+	fmt.Printf("  Link it to your agents: %s\n", ui.Accent.Render("mine agents link"))
+	fmt.Println()
+	return nil
+}
+
+func runAgentsSkillList(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No agents store yet."))
+		fmt.Printf("  Run %s first.\n", ui.Accent.Render("mine agents init"))
+		fmt.Println()
+		return nil
+	}
+
+	skills, err := agents.ListSkills()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(skills) == 0 {
+		fmt.Println(ui.Muted.Render("  No skills yet."))
+		fmt.Printf("  Add one with %s\n", ui.Accent.Render("mine agents skill add <name|path|git-url>"))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("  %s\n", ui.KeyStyle.Render(fmt.Sprintf("Skills (%d):", len(skills))))
+	width := 16
+	for _, s := range skills {
+		if len(s.Name) > width {
+			width = len(s.Name)
+		}
+	}
+	for _, s := range skills {
+		line := fmt.Sprintf("    %-*s", width, s.Name)
+		if len(s.Agents) > 0 {
+			line += "  " + ui.Accent.Render("["+strings.Join(s.Agents, ", ")+"]")
+		}
+		if s.Description != "" {
+			line += "  " + ui.Muted.Render(s.Description)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runAgentsSkillShow(_ *cobra.Command, args []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	skill, err := getAgentsSkill(args[0])
+	if err != nil {
+		return err
+	}
+
+	forAgents := "all agents"
+	if len(skill.Agents) > 0 {
+		forAgents = strings.Join(skill.Agents, ", ")
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s\n", ui.Title.Render(skill.Name))
+	if skill.Description != "" {
+		fmt.Printf("  %s\n", skill.Description)
+	}
+	fmt.Println()
+	fmt.Printf("  %s %s\n", ui.KeyStyle.Render("Agents:  "), forAgents)
+	fmt.Printf("  %s %s\n", ui.KeyStyle.Render("Location:"), ui.Muted.Render("skills/"+skill.Name))
+
+	var files []string
+	_ = filepath.WalkDir(skill.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if rel, err := filepath.Rel(skill.Path, path); err == nil && rel != "SKILL.md" {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if len(files) > 0 {
+		fmt.Printf("  %s %s\n", ui.KeyStyle.Render("Files:   "), strings.Join(files, ", "))
+	}
+
+	content, err := os.ReadFile(filepath.Join(skill.Path, "SKILL.md"))
+	if err != nil {
+		fmt.Println()
+		fmt.Printf("  %s\n", ui.Muted.Render("No SKILL.md — agents won't load this skill."))
+		fmt.Println()
+		return nil
+	}
+	fmt.Println()
+	for _, line := range strings.Split(strings.TrimRight(string(content), "\n"), "\n") {
+		fmt.Println("  " + line)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runAgentsSkillRm(_ *cobra.Command, args []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	name := args[0]
+	if _, err := getAgentsSkill(name); err != nil {
+		return err
+	}
+	if !agentsSkillRmYes {
+		if !tui.IsTTY() {
+			return fmt.Errorf("non-interactive mode requires --yes to confirm removal")
+		}
+		if !confirmPrompt(fmt.Sprintf("Remove skill %q and its files?", name)) {
+			ui.Warn("Cancelled.")
+			return nil
+		}
+	}
+
+	if err := agents.RemoveSkill(name); err != nil {
+		return err
+	}
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Skill %s removed", ui.Accent.Render(name)))
+	fmt.Printf("  Update your agents: %s\n", ui.Accent.Render("mine agents link"))
+	fmt.Println()
+	return nil
+}
+
+// getAgentsSkill looks up a skill, pointing at skill list when it's missing.
+func getAgentsSkill(name string) (*agents.Skill, error) {
+	skill, err := agents.GetSkill(name)
+	if errors.Is(err, agents.ErrSkillNotFound) {
+		return nil, fmt.Errorf("no skill named %q — see %s", name, ui.Accent.Render("mine agents skill list"))
+	}
+	return skill, err
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+func resetAgentsSkillFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		agentsSkillAddAgents = nil
+		agentsSkillAddPath, agentsSkillAddName = "", ""
+		agentsSkillAddForce, agentsSkillRmYes = false, false
+	})
+}
+
+func TestRunAgentsSkillAdd_ScaffoldWithAgentsAndList(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsSkillFlags(t)
+
+	agentsSkillAddAgents = []string{"claude"}
+	captureStdout(t, func() {
+		if err := runAgentsSkillAdd(nil, []string{"review"}); err != nil {
+			t.Fatalf("runAgentsSkillAdd: %v", err)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := runAgentsSkillList(nil, nil); err != nil {
+			t.Fatalf("runAgentsSkillList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "review") || !strings.Contains(out, "[claude]") {
+		t.Errorf("unexpected list output:\n%s", out)
+	}
+}
+
+func TestRunAgentsSkillAdd_InstallFromPath(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsSkillFlags(t)
+
+	src := filepath.Join(t.TempDir(), "notes")
+	if err := os.MkdirAll(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\ndescription: Take notes.\n---\nBody\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	agentsSkillAddName = "note-taking"
+	out := captureStdout(t, func() {
+		if err := runAgentsSkillAdd(nil, []string{src}); err != nil {
+			t.Fatalf("runAgentsSkillAdd: %v", err)
+		}
+	})
+	if !strings.Contains(out, "note-taking") || !strings.Contains(out, "installed") {
+		t.Errorf("unexpected add output:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runAgentsSkillShow(nil, []string{"note-taking"}); err != nil {
+			t.Fatalf("runAgentsSkillShow: %v", err)
+		}
+	})
+	for _, want := range []string{"Take notes.", "all agents", "Body"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("show output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunAgentsSkillAdd_AgentsRejectedForInstall(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsSkillFlags(t)
+
+	agentsSkillAddAgents = []string{"claude"}
+	if err := runAgentsSkillAdd(nil, []string{"./somewhere"}); err == nil {
+		t.Error("runAgentsSkillAdd with --agents and a path = nil, want error")
+	}
+}
+
+func TestRunAgentsSkillRm(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	resetAgentsSkillFlags(t)
+
+	if _, err := agents.AddSkill("old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAgentsSkillRm(nil, []string{"old"}); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("runAgentsSkillRm without --yes = %v, want --yes error", err)
+	}
+
+	agentsSkillRmYes = true
+	captureStdout(t, func() {
+		if err := runAgentsSkillRm(nil, []string{"old"}); err != nil {
+			t.Fatalf("runAgentsSkillRm: %v", err)
+		}
+	})
+	if _, err := agents.GetSkill("old"); err == nil {
+		t.Error("skill still present after rm")
+	}
+	if err := runAgentsSkillRm(nil, []string{"old"}); err == nil || !strings.Contains(err.Error(), "no skill named") {
+		t.Errorf("runAgentsSkillRm(missing) = %v, want not found", err)
+	}
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/rnwolfe/mine/internal/agents"
 	"github.com/rnwolfe/mine/internal/ui"
@@ -61,6 +63,17 @@ func runAgentsStatus(_ *cobra.Command, _ []string) error {
 		fmt.Println()
 		ui.Kv("  Summary", fmt.Sprintf("%d registered, %d detected", len(result.Agents), detectedCount))
 	}
+	if len(result.Disabled) > 0 {
+		names := make([]string, 0, len(result.Disabled))
+		for name := range result.Disabled {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ui.Kv("  Disabled", fmt.Sprintf("%s (%s) — %s", name, strings.Join(result.Disabled[name], ", "),
+				ui.Muted.Render("mine agents enable "+name)))
+		}
+	}
 
 	fmt.Println()
 
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+func TestRunAgentsSyncInit_EmptyRemote(t *testing.T) {
+	agentsTestEnv(t)
+	bare := filepath.Join(t.TempDir(), "agents.git")
+	if out, err := exec.Command("git", "init", "--bare", bare).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %v\n%s", err, out)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runAgentsSyncInit(nil, []string{bare}); err != nil {
+			t.Fatalf("runAgentsSyncInit: %v", err)
+		}
+	})
+	if !strings.Contains(out, "started a fresh store") || !strings.Contains(out, "mine agents sync push") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if !agents.IsInitialized() || agents.SyncRemoteURL() != bare {
+		t.Errorf("store initialized = %v, remote = %q; want initialized with %q",
+			agents.IsInitialized(), agents.SyncRemoteURL(), bare)
+	}
+}
+
+func TestRunAgentsSyncInit_ExistingStore(t *testing.T) {
+	setupAgentsLinkEnv(t)
+
+	out := captureStdout(t, func() {
+		if err := runAgentsSyncInit(nil, []string{"https://example.com/agents.git"}); err != nil {
+			t.Fatalf("runAgentsSyncInit: %v", err)
+		}
+	})
+	if !strings.Contains(out, "will sync with https://example.com/agents.git") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rnwolfe/mine/internal/agents"
@@ -37,10 +38,38 @@ The file argument must be a path relative to the canonical store, e.g.:
 	RunE: hook.Wrap("agents.restore", runAgentsRestore),
 }
 
+var agentsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot [message]",
+	Short: "Save the whole agents store as a snapshot you can roll back to",
+	Long: `Save everything in the canonical store as a snapshot. Take one before editing
+instructions so you can return to it with mine agents rollback:
+
+  mine agents snapshot "before tightening review rules"`,
+	RunE: hook.Wrap("agents.snapshot", runAgentsSnapshot),
+}
+
+var agentsRollbackCmd = &cobra.Command{
+	Use:   "rollback <id>",
+	Short: "Return the whole agents store to an earlier snapshot",
+	Long: `Return every file in the canonical store to a snapshot from mine agents log.
+
+The rollback is saved as a new snapshot, so nothing is lost: uncommitted
+changes are snapshotted first, and rolling back to the previous snapshot
+undoes it. Copy-mode links are re-synced; symlinked agents see the change
+immediately.
+
+  mine agents log
+  mine agents rollback abc1234`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("agents.rollback", runAgentsRollback),
+}
+
 func init() {
 	agentsCmd.AddCommand(agentsCommitCmd)
 	agentsCmd.AddCommand(agentsLogCmd)
 	agentsCmd.AddCommand(agentsRestoreCmd)
+	agentsCmd.AddCommand(agentsSnapshotCmd)
+	agentsCmd.AddCommand(agentsRollbackCmd)
 
 	agentsCommitCmd.Flags().StringP("message", "m", "", "Commit message")
 	agentsRestoreCmd.Flags().StringP("version", "v", "", "Version hash to restore (default: latest)")
@@ -48,6 +77,16 @@ func init() {
 
 func runAgentsCommit(cmd *cobra.Command, _ []string) error {
 	msg, _ := cmd.Flags().GetString("message")
+	return saveAgentsSnapshot(msg)
+}
+
+func runAgentsSnapshot(_ *cobra.Command, args []string) error {
+	return saveAgentsSnapshot(strings.Join(args, " "))
+}
+
+// saveAgentsSnapshot commits the whole store, defaulting to a timestamped
+// message.
+func saveAgentsSnapshot(msg string) error {
 	if msg == "" {
 		msg = fmt.Sprintf("agents snapshot %s", time.Now().Format("2006-01-02 15:04"))
 	}
@@ -70,7 +109,40 @@ func runAgentsCommit(cmd *cobra.Command, _ []string) error {
 	fmt.Println()
 	ui.Ok(fmt.Sprintf("Snapshot saved %s", ui.Muted.Render("["+hash+"]")))
 	fmt.Printf("  %s\n", ui.Muted.Render(msg))
-	fmt.Printf("  Restore anytime: %s\n", ui.Muted.Render("mine agents restore <file>"))
+	fmt.Printf("  Roll back anytime: %s\n", ui.Muted.Render("mine agents rollback "+hash))
+	fmt.Println()
+	return nil
+}
+
+func runAgentsRollback(_ *cobra.Command, args []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	result, err := agents.Rollback(args[0])
+	if err != nil {
+		if errors.Is(err, agents.ErrNoVersionHistory) {
+			return fmt.Errorf("no snapshots yet — run %s first", ui.Accent.Render("mine agents snapshot"))
+		}
+		if result == nil {
+			return err
+		}
+		// The rollback itself was saved; only the follow-up re-sync failed.
+		ui.Warn(fmt.Sprintf("Rolled back, but %v", err))
+	}
+
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Rolled back to %s %s", ui.Accent.Render(result.Target), result.Message))
+	if result.Saved {
+		fmt.Printf("  %s\n", ui.Muted.Render("Uncommitted changes were snapshotted first."))
+	}
+	if result.CopiedLinks > 0 {
+		fmt.Printf("  Re-synced %d copy-mode link(s)\n", result.CopiedLinks)
+	}
+	if result.Relink {
+		fmt.Printf("  Re-render composed configs: %s\n", ui.Accent.Render("mine agents link"))
+	}
+	fmt.Printf("  Undo: %s\n", ui.Muted.Render("mine agents rollback "+result.Previous))
 	fmt.Println()
 	return nil
 }
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+func TestRunAgentsSnapshotAndRollback(t *testing.T) {
+	storeDir, _ := setupAgentsLinkEnv(t)
+	instr := filepath.Join(storeDir, "instructions", "AGENTS.md")
+
+	out := captureStdout(t, func() {
+		if err := runAgentsSnapshot(nil, []string{"before", "edit"}); err != nil {
+			t.Fatalf("runAgentsSnapshot: %v", err)
+		}
+	})
+	if !strings.Contains(out, "before edit") || !strings.Contains(out, "mine agents rollback") {
+		t.Errorf("unexpected snapshot output:\n%s", out)
+	}
+	logs, err := agents.Log("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := logs[0].Short
+
+	if err := os.WriteFile(instr, []byte("# Regressed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out = captureStdout(t, func() {
+		if err := runAgentsRollback(nil, []string{snapshot}); err != nil {
+			t.Fatalf("runAgentsRollback: %v", err)
+		}
+	})
+	if !strings.Contains(out, snapshot) || !strings.Contains(out, "Uncommitted changes") ||
+		!strings.Contains(out, "mine agents rollback") {
+		t.Errorf("unexpected rollback output:\n%s", out)
+	}
+	if data, _ := os.ReadFile(instr); string(data) != "# Shared Instructions\n" {
+		t.Errorf("AGENTS.md = %q after rollback", data)
+	}
+}
+
+func TestRunAgentsRollback_UnknownSnapshot(t *testing.T) {
+	setupAgentsLinkEnv(t)
+
+	err := runAgentsRollback(nil, []string{"deadbeef"})
+	if err == nil || !strings.Contains(err.Error(), "unknown snapshot") {
+		t.Errorf("error = %v, want unknown snapshot", err)
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/rnwolfe/mine/internal/agents"
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	agentsWatchPolicy string
+	agentsWatchOnce   bool
+)
+
+func init() {
+	agentsCmd.AddCommand(agentsWatchCmd)
+	agentsWatchCmd.Flags().StringVar(&agentsWatchPolicy, "policy", "", "two-way, from-store, or to-store (default: agents.watch_policy, then two-way)")
+	agentsWatchCmd.Flags().BoolVar(&agentsWatchOnce, "once", false, "Sync diverged copies once and exit")
+}
+
+var agentsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Keep copy-mode links in sync with the store as files change",
+	Long: `Watch the store and every link made with --copy, and copy changes across as
+they happen, so copies on filesystems without symlinks don't drift.
+
+The policy decides which way changes flow:
+
+  two-way      whichever side changed is copied to the other (default)
+  from-store   store edits reach the copies; edits to a copy are only reported
+  to-store     edits to a copy are saved to the store; store edits are only reported
+
+Set a default with mine config set agents.watch_policy <policy>. A deleted copy
+is restored from the store, never propagated as a deletion. Rendered files
+(composed instructions and settings) aren't watched — run mine agents link after
+editing their sources.
+
+On start, copies that already differ are reconciled: the side modified most
+recently wins. With --once, mine does only that and exits.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("agents.watch", runAgentsWatch),
+}
+
+func runAgentsWatch(_ *cobra.Command, _ []string) error {
+	if !agents.IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", ui.Accent.Render("mine agents init"))
+	}
+
+	policy := agentsWatchPolicy
+	if policy == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		policy = cfg.Agents.WatchPolicy
+	}
+	if policy == "" {
+		policy = agents.WatchTwoWay
+	}
+
+	events, err := agents.ReconcileCopies(policy)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	for _, ev := range events {
+		printAgentsWatchEvent(ev)
+	}
+	if agentsWatchOnce {
+		if len(events) == 0 {
+			ui.Ok("All copies match the store.")
+		}
+		fmt.Println()
+		return nil
+	}
+
+	w, err := agents.NewWatcher(policy)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	if w.Links() == 0 {
+		fmt.Println(ui.Muted.Render("  No copy-mode links yet — watching for new ones."))
+		fmt.Printf("  Create some with %s\n", ui.Accent.Render("mine agents link --copy"))
+	}
+	fmt.Printf("  Watching %d copied link(s), policy %s — Ctrl+C to stop\n", w.Links(), ui.Accent.Render(policy))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	err = w.Run(ctx, printAgentsWatchEvent)
+	fmt.Println()
+	return err
+}
+
+// printAgentsWatchEvent prints one sync, or why a change was held back.
+func printAgentsWatchEvent(ev agents.WatchEvent) {
+	switch {
+	case ev.Err != nil && ev.Target == "":
+		fmt.Printf("  %s%s\n", ui.Error.Render(ui.IconError), ev.Err.Error())
+	case ev.Err != nil:
+		fmt.Printf("  %s%s %s\n", ui.Error.Render(ui.IconError), ev.Target, ui.Muted.Render(ev.Err.Error()))
+	case !ev.Synced:
+		fmt.Printf("  %s%s %s\n", ui.Warning.Render(ui.IconWarn), ev.Target, ui.Muted.Render(ev.Message))
+	case ev.Direction == agents.DirToStore:
+		fmt.Printf("  %s%s %s %s\n", ui.Success.Render(ui.IconOk), ev.Target, ui.Muted.Render(ui.IconArrow), ui.Accent.Render(ev.Source))
+	default:
+		fmt.Printf("  %s%s %s %s\n", ui.Success.Render(ui.IconOk), ui.Accent.Render(ev.Source), ui.Muted.Render(ui.IconArrow), ev.Target)
+	}
+}
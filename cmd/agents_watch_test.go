@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/agents"
+)
+
+func TestRunAgentsWatch_OnceSyncsCopies(t *testing.T) {
+	_, claudeDir := setupAgentsLinkEnv(t)
+	t.Cleanup(func() { agentsWatchPolicy, agentsWatchOnce = "", false })
+
+	storeCmd := filepath.Join(agents.Dir(), "commands", "deploy.md")
+	if err := os.WriteFile(storeCmd, []byte("deploy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	captureStdout(t, func() {
+		if _, err := agents.Link(agents.LinkOptions{Copy: true}); err != nil {
+			t.Fatalf("Link: %v", err)
+		}
+	})
+	target := filepath.Join(claudeDir, "commands", "deploy.md")
+	if _, err := os.Stat(target); err != nil {
+		t.Fatalf("expected a copied command: %v", err)
+	}
+	if err := os.RemoveAll(filepath.Dir(target)); err != nil {
+		t.Fatal(err)
+	}
+
+	agentsWatchOnce = true
+	agentsWatchPolicy = agents.WatchFromStore
+	out := captureStdout(t, func() {
+		if err := runAgentsWatch(nil, nil); err != nil {
+			t.Fatalf("runAgentsWatch: %v", err)
+		}
+	})
+	if !strings.Contains(out, "commands") {
+		t.Errorf("expected a sync line, got:\n%s", out)
+	}
+	if data, err := os.ReadFile(target); err != nil || string(data) != "deploy\n" {
+		t.Errorf("copy = %q, %v; want it restored", data, err)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runAgentsWatch(nil, nil); err != nil {
+			t.Fatalf("runAgentsWatch: %v", err)
+		}
+	})
+	if !strings.Contains(out, "All copies match") {
+		t.Errorf("expected all copies to match, got:\n%s", out)
+	}
+}
+
+func TestRunAgentsWatch_InvalidPolicy(t *testing.T) {
+	setupAgentsLinkEnv(t)
+	t.Cleanup(func() { agentsWatchPolicy, agentsWatchOnce = "", false })
+
+	agentsWatchPolicy = "sideways"
+	if err := runAgentsWatch(nil, nil); err == nil {
+		t.Error("runAgentsWatch with unknown policy = nil, want error")
+	}
+}
@@ -215,6 +215,9 @@ func runConfigShow(_ *cobra.Command, _ []string) error {
 	ui.Kv("Analytics", fmt.Sprintf("%t", cfg.Analytics.IsEnabled()))
 	fmt.Println()
 	ui.Kv("Config", paths.ConfigFile)
+	if overlay := config.FindProjectFile("."); overlay != "" {
+		ui.Kv("Project", overlay)
+	}
 	ui.Kv("Data", paths.DBFile)
 	ui.Kv("Cache", paths.CacheDir)
 	fmt.Println()
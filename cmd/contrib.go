@@ -310,5 +310,5 @@ func launchTmuxWorkspace(cloneDir, repo string, issueNumber int) error {
 	fmt.Printf("  Attach: %s\n", ui.Accent.Render("mine tmux attach "+sessionName))
 	fmt.Println()
 
-	return tmux.AttachSession(sessionName)
+	return attachSession(sessionName)
 }
@@ -83,6 +83,7 @@ func openTodoFromDash(db *store.DB) error {
 	var projPath *string
 	if p != nil {
 		projPath = &p.Path
+		_ = ps.Touch(p.Name)
 	}
 
 	ts := todo.NewStore(db.Conn())
@@ -91,7 +92,7 @@ func openTodoFromDash(db *store.DB) error {
 		return fmt.Errorf("loading todos: %w", err)
 	}
 
-	actions, err := tui.RunTodo(todos, projPath, false)
+	actions, err := tui.RunTodo(todos, projPath, false, tui.WithDetailLoader(todoDetailLoader(ts)))
 	if err != nil {
 		return fmt.Errorf("todo tui: %w", err)
 	}
@@ -126,6 +127,14 @@ func openTodoFromDash(db *store.DB) error {
 			if err := ts.SetSchedule(a.ID, a.Schedule); err != nil {
 				failedActions = append(failedActions, fmt.Sprintf("schedule #%d: %v", a.ID, err))
 			}
+		case "note":
+			if err := ts.AddNote(a.ID, a.Text); err != nil {
+				failedActions = append(failedActions, fmt.Sprintf("note #%d: %v", a.ID, err))
+			}
+		case "tag":
+			if _, err := ts.AddTag(a.ID, a.Text); err != nil {
+				failedActions = append(failedActions, fmt.Sprintf("tag #%d: %v", a.ID, err))
+			}
 		}
 	}
 
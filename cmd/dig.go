@@ -94,6 +94,10 @@ func runDig(_ *cobra.Command, args []string) error {
 		}
 	}
 
+	// Publish the running session for the tmux status line.
+	setDigActive(dig.Active{StartedAt: time.Now(), Duration: duration, Task: taskTitle})
+	defer setDigActive(dig.Active{})
+
 	// Use full-screen TUI when connected to a terminal and --simple not set.
 	if tui.IsTTY() && !digSimple {
 		return runDigTUI(duration, label, linkedTodoID, taskTitle)
@@ -129,6 +133,7 @@ func pickProjectTask() (*todo.Todo, error) {
 	if err != nil || p == nil {
 		return nil, nil
 	}
+	_ = ps.Touch(p.Name)
 
 	ts := todo.NewStore(db.Conn())
 	todos, err := ts.List(todo.ListOptions{ProjectPath: &p.Path})
@@ -315,6 +320,23 @@ func recordDigSession(duration time.Duration, todoID *int, completed bool, start
 	ui.Ok(fmt.Sprintf("%dm logged. %dh %dm total deep work.", mins, totalMins/60, totalMins%60))
 }
 
+// setDigActive records a as the running session, or clears it when a is
+// the zero value. Best-effort: the session runs either way.
+func setDigActive(a dig.Active) {
+	db, err := store.Open()
+	if err != nil {
+		return
+	}
+	defer db.Close()
+
+	ds := dig.NewStore(db.Conn())
+	if a.Duration == 0 {
+		_ = ds.ClearActive()
+		return
+	}
+	_ = ds.SetActive(a)
+}
+
 func runDigStats(_ *cobra.Command, _ []string) error {
 	db, err := store.Open()
 	if err != nil {
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/env"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var goPrintPath bool
+
+var goCmd = &cobra.Command{
+	Use:   "go",
+	Short: "Jump to anything — projects, todos, sessions, stash, env",
+	Long: `Open one fuzzy palette across registered projects, open todos, tmux
+sessions, stashed files, and env profiles for the current directory.
+
+Selecting an item jumps to it: projects are switched to, tmux sessions are
+attached, and todos, stash entries, and env profiles are shown. Type a kind
+("todo", "tmux", ...) to narrow the list. Piped, it prints the plain list.
+
+  cd "$(mine go --print-path)"   # print a chosen project's path instead`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("go", runGo),
+}
+
+func init() {
+	rootCmd.AddCommand(goCmd)
+	goCmd.Flags().BoolVar(&goPrintPath, "print-path", false, "Print a selected project's path only (for shell helpers)")
+	goCmd.Flags().MarkHidden("print-path")
+}
+
+// goItem is one palette entry. run performs the jump; path is set for
+// entries that have a directory to cd into.
+type goItem struct {
+	kind string
+	name string
+	desc string
+	path string
+	run  func() error
+	// boost favors recently used projects in fuzzy matches.
+	boost int
+}
+
+func (g goItem) FilterValue() string { return g.kind + " " + g.name }
+func (g goItem) Title() string       { return g.name }
+func (g goItem) Description() string {
+	if g.desc == "" {
+		return g.kind
+	}
+	return g.kind + " · " + g.desc
+}
+
+func runGo(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return fmt.Errorf("opening store: %w", err)
+	}
+	items := gatherGoItems(db)
+	// The chosen action reopens the store itself.
+	db.Close()
+
+	if len(items) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  Nothing to jump to yet."))
+		fmt.Printf("  Register a project: %s\n", ui.Accent.Render("mine proj add ."))
+		fmt.Println()
+		return nil
+	}
+
+	if !tui.IsTTY() {
+		if goPrintPath {
+			return fmt.Errorf("--print-path requires an interactive terminal")
+		}
+		printGoItems(items)
+		return nil
+	}
+
+	picks := make([]tui.Item, len(items))
+	for i := range items {
+		picks[i] = items[i]
+	}
+	pickerTitle := tui.WithTitle(ui.IconMine + "Go to")
+	pickerHeight := tui.WithHeight(15)
+	boost := tui.WithBoost(func(it tui.Item) int { return it.(goItem).boost })
+	var chosen tui.Item
+	if goPrintPath {
+		chosen, err = tui.RunWithOutput(picks, os.Stderr, pickerTitle, pickerHeight, boost)
+	} else {
+		chosen, err = tui.Run(picks, pickerTitle, pickerHeight, boost)
+	}
+	if err != nil {
+		return err
+	}
+	if chosen == nil {
+		return nil
+	}
+
+	item := chosen.(goItem)
+	if goPrintPath {
+		if item.kind != "project" {
+			return fmt.Errorf("%s %q has no path to jump to", item.kind, item.name)
+		}
+		// Record the switch like 'mine proj' does, then hand back the path.
+		db, err := store.Open()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		res, err := proj.NewStore(db.Conn()).Open(item.name)
+		if err != nil {
+			return err
+		}
+		fmt.Print(res.Project.Path)
+		return nil
+	}
+	return item.run()
+}
+
+// gatherGoItems collects palette entries from every source. Sources are
+// best-effort: one that is unavailable or fails to load is left out rather
+// than failing the whole palette.
+func gatherGoItems(db *store.DB) []goItem {
+	var items []goItem
+
+	ps := proj.NewStore(db.Conn())
+	if projects, err := ps.List(); err == nil {
+		proj.SortByRecent(projects)
+		now := time.Now()
+		for _, p := range projects {
+			name := p.Name
+			items = append(items, goItem{
+				kind:  "project",
+				name:  name,
+				desc:  p.Path,
+				path:  p.Path,
+				run:   func() error { return goOpenProject(name) },
+				boost: proj.RecencyBoost(p.LastAccessed, now),
+			})
+		}
+	}
+
+	ts := todo.NewStore(db.Conn())
+	if todos, err := ts.List(todo.ListOptions{AllProjects: true}); err == nil {
+		for _, t := range todos {
+			id := strconv.Itoa(t.ID)
+			items = append(items, goItem{
+				kind: "todo",
+				name: t.Title,
+				desc: "#" + id,
+				run:  func() error { return runTodoShow(nil, []string{id}) },
+			})
+		}
+	}
+
+	if tmux.Available() {
+		if sessions, err := tmux.ListSessions(); err == nil {
+			for _, s := range sessions {
+				name := s.Name
+				items = append(items, goItem{
+					kind: "tmux",
+					name: name,
+					desc: s.Description(),
+					run:  func() error { return attachSession(name) },
+				})
+			}
+		}
+	}
+
+	if entries, err := stash.ReadManifest(); err == nil {
+		for _, e := range entries {
+			source := e.Source
+			items = append(items, goItem{
+				kind: "stash",
+				name: source,
+				run:  func() error { return runStashLog(nil, []string{source}) },
+			})
+		}
+	}
+
+	m := env.New(db.Conn(), "")
+	if projectPath, err := m.ProjectPath(); err == nil {
+		if profiles, err := m.ListProfiles(projectPath); err == nil {
+			for _, profile := range profiles {
+				name := profile
+				items = append(items, goItem{
+					kind: "env",
+					name: name,
+					desc: projectPath,
+					run:  func() error { return runEnvShow(nil, []string{name}) },
+				})
+			}
+		}
+	}
+
+	return items
+}
+
+// goOpenProject switches to a project the same way the 'mine proj' picker does.
+func goOpenProject(name string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	res, err := proj.NewStore(db.Conn()).Open(name)
+	if err != nil {
+		return err
+	}
+	ui.Ok(fmt.Sprintf("Switched to %s", ui.Accent.Render(res.Project.Name)))
+	fmt.Printf("  %s\n", ui.Muted.Render(res.Project.Path))
+	fmt.Printf("  Jump there: %s\n", ui.Accent.Render("p "+res.Project.Name))
+	fmt.Println()
+	return nil
+}
+
+// printGoItems is the non-interactive fallback: one line per entry.
+func printGoItems(items []goItem) {
+	fmt.Println()
+	for _, it := range items {
+		line := fmt.Sprintf("  %-8s %s", it.kind, it.name)
+		if it.desc != "" {
+			line += "  " + ui.Muted.Render(it.desc)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
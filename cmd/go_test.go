@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func TestGatherGoItems_ProjectsAndTodos(t *testing.T) {
+	todoTestEnv(t)
+	t.Chdir(t.TempDir())
+	projDir := registerProject(t, "palette")
+	seedTodos(t, 2)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	got := map[string]goItem{}
+	for _, it := range gatherGoItems(db) {
+		got[it.kind+":"+it.name] = it
+	}
+
+	p, ok := got["project:palette"]
+	if !ok {
+		t.Fatalf("project missing from palette: %v", got)
+	}
+	if p.path != projDir {
+		t.Errorf("project path = %q, want %q", p.path, projDir)
+	}
+	for _, key := range []string{"todo:task 1", "todo:task 2"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("%s missing from palette", key)
+		}
+	}
+	if d := got["todo:task 1"].Description(); !strings.HasPrefix(d, "todo · #") {
+		t.Errorf("todo description = %q", d)
+	}
+}
+
+func TestGatherGoItems_SkipsDoneTodos(t *testing.T) {
+	todoTestEnv(t)
+	t.Chdir(t.TempDir())
+	ids := seedTodos(t, 2)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, _, err := todo.NewStore(db.Conn()).Complete(ids[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, it := range gatherGoItems(db) {
+		if it.kind == "todo" && it.name == "task 1" {
+			t.Fatal("done todo listed in palette")
+		}
+	}
+}
+
+func TestPrintGoItems(t *testing.T) {
+	out := captureStdout(t, func() {
+		printGoItems([]goItem{
+			{kind: "project", name: "api", desc: "/code/api"},
+			{kind: "stash", name: "/home/me/.zshrc"},
+		})
+	})
+	for _, want := range []string{"project", "api", "/code/api", "stash", ".zshrc"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/mux"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var muxNewDir string
+
+var muxCmd = &cobra.Command{
+	Use:   "mux",
+	Short: "Session commands for your multiplexer — tmux or zellij",
+	Long: `Create, list, attach, kill, and rename sessions with whichever
+multiplexer mux.backend names (tmux by default):
+
+  mine config set mux.backend zellij
+
+The tmux-only extras — layouts, windows, snapshots — stay under 'mine tmux'.`,
+	RunE: hook.Wrap("mux", runMux),
+}
+
+func init() {
+	rootCmd.AddCommand(muxCmd)
+
+	muxCmd.AddCommand(muxNewCmd)
+	muxCmd.AddCommand(muxLsCmd)
+	muxCmd.AddCommand(muxAttachCmd)
+	muxCmd.AddCommand(muxKillCmd)
+	muxCmd.AddCommand(muxRenameCmd)
+
+	muxNewCmd.Flags().StringVar(&muxNewDir, "dir", "", "Directory the session starts in (defaults to the current directory)")
+}
+
+// currentMuxFunc returns the configured backend; stubbed in tests.
+var currentMuxFunc = currentMux
+
+// currentMux returns the backend named by mux.backend, erroring when its
+// binary isn't installed.
+func currentMux() (mux.Multiplexer, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	m, err := mux.New(cfg.Mux.Backend)
+	if err != nil {
+		return nil, err
+	}
+	if !m.Available() {
+		return nil, fmt.Errorf("%s not found in PATH — install it or change mux.backend", m.Name())
+	}
+	return m, nil
+}
+
+// muxSessions returns m's sessions, erroring when there are none.
+func muxSessions(m mux.Multiplexer) ([]mux.Session, error) {
+	sessions, err := m.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("no %s sessions running", m.Name())
+	}
+	return sessions, nil
+}
+
+// pickMuxSession resolves a session by fuzzy name, or with the picker when
+// no name is given. It returns "" when the user cancels or there's no TTY
+// to pick on (after listing the sessions).
+func pickMuxSession(sessions []mux.Session, args []string, title string) (string, error) {
+	if len(args) > 0 {
+		s, err := mux.FuzzyFindSession(args[0], sessions)
+		if err != nil {
+			return "", err
+		}
+		return s.Name, nil
+	}
+
+	if !tui.IsTTY() {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  Specify a session name or run interactively in a terminal."))
+		printMuxSessions(sessions)
+		return "", nil
+	}
+
+	items := make([]tui.Item, len(sessions))
+	for i := range sessions {
+		items[i] = sessions[i]
+	}
+	chosen, err := tui.Run(items,
+		tui.WithTitle(ui.IconMine+title),
+		tui.WithHeight(12),
+	)
+	if err != nil || chosen == nil {
+		return "", err
+	}
+	return chosen.Title(), nil
+}
+
+// muxAttach attaches to name. tmux goes through attachSession so
+// 'mine tmux last' sees it.
+func muxAttach(m mux.Multiplexer, name string) error {
+	if _, ok := m.(mux.Tmux); ok {
+		return attachSession(name)
+	}
+	return m.AttachSession(name)
+}
+
+// --- mine mux (bare) — fuzzy session picker ---
+
+func runMux(_ *cobra.Command, _ []string) error {
+	m, err := currentMuxFunc()
+	if err != nil {
+		return err
+	}
+	sessions, err := m.ListSessions()
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  No %s sessions running.", m.Name())))
+		fmt.Printf("  Create one: %s\n", ui.Accent.Render("mine mux new"))
+		fmt.Println()
+		return nil
+	}
+	if !tui.IsTTY() {
+		printMuxSessions(sessions)
+		return nil
+	}
+
+	name, err := pickMuxSession(sessions, nil, fmt.Sprintf("Select %s session", m.Name()))
+	if err != nil || name == "" {
+		return err
+	}
+	return muxAttach(m, name)
+}
+
+// --- mine mux new ---
+
+var muxNewCmd = &cobra.Command{
+	Use:   "new [name]",
+	Short: "Create a new session",
+	Long:  `Create a detached session. Auto-names from the starting directory if omitted.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  hook.Wrap("mux.new", runMuxNew),
+}
+
+func runMuxNew(_ *cobra.Command, args []string) error {
+	m, err := currentMuxFunc()
+	if err != nil {
+		return err
+	}
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	resolved, err := m.NewSession(name, muxNewDir)
+	if err != nil {
+		return err
+	}
+
+	ui.Ok(fmt.Sprintf("Session %s created", ui.Accent.Render(resolved)))
+	fmt.Printf("  Attach: %s\n", ui.Muted.Render("mine mux attach "+resolved))
+	fmt.Println()
+	return nil
+}
+
+// --- mine mux ls ---
+
+var muxLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List sessions",
+	Args:    cobra.NoArgs,
+	RunE:    hook.Wrap("mux.ls", runMuxLs),
+}
+
+func runMuxLs(_ *cobra.Command, _ []string) error {
+	m, err := currentMuxFunc()
+	if err != nil {
+		return err
+	}
+	sessions, err := m.ListSessions()
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No sessions running."))
+		fmt.Println()
+		return nil
+	}
+	printMuxSessions(sessions)
+	return nil
+}
+
+// --- mine mux attach ---
+
+var muxAttachCmd = &cobra.Command{
+	Use:     "attach [name]",
+	Aliases: []string{"a"},
+	Short:   "Attach to a session (fuzzy match)",
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    hook.Wrap("mux.attach", runMuxAttach),
+}
+
+func runMuxAttach(_ *cobra.Command, args []string) error {
+	m, err := currentMuxFunc()
+	if err != nil {
+		return err
+	}
+	sessions, err := muxSessions(m)
+	if err != nil {
+		return err
+	}
+	name, err := pickMuxSession(sessions, args, "Attach to session")
+	if err != nil || name == "" {
+		return err
+	}
+	return muxAttach(m, name)
+}
+
+// --- mine mux kill ---
+
+var muxKillCmd = &cobra.Command{
+	Use:   "kill [name]",
+	Short: "Kill a session (fuzzy match)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  hook.Wrap("mux.kill", runMuxKill),
+}
+
+func runMuxKill(_ *cobra.Command, args []string) error {
+	m, err := currentMuxFunc()
+	if err != nil {
+		return err
+	}
+	sessions, err := muxSessions(m)
+	if err != nil {
+		return err
+	}
+	name, err := pickMuxSession(sessions, args, "Kill session")
+	if err != nil || name == "" {
+		return err
+	}
+	if err := m.KillSession(name); err != nil {
+		return err
+	}
+	ui.Ok(fmt.Sprintf("Killed session %s", ui.Accent.Render(name)))
+	fmt.Println()
+	return nil
+}
+
+// --- mine mux rename ---
+
+var muxRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a session",
+	Long: `Rename a session; <old> is fuzzy-matched. zellij can only rename the
+session you're in, so run it from inside that session.`,
+	Args: cobra.ExactArgs(2),
+	RunE: hook.Wrap("mux.rename", runMuxRename),
+}
+
+func runMuxRename(_ *cobra.Command, args []string) error {
+	m, err := currentMuxFunc()
+	if err != nil {
+		return err
+	}
+	sessions, err := muxSessions(m)
+	if err != nil {
+		return err
+	}
+	s, err := mux.FuzzyFindSession(args[0], sessions)
+	if err != nil {
+		return err
+	}
+	oldName, newName := s.Name, args[1]
+
+	if _, ok := m.(mux.Tmux); ok {
+		err = renameSession(oldName, newName)
+	} else {
+		err = m.RenameSession(oldName, newName)
+	}
+	if err != nil {
+		return err
+	}
+	ui.Ok(fmt.Sprintf("Renamed session %s → %s", ui.Accent.Render(oldName), ui.Accent.Render(newName)))
+	fmt.Println()
+	return nil
+}
+
+// --- helpers ---
+
+func printMuxSessions(sessions []mux.Session) {
+	fmt.Println()
+	for _, s := range sessions {
+		marker := " "
+		if s.Attached {
+			marker = ui.Success.Render("*")
+		}
+		desc := ""
+		if s.Windows > 0 {
+			desc = mux.Session{Windows: s.Windows}.Description()
+		}
+		fmt.Printf("  %s %-20s %s\n", marker, ui.Accent.Render(s.Name), ui.Muted.Render(desc))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/mux"
+)
+
+// fakeMux is an in-memory multiplexer that records calls.
+type fakeMux struct {
+	sessions []mux.Session
+	calls    []string
+}
+
+func (f *fakeMux) Name() string                         { return "zellij" }
+func (f *fakeMux) Available() bool                      { return true }
+func (f *fakeMux) Inside() bool                         { return false }
+func (f *fakeMux) ListSessions() ([]mux.Session, error) { return f.sessions, nil }
+
+func (f *fakeMux) NewSession(name, dir string) (string, error) {
+	if name == "" {
+		name = "auto"
+	}
+	f.calls = append(f.calls, fmt.Sprintf("new %s %s", name, dir))
+	return name, nil
+}
+
+func (f *fakeMux) AttachSession(name string) error {
+	f.calls = append(f.calls, "attach "+name)
+	return nil
+}
+
+func (f *fakeMux) KillSession(name string) error {
+	f.calls = append(f.calls, "kill "+name)
+	return nil
+}
+
+func (f *fakeMux) RenameSession(oldName, newName string) error {
+	f.calls = append(f.calls, "rename "+oldName+" "+newName)
+	return nil
+}
+
+func setupFakeMux(t *testing.T, sessions ...string) *fakeMux {
+	t.Helper()
+	f := &fakeMux{}
+	for _, name := range sessions {
+		f.sessions = append(f.sessions, mux.Session{Name: name})
+	}
+	orig := currentMuxFunc
+	currentMuxFunc = func() (mux.Multiplexer, error) { return f, nil }
+	t.Cleanup(func() { currentMuxFunc = orig })
+	return f
+}
+
+func TestCurrentMux_UsesConfiguredBackend(t *testing.T) {
+	configTestEnv(t)
+	t.Setenv("PATH", t.TempDir()) // neither binary installed
+
+	cfg := &config.Config{Mux: config.MuxConfig{Backend: "zellij"}}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := currentMux()
+	if err == nil || !strings.Contains(err.Error(), "zellij not found") {
+		t.Fatalf("expected zellij not found error, got %v", err)
+	}
+}
+
+func TestRunMuxNew(t *testing.T) {
+	f := setupFakeMux(t)
+	muxNewDir = "/code/api"
+	t.Cleanup(func() { muxNewDir = "" })
+
+	out := captureStdout(t, func() {
+		if err := runMuxNew(nil, []string{"api"}); err != nil {
+			t.Fatalf("runMuxNew: %v", err)
+		}
+	})
+	if want := []string{"new api /code/api"}; !reflect.DeepEqual(f.calls, want) {
+		t.Fatalf("calls = %q, want %q", f.calls, want)
+	}
+	if !strings.Contains(out, "mine mux attach api") {
+		t.Errorf("expected attach hint, got:\n%s", out)
+	}
+}
+
+func TestRunMuxLs(t *testing.T) {
+	setupFakeMux(t, "api", "web")
+
+	out := captureStdout(t, func() {
+		if err := runMuxLs(nil, nil); err != nil {
+			t.Fatalf("runMuxLs: %v", err)
+		}
+	})
+	for _, name := range []string{"api", "web"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("expected %q in output:\n%s", name, out)
+		}
+	}
+	if strings.Contains(out, "window") {
+		t.Errorf("expected no window count when unknown, got:\n%s", out)
+	}
+}
+
+func TestRunMuxAttachKillRename_FuzzyMatch(t *testing.T) {
+	f := setupFakeMux(t, "api-server", "web")
+
+	captureStdout(t, func() {
+		if err := runMuxAttach(nil, []string{"api"}); err != nil {
+			t.Fatalf("runMuxAttach: %v", err)
+		}
+		if err := runMuxKill(nil, []string{"we"}); err != nil {
+			t.Fatalf("runMuxKill: %v", err)
+		}
+		if err := runMuxRename(nil, []string{"api", "billing"}); err != nil {
+			t.Fatalf("runMuxRename: %v", err)
+		}
+	})
+	want := []string{"attach api-server", "kill web", "rename api-server billing"}
+	if !reflect.DeepEqual(f.calls, want) {
+		t.Fatalf("calls = %q, want %q", f.calls, want)
+	}
+}
+
+func TestRunMuxAttach_NoSessions(t *testing.T) {
+	setupFakeMux(t)
+
+	err := runMuxAttach(nil, []string{"api"})
+	if err == nil || !strings.Contains(err.Error(), "no zellij sessions running") {
+		t.Fatalf("expected no sessions error, got %v", err)
+	}
+}
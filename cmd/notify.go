@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/notify"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notifyWatch    bool
+	notifyInterval time.Duration
+)
+
+// Injectable for testing.
+var newNotifierFunc = notify.NewPlatform
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Desktop notifications for reminders and due tasks",
+	Long: `Deliver desktop notifications for todo reminders and tasks coming due.
+
+Uses notify-send on Linux and osascript on macOS, falling back to a
+terminal bell elsewhere.`,
+	RunE: hook.Wrap("notify", runNotifyHelp),
+}
+
+var notifyRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Send notifications for reminders and due tasks",
+	Long: `Check for reminders whose time has come and tasks that just came due,
+and send a notification for each. Each item is notified once.
+
+Runs once and exits by default — suitable for cron:
+
+  */5 * * * * mine notify run
+
+Use --watch to keep running and check every --interval.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("notify.run", runNotifyRun),
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a test notification",
+	Args:  cobra.NoArgs,
+	RunE:  hook.Wrap("notify.test", runNotifyTest),
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.AddCommand(notifyRunCmd)
+	notifyCmd.AddCommand(notifyTestCmd)
+
+	notifyRunCmd.Flags().BoolVarP(&notifyWatch, "watch", "w", false, "Keep running and check periodically")
+	notifyRunCmd.Flags().DurationVar(&notifyInterval, "interval", time.Minute, "How often to check in --watch mode")
+}
+
+func runNotifyHelp(_ *cobra.Command, _ []string) error {
+	fmt.Println()
+	fmt.Println(ui.Title.Render("  Notifications"))
+	fmt.Println()
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine notify run"), ui.Muted.Render("Send due notifications once"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine notify run --watch"), ui.Muted.Render("Keep checking in the foreground"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine notify test"), ui.Muted.Render("Send a test notification"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo remind <id> <when>"), ui.Muted.Render("Schedule a reminder"))
+	fmt.Println()
+	return nil
+}
+
+func runNotifyRun(_ *cobra.Command, _ []string) error {
+	if notifyWatch && notifyInterval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	n := newNotifierFunc()
+
+	sent, err := deliverNotifications(n, time.Now())
+	if err != nil {
+		return err
+	}
+	if !notifyWatch {
+		if sent == 0 {
+			fmt.Println(ui.Muted.Render("  Nothing to notify."))
+		} else {
+			fmt.Printf("  %s Sent %d notification(s) via %s\n", ui.Success.Render("✓"), sent, n.Name())
+		}
+		return nil
+	}
+
+	fmt.Printf("  Watching for reminders every %s via %s — Ctrl+C to stop\n", notifyInterval, n.Name())
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	ticker := time.NewTicker(notifyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sigCh:
+			fmt.Println()
+			return nil
+		case now := <-ticker.C:
+			if _, err := deliverNotifications(n, now); err != nil {
+				fmt.Println(ui.Warning.Render("  " + err.Error()))
+			}
+		}
+	}
+}
+
+// deliverNotifications sends every reminder due at now and marks it delivered.
+// A failed send is left pending so the next run retries it.
+func deliverNotifications(n notify.Notifier, now time.Time) (int, error) {
+	db, err := store.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	due, err := ts.DueReminders(now)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, r := range due {
+		msg := notify.Notification{
+			Title: "Reminder",
+			Body:  fmt.Sprintf("#%d %s", r.TodoID, r.Title),
+		}
+		if r.Kind == todo.ReminderKindDue {
+			msg.Title = "Due now"
+		}
+		if err := n.Send(msg); err != nil {
+			return sent, err
+		}
+		if err := ts.MarkNotified(r); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func runNotifyTest(_ *cobra.Command, _ []string) error {
+	n := newNotifierFunc()
+	if err := n.Send(notify.Notification{Title: "mine", Body: "Notifications are working."}); err != nil {
+		return err
+	}
+	fmt.Printf("  %s Test notification sent via %s\n", ui.Success.Render("✓"), n.Name())
+	return nil
+}
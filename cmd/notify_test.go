@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/notify"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+type fakeNotifier struct {
+	sent []notify.Notification
+}
+
+func (f *fakeNotifier) Name() string { return "fake" }
+
+func (f *fakeNotifier) Send(n notify.Notification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func withFakeNotifier(t *testing.T) *fakeNotifier {
+	t.Helper()
+	f := &fakeNotifier{}
+	orig := newNotifierFunc
+	newNotifierFunc = func() notify.Notifier { return f }
+	t.Cleanup(func() { newNotifierFunc = orig })
+	return f
+}
+
+func TestParseReminderTime(t *testing.T) {
+	now := time.Date(2030, 6, 10, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"30m", now.Add(30 * time.Minute)},
+		{"in 2h", now.Add(2 * time.Hour)},
+		{"2030-06-12", time.Date(2030, 6, 12, 9, 0, 0, 0, time.Local)},
+		{"2030-06-12 14:30", time.Date(2030, 6, 12, 14, 30, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseReminderTime(tt.in, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseReminderTime(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseReminderTime("whenever", now); err == nil {
+		t.Error("expected error for unparseable time")
+	}
+}
+
+func TestRunTodoRemind_SetsAndLists(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+
+	out := captureStdout(t, func() {
+		if err := runTodoRemind(nil, []string{"1", "in", "2h"}); err != nil {
+			t.Fatalf("runTodoRemind: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Reminder set") {
+		t.Errorf("expected confirmation:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTodoRemind(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoRemind list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "⏰") {
+		t.Errorf("expected pending reminder listed:\n%s", out)
+	}
+}
+
+func TestRunTodoRemind_PastTime_Error(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+
+	err := runTodoRemind(nil, []string{"1", "2000-01-01"})
+	if err == nil || !strings.Contains(err.Error(), "in the past") {
+		t.Fatalf("expected past-time error, got %v", err)
+	}
+}
+
+func TestRunNotifyRun_DeliversOnce(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+	f := withFakeNotifier(t)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := todo.NewStore(db.Conn()).AddReminder(1, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runNotifyRun(nil, nil); err != nil {
+			t.Fatalf("runNotifyRun: %v", err)
+		}
+	})
+	if len(f.sent) != 1 || f.sent[0].Title != "Reminder" || !strings.Contains(f.sent[0].Body, "#1 task 1") {
+		t.Fatalf("unexpected notifications: %+v", f.sent)
+	}
+	if !strings.Contains(out, "Sent 1 notification") {
+		t.Errorf("expected summary:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runNotifyRun(nil, nil); err != nil {
+			t.Fatalf("runNotifyRun: %v", err)
+		}
+	})
+	if len(f.sent) != 1 || !strings.Contains(out, "Nothing to notify") {
+		t.Errorf("expected no repeat notification; sent=%d out=%s", len(f.sent), out)
+	}
+}
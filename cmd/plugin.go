@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/rnwolfe/mine/internal/config"
 	"github.com/rnwolfe/mine/internal/hook"
 	"github.com/rnwolfe/mine/internal/plugin"
 	"github.com/rnwolfe/mine/internal/ui"
@@ -36,13 +37,20 @@ var pluginInfoCmd = &cobra.Command{
 }
 
 var pluginInstallCmd = &cobra.Command{
-	Use:   "install <path>",
-	Short: "Install a plugin from a local directory",
-	Long: `Install a mine plugin from a local directory containing mine-plugin.toml.
+	Use:   "install <path|repo[@version]>",
+	Short: "Install a plugin from a local directory or git repository",
+	Long: `Install a mine plugin from a local directory containing mine-plugin.toml,
+or from a git repository.
+
+A repository can be pinned after "@" to a release tag, a semver range such
+as ^1.2 or ~1.2.0, or a commit. Without a pin, the newest release is
+installed (or the default branch if there are no releases).
 
 Examples:
   mine plugin install ./my-plugin
-  mine plugin install /path/to/mine-plugin-obsidian`,
+  mine plugin install /path/to/mine-plugin-obsidian
+  mine plugin install github.com/user/mine-plugin-foo@v1.2.0
+  mine plugin install github.com/user/mine-plugin-foo@^1.2`,
 	Args: cobra.ExactArgs(1),
 	RunE: hook.Wrap("plugin.install", runPluginInstall),
 }
@@ -56,13 +64,37 @@ var pluginRemoveCmd = &cobra.Command{
 }
 
 var pluginSearchCmd = &cobra.Command{
-	Use:   "search <query>",
-	Short: "Search GitHub for mine plugins",
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  hook.Wrap("plugin.search", runPluginSearch),
+	Use:   "search [query]",
+	Short: "Search the plugin index",
+	Long: `Search the community plugin index for plugins whose name, description, or
+tags match the query. Each result shows what the plugin does, the
+permissions it asks for, and the command that installs it.
+
+The index is read from plugins.index_url. --github searches GitHub for
+mine-plugin-* repositories instead.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("plugin.search", runPluginSearch),
+}
+
+var pluginNewCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Create a new plugin from a template",
+	Long: `Create a plugin skeleton to start from: a manifest, an entrypoint that
+speaks the plugin protocol with an example hook and command, and tests.
+
+The skeleton goes in ./mine-plugin-<name> unless --dir says otherwise.
+
+Examples:
+  mine plugin new greeter
+  mine plugin new greeter --lang shell`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("plugin.new", runPluginNew),
 }
 
-var pluginSearchTag string
+var (
+	pluginSearchTag    string
+	pluginSearchGitHub bool
+)
 
 func init() {
 	rootCmd.AddCommand(pluginCmd)
@@ -71,8 +103,14 @@ func init() {
 	pluginCmd.AddCommand(pluginInstallCmd)
 	pluginCmd.AddCommand(pluginRemoveCmd)
 	pluginCmd.AddCommand(pluginSearchCmd)
-
-	pluginSearchCmd.Flags().StringVar(&pluginSearchTag, "tag", "", "Filter by GitHub topic")
+	pluginCmd.AddCommand(pluginNewCmd)
+
+	pluginSearchCmd.Flags().StringVar(&pluginSearchTag, "tag", "", "Filter by tag (GitHub topic with --github)")
+	pluginSearchCmd.Flags().BoolVar(&pluginSearchGitHub, "github", false, "Search GitHub instead of the plugin index")
+	pluginNewCmd.Flags().String("lang", "go", "Entrypoint language: "+strings.Join(plugin.ScaffoldLanguages(), ", "))
+	pluginNewCmd.Flags().String("dir", "", "Directory to create (default: ./mine-plugin-<name>)")
+	pluginNewCmd.Flags().String("author", "", "Plugin author (default: user.name from config)")
+	pluginInstallCmd.Flags().Bool("insecure", false, "Install even if the signature doesn't verify, and skip checks before each run")
 }
 
 func runPluginList(_ *cobra.Command, _ []string) error {
@@ -138,6 +176,19 @@ func runPluginInfo(_ *cobra.Command, args []string) error {
 	ui.Kv("Protocol", m.Plugin.ProtocolVersion)
 	ui.Kv("Directory", p.Dir)
 	ui.Kv("Enabled", fmt.Sprintf("%v", p.Enabled))
+	switch {
+	case p.Insecure:
+		ui.Kv("Verified", "no (installed with --insecure)")
+	case m.Signature.Signed():
+		ui.Kv("Verified", "signed by "+plugin.KeyFingerprint(m.Signature.PublicKey))
+	case p.Checksum != "":
+		ui.Kv("Verified", "checksum recorded at install (not signed)")
+	}
+	if tool := plugin.Sandbox(); tool != "" {
+		ui.Kv("Sandbox", "writes limited to declared paths ("+tool+")")
+	} else {
+		ui.Kv("Sandbox", "none — filesystem permissions not enforced")
+	}
 
 	if len(m.Hooks) > 0 {
 		fmt.Println()
@@ -172,8 +223,24 @@ func runPluginInfo(_ *cobra.Command, args []string) error {
 	return nil
 }
 
-func runPluginInstall(_ *cobra.Command, args []string) error {
+func runPluginInstall(cmd *cobra.Command, args []string) error {
+	insecure, _ := cmd.Flags().GetBool("insecure")
 	sourceDir := args[0]
+	install := func() (*plugin.InstalledPlugin, error) { return plugin.Install(sourceDir, sourceDir) }
+	if insecure {
+		install = func() (*plugin.InstalledPlugin, error) { return plugin.InstallInsecure(sourceDir, sourceDir) }
+	}
+	if plugin.IsRemote(args[0]) {
+		ui.Inf(fmt.Sprintf("Fetching %s...", args[0]))
+		co, err := plugin.Fetch(args[0])
+		if err != nil {
+			return err
+		}
+		defer co.Cleanup()
+		co.Insecure = insecure
+		sourceDir = co.Dir
+		install = co.Install
+	}
 
 	// Parse manifest first to show permissions
 	manifestPath := filepath.Join(sourceDir, "mine-plugin.toml")
@@ -198,6 +265,7 @@ func runPluginInstall(_ *cobra.Command, args []string) error {
 		fmt.Printf("    %s\n", line)
 	}
 	fmt.Println()
+	printPluginSignature(manifest)
 
 	// Confirm
 	reader := bufio.NewReader(os.Stdin)
@@ -213,23 +281,109 @@ func runPluginInstall(_ *cobra.Command, args []string) error {
 		return nil
 	}
 
-	p, err := plugin.Install(sourceDir, sourceDir)
+	p, err := install()
 	if err != nil {
 		return err
 	}
 
-	if err := plugin.AuditLog(p.Manifest.Plugin.Name, "install", "version="+p.Manifest.Plugin.Version); err != nil {
+	detail := "version=" + p.Manifest.Plugin.Version
+	if p.Insecure {
+		detail += " insecure=true"
+	}
+	if err := plugin.AuditLog(p.Manifest.Plugin.Name, "install", detail); err != nil {
 		log.Printf("warning: audit log: %v", err)
 	}
 
 	fmt.Println()
 	ui.Ok(fmt.Sprintf("Installed %s v%s", p.Manifest.Plugin.Name, p.Manifest.Plugin.Version))
+	if p.Insecure {
+		ui.Warn("Installed with --insecure: its entrypoint won't be verified before it runs.")
+	}
 	fmt.Printf("  %d hooks registered, %d commands available\n",
 		len(p.Manifest.Hooks), len(p.Manifest.Commands))
 	fmt.Println()
 	return nil
 }
 
+// searchPluginIndex lists the plugins in the configured index matching query.
+func searchPluginIndex(query string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	idx, err := plugin.FetchIndex(cfg.Plugins.IndexURLOrDefault())
+	if err != nil {
+		return err
+	}
+	results := idx.Search(query, pluginSearchTag)
+
+	fmt.Println()
+	if len(results) == 0 {
+		fmt.Println(ui.Muted.Render("  No plugins in the index match that."))
+		ui.Tip("try a broader term, search GitHub with --github, or build your own: mine plugin new <name>")
+		fmt.Println()
+		return nil
+	}
+
+	for _, r := range results {
+		version := ""
+		if r.Version != "" {
+			version = ui.Muted.Render("v" + r.Version)
+		}
+		fmt.Printf("  %s %s  %s\n", ui.Accent.Render(r.Name), version, ui.Muted.Render("by "+r.Author))
+		if r.Description != "" {
+			fmt.Printf("    %s\n", r.Description)
+		}
+		fmt.Printf("    %s\n", ui.Muted.Render(strings.Join(plugin.PermissionSummary(r.Permissions), "; ")))
+		fmt.Printf("    %s\n", ui.Muted.Render("$ "+r.InstallCommand()))
+		fmt.Println()
+	}
+
+	fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("%d results", len(results))))
+	fmt.Println()
+	return nil
+}
+
+func runPluginNew(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	lang, _ := cmd.Flags().GetString("lang")
+	dir, _ := cmd.Flags().GetString("dir")
+	author, _ := cmd.Flags().GetString("author")
+	if dir == "" {
+		dir = "mine-plugin-" + name
+	}
+	if author == "" {
+		if cfg, err := config.Load(); err == nil {
+			author = cfg.User.Name
+		}
+	}
+	if author == "" {
+		author = "your-name"
+	}
+
+	created, err := plugin.Scaffold(dir, name, lang, author)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Created %s plugin %s in %s", lang, ui.Accent.Render(name), dir))
+	for _, f := range created {
+		fmt.Printf("    %s\n", ui.Muted.Render(f))
+	}
+	fmt.Println()
+	fmt.Println(ui.Subtitle.Render("  Next:"))
+	fmt.Printf("    cd %s\n", dir)
+	if lang == "go" {
+		fmt.Println("    go test ./... && go build -o mine-plugin-" + name + " .")
+	} else {
+		fmt.Println("    ./test.sh")
+	}
+	fmt.Printf("    mine plugin install . && mine %s hello\n", name)
+	fmt.Println()
+	return nil
+}
+
 func runPluginRemove(_ *cobra.Command, args []string) error {
 	name := args[0]
 
@@ -257,6 +411,9 @@ func runPluginSearch(_ *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		query = args[0]
 	}
+	if !pluginSearchGitHub {
+		return searchPluginIndex(query)
+	}
 
 	fmt.Println()
 	if query != "" {
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/plugin"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var pluginConfigCmd = &cobra.Command{
+	Use:   "config <name> [get <key> | set <key> <value> | unset <key>]",
+	Short: "Show or change a plugin's settings",
+	Long: `Show or change the settings a plugin declares in the [[config]] section of
+its manifest. Values are checked against the declared type and choices, and
+sent to the plugin with each invocation.
+
+With just a name, lists the plugin's settings and their values.
+
+Examples:
+  mine plugin config obsidian
+  mine plugin config obsidian set vault ~/notes
+  mine plugin config obsidian get vault
+  mine plugin config obsidian unset vault`,
+	Args: cobra.RangeArgs(1, 4),
+	RunE: hook.Wrap("plugin.config", runPluginConfig),
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginConfigCmd)
+}
+
+func runPluginConfig(_ *cobra.Command, args []string) error {
+	name := args[0]
+	p, err := plugin.Get(name)
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		printPluginConfig(p)
+		return nil
+	}
+
+	action, rest := args[1], args[2:]
+	switch {
+	case action == "get" && len(rest) == 1:
+		value, _, err := p.ConfigValue(rest[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+
+	case action == "set" && len(rest) == 2:
+		key, value := rest[0], rest[1]
+		if err := plugin.SetConfig(name, key, value); err != nil {
+			return err
+		}
+		// Values can be secrets, so only the key is audited.
+		if err := plugin.AuditLog(name, "config", "set="+key); err != nil {
+			log.Printf("warning: audit log: %v", err)
+		}
+		ui.Ok(fmt.Sprintf("Set %s %s to %s", name, key, value))
+		return nil
+
+	case action == "unset" && len(rest) == 1:
+		key := rest[0]
+		if err := plugin.UnsetConfig(name, key); err != nil {
+			return err
+		}
+		if err := plugin.AuditLog(name, "config", "unset="+key); err != nil {
+			log.Printf("warning: audit log: %v", err)
+		}
+		ui.Ok(fmt.Sprintf("Unset %s %s", name, key))
+		return nil
+	}
+	return fmt.Errorf("usage: mine plugin config <name> [get <key> | set <key> <value> | unset <key>]")
+}
+
+// printPluginConfig lists the settings p declares, with their values.
+func printPluginConfig(p *plugin.InstalledPlugin) {
+	m := p.Manifest
+	fmt.Println()
+	if len(m.Config) == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  %s has no settings.", m.Plugin.Name)))
+		fmt.Println()
+		return
+	}
+
+	fmt.Println(ui.Title.Render(fmt.Sprintf("  %s settings", m.Plugin.Name)))
+	fmt.Println()
+	for _, o := range m.Config {
+		value, ok, _ := p.ConfigValue(o.Name)
+		_, set := p.Config[o.Name]
+		switch {
+		case !ok && o.Required:
+			value = ui.Warning.Render("(required, not set)")
+		case !ok:
+			value = ui.Muted.Render("(not set)")
+		case !set:
+			value += ui.Muted.Render(" (default)")
+		}
+		fmt.Printf("  %s %s\n", ui.Accent.Render(fmt.Sprintf("%-20s", o.Name)), value)
+
+		meta := o.Kind()
+		if len(o.Choices) > 0 {
+			meta = "one of " + strings.Join(o.Choices, ", ")
+		}
+		if o.Description != "" {
+			meta = o.Description + " (" + meta + ")"
+		}
+		fmt.Printf("    %s\n", ui.Muted.Render(meta))
+	}
+	fmt.Println()
+	fmt.Printf("  Change: %s\n", ui.Accent.Render(fmt.Sprintf("mine plugin config %s set <key> <value>", m.Plugin.Name)))
+	fmt.Println()
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/plugin"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var pluginUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [name]",
+	Short: "Upgrade plugins installed from git",
+	Long: `Upgrade a plugin installed from a git repository to its newest release
+within the version range it was installed with, or every such plugin when no
+name is given. Plugins pinned to an exact version or commit are left alone.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("plugin.upgrade", runPluginUpgrade),
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginUpgradeCmd)
+
+	pluginUpgradeCmd.Flags().Bool("insecure", false, "Upgrade even if the new version's signature doesn't verify")
+}
+
+func runPluginUpgrade(cmd *cobra.Command, args []string) error {
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	names := args
+	if len(names) == 0 {
+		var err error
+		if names, err = plugin.Upgradable(); err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Println()
+			fmt.Println(ui.Muted.Render("  No plugins installed from git."))
+			fmt.Println()
+			return nil
+		}
+	}
+
+	fmt.Println()
+	for _, name := range names {
+		if err := upgradePlugin(name, insecure); err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// upgradePlugin upgrades one plugin, asking first if the new version wants
+// permissions the installed one doesn't have. insecure skips signature
+// verification, as for install.
+func upgradePlugin(name string, insecure bool) error {
+	current, err := plugin.Get(name)
+	if err != nil {
+		return err
+	}
+	co, err := plugin.FetchUpgrade(name)
+	if err != nil {
+		return err
+	}
+	if co == nil {
+		ui.Ok(fmt.Sprintf("%s v%s is up to date", name, current.Manifest.Plugin.Version))
+		return nil
+	}
+	defer co.Cleanup()
+	co.Insecure = insecure
+
+	manifest, err := plugin.ParseManifest(filepath.Join(co.Dir, "mine-plugin.toml"))
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if escalations := plugin.HasEscalation(current.Manifest.Permissions, manifest.Permissions); len(escalations) > 0 {
+		ui.Warn(fmt.Sprintf("%s v%s asks for more access:", name, manifest.Plugin.Version))
+		for _, line := range escalations {
+			fmt.Printf("    %s\n", line)
+		}
+		if !confirmPrompt("Upgrade anyway?") {
+			ui.Warn(fmt.Sprintf("Skipped %s.", name))
+			return nil
+		}
+	}
+
+	p, err := co.Install()
+	if err != nil {
+		return err
+	}
+	detail := fmt.Sprintf("version=%s from=%s", p.Manifest.Plugin.Version, current.Manifest.Plugin.Version)
+	if err := plugin.AuditLog(name, "upgrade", detail); err != nil {
+		log.Printf("warning: audit log: %v", err)
+	}
+	ui.Ok(fmt.Sprintf("Upgraded %s v%s → v%s", name, current.Manifest.Plugin.Version, p.Manifest.Plugin.Version))
+	return nil
+}
+
+// printPluginSignature shows who signed the plugin m describes, if anyone.
+func printPluginSignature(m *plugin.Manifest) {
+	if m.Signature.Signed() {
+		fmt.Printf("  %s %s\n", ui.Subtitle.Render("Signed by:"), plugin.KeyFingerprint(m.Signature.PublicKey))
+	} else {
+		fmt.Printf("  %s\n", ui.Muted.Render("Not signed — its checksum is still checked before each run."))
+	}
+	fmt.Println()
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rnwolfe/mine/internal/config"
 	"github.com/rnwolfe/mine/internal/hook"
 	"github.com/rnwolfe/mine/internal/proj"
 	"github.com/rnwolfe/mine/internal/store"
@@ -19,9 +20,15 @@ import (
 var (
 	projRmYes      bool
 	projScanDepth  int
+	projScanRoots  []string
+	projScanYes    bool
 	projUsePrev    bool
 	projPrintPath  bool
 	projConfigName string
+
+	projAddTodoTags     string
+	projAddTodoPriority string
+	projAddTodoSchedule string
 )
 
 var projCmd = &cobra.Command{
@@ -42,8 +49,13 @@ func init() {
 	projCmd.AddCommand(projScanCmd)
 	projCmd.AddCommand(projConfigCmd)
 
+	projAddCmd.Flags().StringVar(&projAddTodoTags, "todo-tags", "", "Comma-separated tags added to todos created in this project")
+	projAddCmd.Flags().StringVar(&projAddTodoPriority, "todo-priority", "", "Default priority for todos created in this project")
+	projAddCmd.Flags().StringVar(&projAddTodoSchedule, "todo-schedule", "", "Default schedule bucket for todos created in this project")
 	projRmCmd.Flags().BoolVarP(&projRmYes, "yes", "y", false, "Skip confirmation prompt")
 	projScanCmd.Flags().IntVar(&projScanDepth, "depth", 3, "Scan recursion depth")
+	projScanCmd.Flags().StringSliceVar(&projScanRoots, "root", nil, "Directory to search (repeatable; defaults to proj.scan_roots)")
+	projScanCmd.Flags().BoolVarP(&projScanYes, "yes", "y", false, "Register found repos without prompting")
 	projOpenCmd.Flags().BoolVar(&projUsePrev, "previous", false, "Open previously active project")
 	projOpenCmd.Flags().BoolVar(&projPrintPath, "print-path", false, "Print resolved path only")
 	projCmd.Flags().BoolVar(&projPrintPath, "print-path", false, "Print selected path only (for shell helpers)")
@@ -78,17 +90,14 @@ func runProj(_ *cobra.Command, _ []string) error {
 		return printProjectList(projects)
 	}
 
-	items := make([]tui.Item, len(projects))
-	for i := range projects {
-		items[i] = projects[i]
-	}
+	items, boost := projectPickerItems(projects)
 	pickerTitle := tui.WithTitle(ui.IconMine + "Select project")
 	pickerHeight := tui.WithHeight(12)
 	var chosen tui.Item
 	if projPrintPath {
-		chosen, err = tui.RunWithOutput(items, os.Stderr, pickerTitle, pickerHeight)
+		chosen, err = tui.RunWithOutput(items, os.Stderr, pickerTitle, pickerHeight, boost)
 	} else {
-		chosen, err = tui.Run(items, pickerTitle, pickerHeight)
+		chosen, err = tui.Run(items, pickerTitle, pickerHeight, boost)
 	}
 	if err != nil {
 		return err
@@ -117,8 +126,16 @@ func runProj(_ *cobra.Command, _ []string) error {
 var projAddCmd = &cobra.Command{
 	Use:   "add [path]",
 	Short: "Register a directory as a project",
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  hook.Wrap("proj.add", runProjAdd),
+	Long: `Register a directory as a project.
+
+Todo defaults apply to every todo added inside the project; flags on
+'mine todo add' still win, and tags are merged:
+
+  mine proj add ~/work/api --todo-tags api --todo-priority high
+
+Change them later with 'mine proj config todo_tags|todo_priority|todo_schedule <value>'.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("proj.add", runProjAdd),
 }
 
 func runProjAdd(_ *cobra.Command, args []string) error {
@@ -133,14 +150,38 @@ func runProjAdd(_ *cobra.Command, args []string) error {
 	}
 	defer db.Close()
 
+	todoDefaults := []struct{ key, value string }{
+		{"todo_tags", projAddTodoTags},
+		{"todo_priority", projAddTodoPriority},
+		{"todo_schedule", projAddTodoSchedule},
+	}
+	for _, d := range todoDefaults {
+		if err := proj.ValidateSetting(d.key, d.value); err != nil {
+			return err
+		}
+	}
+
 	ps := proj.NewStore(db.Conn())
 	p, err := ps.Add(path)
 	if err != nil {
 		return err
 	}
+	for _, d := range todoDefaults {
+		if d.value == "" {
+			continue
+		}
+		if err := ps.SetSetting(p.Name, d.key, d.value); err != nil {
+			return err
+		}
+	}
 
 	ui.Ok(fmt.Sprintf("Registered project %s", ui.Accent.Render(p.Name)))
 	fmt.Printf("  Path: %s\n", ui.Muted.Render(p.Path))
+	for _, d := range todoDefaults {
+		if d.value != "" {
+			fmt.Printf("  %s: %s\n", d.key, ui.Muted.Render(d.value))
+		}
+	}
 	fmt.Println()
 	return nil
 }
@@ -224,9 +265,17 @@ func runProjList(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	projects, err = filterProjectsByGroup(ps, projects, projGroupFlag)
+	if err != nil {
+		return err
+	}
 	if len(projects) == 0 {
 		fmt.Println()
-		fmt.Println(ui.Muted.Render("  No projects registered yet."))
+		if projGroupFlag != "" {
+			fmt.Println(ui.Muted.Render(fmt.Sprintf("  No projects in group %q.", projGroupFlag)))
+		} else {
+			fmt.Println(ui.Muted.Render("  No projects registered yet."))
+		}
 		fmt.Println()
 		return nil
 	}
@@ -287,14 +336,30 @@ func runProjOpen(_ *cobra.Command, args []string) error {
 var projScanCmd = &cobra.Command{
 	Use:   "scan [dir]",
 	Short: "Discover and register all git repos under a directory",
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  hook.Wrap("proj.scan", runProjScan),
+	Long: `Find git repositories that aren't registered yet and register them in bulk.
+
+Searches dir, each --root, or — when neither is given — the roots configured
+in proj.scan_roots, falling back to the current directory. Found repos are
+listed and registered after confirmation; pass --yes to skip the prompt.
+
+  mine proj scan --root ~/code --root ~/work
+  mine config set proj.scan_roots "~/code,~/work"
+  mine proj scan --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("proj.scan", runProjScan),
 }
 
 func runProjScan(_ *cobra.Command, args []string) error {
-	dir := "."
-	if len(args) > 0 {
-		dir = args[0]
+	roots := append(append([]string{}, args...), projScanRoots...)
+	if len(roots) == 0 {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		roots = cfg.Proj.ScanRoots
+	}
+	if len(roots) == 0 {
+		roots = []string{"."}
 	}
 
 	db, err := store.Open()
@@ -304,22 +369,60 @@ func runProjScan(_ *cobra.Command, args []string) error {
 	defer db.Close()
 
 	ps := proj.NewStore(db.Conn())
-	added, err := ps.Scan(dir, projScanDepth)
-	if err != nil {
-		return err
+	var found []string
+	seen := map[string]bool{}
+	for _, root := range roots {
+		paths, err := ps.Discover(root, projScanDepth)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			if !seen[path] {
+				seen[path] = true
+				found = append(found, path)
+			}
+		}
 	}
 
-	if len(added) == 0 {
+	if len(found) == 0 {
 		fmt.Println()
 		fmt.Println(ui.Muted.Render("  No new git projects discovered."))
 		fmt.Println()
 		return nil
 	}
 
-	ui.Ok(fmt.Sprintf("Added %d projects", len(added)))
-	for _, p := range added {
-		fmt.Printf("  %s %s\n", ui.Success.Render("●"), ui.Muted.Render(p.Path))
+	fmt.Println()
+	fmt.Printf("  Found %d unregistered git repo(s):\n", len(found))
+	for _, path := range found {
+		fmt.Printf("  %s %s\n", ui.Muted.Render("○"), path)
+	}
+	fmt.Println()
+
+	if !projScanYes {
+		if !tui.IsTTY() {
+			return fmt.Errorf("non-interactive mode requires --yes to register projects")
+		}
+		if !confirmPrompt(fmt.Sprintf("Register %d project(s)?", len(found))) {
+			ui.Warn("Cancelled.")
+			return nil
+		}
+	}
+
+	var added int
+	for _, path := range found {
+		p, err := ps.Add(path)
+		if err != nil {
+			fmt.Printf("  %s %s %s\n", ui.Warning.Render("!"), path, ui.Muted.Render(err.Error()))
+			continue
+		}
+		added++
+		fmt.Printf("  %s %s %s\n", ui.Success.Render("●"), ui.Accent.Render(p.Name), ui.Muted.Render(p.Path))
+	}
+	fmt.Println()
+	if added == 0 {
+		return fmt.Errorf("no projects registered")
 	}
+	ui.Ok(fmt.Sprintf("Added %d projects", added))
 	ui.Tip(fmt.Sprintf("jump in with %s or %s", ui.Accent.Render("p <name>"), ui.Accent.Render("mine proj open <name>")))
 	fmt.Println()
 	return nil
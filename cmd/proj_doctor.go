@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/env"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projDoctorStaleDays int
+	projDoctorDirtyDays int
+)
+
+// projEnvFiles are files suggesting a project expects environment variables.
+var projEnvFiles = []string{".env", ".env.example", ".env.sample", ".env.local"}
+
+func init() {
+	projCmd.AddCommand(projDoctorCmd)
+	projDoctorCmd.Flags().IntVar(&projDoctorStaleDays, "stale-days", 90, "Flag branches with no commits for this many days")
+	projDoctorCmd.Flags().IntVar(&projDoctorDirtyDays, "dirty-days", 7, "Flag uncommitted changes older than this many days")
+}
+
+var projDoctorCmd = &cobra.Command{
+	Use:   "doctor [name]",
+	Short: "Check projects for missing dirs, stale branches, and forgotten changes",
+	Long: `Check every registered project (or just one) and report what needs attention:
+
+  - the directory no longer exists
+  - the repo has no git remote
+  - local branches with no commits for --stale-days (default 90)
+  - uncommitted changes untouched for --dirty-days (default 7)
+  - .env files or an env_file setting but no mine env profiles`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("proj.doctor", runProjDoctor),
+}
+
+func runProjDoctor(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	var projects []proj.Project
+	if len(args) > 0 {
+		p, err := ps.Get(args[0])
+		if err != nil {
+			return err
+		}
+		projects = []proj.Project{*p}
+	} else if projects, err = ps.List(); err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No projects registered yet."))
+		fmt.Printf("  Add one: %s\n", ui.Accent.Render("mine proj add ."))
+		fmt.Println()
+		return nil
+	}
+
+	opts := proj.DoctorOptions{StaleBranchDays: projDoctorStaleDays, DirtyDays: projDoctorDirtyDays, Now: time.Now()}
+	em := env.New(db.Conn(), "")
+
+	unhealthy := 0
+	fmt.Println()
+	for _, p := range projects {
+		findings := proj.Diagnose(p, opts)
+		if f, ok := projEnvFinding(ps, em, p); ok {
+			findings = append(findings, f)
+		}
+		if len(findings) == 0 {
+			fmt.Printf("  %s %s\n", ui.Success.Render(ui.IconOk), ui.Accent.Render(p.Name))
+			continue
+		}
+		unhealthy++
+		fmt.Printf("  %s %s  %s\n", ui.Warning.Render(ui.IconWarn), ui.Accent.Render(p.Name), ui.Muted.Render(p.Path))
+		for _, f := range findings {
+			fmt.Printf("      %s %s\n", ui.KeyStyle.Render(fmt.Sprintf("%-12s", f.Check)), f.Message)
+			if f.Hint != "" {
+				fmt.Printf("      %s %s\n", fmt.Sprintf("%-12s", ""), ui.Muted.Render("→ "+f.Hint))
+			}
+		}
+	}
+	fmt.Println()
+
+	if unhealthy > 0 {
+		return fmt.Errorf("%d of %d project(s) need attention", unhealthy, len(projects))
+	}
+	ui.Ok("All projects look healthy.")
+	fmt.Println()
+	return nil
+}
+
+// projEnvFinding flags a project that looks like it needs env vars — it has
+// .env files or an env_file setting — but has no mine env profiles.
+func projEnvFinding(ps *proj.Store, em *env.Manager, p proj.Project) (proj.Finding, bool) {
+	hint := ""
+	if envFile, _ := ps.GetSetting(p.Name, "env_file"); envFile != "" {
+		hint = envFile
+	} else if i := slices.IndexFunc(projEnvFiles, func(name string) bool {
+		_, err := os.Stat(filepath.Join(p.Path, name))
+		return err == nil
+	}); i >= 0 {
+		hint = projEnvFiles[i]
+	}
+	if hint == "" {
+		return proj.Finding{}, false
+	}
+	profiles, err := em.ListProfiles(p.Path)
+	if err != nil || len(profiles) > 0 {
+		return proj.Finding{}, false
+	}
+	return proj.Finding{
+		Check:   "env",
+		Message: fmt.Sprintf("uses %s but has no mine env profiles", hint),
+		Hint:    fmt.Sprintf("cd %s && mine env set KEY=VALUE", p.Path),
+	}, true
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var projGroupFlag string
+
+func init() {
+	projCmd.AddCommand(projGroupCmd)
+	projGroupCmd.AddCommand(projGroupCreateCmd)
+	projGroupCmd.AddCommand(projGroupAddCmd)
+	projGroupCmd.AddCommand(projGroupListCmd)
+
+	projListCmd.Flags().StringVar(&projGroupFlag, "group", "", "Only list projects in this group")
+	projStatusCmd.Flags().StringVar(&projGroupFlag, "group", "", "Only show projects in this group")
+}
+
+var projGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Group projects into workspaces like work or oss",
+	Long: `Group projects so commands can work across several at once.
+
+  mine proj group create work
+  mine proj group add work api web
+  mine todo --group work
+  mine todo stats --group work`,
+	RunE: hook.Wrap("proj.group", runProjGroupList),
+}
+
+var projGroupCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty project group",
+	Args:  cobra.ExactArgs(1),
+	RunE:  hook.Wrap("proj.group.create", runProjGroupCreate),
+}
+
+var projGroupAddCmd = &cobra.Command{
+	Use:   "add <group> <project>...",
+	Short: "Add registered projects to a group",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  hook.Wrap("proj.group.add", runProjGroupAdd),
+}
+
+var projGroupListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List project groups and their members",
+	Args:    cobra.NoArgs,
+	RunE:    hook.Wrap("proj.group.list", runProjGroupList),
+}
+
+func runProjGroupCreate(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := proj.NewStore(db.Conn()).CreateGroup(args[0]); err != nil {
+		return err
+	}
+	ui.Ok(fmt.Sprintf("Created group %s", ui.Accent.Render(args[0])))
+	fmt.Printf("  Add projects: %s\n", ui.Accent.Render("mine proj group add "+args[0]+" <project>..."))
+	fmt.Println()
+	return nil
+}
+
+func runProjGroupAdd(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	group, names := args[0], args[1:]
+	added, err := proj.NewStore(db.Conn()).AddToGroup(group, names...)
+	if err != nil {
+		return err
+	}
+	for i, name := range names {
+		if added[i] {
+			fmt.Printf("  %s Added %s to %s\n", ui.Success.Render(ui.IconCheck), ui.Accent.Render(name), group)
+		} else {
+			fmt.Printf("  %s %s is already in %s\n", ui.Muted.Render("·"), name, group)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func runProjGroupList(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	groups, err := proj.NewStore(db.Conn()).Groups()
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No project groups yet."))
+		fmt.Printf("  Create one: %s\n", ui.Accent.Render("mine proj group create work"))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	for _, g := range groups {
+		members := ui.Muted.Render("(empty)")
+		if len(g.Projects) > 0 {
+			members = strings.Join(g.Projects, ", ")
+		}
+		fmt.Printf("  %s  %s\n", ui.Accent.Render(fmt.Sprintf("%-12s", g.Name)), members)
+	}
+	fmt.Println()
+	return nil
+}
+
+// filterProjectsByGroup keeps the projects whose path is in the group.
+// An empty group name keeps everything.
+func filterProjectsByGroup(ps *proj.Store, projects []proj.Project, group string) ([]proj.Project, error) {
+	if group == "" {
+		return projects, nil
+	}
+	paths, err := ps.GroupPaths(group)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(projects, func(p proj.Project) bool {
+		return !slices.Contains(paths, p.Path)
+	}), nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projImportFrom     string
+	projImportLimit    int
+	projImportMinScore float64
+	projImportYes      bool
+)
+
+func init() {
+	projCmd.AddCommand(projImportCmd)
+	projImportCmd.Flags().StringVar(&projImportFrom, "from", "", "History to import from: zoxide or autojump")
+	projImportCmd.Flags().IntVarP(&projImportLimit, "limit", "n", 20, "Offer at most this many repos (0 for all)")
+	projImportCmd.Flags().Float64Var(&projImportMinScore, "min-score", 0, "Skip repos scored below this")
+	projImportCmd.Flags().BoolVarP(&projImportYes, "yes", "y", false, "Register found repos without prompting")
+	_ = projImportCmd.MarkFlagRequired("from")
+}
+
+var projImportCmd = &cobra.Command{
+	Use:   "import --from <zoxide|autojump>",
+	Short: "Register the git repos you visit most, from zoxide or autojump",
+	Long: `Bootstrap the registry from your jump history. Every directory zoxide or
+autojump knows about is mapped to the git repo containing it, scores are
+added up per repo, and the unregistered repos you visit most are offered
+for registration.
+
+  mine proj import --from zoxide
+  mine proj import --from autojump --limit 10 --yes`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("proj.import", runProjImport),
+}
+
+func runProjImport(_ *cobra.Command, _ []string) error {
+	history, err := proj.ReadHistory(projImportFrom)
+	if err != nil {
+		return err
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	candidates, err := ps.ImportCandidates(history)
+	if err != nil {
+		return err
+	}
+	found := candidates[:0]
+	for _, c := range candidates {
+		if c.Score >= projImportMinScore {
+			found = append(found, c)
+		}
+	}
+	if projImportLimit > 0 && len(found) > projImportLimit {
+		found = found[:projImportLimit]
+	}
+
+	if len(found) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  No unregistered git repos in your %s history.", projImportFrom)))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("  Your most visited unregistered repos, from %s:\n", projImportFrom)
+	for _, c := range found {
+		fmt.Printf("  %s %s %s\n", ui.Muted.Render("○"), c.Path, ui.Muted.Render(fmt.Sprintf("(%.0f)", c.Score)))
+	}
+	fmt.Println()
+
+	if !projImportYes {
+		if !tui.IsTTY() {
+			return fmt.Errorf("non-interactive mode requires --yes to register projects")
+		}
+		if !confirmPrompt(fmt.Sprintf("Register %d project(s)?", len(found))) {
+			ui.Warn("Cancelled.")
+			return nil
+		}
+	}
+
+	var added int
+	for _, c := range found {
+		p, err := ps.Add(c.Path)
+		if err != nil {
+			fmt.Printf("  %s %s %s\n", ui.Warning.Render("!"), c.Path, ui.Muted.Render(err.Error()))
+			continue
+		}
+		added++
+		fmt.Printf("  %s %s %s\n", ui.Success.Render("●"), ui.Accent.Render(p.Name), ui.Muted.Render(p.Path))
+	}
+	fmt.Println()
+	if added == 0 {
+		return fmt.Errorf("no projects registered")
+	}
+	ui.Ok(fmt.Sprintf("Added %d projects", added))
+	ui.Tip(fmt.Sprintf("jump in with %s or %s", ui.Accent.Render("p <name>"), ui.Accent.Render("mine proj open <name>")))
+	fmt.Println()
+	return nil
+}
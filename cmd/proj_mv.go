@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rnwolfe/mine/internal/env"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	projCmd.AddCommand(projMvCmd)
+}
+
+var projMvCmd = &cobra.Command{
+	Use:   "mv <name> <new-path-or-name>",
+	Short: "Move or rename a project without orphaning its data",
+	Long: `Rename a project, or point it at a new directory.
+
+A target containing a slash or starting with "." or "~" is a path:
+
+  mine proj mv api ~/work/api     # moves the directory if it's still at the old path
+  mine proj mv api ../api         # or records a move you already made
+
+Anything else is a new name:
+
+  mine proj mv api api-v1
+
+Todos (including archived ones and their dig sessions), env profiles,
+notes, groups, and per-project settings all follow the project.`,
+	Args: cobra.ExactArgs(2),
+	RunE: hook.Wrap("proj.mv", runProjMv),
+}
+
+func runProjMv(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	res, err := ps.Move(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	// Todos and env profiles are keyed by path; a move that strands them is
+	// undone, so the project is never left split across two paths.
+	moved := 0
+	if res.NewPath != res.OldPath {
+		ts := todo.NewStore(db.Conn())
+		if moved, err = ts.MoveProject(res.OldPath, res.NewPath); err != nil {
+			return revertProjMove(ps, res, fmt.Errorf("moving todos: %w", err))
+		}
+		if err := env.New(db.Conn(), "").MoveProject(res.OldPath, res.NewPath); err != nil {
+			if _, revertErr := ts.MoveProject(res.NewPath, res.OldPath); revertErr != nil {
+				return fmt.Errorf("moving env profiles: %w (moving todos back also failed: %v)", err, revertErr)
+			}
+			return revertProjMove(ps, res, fmt.Errorf("moving env profiles: %w", err))
+		}
+	}
+
+	fmt.Println()
+	if res.NewName != res.OldName {
+		fmt.Printf("  %s Renamed %s → %s\n", ui.Success.Render(ui.IconCheck), res.OldName, ui.Accent.Render(res.NewName))
+	}
+	if res.NewPath == res.OldPath {
+		if res.NewName == res.OldName {
+			fmt.Println(ui.Muted.Render("  Nothing to do — that's where it already is."))
+		}
+		fmt.Println()
+		return nil
+	}
+
+	verb := "Recorded move of"
+	if res.MovedOnDisk {
+		verb = "Moved"
+	}
+	fmt.Printf("  %s %s %s\n", ui.Success.Render(ui.IconCheck), verb, ui.Accent.Render(res.NewName))
+	fmt.Printf("    %s\n", ui.Muted.Render(res.OldPath+" → "+res.NewPath))
+	if moved > 0 {
+		fmt.Printf("  %s Moved %d todo(s)\n", ui.Success.Render(ui.IconCheck), moved)
+	}
+	fmt.Println()
+	return nil
+}
+
+// revertProjMove undoes res after a later step failed with err, and returns
+// err noting whether the project was put back.
+func revertProjMove(ps *proj.Store, res *proj.MoveResult, err error) error {
+	if revertErr := ps.Revert(res); revertErr != nil {
+		return fmt.Errorf("%w (undoing the move also failed: %v)", err, revertErr)
+	}
+	return fmt.Errorf("%w — the project was left at %s", err, res.OldPath)
+}
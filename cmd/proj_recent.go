@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var projRecentLimit int
+
+func init() {
+	projCmd.AddCommand(projRecentCmd)
+	projRecentCmd.Flags().IntVarP(&projRecentLimit, "limit", "n", 10, "Number of projects to show (0 for all)")
+}
+
+var projRecentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List recently used projects",
+	Long: `List projects by when you last used them — opened, switched to, or
+worked in via todo, dig, or dash. Project pickers favor the same order.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("proj.recent", runProjRecent),
+}
+
+func runProjRecent(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	projects, err := proj.NewStore(db.Conn()).Recent(projRecentLimit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(projects) == 0 {
+		fmt.Println(ui.Muted.Render("  No recently used projects."))
+		fmt.Printf("  Open one: %s\n", ui.Accent.Render("mine proj open <name>"))
+		fmt.Println()
+		return nil
+	}
+
+	width := 0
+	for _, p := range projects {
+		width = max(width, len(p.Name))
+	}
+	now := time.Now()
+	for _, p := range projects {
+		fmt.Printf("  %s  %s  %s\n",
+			ui.Accent.Render(padRight(p.Name, width)),
+			padRight(todoTimeAgo(p.LastAccessed, now), 14),
+			ui.Muted.Render(p.Path))
+	}
+	fmt.Println()
+	return nil
+}
+
+// projectPickerItems orders projects most recently used first and returns
+// them as picker items, with an option that favors recent projects when
+// filtering.
+func projectPickerItems(projects []proj.Project) ([]tui.Item, tui.PickerOption) {
+	proj.SortByRecent(projects)
+	items := make([]tui.Item, len(projects))
+	for i := range projects {
+		items[i] = projects[i]
+	}
+	now := time.Now()
+	boost := tui.WithBoost(func(it tui.Item) int {
+		return proj.RecencyBoost(it.(proj.Project).LastAccessed, now)
+	})
+	return items, boost
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/dig"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Limits for each section of the proj show card.
+const (
+	projShowNoteLimit    = 5
+	projShowTodoLimit    = 3
+	projShowSessionLimit = 3
+	projShowReadmeLines  = 3
+)
+
+func init() {
+	projCmd.AddCommand(projNoteCmd)
+	projCmd.AddCommand(projShowCmd)
+}
+
+var projNoteCmd = &cobra.Command{
+	Use:   "note <name> <text>",
+	Short: "Add a freeform note to a project",
+	Long: `Attach a timestamped note to a project — deploy quirks, who to ask,
+where the staging creds live. Notes show up in 'mine proj show'.
+
+  mine proj note api "deploys from the release branch, not main"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: hook.Wrap("proj.note", runProjNote),
+}
+
+var projShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a project's detail card",
+	Long: `Show everything mine knows about a project in one card: path, branch,
+the opening lines of its README, notes, open todos, recent dig sessions, and
+stashed files that live inside it.
+
+Without a name, shows the project containing the current directory.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("proj.show", runProjShow),
+}
+
+func runProjNote(_ *cobra.Command, args []string) error {
+	text := strings.Join(args[1:], " ")
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := proj.NewStore(db.Conn()).AddNote(args[0], text); err != nil {
+		return err
+	}
+
+	preview := strings.TrimSpace(text)
+	if len(preview) > 60 {
+		preview = preview[:57] + "…"
+	}
+	fmt.Printf("  %s Note added to %s — %q\n", ui.Success.Render(ui.IconCheck), ui.Accent.Render(args[0]), preview)
+	fmt.Println()
+	return nil
+}
+
+func runProjShow(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	var p *proj.Project
+	if len(args) > 0 {
+		p, err = ps.Get(args[0])
+	} else {
+		p, err = ps.FindForCWD()
+		if err == nil && p == nil {
+			return fmt.Errorf("not inside a registered project — pass a name or run %s", ui.Accent.Render("mine proj add ."))
+		}
+		if err == nil {
+			p, err = ps.Get(p.Name)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	_ = ps.Touch(p.Name)
+
+	notes, err := ps.Notes(p.Name)
+	if err != nil {
+		return err
+	}
+	todos, err := todo.NewStore(db.Conn()).List(todo.ListOptions{ProjectPaths: []string{p.Path}, CurrentProjectPath: &p.Path})
+	if err != nil {
+		return err
+	}
+	// Focus sessions and stash history are extras; a failure there shouldn't
+	// hide the rest of the card.
+	sessions, _ := dig.NewStore(db.Conn()).RecentForProject(p.Path, projShowSessionLimit)
+
+	now := time.Now()
+	fmt.Println()
+	fmt.Printf("  %s %s\n", ui.IconProject, ui.Title.Render(p.Name))
+	fmt.Printf("  %s\n", ui.Muted.Render(p.Path))
+	if p.Branch != "" {
+		fmt.Printf("  %s\n", ui.Muted.Render("Branch: "+p.Branch))
+	}
+	if !p.LastAccessed.IsZero() {
+		fmt.Printf("  %s\n", ui.Muted.Render("Opened "+todoTimeAgo(p.LastAccessed, now)))
+	}
+
+	if readme := readmeExcerpt(p.Path, projShowReadmeLines); len(readme) > 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  README:"))
+		for _, line := range readme {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+
+	if len(notes) > 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  Notes:"))
+		for i, n := range notes {
+			if i == projShowNoteLimit {
+				fmt.Printf("    %s\n", ui.Muted.Render(fmt.Sprintf("…and %d older", len(notes)-i)))
+				break
+			}
+			fmt.Printf("    %s  %s\n", ui.Muted.Render(n.CreatedAt.Local().Format("2006-01-02 15:04")), n.Body)
+		}
+	}
+
+	fmt.Println()
+	if len(todos) == 0 {
+		fmt.Println(ui.Muted.Render("  Todos: none open"))
+	} else {
+		overdue := 0
+		for _, t := range todos {
+			if t.IsOverdue(now) {
+				overdue++
+			}
+		}
+		summary := fmt.Sprintf("%d open", len(todos))
+		if overdue > 0 {
+			summary += ", " + ui.Warning.Render(fmt.Sprintf("%d overdue", overdue))
+		}
+		fmt.Printf("  %s %s\n", ui.Muted.Render("Todos:"), summary)
+		for i, t := range todos {
+			if i == projShowTodoLimit {
+				break
+			}
+			fmt.Printf("    %s %s %s\n", ui.Muted.Render(fmt.Sprintf("#%-4d", t.ID)), todo.PriorityIcon(t.Priority), t.Title)
+		}
+	}
+
+	if len(sessions) > 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  Recent dig sessions:"))
+		for _, s := range sessions {
+			fmt.Printf("    %s  %-6s %s\n", ui.Muted.Render(todoTimeAgo(s.StartedAt, now)), todo.FormatEstimate(s.Duration), s.TodoTitle)
+		}
+	}
+
+	if files := projStashedFiles(p.Path); len(files) > 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  Stashed files:"))
+		for _, f := range files {
+			fmt.Printf("    %s\n", f)
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// readmeExcerpt returns up to n lines of the first prose paragraph of the
+// project's README, skipping headings, badges, and HTML.
+func readmeExcerpt(dir string, n int) []string {
+	var f *os.File
+	for _, name := range []string{"README.md", "README", "README.txt", "readme.md"} {
+		var err error
+		if f, err = os.Open(filepath.Join(dir, name)); err == nil {
+			break
+		}
+	}
+	if f == nil {
+		return nil
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			if len(lines) > 0 {
+				return lines
+			}
+		case len(lines) == 0 && (strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[![") ||
+			strings.HasPrefix(line, "![") || strings.HasPrefix(line, "<") || strings.HasPrefix(line, "---") || strings.HasPrefix(line, "===")):
+		default:
+			lines = append(lines, line)
+			if len(lines) == n {
+				return lines
+			}
+		}
+	}
+	return lines
+}
+
+// projStashedFiles lists stashed files under the project, each with when it
+// was last committed to the stash.
+func projStashedFiles(projectPath string) []string {
+	entries, err := stash.ReadManifest()
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	var files []string
+	for _, e := range entries {
+		rel, err := filepath.Rel(projectPath, e.Source)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+		line := rel
+		if log, err := stash.Log(e.Source); err == nil && len(log) > 0 {
+			line += "  " + ui.Muted.Render("committed "+todoTimeAgo(log[0].Date, now))
+		}
+		files = append(files, line)
+	}
+	return files
+}
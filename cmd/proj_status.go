@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	projCmd.AddCommand(projStatusCmd)
+}
+
+var projStatusCmd = &cobra.Command{
+	Use:     "status",
+	Aliases: []string{"st"},
+	Short:   "Show git state and open todos for every project",
+	Long: `Show each registered project with its branch, dirty or clean working
+tree, commits ahead/behind its upstream, open todo count, and last activity
+(the later of the last commit and the last time the project was opened).
+
+Projects are inspected in parallel, so this stays quick with many repos.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("proj.status", runProjStatus),
+}
+
+func runProjStatus(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	statuses, err := ps.Status()
+	if err != nil {
+		return err
+	}
+	if projGroupFlag != "" {
+		paths, err := ps.GroupPaths(projGroupFlag)
+		if err != nil {
+			return err
+		}
+		statuses = slices.DeleteFunc(statuses, func(st proj.Status) bool {
+			return !slices.Contains(paths, st.Project.Path)
+		})
+	}
+	if len(statuses) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No projects registered yet."))
+		fmt.Printf("  Add one: %s\n", ui.Accent.Render("mine proj add ."))
+		fmt.Println()
+		return nil
+	}
+
+	counts, err := todo.NewStore(db.Conn()).OpenCountsByProject()
+	if err != nil {
+		return err
+	}
+
+	nameW := len("project")
+	branchW := len("branch")
+	for _, st := range statuses {
+		nameW = max(nameW, lipgloss.Width(st.Project.Name))
+		branchW = max(branchW, lipgloss.Width(projStatusBranch(st.Git)))
+	}
+
+	now := time.Now()
+	fmt.Println()
+	fmt.Println(ui.Muted.Render(fmt.Sprintf("  %-*s  %-*s  %-14s  %-7s  %s", nameW, "project", branchW, "branch", "state", "todos", "last activity")))
+	for _, st := range statuses {
+		todos := ui.Muted.Render(fmt.Sprintf("%-7s", "—"))
+		if n := counts[st.Project.Path]; n > 0 {
+			todos = fmt.Sprintf("%-7s", fmt.Sprintf("%d open", n))
+		}
+		last := "never"
+		if t := st.LastActivity(); !t.IsZero() {
+			last = todoTimeAgo(t, now)
+		}
+		fmt.Printf("  %s  %s  %s  %s  %s\n",
+			ui.Accent.Render(fmt.Sprintf("%-*s", nameW, st.Project.Name)),
+			fmt.Sprintf("%-*s", branchW, projStatusBranch(st.Git)),
+			padRight(projStatusState(st.Git), 14),
+			todos,
+			ui.Muted.Render(last),
+		)
+	}
+	fmt.Println()
+	return nil
+}
+
+// projStatusBranch names the checked-out branch, or why there isn't one.
+func projStatusBranch(g proj.GitState) string {
+	switch {
+	case !g.Repo:
+		return "-"
+	case g.Branch == "":
+		return "(detached)"
+	}
+	return g.Branch
+}
+
+// projStatusState renders clean/dirty plus ahead/behind counts, e.g.
+// "● dirty ↑2 ↓1".
+func projStatusState(g proj.GitState) string {
+	if !g.Repo {
+		return ui.Muted.Render("not a repo")
+	}
+	state := ui.Success.Render(ui.IconCheck + " clean")
+	if g.Dirty {
+		state = ui.Warning.Render("● dirty")
+	}
+	var sync []string
+	if g.Ahead > 0 {
+		sync = append(sync, fmt.Sprintf("↑%d", g.Ahead))
+	}
+	if g.Behind > 0 {
+		sync = append(sync, fmt.Sprintf("↓%d", g.Behind))
+	}
+	if len(sync) > 0 {
+		state += " " + ui.Accent.Render(strings.Join(sync, " "))
+	}
+	return state
+}
+
+// padRight pads a styled string to width display cells.
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
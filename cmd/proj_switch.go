@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/env"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// projSwitchTodoLimit caps the pending todos listed on switch.
+const projSwitchTodoLimit = 5
+
+var (
+	projSwitchShell  string
+	projSwitchNoTmux bool
+	projSwitchNoEnv  bool
+)
+
+func init() {
+	projCmd.AddCommand(projSwitchCmd)
+	projSwitchCmd.Flags().StringVar(&projSwitchShell, "shell", "", "Emit a script for eval instead (posix or fish)")
+	projSwitchCmd.Flags().BoolVar(&projSwitchNoTmux, "no-tmux", false, "Don't attach or create the tmux session")
+	projSwitchCmd.Flags().BoolVar(&projSwitchNoEnv, "no-env", false, "Don't load the project's env profile")
+	projSwitchCmd.Flags().MarkHidden("shell")
+}
+
+var projSwitchCmd = &cobra.Command{
+	Use:   "switch [name]",
+	Short: "Enter a project: cd, load env, list todos, attach tmux",
+	Long: `Switch to a project in one step: cd into it, export its active env profile,
+list its pending todos, and attach to (or create) its tmux session.
+
+A program can't change its parent shell, so the full switch runs through the
+'pj' shell function from 'mine shell init':
+
+  pj api              # everything
+  pj api --no-tmux    # stay in this terminal
+
+Run directly, 'mine proj switch' marks the project current and prints its
+todos. With --shell posix|fish it prints the script 'pj' evals; messages go
+to stderr. Without a name, a picker opens.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("proj.switch", runProjSwitch),
+}
+
+func runProjSwitch(_ *cobra.Command, args []string) error {
+	shellName := strings.ToLower(projSwitchShell)
+	if shellName != "" && shellName != "posix" && shellName != "fish" {
+		return fmt.Errorf("unknown shell %q — use --shell posix or --shell fish", projSwitchShell)
+	}
+	// In script mode stdout is eval'd, so everything human goes to stderr.
+	var out io.Writer = os.Stdout
+	if shellName != "" {
+		out = os.Stderr
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	} else {
+		name, err = pickProjectName(ps)
+		if err != nil || name == "" {
+			return err
+		}
+	}
+
+	res, err := ps.Open(name)
+	if err != nil {
+		return err
+	}
+	p := res.Project
+
+	var script []string
+	if shellName != "" {
+		script = append(script, "cd "+env.Quote(shellName, p.Path))
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "  %s Switched to %s  %s\n", ui.Success.Render(ui.IconCheck), ui.Accent.Render(p.Name), ui.Muted.Render(p.Path))
+
+	if !projSwitchNoEnv && shellName != "" {
+		lines, profile, err := projSwitchEnv(db, p.Path, shellName)
+		switch {
+		case err != nil:
+			fmt.Fprintf(out, "  %s env not loaded: %v\n", ui.Warning.Render("!"), err)
+		case profile != "":
+			script = append(script, lines...)
+			fmt.Fprintf(out, "  %s Loaded env profile %s (%d vars)\n", ui.Success.Render(ui.IconCheck), ui.Accent.Render(profile), len(lines))
+		}
+	}
+
+	printProjSwitchTodos(out, todo.NewStore(db.Conn()), p.Path)
+
+	if !projSwitchNoTmux && tmux.Available() {
+		if shellName != "" {
+			line := "mine tmux project " + env.Quote(shellName, p.Path)
+			if layout, _ := ps.GetSetting(p.Name, "tmux_layout"); layout != "" {
+				line += " --layout " + env.Quote(shellName, layout)
+			}
+			script = append(script, line)
+		}
+	}
+
+	if shellName == "" {
+		fmt.Fprintf(out, "  %s\n", ui.Muted.Render("Tip: 'pj "+p.Name+"' also cds there, loads env, and attaches tmux (mine shell init)."))
+		fmt.Fprintln(out)
+		return nil
+	}
+	fmt.Fprintln(out)
+	fmt.Println(strings.Join(script, "\n"))
+	return nil
+}
+
+// pickProjectName opens the project picker on stderr and returns the chosen
+// name, or "" when cancelled.
+func pickProjectName(ps *proj.Store) (string, error) {
+	if !tui.IsTTY() {
+		return "", fmt.Errorf("project name required when not in an interactive terminal")
+	}
+	projects, err := ps.List()
+	if err != nil {
+		return "", err
+	}
+	if len(projects) == 0 {
+		return "", fmt.Errorf("no projects registered — add one with %s", ui.Accent.Render("mine proj add ."))
+	}
+	items, boost := projectPickerItems(projects)
+	chosen, err := tui.RunWithOutput(items, os.Stderr, tui.WithTitle(ui.IconMine+"Switch to project"), tui.WithHeight(12), boost)
+	if err != nil || chosen == nil {
+		return "", err
+	}
+	return chosen.Title(), nil
+}
+
+// projSwitchEnv returns export lines for the project's active env profile.
+// profile is empty when the project has no env profiles.
+func projSwitchEnv(db *store.DB, projectPath, shellName string) (lines []string, profile string, err error) {
+	profiles, err := env.New(db.Conn(), "").ListProfiles(projectPath)
+	if err != nil || len(profiles) == 0 {
+		return nil, "", err
+	}
+	passphrase, err := readEnvPassphrase()
+	if err != nil {
+		return nil, "", err
+	}
+	m := env.New(db.Conn(), passphrase)
+	profile, err = m.ActiveProfile(projectPath)
+	if err != nil {
+		return nil, "", err
+	}
+	lines, err = m.ExportLines(projectPath, profile, shellName)
+	if err != nil {
+		return nil, "", err
+	}
+	return lines, profile, nil
+}
+
+// printProjSwitchTodos lists the project's most urgent open todos.
+func printProjSwitchTodos(out io.Writer, ts *todo.Store, projectPath string) {
+	todos, err := ts.List(todo.ListOptions{ProjectPaths: []string{projectPath}, CurrentProjectPath: &projectPath})
+	if err != nil {
+		return
+	}
+	if len(todos) == 0 {
+		fmt.Fprintf(out, "  %s\n", ui.Muted.Render("No open todos."))
+		return
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "  %s %d open\n", ui.IconTodo, len(todos))
+	for i, t := range todos {
+		if i == projSwitchTodoLimit {
+			fmt.Fprintf(out, "    %s\n", ui.Muted.Render(fmt.Sprintf("…and %d more", len(todos)-i)))
+			break
+		}
+		fmt.Fprintf(out, "    %s %s %s\n", ui.Muted.Render(fmt.Sprintf("#%-4d", t.ID)), todo.PriorityIcon(t.Priority), t.Title)
+	}
+	fmt.Fprintln(out)
+}
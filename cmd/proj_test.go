@@ -0,0 +1,582 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/env"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func mkScanRepos(t *testing.T, names ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, name := range names {
+		if err := os.MkdirAll(filepath.Join(root, name, ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func resetProjScanFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		projScanRoots = nil
+		projScanYes = false
+		projScanDepth = 3
+	})
+	projScanDepth = 3
+}
+
+func TestRunProjScan_YesRegistersFromRoots(t *testing.T) {
+	todoTestEnv(t)
+	resetProjScanFlags(t)
+	rootA := mkScanRepos(t, "alpha")
+	rootB := mkScanRepos(t, "beta")
+	projScanRoots = []string{rootA, rootB}
+	projScanYes = true
+
+	out := captureStdout(t, func() {
+		if err := runProjScan(nil, nil); err != nil {
+			t.Fatalf("runProjScan: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Found 2") {
+		t.Errorf("expected found summary, got:\n%s", out)
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	projects, err := proj.NewStore(db.Conn()).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects registered, got %d", len(projects))
+	}
+}
+
+func TestRunProjScan_NonInteractiveRequiresYes(t *testing.T) {
+	todoTestEnv(t)
+	resetProjScanFlags(t)
+	root := mkScanRepos(t, "alpha")
+
+	var err error
+	captureStdout(t, func() {
+		err = runProjScan(nil, []string{root})
+	})
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected --yes error, got %v", err)
+	}
+
+	db, dbErr := store.Open()
+	if dbErr != nil {
+		t.Fatal(dbErr)
+	}
+	defer db.Close()
+	projects, _ := proj.NewStore(db.Conn()).List()
+	if len(projects) != 0 {
+		t.Fatalf("expected nothing registered, got %d", len(projects))
+	}
+}
+
+func TestRunProjScan_UsesConfiguredRoots(t *testing.T) {
+	todoTestEnv(t)
+	resetProjScanFlags(t)
+	root := mkScanRepos(t, "gamma")
+	if err := runConfigSet(nil, []string{"proj.scan_roots", root}); err != nil {
+		t.Fatal(err)
+	}
+	projScanYes = true
+
+	out := captureStdout(t, func() {
+		if err := runProjScan(nil, nil); err != nil {
+			t.Fatalf("runProjScan: %v", err)
+		}
+	})
+	if !strings.Contains(out, "gamma") {
+		t.Errorf("expected configured root to be scanned, got:\n%s", out)
+	}
+}
+
+func TestRunProjStatus_ShowsTodosAndState(t *testing.T) {
+	todoTestEnv(t)
+	projDir := registerProject(t, "statusproj")
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	if _, err := ts.Add("in project", "", todo.PrioMedium, nil, nil, &projDir, todo.ScheduleLater, todo.RecurrenceNone); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runProjStatus(nil, nil); err != nil {
+			t.Fatalf("runProjStatus: %v", err)
+		}
+	})
+	for _, want := range []string{"statusproj", "not a repo", "1 open"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestProjStatusState(t *testing.T) {
+	got := projStatusState(proj.GitState{Repo: true, Dirty: true, Ahead: 2, Behind: 1})
+	for _, want := range []string{"dirty", "↑2", "↓1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("state %q missing %q", got, want)
+		}
+	}
+	if got := projStatusState(proj.GitState{Repo: true}); !strings.Contains(got, "clean") {
+		t.Errorf("state %q, want clean", got)
+	}
+}
+
+func TestRunTodoList_GroupScopesAcrossProjects(t *testing.T) {
+	todoTestEnv(t)
+	t.Chdir(t.TempDir())
+	todoProjectName = ""
+	todoShowAll = false
+	todoShowDone = false
+	t.Cleanup(func() { todoGroupFlag = "" })
+
+	api := registerProject(t, "api")
+	web := registerProject(t, "web")
+	other := registerProject(t, "other")
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	for title, path := range map[string]*string{"api task": &api, "web task": &web, "other task": &other, "global task": nil} {
+		if _, err := ts.Add(title, "", todo.PrioMedium, nil, nil, path, todo.ScheduleLater, todo.RecurrenceNone); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+
+	captureStdout(t, func() {
+		if err := runProjGroupCreate(nil, []string{"work"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := runProjGroupAdd(nil, []string{"work", "api", "web"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	todoGroupFlag = "work"
+	out := captureStdout(t, func() {
+		if err := runTodoList(nil, nil); err != nil {
+			t.Fatalf("runTodoList: %v", err)
+		}
+	})
+	for _, want := range []string{"api task", "web task"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in group listing:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"other task", "global task"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("unexpected %q in group listing:\n%s", unwanted, out)
+		}
+	}
+
+	todoGroupFlag = "missing"
+	if err := runTodoList(nil, nil); err == nil {
+		t.Error("expected error for unknown group")
+	}
+}
+
+func TestRunProjGroupList(t *testing.T) {
+	todoTestEnv(t)
+	registerProject(t, "api")
+
+	out := captureStdout(t, func() {
+		runProjGroupCreate(nil, []string{"oss"})     //nolint:errcheck
+		runProjGroupAdd(nil, []string{"oss", "api"}) //nolint:errcheck
+		if err := runProjGroupList(nil, nil); err != nil {
+			t.Fatalf("runProjGroupList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "oss") || !strings.Contains(out, "api") {
+		t.Errorf("expected group and member in output:\n%s", out)
+	}
+}
+
+func resetProjSwitchFlags(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		projSwitchShell = ""
+		projSwitchNoTmux = false
+		projSwitchNoEnv = false
+	})
+}
+
+func TestRunProjSwitch_ScriptCdsAndExportsEnv(t *testing.T) {
+	todoTestEnv(t)
+	resetProjSwitchFlags(t)
+	t.Setenv("MINE_ENV_PASSPHRASE", "test-pass")
+	projDir := registerProject(t, "switchproj")
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.New(db.Conn(), "test-pass").SaveProfile(projDir, "local", map[string]string{"API_URL": "http://localhost"}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	projSwitchShell = "posix"
+	projSwitchNoTmux = true
+	out := captureStdout(t, func() {
+		if err := runProjSwitch(nil, []string{"switchproj"}); err != nil {
+			t.Fatalf("runProjSwitch: %v", err)
+		}
+	})
+	if !strings.Contains(out, "cd '"+projDir+"'") {
+		t.Errorf("script missing cd line:\n%s", out)
+	}
+	if !strings.Contains(out, "export API_URL='http://localhost'") {
+		t.Errorf("script missing env export:\n%s", out)
+	}
+	if strings.Contains(out, "Switched") {
+		t.Errorf("human output leaked into script:\n%s", out)
+	}
+}
+
+func TestRunProjSwitch_DirectListsTodos(t *testing.T) {
+	todoTestEnv(t)
+	resetProjSwitchFlags(t)
+	projDir := registerProject(t, "switchproj")
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	if _, err := ts.Add("ship the switch", "", todo.PrioHigh, nil, nil, &projDir, todo.ScheduleLater, todo.RecurrenceNone); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runProjSwitch(nil, []string{"switchproj"}); err != nil {
+			t.Fatalf("runProjSwitch: %v", err)
+		}
+	})
+	for _, want := range []string{"Switched to", "switchproj", "ship the switch", "pj switchproj"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "cd '") {
+		t.Errorf("direct mode should not emit a script:\n%s", out)
+	}
+}
+
+func TestRunProjSwitch_RejectsUnknownShell(t *testing.T) {
+	todoTestEnv(t)
+	resetProjSwitchFlags(t)
+	projSwitchShell = "powershell"
+	if err := runProjSwitch(nil, []string{"x"}); err == nil {
+		t.Fatal("expected error for unknown shell")
+	}
+}
+
+func TestRunProjShow_CardIncludesNotesTodosAndReadme(t *testing.T) {
+	todoTestEnv(t)
+	projDir := registerProject(t, "showproj")
+	readme := "# showproj\n\n[![ci](badge.svg)](ci)\n\nA tiny service that\nanswers pings.\n\n## Usage\n"
+	if err := os.WriteFile(filepath.Join(projDir, "README.md"), []byte(readme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	captureStdout(t, func() {
+		if err := runProjNote(nil, []string{"showproj", "deploys", "from", "release"}); err != nil {
+			t.Fatalf("runProjNote: %v", err)
+		}
+	})
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	if _, err := ts.Add("wire up alerts", "", todo.PrioHigh, nil, nil, &projDir, todo.ScheduleLater, todo.RecurrenceNone); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runProjShow(nil, []string{"showproj"}); err != nil {
+			t.Fatalf("runProjShow: %v", err)
+		}
+	})
+	for _, want := range []string{"showproj", projDir, "A tiny service that", "answers pings.", "deploys from release", "1 open", "wire up alerts"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Usage") || strings.Contains(out, "badge.svg") {
+		t.Errorf("README excerpt should stop at the first paragraph:\n%s", out)
+	}
+}
+
+func TestRunProjShow_UnknownProject(t *testing.T) {
+	todoTestEnv(t)
+	if err := runProjShow(nil, []string{"nope"}); err == nil {
+		t.Fatal("expected error for unknown project")
+	}
+}
+
+func TestRunProjMv_MovesTodosWithProject(t *testing.T) {
+	todoTestEnv(t)
+	oldDir := registerProject(t, "mvproj")
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := todo.NewStore(db.Conn()).Add("follow me", "", todo.PrioMedium, nil, nil, &oldDir, todo.ScheduleLater, todo.RecurrenceNone); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	newDir := filepath.Join(filepath.Dir(oldDir), "moved", "mvproj")
+	out := captureStdout(t, func() {
+		if err := runProjMv(nil, []string{"mvproj", newDir}); err != nil {
+			t.Fatalf("runProjMv: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Moved 1 todo(s)") {
+		t.Errorf("output missing todo count:\n%s", out)
+	}
+	if _, err := os.Stat(newDir); err != nil {
+		t.Fatalf("directory not moved: %v", err)
+	}
+
+	db, err = store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	todos, err := todo.NewStore(db.Conn()).List(todo.ListOptions{ProjectPaths: []string{newDir}})
+	if err != nil || len(todos) != 1 {
+		t.Fatalf("todo not moved with project: %v, %v", todos, err)
+	}
+}
+
+func TestRunProjMv_UndoneWhenTodosFail(t *testing.T) {
+	todoTestEnv(t)
+	oldDir := registerProject(t, "mvfail")
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := todo.NewStore(db.Conn()).Add("stay put", "", todo.PrioMedium, nil, nil, &oldDir, todo.ScheduleLater, todo.RecurrenceNone); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Conn().Exec(`CREATE TRIGGER fail_todo_move BEFORE UPDATE OF project_path ON todos BEGIN SELECT RAISE(ABORT, 'boom'); END`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	newDir := filepath.Join(filepath.Dir(oldDir), "moved", "mvfail")
+	captureStdout(t, func() {
+		if err := runProjMv(nil, []string{"mvfail", newDir}); err == nil {
+			t.Error("expected runProjMv to fail")
+		}
+	})
+	if _, err := os.Stat(oldDir); err != nil {
+		t.Errorf("directory should be back at %s: %v", oldDir, err)
+	}
+	if _, err := os.Stat(newDir); !os.IsNotExist(err) {
+		t.Errorf("directory left at %s", newDir)
+	}
+
+	db, err = store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if p, err := proj.NewStore(db.Conn()).Get("mvfail"); err != nil || p.Path != oldDir {
+		t.Errorf("registry = %+v, %v; want path %s", p, err, oldDir)
+	}
+}
+
+func TestRunProjDoctor_ReportsFindings(t *testing.T) {
+	todoTestEnv(t)
+	registerProject(t, "healthyproj")
+	needsEnv := registerProject(t, "envproj")
+	if err := os.WriteFile(filepath.Join(needsEnv, ".env.example"), []byte("API_KEY=\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gone := registerProject(t, "goneproj")
+	if err := os.RemoveAll(gone); err != nil {
+		t.Fatal(err)
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = runProjDoctor(nil, nil)
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "2 of 3") {
+		t.Errorf("expected 2 of 3 projects flagged, got %v", runErr)
+	}
+	for _, want := range []string{"healthyproj", "uses .env.example but has no mine env profiles", "no longer exists", "mine proj mv goneproj"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = captureStdout(t, func() {
+		if err := runProjDoctor(nil, []string{"healthyproj"}); err != nil {
+			t.Errorf("healthy project flagged: %v", err)
+		}
+	})
+	if !strings.Contains(out, "All projects look healthy") {
+		t.Errorf("expected healthy summary:\n%s", out)
+	}
+}
+
+func TestRunProjRecent_ListsResolvedProjects(t *testing.T) {
+	todoTestEnv(t)
+	registerProject(t, "idleproj")
+	dir := registerProject(t, "busyproj")
+
+	out := captureStdout(t, func() {
+		if err := runProjRecent(nil, nil); err != nil {
+			t.Fatalf("runProjRecent: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No recently used projects") {
+		t.Errorf("expected empty state:\n%s", out)
+	}
+
+	// Resolving a project from the cwd counts as using it.
+	t.Chdir(dir)
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resolveTodoProject(proj.NewStore(db.Conn()), ""); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	out = captureStdout(t, func() {
+		if err := runProjRecent(nil, nil); err != nil {
+			t.Fatalf("runProjRecent: %v", err)
+		}
+	})
+	if !strings.Contains(out, "busyproj") || !strings.Contains(out, "just now") {
+		t.Errorf("resolved project not listed:\n%s", out)
+	}
+	if strings.Contains(out, "idleproj") {
+		t.Errorf("unused project listed:\n%s", out)
+	}
+}
+
+func TestRunProjWorktree_AddListRm(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	todoTestEnv(t)
+	dir := registerProject(t, "wtproj")
+	for _, args := range [][]string{{"init", "-q", "-b", "main"}, {"commit", "-q", "--allow-empty", "-m", "first"}} {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	t.Chdir(dir)
+	t.Cleanup(func() { projWorktreeProject = "" })
+
+	out := captureStdout(t, func() {
+		if err := runProjWorktreeAdd(nil, []string{"feature/x"}); err != nil {
+			t.Fatalf("runProjWorktreeAdd: %v", err)
+		}
+	})
+	if !strings.Contains(out, "wtproj-feature-x") {
+		t.Errorf("add output missing worktree name:\n%s", out)
+	}
+
+	// From inside the worktree, commands manage its parent's worktrees.
+	t.Chdir(filepath.Join(filepath.Dir(dir), "wtproj-feature-x"))
+	out = captureStdout(t, func() {
+		if err := runProjWorktreeList(nil, nil); err != nil {
+			t.Fatalf("runProjWorktreeList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "wtproj worktrees") || !strings.Contains(out, "feature/x") {
+		t.Errorf("list output missing worktree:\n%s", out)
+	}
+
+	t.Chdir(dir)
+	projWorktreeProject = "wtproj"
+	out = captureStdout(t, func() {
+		if err := runProjWorktreeRm(nil, []string{"feature/x"}); err != nil {
+			t.Fatalf("runProjWorktreeRm: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Removed worktree") {
+		t.Errorf("rm output:\n%s", out)
+	}
+}
+
+func TestRunProjImport_AutojumpYes(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("autojump ignores XDG_DATA_HOME on macOS")
+	}
+	todoTestEnv(t)
+	repos := mkScanRepos(t, "often", "rarely")
+	data := os.Getenv("XDG_DATA_HOME")
+	if err := os.MkdirAll(filepath.Join(data, "autojump"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	history := "40.0\t" + filepath.Join(repos, "often") + "\n2.0\t" + filepath.Join(repos, "rarely") + "\n"
+	if err := os.WriteFile(filepath.Join(data, "autojump", "autojump.txt"), []byte(history), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		projImportFrom, projImportYes, projImportMinScore, projImportLimit = "", false, 0, 20
+	})
+	projImportFrom, projImportYes, projImportMinScore = "autojump", true, 10
+
+	out := captureStdout(t, func() {
+		if err := runProjImport(nil, nil); err != nil {
+			t.Fatalf("runProjImport: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Added 1 projects") || strings.Contains(out, "rarely") {
+		t.Errorf("expected only the frequent repo imported:\n%s", out)
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := proj.NewStore(db.Conn()).Get("often"); err != nil {
+		t.Errorf("often not registered: %v", err)
+	}
+}
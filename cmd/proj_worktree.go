@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projWorktreeProject string
+	projWorktreeTmux    bool
+	projWorktreeForce   bool
+)
+
+func init() {
+	projCmd.AddCommand(projWorktreeCmd)
+	projWorktreeCmd.AddCommand(projWorktreeAddCmd)
+	projWorktreeCmd.AddCommand(projWorktreeListCmd)
+	projWorktreeCmd.AddCommand(projWorktreeRmCmd)
+
+	projWorktreeCmd.PersistentFlags().StringVarP(&projWorktreeProject, "project", "p", "", "Project name (defaults to current project)")
+	projWorktreeAddCmd.Flags().BoolVar(&projWorktreeTmux, "tmux", false, "Start a detached tmux session in the new worktree")
+	projWorktreeRmCmd.Flags().BoolVarP(&projWorktreeForce, "force", "f", false, "Remove even with uncommitted changes")
+}
+
+var projWorktreeCmd = &cobra.Command{
+	Use:     "worktree",
+	Aliases: []string{"wt"},
+	Short:   "Work on several branches of a project at once with git worktrees",
+	Long: `Create git worktrees for a project's branches. Each worktree is registered
+as its own project, linked to the one it came from, so 'p', todos, env
+profiles, and tmux sessions work in it like anywhere else.
+
+Worktrees are created beside the project (~/dev/api → ~/dev/api-feature-login)
+unless proj.worktree_dir is set.
+
+  mine proj worktree add feature/login --tmux
+  mine proj worktree list
+  mine proj worktree rm feature/login`,
+	RunE: hook.Wrap("proj.worktree", runProjWorktreeList),
+}
+
+var projWorktreeAddCmd = &cobra.Command{
+	Use:   "add <branch>",
+	Short: "Create a worktree for a branch and register it",
+	Long: `Check out branch in a new worktree and register it as a project. The
+branch is created from HEAD if it doesn't exist locally or on a remote.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("proj.worktree.add", runProjWorktreeAdd),
+}
+
+var projWorktreeListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List a project's worktrees",
+	Args:    cobra.NoArgs,
+	RunE:    hook.Wrap("proj.worktree.list", runProjWorktreeList),
+}
+
+var projWorktreeRmCmd = &cobra.Command{
+	Use:   "rm <branch>",
+	Short: "Remove a worktree and unregister it (the branch is kept)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  hook.Wrap("proj.worktree.rm", runProjWorktreeRm),
+}
+
+// resolveWorktreeParent returns the project whose worktrees to manage: the
+// --project flag, or the current project. From inside a worktree, that's
+// the project it was created from.
+func resolveWorktreeParent(ps *proj.Store) (string, error) {
+	name := projWorktreeProject
+	if name == "" {
+		p, err := ps.FindForCWD()
+		if err != nil {
+			return "", err
+		}
+		if p == nil {
+			return "", fmt.Errorf("not inside a registered project — pass --project or run %s", ui.Accent.Render("mine proj add ."))
+		}
+		name = p.Name
+	}
+	parent, err := ps.WorktreeParent(name)
+	if err != nil {
+		return "", err
+	}
+	if parent != "" {
+		return parent, nil
+	}
+	return name, nil
+}
+
+func runProjWorktreeAdd(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	parent, err := resolveWorktreeParent(ps)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	w, err := ps.AddWorktree(parent, args[0], cfg.Proj.WorktreeDir)
+	if err != nil {
+		return err
+	}
+	ui.Ok(fmt.Sprintf("Created worktree %s for %s", ui.Accent.Render(w.Name), w.Branch))
+	fmt.Printf("    %s\n", ui.Muted.Render(w.Path))
+
+	if projWorktreeTmux {
+		if !tmux.Available() {
+			fmt.Printf("  %s tmux not found — skipped the session\n", ui.Warning.Render(ui.IconWarn))
+		} else if name, err := newSessionFunc(w.Name, w.Path); err != nil {
+			fmt.Printf("  %s %v\n", ui.Warning.Render(ui.IconWarn), err)
+		} else {
+			fmt.Printf("  %s Started tmux session %s\n", ui.Success.Render(ui.IconCheck), ui.Accent.Render(name))
+		}
+	}
+	fmt.Printf("  Jump in: %s\n", ui.Accent.Render("p "+w.Name))
+	fmt.Println()
+	return nil
+}
+
+func runProjWorktreeList(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	parent, err := resolveWorktreeParent(ps)
+	if err != nil {
+		return err
+	}
+	worktrees, err := ps.Worktrees(parent)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(worktrees) == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  No worktrees for %s.", parent)))
+		fmt.Printf("  Create one: %s\n", ui.Accent.Render("mine proj worktree add <branch>"))
+		fmt.Println()
+		return nil
+	}
+
+	nameWidth, branchWidth := 0, 0
+	for _, w := range worktrees {
+		nameWidth = max(nameWidth, len(w.Name))
+		branchWidth = max(branchWidth, len(w.Branch))
+	}
+	fmt.Printf("  %s\n", ui.Title.Render(parent+" worktrees"))
+	for _, w := range worktrees {
+		path := ui.Muted.Render(w.Path)
+		if _, err := os.Stat(w.Path); err != nil {
+			path = ui.Warning.Render(w.Path + " (missing)")
+		}
+		fmt.Printf("  %s  %s  %s\n", ui.Accent.Render(padRight(w.Name, nameWidth)), padRight(w.Branch, branchWidth), path)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runProjWorktreeRm(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	parent, err := resolveWorktreeParent(ps)
+	if err != nil {
+		return err
+	}
+	w, err := ps.RemoveWorktree(parent, args[0], projWorktreeForce)
+	if err != nil {
+		return err
+	}
+	ui.Ok(fmt.Sprintf("Removed worktree %s (branch %s kept)", ui.Accent.Render(w.Name), w.Branch))
+
+	// A session started by 'add --tmux' would otherwise sit in a deleted dir.
+	if tmux.Available() {
+		if sessions, err := tmux.ListSessions(); err == nil && tmux.FindSessionByName(w.Name, sessions) != nil {
+			if err := killSessionFunc(w.Name); err == nil {
+				fmt.Printf("  %s Killed tmux session %s\n", ui.Success.Render(ui.IconCheck), w.Name)
+			}
+		}
+	}
+	fmt.Println()
+	return nil
+}
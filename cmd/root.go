@@ -50,6 +50,7 @@ func Execute() {
 	if err := plugin.RegisterPluginHooks(); err != nil {
 		log.Printf("warning: loading plugin hooks: %v", err)
 	}
+	applyTheme()
 
 	if err := rootCmd.Execute(); err != nil {
 		ui.Err(err.Error())
@@ -71,6 +72,18 @@ func init() {
 	rootCmd.Flags().BoolVar(&dashPlain, "plain", false, "Print static text dashboard instead of launching the TUI")
 }
 
+// applyTheme switches ui styles to the configured [ui.theme]. An unreadable
+// config or unknown theme falls back to the default palette with a warning.
+func applyTheme() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	if err := ui.ApplyTheme(cfg.UI.Theme.Name, cfg.UI.Theme.ASCII); err != nil {
+		log.Printf("warning: %v", err)
+	}
+}
+
 // fireAnalytics sends an anonymous analytics ping synchronously.
 // It's a no-op if config is not initialized, analytics are disabled,
 // or the store can't be opened.
@@ -39,7 +39,9 @@ var shellInitCmd = &cobra.Command{
 Usage: eval "$(mine shell init zsh)"
 
 This sets up aliases, utility functions, and prompt integration
-in a single command. Add it to your shell config for persistent use.`,
+in a single command. With tmux.auto_session (or a project's
+tmux_auto_session) set to ask or attach, it also adds a cd hook that
+offers the project's tmux session. Add it to your shell config for persistent use.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: hook.Wrap("shell.init", runShellInit),
 }
@@ -55,6 +57,12 @@ func runShellInit(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	// The tmux auto-session hook runs on every cd, so it's opt-in.
+	if tmuxAutoEnabled() {
+		autoHook, _ := shell.TmuxAutoScript(sh)
+		script += autoHook
+	}
+
 	fmt.Print(script)
 	return nil
 }
@@ -3,12 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/rnwolfe/mine/internal/config"
 	"github.com/rnwolfe/mine/internal/hook"
 	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/tui"
 	"github.com/rnwolfe/mine/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -22,18 +23,45 @@ var stashCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(stashCmd)
-	stashCmd.AddCommand(stashTrackCmd)
-	stashCmd.AddCommand(stashListCmd)
 	stashCmd.AddCommand(stashInitCmd)
+	stashCmd.AddCommand(stashListCmd)
 	stashCmd.AddCommand(stashDiffCmd)
 	stashCmd.AddCommand(stashCommitCmd)
 	stashCmd.AddCommand(stashLogCmd)
-	stashCmd.AddCommand(stashRestoreCmd)
-	stashCmd.AddCommand(stashSyncCmd)
 
 	stashCommitCmd.Flags().StringP("message", "m", "", "Commit message")
-	stashRestoreCmd.Flags().StringP("version", "v", "", "Version hash to restore (default: latest)")
-	stashRestoreCmd.Flags().BoolP("force", "f", false, "Override destination file permissions with stash-recorded permissions")
+}
+
+// loadStashScrubber sets stash.Scrub from config: the built-in secret rules
+// plus stash.scrub_patterns, or nil when stash.scrub is off.
+func loadStashScrubber() error {
+	stash.Scrub = nil
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if !cfg.Stash.ScrubEnabled() {
+		return nil
+	}
+	s, err := stash.NewScrubber(cfg.Stash.ScrubPatterns)
+	if err != nil {
+		return fmt.Errorf("stash.scrub_patterns: %w", err)
+	}
+	stash.Scrub = s
+	return nil
+}
+
+// printRedactions warns about the secrets scrubbing kept out of the stash.
+func printRedactions() {
+	if stash.Scrub == nil || len(stash.Scrub.Redacted) == 0 {
+		return
+	}
+	home, _ := os.UserHomeDir()
+	for _, r := range stash.Scrub.Redacted {
+		display := strings.Replace(r.Source, home, "~", 1)
+		ui.Warn(fmt.Sprintf("Redacted %d secret(s) from %s: %s", len(r.Rules), display, strings.Join(r.Rules, ", ")))
+	}
+	fmt.Printf("  %s\n", ui.Muted.Render("The stash keeps placeholders; consider mine vault or track --encrypt for these files."))
 }
 
 var stashInitCmd = &cobra.Command{
@@ -42,13 +70,6 @@ var stashInitCmd = &cobra.Command{
 	RunE:  hook.Wrap("stash.init", runStashInit),
 }
 
-var stashTrackCmd = &cobra.Command{
-	Use:   "track <file>",
-	Short: "Start tracking a dotfile",
-	Args:  cobra.ExactArgs(1),
-	RunE:  hook.Wrap("stash.track", runStashTrack),
-}
-
 var stashListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Show all tracked dotfiles",
@@ -75,25 +96,6 @@ var stashLogCmd = &cobra.Command{
 	RunE:  hook.Wrap("stash.log", runStashLog),
 }
 
-var stashRestoreCmd = &cobra.Command{
-	Use:   "restore <file>",
-	Short: "Restore a dotfile to a previous snapshot",
-	Args:  cobra.ExactArgs(1),
-	RunE:  hook.Wrap("stash.restore", runStashRestore),
-}
-
-var stashSyncCmd = &cobra.Command{
-	Use:   "sync <push|pull|remote>",
-	Short: "Back up your stash to a git remote",
-	Long: `Sync your stash with a git remote. Opt-in cloud backup.
-
-  mine stash sync remote <url>   Set the remote repository URL
-  mine stash sync push           Push stash to remote
-  mine stash sync pull           Pull stash from remote`,
-	Args: cobra.RangeArgs(1, 2),
-	RunE: hook.Wrap("stash.sync", runStashSync),
-}
-
 func runStashInit(_ *cobra.Command, _ []string) error {
 	dir := stash.Dir()
 	if err := os.MkdirAll(dir, 0o755); err != nil {
@@ -103,7 +105,7 @@ func runStashInit(_ *cobra.Command, _ []string) error {
 	// Create manifest if it doesn't exist.
 	manifestPath := stash.ManifestPath()
 	if _, err := os.Stat(manifestPath); os.IsNotExist(err) {
-		if err := os.WriteFile(manifestPath, []byte("# mine stash manifest\n# each line: source_path -> safe_name (e.g. ~/.zshrc -> zshrc)\n"), 0o644); err != nil {
+		if err := os.WriteFile(manifestPath, []byte(stash.ManifestHeader), 0o644); err != nil {
 			return err
 		}
 	}
@@ -116,35 +118,6 @@ func runStashInit(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
-func runStashTrack(_ *cobra.Command, args []string) error {
-	source := args[0]
-
-	// Expand ~ to home dir.
-	if strings.HasPrefix(source, "~") {
-		home, _ := os.UserHomeDir()
-		source = filepath.Join(home, source[1:])
-	}
-
-	source, err := filepath.Abs(source)
-	if err != nil {
-		return err
-	}
-
-	entry, err := stash.TrackFile(source)
-	if err != nil {
-		return err
-	}
-
-	home, _ := os.UserHomeDir()
-	relPath := strings.TrimPrefix(entry.Source, home+"/")
-	dest := filepath.Join(stash.Dir(), entry.SafeName)
-
-	ui.Ok(fmt.Sprintf("Tracking %s", relPath))
-	fmt.Printf("  Stashed to: %s\n", ui.Muted.Render(dest))
-	fmt.Println()
-	return nil
-}
-
 func runStashList(_ *cobra.Command, _ []string) error {
 	entries, err := stash.ReadManifest()
 	if err != nil {
@@ -173,7 +146,20 @@ func runStashList(_ *cobra.Command, _ []string) error {
 	fmt.Println()
 	for _, e := range entries {
 		display := strings.Replace(e.Source, home, "~", 1)
+		switch {
+		case e.Encrypted:
+			display += ui.Muted.Render(" (encrypted)")
+		case e.Dir:
+			display += "/"
+		}
+		if h := stash.Variant(e); h != "" {
+			display += ui.Muted.Render(" @" + h)
+		}
+		if e.Link != "" {
+			display += ui.Muted.Render(" → " + e.Link)
+		}
 		fmt.Printf("  %s %s\n", ui.Success.Render("●"), display)
+		printStashOptions(e)
 	}
 	fmt.Println()
 	fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d files tracked", len(entries))))
@@ -189,8 +175,24 @@ func runStashList(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// printStashOptions shows a tracked directory's include/exclude globs and
+// an entry's on-restore command.
+func printStashOptions(e stash.Entry) {
+	if len(e.Include) > 0 {
+		fmt.Printf("    %s\n", ui.Muted.Render("include: "+strings.Join(e.Include, ", ")))
+	}
+	if len(e.Exclude) > 0 {
+		fmt.Printf("    %s\n", ui.Muted.Render("exclude: "+strings.Join(e.Exclude, ", ")))
+	}
+	if e.OnRestore != "" {
+		fmt.Printf("    %s\n", ui.Muted.Render("on restore: "+e.OnRestore))
+	}
+}
+
 func runStashDiff(_ *cobra.Command, _ []string) error {
-	dir := stash.Dir()
+	if err := loadStashScrubber(); err != nil {
+		return err
+	}
 	entries, err := stash.ReadManifest()
 	if err != nil {
 		return err
@@ -207,34 +209,26 @@ func runStashDiff(_ *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	changes := 0
-	fmt.Println()
-	for _, e := range entries {
-		sourceData, err := os.ReadFile(e.Source)
-		if err != nil {
-			fmt.Printf("  %s %s (missing!)\n", ui.Error.Render("✗"), e.Source)
-			changes++
-			continue
-		}
+	changes, err := stash.Changes(entries)
+	if err != nil {
+		return err
+	}
 
-		stashedData, err := os.ReadFile(filepath.Join(dir, e.SafeName))
-		if err != nil {
+	fmt.Println()
+	home, _ := os.UserHomeDir()
+	for _, c := range changes {
+		if c.Missing {
+			fmt.Printf("  %s %s (missing!)\n", ui.Error.Render("✗"), c.Path)
 			continue
 		}
-
-		if string(sourceData) != string(stashedData) {
-			home, _ := os.UserHomeDir()
-			display := strings.Replace(e.Source, home, "~", 1)
-			fmt.Printf("  %s %s (modified)\n", ui.Warning.Render("~"), display)
-			changes++
-		}
+		fmt.Printf("  %s %s (modified)\n", ui.Warning.Render("~"), strings.Replace(c.Path, home, "~", 1))
 	}
 
-	if changes == 0 {
+	if len(changes) == 0 {
 		fmt.Println(ui.Success.Render("  Everything in sync."))
 	} else {
 		fmt.Println()
-		fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d files changed since last stash", changes)))
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d files changed since last stash", len(changes))))
 	}
 	fmt.Println()
 	return nil
@@ -254,15 +248,22 @@ func runStashCommit(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("nothing to snapshot — add a file first with `mine stash track ~/.zshrc`")
 	}
 
+	if err := loadStashScrubber(); err != nil {
+		return err
+	}
 	hash, err := stash.Commit(msg)
 	if err != nil {
 		return err
 	}
 
 	fmt.Println()
+	printRedactions()
 	ui.Ok(fmt.Sprintf("Snapshot saved %s", ui.Muted.Render("["+hash+"]")))
 	fmt.Printf("  %s\n", ui.Muted.Render(msg))
 	fmt.Printf("  Your dotfiles are safe. %s\n", ui.Muted.Render("restore anytime with `mine stash restore <file>`"))
+	if err := warnLargeStashFiles(entries); err != nil {
+		return err
+	}
 	fmt.Println()
 	return nil
 }
@@ -304,81 +305,9 @@ func runStashLog(_ *cobra.Command, args []string) error {
 	return nil
 }
 
-func runStashRestore(cmd *cobra.Command, args []string) error {
-	file := args[0]
-	version, _ := cmd.Flags().GetString("version")
-	force, _ := cmd.Flags().GetBool("force")
-
-	// RestoreToSource returns the Entry, avoiding duplicate FindEntry calls
-	entry, err := stash.RestoreToSource(file, version, force)
-	if err != nil {
-		return err
-	}
-
-	home, _ := os.UserHomeDir()
-	display := strings.Replace(entry.Source, home, "~", 1)
-
-	versionLabel := "latest"
-	if version != "" {
-		versionLabel = version
-	}
-
-	fmt.Println()
-	ui.Ok(fmt.Sprintf("Restored %s to %s", display, versionLabel))
-	fmt.Println()
-	return nil
-}
-
-func runStashSync(_ *cobra.Command, args []string) error {
-	action := args[0]
-	switch action {
-	case "remote":
-		if len(args) < 2 {
-			// Show current remote.
-			url := stash.SyncRemoteURL()
-			if url == "" {
-				fmt.Println()
-				fmt.Println(ui.Muted.Render("  No remote configured."))
-				fmt.Printf("  Set one: %s\n", ui.Accent.Render("mine stash sync remote <url>"))
-				fmt.Println()
-			} else {
-				fmt.Println()
-				ui.Kv("remote", url)
-				fmt.Println()
-			}
-			return nil
-		}
-		url := args[1]
-		if err := stash.SyncSetRemote(url); err != nil {
-			return err
-		}
-		fmt.Println()
-		ui.Ok(fmt.Sprintf("Remote set to %s", url))
-		fmt.Println()
-		return nil
-
-	case "push":
-		if err := stash.SyncPush(); err != nil {
-			return err
-		}
-		fmt.Println()
-		ui.Ok("Stash backed up to remote — your configs are safe in the cloud")
-		fmt.Println()
-		return nil
-
-	case "pull":
-		if err := stash.SyncPull(); err != nil {
-			return err
-		}
-		fmt.Println()
-		ui.Ok("Stash pulled and restored — welcome back to your setup")
-		fmt.Println()
-		return nil
-
-	default:
-		return fmt.Errorf("unknown sync action %q — use push, pull, or remote", action)
-	}
-}
+// stashIsTTY reports whether restore, bootstrap, and sync pull can prompt. Injectable
+// for testing.
+var stashIsTTY = tui.IsTTY
 
 func runStashStatus(_ *cobra.Command, _ []string) error {
 	return runStashList(nil, nil)
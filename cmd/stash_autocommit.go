@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var stashAutocommitCmd = &cobra.Command{
+	Use:   "autocommit",
+	Short: "Snapshot tracked dotfiles automatically as they change",
+	Long: `Watch every tracked file and directory and commit changes as they happen,
+with a message naming the files that changed. Runs until interrupted.
+
+--interval spaces snapshots out: changes are still noticed right away, but
+committed at most once per interval.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("stash.autocommit", runStashAutocommit),
+}
+
+func init() {
+	stashCmd.AddCommand(stashAutocommitCmd)
+
+	stashAutocommitCmd.Flags().Duration("interval", 0, "Commit at most this often, e.g. 6h (default: as soon as changes settle)")
+}
+
+func runStashAutocommit(cmd *cobra.Command, _ []string) error {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval < 0 {
+		return fmt.Errorf("--interval can't be negative")
+	}
+	if err := loadStashScrubber(); err != nil {
+		return err
+	}
+
+	a, err := stash.NewAutoCommitter(interval)
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	fmt.Println()
+	if a.Entries() == 0 {
+		fmt.Println(ui.Muted.Render("  Nothing tracked yet — watching for new entries."))
+		fmt.Printf("  Add some with %s\n", ui.Accent.Render("mine stash track ~/.zshrc"))
+	}
+	every := "as changes settle"
+	if interval > 0 {
+		every = "at most every " + interval.String()
+	}
+	fmt.Printf("  Watching %d tracked entries, committing %s — Ctrl+C to stop\n", a.Entries(), ui.Accent.Render(every))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	err = a.Run(ctx, printStashAutocommitEvent)
+	fmt.Println()
+	return err
+}
+
+// printStashAutocommitEvent prints one automatic snapshot, or why it failed.
+func printStashAutocommitEvent(ev stash.AutoCommitEvent) {
+	if ev.Err != nil {
+		fmt.Printf("  %s%s\n", ui.Error.Render(ui.IconError), ev.Err.Error())
+		return
+	}
+	printRedactions()
+	if stash.Scrub != nil {
+		stash.Scrub.Redacted = nil
+	}
+	fmt.Printf("  %s%s %s %s\n", ui.Success.Render(ui.IconOk), time.Now().Format("15:04"), ev.Message, ui.Muted.Render("["+ev.Hash+"]"))
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var stashBootstrapCmd = &cobra.Command{
+	Use:   "bootstrap <remote-url>",
+	Short: "Set up a new machine from a remote stash",
+	Long: `Clone a remote stash into place, check its manifest, and restore every tracked
+file to its source path — a fresh machine configured in one command.
+
+Where a file already exists and differs from the stash, you're asked whether
+to overwrite it, back it up to <file>.pre-stash first, or skip it. --conflict
+answers for every such file; without a terminal, they're skipped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("stash.bootstrap", runStashBootstrap),
+}
+
+func init() {
+	stashCmd.AddCommand(stashBootstrapCmd)
+
+	stashBootstrapCmd.Flags().String("conflict", "", "Settle existing files that differ without asking: overwrite, backup, or skip")
+}
+
+func runStashBootstrap(cmd *cobra.Command, args []string) error {
+	flag, _ := cmd.Flags().GetString("conflict")
+	strategy := stash.ConflictAction(flag)
+	switch strategy {
+	case "", stash.ConflictOverwrite, stash.ConflictBackup, stash.ConflictSkip:
+	default:
+		return fmt.Errorf("invalid --conflict %q — use %s, %s, or %s",
+			strategy, stash.ConflictOverwrite, stash.ConflictBackup, stash.ConflictSkip)
+	}
+	if err := loadStashScrubber(); err != nil {
+		return err
+	}
+
+	entries, err := stash.Bootstrap(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Cloned stash — %d tracked entries", len(entries)))
+	fmt.Println()
+
+	home, _ := os.UserHomeDir()
+	reader := bufio.NewReader(os.Stdin)
+	restored, skipped := 0, 0
+	for _, e := range entries {
+		display := strings.Replace(e.Source, home, "~", 1)
+		action := stash.ConflictOverwrite
+		conflict, err := stash.SourceConflict(e)
+		if err != nil {
+			fmt.Printf("  %s %s %s\n", ui.Warning.Render(ui.IconWarn), display, ui.Muted.Render(err.Error()))
+			skipped++
+			continue
+		}
+		if conflict {
+			switch {
+			case strategy != "":
+				action = strategy
+			case stashIsTTY():
+				action = promptStashConflict(reader, display)
+			default:
+				action = stash.ConflictSkip
+			}
+		}
+		if action == stash.ConflictSkip {
+			fmt.Printf("  %s %s %s\n", ui.Warning.Render(ui.IconWarn), display, ui.Muted.Render("skipped — differs from the stash"))
+			skipped++
+			continue
+		}
+
+		backup, err := stash.Settle(e, action)
+		if backup != "" {
+			fmt.Printf("  %s %s\n", ui.Muted.Render("backed up to"), ui.Muted.Render(strings.Replace(backup, home, "~", 1)))
+		}
+		if err != nil {
+			fmt.Printf("  %s %s %s\n", ui.Warning.Render(ui.IconWarn), display, ui.Muted.Render(err.Error()))
+			skipped++
+			continue
+		}
+		fmt.Printf("  %s %s\n", ui.Success.Render("●"), display)
+		restored++
+	}
+
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Restored %d of %d — welcome to your setup", restored, len(entries)))
+	if skipped > 0 {
+		fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("%d skipped; apply them later with `mine stash apply <file>`", skipped)))
+	}
+	fmt.Println()
+	return nil
+}
+
+// promptStashConflict asks what to do with an existing file that differs
+// from the stash. End of input skips it.
+func promptStashConflict(reader *bufio.Reader, display string) stash.ConflictAction {
+	for {
+		fmt.Printf("  %s %s differs from the stash — [o]verwrite · [b]ackup and overwrite · [s]kip: ",
+			ui.Warning.Render(ui.IconWarn), display)
+		line, readErr := reader.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "o", "overwrite":
+			return stash.ConflictOverwrite
+		case "b", "backup":
+			return stash.ConflictBackup
+		case "s", "skip":
+			return stash.ConflictSkip
+		}
+		if readErr == io.EOF {
+			return stash.ConflictSkip
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var stashDuCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show how much space the stash takes",
+	Long: `Report the stash repo's size, the largest file versions anywhere in its
+history, and how much each month's snapshots added. Tracked files bigger than
+stash.max_file_size (default 1MB) are flagged.
+
+--gc compacts the repo with git gc before measuring.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("stash.du", runStashDu),
+}
+
+var stashGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Compact the stash repo",
+	Long: `Run git gc on the stash repo, packing its history and pruning objects
+nothing refers to any more, such as those a purge left behind.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("stash.gc", runStashGC),
+}
+
+func init() {
+	stashCmd.AddCommand(stashDuCmd)
+	stashCmd.AddCommand(stashGCCmd)
+
+	stashDuCmd.Flags().Int("top", 10, "How many of the largest file versions to list")
+	stashDuCmd.Flags().Bool("gc", false, "Compact the stash repo with git gc first")
+}
+
+func runStashDu(cmd *cobra.Command, _ []string) error {
+	top, _ := cmd.Flags().GetInt("top")
+	if gc, _ := cmd.Flags().GetBool("gc"); gc {
+		if err := runStashGC(cmd, nil); err != nil {
+			return err
+		}
+	}
+
+	usage, err := stash.DiskUsage(top)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Printf("  %s %s in %d snapshots\n", ui.Accent.Render("Stash:"), formatBytes(usage.RepoBytes), usage.Snapshots)
+
+	if len(usage.Largest) > 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  Largest file versions"))
+		for _, b := range usage.Largest {
+			fmt.Printf("  %10s  %s\n", formatBytes(b.Bytes), b.Path)
+		}
+	}
+	if len(usage.Growth) > 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  History growth"))
+		for _, g := range usage.Growth {
+			fmt.Printf("  %s  %10s  %s\n", g.Month, "+"+formatBytes(g.Bytes), ui.Muted.Render(fmt.Sprintf("%d snapshots", g.Snapshots)))
+		}
+	}
+
+	entries, err := stash.ReadManifest()
+	if err != nil {
+		return err
+	}
+	if err := warnLargeStashFiles(entries); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+func runStashGC(_ *cobra.Command, _ []string) error {
+	before, after, err := stash.GC()
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Compacted the stash: %s → %s", formatBytes(before), formatBytes(after)))
+	return nil
+}
+
+// warnLargeStashFiles flags the files entries track that are bigger than
+// stash.max_file_size.
+func warnLargeStashFiles(entries []stash.Entry) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	limit := cfg.Stash.MaxFileBytes()
+	large, err := stash.LargeFiles(entries, limit)
+	if err != nil {
+		return err
+	}
+	if len(large) == 0 {
+		return nil
+	}
+	home, _ := os.UserHomeDir()
+	fmt.Println()
+	for _, f := range large {
+		ui.Warn(fmt.Sprintf("%s is %s, over the %s limit", strings.Replace(f.Path, home, "~", 1), formatBytes(f.Bytes), formatBytes(limit)))
+	}
+	fmt.Printf("  %s\n", ui.Muted.Render("Every version stays in history — exclude it, or raise stash.max_file_size."))
+	return nil
+}
+
+// formatBytes renders n as a human-readable size, e.g. 1.5 MB.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/vault"
+)
+
+func init() {
+
+	stash.PassphraseFunc = stashPassphrase
+}
+
+// stashPass remembers the vault passphrase once read, so one command touching
+// several encrypted files asks for it once.
+var stashPass string
+
+func stashPassphrase() (string, error) {
+	if stashPass == "" {
+		p, err := readPassphrase(false)
+		if err != nil {
+			return "", err
+		}
+		stashPass = p
+	}
+	return stashPass, nil
+}
+
+// confirmStashPassphrase reads the passphrase for a newly encrypted file. It
+// must open the vault when there is one; otherwise it's typed twice, since a
+// mistyped one would lock the file away.
+func confirmStashPassphrase() error {
+	if stashPass != "" {
+		return nil
+	}
+	v := vault.New("")
+	_, err := os.Stat(v.Path())
+	exists := err == nil
+	p, err := readPassphrase(!exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if _, err := vault.New(p).List(); err != nil {
+			return formatVaultError(err)
+		}
+	}
+	stashPass = p
+	return nil
+}
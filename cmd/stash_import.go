@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var stashImportCmd = &cobra.Command{
+	Use:   "import --from <path|url>",
+	Short: "Track the files of an existing dotfiles repo",
+	Long: `Convert a dotfiles repo you already have into tracked stash entries. The
+layout is detected:
+
+  chezmoi   a chezmoi source directory (dot_zshrc, private_dot_ssh/...)
+  stow      GNU Stow packages, one directory per program
+  home      a repo mirroring $HOME, like a bare-repo setup (~/.cfg)
+
+Files in your home directory aren't touched: compare them with mine stash
+diff, write the imported versions with mine stash apply, then snapshot with
+mine stash commit.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("stash.import", runStashImport),
+}
+
+var stashExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write a snapshot of tracked files to a tarball",
+	Long: `Archive every tracked file as of a snapshot — the latest, or the one --at
+names — into a gzipped tarball. Paths are relative to your home directory, so
+the dotfiles can be unpacked on a machine without mine:
+
+  tar -xzf dotfiles.tar.gz -C ~
+
+Encrypted files are left out.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("stash.export", runStashExport),
+}
+
+func init() {
+	stashCmd.AddCommand(stashImportCmd)
+	stashCmd.AddCommand(stashExportCmd)
+
+	stashImportCmd.Flags().String("from", "", "Dotfiles repo to import: a directory, bare repo, or git URL")
+	_ = stashImportCmd.MarkFlagRequired("from")
+	stashExportCmd.Flags().StringP("output", "o", "dotfiles.tar.gz", "Archive to write")
+	stashExportCmd.Flags().String("at", "", "Version to export (default: latest snapshot)")
+}
+
+func runStashImport(cmd *cobra.Command, _ []string) error {
+	from, _ := cmd.Flags().GetString("from")
+	if strings.HasPrefix(from, "~/") {
+		home, _ := os.UserHomeDir()
+		from = filepath.Join(home, from[2:])
+	}
+	if err := loadStashScrubber(); err != nil {
+		return err
+	}
+
+	result, err := stash.Import(from)
+	if err != nil {
+		return err
+	}
+
+	home, _ := os.UserHomeDir()
+	fmt.Println()
+	for _, e := range result.Imported {
+		fmt.Printf("  %s %s\n", ui.Success.Render("●"), strings.Replace(e.Source, home, "~", 1))
+	}
+	for _, s := range result.Skipped {
+		fmt.Printf("  %s %s %s\n", ui.Warning.Render(ui.IconWarn), s.Path, ui.Muted.Render(s.Reason))
+	}
+	fmt.Println()
+	printRedactions()
+	ui.Ok(fmt.Sprintf("Imported %d files from a %s repo", len(result.Imported), ui.Accent.Render(result.Layout)))
+	if len(result.Skipped) > 0 {
+		fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("%d skipped", len(result.Skipped))))
+	}
+	if len(result.Imported) > 0 {
+		fmt.Printf("  Compare with this machine: %s\n", ui.Accent.Render("mine stash diff"))
+		fmt.Printf("  Write them to your home:   %s\n", ui.Accent.Render("mine stash apply"))
+	}
+	fmt.Println()
+	return nil
+}
+
+func runStashExport(cmd *cobra.Command, _ []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	at, _ := cmd.Flags().GetString("at")
+
+	result, err := stash.Export(output, at)
+	if err != nil {
+		return err
+	}
+
+	home, _ := os.UserHomeDir()
+	fmt.Println()
+	for _, f := range result.Files {
+		fmt.Printf("  %s ~/%s\n", ui.Success.Render("●"), f)
+	}
+	for _, s := range result.Skipped {
+		fmt.Printf("  %s %s\n", ui.Warning.Render(ui.IconWarn), strings.Replace(s, home, "~", 1))
+	}
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Exported %d files at %s to %s", len(result.Files), ui.Accent.Render(result.Version), output))
+	if len(result.Skipped) > 0 {
+		fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("%d skipped: encrypted, or not in that snapshot", len(result.Skipped))))
+	}
+	fmt.Printf("  Unpack with: %s\n", ui.Accent.Render("tar -xzf "+output+" -C ~"))
+	fmt.Println()
+	return nil
+}
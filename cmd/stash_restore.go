@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var stashRestoreCmd = &cobra.Command{
+	Use:   "restore [file]",
+	Short: "Restore a dotfile to a previous snapshot",
+	Long: `Restore a tracked file to a snapshot — the latest, or the one --version names.
+
+Without a file, pick one of your tracked files and then a snapshot from its
+history.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("stash.restore", runStashRestore),
+}
+
+var stashApplyCmd = &cobra.Command{
+	Use:   "apply [file]",
+	Short: "Write stashed dotfiles to this machine",
+	Long: `Write the stash's current copy of every tracked file (or just one) to its
+source path. Files with a variant for this host get the variant; the rest get
+the base file.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("stash.apply", runStashApply),
+}
+
+func init() {
+	stashCmd.AddCommand(stashRestoreCmd)
+	stashCmd.AddCommand(stashApplyCmd)
+
+	stashRestoreCmd.Flags().StringP("version", "v", "", "Version hash to restore (default: latest)")
+	stashRestoreCmd.Flags().BoolP("force", "f", false, "Override destination file permissions with the ones recorded at commit time")
+
+	stash.AfterRestore = runStashOnRestore
+}
+
+func runStashRestore(cmd *cobra.Command, args []string) error {
+	version, _ := cmd.Flags().GetString("version")
+	force, _ := cmd.Flags().GetBool("force")
+
+	var file string
+	if len(args) > 0 {
+		file = args[0]
+	} else {
+		if !stashIsTTY() {
+			return fmt.Errorf("which file? — run `mine stash restore <file>`, or run it in a terminal to pick one")
+		}
+		var err error
+		file, version, err = pickStashRestore(version)
+		if err != nil || file == "" {
+			return err // nil when the user canceled
+		}
+	}
+
+	if err := loadStashScrubber(); err != nil {
+		return err
+	}
+	// RestoreToSource returns the Entry, avoiding duplicate FindEntry calls
+	entry, err := stash.RestoreToSource(file, version, force)
+	if err != nil {
+		return err
+	}
+
+	home, _ := os.UserHomeDir()
+	display := strings.Replace(entry.Source, home, "~", 1)
+
+	versionLabel := "latest"
+	if version != "" {
+		versionLabel = version
+	}
+
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Restored %s to %s", display, versionLabel))
+	fmt.Println()
+	return nil
+}
+
+// stashFileItem is a tracked entry in the restore picker.
+type stashFileItem struct {
+	entry   stash.Entry
+	display string
+	history string
+}
+
+func (i stashFileItem) FilterValue() string { return i.display }
+
+func (i stashFileItem) Title() string { return i.display }
+
+func (i stashFileItem) Description() string { return i.history }
+
+// stashVersionItem is a snapshot in the restore picker.
+type stashVersionItem struct {
+	log stash.LogEntry
+}
+
+func (i stashVersionItem) FilterValue() string { return i.log.Short + " " + i.log.Message }
+
+func (i stashVersionItem) Title() string { return i.log.Short + "  " + i.log.Message }
+
+func (i stashVersionItem) Description() string {
+	return i.log.Date.Format("2006-01-02 15:04") + " · " + formatAge(i.log.Date)
+}
+
+// pickStashRestore lets the user pick a tracked file and then, unless
+// version is already set, one of its snapshots. Returns the file's source
+// path and the version, shortened when picked, or "" for both if the user
+// canceled.
+func pickStashRestore(version string) (string, string, error) {
+	entries, err := stash.ReadManifest()
+	if err != nil {
+		return "", "", err
+	}
+	if len(entries) == 0 {
+		return "", "", fmt.Errorf("nothing to restore — add a file first with `mine stash track ~/.zshrc`")
+	}
+
+	home, _ := os.UserHomeDir()
+	items := make([]tui.Item, len(entries))
+	for i, e := range entries {
+		item := stashFileItem{entry: e, display: strings.Replace(e.Source, home, "~", 1)}
+		if e.Dir {
+			item.display += "/"
+		}
+		if logs, err := stash.Log(e.Source); err == nil && len(logs) > 0 {
+			item.history = fmt.Sprintf("%d snapshots · last %s", len(logs), formatAge(logs[0].Date))
+		}
+		items[i] = item
+	}
+	chosen, err := stashPick(items,
+		tui.WithTitle(ui.IconMine+"Restore which file?"),
+		tui.WithHeight(12),
+	)
+	if err != nil || chosen == nil {
+		return "", "", err
+	}
+	entry, display := chosen.(stashFileItem).entry, chosen.Title()
+	if version != "" {
+		return entry.Source, version, nil
+	}
+
+	logs, err := stash.Log(entry.Source)
+	if err != nil {
+		return "", "", err
+	}
+	if len(logs) == 0 {
+		return "", "", fmt.Errorf("no snapshots of %s yet — run `mine stash commit` first", display)
+	}
+	items = make([]tui.Item, len(logs))
+	for i, l := range logs {
+		items[i] = stashVersionItem{log: l}
+	}
+	chosen, err = stashPick(items,
+		tui.WithTitle(ui.IconMine+"Restore "+display+" to"),
+		tui.WithHeight(12),
+	)
+	if err != nil || chosen == nil {
+		return "", "", err
+	}
+	return entry.Source, chosen.(stashVersionItem).log.Short, nil
+}
+
+// stashPick shows the restore pickers. Injectable for testing.
+var stashPick = tui.Run
+
+func runStashApply(_ *cobra.Command, args []string) error {
+	file := ""
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	if err := loadStashScrubber(); err != nil {
+		return err
+	}
+	applied, err := stash.Apply(file)
+	if err != nil {
+		return err
+	}
+
+	home, _ := os.UserHomeDir()
+	fmt.Println()
+	for _, e := range applied {
+		display := strings.Replace(e.Source, home, "~", 1)
+		if h := stash.Variant(e); h != "" {
+			display += ui.Muted.Render(" @" + h)
+		}
+		if e.Link != "" {
+			display += ui.Muted.Render(" → " + e.Link)
+		}
+		fmt.Printf("  %s %s\n", ui.Success.Render("●"), display)
+	}
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Applied %d tracked files for %s", len(applied), ui.Accent.Render(stash.Hostname())))
+	fmt.Println()
+	return nil
+}
+
+// runStashOnRestore runs an entry's on-restore command once its source has
+// been restored, showing the command and any output. A failure is reported
+// but doesn't stop the restore.
+func runStashOnRestore(e stash.Entry) {
+	fmt.Printf("  %s %s\n", ui.Muted.Render("running"), ui.Accent.Render(e.OnRestore))
+	out, err := stash.RunOnRestore(e)
+	if text := strings.TrimRight(string(out), "\n"); text != "" {
+		for _, line := range strings.Split(text, "\n") {
+			fmt.Printf("    %s\n", ui.Muted.Render(line))
+		}
+	}
+	if err != nil {
+		ui.Warn(fmt.Sprintf("on-restore command for %s failed: %v", filepath.Base(e.Source), err))
+	}
+}
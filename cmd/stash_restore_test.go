@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/tui"
+)
+
+// stashRestoreEnv tracks ~/.zshrc with two snapshots and returns its path
+// and the stash log, newest first.
+func stashRestoreEnv(t *testing.T) (string, []stash.LogEntry) {
+	t.Helper()
+	configTestEnv(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	zshrc := filepath.Join(home, ".zshrc")
+	for i, content := range []string{"export A=1\n", "export A=2\n"} {
+		if err := os.WriteFile(zshrc, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			if _, err := stash.TrackFile(zshrc); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := stash.Commit("snapshot"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	logs, err := stash.Log(zshrc)
+	if err != nil || len(logs) != 2 {
+		t.Fatalf("stash.Log = %v, %v; want 2 entries", logs, err)
+	}
+	return zshrc, logs
+}
+
+// withStashPicks makes stashPick return the item at each index in turn.
+func withStashPicks(t *testing.T, picks ...int) {
+	t.Helper()
+	orig := stashPick
+	t.Cleanup(func() { stashPick = orig })
+	stashPick = func(items []tui.Item, _ ...tui.PickerOption) (tui.Item, error) {
+		if len(picks) == 0 {
+			t.Fatal("unexpected picker")
+		}
+		i := picks[0]
+		picks = picks[1:]
+		return items[i], nil
+	}
+}
+
+func TestStashRestore_NoArgsWithoutTTY(t *testing.T) {
+	orig := stashIsTTY
+	stashIsTTY = func() bool { return false }
+	t.Cleanup(func() { stashIsTTY = orig })
+
+	err := runStashRestore(stashRestoreCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "mine stash restore <file>") {
+		t.Fatalf("runStashRestore() error = %v, want a usage hint", err)
+	}
+}
+
+func TestPickStashRestore(t *testing.T) {
+	zshrc, logs := stashRestoreEnv(t)
+
+	withStashPicks(t, 0, 1)
+	file, version, err := pickStashRestore("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file != zshrc {
+		t.Errorf("file = %q, want %q", file, zshrc)
+	}
+	if version != logs[1].Short {
+		t.Errorf("version = %q, want the older snapshot %q", version, logs[1].Short)
+	}
+
+	// A version the user passed skips the snapshot picker and is kept as is.
+	withStashPicks(t, 0)
+	if _, version, err = pickStashRestore(logs[0].Hash); err != nil || version != logs[0].Hash {
+		t.Errorf("pickStashRestore(hash) = %q, %v; want %q", version, err, logs[0].Hash)
+	}
+}
+
+func TestStashRestore_PickedVersion(t *testing.T) {
+	zshrc, logs := stashRestoreEnv(t)
+	orig := stashIsTTY
+	stashIsTTY = func() bool { return true }
+	t.Cleanup(func() { stashIsTTY = orig })
+	withStashPicks(t, 0, 1)
+
+	out := captureStdout(t, func() {
+		if err := runStashRestore(stashRestoreCmd, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, logs[1].Short) {
+		t.Errorf("output = %q, want it to name %s", out, logs[1].Short)
+	}
+	got, _ := os.ReadFile(zshrc)
+	if string(got) != "export A=1\n" {
+		t.Errorf("~/.zshrc = %q, want the older snapshot", got)
+	}
+}
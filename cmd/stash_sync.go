@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var stashSyncCmd = &cobra.Command{
+	Use:   "sync <push|pull|remote>",
+	Short: "Back up your stash to a git remote",
+	Long: `Sync your stash with a git remote. Opt-in cloud backup.
+
+  mine stash sync remote <url>   Set the remote repository URL
+  mine stash sync push           Push stash to remote
+  mine stash sync pull           Pull stash from remote
+
+When both this machine and the remote have new snapshots, pull asks how to
+reconcile them, or takes --strategy: rebase or merge (asking which side to
+keep for each file changed on both), ours (keep this machine's copies), or
+theirs (take the remote's). Outside a terminal it rebases. If the pull can't
+finish, the stash is left as it was.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: hook.Wrap("stash.sync", runStashSync),
+}
+
+func init() {
+	stashCmd.AddCommand(stashSyncCmd)
+
+	stashSyncCmd.Flags().String("strategy", "", "If the stash and remote have diverged on pull: rebase, merge, ours, or theirs")
+}
+
+func runStashSync(cmd *cobra.Command, args []string) error {
+	action := args[0]
+	switch action {
+	case "remote":
+		if len(args) < 2 {
+			// Show current remote.
+			url := stash.SyncRemoteURL()
+			if url == "" {
+				fmt.Println()
+				fmt.Println(ui.Muted.Render("  No remote configured."))
+				fmt.Printf("  Set one: %s\n", ui.Accent.Render("mine stash sync remote <url>"))
+				fmt.Println()
+			} else {
+				fmt.Println()
+				ui.Kv("remote", url)
+				fmt.Println()
+			}
+			return nil
+		}
+		url := args[1]
+		if err := stash.SyncSetRemote(url); err != nil {
+			return err
+		}
+		fmt.Println()
+		ui.Ok(fmt.Sprintf("Remote set to %s", url))
+		fmt.Println()
+		return nil
+
+	case "push":
+		if err := stash.SyncPush(); err != nil {
+			return err
+		}
+		fmt.Println()
+		ui.Ok("Stash backed up to remote — your configs are safe in the cloud")
+		fmt.Println()
+		return nil
+
+	case "pull":
+		if err := loadStashScrubber(); err != nil {
+			return err
+		}
+		strategy, _ := cmd.Flags().GetString("strategy")
+		used, err := pullStash(stash.PullStrategy(strategy))
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+		ui.Ok("Stash pulled and restored — welcome back to your setup")
+		if used != stash.PullFastForward {
+			fmt.Printf("  Share the result with %s\n", ui.Accent.Render("mine stash sync push"))
+		}
+		fmt.Println()
+		return nil
+
+	default:
+		return fmt.Errorf("unknown sync action %q — use push, pull, or remote", action)
+	}
+}
+
+// pullStash pulls with strategy. At a terminal, a stash that has diverged
+// from the remote prompts for a strategy, and conflicting files for a side.
+// Without one, it rebases, as pull always has, so scripted pulls keep
+// working. Returns the strategy used.
+func pullStash(strategy stash.PullStrategy) (stash.PullStrategy, error) {
+	reader := bufio.NewReader(os.Stdin)
+	var resolve stash.ConflictResolver
+	if stashIsTTY() {
+		resolve = func(file string) (stash.PullStrategy, error) {
+			return promptStashPullSide(reader, file), nil
+		}
+	}
+	err := stash.SyncPullWith(strategy, resolve)
+	var diverged *stash.DivergedError
+	if !errors.As(err, &diverged) {
+		return strategy, err
+	}
+	if !stashIsTTY() {
+		return stash.PullRebase, stash.SyncPullWith(stash.PullRebase, nil)
+	}
+
+	fmt.Println()
+	ui.Warn(fmt.Sprintf("Your stash and the remote have diverged — %d local and %d remote snapshot(s)", diverged.Ahead, diverged.Behind))
+	for _, f := range diverged.Files {
+		fmt.Printf("  %s %s\n", ui.Muted.Render("changed on both:"), f)
+	}
+	strategy = promptStashPullStrategy(reader)
+	if strategy == stash.PullFastForward {
+		return strategy, fmt.Errorf("pull cancelled — stash unchanged")
+	}
+	return strategy, stash.SyncPullWith(strategy, resolve)
+}
+
+// promptStashPullStrategy asks how to reconcile a diverged stash. End of
+// input, or cancelling, returns PullFastForward.
+func promptStashPullStrategy(reader *bufio.Reader) stash.PullStrategy {
+	for {
+		fmt.Printf("  [r]ebase · [m]erge · keep [o]urs · take [t]heirs · [c]ancel: ")
+		line, readErr := reader.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "r", "rebase":
+			return stash.PullRebase
+		case "m", "merge":
+			return stash.PullMerge
+		case "o", "ours":
+			return stash.PullOurs
+		case "t", "theirs":
+			return stash.PullTheirs
+		case "c", "cancel":
+			return stash.PullFastForward
+		}
+		if readErr == io.EOF {
+			return stash.PullFastForward
+		}
+	}
+}
+
+// promptStashPullSide asks which copy of a file changed on both machines to
+// keep. End of input, or aborting, returns "", which abandons the pull.
+func promptStashPullSide(reader *bufio.Reader, file string) stash.PullStrategy {
+	for {
+		fmt.Printf("  %s %s conflicts — keep [l]ocal · take [r]emote · [a]bort: ", ui.Warning.Render(ui.IconWarn), file)
+		line, readErr := reader.ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "l", "local":
+			return stash.PullOurs
+		case "r", "remote":
+			return stash.PullTheirs
+		case "a", "abort":
+			return ""
+		}
+		if readErr == io.EOF {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/stash"
+)
+
+// runGit runs a git command in dir for test setup.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", args[0], err, out)
+	}
+}
+
+func TestPullStash_RebasesWithoutTTY(t *testing.T) {
+	configTestEnv(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	orig := stashIsTTY
+	stashIsTTY = func() bool { return false }
+	t.Cleanup(func() { stashIsTTY = orig })
+
+	zshrc, bashrc := filepath.Join(home, ".zshrc"), filepath.Join(home, ".bashrc")
+	for _, f := range []string{zshrc, bashrc} {
+		if err := os.WriteFile(f, []byte("base"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := stash.TrackFile(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := stash.Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+	remote := t.TempDir()
+	runGit(t, remote, "init", "--quiet", "--bare")
+	if err := stash.SyncSetRemote("file://" + remote); err != nil {
+		t.Fatal(err)
+	}
+	if err := stash.SyncPush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another machine changes .bashrc while this one changes .zshrc.
+	other := t.TempDir()
+	runGit(t, other, "clone", "--quiet", "file://"+remote, ".")
+	if err := os.WriteFile(filepath.Join(other, ".bashrc"), []byte("remote"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, other, "commit", "-qam", "remote edit")
+	runGit(t, other, "push", "--quiet", "origin", "HEAD")
+	if err := os.WriteFile(zshrc, []byte("local"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stash.Commit("local edit"); err != nil {
+		t.Fatal(err)
+	}
+
+	used, err := pullStash(stash.PullFastForward)
+	if err != nil {
+		t.Fatalf("pullStash() error: %v", err)
+	}
+	if used != stash.PullRebase {
+		t.Errorf("pullStash() used %q, want rebase", used)
+	}
+	if got, _ := os.ReadFile(bashrc); string(got) != "remote" {
+		t.Errorf(".bashrc = %q, want the remote's change", got)
+	}
+	if got, _ := os.ReadFile(zshrc); string(got) != "local" {
+		t.Errorf(".zshrc = %q, want the local change kept", got)
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/stash"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var stashTrackCmd = &cobra.Command{
+	Use:   "track <file|dir>",
+	Short: "Start tracking a dotfile",
+	Long: `Start tracking a dotfile, or a whole config directory such as ~/.config/nvim.
+
+Directories are copied recursively on every commit, picking up new files and
+recording deleted ones. --include limits them to files matching a glob and
+--exclude skips files and subdirectories; a pattern without "/" matches a
+name at any depth. Tracking a directory again replaces its patterns. A
+.mine-stash-ignore file inside the directory, in .gitignore syntax, keeps
+caches and history files out too. .git directories are never copied.
+
+With --host, the file's current content is stored as a variant for this
+machine (e.g. .zshrc@work-laptop). This machine then commits to and restores
+from its variant, while machines without one keep using the base file. The
+host name is the short hostname, or MINE_STASH_HOST if set.
+
+With --encrypt, the stashed copy is encrypted with age using the vault
+passphrase, so files holding credentials (.netrc, .npmrc, ...) can be
+committed and synced without exposing their contents. Diff, commit, restore,
+and sync pull decrypt it as needed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("stash.track", runStashTrack),
+}
+
+var stashUntrackCmd = &cobra.Command{
+	Use:   "untrack <file>",
+	Short: "Stop tracking a dotfile",
+	Long: `Remove a file from the stash. The file itself is left alone.
+
+Its past snapshots stay in history unless you pass --purge, which rewrites
+the stash's history so no snapshot ever held it — for a secret tracked by
+mistake.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("stash.untrack", runStashUntrack),
+}
+
+func init() {
+	stashCmd.AddCommand(stashTrackCmd)
+	stashCmd.AddCommand(stashUntrackCmd)
+
+	stashTrackCmd.Flags().Bool("encrypt", false, "Encrypt the stashed copy with the vault passphrase")
+	stashTrackCmd.Flags().Bool("host", false, "Store this machine's version as a variant for this host")
+	stashTrackCmd.Flags().StringSlice("include", nil, "For directories: only track files matching these globs")
+	stashTrackCmd.Flags().StringSlice("exclude", nil, "For directories: skip files and subdirectories matching these globs")
+	stashTrackCmd.Flags().String("on-restore", "", "Shell command to run after the file is restored, e.g. to reload it (\"\" removes it)")
+	stashUntrackCmd.Flags().Bool("purge", false, "Also rewrite stash history to remove every trace of the file")
+}
+
+func runStashTrack(cmd *cobra.Command, args []string) error {
+	source := args[0]
+	encrypt, _ := cmd.Flags().GetBool("encrypt")
+	host, _ := cmd.Flags().GetBool("host")
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+
+	// Expand ~ to home dir.
+	if strings.HasPrefix(source, "~") {
+		home, _ := os.UserHomeDir()
+		source = filepath.Join(home, source[1:])
+	}
+
+	source, err := filepath.Abs(source)
+	if err != nil {
+		return err
+	}
+	if err := loadStashScrubber(); err != nil {
+		return err
+	}
+
+	var entry *stash.Entry
+	switch {
+	case encrypt && (len(include) > 0 || len(exclude) > 0):
+		return fmt.Errorf("--encrypt can't be combined with --include or --exclude — directories can't be encrypted")
+	case host && (encrypt || len(include) > 0 || len(exclude) > 0):
+		return fmt.Errorf("--host can't be combined with --encrypt, --include, or --exclude — track the file first, then add its variant")
+	case host:
+		if e, findErr := stash.FindEntry(source); findErr == nil && e.Encrypted {
+			if err := confirmStashPassphrase(); err != nil {
+				return err
+			}
+		}
+		entry, err = stash.TrackVariant(source)
+	case encrypt:
+		if err := confirmStashPassphrase(); err != nil {
+			return err
+		}
+		entry, err = stash.TrackFileEncrypted(source)
+	case len(include) > 0 || len(exclude) > 0:
+		entry, err = stash.TrackDir(source, include, exclude)
+	default:
+		entry, err = stash.TrackFile(source)
+	}
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("on-restore") {
+		onRestore, _ := cmd.Flags().GetString("on-restore")
+		if entry, err = stash.SetOnRestore(entry.Source, onRestore); err != nil {
+			return err
+		}
+	}
+
+	home, _ := os.UserHomeDir()
+	relPath := strings.TrimPrefix(entry.Source, home+"/")
+	dest := filepath.Join(stash.Dir(), entry.SafeName)
+
+	switch {
+	case host:
+		ui.Ok(fmt.Sprintf("Tracking %s for %s", relPath, ui.Accent.Render(stash.Hostname())))
+		dest += "@" + stash.Hostname()
+	case entry.Encrypted:
+		ui.Ok(fmt.Sprintf("Tracking %s (encrypted)", relPath))
+	case entry.Dir:
+		ui.Ok(fmt.Sprintf("Tracking %s/", relPath))
+	default:
+		ui.Ok(fmt.Sprintf("Tracking %s", relPath))
+	}
+	fmt.Printf("  Stashed to: %s\n", ui.Muted.Render(dest))
+	printStashOptions(*entry)
+	printRedactions()
+	if err := warnLargeStashFiles([]stash.Entry{*entry}); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+func runStashUntrack(cmd *cobra.Command, args []string) error {
+	purge, _ := cmd.Flags().GetBool("purge")
+
+	entry, purged, err := stash.Untrack(args[0], purge)
+	if err != nil {
+		return err
+	}
+
+	home, _ := os.UserHomeDir()
+	display := strings.Replace(entry.Source, home, "~", 1)
+	fmt.Println()
+	ui.Ok(fmt.Sprintf("Stopped tracking %s", display))
+	switch {
+	case purged:
+		ui.Ok("Purged it from every snapshot")
+		if stash.SyncRemoteURL() != "" {
+			ui.Warn("Your remote still has the old history")
+			fmt.Printf("  Overwrite it with %s, then re-clone the stash on your other machines.\n",
+				ui.Accent.Render("git -C "+stash.Dir()+" push --force origin HEAD"))
+		}
+		fmt.Printf("  %s\n", ui.Muted.Render("If it held a secret, rotate it anyway — it may have been copied elsewhere."))
+	case purge:
+		fmt.Printf("  %s\n", ui.Muted.Render("It was never committed, so there's no history to purge."))
+	default:
+		fmt.Printf("  %s\n", ui.Muted.Render("The file is untouched; its past snapshots stay in history (--purge removes them)."))
+	}
+	fmt.Println()
+	return nil
+}
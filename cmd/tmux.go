@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/rnwolfe/mine/internal/config"
 	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
 	"github.com/rnwolfe/mine/internal/tmux"
 	"github.com/rnwolfe/mine/internal/tui"
 	"github.com/rnwolfe/mine/internal/ui"
@@ -27,12 +30,15 @@ var tmuxNewLayout string
 
 // Injectable for testing — only used in runTmuxNew.
 var (
-	readLayoutFunc  = tmux.ReadLayout
+	readLayoutFunc  = tmux.FindLayout
 	newSessionFunc  = tmux.NewSession
-	loadLayoutFunc  = tmux.LoadLayout
+	loadLayoutFunc  = tmux.ApplyLayoutToSession
 	killSessionFunc = tmux.KillSession
 )
 
+// attachSessionFunc replaces the process with tmux; stubbed in tests.
+var attachSessionFunc = tmux.AttachSession
+
 func init() {
 	rootCmd.AddCommand(tmuxCmd)
 
@@ -90,7 +96,7 @@ func runTmux(_ *cobra.Command, _ []string) error {
 		return nil // user canceled
 	}
 
-	return tmux.AttachSession(chosen.Title())
+	return attachSession(chosen.Title())
 }
 
 // --- mine tmux new ---
@@ -98,9 +104,12 @@ func runTmux(_ *cobra.Command, _ []string) error {
 var tmuxNewCmd = &cobra.Command{
 	Use:   "new [name]",
 	Short: "Create a new tmux session",
-	Long:  `Create a named tmux session. Auto-names from the current directory if omitted.`,
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  hook.Wrap("tmux.new", runTmuxNew),
+	Long: `Create a named tmux session. Auto-names from the current directory if omitted.
+
+With --layout, the layout is looked up in the current project's
+.mine/layouts directory first, then among your saved layouts.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("tmux.new", runTmuxNew),
 }
 
 func runTmuxNew(_ *cobra.Command, args []string) error {
@@ -114,8 +123,11 @@ func runTmuxNew(_ *cobra.Command, args []string) error {
 	}
 
 	// Validate layout exists before creating the session — fail fast, no side effects.
+	var layout *tmux.Layout
 	if tmuxNewLayout != "" {
-		if _, err := readLayoutFunc(tmuxNewLayout); err != nil {
+		cwd, _ := os.Getwd()
+		var err error
+		if layout, err = readLayoutFunc(tmuxNewLayout, cwd); err != nil {
 			return fmt.Errorf("layout %q not found — session not created; save a layout first: mine tmux layout save %s",
 				tmuxNewLayout, tmuxNewLayout)
 		}
@@ -127,7 +139,7 @@ func runTmuxNew(_ *cobra.Command, args []string) error {
 	}
 
 	if tmuxNewLayout != "" {
-		if err := loadLayoutFunc(tmuxNewLayout); err != nil {
+		if err := loadLayoutFunc(layout, resolved); err != nil {
 			// Best-effort cleanup: kill the newly created session to avoid leaving it orphaned.
 			if killErr := killSessionFunc(resolved); killErr != nil {
 				return fmt.Errorf("layout %q failed to apply: %v (also failed to clean up session %q: %v)",
@@ -155,8 +167,10 @@ var tmuxProjectCmd = &cobra.Command{
 one already exists. Session name is derived from the directory basename.
 
 If --layout is specified, the saved layout is applied after creating a new
-session (not applied when attaching to an existing one). The layout must
-already exist or an error is returned.`,
+session (not applied when attaching to an existing one). Without it, the
+tmux.layout config value is used, which a project's .mine.toml can override.
+Layouts in the project's .mine/layouts directory win over saved layouts of
+the same name. The layout must exist or an error is returned.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: hook.Wrap("tmux.project", runTmuxProject),
 }
@@ -170,25 +184,40 @@ func runTmuxProject(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		dir = args[0]
 	}
+	return openProjectSession(dir, tmuxProjectLayout)
+}
+
+// openProjectSession attaches to dir's session, creating it with layout
+// first if it isn't running. An empty layout falls back to tmux.layout.
+func openProjectSession(dir, layout string) error {
+	resolvedDir, sessionName, exists, err := tmux.ResolveProjectSession(dir)
+	if err != nil {
+		return err
+	}
+
+	// Without --layout, fall back to tmux.layout from config (or the
+	// project's .mine.toml).
+	if layout == "" {
+		cfg, err := config.LoadForDir(resolvedDir)
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+		layout = cfg.Tmux.Layout
+	}
 
 	// Pre-validate layout before doing any session work.
-	layout := tmuxProjectLayout
+	var spec *tmux.Layout
 	if layout != "" {
-		if _, err := tmux.ReadLayout(layout); err != nil {
+		if spec, err = tmux.FindLayout(layout, resolvedDir); err != nil {
 			return fmt.Errorf("layout %q not found — save it first with: mine tmux layout save %s", layout, layout)
 		}
 	}
 
-	resolvedDir, sessionName, exists, err := tmux.ResolveProjectSession(dir)
-	if err != nil {
-		return err
-	}
-
 	if exists {
 		fmt.Println()
 		fmt.Printf("  Session %s already running — attaching\n", ui.Accent.Render(sessionName))
 		fmt.Println()
-		return tmux.AttachSession(sessionName)
+		return attachSession(sessionName)
 	}
 
 	// Create the session (detached) starting in the project directory.
@@ -197,15 +226,15 @@ func runTmuxProject(cmd *cobra.Command, args []string) error {
 	}
 
 	// Apply layout to the new session before attaching.
-	if layout != "" {
-		if err := tmux.LoadLayoutToSession(layout, sessionName); err != nil {
+	if spec != nil {
+		if err := tmux.ApplyLayoutToSession(spec, sessionName); err != nil {
 			return err
 		}
 	}
 
 	ui.Ok(fmt.Sprintf("Session %s created", ui.Accent.Render(sessionName)))
 	fmt.Println()
-	return tmux.AttachSession(sessionName)
+	return attachSession(sessionName)
 }
 
 // --- mine tmux ls ---
@@ -267,7 +296,7 @@ func runTmuxAttach(_ *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		return tmux.AttachSession(s.Name)
+		return attachSession(s.Name)
 	}
 
 	// No name: use picker if TTY, else show list.
@@ -293,7 +322,7 @@ func runTmuxAttach(_ *cobra.Command, args []string) error {
 		return nil
 	}
 
-	return tmux.AttachSession(chosen.Title())
+	return attachSession(chosen.Title())
 }
 
 // --- mine tmux kill ---
@@ -403,7 +432,7 @@ func runTmuxRename(_ *cobra.Command, args []string) error {
 		if newName == "" {
 			return fmt.Errorf("new session name cannot be empty")
 		}
-		if err := tmux.RenameSession(oldName, newName); err != nil {
+		if err := renameSession(oldName, newName); err != nil {
 			return err
 		}
 		ui.Ok(fmt.Sprintf("Renamed session %s → %s", ui.Accent.Render(oldName), ui.Accent.Render(newName)))
@@ -457,7 +486,7 @@ func runTmuxRename(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("new session name cannot be empty")
 	}
 
-	if err := tmux.RenameSession(oldName, newName); err != nil {
+	if err := renameSession(oldName, newName); err != nil {
 		return err
 	}
 
@@ -490,3 +519,32 @@ func printSessionList(sessions []tmux.Session) error {
 	fmt.Println()
 	return nil
 }
+
+// attachSession attaches or switches to name, first recording it (and the
+// session being left) for 'mine tmux last'.
+func attachSession(name string) error {
+	if db, err := store.Open(); err == nil {
+		h := tmux.NewHistory(db.Conn())
+		now := time.Now()
+		if current, err := tmux.CurrentSession(); err == nil && current != name {
+			// Left just before the switch, so name stays the most recent.
+			_ = h.Touch(current, now.Add(-time.Millisecond))
+		}
+		_ = h.Touch(name, now)
+		db.Close()
+	}
+	return attachSessionFunc(name)
+}
+
+// renameSession renames a session and carries its 'mine tmux last' history
+// over to the new name.
+func renameSession(oldName, newName string) error {
+	if err := tmux.RenameSession(oldName, newName); err != nil {
+		return err
+	}
+	if db, err := store.Open(); err == nil {
+		_ = tmux.NewHistory(db.Conn()).Rename(oldName, newName)
+		db.Close()
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var tmuxAutoFrom string
+
+func init() {
+	tmuxCmd.AddCommand(tmuxAutoCmd)
+	tmuxAutoCmd.Flags().StringVar(&tmuxAutoFrom, "from", "", "Directory the shell just left")
+}
+
+var tmuxAutoCmd = &cobra.Command{
+	Use:   "auto",
+	Short: "Offer or attach the current project's session (run by the shell hook)",
+	Long: `Called by the shell hook when you cd outside tmux. If the new directory is
+inside a registered project — and you didn't just come from elsewhere in the
+same project — it acts on tmux.auto_session, or the project's
+tmux_auto_session setting if set:
+
+  off     do nothing (the default)
+  ask     offer to create or attach the project's session
+  attach  create or attach it straight away
+
+New sessions get the project's tmux_layout. The hook is added to
+'mine shell init' once either setting is ask or attach:
+
+  mine config set tmux.auto_session ask
+  mine proj config tmux_auto_session attach`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("tmux.auto", runTmuxAuto),
+}
+
+func runTmuxAuto(_ *cobra.Command, _ []string) error {
+	if !tmux.Available() || tmux.InsideTmux() {
+		return nil
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	ps := proj.NewStore(db.Conn())
+	p, mode, err := tmuxAutoTarget(ps, cfg, tmuxAutoFrom)
+	if err != nil || p == nil {
+		return err
+	}
+
+	switch mode {
+	case "ask":
+		if !tui.IsTTY() || !confirmPrompt(fmt.Sprintf("Open tmux session for %s?", p.Name)) {
+			return nil
+		}
+	case "attach":
+	default:
+		return nil
+	}
+
+	layout, _ := ps.GetSetting(p.Name, "tmux_layout")
+	return openProjectSession(p.Path, layout)
+}
+
+// tmuxAutoTarget returns the project the current directory entered and its
+// auto-session mode, or nil when the move stayed within one project or left
+// projects altogether.
+func tmuxAutoTarget(ps *proj.Store, cfg *config.Config, from string) (*proj.Project, string, error) {
+	p, err := ps.FindForCWD()
+	if err != nil || p == nil {
+		return nil, "", err
+	}
+	if from != "" {
+		if prev, _ := ps.FindForPath(from); prev != nil && prev.Name == p.Name {
+			return nil, "", nil
+		}
+	}
+	mode, err := ps.GetSetting(p.Name, "tmux_auto_session")
+	if err != nil {
+		return nil, "", err
+	}
+	if mode == "" {
+		mode = cfg.Tmux.AutoSession
+	}
+	return p, mode, nil
+}
+
+// tmuxAutoEnabled reports whether any project can get an auto-session, so
+// 'mine shell init' only installs the cd hook for people who opted in.
+func tmuxAutoEnabled() bool {
+	cfg, err := config.Load()
+	if err != nil {
+		return false
+	}
+	if cfg.Tmux.AutoSession == "ask" || cfg.Tmux.AutoSession == "attach" {
+		return true
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return false
+	}
+	defer db.Close()
+
+	modes, err := proj.NewStore(db.Conn()).SettingValues("tmux_auto_session")
+	if err != nil {
+		return false
+	}
+	for _, mode := range modes {
+		if mode != "off" {
+			return true
+		}
+	}
+	return false
+}
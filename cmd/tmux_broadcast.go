@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var tmuxBroadcastWindow string
+
+func init() {
+	tmuxCmd.AddCommand(tmuxBroadcastCmd)
+	tmuxCmd.AddCommand(tmuxRunAllCmd)
+
+	for _, c := range []*cobra.Command{tmuxBroadcastCmd, tmuxRunAllCmd} {
+		c.Flags().StringVarP(&tmuxBroadcastWindow, "window", "w", "", "Target window, e.g. servers or dev:servers (defaults to current)")
+	}
+}
+
+var tmuxBroadcastCmd = &cobra.Command{
+	Use:   "broadcast [on|off|toggle]",
+	Short: "Type into every pane of the window at once",
+	Long: `Turn tmux's synchronize-panes on or off for a window, so whatever you type
+goes to all of its panes — handy for a window of ssh sessions. With no
+argument, toggles it.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off", "toggle"},
+	RunE:      hook.Wrap("tmux.broadcast", runTmuxBroadcast),
+}
+
+var tmuxRunAllCmd = &cobra.Command{
+	Use:   "run-all -- <command>",
+	Short: "Run a command in every pane of the window",
+	Long: `Type a command into every pane of a window and press Enter in each, without
+leaving broadcast mode on.
+
+  mine tmux run-all -- uptime
+  mine tmux run-all -w servers -- sudo systemctl restart api`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: hook.Wrap("tmux.run-all", runTmuxRunAll),
+}
+
+// checkBroadcastTarget errors unless there's a window to act on.
+func checkBroadcastTarget() error {
+	if !tmux.Available() {
+		return fmt.Errorf("tmux not found in PATH")
+	}
+	if tmuxBroadcastWindow == "" && !tmux.InsideTmux() {
+		return fmt.Errorf("not inside a tmux session — use %s to target a window",
+			ui.Accent.Render("--window <session:window>"))
+	}
+	return nil
+}
+
+func runTmuxBroadcast(_ *cobra.Command, args []string) error {
+	if err := checkBroadcastTarget(); err != nil {
+		return err
+	}
+
+	mode := "toggle"
+	if len(args) > 0 {
+		mode = strings.ToLower(args[0])
+	}
+	var on bool
+	switch mode {
+	case "on":
+		on = true
+	case "off":
+	case "toggle":
+		current, err := tmux.Synchronized(tmuxBroadcastWindow)
+		if err != nil {
+			return err
+		}
+		on = !current
+	default:
+		return fmt.Errorf("unknown mode %q — use on, off, or toggle", args[0])
+	}
+
+	if err := tmux.SetSynchronized(tmuxBroadcastWindow, on); err != nil {
+		return err
+	}
+	if on {
+		ui.Ok("Broadcast on — input goes to every pane")
+	} else {
+		ui.Ok("Broadcast off")
+	}
+	fmt.Println()
+	return nil
+}
+
+func runTmuxRunAll(_ *cobra.Command, args []string) error {
+	if err := checkBroadcastTarget(); err != nil {
+		return err
+	}
+
+	command := strings.Join(args, " ")
+	n, err := tmux.RunInPanes(tmuxBroadcastWindow, command)
+	if err != nil {
+		return err
+	}
+	ui.Ok(fmt.Sprintf("Sent %s to %d panes", ui.Accent.Render(command), n))
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupRecordingTmux installs a tmux stub that logs its arguments and
+// prints out, returning the log's path.
+func setupRecordingTmux(t *testing.T, out string) string {
+	t.Helper()
+	dir := t.TempDir()
+	log := filepath.Join(dir, "calls")
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\nprintf '" + out + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, "tmux"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+":"+os.Getenv("PATH"))
+	t.Setenv("TMUX", "/tmp/tmux-test,12345,0")
+	t.Cleanup(func() { tmuxBroadcastWindow = "" })
+	return log
+}
+
+func readCalls(t *testing.T, log string) string {
+	t.Helper()
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRunTmuxBroadcast_Toggle(t *testing.T) {
+	log := setupRecordingTmux(t, "on")
+	tmuxBroadcastWindow = "dev:servers"
+
+	captureStdout(t, func() {
+		if err := runTmuxBroadcast(nil, nil); err != nil {
+			t.Fatalf("runTmuxBroadcast: %v", err)
+		}
+	})
+	if calls := readCalls(t, log); !strings.Contains(calls, "set-window-option -t dev:servers synchronize-panes off") {
+		t.Errorf("expected toggle to turn broadcast off, calls:\n%s", calls)
+	}
+
+	if err := runTmuxBroadcast(nil, []string{"sometimes"}); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestRunTmuxBroadcast_NeedsWindowOutsideTmux(t *testing.T) {
+	setupRecordingTmux(t, "")
+	t.Setenv("TMUX", "")
+
+	if err := runTmuxBroadcast(nil, []string{"on"}); err == nil || !strings.Contains(err.Error(), "--window") {
+		t.Errorf("expected --window hint, got %v", err)
+	}
+}
+
+func TestRunTmuxRunAll(t *testing.T) {
+	log := setupRecordingTmux(t, `%%1\n%%2\n`)
+
+	out := captureStdout(t, func() {
+		if err := runTmuxRunAll(nil, []string{"uptime", "-p"}); err != nil {
+			t.Fatalf("runTmuxRunAll: %v", err)
+		}
+	})
+	calls := readCalls(t, log)
+	for _, want := range []string{"send-keys -t %1 uptime -p Enter", "send-keys -t %2 uptime -p Enter"} {
+		if !strings.Contains(calls, want) {
+			t.Errorf("missing %q in calls:\n%s", want, calls)
+		}
+	}
+	if !strings.Contains(out, "2 panes") {
+		t.Errorf("expected pane count in output:\n%s", out)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Injectable for testing — the tmux queries behind 'mine tmux doctor'.
+var (
+	tmuxVersionFunc         = tmux.Version
+	tmuxOptionConflictsFunc = tmux.OptionConflicts
+	tmuxOrphanedFunc        = tmux.OrphanedSessions
+)
+
+func init() {
+	tmuxCmd.AddCommand(tmuxDoctorCmd)
+}
+
+var tmuxDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check your tmux setup for problems mine would trip over",
+	Long: `Check tmux and report what needs attention:
+
+  - tmux is missing or older than ` + tmux.MinVersion + `
+  - tmux.conf sets options that break mine's sessions (exit-unattached,
+    destroy-unattached, a non-zero pane-base-index)
+  - running sessions whose starting directory was deleted
+  - tmux.layout names a layout that doesn't exist`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("tmux.doctor", runTmuxDoctor),
+}
+
+func runTmuxDoctor(_ *cobra.Command, _ []string) error {
+	version := checkTmuxVersion()
+	results := []checkResult{version}
+	if version.ok {
+		results = append(results, checkTmuxOptions(), checkTmuxSessions())
+		if cfg, err := config.Load(); err == nil && cfg.Tmux.Layout != "" {
+			results = append(results, checkTmuxLayout(cfg.Tmux.Layout))
+		}
+	}
+
+	fmt.Println()
+	failed := 0
+	for _, r := range results {
+		printCheck(r)
+		if !r.ok {
+			failed++
+		}
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("%d tmux check(s) failed — see suggestions above", failed)
+	}
+	ui.Ok("tmux looks good.")
+	fmt.Println()
+	return nil
+}
+
+func checkTmuxVersion() checkResult {
+	if !tmux.Available() {
+		return checkResult{
+			name:    "tmux",
+			detail:  "tmux not found in PATH",
+			fixHint: "Install tmux " + tmux.MinVersion + " or newer with your package manager",
+		}
+	}
+	v, err := tmuxVersionFunc()
+	if err != nil {
+		return checkResult{
+			name:    "tmux",
+			detail:  err.Error(),
+			fixHint: "Verify your tmux installation runs: tmux -V",
+		}
+	}
+	if !tmux.VersionAtLeast(v, tmux.MinVersion) {
+		return checkResult{
+			name:    "tmux",
+			detail:  fmt.Sprintf("tmux %s is older than %s", v, tmux.MinVersion),
+			fixHint: "Upgrade tmux to " + tmux.MinVersion + " or newer",
+		}
+	}
+	return checkResult{name: "tmux", ok: true, detail: "tmux " + v}
+}
+
+func checkTmuxOptions() checkResult {
+	conflicts, err := tmuxOptionConflictsFunc()
+	if err != nil {
+		return checkResult{
+			name:    "Options",
+			detail:  err.Error(),
+			fixHint: "Check ~/.tmux.conf for errors: tmux source-file ~/.tmux.conf",
+		}
+	}
+	if len(conflicts) == 0 {
+		return checkResult{name: "Options", ok: true, detail: "no conflicting options"}
+	}
+	var details, fixes []string
+	for _, c := range conflicts {
+		details = append(details, fmt.Sprintf("%s %s — %s", c.Option, c.Value, c.Problem))
+		fixes = append(fixes, c.Fix)
+	}
+	return checkResult{
+		name:    "Options",
+		detail:  strings.Join(details, "; "),
+		fixHint: "Add to ~/.tmux.conf: " + strings.Join(fixes, "; "),
+	}
+}
+
+func checkTmuxSessions() checkResult {
+	orphans, err := tmuxOrphanedFunc()
+	if err != nil {
+		return checkResult{name: "Sessions", detail: err.Error()}
+	}
+	if len(orphans) == 0 {
+		return checkResult{name: "Sessions", ok: true, detail: "no sessions in deleted directories"}
+	}
+	var names, kills []string
+	for _, o := range orphans {
+		names = append(names, fmt.Sprintf("%s (%s)", o.Name, o.Dir))
+		kills = append(kills, "mine tmux kill "+o.Name)
+	}
+	return checkResult{
+		name:    "Sessions",
+		detail:  fmt.Sprintf("%d session(s) started in deleted directories: %s", len(orphans), strings.Join(names, ", ")),
+		fixHint: "Kill them: " + strings.Join(kills, "; "),
+	}
+}
+
+func checkTmuxLayout(name string) checkResult {
+	cwd, _ := os.Getwd()
+	if _, err := readLayoutFunc(name, cwd); err != nil {
+		return checkResult{
+			name:    "Layout",
+			detail:  fmt.Sprintf("tmux.layout is %q but no such layout exists", name),
+			fixHint: fmt.Sprintf("Save it with %s or run %s", ui.Accent.Render("mine tmux layout save "+name), ui.Accent.Render("mine config unset tmux.layout")),
+		}
+	}
+	return checkResult{name: "Layout", ok: true, detail: fmt.Sprintf("tmux.layout %q found", name)}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/tmux"
+)
+
+func setupTmuxDoctor(t *testing.T, version string, conflicts []tmux.OptionConflict, orphans []tmux.OrphanedSession) {
+	t.Helper()
+	setupTmuxEnv(t)
+	origVersion, origOptions, origOrphans := tmuxVersionFunc, tmuxOptionConflictsFunc, tmuxOrphanedFunc
+	t.Cleanup(func() {
+		tmuxVersionFunc, tmuxOptionConflictsFunc, tmuxOrphanedFunc = origVersion, origOptions, origOrphans
+	})
+	tmuxVersionFunc = func() (string, error) { return version, nil }
+	tmuxOptionConflictsFunc = func() ([]tmux.OptionConflict, error) { return conflicts, nil }
+	tmuxOrphanedFunc = func() ([]tmux.OrphanedSession, error) { return orphans, nil }
+}
+
+func TestCheckTmuxVersion(t *testing.T) {
+	setupTmuxDoctor(t, "3.3a", nil, nil)
+	if r := checkTmuxVersion(); !r.ok || r.detail != "tmux 3.3a" {
+		t.Fatalf("expected ok for 3.3a, got %+v", r)
+	}
+
+	tmuxVersionFunc = func() (string, error) { return "2.1", nil }
+	if r := checkTmuxVersion(); r.ok || !strings.Contains(r.fixHint, "Upgrade") {
+		t.Fatalf("expected upgrade hint for 2.1, got %+v", r)
+	}
+
+	tmuxVersionFunc = func() (string, error) { return "", fmt.Errorf("boom") }
+	if r := checkTmuxVersion(); r.ok {
+		t.Fatalf("expected failure on version error, got %+v", r)
+	}
+}
+
+func TestCheckTmuxOptions_Conflict(t *testing.T) {
+	setupTmuxDoctor(t, "3.4", []tmux.OptionConflict{{
+		Option: "pane-base-index", Value: "1", Problem: "panes get skipped", Fix: "set -g pane-base-index 0",
+	}}, nil)
+
+	r := checkTmuxOptions()
+	if r.ok {
+		t.Fatal("expected the options check to fail")
+	}
+	if !strings.Contains(r.detail, "pane-base-index 1") || !strings.Contains(r.fixHint, "set -g pane-base-index 0") {
+		t.Fatalf("unexpected result: %+v", r)
+	}
+}
+
+func TestCheckTmuxSessions_Orphans(t *testing.T) {
+	setupTmuxDoctor(t, "3.4", nil, []tmux.OrphanedSession{{Name: "old", Dir: "/gone"}})
+
+	r := checkTmuxSessions()
+	if r.ok || !strings.Contains(r.detail, "old (/gone)") || !strings.Contains(r.fixHint, "mine tmux kill old") {
+		t.Fatalf("unexpected result: %+v", r)
+	}
+}
+
+func TestRunTmuxDoctor_Healthy(t *testing.T) {
+	configTestEnv(t)
+	setupTmuxDoctor(t, "3.4", nil, nil)
+
+	out := captureStdout(t, func() {
+		if err := runTmuxDoctor(nil, nil); err != nil {
+			t.Fatalf("runTmuxDoctor: %v", err)
+		}
+	})
+	for _, want := range []string{"tmux 3.4", "no conflicting options", "tmux looks good"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Layout") {
+		t.Errorf("expected no layout check without tmux.layout, got:\n%s", out)
+	}
+}
+
+func TestRunTmuxDoctor_ReportsFailures(t *testing.T) {
+	configTestEnv(t)
+	setupTmuxDoctor(t, "3.4", nil, []tmux.OrphanedSession{{Name: "old", Dir: "/gone"}})
+
+	var err error
+	captureStdout(t, func() { err = runTmuxDoctor(nil, nil) })
+	if err == nil || !strings.Contains(err.Error(), "1 tmux check(s) failed") {
+		t.Fatalf("expected one failed check, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	tmuxCmd.AddCommand(tmuxLastCmd)
+}
+
+var tmuxLastCmd = &cobra.Command{
+	Use:   "last",
+	Short: "Jump back to the most recently active session",
+	Long: `Attach to the session you were most recently in — or, inside tmux, switch
+to the one before the current session. mine remembers every session it
+attaches to or switches away from, so running 'mine tmux last' repeatedly
+flips between two sessions.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("tmux.last", runTmuxLast),
+}
+
+func runTmuxLast(_ *cobra.Command, _ []string) error {
+	if !tmux.Available() {
+		return fmt.Errorf("tmux not found in PATH")
+	}
+
+	sessions, err := tmux.ListSessions()
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no tmux sessions running")
+	}
+
+	current := ""
+	if tmux.InsideTmux() {
+		if current, err = tmux.CurrentSession(); err != nil {
+			return err
+		}
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	name, err := tmux.NewHistory(db.Conn()).Last(sessions, current)
+	db.Close()
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return fmt.Errorf("no recent session to go back to — attach one with %s", ui.Accent.Render("mine tmux attach"))
+	}
+	return attachSession(name)
+}
@@ -2,9 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
 	"github.com/rnwolfe/mine/internal/tmux"
 	"github.com/rnwolfe/mine/internal/tui"
 	"github.com/rnwolfe/mine/internal/ui"
@@ -17,8 +22,12 @@ func init() {
 	tmuxLayoutCmd.AddCommand(tmuxLayoutLsCmd)
 	tmuxLayoutCmd.AddCommand(tmuxLayoutPreviewCmd)
 	tmuxLayoutCmd.AddCommand(tmuxLayoutDeleteCmd)
+
+	tmuxLayoutSaveCmd.Flags().BoolVar(&tmuxLayoutSaveProject, "project", false, "Save into the current project's .mine/layouts instead")
 }
 
+var tmuxLayoutSaveProject bool
+
 // --- mine tmux layout ---
 
 var tmuxLayoutCmd = &cobra.Command{
@@ -33,7 +42,7 @@ func runTmuxLayoutHelp(_ *cobra.Command, _ []string) error {
 		if !tmux.Available() {
 			return fmt.Errorf("tmux not found in PATH")
 		}
-		names, err := tmux.ListLayouts()
+		names, err := visibleLayoutNames()
 		if err != nil {
 			return err
 		}
@@ -51,7 +60,7 @@ func runTmuxLayoutHelp(_ *cobra.Command, _ []string) error {
 			items := make([]tui.Item, len(names))
 			for i, n := range names {
 				desc := ""
-				if layout, err := tmux.ReadLayout(n); err == nil {
+				if layout, err := tmux.FindLayout(n, layoutSearchDir()); err == nil {
 					w := "windows"
 					if len(layout.Windows) == 1 {
 						w = "window"
@@ -75,7 +84,7 @@ func runTmuxLayoutHelp(_ *cobra.Command, _ []string) error {
 			}
 
 			name := chosen.Title()
-			if err := tmux.LoadLayout(name); err != nil {
+			if err := loadLayoutHere(name); err != nil {
 				return err
 			}
 
@@ -103,8 +112,14 @@ func runTmuxLayoutHelp(_ *cobra.Command, _ []string) error {
 var tmuxLayoutSaveCmd = &cobra.Command{
 	Use:   "save <name>",
 	Short: "Save the current window/pane layout",
-	Args:  cobra.ExactArgs(1),
-	RunE:  hook.Wrap("tmux.layout.save", runTmuxLayoutSave),
+	Long: `Save the current session's windows, panes, directories, and running
+commands as a named layout.
+
+With --project, the layout is written to the current project's
+.mine/layouts directory, where it can be committed and edited by hand.
+Project layouts win over saved layouts of the same name inside that project.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("tmux.layout.save", runTmuxLayoutSave),
 }
 
 func runTmuxLayoutSave(_ *cobra.Command, args []string) error {
@@ -116,6 +131,20 @@ func runTmuxLayoutSave(_ *cobra.Command, args []string) error {
 	}
 
 	name := args[0]
+	if tmuxLayoutSaveProject {
+		root, err := currentProjectRoot()
+		if err != nil {
+			return err
+		}
+		path, err := tmux.SaveProjectLayout(name, root)
+		if err != nil {
+			return err
+		}
+		ui.Ok(fmt.Sprintf("Layout %s saved to %s", ui.Accent.Render(name), path))
+		fmt.Printf("  Start it with: %s\n", ui.Muted.Render("mine tmux new --layout "+name))
+		fmt.Println()
+		return nil
+	}
 	if err := tmux.SaveLayout(name); err != nil {
 		return err
 	}
@@ -149,7 +178,7 @@ func runTmuxLayoutLoad(_ *cobra.Command, args []string) error {
 		name = args[0]
 	} else {
 		// No name given: open fuzzy picker (TTY) or list layouts (non-TTY).
-		names, err := tmux.ListLayouts()
+		names, err := visibleLayoutNames()
 		if err != nil {
 			return err
 		}
@@ -176,7 +205,7 @@ func runTmuxLayoutLoad(_ *cobra.Command, args []string) error {
 		items := make([]tui.Item, len(names))
 		for i, n := range names {
 			desc := ""
-			if layout, err := tmux.ReadLayout(n); err == nil {
+			if layout, err := tmux.FindLayout(n, layoutSearchDir()); err == nil {
 				w := "windows"
 				if len(layout.Windows) == 1 {
 					w = "window"
@@ -201,7 +230,7 @@ func runTmuxLayoutLoad(_ *cobra.Command, args []string) error {
 		name = chosen.Title()
 	}
 
-	if err := tmux.LoadLayout(name); err != nil {
+	if err := loadLayoutHere(name); err != nil {
 		return err
 	}
 
@@ -222,7 +251,7 @@ var tmuxLayoutPreviewCmd = &cobra.Command{
 
 func runTmuxLayoutPreview(_ *cobra.Command, args []string) error {
 	name := args[0]
-	layout, err := tmux.ReadLayout(name)
+	layout, err := tmux.FindLayout(name, layoutSearchDir())
 	if err != nil {
 		return err
 	}
@@ -252,9 +281,14 @@ func runTmuxLayoutPreview(_ *cobra.Command, args []string) error {
 		}
 		fmt.Printf("  %s  %s  %s\n",
 			ui.Accent.Render(fmt.Sprintf("%-20s", w.Name)),
-			fmt.Sprintf("%-6d", w.PaneCount),
+			fmt.Sprintf("%-6d", max(w.PaneCount, len(w.Panes))),
 			ui.Muted.Render(dir),
 		)
+		for _, p := range w.Panes {
+			if p.Command != "" {
+				fmt.Printf("  %s  %s\n", strings.Repeat(" ", 28), ui.Muted.Render("$ "+p.Command))
+			}
+		}
 	}
 	fmt.Println()
 	return nil
@@ -284,8 +318,12 @@ func runTmuxLayoutLs(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	projectNames, err := tmux.ListProjectLayouts(layoutSearchDir())
+	if err != nil {
+		return err
+	}
 
-	if len(names) == 0 {
+	if len(names) == 0 && len(projectNames) == 0 {
 		fmt.Println()
 		fmt.Println(ui.Muted.Render("  No saved layouts."))
 		fmt.Printf("  Save one: %s\n", ui.Accent.Render("mine tmux layout save <name>"))
@@ -293,9 +331,29 @@ func runTmuxLayoutLs(_ *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	fmt.Println()
+	if len(projectNames) > 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  This project:"))
+		printLayoutRows(projectNames, func(name string) (*tmux.Layout, error) {
+			return tmux.FindLayout(name, layoutSearchDir())
+		})
+		if len(names) > 0 {
+			fmt.Println(ui.Muted.Render("  Saved:"))
+		}
+	} else {
+		fmt.Println()
+	}
+	printLayoutRows(names, tmux.ReadLayout)
+	return nil
+}
+
+// printLayoutRows prints one summary line per layout, reading each with read.
+func printLayoutRows(names []string, read func(string) (*tmux.Layout, error)) {
+	if len(names) == 0 {
+		return
+	}
 	for _, name := range names {
-		layout, err := tmux.ReadLayout(name)
+		layout, err := read(name)
 		if err != nil {
 			fmt.Printf("  %s %s\n", ui.Accent.Render(name), ui.Muted.Render("(error reading)"))
 			continue
@@ -324,7 +382,6 @@ func runTmuxLayoutLs(_ *cobra.Command, _ []string) error {
 		)
 	}
 	fmt.Println()
-	return nil
 }
 
 // --- mine tmux layout delete ---
@@ -401,3 +458,64 @@ func printLayoutList(names []string) error {
 	fmt.Println()
 	return nil
 }
+
+// layoutSearchDir is where project layouts are looked up from: the current
+// directory, or "" if it can't be determined.
+func layoutSearchDir() string {
+	cwd, _ := os.Getwd()
+	return cwd
+}
+
+// visibleLayoutNames lists the current project's layouts followed by saved
+// layouts they don't shadow.
+func visibleLayoutNames() ([]string, error) {
+	names, err := tmux.ListProjectLayouts(layoutSearchDir())
+	if err != nil {
+		return nil, err
+	}
+	saved, err := tmux.ListLayouts()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range saved {
+		if !slices.Contains(names, n) {
+			names = append(names, n)
+		}
+	}
+	return names, nil
+}
+
+// loadLayoutHere applies the named layout, project layouts first, to the
+// current session.
+func loadLayoutHere(name string) error {
+	layout, err := tmux.FindLayout(name, layoutSearchDir())
+	if err != nil {
+		return err
+	}
+	return tmux.ApplyLayout(layout)
+}
+
+// currentProjectRoot returns the registered project containing the current
+// directory, falling back to the enclosing git repo.
+func currentProjectRoot() (string, error) {
+	db, err := store.Open()
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+	if p, _ := proj.NewStore(db.Conn()).FindForCWD(); p != nil {
+		return p.Path, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d, nil
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("not inside a project — run from a registered project or git repo")
+		}
+	}
+}
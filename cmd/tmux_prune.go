@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tmuxPruneIdle   string
+	tmuxPruneDryRun bool
+	tmuxPruneYes    bool
+)
+
+func init() {
+	tmuxCmd.AddCommand(tmuxPruneCmd)
+	tmuxPruneCmd.Flags().StringVar(&tmuxPruneIdle, "idle", "7d", "Kill sessions idle at least this long (e.g. 7d, 2w, 12h)")
+	tmuxPruneCmd.Flags().BoolVar(&tmuxPruneDryRun, "dry-run", false, "List idle sessions without killing them")
+	tmuxPruneCmd.Flags().BoolVarP(&tmuxPruneYes, "yes", "y", false, "Kill without prompting")
+}
+
+var tmuxPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Kill sessions nobody has touched in a while",
+	Long: `Find sessions with no attached clients and no input or output for --idle
+(default 7d), and kill them after confirmation. Use --dry-run to only list
+them, or --yes to skip the prompt (required when not in a terminal).`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("tmux.prune", runTmuxPrune),
+}
+
+func runTmuxPrune(_ *cobra.Command, _ []string) error {
+	idle, err := parseAge(tmuxPruneIdle)
+	if err != nil {
+		return fmt.Errorf("%w\n  Use a value like %s", err, ui.Accent.Render("--idle 7d"))
+	}
+	if !tmux.Available() {
+		return fmt.Errorf("tmux not found in PATH")
+	}
+
+	sessions, err := tmux.ListSessions()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	stale := tmux.IdleSessions(sessions, idle, now)
+	if len(stale) == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  No sessions idle for %s or more.", tmuxPruneIdle)))
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("  %d idle session(s):\n", len(stale))
+	for _, s := range stale {
+		fmt.Printf("  %s %-20s %s\n", ui.Muted.Render("○"), ui.Accent.Render(s.Name),
+			ui.Muted.Render("idle "+formatIdle(now.Sub(s.Activity))))
+	}
+	fmt.Println()
+
+	if tmuxPruneDryRun {
+		fmt.Printf("  Kill them: %s\n", ui.Muted.Render("mine tmux prune --idle "+tmuxPruneIdle))
+		fmt.Println()
+		return nil
+	}
+	if !tmuxPruneYes {
+		if !tui.IsTTY() {
+			return fmt.Errorf("non-interactive mode requires --yes to kill sessions")
+		}
+		if !confirmPrompt(fmt.Sprintf("Kill %d session(s)?", len(stale))) {
+			ui.Warn("Cancelled.")
+			return nil
+		}
+	}
+
+	killed := 0
+	for _, s := range stale {
+		if err := tmux.KillSession(s.Name); err != nil {
+			fmt.Printf("  %s %s %s\n", ui.Warning.Render("!"), s.Name, ui.Muted.Render(err.Error()))
+			continue
+		}
+		killed++
+	}
+	ui.Ok(fmt.Sprintf("Killed %d idle session(s)", killed))
+	fmt.Println()
+	return nil
+}
+
+// formatIdle renders an idle time in days, or hours when under a day.
+func formatIdle(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
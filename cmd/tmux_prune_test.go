@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupPruneSessions stubs tmux with a stale detached session, a stale
+// attached one, and a fresh one.
+func setupPruneSessions(t *testing.T) string {
+	t.Helper()
+	now := time.Now().Unix()
+	old := now - 10*24*60*60
+	out := fmt.Sprintf("stale\\t1\\t%d\\t0\\t%d\\nbusy\\t1\\t%d\\t1\\t%d\\nfresh\\t1\\t%d\\t0\\t%d\\n",
+		old, old, old, old, now, now)
+	log := setupRecordingTmux(t, out)
+	t.Cleanup(func() {
+		tmuxPruneIdle, tmuxPruneDryRun, tmuxPruneYes = "7d", false, false
+	})
+	return log
+}
+
+func TestRunTmuxPrune_DryRun(t *testing.T) {
+	log := setupPruneSessions(t)
+	tmuxPruneDryRun = true
+
+	out := captureStdout(t, func() {
+		if err := runTmuxPrune(nil, nil); err != nil {
+			t.Fatalf("runTmuxPrune: %v", err)
+		}
+	})
+	if !strings.Contains(out, "stale") || strings.Contains(out, "busy") || strings.Contains(out, "fresh") {
+		t.Errorf("expected only stale listed, got:\n%s", out)
+	}
+	if !strings.Contains(out, "idle 10d") {
+		t.Errorf("expected idle time, got:\n%s", out)
+	}
+	if strings.Contains(readCalls(t, log), "kill-session") {
+		t.Error("dry run should not kill sessions")
+	}
+}
+
+func TestRunTmuxPrune_Yes(t *testing.T) {
+	log := setupPruneSessions(t)
+	tmuxPruneYes = true
+
+	captureStdout(t, func() {
+		if err := runTmuxPrune(nil, nil); err != nil {
+			t.Fatalf("runTmuxPrune: %v", err)
+		}
+	})
+	calls := readCalls(t, log)
+	if !strings.Contains(calls, "kill-session -t stale") {
+		t.Errorf("expected stale killed, got:\n%s", calls)
+	}
+	if strings.Count(calls, "kill-session") != 1 {
+		t.Errorf("expected exactly one kill, got:\n%s", calls)
+	}
+}
+
+func TestRunTmuxPrune_NonTTYNeedsYes(t *testing.T) {
+	log := setupPruneSessions(t)
+
+	var err error
+	captureStdout(t, func() { err = runTmuxPrune(nil, nil) })
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected --yes error, got %v", err)
+	}
+	if strings.Contains(readCalls(t, log), "kill-session") {
+		t.Error("should not kill without confirmation")
+	}
+}
+
+func TestRunTmuxPrune_NothingIdle(t *testing.T) {
+	setupPruneSessions(t)
+	tmuxPruneIdle = "30d"
+
+	out := captureStdout(t, func() {
+		if err := runTmuxPrune(nil, nil); err != nil {
+			t.Fatalf("runTmuxPrune: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No sessions idle for 30d") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestRunTmuxPrune_BadIdle(t *testing.T) {
+	setupPruneSessions(t)
+	tmuxPruneIdle = "soon"
+
+	if err := runTmuxPrune(nil, nil); err == nil {
+		t.Fatal("expected an error for an invalid --idle")
+	}
+}
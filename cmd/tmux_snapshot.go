@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	tmuxCmd.AddCommand(tmuxSaveCmd)
+	tmuxCmd.AddCommand(tmuxRestoreCmd)
+}
+
+var tmuxSaveCmd = &cobra.Command{
+	Use:   "save [session]",
+	Short: "Snapshot sessions so they can be restored after a reboot",
+	Long: `Save a session's windows, pane layout, working directories, and running
+commands to the mine database. Without a name, saves the current session —
+or, outside tmux, every running session. Saving again replaces the snapshot.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("tmux.save", runTmuxSave),
+}
+
+var tmuxRestoreCmd = &cobra.Command{
+	Use:   "restore [session]",
+	Short: "Recreate sessions from their saved snapshots",
+	Long: `Recreate a saved session, detached, with its windows, panes, directories,
+and commands. Without a name, restores every snapshot whose session isn't
+already running — the thing to run after a reboot.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("tmux.restore", runTmuxRestore),
+}
+
+func runTmuxSave(_ *cobra.Command, args []string) error {
+	if !tmux.Available() {
+		return fmt.Errorf("tmux not found in PATH")
+	}
+
+	var names []string
+	switch {
+	case len(args) > 0:
+		names = args
+	case tmux.InsideTmux():
+		name, err := tmux.CurrentSession()
+		if err != nil {
+			return err
+		}
+		names = []string{name}
+	default:
+		sessions, err := tmux.ListSessions()
+		if err != nil {
+			return err
+		}
+		for _, s := range sessions {
+			names = append(names, s.Name)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No tmux sessions running."))
+		fmt.Println()
+		return nil
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ss := tmux.NewSnapshotStore(db.Conn())
+	fmt.Println()
+	for _, name := range names {
+		snap, err := ss.Save(name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %s Saved %s  %s\n", ui.Success.Render(ui.IconCheck), ui.Accent.Render(name), ui.Muted.Render(snapshotSummary(snap)))
+	}
+	fmt.Printf("  Restore with: %s\n", ui.Muted.Render("mine tmux restore"))
+	fmt.Println()
+	return nil
+}
+
+func runTmuxRestore(_ *cobra.Command, args []string) error {
+	if !tmux.Available() {
+		return fmt.Errorf("tmux not found in PATH")
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ss := tmux.NewSnapshotStore(db.Conn())
+	if len(args) > 0 {
+		snap, err := ss.Get(args[0])
+		if err != nil {
+			return err
+		}
+		if err := ss.Restore(snap); err != nil {
+			return err
+		}
+		ui.Ok(fmt.Sprintf("Restored %s  %s", ui.Accent.Render(snap.Session), ui.Muted.Render(snapshotSummary(snap))))
+		fmt.Printf("  Attach: %s\n", ui.Muted.Render("mine tmux attach "+snap.Session))
+		fmt.Println()
+		return nil
+	}
+
+	snaps, err := ss.List()
+	if err != nil {
+		return err
+	}
+	if len(snaps) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No saved sessions."))
+		fmt.Printf("  Save one: %s\n", ui.Accent.Render("mine tmux save"))
+		fmt.Println()
+		return nil
+	}
+	sessions, err := tmux.ListSessions()
+	if err != nil {
+		return err
+	}
+
+	restored := 0
+	fmt.Println()
+	for i := range snaps {
+		snap := &snaps[i]
+		if tmux.FindSessionByName(snap.Session, sessions) != nil {
+			fmt.Printf("  %s %s %s\n", ui.Muted.Render("○"), snap.Session, ui.Muted.Render("already running"))
+			continue
+		}
+		if err := ss.Restore(snap); err != nil {
+			fmt.Printf("  %s %s %s\n", ui.Warning.Render("!"), snap.Session, ui.Muted.Render(err.Error()))
+			continue
+		}
+		restored++
+		fmt.Printf("  %s Restored %s  %s\n", ui.Success.Render(ui.IconCheck), ui.Accent.Render(snap.Session), ui.Muted.Render(snapshotSummary(snap)))
+	}
+	if restored > 0 {
+		fmt.Printf("  Attach: %s\n", ui.Muted.Render("mine tmux"))
+	}
+	fmt.Println()
+	return nil
+}
+
+// snapshotSummary describes a snapshot's size and age, e.g.
+// "3 windows, 5 panes, saved 2 hours ago".
+func snapshotSummary(snap *tmux.Snapshot) string {
+	panes := 0
+	for _, w := range snap.Layout.Windows {
+		panes += max(w.PaneCount, len(w.Panes))
+	}
+	windows := fmt.Sprintf("%d windows", len(snap.Layout.Windows))
+	if len(snap.Layout.Windows) == 1 {
+		windows = "1 window"
+	}
+	return fmt.Sprintf("%s, %d panes, saved %s", windows, panes, todoTimeAgo(snap.SavedAt, time.Now()))
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/dig"
+	"github.com/rnwolfe/mine/internal/env"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tmuxStatuslineDir string
+	tmuxStatuslineTTL time.Duration
+)
+
+func init() {
+	tmuxCmd.AddCommand(tmuxStatuslineCmd)
+	tmuxStatuslineCmd.Flags().StringVar(&tmuxStatuslineDir, "dir", "", "Directory to report on (defaults to the current directory)")
+	tmuxStatuslineCmd.Flags().DurationVar(&tmuxStatuslineTTL, "ttl", 5*time.Second, "Reuse the last output for this long (0 to always refresh)")
+}
+
+var tmuxStatuslineCmd = &cobra.Command{
+	Use:   "statusline",
+	Short: "Print a compact status segment for tmux's status-right",
+	Long: `Print open todos, the running dig session's time left, and the active env
+profile as one short line, e.g. "4 todos · dig 18m · env staging". Todos are
+scoped to the project containing --dir. Output is cached per directory for
+--ttl, so it's cheap to call on every status refresh.
+
+Add it to ~/.tmux.conf:
+
+  set -g status-interval 5
+  set -g status-right '#(mine tmux statusline --dir "#{pane_current_path}")'`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("tmux.statusline", runTmuxStatusline),
+}
+
+func runTmuxStatusline(_ *cobra.Command, _ []string) error {
+	dir := tmuxStatuslineDir
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		dir = wd
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cache := statuslineCachePath(dir)
+	if seg, ok := readStatuslineCache(cache, tmuxStatuslineTTL, now); ok {
+		fmt.Println(seg)
+		return nil
+	}
+	seg := formatStatusline(gatherStatusline(dir, now), now)
+	if tmuxStatuslineTTL > 0 {
+		writeStatuslineCache(cache, seg)
+	}
+	fmt.Println(seg)
+	return nil
+}
+
+// statuslineData is what the tmux status segment shows.
+type statuslineData struct {
+	OpenTodos    int
+	OverdueTodos int
+	Dig          *dig.Active
+	EnvProfile   string
+}
+
+// gatherStatusline collects the segment's data for dir. Anything that can't
+// be read is left out rather than failing the status bar.
+func gatherStatusline(dir string, now time.Time) statuslineData {
+	var data statuslineData
+
+	db, err := store.Open()
+	if err != nil {
+		return data
+	}
+	defer db.Close()
+
+	var scope *string
+	if p, _ := proj.NewStore(db.Conn()).FindForPath(dir); p != nil {
+		scope = &p.Path
+	}
+	if open, _, overdue, err := todo.NewStore(db.Conn()).Count(scope); err == nil {
+		data.OpenTodos, data.OverdueTodos = open, overdue
+	}
+
+	data.Dig, _ = dig.NewStore(db.Conn()).GetActive(now)
+
+	// Only directories with env profiles have one worth showing.
+	m := env.New(db.Conn(), "")
+	if profiles, err := m.ListProfiles(dir); err == nil && len(profiles) > 0 {
+		data.EnvProfile, _ = m.ActiveProfile(dir)
+	}
+	return data
+}
+
+// formatStatusline renders data as "4 todos, 1 overdue · dig 18m · env staging",
+// leaving out empty parts.
+func formatStatusline(data statuslineData, now time.Time) string {
+	var parts []string
+	switch {
+	case data.OpenTodos == 1:
+		parts = append(parts, "1 todo")
+	case data.OpenTodos > 1:
+		parts = append(parts, fmt.Sprintf("%d todos", data.OpenTodos))
+	}
+	if data.OverdueTodos > 0 && len(parts) > 0 {
+		parts[0] += fmt.Sprintf(", %d overdue", data.OverdueTodos)
+	}
+	if data.Dig != nil {
+		left := data.Dig.Remaining(now)
+		if left < time.Minute {
+			parts = append(parts, "dig <1m")
+		} else {
+			parts = append(parts, fmt.Sprintf("dig %dm", int(left.Minutes())))
+		}
+	}
+	if data.EnvProfile != "" {
+		parts = append(parts, "env "+data.EnvProfile)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// statuslineCachePath is where the segment for dir is cached.
+func statuslineCachePath(dir string) string {
+	sum := sha256.Sum256([]byte(dir))
+	return filepath.Join(config.GetPaths().CacheDir, "statusline", hex.EncodeToString(sum[:8]))
+}
+
+func readStatuslineCache(path string, ttl time.Duration, now time.Time) (string, bool) {
+	if ttl <= 0 {
+		return "", false
+	}
+	info, err := os.Stat(path)
+	if err != nil || now.Sub(info.ModTime()) >= ttl {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// writeStatuslineCache stores seg for the next call. Best-effort, and
+// written via rename so a concurrent reader never sees half a line.
+func writeStatuslineCache(path, seg string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".statusline-*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.WriteString(seg)
+	if cerr := tmp.Close(); werr != nil || cerr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
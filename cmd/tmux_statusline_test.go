@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/dig"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func TestFormatStatusline(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		data statuslineData
+		want string
+	}{
+		{"empty", statuslineData{}, ""},
+		{"one todo", statuslineData{OpenTodos: 1}, "1 todo"},
+		{"overdue", statuslineData{OpenTodos: 4, OverdueTodos: 1}, "4 todos, 1 overdue"},
+		{
+			"everything",
+			statuslineData{
+				OpenTodos:  2,
+				Dig:        &dig.Active{StartedAt: now.Add(-7 * time.Minute), Duration: 25 * time.Minute},
+				EnvProfile: "staging",
+			},
+			"2 todos · dig 18m · env staging",
+		},
+		{"dig ending", statuslineData{Dig: &dig.Active{StartedAt: now.Add(-24*time.Minute - 30*time.Second), Duration: 25 * time.Minute}}, "dig <1m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatStatusline(tt.data, now); got != tt.want {
+				t.Errorf("formatStatusline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunTmuxStatusline_Caches(t *testing.T) {
+	todoTestEnv(t)
+	tmuxStatuslineDir = t.TempDir()
+	t.Cleanup(func() { tmuxStatuslineDir, tmuxStatuslineTTL = "", 5*time.Second })
+
+	addTodo := func(title string) {
+		t.Helper()
+		db, err := store.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		if _, err := todo.NewStore(db.Conn()).Add(title, "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone); err != nil {
+			t.Fatal(err)
+		}
+	}
+	statusline := func() string {
+		return strings.TrimSpace(captureStdout(t, func() {
+			if err := runTmuxStatusline(nil, nil); err != nil {
+				t.Fatalf("runTmuxStatusline: %v", err)
+			}
+		}))
+	}
+
+	addTodo("first")
+	tmuxStatuslineTTL = time.Hour
+	if got := statusline(); got != "1 todo" {
+		t.Fatalf("first call = %q, want %q", got, "1 todo")
+	}
+	addTodo("second")
+	if got := statusline(); got != "1 todo" {
+		t.Errorf("cached call = %q, want the cached %q", got, "1 todo")
+	}
+	tmuxStatuslineTTL = 0
+	if got := statusline(); got != "2 todos" {
+		t.Errorf("uncached call = %q, want %q", got, "2 todos")
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tmuxTemplateSession string
+	tmuxTemplateProject bool
+)
+
+func init() {
+	tmuxCmd.AddCommand(tmuxTemplateCmd)
+	tmuxTemplateCmd.AddCommand(tmuxTemplateFromCurrentCmd)
+
+	tmuxTemplateFromCurrentCmd.Flags().StringVar(&tmuxTemplateSession, "session", "", "Session to capture (defaults to current)")
+	tmuxTemplateFromCurrentCmd.Flags().BoolVar(&tmuxTemplateProject, "project", false, "Save into the current project's .mine/layouts instead")
+}
+
+// --- mine tmux template ---
+
+var tmuxTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Turn a session you built by hand into a reusable layout",
+	RunE:  hook.Wrap("tmux.template", runTmuxTemplateHelp),
+}
+
+func runTmuxTemplateHelp(_ *cobra.Command, _ []string) error {
+	fmt.Println()
+	fmt.Println(ui.Title.Render("  Tmux Templates"))
+	fmt.Println()
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine tmux template from-current <name>"), ui.Muted.Render("Save the live session as a reusable layout"))
+	fmt.Println()
+	return nil
+}
+
+// --- mine tmux template from-current ---
+
+var tmuxTemplateFromCurrentCmd = &cobra.Command{
+	Use:   "from-current <name>",
+	Short: "Save the live session as a reusable layout",
+	Long: `Capture a running session's windows, panes, working directories, and
+commands as a layout you can start anywhere. Unlike 'mine tmux layout save',
+pane directories are stored relative to the session's starting directory,
+so the template recreates the same arrangement in whichever project you
+start it in:
+
+  mine tmux template from-current service
+  mine tmux new api --layout service
+  mine tmux project ~/code/billing --layout service
+
+Outside tmux, pass --session to capture another session. With --project,
+the template is written to the current project's .mine/layouts directory.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("tmux.template.from-current", runTmuxTemplateFromCurrent),
+}
+
+func runTmuxTemplateFromCurrent(_ *cobra.Command, args []string) error {
+	if !tmux.Available() {
+		return fmt.Errorf("tmux not found in PATH")
+	}
+	if tmuxTemplateSession == "" && !tmux.InsideTmux() {
+		return fmt.Errorf("not inside a tmux session — use %s to capture a specific session",
+			ui.Accent.Render("--session <name>"))
+	}
+
+	var root string
+	if tmuxTemplateProject {
+		var err error
+		if root, err = currentProjectRoot(); err != nil {
+			return err
+		}
+	}
+
+	name := args[0]
+	layout, path, err := tmux.SaveTemplate(name, tmuxTemplateSession, root)
+	if err != nil {
+		return err
+	}
+
+	panes := 0
+	for _, w := range layout.Windows {
+		panes += max(w.PaneCount, len(w.Panes))
+	}
+	ui.Ok(fmt.Sprintf("Template %s saved  %s", ui.Accent.Render(name),
+		ui.Muted.Render(fmt.Sprintf("%d windows, %d panes", len(layout.Windows), panes))))
+	fmt.Printf("    %s\n", ui.Muted.Render(path))
+	fmt.Printf("  Start it with: %s\n", ui.Muted.Render("mine tmux new --layout "+name))
+	fmt.Println()
+	return nil
+}
@@ -9,6 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
 	"github.com/rnwolfe/mine/internal/tmux"
 )
 
@@ -359,7 +362,7 @@ func TestRunTmuxNew_CleanupOnLoadFailure(t *testing.T) {
 	newSessionFunc = func(name, dir string) (string, error) {
 		return name, nil // session creation succeeds
 	}
-	loadLayoutFunc = func(_ string) error {
+	loadLayoutFunc = func(_ *tmux.Layout, _ string) error {
 		return fmt.Errorf("simulated layout apply failure")
 	}
 	killSessionFunc = func(name string) error {
@@ -392,3 +395,175 @@ func TestRunTmuxNew_CleanupOnLoadFailure(t *testing.T) {
 		t.Errorf("error should mention session was cleaned up, got: %v", err)
 	}
 }
+
+func TestRunTmuxLayoutLs_IncludesProjectLayouts(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := tmux.WriteLayout(&tmux.Layout{Name: "global", Windows: []tmux.WindowLayout{{Name: "main"}}}); err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	dir := filepath.Join(root, tmux.ProjectLayoutDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := "[[windows]]\nname = \"code\"\n\n[[windows.panes]]\ncommand = \"nvim .\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "dev.toml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+
+	out := captureStdout(t, func() {
+		if err := runTmuxLayoutLs(nil, nil); err != nil {
+			t.Fatalf("runTmuxLayoutLs: %v", err)
+		}
+	})
+	for _, want := range []string{"This project:", "dev", "[code]", "Saved:", "global"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTmuxLayoutPreview(nil, []string{"dev"}); err != nil {
+			t.Fatalf("runTmuxLayoutPreview: %v", err)
+		}
+	})
+	if !strings.Contains(out, "$ nvim .") {
+		t.Errorf("preview missing startup command:\n%s", out)
+	}
+}
+
+func TestRunTmuxRestore_NoSnapshots(t *testing.T) {
+	todoTestEnv(t)
+	setupTmuxEnv(t)
+
+	out := captureStdout(t, func() {
+		if err := runTmuxRestore(nil, nil); err != nil {
+			t.Fatalf("runTmuxRestore: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No saved sessions") {
+		t.Errorf("expected empty state:\n%s", out)
+	}
+	if err := runTmuxRestore(nil, []string{"ghost"}); err == nil || !strings.Contains(err.Error(), "mine tmux save ghost") {
+		t.Errorf("expected missing snapshot error, got %v", err)
+	}
+}
+
+func TestTmuxAutoTarget(t *testing.T) {
+	todoTestEnv(t)
+	apiDir := registerProject(t, "api")
+	webDir := registerProject(t, "web")
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ps := proj.NewStore(db.Conn())
+	cfg := &config.Config{Tmux: config.TmuxConfig{AutoSession: "ask"}}
+
+	src := filepath.Join(apiDir, "src")
+	if err := os.Mkdir(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(src)
+
+	p, mode, err := tmuxAutoTarget(ps, cfg, webDir)
+	if err != nil || p == nil || p.Name != "api" || mode != "ask" {
+		t.Fatalf("entering api from web: got %v, %q, %v", p, mode, err)
+	}
+	if p, _, _ := tmuxAutoTarget(ps, cfg, apiDir); p != nil {
+		t.Errorf("moving within api should not offer a session, got %s", p.Name)
+	}
+
+	if err := ps.SetSetting("api", "tmux_auto_session", "attach"); err != nil {
+		t.Fatal(err)
+	}
+	if _, mode, _ := tmuxAutoTarget(ps, cfg, ""); mode != "attach" {
+		t.Errorf("project setting should override config, got %q", mode)
+	}
+
+	t.Chdir(t.TempDir())
+	if p, _, _ := tmuxAutoTarget(ps, cfg, apiDir); p != nil {
+		t.Errorf("leaving projects should not offer a session, got %s", p.Name)
+	}
+}
+
+func TestRunShellInit_TmuxAutoHookOptIn(t *testing.T) {
+	todoTestEnv(t)
+	registerProject(t, "api")
+
+	initScript := func() string {
+		return captureStdout(t, func() {
+			if err := runShellInit(nil, []string{"zsh"}); err != nil {
+				t.Fatalf("runShellInit: %v", err)
+			}
+		})
+	}
+	if strings.Contains(initScript(), "mine tmux auto") {
+		t.Error("hook should not be installed while auto-session is off")
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = proj.NewStore(db.Conn()).SetSetting("api", "tmux_auto_session", "ask")
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(initScript(), "mine tmux auto") {
+		t.Error("hook should be installed once a project opts in")
+	}
+}
+
+func TestRunTmuxTemplateFromCurrent_NeedsSessionOutsideTmux(t *testing.T) {
+	setupTmuxEnv(t)
+	t.Setenv("TMUX", "")
+
+	err := runTmuxTemplateFromCurrent(nil, []string{"service"})
+	if err == nil || !strings.Contains(err.Error(), "--session") {
+		t.Errorf("expected --session hint outside tmux, got %v", err)
+	}
+}
+
+func TestRunTmuxLast_FlipsBetweenSessions(t *testing.T) {
+	todoTestEnv(t)
+	stubDir := t.TempDir()
+	// A tmux stub with two sessions, currently in "api".
+	script := "#!/bin/sh\ncase \"$1\" in\n" +
+		"list-sessions) printf 'api\\t1\\t0\\t1\\nweb\\t1\\t0\\t0\\n' ;;\n" +
+		"display-message) echo api ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(filepath.Join(stubDir, "tmux"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", stubDir+":"+os.Getenv("PATH"))
+	t.Setenv("TMUX", "/tmp/tmux-test,12345,0")
+
+	var attached []string
+	orig := attachSessionFunc
+	t.Cleanup(func() { attachSessionFunc = orig })
+	attachSessionFunc = func(name string) error {
+		attached = append(attached, name)
+		return nil
+	}
+
+	if err := runTmuxLast(nil, nil); err == nil || !strings.Contains(err.Error(), "no recent session") {
+		t.Fatalf("expected no-history error, got %v", err)
+	}
+
+	// Switching api → web records api as just left, so 'last' from api
+	// (as the stub still reports) goes to web, the most recent other session.
+	if err := attachSession("web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTmuxLast(nil, nil); err != nil {
+		t.Fatalf("runTmuxLast: %v", err)
+	}
+	if strings.Join(attached, ",") != "web,web" {
+		t.Errorf("attached %v, want [web web]", attached)
+	}
+}
@@ -130,8 +130,10 @@ func runTmuxWindowNew(_ *cobra.Command, args []string) error {
 
 var tmuxWindowKillCmd = &cobra.Command{
 	Use:   "kill [name]",
-	Short: "Kill a window in the current session",
-	Args:  cobra.MaximumNArgs(1),
+	Short: "Kill a window in the current session (fuzzy match)",
+	Long: `Kill a window by name or index. The name is fuzzy-matched like session
+names; with no argument, pick the window interactively.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE:  hook.Wrap("tmux.window.kill", runTmuxWindowKill),
 }
 
@@ -157,10 +159,10 @@ func runTmuxWindowKill(_ *cobra.Command, args []string) error {
 	var target *tmux.Window
 
 	if len(args) > 0 {
-		name := args[0]
-		w := tmux.FindWindowByName(name, windows)
-		if w == nil {
-			return fmt.Errorf("no window named %q in session %q", name, session)
+		// Name or index provided — fuzzy-match it.
+		w, err := tmux.FuzzyFindWindow(args[0], windows)
+		if err != nil {
+			return fmt.Errorf("%w in session %q", err, session)
 		}
 		target = w
 	} else {
@@ -212,7 +214,7 @@ var tmuxWindowRenameCmd = &cobra.Command{
 	Long: `Rename a window interactively or directly.
 
   2 args: rename directly without prompts
-  1 arg:  select window by name, then prompt for new name
+  1 arg:  select window by name (fuzzy) or index, then prompt for new name
   0 args: open TUI picker to select window, then prompt for new name`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: hook.Wrap("tmux.window.rename", runTmuxWindowRename),
@@ -255,10 +257,9 @@ func runTmuxWindowRename(_ *cobra.Command, args []string) error {
 	var oldName string
 
 	if len(args) == 1 {
-		name := args[0]
-		w := tmux.FindWindowByName(name, windows)
-		if w == nil {
-			return fmt.Errorf("no window named %q in session %q", name, session)
+		w, err := tmux.FuzzyFindWindow(args[0], windows)
+		if err != nil {
+			return fmt.Errorf("%w in session %q", err, session)
 		}
 		oldName = w.Name
 	} else {
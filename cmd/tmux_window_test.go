@@ -213,6 +213,35 @@ func TestRunTmuxWindowKill_ByName(t *testing.T) {
 	}
 }
 
+// TestRunTmuxWindowKill_FuzzyAndIndex verifies that kill matches windows
+// the way session commands match sessions.
+func TestRunTmuxWindowKill_FuzzyAndIndex(t *testing.T) {
+	setupTmuxEnv(t)
+	windows := []tmux.Window{
+		{Index: 0, Name: "editor", Active: false},
+		{Index: 1, Name: "server", Active: true},
+	}
+	setupWindowFuncs(t, windows, "dev")
+
+	var killed []string
+	killWindowFunc = func(_, name string) error {
+		killed = append(killed, name)
+		return nil
+	}
+
+	captureStdout(t, func() {
+		if err := runTmuxWindowKill(nil, []string{"serv"}); err != nil {
+			t.Errorf("kill by prefix: %v", err)
+		}
+		if err := runTmuxWindowKill(nil, []string{"0"}); err != nil {
+			t.Errorf("kill by index: %v", err)
+		}
+	})
+	if strings.Join(killed, ",") != "server,editor" {
+		t.Errorf("killed %v, want [server editor]", killed)
+	}
+}
+
 // TestRunTmuxWindowKill_UnknownName verifies that kill with a nonexistent name
 // returns a clear error.
 func TestRunTmuxWindowKill_UnknownName(t *testing.T) {
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tmux"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var tmuxWorktreesDetach bool
+
+// ensureWindowsFunc opens the worktree windows; stubbed in tests.
+var ensureWindowsFunc = tmux.EnsureWindows
+
+func init() {
+	tmuxCmd.AddCommand(tmuxWorktreesCmd)
+	tmuxWorktreesCmd.Flags().BoolVarP(&tmuxWorktreesDetach, "detach", "d", false, "Set up the windows without attaching")
+}
+
+var tmuxWorktreesCmd = &cobra.Command{
+	Use:     "worktrees [project]",
+	Aliases: []string{"wt"},
+	Short:   "Open a window per git worktree of a project",
+	Long: `Open the project's session with one window per worktree — the main
+checkout first, then each worktree from 'mine proj worktree add' — each
+named after its branch and starting in its directory.
+
+The session is the same one 'mine tmux project' opens for the project. If
+it's already running, only windows for new worktrees are added, so re-run
+it after adding a worktree. Defaults to the current project; from inside a
+worktree, that's the project it came from.
+
+  mine proj worktree add feature/login
+  mine tmux worktrees api`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("tmux.worktrees", runTmuxWorktrees),
+}
+
+func runTmuxWorktrees(_ *cobra.Command, args []string) error {
+	if !tmux.Available() {
+		return fmt.Errorf("tmux not found in PATH")
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	p, worktrees, err := worktreeProject(ps, args)
+	if err != nil {
+		return err
+	}
+	if len(worktrees) == 0 {
+		return fmt.Errorf("%s has no worktrees — add one with %s", p.Name,
+			ui.Accent.Render("mine proj worktree add <branch>"))
+	}
+
+	specs := []tmux.DirWindow{{Name: worktreeWindowName(p.Branch, p.Name), Dir: p.Path}}
+	for _, w := range worktrees {
+		if _, err := os.Stat(w.Path); err != nil {
+			ui.Warn(fmt.Sprintf("Skipping %s — %s is missing", w.Branch, w.Path))
+			continue
+		}
+		specs = append(specs, tmux.DirWindow{Name: worktreeWindowName(w.Branch, w.Name), Dir: w.Path})
+	}
+
+	_, session, _, err := tmux.ResolveProjectSession(p.Path)
+	if err != nil {
+		return err
+	}
+	created, err := ensureWindowsFunc(session, specs)
+	if err != nil {
+		return err
+	}
+
+	if len(created) == 0 {
+		ui.Ok(fmt.Sprintf("Session %s already has a window per worktree", ui.Accent.Render(session)))
+	} else {
+		ui.Ok(fmt.Sprintf("Session %s: %d windows opened", ui.Accent.Render(session), len(created)))
+		for _, w := range created {
+			fmt.Printf("    %-20s %s\n", ui.Accent.Render(w.Name), ui.Muted.Render(w.Dir))
+		}
+	}
+	if tmuxWorktreesDetach {
+		fmt.Printf("  Attach: %s\n", ui.Muted.Render("mine tmux attach "+session))
+		fmt.Println()
+		return nil
+	}
+	fmt.Println()
+	return attachSession(session)
+}
+
+// worktreeProject resolves the project whose worktrees to open — the named
+// one or the current one, mapped back to its parent when it is itself a
+// worktree — along with those worktrees.
+func worktreeProject(ps *proj.Store, args []string) (*proj.Project, []proj.Worktree, error) {
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	} else {
+		p, err := ps.FindForCWD()
+		if err != nil {
+			return nil, nil, err
+		}
+		if p == nil {
+			return nil, nil, fmt.Errorf("not inside a registered project — name one: %s",
+				ui.Accent.Render("mine tmux worktrees <project>"))
+		}
+		name = p.Name
+	}
+	if parent, err := ps.WorktreeParent(name); err != nil {
+		return nil, nil, err
+	} else if parent != "" {
+		name = parent
+	}
+
+	p, err := ps.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	worktrees, err := ps.Worktrees(p.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, worktrees, nil
+}
+
+// worktreeWindowName names a worktree's window after its branch, falling
+// back to the project name for a detached HEAD.
+func worktreeWindowName(branch, fallback string) string {
+	if branch != "" {
+		return branch
+	}
+	return fallback
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/tmux"
+)
+
+// linkWorktree registers dir as branch's worktree of parent.
+func linkWorktree(t *testing.T, parent, branch, dir string) {
+	t.Helper()
+	db, err := store.Open()
+	if err != nil {
+		t.Fatalf("store.Open: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Conn().Exec(
+		`INSERT INTO project_worktrees (project_name, parent_name, branch) VALUES (?, ?, ?)`,
+		filepath.Base(dir), parent, branch,
+	); err != nil {
+		t.Fatalf("link worktree: %v", err)
+	}
+}
+
+func setupWorktreeWindows(t *testing.T) *[]tmux.DirWindow {
+	t.Helper()
+	var got []tmux.DirWindow
+	orig := ensureWindowsFunc
+	ensureWindowsFunc = func(_ string, specs []tmux.DirWindow) ([]tmux.DirWindow, error) {
+		got = specs
+		return specs, nil
+	}
+	t.Cleanup(func() {
+		ensureWindowsFunc = orig
+		tmuxWorktreesDetach = false
+	})
+	return &got
+}
+
+func TestRunTmuxWorktrees_WindowPerWorktree(t *testing.T) {
+	todoTestEnv(t)
+	setupTmuxEnv(t)
+	specs := setupWorktreeWindows(t)
+	tmuxWorktreesDetach = true
+
+	apiDir := registerProject(t, "api")
+	loginDir := registerProject(t, "api-feature-login")
+	goneDir := registerProject(t, "api-old")
+	linkWorktree(t, "api", "feature/login", loginDir)
+	linkWorktree(t, "api", "old", goneDir)
+	if err := os.RemoveAll(goneDir); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTmuxWorktrees(nil, []string{"api"}); err != nil {
+			t.Fatalf("runTmuxWorktrees: %v", err)
+		}
+	})
+
+	want := []tmux.DirWindow{
+		{Name: "api", Dir: apiDir},
+		{Name: "feature/login", Dir: loginDir},
+	}
+	if !reflect.DeepEqual(*specs, want) {
+		t.Fatalf("windows = %+v, want %+v", *specs, want)
+	}
+	if !strings.Contains(out, "mine tmux attach api") {
+		t.Errorf("expected attach hint with --detach, got:\n%s", out)
+	}
+}
+
+func TestRunTmuxWorktrees_FromWorktreeUsesParent(t *testing.T) {
+	todoTestEnv(t)
+	setupTmuxEnv(t)
+	specs := setupWorktreeWindows(t)
+	tmuxWorktreesDetach = true
+
+	registerProject(t, "api")
+	loginDir := registerProject(t, "api-feature-login")
+	linkWorktree(t, "api", "feature/login", loginDir)
+
+	captureStdout(t, func() {
+		if err := runTmuxWorktrees(nil, []string{"api-feature-login"}); err != nil {
+			t.Fatalf("runTmuxWorktrees: %v", err)
+		}
+	})
+	if len(*specs) != 2 || (*specs)[0].Name != "api" {
+		t.Fatalf("expected the parent's windows, got %+v", *specs)
+	}
+}
+
+func TestRunTmuxWorktrees_NoWorktrees(t *testing.T) {
+	todoTestEnv(t)
+	setupTmuxEnv(t)
+	setupWorktreeWindows(t)
+	registerProject(t, "api")
+
+	err := runTmuxWorktrees(nil, []string{"api"})
+	if err == nil || !strings.Contains(err.Error(), "no worktrees") {
+		t.Fatalf("expected no worktrees error, got %v", err)
+	}
+}
@@ -1,10 +1,11 @@
 package cmd
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"path/filepath"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,16 +31,26 @@ Pipe output or use subcommands for scripting.
 Tasks are automatically scoped to the current project when run inside a
 registered project directory. Use --all to view tasks across all projects.
 
-Keyboard shortcuts (interactive mode):
+Keyboard shortcuts (interactive mode, defaults):
   j / k        Move down / up
+  g / G        Jump to top / bottom
   x / space    Toggle done/undone
-  a            Add new todo (type title, Enter to save)
-  d            Delete selected todo
+  Enter        Open / close the detail pane (body, notes, focus time)
+  n            Add a note to the selected todo
   s            Cycle schedule bucket (today → soon → later → someday)
+  p            Pin / unpin
+  v / V        Mark the selected todo / mark all visible
+  t            Tag the marked (or selected) todos
+  o / O        Cycle sort order / grouping
+  a            Add new todo (type title, Enter to save)
+  d            Delete selected todo (or the marked ones)
+  u            Undo the last toggle, delete, or schedule
   /            Filter todos (fuzzy search)
-  g / G        Jump to top / bottom
-  Esc          Clear active filter (or no-op)
-  q / Ctrl+C   Quit`,
+  Esc          Clear marks, close the detail pane, or clear the filter
+  q / Ctrl+C   Quit
+
+The footer shows the active bindings; remap them under [tui.keys] in
+~/.config/mine/config.toml.`,
 	RunE: hook.Wrap("todo.list", runTodoList),
 }
 
@@ -54,7 +65,24 @@ var (
 	todoIncludeSomeday   bool
 	todoNoteFlag         string
 	todoStatsProjectFlag string
+	todoStatsGroupFlag   string
+	todoGroupFlag        string
+	todoStatsChart       bool
+	todoStatsExport      string
+	todoStatsDays        int
 	todoEveryFlag        string
+	todoParentFlag       string
+	todoEditPriority     string
+	todoEditContext      string
+	todoEditBody         bool
+	todoEditEstimate     string
+	todoEditWaitingOn    string
+	todoWaitingOnFlag    string
+	todoEstimateFlag     string
+	todoBudgetFlag       string
+	todoShowArchived     bool
+	todoArchiveOlder     string
+	todoContextFlag      string
 )
 
 func init() {
@@ -69,24 +97,53 @@ func init() {
 	todoCmd.AddCommand(todoShowCmd)
 	todoCmd.AddCommand(todoStatsCmd)
 	todoCmd.AddCommand(todoRecurringCmd)
+	todoCmd.AddCommand(todoSubtasksCmd)
+	todoCmd.AddCommand(todoUndoCmd)
+	todoCmd.AddCommand(todoArchiveCmd)
 
 	// Flags on stats subcommand
 	todoStatsCmd.Flags().StringVar(&todoStatsProjectFlag, "project", "", "Scope stats to a named project")
+	todoStatsCmd.Flags().StringVar(&todoStatsGroupFlag, "group", "", "Scope stats to a project group's projects")
+	todoStatsCmd.Flags().BoolVar(&todoStatsChart, "chart", false, "Chart daily completions and open todos")
+	todoStatsCmd.Flags().StringVar(&todoStatsExport, "export", "", "Write stats as CSV to stdout instead (--export csv)")
+	todoStatsCmd.Flags().IntVar(&todoStatsDays, "days", 30, "Days of history for --chart and --export")
 
 	// Flags on the root todo command
 	todoCmd.Flags().BoolVar(&todoShowDone, "done", false, "Show completed todos too")
 	todoCmd.Flags().BoolVarP(&todoShowAll, "all", "a", false, "Show todos across all projects")
 	todoCmd.Flags().StringVar(&todoProjectName, "project", "", "Scope to a named project")
+	todoCmd.Flags().StringVar(&todoGroupFlag, "group", "", "Scope to the projects in a group (see 'mine proj group')")
 	todoCmd.Flags().BoolVar(&todoIncludeSomeday, "someday", false, "Include someday tasks in output")
+	todoCmd.Flags().BoolVar(&todoShowArchived, "archived", false, "Browse archived (completed) todos")
+	todoCmd.Flags().StringVar(&todoContextFlag, "context", "", "Only show todos in this context (e.g. @home)")
+	todoCmd.Flags().StringVar(&todoFilterFlag, "filter", "", "Only show todos matching a saved filter or expression (see 'mine todo filter')")
+
+	// Flags on archive subcommand
+	todoArchiveCmd.Flags().StringVar(&todoArchiveOlder, "older-than", "30d", "Archive todos completed longer ago than this (e.g. 30d, 2w, 12h)")
 
 	// Flags on add subcommand
-	todoAddCmd.Flags().StringVarP(&todoPriority, "priority", "p", "med", "Priority: low, med, high, crit")
-	todoAddCmd.Flags().StringVarP(&todoDue, "due", "d", "", "Due date (YYYY-MM-DD, tomorrow, next-week)")
+	todoAddCmd.Flags().StringVarP(&todoPriority, "priority", "p", "", "Priority: low, med, high, crit (default med, or the project's todo_priority)")
+	todoAddCmd.Flags().StringVarP(&todoDue, "due", "d", "", "Due date, optionally with a time (YYYY-MM-DD, tomorrow, next-week, \"today 5pm\", \"2026-06-01 14:00\")")
 	todoAddCmd.Flags().StringVarP(&todoTags, "tags", "t", "", "Comma-separated tags")
 	todoAddCmd.Flags().StringVar(&todoProjectName, "project", "", "Assign to a named project")
-	todoAddCmd.Flags().StringVar(&todoScheduleFlag, "schedule", "later", "Schedule bucket: today, soon, later, someday")
+	todoAddCmd.Flags().StringVar(&todoContextFlag, "context", "", "GTD context where this can be done (e.g. @home, @work, @errands)")
+	todoAddCmd.Flags().StringVar(&todoScheduleFlag, "schedule", "", "Schedule bucket: today, soon, later, someday (default later, or the project's todo_schedule)")
 	todoAddCmd.Flags().StringVar(&todoNoteFlag, "note", "", "Initial body/context for the task")
-	todoAddCmd.Flags().StringVar(&todoEveryFlag, "every", "", "Recurrence frequency: day (d), weekday (wd), week (w), month (m)")
+	todoAddCmd.Flags().StringVar(&todoEstimateFlag, "estimate", "", "Expected effort (e.g. 30m, 2h, 1h30m)")
+	todoAddCmd.Flags().StringVar(&todoWaitingOnFlag, "waiting-on", "", "Person you've delegated this to (kept out of 'mine todo next')")
+	todoAddCmd.Flags().StringVar(&todoEveryFlag, "every", "", "Recurrence: day (d), weekday (wd), week (w), month (m), or a rule like \"2 weeks\", \"mon,wed,fri\", \"1st of month\"")
+	todoAddCmd.Flags().StringVar(&todoParentFlag, "parent", "", "Make this a subtask of the given todo (ID, slug, or title prefix)")
+
+	// Flags on edit subcommand
+	todoEditCmd.Flags().StringVarP(&todoEditPriority, "priority", "p", "", "New priority: low, med, high, crit")
+	todoEditCmd.Flags().StringVar(&todoEditContext, "context", "", "New context (e.g. @home); \"none\" clears it")
+	todoEditCmd.Flags().BoolVar(&todoEditBody, "body", false, "Edit the body in $EDITOR")
+	todoEditCmd.Flags().StringVar(&todoEditEstimate, "estimate", "", "New effort estimate (e.g. 30m, 1h30m); \"none\" clears it")
+	todoEditCmd.Flags().StringVar(&todoEditWaitingOn, "waiting-on", "", "Person this is delegated to; \"none\" makes it yours again")
+
+	// Flags on next subcommand
+	todoNextCmd.Flags().StringVar(&todoBudgetFlag, "budget", "", "Pick the most urgent tasks whose estimates fit in this much time (e.g. 2h)")
+	todoNextCmd.Flags().StringVar(&todoContextFlag, "context", "", "Only consider todos in this context (e.g. @work)")
 }
 
 var todoAddCmd = &cobra.Command{
@@ -97,18 +154,24 @@ var todoAddCmd = &cobra.Command{
 }
 
 var todoDoneCmd = &cobra.Command{
-	Use:     "done <id>",
+	Use:     "done <id>...",
 	Aliases: []string{"do", "complete", "x"},
-	Short:   "Mark a todo complete — check it off",
-	Args:    cobra.ExactArgs(1),
-	RunE:    hook.Wrap("todo.done", runTodoDone),
+	Short:   "Mark todos complete — check them off",
+	Long: `Mark one or more todos complete.
+
+IDs may be listed individually, as inclusive ranges, or both:
+
+  mine todo done 3 5 7-9`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: hook.Wrap("todo.done", runTodoDone),
 }
 
 var todoRmCmd = &cobra.Command{
-	Use:     "rm <id>",
+	Use:     "rm <id>...",
 	Aliases: []string{"remove", "delete"},
-	Short:   "Remove a todo from the list",
-	Args:    cobra.ExactArgs(1),
+	Short:   "Remove todos from the list",
+	Long:    `Remove one or more todos. Accepts IDs and ranges, e.g. 'mine todo rm 4 10-12'.`,
+	Args:    cobra.MinimumNArgs(1),
 	RunE:    hook.Wrap("todo.rm", runTodoRm),
 }
 
@@ -126,6 +189,7 @@ func resolveTodoProject(ps *proj.Store, projectName string) (*string, error) {
 			}
 			return nil, fmt.Errorf("looking up project %q: %w", projectName, err)
 		}
+		_ = ps.Touch(p.Name) // recency tracking is best-effort
 		return &p.Path, nil
 	}
 
@@ -135,6 +199,7 @@ func resolveTodoProject(ps *proj.Store, projectName string) (*string, error) {
 		return nil, err
 	}
 	if p != nil {
+		_ = ps.Touch(p.Name)
 		return &p.Path, nil
 	}
 	return nil, nil
@@ -149,14 +214,31 @@ func runTodoList(_ *cobra.Command, _ []string) error {
 
 	ps := proj.NewStore(db.Conn())
 
+	ctx, err := parseContextFlag(todoContextFlag)
+	if err != nil {
+		return err
+	}
+
 	opts := todo.ListOptions{
 		ShowDone:       todoShowDone,
 		AllProjects:    todoShowAll,
 		IncludeSomeday: todoIncludeSomeday,
+		Context:        ctx,
 	}
 
 	var projectPath *string
-	if !todoShowAll {
+	grouped := false
+	switch {
+	case todoGroupFlag != "":
+		if todoShowAll || todoProjectName != "" {
+			return fmt.Errorf("--group can't be combined with --all or --project")
+		}
+		opts.ProjectPaths, err = ps.GroupPaths(todoGroupFlag)
+		if err != nil {
+			return err
+		}
+		grouped = true
+	case !todoShowAll:
 		projectPath, err = resolveTodoProject(ps, todoProjectName)
 		if err != nil {
 			return err
@@ -164,6 +246,20 @@ func runTodoList(_ *cobra.Command, _ []string) error {
 		opts.ProjectPath = projectPath
 	}
 
+	if todoShowArchived {
+		archived, err := todo.NewStore(db.Conn()).ListArchived(projectPath, todoShowAll || grouped)
+		if err != nil {
+			return err
+		}
+		if grouped {
+			archived = slices.DeleteFunc(archived, func(t todo.Todo) bool {
+				return t.ProjectPath == nil || !slices.Contains(opts.ProjectPaths, *t.ProjectPath)
+			})
+		}
+		printArchivedTodos(archived, todoShowAll || grouped)
+		return nil
+	}
+
 	// Always resolve the cwd project for urgency scoring boost, independent of
 	// the --all flag. When --all is set, projectPath is nil (no filter) but we
 	// still want the current-project boost to apply for tasks in the active project.
@@ -172,7 +268,7 @@ func runTodoList(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	cfg, err := config.Load()
+	cfg, err := config.LoadForCWD()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -183,23 +279,33 @@ func runTodoList(_ *cobra.Command, _ []string) error {
 	opts.ReferenceTime = now
 
 	ts := todo.NewStore(db.Conn())
+	if err := autoEscalate(ts, cfg, now); err != nil {
+		return err
+	}
 	todos, err := ts.List(opts)
 	if err != nil {
 		return err
 	}
+	if todoFilterFlag != "" {
+		todos, err = applyTodoFilter(ts, todos, todoFilterFlag, now)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Launch interactive TUI when connected to a terminal.
+	// A group spans projects, so list it like --all with project labels.
+	showAll := todoShowAll || grouped
 	if tui.IsTTY() {
-		return runTodoTUI(ts, todos, projectPath, todoShowAll)
+		return runTodoTUI(ts, todos, projectPath, showAll)
 	}
 
-	return printTodoList(todos, ts, projectPath, todoShowAll)
+	return printTodoList(todos, ts, projectPath, showAll)
 }
 
 func runTodoAdd(_ *cobra.Command, args []string) error {
 	title := strings.Join(args, " ")
 
-	prio := parsePriority(todoPriority)
 	due := parseDueDate(todoDue)
 
 	var tags []string
@@ -210,16 +316,28 @@ func runTodoAdd(_ *cobra.Command, args []string) error {
 		}
 	}
 
-	schedule, err := todo.ParseSchedule(todoScheduleFlag)
+	ctx, err := parseContextFlag(todoContextFlag)
 	if err != nil {
-		return fmt.Errorf("%w\n  Use: %s", err, ui.Accent.Render("--schedule today|soon|later|someday"))
+		return err
+	}
+
+	var estimate time.Duration
+	if todoEstimateFlag != "" {
+		if estimate, err = parseEstimateFlag(todoEstimateFlag); err != nil {
+			return err
+		}
+	}
+
+	waitingOn, err := todo.ParseWaitingOn(todoWaitingOnFlag)
+	if err != nil {
+		return err
 	}
 
 	recurrence := todo.RecurrenceNone
 	if todoEveryFlag != "" {
 		recurrence, err = todo.ParseRecurrence(todoEveryFlag)
 		if err != nil {
-			return fmt.Errorf("%w\n  Use: %s", err, ui.Accent.Render("--every day|weekday|week|month"))
+			return fmt.Errorf("%w\n  Use: %s", err, ui.Accent.Render(`--every day|weekday|week|month|"2 weeks"|mon,wed,fri|"1st of month"`))
 		}
 	}
 
@@ -236,26 +354,98 @@ func runTodoAdd(_ *cobra.Command, args []string) error {
 	}
 
 	ts := todo.NewStore(db.Conn())
+
+	var parent *todo.Todo
+	if todoParentFlag != "" {
+		parentID, err := resolveTodoID(ts, todoParentFlag)
+		if err != nil {
+			return err
+		}
+		parent, err = ts.Get(parentID)
+		if err != nil {
+			return fmt.Errorf("%w — use %s to see IDs", err, ui.Accent.Render("mine todo"))
+		}
+		// Subtasks live with their parent unless a project was named explicitly.
+		if todoProjectName == "" {
+			projectPath = parent.ProjectPath
+		}
+	}
+
+	defaults, err := projectTodoDefaults(ps, projectPath)
+	if err != nil {
+		return err
+	}
+	prio := parsePriority(cmp.Or(todoPriority, defaults.Priority))
+	schedule, err := todo.ParseSchedule(cmp.Or(todoScheduleFlag, defaults.Schedule, todo.ScheduleLater))
+	if err != nil {
+		return fmt.Errorf("%w\n  Use: %s", err, ui.Accent.Render("--schedule today|soon|later|someday"))
+	}
+	cfg, err := todoAddConfig(projectPath)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	tags = mergeTags(mergeTags(cfg.Todo.DefaultTags, defaults.Tags), tags)
+
 	id, err := ts.Add(title, todoNoteFlag, prio, tags, due, projectPath, schedule, recurrence)
 	if err != nil {
 		return err
 	}
+	if parent != nil {
+		if err := ts.SetParent(id, &parent.ID); err != nil {
+			return fmt.Errorf("linking #%d to parent #%d: %w", id, parent.ID, err)
+		}
+	}
+	if ctx != "" {
+		if err := ts.SetContext(id, ctx); err != nil {
+			return fmt.Errorf("setting context on #%d: %w", id, err)
+		}
+	}
+	if estimate > 0 {
+		if err := ts.SetEstimate(id, estimate); err != nil {
+			return fmt.Errorf("setting estimate on #%d: %w", id, err)
+		}
+	}
+	if waitingOn != "" {
+		if err := ts.SetWaitingOn(id, waitingOn); err != nil {
+			return fmt.Errorf("setting waiting-on for #%d: %w", id, err)
+		}
+	}
 
 	icon := todo.PriorityIcon(prio)
 	fmt.Printf("  %s Added %s %s\n", ui.Success.Render("✓"), icon, ui.Accent.Render(fmt.Sprintf("#%d", id)))
 	fmt.Printf("    %s\n", title)
 
+	if parent != nil {
+		fmt.Printf("    Subtask of: %s %s\n", ui.Accent.Render(fmt.Sprintf("#%d", parent.ID)), ui.Muted.Render(parent.Title))
+	}
+
 	if projectPath != nil {
 		projName := filepath.Base(*projectPath)
 		fmt.Printf("    Project: %s\n", ui.Muted.Render(projName))
 	}
 
+	if ctx != "" {
+		fmt.Printf("    Context: %s\n", ui.Accent.Render(todo.ContextLabel(ctx)))
+	}
+
+	if estimate > 0 {
+		fmt.Printf("    Estimate: %s\n", ui.Muted.Render(todo.FormatEstimate(estimate)))
+	}
+
+	if waitingOn != "" {
+		fmt.Printf("    Waiting on: %s\n", ui.Muted.Render(waitingOn))
+	}
+
 	if schedule != todo.ScheduleLater {
 		fmt.Printf("    Schedule: %s\n", todo.FormatScheduleTag(schedule))
 	}
 
 	if due != nil {
-		fmt.Printf("    Due: %s\n", ui.Muted.Render(due.Format("Mon, Jan 2")))
+		dueStr := due.Format("Mon, Jan 2")
+		if due.Hour() != 0 || due.Minute() != 0 {
+			dueStr += " " + due.Format("3:04pm")
+		}
+		fmt.Printf("    Due: %s\n", ui.Muted.Render(dueStr))
 	}
 
 	if recurrence != todo.RecurrenceNone {
@@ -268,11 +458,6 @@ func runTodoAdd(_ *cobra.Command, args []string) error {
 }
 
 func runTodoDone(_ *cobra.Command, args []string) error {
-	id, err := strconv.Atoi(args[0])
-	if err != nil {
-		return fmt.Errorf("%q is not a valid todo ID — use %s to see IDs", args[0], ui.Accent.Render("mine todo"))
-	}
-
 	db, err := store.Open()
 	if err != nil {
 		return err
@@ -280,13 +465,48 @@ func runTodoDone(_ *cobra.Command, args []string) error {
 	defer db.Close()
 
 	ts := todo.NewStore(db.Conn())
+	ids, err := resolveTodoIDs(ts, args)
+	if err != nil {
+		return err
+	}
+	ts.BeginBatch()
+	result := newBulkResult("completed", len(ids))
+	for _, id := range ids {
+		if err := completeTodo(ts, id); err != nil {
+			result.fail(err)
+		}
+	}
 
+	// Check remaining
+	open, _, _, _ := ts.Count(nil)
+	if open == 0 {
+		fmt.Println(ui.Success.Render("  " + ui.IconParty + " All clear! Nothing left to do."))
+	} else {
+		fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("  %d remaining", open)))
+	}
+	fmt.Println()
+
+	return result.err()
+}
+
+// completeTodo marks a single todo done and prints the per-task outcome:
+// open-subtask warnings, spawned recurrences, and newly unblocked dependents.
+func completeTodo(ts *todo.Store, id int) error {
 	// Get the todo first for display
 	t, err := ts.Get(id)
 	if err != nil {
 		return err
 	}
 
+	openSubtasks, err := ts.OpenSubtaskCount(id)
+	if err != nil {
+		return err
+	}
+	dependents, err := ts.Dependents(id)
+	if err != nil {
+		return err
+	}
+
 	spawnedID, spawnedDue, err := ts.Complete(id)
 	if err != nil {
 		return err
@@ -294,6 +514,14 @@ func runTodoDone(_ *cobra.Command, args []string) error {
 
 	fmt.Printf("  %s Done! %s\n", ui.Success.Render("✓"), ui.Muted.Render(t.Title))
 
+	if openSubtasks > 0 {
+		fmt.Printf("  %s %s\n",
+			ui.Warning.Render(fmt.Sprintf("%s#%d still has %d open subtask(s) —", ui.IconWarn, id, openSubtasks)),
+			ui.Accent.Render(fmt.Sprintf("mine todo subtasks %d", id)))
+	}
+
+	printUnblocked(ts, dependents)
+
 	if spawnedID > 0 {
 		dueStr := "today"
 		if spawnedDue != nil {
@@ -305,25 +533,10 @@ func runTodoDone(_ *cobra.Command, args []string) error {
 			ui.Muted.Render(dueStr),
 		)
 	}
-
-	// Check remaining
-	open, _, _, _ := ts.Count(nil)
-	if open == 0 {
-		fmt.Println(ui.Success.Render("  " + ui.IconParty + " All clear! Nothing left to do."))
-	} else {
-		fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("  %d remaining", open)))
-	}
-	fmt.Println()
-
 	return nil
 }
 
 func runTodoRm(_ *cobra.Command, args []string) error {
-	id, err := strconv.Atoi(args[0])
-	if err != nil {
-		return fmt.Errorf("%q is not a valid todo ID — use %s to see IDs", args[0], ui.Accent.Render("mine todo"))
-	}
-
 	db, err := store.Open()
 	if err != nil {
 		return err
@@ -331,13 +544,21 @@ func runTodoRm(_ *cobra.Command, args []string) error {
 	defer db.Close()
 
 	ts := todo.NewStore(db.Conn())
-	if err := ts.Delete(id); err != nil {
+	ids, err := resolveTodoIDs(ts, args)
+	if err != nil {
 		return err
 	}
-
-	fmt.Printf("  %s Removed #%d\n", ui.Success.Render("✓"), id)
+	ts.BeginBatch()
+	result := newBulkResult("removed", len(ids))
+	for _, id := range ids {
+		if err := ts.Delete(id); err != nil {
+			result.fail(err)
+			continue
+		}
+		fmt.Printf("  %s Removed #%d\n", ui.Success.Render("✓"), id)
+	}
 	fmt.Println()
-	return nil
+	return result.err()
 }
 
 func parsePriority(s string) int {
@@ -353,7 +574,110 @@ func parsePriority(s string) int {
 	}
 }
 
+// parsePriorityStrict is like parsePriority but rejects unknown values
+// instead of falling back to medium — for edits, where a typo should not
+// silently reset a priority.
+func parsePriorityStrict(s string) (int, error) {
+	switch strings.ToLower(s) {
+	case "med", "medium", "m", "2":
+		return todo.PrioMedium, nil
+	}
+	p := parsePriority(s)
+	if p == todo.PrioMedium {
+		return 0, fmt.Errorf("invalid priority %q\n  Valid values: %s", s, ui.Accent.Render("low (l), med (m), high (h), crit (c)"))
+	}
+	return p, nil
+}
+
+// projectTodoDefaults returns the todo defaults of the project at
+// projectPath, or none for global todos.
+func projectTodoDefaults(ps *proj.Store, projectPath *string) (proj.TodoDefaults, error) {
+	if projectPath == nil {
+		return proj.TodoDefaults{}, nil
+	}
+	p, err := ps.FindForPath(*projectPath)
+	if err != nil || p == nil {
+		return proj.TodoDefaults{}, err
+	}
+	return ps.TodoDefaults(p.Name)
+}
+
+// todoAddConfig loads the config for a new todo, including the overlay of
+// the project it belongs to.
+func todoAddConfig(projectPath *string) (*config.Config, error) {
+	if projectPath == nil {
+		return config.Load()
+	}
+	return config.LoadForDir(*projectPath)
+}
+
+// mergeTags appends extra to base, skipping tags base already has
+// (case-insensitively).
+func mergeTags(base, extra []string) []string {
+	out := append([]string(nil), base...)
+	for _, tag := range extra {
+		if tag == "" || slices.ContainsFunc(out, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			continue
+		}
+		out = append(out, tag)
+	}
+	return out
+}
+
+// parseDueDate parses a --due value: a date ("2026-03-01", "tomorrow",
+// "Jan 2"), optionally followed by a time of day ("2026-03-01 14:00",
+// "today 5pm", "tomorrow 9:30am"). A bare time ("5pm") means today.
+// Returns nil if the value is empty or unrecognized.
 func parseDueDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02T15:04", s, time.Local); err == nil {
+		return &t
+	}
+
+	datePart, hour, minute, hasClock := splitDueClock(s)
+	if !hasClock {
+		return parseDueDay(s)
+	}
+
+	day := parseDueDay(datePart)
+	if datePart == "" {
+		day = parseDueDay("today")
+	}
+	if day == nil {
+		return nil
+	}
+	t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, time.Local)
+	return &t
+}
+
+// splitDueClock separates a trailing time of day from a due value.
+// Accepts "15:04", "3pm", "3:04pm", and "3 pm" (case-insensitive).
+func splitDueClock(s string) (datePart string, hour, minute int, ok bool) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return "", 0, 0, false
+	}
+	clock := fields[len(fields)-1]
+	rest := fields[:len(fields)-1]
+	if (clock == "am" || clock == "pm") && len(rest) > 0 {
+		clock = rest[len(rest)-1] + clock
+		rest = rest[:len(rest)-1]
+	}
+
+	for _, layout := range []string{"15:04", "3pm", "3:04pm"} {
+		if t, err := time.Parse(layout, clock); err == nil {
+			return strings.Join(rest, " "), t.Hour(), t.Minute(), true
+		}
+	}
+	return "", 0, 0, false
+}
+
+// parseDueDay parses the calendar-date portion of a due value.
+func parseDueDay(s string) *time.Time {
 	if s == "" {
 		return nil
 	}
@@ -389,3 +713,21 @@ func parseDueDate(s string) *time.Time {
 
 	return nil
 }
+
+// parseContextFlag normalizes a --context value, wrapping errors with a usage hint.
+func parseContextFlag(s string) (string, error) {
+	ctx, err := todo.ParseContext(s)
+	if err != nil {
+		return "", fmt.Errorf("%w\n  Use: %s", err, ui.Accent.Render("--context @home|@work|@errands"))
+	}
+	return ctx, nil
+}
+
+// parseEstimateFlag parses an --estimate value with a usage hint on error.
+func parseEstimateFlag(s string) (time.Duration, error) {
+	d, err := todo.ParseEstimate(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w\n  Use: %s", err, ui.Accent.Render("--estimate 30m|2h|1h30m"))
+	}
+	return d, nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var todoArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move old completed todos into the archive",
+	Long: `Move completed todos out of the active list and into the archive.
+
+Only todos completed longer ago than --older-than (default 30d) are moved.
+Archived todos still count toward 'mine todo stats' and can be browsed
+with 'mine todo --archived'.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("todo.archive", runTodoArchive),
+}
+
+func runTodoArchive(_ *cobra.Command, _ []string) error {
+	age, err := parseAge(todoArchiveOlder)
+	if err != nil {
+		return fmt.Errorf("%w\n  Use a value like %s", err, ui.Accent.Render("--older-than 30d"))
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	n, err := ts.Archive(time.Now().Add(-age))
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  Nothing to archive — no todos completed more than %s ago.", todoArchiveOlder)))
+		return nil
+	}
+	fmt.Printf("  %s Archived %d completed todo(s)\n", ui.Success.Render("✓"), n)
+	fmt.Printf("  Browse them: %s\n", ui.Accent.Render("mine todo --archived"))
+	return nil
+}
+
+// parseAge parses an age like "30d", "2w", or any time.ParseDuration value.
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	unit := s[len(s)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		days := n
+		if unit == 'w' {
+			days *= 7
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+func printArchivedTodos(todos []todo.Todo, showAll bool) {
+	fmt.Println()
+	if len(todos) == 0 {
+		fmt.Println(ui.Muted.Render("  The archive is empty."))
+		fmt.Printf("  Archive old completed todos: %s\n", ui.Accent.Render("mine todo archive"))
+		fmt.Println()
+		return
+	}
+
+	for _, t := range todos {
+		id := lipgloss.NewStyle().Width(todo.ColWidthID).Render(ui.Muted.Render(fmt.Sprintf("#%d", t.ID)))
+		line := fmt.Sprintf("  %s %s %s %s", ui.Success.Render("✓"), id, todo.FormatPriorityIcon(t.Priority), ui.Muted.Render(t.Title))
+		if t.CompletedAt != nil {
+			line += ui.Muted.Render(" (done " + t.CompletedAt.Local().Format("Jan 2, 2006") + ")")
+		}
+		if len(t.Tags) > 0 {
+			line += ui.Muted.Render(" [" + strings.Join(t.Tags, ", ") + "]")
+		}
+		if showAll && t.ProjectPath != nil {
+			line += ui.Muted.Render(fmt.Sprintf(" @%s", filepath.Base(*t.ProjectPath)))
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d archived", len(todos))))
+	fmt.Println()
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"0d", 0, false},
+		{"", 0, true},
+		{"xd", 0, true},
+		{"-3d", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseAge(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAge(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunTodoArchive_ThenBrowse(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 2)
+
+	captureStdout(t, func() {
+		if err := runTodoDone(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoDone: %v", err)
+		}
+	})
+
+	todoArchiveOlder = "0d"
+	defer func() { todoArchiveOlder = "30d" }()
+	out := captureStdout(t, func() {
+		if err := runTodoArchive(nil, nil); err != nil {
+			t.Fatalf("runTodoArchive: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Archived 1") {
+		t.Errorf("expected archive confirmation:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTodoList(nil, nil); err != nil {
+			t.Fatalf("runTodoList: %v", err)
+		}
+	})
+	if strings.Contains(out, "task 1") {
+		t.Errorf("archived todo should not appear in the active list:\n%s", out)
+	}
+
+	todoShowArchived = true
+	defer func() { todoShowArchived = false }()
+	out = captureStdout(t, func() {
+		if err := runTodoList(nil, nil); err != nil {
+			t.Fatalf("runTodoList --archived: %v", err)
+		}
+	})
+	if !strings.Contains(out, "task 1") || strings.Contains(out, "task 2") {
+		t.Errorf("expected only archived todo in --archived output:\n%s", out)
+	}
+}
+
+func TestRunTodoArchive_InvalidAge(t *testing.T) {
+	todoTestEnv(t)
+	todoArchiveOlder = "forever"
+	defer func() { todoArchiveOlder = "30d" }()
+
+	err := runTodoArchive(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid duration") {
+		t.Fatalf("expected invalid duration error, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var todoAttachRemove int
+
+func init() {
+	todoCmd.AddCommand(todoAttachCmd)
+	todoCmd.AddCommand(todoOpenCmd)
+	todoAttachCmd.Flags().IntVar(&todoAttachRemove, "rm", 0, "Remove the attachment at this position (see 'mine todo show')")
+}
+
+var todoAttachCmd = &cobra.Command{
+	Use:   "attach <id> <path-or-url>",
+	Short: "Attach a file or link to a todo",
+	Long: `Attach a local file or a URL to a todo. Files are stored as absolute
+paths and must exist; nothing is copied. Attachments are listed in
+'mine todo show' and opened with 'mine todo open'.
+
+  mine todo attach 3 ~/Downloads/invoice.pdf
+  mine todo attach 3 https://github.com/org/repo/pull/42
+  mine todo attach 3 --rm 1        # remove the first attachment`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: hook.Wrap("todo.attach", runTodoAttach),
+}
+
+var todoOpenCmd = &cobra.Command{
+	Use:   "open <id> [n]",
+	Short: "Open a todo's attachment",
+	Long: `Open a todo's first attachment (or the n-th) with the system handler —
+xdg-open on Linux, open on macOS.
+
+  mine todo open 3
+  mine todo open 3 2`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: hook.Wrap("todo.open", runTodoOpen),
+}
+
+// openTarget hands a file or URL to the desktop's default handler.
+// Injectable for testing.
+var openTarget = func(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if _, err := exec.LookPath("xdg-open"); err != nil {
+			return fmt.Errorf("xdg-open not found — install xdg-utils")
+		}
+		cmd = exec.Command("xdg-open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		return fmt.Errorf("opening files is not supported on %s", runtime.GOOS)
+	}
+	return cmd.Start()
+}
+
+func runTodoAttach(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
+
+	if todoAttachRemove > 0 {
+		if len(args) > 1 {
+			return fmt.Errorf("--rm takes a position, not a path — use %s", ui.Accent.Render(fmt.Sprintf("mine todo attach %d --rm <n>", id)))
+		}
+		a, err := ts.RemoveAttachment(id, todoAttachRemove)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("  %s Removed from #%d: %s\n", ui.Success.Render("✓"), id, ui.Muted.Render(a.Target))
+		return nil
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("nothing to attach — use %s", ui.Accent.Render(fmt.Sprintf("mine todo attach %d <path-or-url>", id)))
+	}
+
+	target, err := resolveAttachment(args[1])
+	if err != nil {
+		return err
+	}
+	n, err := ts.AddAttachment(id, target)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  %s Attached to #%d: %s\n", ui.Success.Render("✓"), id, ui.Muted.Render(target))
+	fmt.Printf("    Open it: %s\n", ui.Accent.Render(attachmentOpenHint(id, n)))
+	return nil
+}
+
+// resolveAttachment keeps URLs as-is and turns file paths into absolute,
+// existing paths.
+func resolveAttachment(arg string) (string, error) {
+	arg = strings.TrimSpace(arg)
+	if todo.IsAttachmentURL(arg) {
+		return arg, nil
+	}
+	path := arg
+	if strings.HasPrefix(path, "~") {
+		home, _ := os.UserHomeDir()
+		path = filepath.Join(home, path[1:])
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("no such file %q — attach an existing file or a full URL like https://…", arg)
+	}
+	return path, nil
+}
+
+func attachmentOpenHint(id, n int) string {
+	if n == 1 {
+		return fmt.Sprintf("mine todo open %d", id)
+	}
+	return fmt.Sprintf("mine todo open %d %d", id, n)
+}
+
+func runTodoOpen(_ *cobra.Command, args []string) error {
+	n := 1
+	if len(args) == 2 {
+		var err error
+		if n, err = strconv.Atoi(args[1]); err != nil || n < 1 {
+			return fmt.Errorf("%q is not a valid attachment position — use %s to see them", args[1], ui.Accent.Render("mine todo show "+args[0]))
+		}
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := ts.Get(id); err != nil {
+		return err
+	}
+	attachments, err := ts.Attachments(id)
+	if err != nil {
+		return err
+	}
+	if len(attachments) == 0 {
+		return fmt.Errorf("todo #%d has no attachments — add one with %s", id, ui.Accent.Render(fmt.Sprintf("mine todo attach %d <path-or-url>", id)))
+	}
+	if n > len(attachments) {
+		return fmt.Errorf("todo #%d has no attachment %d — it has %d", id, n, len(attachments))
+	}
+
+	target := attachments[n-1].Target
+	if err := openTarget(target); err != nil {
+		return fmt.Errorf("opening %s: %w", target, err)
+	}
+	fmt.Printf("  %s Opened %s\n", ui.Success.Render("✓"), ui.Muted.Render(target))
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunTodoAttach_FileAndURL(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile("spec.md", []byte("# spec"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	captureStdout(t, func() {
+		if err := runTodoAttach(nil, []string{"1", "spec.md"}); err != nil {
+			t.Fatalf("runTodoAttach file: %v", err)
+		}
+		if err := runTodoAttach(nil, []string{"1", "https://example.com/pr/42"}); err != nil {
+			t.Fatalf("runTodoAttach url: %v", err)
+		}
+	})
+
+	if err := runTodoAttach(nil, []string{"1", "missing.md"}); err == nil || !strings.Contains(err.Error(), "no such file") {
+		t.Errorf("expected no such file error, got %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTodoShow(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoShow: %v", err)
+		}
+	})
+	for _, want := range []string{filepath.Join(dir, "spec.md"), "https://example.com/pr/42"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in show output:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunTodoOpen(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+
+	var opened []string
+	orig := openTarget
+	openTarget = func(target string) error {
+		opened = append(opened, target)
+		return nil
+	}
+	defer func() { openTarget = orig }()
+
+	if err := runTodoOpen(nil, []string{"1"}); err == nil || !strings.Contains(err.Error(), "no attachments") {
+		t.Fatalf("expected no attachments error, got %v", err)
+	}
+
+	captureStdout(t, func() {
+		runTodoAttach(nil, []string{"1", "https://a.example"})
+		runTodoAttach(nil, []string{"1", "https://b.example"})
+		if err := runTodoOpen(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoOpen: %v", err)
+		}
+		if err := runTodoOpen(nil, []string{"1", "2"}); err != nil {
+			t.Fatalf("runTodoOpen 2: %v", err)
+		}
+	})
+	if len(opened) != 2 || opened[0] != "https://a.example" || opened[1] != "https://b.example" {
+		t.Errorf("opened = %v", opened)
+	}
+
+	if err := runTodoOpen(nil, []string{"1", "3"}); err == nil || !strings.Contains(err.Error(), "no attachment 3") {
+		t.Errorf("expected out-of-range error, got %v", err)
+	}
+
+	todoAttachRemove = 1
+	defer func() { todoAttachRemove = 0 }()
+	captureStdout(t, func() {
+		if err := runTodoAttach(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoAttach --rm: %v", err)
+		}
+	})
+	opened = nil
+	captureStdout(t, func() { runTodoOpen(nil, []string{"1"}) })
+	if len(opened) != 1 || opened[0] != "https://b.example" {
+		t.Errorf("after --rm 1, opened = %v", opened)
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	todoCmd.AddCommand(todoBoardCmd)
+	todoBoardCmd.Flags().BoolVarP(&todoShowAll, "all", "a", false, "Show todos across all projects")
+	todoBoardCmd.Flags().StringVar(&todoProjectName, "project", "", "Scope to a named project")
+}
+
+var todoBoardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Kanban board of todos by schedule bucket",
+	Long: `Show open todos as a board with one column per schedule bucket —
+today, soon, later, someday — so it's easy to see when a bucket is
+overloaded.
+
+In a terminal the board is interactive:
+
+  ←/→   focus the previous/next column
+  j/k   select a card
+  h/l   move the card one column left/right (reschedules it)
+  x     complete the card
+  q     quit and save
+
+Piped or redirected, the board prints as plain text, one bucket per section.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("todo.board", runTodoBoard),
+}
+
+func runTodoBoard(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	opts := todo.ListOptions{
+		AllProjects:    todoShowAll,
+		IncludeSomeday: true,
+	}
+	if !todoShowAll {
+		projectPath, err := resolveTodoProject(proj.NewStore(db.Conn()), todoProjectName)
+		if err != nil {
+			return err
+		}
+		opts.ProjectPath = projectPath
+	}
+
+	ts := todo.NewStore(db.Conn())
+	todos, err := ts.List(opts)
+	if err != nil {
+		return err
+	}
+
+	if tui.IsTTY() {
+		actions, err := tui.RunBoard(todos)
+		if err != nil {
+			return err
+		}
+		applyTodoActions(ts, actions)
+		return nil
+	}
+
+	printTodoBoard(todos, todoShowAll)
+	return nil
+}
+
+// printTodoBoard renders the board as plain text: a section per bucket.
+func printTodoBoard(todos []todo.Todo, showAll bool) {
+	columns := make(map[string][]todo.Todo, len(tui.BoardColumns))
+	for _, t := range todos {
+		if t.Done {
+			continue
+		}
+		sched := t.Schedule
+		if !slices.Contains(tui.BoardColumns, sched) {
+			sched = todo.ScheduleLater
+		}
+		columns[sched] = append(columns[sched], t)
+	}
+
+	fmt.Println()
+	for _, sched := range tui.BoardColumns {
+		cards := columns[sched]
+		fmt.Printf("  %s %s\n", ui.Title.Render(strings.ToUpper(todo.ScheduleLabel(sched))), ui.Muted.Render(fmt.Sprintf("(%d)", len(cards))))
+		if len(cards) == 0 {
+			fmt.Println(ui.Muted.Render("    —"))
+		}
+		for _, t := range cards {
+			id := lipgloss.NewStyle().Width(todo.ColWidthID).Render(ui.Muted.Render(fmt.Sprintf("#%d", t.ID)))
+			line := fmt.Sprintf("    %s %s  %s", id, todo.FormatPriorityIcon(t.Priority), t.Title)
+			if showAll && t.ProjectPath != nil {
+				line += ui.Muted.Render(" @" + filepath.Base(*t.ProjectPath))
+			}
+			fmt.Println(line)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func TestRunTodoBoard_PlainText(t *testing.T) {
+	todoTestEnv(t)
+	todoShowAll = false
+	todoProjectName = ""
+	t.Chdir(t.TempDir())
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	ts.Add("ship release", "", todo.PrioHigh, nil, nil, nil, todo.ScheduleToday, todo.RecurrenceNone)
+	ts.Add("learn rust", "", todo.PrioLow, nil, nil, nil, todo.ScheduleSomeday, todo.RecurrenceNone)
+	done, _ := ts.Add("old chore", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleToday, todo.RecurrenceNone)
+	ts.Complete(done)
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runTodoBoard(nil, nil); err != nil {
+			t.Fatalf("runTodoBoard: %v", err)
+		}
+	})
+	for _, want := range []string{"TODAY", "(1)", "ship release", "SOON", "LATER", "(0)", "SOMEDAY", "learn rust"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in board:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "old chore") {
+		t.Errorf("done todos should be left off the board:\n%s", out)
+	}
+	if strings.Index(out, "ship release") > strings.Index(out, "SOON") {
+		t.Errorf("expected today's todo under TODAY:\n%s", out)
+	}
+}
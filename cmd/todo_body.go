@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	todoCmd.AddCommand(todoBodyCmd)
+}
+
+var todoBodyCmd = &cobra.Command{
+	Use:   "body <id>",
+	Short: "Edit a todo's body in $EDITOR",
+	Long: `Open a todo's body in $EDITOR and save it back when the editor exits,
+like 'git commit' does. Saving an empty file clears the body.
+
+Same as 'mine todo edit <id> --body'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("todo.body", runTodoBody),
+}
+
+// runEditor opens path in the user's editor. Injectable for testing.
+var runEditor = func(editor, path string) error {
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func runTodoBody(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
+	return editTodoBody(ts, id)
+}
+
+// editTodoBody round-trips a todo's body through $EDITOR via a temp file.
+func editTodoBody(ts *todo.Store, id int) error {
+	editor := os.Getenv("EDITOR")
+	if strings.TrimSpace(editor) == "" {
+		return fmt.Errorf(
+			"$EDITOR is not set\n\nSet it in your shell profile:\n  export EDITOR=vim\n\nOr add context without an editor:\n  %s",
+			ui.Accent.Render(fmt.Sprintf(`mine todo note %d "..."`, id)),
+		)
+	}
+
+	t, err := ts.Get(id)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(os.TempDir(), fmt.Sprintf("mine-todo-%d-*.md", id))
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	initial := t.Body
+	if initial != "" && !strings.HasSuffix(initial, "\n") {
+		initial += "\n"
+	}
+	if _, err := tmp.WriteString(initial); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+
+	if err := runEditor(editor, tmpPath); err != nil {
+		return fmt.Errorf("editor exited with an error — no changes saved: %w", err)
+	}
+
+	content, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return fmt.Errorf("reading temp file after edit: %w", err)
+	}
+	body := strings.TrimRight(string(content), " \t\r\n")
+	if body == t.Body {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  Body of #%d unchanged.", id)))
+		return nil
+	}
+
+	if err := ts.SetBody(id, body); err != nil {
+		return err
+	}
+	if body == "" {
+		fmt.Printf("  %s Cleared body of #%d\n", ui.Success.Render("✓"), id)
+	} else {
+		fmt.Printf("  %s Saved body of #%d %s\n", ui.Success.Render("✓"), id,
+			ui.Muted.Render(fmt.Sprintf("(%d line(s))", strings.Count(body, "\n")+1)))
+	}
+	return nil
+}
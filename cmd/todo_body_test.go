@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// fakeEditor replaces the file's contents with body and records what it was given.
+func fakeEditor(t *testing.T, body string, seen *string) {
+	t.Helper()
+	t.Setenv("EDITOR", "fake-editor --wait")
+	orig := runEditor
+	t.Cleanup(func() { runEditor = orig })
+	runEditor = func(editor, path string) error {
+		if editor != "fake-editor --wait" {
+			t.Errorf("editor = %q", editor)
+		}
+		if seen != nil {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("reading temp file: %v", err)
+			}
+			*seen = string(data)
+		}
+		return os.WriteFile(path, []byte(body), 0o600)
+	}
+}
+
+func TestRunTodoBody_SavesEditedBody(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+	fakeEditor(t, "first line\nsecond line\n\n", nil)
+
+	out := captureStdout(t, func() {
+		if err := runTodoBody(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoBody: %v", err)
+		}
+	})
+	if got := getTodo(t, 1).Body; got != "first line\nsecond line" {
+		t.Errorf("body = %q", got)
+	}
+	if !strings.Contains(out, "Saved body of #1") {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestRunTodoBody_PrefillsAndDetectsNoChange(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+	fakeEditor(t, "draft\n", nil)
+	captureStdout(t, func() { _ = runTodoBody(nil, []string{"1"}) })
+
+	var seen string
+	fakeEditor(t, "draft\n", &seen)
+	out := captureStdout(t, func() {
+		if err := runTodoBody(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoBody: %v", err)
+		}
+	})
+	if seen != "draft\n" {
+		t.Errorf("editor saw %q, want existing body", seen)
+	}
+	if !strings.Contains(out, "unchanged") {
+		t.Errorf("output = %q, want unchanged", out)
+	}
+}
+
+func TestRunTodoBody_NoEditor(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+	t.Setenv("EDITOR", "")
+
+	err := runTodoBody(nil, []string{"1"})
+	if err == nil || !strings.Contains(err.Error(), "$EDITOR is not set") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRunTodoEdit_BodyWithTitle(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+	fakeEditor(t, "details", nil)
+	todoEditBody = true
+	defer func() { todoEditBody = false }()
+
+	captureStdout(t, func() {
+		if err := runTodoEdit(nil, []string{"1", "renamed"}); err != nil {
+			t.Fatalf("runTodoEdit: %v", err)
+		}
+	})
+	got := getTodo(t, 1)
+	if got.Title != "renamed" || got.Body != "details" {
+		t.Errorf("got title %q body %q", got.Title, got.Body)
+	}
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+)
+
+// maxTodoIDRange caps how many IDs a single "a-b" range may expand to,
+// guarding against typos like "1-100000".
+const maxTodoIDRange = 500
+
+// parseTodoIDs parses todo ID arguments. Each argument may be a single ID
+// ("7"), an inclusive range ("7-9"), or a comma-separated mix ("3,5,7-9").
+// Duplicates are dropped; order of first appearance is preserved.
+func parseTodoIDs(args []string) ([]int, error) {
+	var ids []int
+	seen := map[int]bool{}
+	add := func(id int) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			lo, hi, isRange := strings.Cut(part, "-")
+			if !isRange {
+				id, err := strconv.Atoi(part)
+				if err != nil || id <= 0 {
+					return nil, invalidTodoIDError(part)
+				}
+				add(id)
+				continue
+			}
+			start, err1 := strconv.Atoi(lo)
+			end, err2 := strconv.Atoi(hi)
+			if err1 != nil || err2 != nil || start <= 0 || end < start {
+				return nil, fmt.Errorf("%q is not a valid ID range — use %s", part, ui.Accent.Render("low-high (e.g. 7-9)"))
+			}
+			if end-start+1 > maxTodoIDRange {
+				return nil, fmt.Errorf("range %q spans more than %d IDs", part, maxTodoIDRange)
+			}
+			for id := start; id <= end; id++ {
+				add(id)
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no todo IDs given — use %s to see IDs", ui.Accent.Render("mine todo"))
+	}
+	return ids, nil
+}
+
+// isTodoIDArg reports whether arg parses as a todo ID, range, or ID list.
+func isTodoIDArg(arg string) bool {
+	_, err := parseTodoIDs([]string{arg})
+	return err == nil
+}
+
+// numericTodoRef matches a bare ID or an "a-b" range; anything else is
+// resolved as a slug or title reference.
+var numericTodoRef = regexp.MustCompile(`^\d+(-\d+)?$`)
+
+// resolveTodoIDs is parseTodoIDs that also accepts slugs and title prefixes
+// ("fix-login") alongside IDs and ranges.
+func resolveTodoIDs(ts *todo.Store, args []string) ([]int, error) {
+	var ids []int
+	seen := map[int]bool{}
+	for _, arg := range args {
+		for _, part := range strings.Split(arg, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			var partIDs []int
+			if numericTodoRef.MatchString(part) {
+				parsed, err := parseTodoIDs([]string{part})
+				if err != nil {
+					return nil, err
+				}
+				partIDs = parsed
+			} else {
+				id, err := resolveTodoRef(ts, part)
+				if err != nil {
+					return nil, err
+				}
+				partIDs = []int{id}
+			}
+			for _, id := range partIDs {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no todo IDs given — use %s to see IDs", ui.Accent.Render("mine todo"))
+	}
+	return ids, nil
+}
+
+// resolveTodoID resolves a single todo reference: an ID, a slug, or a
+// title prefix.
+func resolveTodoID(ts *todo.Store, arg string) (int, error) {
+	arg = strings.TrimSpace(arg)
+	if id, err := strconv.Atoi(arg); err == nil {
+		if id <= 0 {
+			return 0, invalidTodoIDError(arg)
+		}
+		return id, nil
+	}
+	return resolveTodoRef(ts, arg)
+}
+
+// resolveTodoRef turns a slug or title prefix into an ID, asking the user
+// to choose when several todos match.
+func resolveTodoRef(ts *todo.Store, ref string) (int, error) {
+	matches, err := ts.Resolve(ref)
+	if err != nil {
+		return 0, fmt.Errorf("resolving %q: %w", ref, err)
+	}
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("%q is not a valid todo ID and matches no todo title — use %s to see IDs", ref, ui.Accent.Render("mine todo"))
+	case 1:
+		return matches[0].ID, nil
+	}
+	return chooseTodoMatch(ref, matches)
+}
+
+// chooseTodoMatch asks which of several matching todos was meant. Outside a
+// terminal it lists the candidates in the error instead. Injectable for
+// testing.
+var chooseTodoMatch = func(ref string, matches []todo.Todo) (int, error) {
+	if !tui.IsTTY() {
+		return 0, ambiguousTodoRefError(ref, matches)
+	}
+	return promptTodoMatch(bufio.NewReader(os.Stdin), ref, matches)
+}
+
+// maxTodoMatches caps how many candidates a disambiguation prompt lists.
+const maxTodoMatches = 9
+
+// promptTodoMatch prints numbered candidates and reads a choice.
+func promptTodoMatch(reader *bufio.Reader, ref string, matches []todo.Todo) (int, error) {
+	if len(matches) > maxTodoMatches {
+		return 0, ambiguousTodoRefError(ref, matches)
+	}
+	fmt.Printf("  %s matches %d todos:\n", ui.Accent.Render(ref), len(matches))
+	for i, t := range matches {
+		fmt.Printf("    %d) %s %s\n", i+1, ui.Muted.Render(fmt.Sprintf("#%d", t.ID)), t.Title)
+	}
+	fmt.Printf("  Which one? [1-%d, enter to cancel] ", len(matches))
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return 0, fmt.Errorf("canceled — %q is ambiguous", ref)
+	}
+	n, err := strconv.Atoi(line)
+	if err != nil || n < 1 || n > len(matches) {
+		return 0, fmt.Errorf("%q is not one of the choices", line)
+	}
+	return matches[n-1].ID, nil
+}
+
+func ambiguousTodoRefError(ref string, matches []todo.Todo) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches %d todos — use an ID or a longer prefix:", ref, len(matches))
+	for i, t := range matches {
+		if i == maxTodoMatches {
+			fmt.Fprintf(&b, "\n    … and %d more", len(matches)-i)
+			break
+		}
+		fmt.Fprintf(&b, "\n    #%d %s", t.ID, t.Title)
+	}
+	return errors.New(b.String())
+}
+
+func invalidTodoIDError(arg string) error {
+	return fmt.Errorf("%q is not a valid todo ID — use %s to see IDs", arg, ui.Accent.Render("mine todo"))
+}
+
+// bulkResult collects per-ID failures from a multi-ID operation.
+type bulkResult struct {
+	verb   string
+	total  int
+	failed []error
+}
+
+func newBulkResult(verb string, total int) *bulkResult {
+	return &bulkResult{verb: verb, total: total}
+}
+
+func (r *bulkResult) fail(err error) {
+	r.failed = append(r.failed, err)
+}
+
+// err reports the outcome. A single-ID operation returns its error unchanged
+// so messages match the one-at-a-time commands; bulk failures are listed and
+// summarized.
+func (r *bulkResult) err() error {
+	if len(r.failed) == 0 {
+		return nil
+	}
+	if r.total == 1 {
+		return r.failed[0]
+	}
+	fmt.Println(ui.Warning.Render("  Some todos failed:"))
+	for _, e := range r.failed {
+		fmt.Println("    " + e.Error())
+	}
+	fmt.Println()
+	return fmt.Errorf("%d of %d todos could not be %s", len(r.failed), r.total, r.verb)
+}
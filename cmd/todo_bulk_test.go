@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"bufio"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func TestParseTodoIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []int
+		wantErr string
+	}{
+		{"single", []string{"4"}, []int{4}, ""},
+		{"multiple", []string{"3", "5"}, []int{3, 5}, ""},
+		{"range", []string{"7-9"}, []int{7, 8, 9}, ""},
+		{"mixed", []string{"3", "5", "7-9"}, []int{3, 5, 7, 8, 9}, ""},
+		{"comma list", []string{"3,5,7-8"}, []int{3, 5, 7, 8}, ""},
+		{"dedupes", []string{"2", "1-3"}, []int{2, 1, 3}, ""},
+		{"non-numeric", []string{"abc"}, nil, "not a valid todo ID"},
+		{"reversed range", []string{"9-7"}, nil, "not a valid ID range"},
+		{"zero", []string{"0"}, nil, "not a valid todo ID"},
+		{"huge range", []string{"1-100000"}, nil, "spans more than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTodoIDs(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// seedTodos adds n open todos and returns their IDs.
+func seedTodos(t *testing.T, n int) []int {
+	t.Helper()
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ts := todo.NewStore(db.Conn())
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i], err = ts.Add("task "+strconv.Itoa(i+1), "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ids
+}
+
+func getTodo(t *testing.T, id int) *todo.Todo {
+	t.Helper()
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	got, err := todo.NewStore(db.Conn()).Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestRunTodoDone_MultipleIDsAndRange(t *testing.T) {
+	todoTestEnv(t)
+	ids := seedTodos(t, 5)
+
+	captureStdout(t, func() {
+		if err := runTodoDone(nil, []string{"1", "3-4"}); err != nil {
+			t.Fatalf("runTodoDone: %v", err)
+		}
+	})
+
+	for _, id := range ids {
+		want := id == 1 || id == 3 || id == 4
+		if got := getTodo(t, id).Done; got != want {
+			t.Errorf("#%d done = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestRunTodoDone_PartialFailure_ReportsAndContinues(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 2)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = runTodoDone(nil, []string{"1", "99", "2"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("expected '1 of 3' summary error, got %v", err)
+	}
+	if !strings.Contains(out, "todo #99 not found") {
+		t.Errorf("expected failure detail in output:\n%s", out)
+	}
+	if !getTodo(t, 2).Done {
+		t.Error("expected #2 completed despite #99 failing")
+	}
+}
+
+func TestRunTodoRm_Range(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 3)
+
+	captureStdout(t, func() {
+		if err := runTodoRm(nil, []string{"1-2"}); err != nil {
+			t.Fatalf("runTodoRm: %v", err)
+		}
+	})
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	todos, _ := todo.NewStore(db.Conn()).List(todo.ListOptions{AllProjects: true})
+	if len(todos) != 1 || todos[0].ID != 3 {
+		t.Fatalf("expected only #3 left, got %v", todos)
+	}
+}
+
+func TestRunTodoSchedule_MultipleIDs(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 3)
+
+	captureStdout(t, func() {
+		if err := runTodoSchedule(nil, []string{"1", "3", "today"}); err != nil {
+			t.Fatalf("runTodoSchedule: %v", err)
+		}
+	})
+	if getTodo(t, 1).Schedule != todo.ScheduleToday || getTodo(t, 3).Schedule != todo.ScheduleToday {
+		t.Error("expected #1 and #3 scheduled today")
+	}
+	if getTodo(t, 2).Schedule != todo.ScheduleLater {
+		t.Error("expected #2 untouched")
+	}
+}
+
+func TestRunTodoEdit_BulkPriority(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 3)
+	todoEditPriority = "crit"
+	defer func() { todoEditPriority = "" }()
+
+	captureStdout(t, func() {
+		if err := runTodoEdit(nil, []string{"1-2"}); err != nil {
+			t.Fatalf("runTodoEdit: %v", err)
+		}
+	})
+	if getTodo(t, 1).Priority != todo.PrioCrit || getTodo(t, 2).Priority != todo.PrioCrit {
+		t.Error("expected #1 and #2 at crit priority")
+	}
+	if got := getTodo(t, 1).Title; got != "task 1" {
+		t.Errorf("title should be unchanged, got %q", got)
+	}
+}
+
+func TestRunTodoEdit_TitleAndPriority(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+	todoEditPriority = "high"
+	defer func() { todoEditPriority = "" }()
+
+	captureStdout(t, func() {
+		if err := runTodoEdit(nil, []string{"1", "renamed", "task"}); err != nil {
+			t.Fatalf("runTodoEdit: %v", err)
+		}
+	})
+	got := getTodo(t, 1)
+	if got.Title != "renamed task" || got.Priority != todo.PrioHigh {
+		t.Errorf("got title %q priority %d", got.Title, got.Priority)
+	}
+}
+
+func TestRunTodoEdit_InvalidPriority_Error(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+	todoEditPriority = "urgent"
+	defer func() { todoEditPriority = "" }()
+
+	err := runTodoEdit(nil, []string{"1"})
+	if err == nil || !strings.Contains(err.Error(), "invalid priority") {
+		t.Fatalf("expected invalid priority error, got %v", err)
+	}
+}
+
+func TestRunTodoEdit_NothingToChange_Error(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+
+	err := runTodoEdit(nil, []string{"1"})
+	if err == nil || !strings.Contains(err.Error(), "nothing to change") {
+		t.Fatalf("expected nothing to change error, got %v", err)
+	}
+}
+
+func TestRunTodoUndo_RevertsBulkDone(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 3)
+
+	captureStdout(t, func() {
+		if err := runTodoDone(nil, []string{"1-3"}); err != nil {
+			t.Fatalf("runTodoDone: %v", err)
+		}
+	})
+	out := captureStdout(t, func() {
+		if err := runTodoUndo(nil, nil); err != nil {
+			t.Fatalf("runTodoUndo: %v", err)
+		}
+	})
+	if strings.Count(out, "Undid done") != 3 {
+		t.Errorf("expected 3 undone completions:\n%s", out)
+	}
+	for id := 1; id <= 3; id++ {
+		if getTodo(t, id).Done {
+			t.Errorf("expected #%d reopened", id)
+		}
+	}
+}
+
+func TestRunTodoUndo_RestoresRemoved(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 1)
+
+	captureStdout(t, func() {
+		if err := runTodoRm(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoRm: %v", err)
+		}
+	})
+	captureStdout(t, func() {
+		if err := runTodoUndo(nil, nil); err != nil {
+			t.Fatalf("runTodoUndo: %v", err)
+		}
+	})
+	if got := getTodo(t, 1); got.Title != "task 1" {
+		t.Errorf("expected task restored, got %q", got.Title)
+	}
+}
+
+func TestRunTodoUndo_EmptyJournal(t *testing.T) {
+	todoTestEnv(t)
+
+	out := captureStdout(t, func() {
+		if err := runTodoUndo(nil, nil); err != nil {
+			t.Fatalf("runTodoUndo: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Nothing to undo") {
+		t.Errorf("expected 'Nothing to undo':\n%s", out)
+	}
+}
+
+func TestResolveTodoIDs_SlugsAndTitles(t *testing.T) {
+	todoTestEnv(t)
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ts := todo.NewStore(db.Conn())
+	login, _ := ts.Add("Fix login", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	logout, _ := ts.Add("Fix logout", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	docs, _ := ts.Add("Write the docs", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+
+	got, err := resolveTodoIDs(ts, []string{"fix-login", "1-2,write"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{login, logout, docs}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := resolveTodoIDs(ts, []string{"deploy"}); err == nil || !strings.Contains(err.Error(), "matches no todo") {
+		t.Errorf("expected no-match error, got %v", err)
+	}
+
+	var offered []todo.Todo
+	orig := chooseTodoMatch
+	chooseTodoMatch = func(_ string, matches []todo.Todo) (int, error) {
+		offered = matches
+		return matches[1].ID, nil
+	}
+	defer func() { chooseTodoMatch = orig }()
+
+	id, err := resolveTodoID(ts, "fix-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != logout || len(offered) != 2 {
+		t.Errorf("resolved %d from %d candidates, want #%d from 2", id, len(offered), logout)
+	}
+}
+
+func TestPromptTodoMatch(t *testing.T) {
+	matches := []todo.Todo{{ID: 4, Title: "Fix login"}, {ID: 9, Title: "Fix logout"}}
+
+	var id int
+	var err error
+	out := captureStdout(t, func() {
+		id, err = promptTodoMatch(bufio.NewReader(strings.NewReader("2\n")), "fix-log", matches)
+	})
+	if err != nil || id != 9 {
+		t.Errorf("promptTodoMatch = (%d, %v), want (9, nil)", id, err)
+	}
+	if !strings.Contains(out, "matches 2 todos") || !strings.Contains(out, "Fix logout") {
+		t.Errorf("unexpected prompt:\n%s", out)
+	}
+
+	captureStdout(t, func() {
+		_, err = promptTodoMatch(bufio.NewReader(strings.NewReader("\n")), "fix-log", matches)
+	})
+	if err == nil || !strings.Contains(err.Error(), "canceled") {
+		t.Errorf("expected cancel error, got %v", err)
+	}
+
+	if err := ambiguousTodoRefError("fix-log", matches); !strings.Contains(err.Error(), "#4 Fix login") {
+		t.Errorf("ambiguity error should list candidates, got %v", err)
+	}
+}
+
+func TestRunTodoDone_BySlug(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 2)
+
+	captureStdout(t, func() {
+		if err := runTodoDone(nil, []string{"task-2"}); err != nil {
+			t.Fatalf("runTodoDone: %v", err)
+		}
+	})
+	if getTodo(t, 1).Done || !getTodo(t, 2).Done {
+		t.Error("expected only #2 completed")
+	}
+}
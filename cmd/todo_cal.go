@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/tui"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	todoCmd.AddCommand(todoCalCmd)
+	todoCalCmd.Flags().BoolVarP(&todoShowAll, "all", "a", false, "Show todos across all projects")
+	todoCalCmd.Flags().StringVar(&todoProjectName, "project", "", "Scope to a named project")
+}
+
+var todoCalCmd = &cobra.Command{
+	Use:   "cal [month]",
+	Short: "Month calendar of todo due dates",
+	Long: `Show a month calendar with the number of open todos due each day.
+Days with overdue todos are red, today is gold, and upcoming days with
+work due are green.
+
+The month defaults to the current one and can be given as 2026-03, a month
+name (march, mar), next, or prev.
+
+In a terminal the calendar is interactive: arrow keys select a day and its
+todos are listed under the grid; p/n flip months. Piped, it prints the grid
+followed by each day's todos.
+
+  mine todo cal
+  mine todo cal next
+  mine todo cal 2026-12`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("todo.cal", runTodoCal),
+}
+
+func runTodoCal(_ *cobra.Command, args []string) error {
+	now := time.Now()
+	arg := ""
+	if len(args) == 1 {
+		arg = args[0]
+	}
+	month, err := parseCalMonth(arg, now)
+	if err != nil {
+		return err
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	opts := todo.ListOptions{
+		AllProjects:    todoShowAll,
+		IncludeSomeday: true,
+	}
+	if !todoShowAll {
+		projectPath, err := resolveTodoProject(proj.NewStore(db.Conn()), todoProjectName)
+		if err != nil {
+			return err
+		}
+		opts.ProjectPath = projectPath
+	}
+	todos, err := todo.NewStore(db.Conn()).List(opts)
+	if err != nil {
+		return err
+	}
+
+	if tui.IsTTY() {
+		return tui.RunCal(todos, month)
+	}
+	printTodoCal(todos, month, now)
+	return nil
+}
+
+// printTodoCal prints the month grid and then each day's todos.
+func printTodoCal(todos []todo.Todo, month, now time.Time) {
+	days := tui.CalDays(todos)
+
+	fmt.Println()
+	fmt.Print(tui.RenderCalendar(month, days, now, 0))
+	fmt.Println()
+
+	prefix := month.Format("2006-01-")
+	var keys []string
+	for k := range days {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		fmt.Println(ui.Muted.Render("  Nothing due this month."))
+		fmt.Println()
+		return
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		day, _ := time.ParseInLocation("2006-01-02", k, now.Location())
+		fmt.Println("  " + ui.Accent.Render(day.Format("Mon, Jan 2")))
+		for _, t := range days[k] {
+			fmt.Println(tui.RenderCalTodo(t, 100))
+		}
+	}
+	fmt.Println()
+}
+
+// parseCalMonth resolves a month argument to the first of that month.
+// Accepts "" (this month), "next", "prev", "YYYY-MM", or a month name.
+func parseCalMonth(arg string, now time.Time) (time.Time, error) {
+	this := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	switch arg {
+	case "", "this", "now":
+		return this, nil
+	case "next":
+		return this.AddDate(0, 1, 0), nil
+	case "prev", "last":
+		return this.AddDate(0, -1, 0), nil
+	}
+	if t, err := time.ParseInLocation("2006-01", arg, now.Location()); err == nil {
+		return t, nil
+	}
+	if len(arg) >= 3 {
+		for m := time.January; m <= time.December; m++ {
+			if strings.HasPrefix(strings.ToLower(m.String()), arg) {
+				return time.Date(now.Year(), m, 1, 0, 0, 0, 0, now.Location()), nil
+			}
+		}
+	}
+	if n, err := strconv.Atoi(arg); err == nil && n >= 1 && n <= 12 {
+		return time.Date(now.Year(), time.Month(n), 1, 0, 0, 0, 0, now.Location()), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid month %q — use %s, a month name, %s, or %s",
+		arg, ui.Accent.Render("2026-03"), ui.Accent.Render("next"), ui.Accent.Render("prev"))
+}
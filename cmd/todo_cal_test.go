@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func TestParseCalMonth(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	cases := map[string]string{
+		"":        "2026-10",
+		"next":    "2026-11",
+		"prev":    "2026-09",
+		"2027-02": "2027-02",
+		"march":   "2026-03",
+		"Dec":     "2026-12",
+		"4":       "2026-04",
+	}
+	for in, want := range cases {
+		got, err := parseCalMonth(in, now)
+		if err != nil || got.Format("2006-01") != want || got.Day() != 1 {
+			t.Errorf("parseCalMonth(%q) = (%v, %v), want %s-01", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"ma", "13", "2026-13", "someday"} {
+		if _, err := parseCalMonth(bad, now); err == nil || !strings.Contains(err.Error(), "invalid month") {
+			t.Errorf("parseCalMonth(%q) should fail, got %v", bad, err)
+		}
+	}
+}
+
+func TestRunTodoCal_PlainText(t *testing.T) {
+	todoTestEnv(t)
+	todoShowAll = false
+	todoProjectName = ""
+	t.Chdir(t.TempDir())
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	due := time.Date(2027, 2, 14, 0, 0, 0, 0, time.Local)
+	ts.Add("buy flowers", "", todo.PrioHigh, nil, &due, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runTodoCal(nil, []string{"2027-02"}); err != nil {
+			t.Fatalf("runTodoCal: %v", err)
+		}
+	})
+	for _, want := range []string{"February 2027", "14•1", "Sun, Feb 14", "buy flowers"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTodoCal(nil, []string{"2027-03"}); err != nil {
+			t.Fatalf("runTodoCal: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Nothing due this month") {
+		t.Errorf("expected empty month message:\n%s", out)
+	}
+}
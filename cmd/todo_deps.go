@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	todoBlockOnFlag   []string
+	todoUnblockOnFlag string
+)
+
+var todoBlockCmd = &cobra.Command{
+	Use:   "block <id> --on <other-id>",
+	Short: "Mark a todo as waiting on another",
+	Long: `Record that a todo cannot start until one or more other todos are done.
+
+Blocked todos are flagged in list output and skipped by 'mine todo next'.
+They unblock automatically once every dependency is completed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("todo.block", runTodoBlock),
+}
+
+var todoUnblockCmd = &cobra.Command{
+	Use:   "unblock <id>",
+	Short: "Remove a todo's dependencies",
+	Long:  `Remove the dependency on --on, or all of the todo's dependencies when --on is omitted.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  hook.Wrap("todo.unblock", runTodoUnblock),
+}
+
+func init() {
+	todoCmd.AddCommand(todoBlockCmd)
+	todoCmd.AddCommand(todoUnblockCmd)
+
+	todoBlockCmd.Flags().StringSliceVar(&todoBlockOnFlag, "on", nil, "The todo(s) this one waits on (IDs, slugs, or title prefixes)")
+	_ = todoBlockCmd.MarkFlagRequired("on")
+	todoUnblockCmd.Flags().StringVar(&todoUnblockOnFlag, "on", "", "Only remove the dependency on this todo (ID, slug, or title prefix)")
+}
+
+func runTodoBlock(_ *cobra.Command, args []string) error {
+	if len(todoBlockOnFlag) == 0 {
+		return fmt.Errorf("no dependency given\n  Use: %s", ui.Accent.Render("mine todo block <id> --on <other-id>"))
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
+	onIDs, err := resolveTodoIDs(ts, todoBlockOnFlag)
+	if err != nil {
+		return err
+	}
+	for _, on := range onIDs {
+		if err := ts.AddDependency(id, on); err != nil {
+			return fmt.Errorf("blocking #%d on #%d: %w", id, on, err)
+		}
+	}
+
+	fmt.Printf("  %s %s now waits on %s\n",
+		ui.Success.Render("✓"),
+		ui.Accent.Render(fmt.Sprintf("#%d", id)),
+		ui.Accent.Render(todo.FormatIDs(onIDs)))
+	fmt.Println()
+	return nil
+}
+
+func runTodoUnblock(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
+	if _, err := ts.Get(id); err != nil {
+		return err
+	}
+	on := 0
+	if todoUnblockOnFlag != "" {
+		if on, err = resolveTodoID(ts, todoUnblockOnFlag); err != nil {
+			return err
+		}
+	}
+	n, err := ts.RemoveDependency(id, on)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  #%d had no matching dependencies.", id)))
+		fmt.Println()
+		return nil
+	}
+	fmt.Printf("  %s Removed %d dependency link(s) from %s\n",
+		ui.Success.Render("✓"), n, ui.Accent.Render(fmt.Sprintf("#%d", id)))
+	fmt.Println()
+	return nil
+}
+
+// printUnblocked reports todos that became actionable after completing id.
+func printUnblocked(ts *todo.Store, dependents []todo.Todo) {
+	for _, d := range dependents {
+		t, err := ts.Get(d.ID)
+		if err != nil || len(t.BlockedBy) > 0 {
+			continue
+		}
+		fmt.Printf("  %s Unblocked %s %s\n",
+			ui.Success.Render("→"),
+			ui.Accent.Render(fmt.Sprintf("#%d", t.ID)),
+			ui.Muted.Render(t.Title))
+	}
+}
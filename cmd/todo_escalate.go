@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var todoEscalateDryRun bool
+
+func init() {
+	todoCmd.AddCommand(todoEscalateCmd)
+	todoEscalateCmd.Flags().BoolVar(&todoEscalateDryRun, "dry-run", false, "Show what would change without changing anything")
+}
+
+var todoEscalateCmd = &cobra.Command{
+	Use:   "escalate",
+	Short: "Raise overdue todos per your escalation rules",
+	Long: `Apply the [[todo.escalation]] rules from config.toml to overdue todos.
+Rules also run automatically whenever you list todos with 'mine todo'.
+
+  [[todo.escalation]]
+  overdue_days = 3
+  priority = "high"      # raise to at least high
+
+  [[todo.escalation]]
+  overdue_days = 7
+  schedule = "today"     # move to today
+
+Rules only ever raise urgency, so running them again changes nothing.
+Use --dry-run to preview; 'mine todo undo' reverts an escalation run
+here, but not the automatic ones made while listing.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("todo.escalate", runTodoEscalate),
+}
+
+func runTodoEscalate(_ *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	rules, err := escalationRulesFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(rules) == 0 {
+		fmt.Println(ui.Muted.Render("  No escalation rules configured."))
+		fmt.Printf("  Add one to config.toml — see %s\n", ui.Accent.Render("mine todo escalate --help"))
+		fmt.Println()
+		return nil
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	now := time.Now()
+	ts := todo.NewStore(db.Conn())
+	plan, err := ts.PlanEscalations(rules, now)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		fmt.Println(ui.Success.Render("  ✓ Nothing to escalate."))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println(ui.Title.Render(fmt.Sprintf("  Escalation — %d overdue todo(s)", len(plan))))
+	fmt.Println()
+	for _, esc := range plan {
+		fmt.Printf("  %s %s %s  %s\n", ui.Accent.Render(fmt.Sprintf("#%d", esc.Todo.ID)),
+			todo.PriorityIcon(esc.Todo.Priority), esc.Todo.Title,
+			ui.Warning.Render(fmt.Sprintf("%dd overdue", esc.OverdueDays)))
+		fmt.Printf("      %s\n", ui.Muted.Render(describeEscalation(esc)))
+	}
+	fmt.Println()
+
+	if todoEscalateDryRun {
+		fmt.Printf("  %s Run %s to apply.\n", ui.Muted.Render("Dry run — nothing changed."), ui.Accent.Render("mine todo escalate"))
+		fmt.Println()
+		return nil
+	}
+
+	ts.BeginBatch()
+	if err := ts.ApplyEscalations(plan, true); err != nil {
+		return err
+	}
+	fmt.Printf("  %s Escalated %d todo(s) %s\n", ui.Success.Render("✓"), len(plan), ui.Muted.Render("(mine todo undo to revert)"))
+	fmt.Println()
+	return nil
+}
+
+// autoEscalate applies the configured escalation rules before a listing,
+// noting how many todos changed. These aren't journaled, so 'mine todo undo'
+// still reverts the user's own last change.
+func autoEscalate(ts *todo.Store, cfg *config.Config, now time.Time) error {
+	rules, err := escalationRulesFromConfig(cfg)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+	plan, err := ts.PlanEscalations(rules, now)
+	if err != nil || len(plan) == 0 {
+		return err
+	}
+	if err := ts.ApplyEscalations(plan, false); err != nil {
+		return fmt.Errorf("escalating overdue todos: %w", err)
+	}
+	fmt.Printf("  %s\n", ui.Muted.Render(fmt.Sprintf("↑ Escalated %d overdue todo(s)", len(plan))))
+	return nil
+}
+
+// escalationRulesFromConfig validates the [[todo.escalation]] rules.
+func escalationRulesFromConfig(cfg *config.Config) ([]todo.EscalationRule, error) {
+	var rules []todo.EscalationRule
+	for i, rc := range cfg.Todo.Escalation {
+		where := fmt.Sprintf("todo.escalation rule %d", i+1)
+		if rc.OverdueDays < 1 {
+			return nil, fmt.Errorf("%s: overdue_days must be at least 1", where)
+		}
+		r := todo.EscalationRule{OverdueDays: rc.OverdueDays}
+		if rc.Priority != "" {
+			p, err := parsePriorityStrict(rc.Priority)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", where, err)
+			}
+			r.Priority = p
+		}
+		if rc.Schedule != "" {
+			schedule, err := todo.ParseSchedule(rc.Schedule)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", where, err)
+			}
+			r.Schedule = schedule
+		}
+		if r.Priority == 0 && r.Schedule == "" {
+			return nil, fmt.Errorf("%s: set priority and/or schedule", where)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// describeEscalation renders an escalation as "priority med → high · schedule later → today".
+func describeEscalation(esc todo.Escalation) string {
+	var parts []string
+	if esc.Priority != 0 {
+		parts = append(parts, fmt.Sprintf("priority %s → %s", todo.PriorityLabel(esc.Todo.Priority), todo.PriorityLabel(esc.Priority)))
+	}
+	if esc.Schedule != "" {
+		parts = append(parts, fmt.Sprintf("schedule %s → %s", esc.Todo.Schedule, esc.Schedule))
+	}
+	return strings.Join(parts, " · ")
+}
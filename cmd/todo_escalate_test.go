@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/config"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func saveEscalationRules(t *testing.T, rules ...config.EscalationRuleConfig) {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Todo.Escalation = rules
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func seedOverdue(t *testing.T, days int) {
+	t.Helper()
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	due := time.Now().AddDate(0, 0, -days)
+	todo.NewStore(db.Conn()).Add("late task", "", todo.PrioMedium, nil, &due, nil, todo.ScheduleLater, todo.RecurrenceNone)
+}
+
+func TestRunTodoEscalate_DryRunThenApply(t *testing.T) {
+	todoTestEnv(t)
+	saveEscalationRules(t, config.EscalationRuleConfig{OverdueDays: 3, Priority: "high", Schedule: "today"})
+	seedOverdue(t, 5)
+
+	todoEscalateDryRun = true
+	out := captureStdout(t, func() {
+		if err := runTodoEscalate(nil, nil); err != nil {
+			t.Fatalf("runTodoEscalate: %v", err)
+		}
+	})
+	todoEscalateDryRun = false
+	for _, want := range []string{"5d overdue", "priority med → high", "schedule later → today", "Dry run"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in dry-run output:\n%s", want, out)
+		}
+	}
+	if getTodo(t, 1).Priority != todo.PrioMedium {
+		t.Fatal("dry run changed the todo")
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTodoEscalate(nil, nil); err != nil {
+			t.Fatalf("runTodoEscalate: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Escalated 1 todo(s)") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if got := getTodo(t, 1); got.Priority != todo.PrioHigh || got.Schedule != todo.ScheduleToday {
+		t.Errorf("got %d/%s, want high/today", got.Priority, got.Schedule)
+	}
+}
+
+func TestRunTodoList_AutoEscalates(t *testing.T) {
+	todoTestEnv(t)
+	t.Chdir(t.TempDir())
+	todoProjectName, todoShowAll = "", false
+	saveEscalationRules(t, config.EscalationRuleConfig{OverdueDays: 1, Priority: "crit"})
+	seedOverdue(t, 2)
+
+	out := captureStdout(t, func() {
+		if err := runTodoList(nil, nil); err != nil {
+			t.Fatalf("runTodoList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Escalated 1 overdue todo(s)") {
+		t.Errorf("expected escalation note:\n%s", out)
+	}
+	if getTodo(t, 1).Priority != todo.PrioCrit {
+		t.Error("expected listing to escalate priority")
+	}
+}
+
+func TestRunTodoEscalate_InvalidRule(t *testing.T) {
+	todoTestEnv(t)
+	saveEscalationRules(t, config.EscalationRuleConfig{OverdueDays: 3})
+
+	err := runTodoEscalate(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "rule 1: set priority and/or schedule") {
+		t.Fatalf("expected rule validation error, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Formats accepted by 'mine todo export/import --format'.
+const (
+	exportFormatTaskwarrior = "taskwarrior"
+	exportFormatMarkdown    = "markdown"
+	exportFormatICS         = "ics"
+)
+
+var (
+	exportFormats = []string{exportFormatTaskwarrior, exportFormatMarkdown, exportFormatICS}
+	importFormats = []string{exportFormatTaskwarrior}
+)
+
+var (
+	todoExportFormat string
+	todoExportOutput string
+	todoExportDone   bool
+)
+
+func init() {
+	todoCmd.AddCommand(todoExportCmd)
+	todoExportCmd.Flags().StringVarP(&todoExportFormat, "format", "f", "", "Export format: "+strings.Join(exportFormats, ", "))
+	todoExportCmd.Flags().StringVarP(&todoExportOutput, "output", "o", "", "Write to a file instead of stdout")
+	todoExportCmd.Flags().StringVar(&todoProjectName, "project", "", "Markdown/ics: only this project's todos")
+	todoExportCmd.Flags().BoolVar(&todoExportDone, "done", false, "Markdown/ics: include completed todos")
+}
+
+var todoExportCmd = &cobra.Command{
+	Use:   "export --format <format>",
+	Short: "Export todos to another tool's format",
+	Long: `Export todos for use in another tool.
+
+  mine todo export --format taskwarrior > tasks.json
+  task import tasks.json
+
+  mine todo export --format markdown --project mine --done | pbcopy
+  mine todo export --format ics -o ~/Sync/mine.ics
+
+The taskwarrior format includes every todo — open, done, and archived — with
+priority, tags, due date, project, recurrence, dependencies, and notes
+(as annotations).
+
+The markdown format writes a GitHub checkbox list grouped by project and
+schedule bucket, ready to paste into a PR description or weekly update. It
+covers open todos across all projects; narrow it with --project and add
+completed todos with --done.
+
+The ics format writes an iCalendar file with an event and a task (VEVENT and
+VTODO) for every todo with a due date. Point a calendar app at the file, and
+re-export on a schedule (e.g. from cron) to keep it current. It honours
+--project and --done like markdown.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("todo.export", runTodoExport),
+}
+
+func runTodoExport(_ *cobra.Command, _ []string) error {
+	format, err := parseTransferFormat(todoExportFormat, "export", exportFormats)
+	if err != nil {
+		return err
+	}
+	if format == exportFormatTaskwarrior && (todoProjectName != "" || todoExportDone) {
+		return fmt.Errorf("--project and --done only apply to %s and %s",
+			ui.Accent.Render("--format markdown"), ui.Accent.Render("--format ics"))
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var w io.Writer = os.Stdout
+	if todoExportOutput != "" {
+		f, err := os.Create(todoExportOutput)
+		if err != nil {
+			return fmt.Errorf("creating export file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	ts := todo.NewStore(db.Conn())
+	var n int
+	switch format {
+	case exportFormatTaskwarrior:
+		n, err = ts.ExportTaskwarrior(w)
+	case exportFormatMarkdown:
+		n, err = exportMarkdown(ts, proj.NewStore(db.Conn()), w)
+	case exportFormatICS:
+		n, err = exportICS(ts, proj.NewStore(db.Conn()), w)
+	}
+	if err != nil {
+		return err
+	}
+
+	if todoExportOutput != "" {
+		fmt.Printf("  %s Exported %d todo(s) to %s\n", ui.Success.Render("✓"), n, ui.Accent.Render(todoExportOutput))
+	}
+	return nil
+}
+
+// exportMarkdown writes open (and with --done, completed) todos as a GitHub
+// task list. Without --project it covers every project.
+func exportMarkdown(ts *todo.Store, ps *proj.Store, w io.Writer) (int, error) {
+	todos, err := listExportTodos(ts, ps)
+	if err != nil {
+		return 0, err
+	}
+	if len(todos) == 0 {
+		fmt.Println(ui.Muted.Render("  No todos to export."))
+		return 0, nil
+	}
+	if _, err := io.WriteString(w, todo.RenderMarkdown(todos)); err != nil {
+		return 0, fmt.Errorf("writing markdown export: %w", err)
+	}
+	return len(todos), nil
+}
+
+// exportICS writes todos with due dates as an iCalendar feed. An empty feed
+// is still written so calendar subscriptions keep working.
+func exportICS(ts *todo.Store, ps *proj.Store, w io.Writer) (int, error) {
+	todos, err := listExportTodos(ts, ps)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := io.WriteString(w, todo.RenderICS(todos, time.Now())); err != nil {
+		return 0, fmt.Errorf("writing ics export: %w", err)
+	}
+	n := 0
+	for _, t := range todos {
+		if t.DueDate != nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// listExportTodos returns the todos covered by markdown and ics exports:
+// open todos (plus done with --done) in every project, or only --project's.
+func listExportTodos(ts *todo.Store, ps *proj.Store) ([]todo.Todo, error) {
+	opts := todo.ListOptions{
+		ShowDone:       todoExportDone,
+		IncludeSomeday: true,
+		AllProjects:    true,
+	}
+	var projectPath *string
+	if todoProjectName != "" {
+		p, err := resolveTodoProject(ps, todoProjectName)
+		if err != nil {
+			return nil, err
+		}
+		projectPath = p
+		opts.CurrentProjectPath = p
+	}
+
+	todos, err := ts.List(opts)
+	if err != nil {
+		return nil, err
+	}
+	if projectPath != nil {
+		scoped := todos[:0]
+		for _, t := range todos {
+			if t.ProjectPath != nil && *t.ProjectPath == *projectPath {
+				scoped = append(scoped, t)
+			}
+		}
+		todos = scoped
+	}
+	return todos, nil
+}
+
+// parseTransferFormat validates an import/export --format value against valid.
+func parseTransferFormat(format, verb string, valid []string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	for _, f := range valid {
+		if format == f {
+			return format, nil
+		}
+	}
+	if format == "" {
+		return "", fmt.Errorf("no format given\n  Use: %s", ui.Accent.Render(fmt.Sprintf("mine todo %s --format %s", verb, valid[0])))
+	}
+	return "", fmt.Errorf("unsupported format %q — valid formats: %s", format, strings.Join(valid, ", "))
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func TestRunTodoExport_TaskwarriorToFile(t *testing.T) {
+	dir := todoTestEnv(t)
+	seedTodos(t, 2)
+
+	out := filepath.Join(dir, "tasks.json")
+	todoExportFormat = "taskwarrior"
+	todoExportOutput = out
+	defer func() { todoExportFormat, todoExportOutput = "", "" }()
+
+	msg := captureStdout(t, func() {
+		if err := runTodoExport(nil, nil); err != nil {
+			t.Fatalf("runTodoExport: %v", err)
+		}
+	})
+	if !strings.Contains(msg, "Exported 2 todo(s)") {
+		t.Errorf("expected export summary, got:\n%s", msg)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"description": "task 2"`) {
+		t.Errorf("expected task 2 in export, got:\n%s", data)
+	}
+}
+
+func TestRunTodoExport_FormatRequired(t *testing.T) {
+	todoTestEnv(t)
+	todoExportFormat = ""
+	if err := runTodoExport(nil, nil); err == nil || !strings.Contains(err.Error(), "no format given") {
+		t.Fatalf("expected missing format error, got %v", err)
+	}
+
+	todoExportFormat = "csv"
+	defer func() { todoExportFormat = "" }()
+	if err := runTodoExport(nil, nil); err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+}
+
+func TestRunTodoImport_Taskwarrior(t *testing.T) {
+	dir := todoTestEnv(t)
+	file := filepath.Join(dir, "tasks.json")
+	data := `[{"description":"migrated task","status":"pending","project":"nowhere","tags":["old"]},
+{"description":"deleted task","status":"deleted"}]`
+	if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	todoImportFormat = "taskwarrior"
+	defer func() { todoImportFormat = "" }()
+
+	out := captureStdout(t, func() {
+		if err := runTodoImport(nil, []string{file}); err != nil {
+			t.Fatalf("runTodoImport: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Imported 1 todo(s)") || !strings.Contains(out, "Skipped 1") || !strings.Contains(out, "nowhere") {
+		t.Errorf("unexpected import output:\n%s", out)
+	}
+	got := getTodo(t, 1)
+	if got.Title != "migrated task" || strings.Join(got.Tags, ",") != "old,project:nowhere" {
+		t.Errorf("unexpected imported todo %+v", got)
+	}
+}
+
+func TestRunTodoExport_MarkdownProjectAndDone(t *testing.T) {
+	todoTestEnv(t)
+	projDir := registerProject(t, "webapp")
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	ts.Add("open project task", "", todo.PrioMedium, nil, nil, &projDir, todo.ScheduleToday, todo.RecurrenceNone)
+	doneID, _ := ts.Add("finished project task", "", todo.PrioMedium, nil, nil, &projDir, todo.ScheduleToday, todo.RecurrenceNone)
+	ts.Complete(doneID) //nolint:errcheck
+	ts.Add("global task", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	db.Close()
+
+	todoExportFormat = "markdown"
+	todoProjectName = "webapp"
+	defer func() { todoExportFormat, todoProjectName, todoExportDone = "", "", false }()
+
+	out := captureStdout(t, func() {
+		if err := runTodoExport(nil, nil); err != nil {
+			t.Fatalf("runTodoExport: %v", err)
+		}
+	})
+	if !strings.Contains(out, "## webapp") || !strings.Contains(out, "- [ ] open project task") {
+		t.Errorf("expected project checklist, got:\n%s", out)
+	}
+	if strings.Contains(out, "global task") || strings.Contains(out, "finished project task") {
+		t.Errorf("expected only open project todos, got:\n%s", out)
+	}
+
+	todoExportDone = true
+	out = captureStdout(t, func() {
+		if err := runTodoExport(nil, nil); err != nil {
+			t.Fatalf("runTodoExport: %v", err)
+		}
+	})
+	if !strings.Contains(out, "- [x] finished project task") {
+		t.Errorf("expected checked done todo with --done, got:\n%s", out)
+	}
+}
+
+func TestRunTodoExport_MarkdownFlagsRejectedForTaskwarrior(t *testing.T) {
+	todoTestEnv(t)
+	todoExportFormat = "taskwarrior"
+	todoExportDone = true
+	defer func() { todoExportFormat, todoExportDone = "", false }()
+
+	if err := runTodoExport(nil, nil); err == nil || !strings.Contains(err.Error(), "only apply") {
+		t.Fatalf("expected flag scope error, got %v", err)
+	}
+}
+
+func TestRunTodoExport_ICS(t *testing.T) {
+	dir := todoTestEnv(t)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	due := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	ts.Add("file taxes", "", todo.PrioHigh, nil, &due, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	ts.Add("someday idea", "", todo.PrioLow, nil, nil, nil, todo.ScheduleSomeday, todo.RecurrenceNone)
+	db.Close()
+
+	out := filepath.Join(dir, "mine.ics")
+	todoExportFormat = "ics"
+	todoExportOutput = out
+	defer func() { todoExportFormat, todoExportOutput = "", "" }()
+
+	msg := captureStdout(t, func() {
+		if err := runTodoExport(nil, nil); err != nil {
+			t.Fatalf("runTodoExport: %v", err)
+		}
+	})
+	if !strings.Contains(msg, "Exported 1 todo(s)") {
+		t.Errorf("expected 1 todo exported, got:\n%s", msg)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "SUMMARY:file taxes") || strings.Contains(string(data), "someday idea") {
+		t.Errorf("unexpected ics content:\n%s", data)
+	}
+}
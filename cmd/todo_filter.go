@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// todoFilterFlag is the saved filter name (or inline expression) for 'mine todo --filter'.
+var todoFilterFlag string
+
+func init() {
+	todoCmd.AddCommand(todoFilterCmd)
+	todoFilterCmd.AddCommand(todoFilterSaveCmd)
+	todoFilterCmd.AddCommand(todoFilterListCmd)
+	todoFilterCmd.AddCommand(todoFilterRmCmd)
+}
+
+// --- mine todo filter ---
+
+var todoFilterCmd = &cobra.Command{
+	Use:   "filter",
+	Short: "Saved filters for the todo list",
+	Long: `Save filter expressions under a name and apply them with 'mine todo --filter <name>'.
+
+Terms are joined with AND, OR, and NOT, grouped with parentheses:
+
+  priority>=high AND tag:infra AND due<7d
+  (@home OR @errands) AND NOT is:blocked
+  project:mine estimate<=30m
+
+Fields:
+  priority   low, med, high, crit           (= != < <= > >=)
+  schedule   today, soon, later, someday    (= != < <= > >=; today is lowest)
+  due        today, 7d, 2w, YYYY-MM-DD      (= != < <= > >=), or due:none / due:any
+  estimate   30m, 1h30m                     (= != < <= > >=), or estimate:none
+  tag        tag:infra
+  context    context:home, or @home; context:none
+  project    project name, or project:none for global todos
+  waiting    person a todo is delegated to, or waiting:none
+  is         done, open, overdue, pinned, blocked, recurring, subtask, waiting
+  title      title:deploy — a bare word does the same
+
+Field tests use ':' (or '='); '!=' negates them. Quote values with spaces: title:"fix login".`,
+	RunE: hook.Wrap("todo.filter", runTodoFilterHelp),
+}
+
+func runTodoFilterHelp(_ *cobra.Command, _ []string) error {
+	fmt.Println()
+	fmt.Println(ui.Title.Render("  Todo Filters"))
+	fmt.Println()
+	fmt.Printf("  %s  %s\n", ui.Accent.Render(`mine todo filter save <name> "<expr>"`), ui.Muted.Render("Save a filter expression"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo filter list"), ui.Muted.Render("List saved filters"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo filter rm <name>"), ui.Muted.Render("Delete a saved filter"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo --filter <name>"), ui.Muted.Render("List todos matching a filter"))
+	fmt.Println()
+	fmt.Printf("  %s %s\n", ui.Muted.Render("Example:"), ui.Accent.Render(`mine todo filter save urgent-infra "priority>=high AND tag:infra AND due<7d"`))
+	fmt.Printf("  %s\n", ui.Muted.Render("See 'mine todo filter --help' for the expression syntax."))
+	fmt.Println()
+	return nil
+}
+
+// --- mine todo filter save ---
+
+var todoFilterSaveCmd = &cobra.Command{
+	Use:   "save <name> <expr>",
+	Short: "Save a filter expression under a name",
+	Long: `Save a filter expression under a name. Saving over an existing name replaces it.
+
+  mine todo filter save urgent-infra "priority>=high AND tag:infra AND due<7d"`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: hook.Wrap("todo.filter.save", runTodoFilterSave),
+}
+
+func runTodoFilterSave(_ *cobra.Command, args []string) error {
+	name := args[0]
+	expr := strings.Join(args[1:], " ")
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	replaced, err := todo.NewStore(db.Conn()).SaveFilter(name, expr)
+	if err != nil {
+		return err
+	}
+
+	verb := "Saved"
+	if replaced {
+		verb = "Updated"
+	}
+	fmt.Printf("  %s %s filter %s %s\n", ui.Success.Render("✓"), verb, ui.Accent.Render(name), ui.Muted.Render(expr))
+	fmt.Printf("  Use it: %s\n", ui.Accent.Render("mine todo --filter "+name))
+	return nil
+}
+
+// --- mine todo filter list ---
+
+var todoFilterListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List saved filters",
+	Args:    cobra.NoArgs,
+	RunE:    hook.Wrap("todo.filter.list", runTodoFilterList),
+}
+
+func runTodoFilterList(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	filters, err := todo.NewStore(db.Conn()).ListFilters()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(filters) == 0 {
+		fmt.Println(ui.Muted.Render("  No saved filters yet."))
+		fmt.Printf("  Create one: %s\n", ui.Accent.Render(`mine todo filter save urgent "priority>=high AND due<7d"`))
+		fmt.Println()
+		return nil
+	}
+	for _, f := range filters {
+		fmt.Printf("  %s  %s\n", ui.Accent.Render(f.Name), ui.Muted.Render(f.Expr))
+	}
+	fmt.Println()
+	return nil
+}
+
+// --- mine todo filter rm ---
+
+var todoFilterRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove", "delete"},
+	Short:   "Delete a saved filter",
+	Args:    cobra.ExactArgs(1),
+	RunE:    hook.Wrap("todo.filter.rm", runTodoFilterRm),
+}
+
+func runTodoFilterRm(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := todo.NewStore(db.Conn()).DeleteFilter(args[0]); err != nil {
+		if errors.Is(err, todo.ErrFilterNotFound) {
+			return fmt.Errorf("filter %q not found — use %s to see filters", args[0], ui.Accent.Render("mine todo filter list"))
+		}
+		return err
+	}
+	fmt.Printf("  %s Deleted filter %s\n", ui.Success.Render("✓"), ui.Accent.Render(args[0]))
+	return nil
+}
+
+// resolveTodoFilter looks up a saved filter by name. Anything that isn't a
+// saved name but reads like an expression (tag:x, priority>=high, ...) is
+// parsed inline, so one-off filters don't need saving first.
+func resolveTodoFilter(ts *todo.Store, arg string) (*todo.Filter, error) {
+	saved, err := ts.GetFilter(arg)
+	if err == nil {
+		f, err := todo.ParseFilter(saved.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("saved filter %q: %w", saved.Name, err)
+		}
+		return f, nil
+	}
+	if !errors.Is(err, todo.ErrFilterNotFound) {
+		return nil, err
+	}
+	if strings.ContainsAny(arg, ":=<>@() \"") {
+		return todo.ParseFilter(arg)
+	}
+	return nil, fmt.Errorf("filter %q not found — use %s to see filters", arg, ui.Accent.Render("mine todo filter list"))
+}
+
+// applyTodoFilter narrows a listing to the todos matching --filter.
+func applyTodoFilter(ts *todo.Store, todos []todo.Todo, arg string, now time.Time) ([]todo.Todo, error) {
+	f, err := resolveTodoFilter(ts, arg)
+	if err != nil {
+		return nil, err
+	}
+	return f.Apply(todos, now), nil
+}
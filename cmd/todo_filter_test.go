@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func TestRunTodoList_SavedFilter(t *testing.T) {
+	todoTestEnv(t)
+	t.Chdir(t.TempDir())
+	todoProjectName = ""
+	todoShowAll = false
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	ts.Add("patch kernel", "", todo.PrioHigh, []string{"infra"}, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	ts.Add("tidy docs", "", todo.PrioLow, []string{"infra"}, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	ts.Add("plan party", "", todo.PrioHigh, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runTodoFilterSave(nil, []string{"hot-infra", "priority>=high", "AND", "tag:infra"}); err != nil {
+			t.Fatalf("runTodoFilterSave: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Saved filter") || !strings.Contains(out, "hot-infra") {
+		t.Errorf("unexpected save output:\n%s", out)
+	}
+
+	todoFilterFlag = "hot-infra"
+	defer func() { todoFilterFlag = "" }()
+	out = captureStdout(t, func() {
+		if err := runTodoList(nil, nil); err != nil {
+			t.Fatalf("runTodoList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "patch kernel") || strings.Contains(out, "tidy docs") || strings.Contains(out, "plan party") {
+		t.Errorf("filter not applied:\n%s", out)
+	}
+
+	// Inline expressions work without saving.
+	todoFilterFlag = "priority<=med"
+	out = captureStdout(t, func() {
+		if err := runTodoList(nil, nil); err != nil {
+			t.Fatalf("runTodoList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "tidy docs") || strings.Contains(out, "patch kernel") {
+		t.Errorf("inline filter not applied:\n%s", out)
+	}
+}
+
+func TestRunTodoList_UnknownFilter(t *testing.T) {
+	todoTestEnv(t)
+	t.Chdir(t.TempDir())
+	todoFilterFlag = "nope"
+	defer func() { todoFilterFlag = "" }()
+
+	err := runTodoList(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), `filter "nope" not found`) {
+		t.Fatalf("expected not-found error, got %v", err)
+	}
+}
+
+func TestRunTodoFilterListAndRm(t *testing.T) {
+	todoTestEnv(t)
+
+	out := captureStdout(t, func() { runTodoFilterList(nil, nil) })
+	if !strings.Contains(out, "No saved filters") {
+		t.Errorf("expected empty message, got:\n%s", out)
+	}
+
+	captureStdout(t, func() { runTodoFilterSave(nil, []string{"home", "@home"}) })
+	out = captureStdout(t, func() { runTodoFilterList(nil, nil) })
+	if !strings.Contains(out, "home") || !strings.Contains(out, "@home") {
+		t.Errorf("expected saved filter listed, got:\n%s", out)
+	}
+
+	captureStdout(t, func() {
+		if err := runTodoFilterRm(nil, []string{"home"}); err != nil {
+			t.Fatalf("runTodoFilterRm: %v", err)
+		}
+	})
+	if err := runTodoFilterRm(nil, []string{"home"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not-found error, got %v", err)
+	}
+}
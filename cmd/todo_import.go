@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var todoImportFormat string
+
+func init() {
+	todoCmd.AddCommand(todoImportCmd)
+	todoImportCmd.Flags().StringVarP(&todoImportFormat, "format", "f", "", "Import format: taskwarrior")
+}
+
+var todoImportCmd = &cobra.Command{
+	Use:   "import --format <format> <file>",
+	Short: "Import todos from another tool",
+	Long: `Import todos from another tool's export. Use '-' to read from stdin.
+
+  task export > tasks.json
+  mine todo import --format taskwarrior tasks.json
+
+Taskwarrior priority, tags, due date, recurrence, dependencies, and
+annotations (as notes) are carried over, and completed tasks keep their
+completion time. A project is linked when its name matches a registered
+project; otherwise the todo is global and tagged project:<name>. Deleted
+tasks are skipped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("todo.import", runTodoImport),
+}
+
+func runTodoImport(_ *cobra.Command, args []string) error {
+	format, err := parseTransferFormat(todoImportFormat, "import", importFormats)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("reading import file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	resolve := func(name string) *string {
+		p, err := ps.Get(name)
+		if err != nil {
+			return nil
+		}
+		return &p.Path
+	}
+
+	ts := todo.NewStore(db.Conn())
+	var res todo.TaskwarriorImport
+	switch format {
+	case exportFormatTaskwarrior:
+		res, err = ts.ImportTaskwarrior(r, resolve)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("  %s Imported %d todo(s)\n", ui.Success.Render("✓"), res.Imported)
+	if res.Skipped > 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("    Skipped %d deleted or template task(s)", res.Skipped)))
+	}
+	if len(res.UnknownProjects) > 0 {
+		fmt.Printf("    %s %s\n", ui.Warning.Render("Unregistered projects, tagged instead:"), strings.Join(res.UnknownProjects, ", "))
+		fmt.Printf("    Register one with %s\n", ui.Accent.Render("mine proj add <path>"))
+	}
+	return nil
+}
@@ -13,14 +13,44 @@ import (
 )
 
 func runTodoTUI(ts *todo.Store, todos []todo.Todo, projectPath *string, showAll bool) error {
-	actions, err := tui.RunTodo(todos, projectPath, showAll)
+	actions, err := tui.RunTodo(todos, projectPath, showAll, tui.WithDetailLoader(todoDetailLoader(ts)))
 	if err != nil {
 		return err
 	}
+	applyTodoActions(ts, actions)
+	return nil
+}
+
+// todoDetailLoader feeds the TUI detail pane: body, notes, and focus time.
+func todoDetailLoader(ts *todo.Store) func(id int) (*tui.TodoDetail, error) {
+	return func(id int) (*tui.TodoDetail, error) {
+		t, err := ts.GetWithNotes(id)
+		if err != nil {
+			return nil, err
+		}
+		focus, err := ts.FocusTime(id)
+		if err != nil {
+			return nil, err
+		}
+		return &tui.TodoDetail{Todo: *t, Focus: focus}, nil
+	}
+}
 
-	// Apply actions returned from the TUI.
+// applyTodoActions applies the actions collected by a todo TUI (list or
+// board) and reports any that failed.
+func applyTodoActions(ts *todo.Store, actions []tui.TodoAction) {
 	var failedActions []string
+	batch := 0
 	for _, a := range actions {
+		// Actions taken on several marked todos at once undo as one step.
+		if a.Batch != batch {
+			batch = a.Batch
+			if batch != 0 {
+				ts.BeginBatch()
+			} else {
+				ts.EndBatch()
+			}
+		}
 		switch a.Type {
 		case "toggle":
 			t, err := ts.Get(a.ID)
@@ -42,7 +72,7 @@ func runTodoTUI(ts *todo.Store, todos []todo.Todo, projectPath *string, showAll
 						dueStr = spawnedDue.Format("Mon, Jan 2")
 					}
 					fmt.Printf("  %s Next occurrence spawned: %s (due %s)\n",
-						ui.Muted.Render("↻"),
+						ui.Muted.Render(ui.IconRecur),
 						ui.Accent.Render(fmt.Sprintf("#%d", spawnedID)),
 						ui.Muted.Render(dueStr),
 					)
@@ -62,6 +92,18 @@ func runTodoTUI(ts *todo.Store, todos []todo.Todo, projectPath *string, showAll
 			if err := ts.SetSchedule(a.ID, a.Schedule); err != nil {
 				failedActions = append(failedActions, fmt.Sprintf("schedule #%d: %v", a.ID, err))
 			}
+		case "pin", "unpin":
+			if err := ts.SetPinned(a.ID, a.Type == "pin"); err != nil {
+				failedActions = append(failedActions, fmt.Sprintf("%s #%d: %v", a.Type, a.ID, err))
+			}
+		case "note":
+			if err := ts.AddNote(a.ID, a.Text); err != nil {
+				failedActions = append(failedActions, fmt.Sprintf("note #%d: %v", a.ID, err))
+			}
+		case "tag":
+			if _, err := ts.AddTag(a.ID, a.Text); err != nil {
+				failedActions = append(failedActions, fmt.Sprintf("tag #%d: %v", a.ID, err))
+			}
 		}
 	}
 
@@ -71,8 +113,6 @@ func runTodoTUI(ts *todo.Store, todos []todo.Todo, projectPath *string, showAll
 			fmt.Println("  " + msg)
 		}
 	}
-
-	return nil
 }
 
 func printTodoList(todos []todo.Todo, ts *todo.Store, projectPath *string, showAll bool) error {
@@ -94,12 +134,14 @@ func printTodoList(todos []todo.Todo, ts *todo.Store, projectPath *string, showA
 
 	fmt.Println()
 	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// Subtasks are listed directly under their parent.
+	todos, depths := todo.NestSubtasks(todos)
 
 	for _, t := range todos {
 		marker := " "
 		if t.Done {
-			marker = ui.Success.Render("✓")
+			marker = ui.Success.Render(ui.IconCheck)
 		}
 
 		id := lipgloss.NewStyle().Width(todo.ColWidthID).Render(ui.Muted.Render(fmt.Sprintf("#%d", t.ID)))
@@ -112,25 +154,20 @@ func printTodoList(todos []todo.Todo, ts *todo.Store, projectPath *string, showA
 		schedTag := todo.FormatScheduleTag(t.Schedule)
 		recurTag := ""
 		if t.Recurrence != "" && t.Recurrence != todo.RecurrenceNone {
-			recurTag = " " + ui.Muted.Render("↻")
+			recurTag = " " + ui.Muted.Render(ui.IconRecur)
+		}
+		line := fmt.Sprintf("  %s %s %s %s %s%s%s", marker, id, prio, schedTag, todo.FormatSubtaskIndent(depths[t.ID]), title, recurTag)
+		line += todo.FormatPinnedTag(t.Pinned)
+		line += todo.FormatContextTag(t.Context)
+		line += todo.FormatWaitingTag(t.WaitingOn)
+		line += todo.FormatEstimateTag(t.Estimate)
+
+		if !t.Done {
+			line += todo.FormatBlockedTag(t.BlockedBy)
 		}
-		line := fmt.Sprintf("  %s %s %s %s %s%s", marker, id, prio, schedTag, title, recurTag)
 
 		// Due date annotation
-		if t.DueDate != nil && !t.Done {
-			due := *t.DueDate
-			dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
-			switch {
-			case dueDay.Before(today):
-				line += ui.Error.Render(fmt.Sprintf(" (overdue: %s)", due.Format("Jan 2")))
-			case dueDay.Equal(today):
-				line += ui.Warning.Render(" (due today!)")
-			case dueDay.Before(today.AddDate(0, 0, 7)):
-				line += ui.Muted.Render(fmt.Sprintf(" (due %s)", due.Format("Mon")))
-			default:
-				line += ui.Muted.Render(fmt.Sprintf(" (due %s)", due.Format("Jan 2")))
-			}
-		}
+		line += todo.FormatDueAnnotation(t, now)
 
 		// Tags
 		if len(t.Tags) > 0 {
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/tui"
+)
+
+func TestApplyTodoActions_BatchUndoesAsOneStep(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 3)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ts := todo.NewStore(db.Conn())
+
+	captureStdout(t, func() {
+		applyTodoActions(ts, []tui.TodoAction{
+			{Type: "schedule", ID: 3, Schedule: todo.ScheduleToday},
+			{Type: "toggle", ID: 1, Batch: 1},
+			{Type: "toggle", ID: 2, Batch: 1},
+			{Type: "tag", ID: 1, Text: "errands", Batch: 2},
+			{Type: "tag", ID: 2, Text: "errands", Batch: 2},
+		})
+	})
+
+	for _, id := range []int{1, 2} {
+		got := getTodo(t, id)
+		if !got.Done || strings.Join(got.Tags, ",") != "errands" {
+			t.Errorf("#%d = done %v tags %v, want done and tagged", id, got.Done, got.Tags)
+		}
+	}
+
+	// The tag batch, then the toggle batch, each undo in one go.
+	if _, err := ts.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got := getTodo(t, 1); len(got.Tags) != 0 || !got.Done {
+		t.Errorf("#1 after first undo = done %v tags %v, want done untagged", got.Done, got.Tags)
+	}
+	if _, err := ts.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if getTodo(t, 1).Done || getTodo(t, 2).Done {
+		t.Error("expected both completions undone together")
+	}
+	if got := getTodo(t, 3).Schedule; got != todo.ScheduleToday {
+		t.Errorf("#3 schedule = %q, want the unbatched change kept", got)
+	}
+}
@@ -1,12 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/rnwolfe/mine/internal/hook"
 	"github.com/rnwolfe/mine/internal/store"
 	"github.com/rnwolfe/mine/internal/todo"
@@ -15,14 +16,29 @@ import (
 )
 
 var todoEditCmd = &cobra.Command{
-	Use:   "edit <id> <new title>",
-	Short: "Rename a todo",
-	Args:  cobra.MinimumNArgs(2),
-	RunE:  hook.Wrap("todo.edit", runTodoEdit),
+	Use:   "edit <id> [new title]",
+	Short: "Rename a todo or change its priority, context, estimate, delegation, or body",
+	Long: `Rename a todo, change its priority, context, estimate or who it's
+waiting on, or any combination. --body opens the body in $EDITOR.
+
+With --priority, --context, --estimate or --waiting-on and no title, every
+argument is treated as an ID or range, so todos can be changed in bulk:
+
+  mine todo edit 4 "new title"
+  mine todo edit 4 --priority high
+  mine todo edit 3 5 7-9 --priority crit
+  mine todo edit 3 5 --context @errands
+  mine todo edit 3 --context none   # clear the context
+  mine todo edit 3 5 --estimate 30m
+  mine todo edit 6 --waiting-on alice
+  mine todo edit 6 --waiting-on none  # it's yours again
+  mine todo edit 4 --body           # write long context in $EDITOR`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: hook.Wrap("todo.edit", runTodoEdit),
 }
 
 var todoScheduleCmd = &cobra.Command{
-	Use:   "schedule <id> <when>",
+	Use:   "schedule <id>... <when>",
 	Short: "Set the scheduling intent for a todo",
 	Long: `Set the scheduling bucket for a todo. Buckets represent when you intend to work on it:
 
@@ -31,8 +47,10 @@ var todoScheduleCmd = &cobra.Command{
   later    — on the radar, not urgent (alias: l)
   someday  — aspirational, hidden from default view (alias: sd)
 
-Someday tasks are hidden from the default list. Use 'mine todo --someday' to see them.`,
-	Args: cobra.ExactArgs(2),
+Someday tasks are hidden from the default list. Use 'mine todo --someday' to see them.
+
+Several IDs or ranges may be given before the bucket: 'mine todo schedule 3 5 7-9 today'.`,
+	Args: cobra.MinimumNArgs(2),
 	RunE: hook.Wrap("todo.schedule", runTodoSchedule),
 }
 
@@ -50,12 +68,87 @@ var todoShowCmd = &cobra.Command{
 	RunE:  hook.Wrap("todo.show", runTodoShow),
 }
 
-func runTodoEdit(_ *cobra.Command, args []string) error {
-	id, err := strconv.Atoi(args[0])
-	if err != nil {
-		return fmt.Errorf("%q is not a valid todo ID — use %s to see IDs", args[0], ui.Accent.Render("mine todo"))
+var todoSubtasksCmd = &cobra.Command{
+	Use:   "subtasks <id>",
+	Short: "List the subtasks of a todo",
+	Long: `Show a todo and its direct subtasks with a completion tally.
+
+Create subtasks with 'mine todo add "title" --parent <id>'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("todo.subtasks", runTodoSubtasks),
+}
+
+func runTodoEdit(cmd *cobra.Command, args []string) error {
+	var prio *int
+	if todoEditPriority != "" {
+		p, err := parsePriorityStrict(todoEditPriority)
+		if err != nil {
+			return err
+		}
+		prio = &p
+	}
+	var ctx *string
+	if todoEditContext != "" {
+		c := ""
+		if !strings.EqualFold(todoEditContext, "none") {
+			parsed, err := parseContextFlag(todoEditContext)
+			if err != nil {
+				return err
+			}
+			c = parsed
+		}
+		ctx = &c
+	}
+	var estimate *time.Duration
+	if todoEditEstimate != "" {
+		var d time.Duration
+		if !strings.EqualFold(todoEditEstimate, "none") {
+			parsed, err := parseEstimateFlag(todoEditEstimate)
+			if err != nil {
+				return err
+			}
+			d = parsed
+		}
+		estimate = &d
+	}
+	var waitingOn *string
+	if todoEditWaitingOn != "" {
+		who, err := todo.ParseWaitingOn(todoEditWaitingOn)
+		if err != nil {
+			return err
+		}
+		waitingOn = &who
+	}
+
+	// With --priority/--context/--estimate/--waiting-on and only ID-like arguments, this is a bulk edit.
+	var ids []int
+	var newTitle *string
+	allIDs := true
+	for _, a := range args {
+		if !isTodoIDArg(a) {
+			allIDs = false
+			break
+		}
+	}
+	if (prio != nil || ctx != nil || estimate != nil || waitingOn != nil) && allIDs && !todoEditBody {
+		parsed, err := parseTodoIDs(args)
+		if err != nil {
+			return err
+		}
+		ids = parsed
+	} else if len(args) > 1 {
+		t := strings.Join(args[1:], " ")
+		newTitle = &t
+	}
+	if newTitle == nil && prio == nil && ctx == nil && estimate == nil && waitingOn == nil && !todoEditBody {
+		return fmt.Errorf("nothing to change\n  Use: %s, %s, %s, %s, %s or %s",
+			ui.Accent.Render(`mine todo edit <id> "new title"`),
+			ui.Accent.Render("mine todo edit <id>... --priority high"),
+			ui.Accent.Render("mine todo edit <id>... --context @home"),
+			ui.Accent.Render("mine todo edit <id>... --estimate 30m"),
+			ui.Accent.Render("mine todo edit <id>... --waiting-on alice"),
+			ui.Accent.Render("mine todo edit <id> --body"))
 	}
-	newTitle := strings.Join(args[1:], " ")
 
 	db, err := store.Open()
 	if err != nil {
@@ -64,22 +157,92 @@ func runTodoEdit(_ *cobra.Command, args []string) error {
 	defer db.Close()
 
 	ts := todo.NewStore(db.Conn())
-	if err := ts.Edit(id, &newTitle, nil); err != nil {
-		return err
+	if ids == nil {
+		// Single edit: the first argument may be an ID, slug, or title prefix.
+		id, err := resolveTodoID(ts, args[0])
+		if err != nil {
+			return err
+		}
+		ids = []int{id}
+	}
+	ts.BeginBatch()
+	result := newBulkResult("updated", len(ids))
+	for _, id := range ids {
+		if _, err := ts.Get(id); err != nil {
+			result.fail(err)
+			continue
+		}
+		if newTitle != nil || prio != nil {
+			if err := ts.Edit(id, newTitle, prio); err != nil {
+				result.fail(fmt.Errorf("editing #%d: %w", id, err))
+				continue
+			}
+		}
+		if ctx != nil {
+			if err := ts.SetContext(id, *ctx); err != nil {
+				result.fail(fmt.Errorf("setting context on #%d: %w", id, err))
+				continue
+			}
+		}
+		if estimate != nil {
+			if err := ts.SetEstimate(id, *estimate); err != nil {
+				result.fail(fmt.Errorf("setting estimate on #%d: %w", id, err))
+				continue
+			}
+		}
+		if waitingOn != nil {
+			if err := ts.SetWaitingOn(id, *waitingOn); err != nil {
+				result.fail(fmt.Errorf("setting waiting-on for #%d: %w", id, err))
+				continue
+			}
+		}
+		if newTitle == nil && prio == nil && ctx == nil && estimate == nil && waitingOn == nil {
+			continue
+		}
+		var parts []string
+		switch {
+		case newTitle != nil && prio != nil:
+			parts = append(parts, todo.PriorityIcon(*prio)+" "+*newTitle)
+		case newTitle != nil:
+			parts = append(parts, *newTitle)
+		case prio != nil:
+			parts = append(parts, todo.PriorityIcon(*prio)+" "+todo.PriorityLabel(*prio))
+		}
+		if ctx != nil {
+			label := todo.ContextLabel(*ctx)
+			if label == "" {
+				label = "no context"
+			}
+			parts = append(parts, label)
+		}
+		if estimate != nil {
+			label := "~" + todo.FormatEstimate(*estimate)
+			if *estimate == 0 {
+				label = "no estimate"
+			}
+			parts = append(parts, label)
+		}
+		if waitingOn != nil {
+			label := "⏳ " + *waitingOn
+			if *waitingOn == "" {
+				label = "not waiting"
+			}
+			parts = append(parts, label)
+		}
+		fmt.Printf("  %s Updated #%d → %s\n", ui.Success.Render("✓"), id, strings.Join(parts, " "))
+	}
+	if todoEditBody && len(result.failed) == 0 {
+		if err := editTodoBody(ts, ids[0]); err != nil {
+			return err
+		}
 	}
-
-	fmt.Printf("  %s Updated #%d → %s\n", ui.Success.Render("✓"), id, newTitle)
 	fmt.Println()
-	return nil
+	return result.err()
 }
 
 func runTodoSchedule(_ *cobra.Command, args []string) error {
-	id, err := strconv.Atoi(args[0])
-	if err != nil {
-		return fmt.Errorf("%q is not a valid todo ID — use %s to see IDs", args[0], ui.Accent.Render("mine todo"))
-	}
-
-	schedule, err := todo.ParseSchedule(args[1])
+	whenArg := args[len(args)-1]
+	schedule, err := todo.ParseSchedule(whenArg)
 	if err != nil {
 		return fmt.Errorf("%w\n  Valid values: %s",
 			err,
@@ -93,21 +256,25 @@ func runTodoSchedule(_ *cobra.Command, args []string) error {
 	defer db.Close()
 
 	ts := todo.NewStore(db.Conn())
-	if err := ts.SetSchedule(id, schedule); err != nil {
-		return fmt.Errorf("scheduling todo #%d: %w", id, err)
+	ids, err := resolveTodoIDs(ts, args[:len(args)-1])
+	if err != nil {
+		return err
 	}
-
+	ts.BeginBatch()
 	schedLabel := todo.FormatScheduleTag(schedule)
-	fmt.Printf("  %s Scheduled #%d → %s\n", ui.Success.Render("✓"), id, schedLabel)
+	result := newBulkResult("scheduled", len(ids))
+	for _, id := range ids {
+		if err := ts.SetSchedule(id, schedule); err != nil {
+			result.fail(fmt.Errorf("scheduling todo #%d: %w", id, err))
+			continue
+		}
+		fmt.Printf("  %s Scheduled #%d → %s\n", ui.Success.Render("✓"), id, schedLabel)
+	}
 	fmt.Println()
-	return nil
+	return result.err()
 }
 
 func runTodoNote(_ *cobra.Command, args []string) error {
-	id, err := strconv.Atoi(args[0])
-	if err != nil {
-		return fmt.Errorf("%q is not a valid todo ID — use %s to see IDs", args[0], ui.Accent.Render("mine todo"))
-	}
 	text := args[1]
 
 	db, err := store.Open()
@@ -117,6 +284,10 @@ func runTodoNote(_ *cobra.Command, args []string) error {
 	defer db.Close()
 
 	ts := todo.NewStore(db.Conn())
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
 	if err := ts.AddNote(id, text); err != nil {
 		return err
 	}
@@ -131,11 +302,28 @@ func runTodoNote(_ *cobra.Command, args []string) error {
 }
 
 func runTodoShow(_ *cobra.Command, args []string) error {
-	id, err := strconv.Atoi(args[0])
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
+	t, err := ts.GetWithNotes(id)
 	if err != nil {
-		return fmt.Errorf("%q is not a valid todo ID — use %s to see IDs", args[0], ui.Accent.Render("mine todo"))
+		return err
 	}
 
+	printTodoDetail(*t)
+	return nil
+}
+
+func runTodoSubtasks(_ *cobra.Command, args []string) error {
+
 	db, err := store.Open()
 	if err != nil {
 		return err
@@ -143,12 +331,46 @@ func runTodoShow(_ *cobra.Command, args []string) error {
 	defer db.Close()
 
 	ts := todo.NewStore(db.Conn())
-	t, err := ts.GetWithNotes(id)
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
+	parent, err := ts.Get(id)
+	if err != nil {
+		return err
+	}
+	children, err := ts.Subtasks(id)
 	if err != nil {
 		return err
 	}
 
-	printTodoDetail(*t)
+	fmt.Println()
+	fmt.Printf("  %s %s %s\n", ui.Muted.Render(fmt.Sprintf("#%d", parent.ID)), todo.PriorityIcon(parent.Priority), ui.Accent.Render(parent.Title))
+
+	if len(children) == 0 {
+		fmt.Println()
+		fmt.Println(ui.Muted.Render("  No subtasks yet."))
+		fmt.Printf("  Add one: %s\n", ui.Accent.Render(fmt.Sprintf(`mine todo add "step one" --parent %d`, id)))
+		fmt.Println()
+		return nil
+	}
+
+	done := 0
+	for _, c := range children {
+		marker := " "
+		title := c.Title
+		if c.Done {
+			done++
+			marker = ui.Success.Render("✓")
+			title = ui.Muted.Render(title)
+		}
+		cid := lipgloss.NewStyle().Width(todo.ColWidthID).Render(ui.Muted.Render(fmt.Sprintf("#%d", c.ID)))
+		fmt.Printf("    %s %s %s %s%s\n", marker, cid, todo.FormatPriorityIcon(c.Priority), todo.FormatSubtaskIndent(1), title)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d/%d subtasks done", done, len(children))))
+	fmt.Println()
 	return nil
 }
 
@@ -162,6 +384,9 @@ func printTodoDetail(t todo.Todo) {
 	idStr := ui.Muted.Render(fmt.Sprintf("#%d", t.ID))
 	prio := todo.PriorityIcon(t.Priority)
 	fmt.Printf("  %s %s %s\n", idStr, prio, ui.Accent.Render(t.Title))
+	if slug := todo.Slug(t.Title); slug != "" {
+		fmt.Println(ui.Muted.Render("  Slug: " + slug))
+	}
 
 	// Details row: schedule, priority label, due date
 	details := fmt.Sprintf("  Schedule: %s  Priority: %s",
@@ -169,24 +394,53 @@ func printTodoDetail(t todo.Todo) {
 		todo.PriorityLabel(t.Priority),
 	)
 	if t.DueDate != nil {
-		details += fmt.Sprintf("  Due: %s", t.DueDate.Format("Jan 2"))
+		details += fmt.Sprintf("  Due: %s", todo.DueLabel(t, "Jan 2"))
+	}
+	if t.Estimate > 0 {
+		details += fmt.Sprintf("  Estimate: %s", todo.FormatEstimate(t.Estimate))
 	}
 	if t.Recurrence != "" && t.Recurrence != todo.RecurrenceNone {
 		details += fmt.Sprintf("  Recurrence: ↻ %s", todo.RecurrenceLabel(t.Recurrence))
 	}
 	fmt.Println(ui.Muted.Render(details))
 
-	// Project and tags (if set)
-	if t.ProjectPath != nil || len(t.Tags) > 0 {
+	if t.Pinned {
+		fmt.Println(ui.Muted.Render("  📌 Pinned"))
+	}
+	if t.WaitingOn != "" {
+		fmt.Println(ui.Muted.Render("  ⏳ Waiting on " + t.WaitingOn))
+	}
+	if t.ParentID != nil {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  Subtask of #%d", *t.ParentID)))
+	}
+	if len(t.BlockedBy) > 0 && !t.Done {
+		fmt.Println(ui.Warning.Render("  Blocked by " + todo.FormatIDs(t.BlockedBy)))
+	}
+
+	// Project, context and tags (if set)
+	if t.ProjectPath != nil || t.Context != "" || len(t.Tags) > 0 {
 		extra := "  "
 		if t.ProjectPath != nil {
 			extra += fmt.Sprintf("Project: %s  ", filepath.Base(*t.ProjectPath))
 		}
+		if t.Context != "" {
+			extra += fmt.Sprintf("Context: %s  ", todo.ContextLabel(t.Context))
+		}
 		if len(t.Tags) > 0 {
 			extra += fmt.Sprintf("Tags: %s", strings.Join(t.Tags, ", "))
 		}
 		fmt.Println(ui.Muted.Render(extra))
 	}
+	for _, l := range t.Links {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  Linked: %s %s", l.ExternalID, l.URL)))
+	}
+	for i, a := range t.Attachments {
+		icon := "📎"
+		if a.IsURL() {
+			icon = "🔗"
+		}
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  %s %d. %s", icon, i+1, a.Target)))
+	}
 
 	// Timestamps
 	fmt.Println()
@@ -240,3 +494,45 @@ func todoTimeAgo(t time.Time, now time.Time) string {
 		return fmt.Sprintf("%d days ago", days)
 	}
 }
+
+var todoUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the last done, rm, edit, or schedule",
+	Long: `Undo the most recent destructive todo change.
+
+Reverts completions (removing any spawned recurrence), deletions (restoring the
+task with its notes, dependencies, and subtasks), edits, and schedule changes.
+A bulk command such as 'mine todo done 3 5 7-9' is undone as one step.
+Run undo repeatedly to walk further back.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("todo.undo", runTodoUndo),
+}
+
+func runTodoUndo(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	undone, err := ts.Undo()
+	if errors.Is(err, todo.ErrNothingToUndo) {
+		fmt.Println(ui.Muted.Render("  Nothing to undo."))
+		fmt.Println()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, u := range undone {
+		fmt.Printf("  %s Undid %s on %s %s\n",
+			ui.Success.Render("↶"),
+			u.Action,
+			ui.Accent.Render(fmt.Sprintf("#%d", u.TodoID)),
+			ui.Muted.Render(u.Title))
+	}
+	fmt.Println()
+	return nil
+}
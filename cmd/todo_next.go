@@ -22,9 +22,14 @@ var todoNextCmd = &cobra.Command{
 	Long: `Surface the most urgent open tasks using a weighted urgency score.
 
 Urgency accounts for: overdue status, schedule bucket, priority, task age,
-and whether the task belongs to the current project.
+whether the task belongs to the current project, and whether it matches
+your active context (config key todo.active_context).
 
-Someday tasks are always excluded. Use 'mine todo next 3' to see the top 3.`,
+Someday tasks and tasks blocked by open dependencies are always excluded. Use 'mine todo next 3' to see the top 3.
+
+With --budget, picks the most urgent tasks whose estimates add up to no more
+than the given time: 'mine todo next --budget 2h'. Tasks without an estimate
+are skipped.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: hook.Wrap("todo.next", runTodoNext),
 }
@@ -39,6 +44,22 @@ func runTodoNext(_ *cobra.Command, args []string) error {
 		}
 		count = n
 	}
+	var budget time.Duration
+	if todoBudgetFlag != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("a count and --budget can't be combined — use %s or %s",
+				ui.Accent.Render("mine todo next [n]"), ui.Accent.Render("mine todo next --budget 2h"))
+		}
+		d, err := todo.ParseEstimate(todoBudgetFlag)
+		if err != nil {
+			return fmt.Errorf("invalid budget %q — use a duration like %s", todoBudgetFlag, ui.Accent.Render("--budget 2h"))
+		}
+		budget = d
+	}
+	ctx, err := parseContextFlag(todoContextFlag)
+	if err != nil {
+		return err
+	}
 
 	db, err := store.Open()
 	if err != nil {
@@ -52,7 +73,7 @@ func runTodoNext(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	cfg, err := config.Load()
+	cfg, err := config.LoadForCWD()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
@@ -65,6 +86,9 @@ func runTodoNext(_ *cobra.Command, args []string) error {
 	todos, err := ts.List(todo.ListOptions{
 		AllProjects:        false,
 		ProjectPath:        projectPath,
+		ExcludeBlocked:     true,
+		ExcludeWaiting:     true,
+		Context:            ctx,
 		Sort:               todo.SortUrgency,
 		CurrentProjectPath: projectPath,
 		Weights:            &weights,
@@ -81,6 +105,11 @@ func runTodoNext(_ *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if budget > 0 {
+		printBudgetPlan(todos, budget, now, projectPath)
+		return nil
+	}
+
 	if count > len(todos) {
 		count = len(todos)
 	}
@@ -92,6 +121,36 @@ func runTodoNext(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// printBudgetPlan prints the most urgent tasks that fit within budget.
+func printBudgetPlan(todos []todo.Todo, budget time.Duration, now time.Time, projectPath *string) {
+	picked, used, unestimated := todo.FitBudget(todos, budget)
+
+	fmt.Println()
+	if len(picked) == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  No estimated tasks fit in %s.", todo.FormatEstimate(budget))))
+	} else {
+		fmt.Printf("  %s %s\n\n",
+			ui.Title.Render(fmt.Sprintf("Plan for %s", todo.FormatEstimate(budget))),
+			ui.Muted.Render(fmt.Sprintf("%d task(s) · %s planned · %s free", len(picked), todo.FormatEstimate(used), formatFreeTime(budget-used))))
+		for rank, t := range picked {
+			printTodoCard(t, rank+1, now, projectPath)
+		}
+	}
+	if unestimated > 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d task(s) without an estimate skipped — add one with", unestimated)) + " " +
+			ui.Accent.Render("mine todo edit <id> --estimate 30m"))
+		fmt.Println()
+	}
+}
+
+// formatFreeTime renders leftover budget, which may be zero.
+func formatFreeTime(d time.Duration) string {
+	if d <= 0 {
+		return "0m"
+	}
+	return todo.FormatEstimate(d)
+}
+
 // cardMetaIndent is the number of spaces to indent metadata lines in a todo card,
 // computed to align under the title text:
 //   - "  " (2) + rank "%2d." (3) + " " (1) + prio emoji (2) + " " (1) + sched (2) + " " (1) = 12
@@ -108,11 +167,15 @@ func printTodoCard(t todo.Todo, rank int, now time.Time, currentProjectPath *str
 
 	fmt.Printf("  %s %s %s %s\n", rankStr, prio, schedTag, title)
 
-	// ID and priority label — indented to align under the title.
+	// ID, priority label and estimate — indented to align under the title.
+	meta := todo.PriorityLabel(t.Priority) + " priority"
+	if t.Estimate > 0 {
+		meta += "  ~" + todo.FormatEstimate(t.Estimate)
+	}
 	fmt.Printf("%s%s  %s\n",
 		cardMetaIndent,
 		ui.Muted.Render(fmt.Sprintf("#%d", t.ID)),
-		ui.Muted.Render(todo.PriorityLabel(t.Priority)+" priority"),
+		ui.Muted.Render(meta),
 	)
 
 	// Due date (if set)
@@ -123,12 +186,14 @@ func printTodoCard(t todo.Todo, rank int, now time.Time, currentProjectPath *str
 		dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, now.Location())
 		var dueStr string
 		switch {
-		case dueDay.Before(today):
-			dueStr = ui.Error.Render("overdue: " + due.Format("Jan 2"))
+		case t.IsOverdue(now):
+			dueStr = ui.Error.Render("overdue: " + todo.DueLabel(t, "Jan 2"))
+		case dueDay.Equal(today) && t.DueHasTime:
+			dueStr = ui.Warning.Render("due today " + due.Format("3:04pm") + "!")
 		case dueDay.Equal(today):
 			dueStr = ui.Warning.Render("due today!")
 		default:
-			dueStr = ui.Muted.Render("due " + due.Format("Mon, Jan 2"))
+			dueStr = ui.Muted.Render("due " + todo.DueLabel(t, "Mon, Jan 2"))
 		}
 		fmt.Printf("%s%s\n", cardMetaIndent, dueStr)
 	}
@@ -139,6 +204,11 @@ func printTodoCard(t todo.Todo, rank int, now time.Time, currentProjectPath *str
 		fmt.Printf("%s%s\n", cardMetaIndent, ui.Muted.Render("@"+projName))
 	}
 
+	// Context
+	if t.Context != "" {
+		fmt.Printf("%s%s\n", cardMetaIndent, ui.Accent.Render(todo.ContextLabel(t.Context)))
+	}
+
 	// Tags
 	if len(t.Tags) > 0 {
 		fmt.Printf("%s%s\n", cardMetaIndent, ui.Muted.Render("["+strings.Join(t.Tags, ", ")+"]"))
@@ -191,5 +261,12 @@ func urgencyWeightsFromConfig(cfg *config.Config) todo.UrgencyWeights {
 	if u.ProjectBoost != nil {
 		w.ProjectBoost = *u.ProjectBoost
 	}
+	if u.ContextBoost != nil {
+		w.ContextBoost = *u.ContextBoost
+	}
+	// An invalid configured context is ignored rather than failing every listing.
+	if ctx, err := todo.ParseContext(cfg.Todo.ActiveContext); err == nil {
+		w.ActiveContext = ctx
+	}
 	return w
 }
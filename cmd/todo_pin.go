@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	todoCmd.AddCommand(todoPinCmd)
+	todoCmd.AddCommand(todoUnpinCmd)
+}
+
+var todoPinCmd = &cobra.Command{
+	Use:   "pin <id>...",
+	Short: "Keep todos at the top of the list",
+	Long: `Pin todos so they list above everything else, in both the plain list and
+the TUI, regardless of urgency. Pins stay until 'mine todo unpin'; done
+todos drop back into normal order.
+
+  mine todo pin 3
+  mine todo pin 3 5 7-9`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: hook.Wrap("todo.pin", runTodoPin),
+}
+
+var todoUnpinCmd = &cobra.Command{
+	Use:   "unpin <id>...",
+	Short: "Return pinned todos to normal order",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  hook.Wrap("todo.unpin", runTodoUnpin),
+}
+
+func runTodoPin(_ *cobra.Command, args []string) error {
+	return setTodosPinned(args, true)
+}
+
+func runTodoUnpin(_ *cobra.Command, args []string) error {
+	return setTodosPinned(args, false)
+}
+
+func setTodosPinned(args []string, pinned bool) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	verb := "pinned"
+	if !pinned {
+		verb = "unpinned"
+	}
+
+	ts := todo.NewStore(db.Conn())
+	ids, err := resolveTodoIDs(ts, args)
+	if err != nil {
+		return err
+	}
+	ts.BeginBatch()
+	result := newBulkResult(verb, len(ids))
+	for _, id := range ids {
+		t, err := ts.Get(id)
+		if err != nil {
+			result.fail(err)
+			continue
+		}
+		if err := ts.SetPinned(id, pinned); err != nil {
+			result.fail(fmt.Errorf("%s #%d: %w", verb, id, err))
+			continue
+		}
+		icon := ui.Success.Render("✓")
+		if pinned {
+			icon = "📌"
+		}
+		fmt.Printf("  %s %s #%d %s\n", icon, capitalize(verb), id, ui.Muted.Render(t.Title))
+	}
+	fmt.Println()
+	return result.err()
+}
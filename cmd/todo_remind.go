@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultReminderHour is used when a reminder is given as a bare date.
+const defaultReminderHour = 9
+
+var todoRemindCmd = &cobra.Command{
+	Use:   "remind <id> [when]",
+	Short: "Get a desktop notification about a todo",
+	Long: `Schedule a reminder for a todo. Reminders are delivered by 'mine notify run'.
+
+When accepts a relative delay or the same formats as --due:
+
+  mine todo remind 3 30m              # in 30 minutes
+  mine todo remind 3 in 2h
+  mine todo remind 3 5pm              # today at 5pm
+  mine todo remind 3 tomorrow 9:30am
+  mine todo remind 3 2026-06-01       # a bare date means 9am that day
+
+With no time, lists the todo's pending reminders.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: hook.Wrap("todo.remind", runTodoRemind),
+}
+
+func init() {
+	todoCmd.AddCommand(todoRemindCmd)
+}
+
+func runTodoRemind(_ *cobra.Command, args []string) error {
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	id, err := resolveTodoID(ts, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		return printPendingReminders(ts, id)
+	}
+
+	when := strings.Join(args[1:], " ")
+	now := time.Now()
+	at, err := parseReminderTime(when, now)
+	if err != nil {
+		return err
+	}
+	if !at.After(now) {
+		return fmt.Errorf("reminder time %s is in the past", at.Format("Mon Jan 2 3:04pm"))
+	}
+
+	if _, err := ts.AddReminder(id, at); err != nil {
+		return err
+	}
+
+	fmt.Printf("  %s Reminder set for %s at %s\n",
+		ui.Success.Render("✓"),
+		ui.Accent.Render(fmt.Sprintf("#%d", id)),
+		ui.Accent.Render(at.Format("Mon Jan 2 3:04pm")),
+	)
+	fmt.Printf("  Delivered by %s (run it from cron or with --watch)\n", ui.Accent.Render("mine notify run"))
+	return nil
+}
+
+// parseReminderTime parses a reminder time: a relative delay ("30m", "in 2h")
+// or anything parseDueDate understands. Bare dates resolve to 9am.
+func parseReminderTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(s, "in "))); err == nil {
+		return now.Add(d), nil
+	}
+	due := parseDueDate(s)
+	if due == nil {
+		return time.Time{}, fmt.Errorf("could not understand reminder time %q\n  Try: %s", s,
+			ui.Accent.Render(`30m, "in 2h", 5pm, "tomorrow 9am", 2026-06-01`))
+	}
+	at := *due
+	if at.Hour() == 0 && at.Minute() == 0 {
+		at = time.Date(at.Year(), at.Month(), at.Day(), defaultReminderHour, 0, 0, 0, time.Local)
+	}
+	return at, nil
+}
+
+func printPendingReminders(ts *todo.Store, id int) error {
+	if _, err := ts.Get(id); err != nil {
+		return err
+	}
+	reminders, err := ts.PendingReminders(id)
+	if err != nil {
+		return err
+	}
+	if len(reminders) == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  No pending reminders for #%d.", id)))
+		fmt.Printf("  Set one: %s\n", ui.Accent.Render(fmt.Sprintf("mine todo remind %d 30m", id)))
+		return nil
+	}
+	fmt.Println()
+	for _, r := range reminders {
+		fmt.Printf("  %s %s\n", ui.Muted.Render("⏰"), r.At.Format("Mon Jan 2 3:04pm"))
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var todoReviewStale string
+
+// reviewSnoozeDays is how far snooze pushes a todo.
+const reviewSnoozeDays = 7
+
+func init() {
+	todoCmd.AddCommand(todoReviewCmd)
+	todoReviewCmd.Flags().StringVar(&todoReviewStale, "stale", "14d", "Flag open todos untouched for this long (e.g. 14d, 3w)")
+}
+
+var todoReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Walk through overdue, stale, and someday todos",
+	Long: `A weekly review: step through overdue todos, todos nobody has touched in
+a while (--stale, default 14d), and the someday list, one at a time.
+
+For each todo pick a quick action:
+
+  r  reschedule to today, soon, later, or someday
+  c  complete
+  d  delete
+  s  snooze a week (moves the due date, resets staleness)
+  m  demote one bucket (today → soon → later → someday)
+  k  keep as is (or just press enter)
+  q  quit the review
+
+Every action can be reverted with 'mine todo undo'.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("todo.review", runTodoReview),
+}
+
+func runTodoReview(_ *cobra.Command, _ []string) error {
+	return runTodoReviewWithReader(bufio.NewReader(os.Stdin))
+}
+
+// reviewTally counts what a review did.
+type reviewTally struct {
+	rescheduled, completed, deleted, snoozed, demoted, kept int
+}
+
+func runTodoReviewWithReader(reader *bufio.Reader) error {
+	staleAfter, err := parseAge(todoReviewStale)
+	if err != nil {
+		return fmt.Errorf("%w\n  Use a value like %s", err, ui.Accent.Render("--stale 14d"))
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	now := time.Now()
+	ts := todo.NewStore(db.Conn())
+	items, err := ts.ReviewItems(now, staleAfter)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(items) == 0 {
+		fmt.Println(ui.Success.Render("  " + ui.IconParty + " Nothing to review — no overdue, stale, or someday todos."))
+		fmt.Println()
+		return nil
+	}
+	fmt.Println(ui.Title.Render(fmt.Sprintf("  Weekly review — %d todo(s)", len(items))))
+	fmt.Println()
+
+	var tally reviewTally
+	section := ""
+review:
+	for i, item := range items {
+		if item.Reason != section {
+			section = item.Reason
+			fmt.Println(ui.Warning.Render("  " + reviewSectionTitle(section)))
+			fmt.Println()
+		}
+		printTodoCard(item.Todo, i+1, now, nil)
+
+		for {
+			fmt.Printf("%s%s ", cardMetaIndent, ui.Muted.Render("[r]eschedule [c]omplete [d]elete [s]nooze [m]demote [k]eep [q]uit"))
+			line, readErr := reader.ReadString('\n')
+			action := strings.ToLower(strings.TrimSpace(line))
+			if readErr == io.EOF && action == "" {
+				break review
+			}
+
+			done, quit, err := applyReviewAction(ts, reader, item.Todo, action, now, &tally)
+			if err != nil {
+				fmt.Printf("%s%s\n", cardMetaIndent, ui.Error.Render(err.Error()))
+				continue
+			}
+			if quit {
+				break review
+			}
+			if done {
+				break
+			}
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s Review done: %s\n", ui.Success.Render("✓"), ui.Muted.Render(fmt.Sprintf(
+		"%d rescheduled · %d completed · %d deleted · %d snoozed · %d demoted · %d kept",
+		tally.rescheduled, tally.completed, tally.deleted, tally.snoozed, tally.demoted, tally.kept)))
+	fmt.Println()
+	return nil
+}
+
+// applyReviewAction runs one review choice. done means move to the next
+// todo; quit ends the review. An error re-prompts for the same todo.
+func applyReviewAction(ts *todo.Store, reader *bufio.Reader, t todo.Todo, action string, now time.Time, tally *reviewTally) (done, quit bool, err error) {
+	out := func(msg string) {
+		fmt.Printf("%s%s %s\n", cardMetaIndent, ui.Success.Render("✓"), msg)
+	}
+
+	switch action {
+	case "", "k", "keep":
+		tally.kept++
+		return true, false, nil
+	case "q", "quit":
+		return false, true, nil
+	case "c", "complete", "done":
+		if _, _, err := ts.Complete(t.ID); err != nil {
+			return false, false, err
+		}
+		tally.completed++
+		out("Completed")
+	case "d", "delete", "rm":
+		if err := ts.Delete(t.ID); err != nil {
+			return false, false, err
+		}
+		tally.deleted++
+		out("Deleted")
+	case "s", "snooze":
+		until := now.AddDate(0, 0, reviewSnoozeDays)
+		if err := ts.Snooze(t.ID, until); err != nil {
+			return false, false, err
+		}
+		tally.snoozed++
+		if t.DueDate != nil {
+			out("Snoozed — now due " + until.Format("Mon, Jan 2"))
+		} else {
+			out(fmt.Sprintf("Snoozed for %d days", reviewSnoozeDays))
+		}
+	case "m", "demote":
+		next, ok := todo.DemoteSchedule(t.Schedule)
+		if !ok {
+			return false, false, fmt.Errorf("already someday — pick another action")
+		}
+		if err := ts.SetSchedule(t.ID, next); err != nil {
+			return false, false, err
+		}
+		tally.demoted++
+		out("Demoted to " + todo.ScheduleLabel(next))
+	case "r", "reschedule":
+		fmt.Printf("%s%s ", cardMetaIndent, ui.Muted.Render("Schedule (today/soon/later/someday):"))
+		line, _ := reader.ReadString('\n')
+		schedule, err := todo.ParseSchedule(strings.TrimSpace(line))
+		if err != nil {
+			return false, false, err
+		}
+		if err := ts.SetSchedule(t.ID, schedule); err != nil {
+			return false, false, err
+		}
+		tally.rescheduled++
+		out("Scheduled for " + todo.ScheduleLabel(schedule))
+	default:
+		return false, false, fmt.Errorf("unknown action %q", action)
+	}
+	return true, false, nil
+}
+
+// reviewSectionTitle heads each group of review items.
+func reviewSectionTitle(reason string) string {
+	switch reason {
+	case todo.ReviewOverdue:
+		return "Overdue"
+	case todo.ReviewStale:
+		return "Untouched for a while"
+	default:
+		return "Someday — still want these?"
+	}
+}
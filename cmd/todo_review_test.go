@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+// seedReview creates an overdue todo (#1), a stale todo (#2), and a someday todo (#3).
+func seedReview(t *testing.T) {
+	t.Helper()
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ts := todo.NewStore(db.Conn())
+	past := time.Now().AddDate(0, 0, -2)
+	ts.Add("overdue task", "", todo.PrioMedium, nil, &past, nil, todo.ScheduleToday, todo.RecurrenceNone)
+	stale, _ := ts.Add("stale task", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleSoon, todo.RecurrenceNone)
+	ts.Add("someday task", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleSomeday, todo.RecurrenceNone)
+	if _, err := db.Conn().Exec(`UPDATE todos SET updated_at = datetime('now', '-30 days') WHERE id = ?`, stale); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runReview(t *testing.T, input string) string {
+	t.Helper()
+	var err error
+	out := captureStdout(t, func() {
+		err = runTodoReviewWithReader(bufio.NewReader(strings.NewReader(input)))
+	})
+	if err != nil {
+		t.Fatalf("runTodoReviewWithReader: %v", err)
+	}
+	return out
+}
+
+func TestRunTodoReview_Actions(t *testing.T) {
+	todoTestEnv(t)
+	seedReview(t)
+
+	// Overdue: snooze. Stale: demote. Someday: bad action, then delete.
+	out := runReview(t, "s\nm\nbogus\nd\n")
+
+	for _, want := range []string{"Overdue", "Untouched", "Someday", `unknown action "bogus"`, "1 snoozed", "1 demoted", "1 deleted"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if got := getTodo(t, 1); got.IsOverdue(time.Now()) {
+		t.Errorf("expected #1 snoozed past today, due %v", got.DueDate)
+	}
+	if got := getTodo(t, 2).Schedule; got != todo.ScheduleLater {
+		t.Errorf("#2 schedule = %q, want later", got)
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := todo.NewStore(db.Conn()).Get(3); err == nil {
+		t.Error("expected #3 deleted")
+	}
+}
+
+func TestRunTodoReview_RescheduleCompleteQuit(t *testing.T) {
+	todoTestEnv(t)
+	seedReview(t)
+
+	out := runReview(t, "c\nr\ntoday\nq\n")
+
+	if !getTodo(t, 1).Done {
+		t.Error("expected #1 completed")
+	}
+	if got := getTodo(t, 2).Schedule; got != todo.ScheduleToday {
+		t.Errorf("#2 schedule = %q, want today", got)
+	}
+	if getTodo(t, 3).Schedule != todo.ScheduleSomeday {
+		t.Error("expected #3 untouched after quit")
+	}
+	if !strings.Contains(out, "1 rescheduled · 1 completed") {
+		t.Errorf("unexpected summary:\n%s", out)
+	}
+}
+
+func TestRunTodoReview_NothingToReview(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 2)
+
+	out := runReview(t, "")
+	if !strings.Contains(out, "Nothing to review") {
+		t.Errorf("expected empty-review message, got:\n%s", out)
+	}
+}
+
+func TestRunTodoReview_DemoteSomedayRejected(t *testing.T) {
+	todoTestEnv(t)
+	seedReview(t)
+
+	out := runReview(t, "k\n\nm\nk\n")
+	if !strings.Contains(out, "already someday") || !strings.Contains(out, "3 kept") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -26,7 +32,14 @@ Displays:
   - Total focus time from linked dig sessions (if available)
   - Per-project breakdown of open/completed counts
 
-Use --project to scope stats to a single named project.`,
+Use --project to scope stats to a single named project, and --chart to add
+a sparkline of daily completions and a burndown of open todos over the
+last 30 days (--days to change).
+
+--export csv writes per-day completions, open counts, and average close
+times plus the per-project breakdown as CSV, for spreadsheets:
+
+  mine todo stats --export csv --days 90 > stats.csv`,
 	RunE: hook.Wrap("todo.stats", runTodoStats),
 }
 
@@ -49,24 +62,134 @@ func runTodoStats(_ *cobra.Command, _ []string) error {
 
 	ps := proj.NewStore(db.Conn())
 
+	if todoStatsProjectFlag != "" && todoStatsGroupFlag != "" {
+		return fmt.Errorf("--project and --group can't be combined")
+	}
+
+	// paths scopes the stats; nil means every todo.
 	var projectPath *string
+	var paths []string
 	if todoStatsProjectFlag != "" {
 		projectPath, err = resolveTodoProject(ps, todoStatsProjectFlag)
 		if err != nil {
 			return err
 		}
+		paths = []string{*projectPath}
+	}
+	if todoStatsGroupFlag != "" {
+		paths, err = ps.GroupPaths(todoStatsGroupFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	if todoStatsExport != "" && !strings.EqualFold(todoStatsExport, "csv") {
+		return fmt.Errorf("unsupported export format %q — use %s", todoStatsExport, ui.Accent.Render("--export csv"))
+	}
+	if todoStatsDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
 	}
 
 	now := time.Now()
-	stats, err := todo.GetStats(db.Conn(), projectPath, now)
+	stats, err := todo.GetStatsFor(db.Conn(), paths, now)
 	if err != nil {
 		return fmt.Errorf("computing stats: %w", err)
 	}
 
+	var history []todo.DayHistory
+	if todoStatsChart || todoStatsExport != "" {
+		history, err = todo.GetHistoryFor(db.Conn(), paths, now, todoStatsDays)
+		if err != nil {
+			return fmt.Errorf("computing history: %w", err)
+		}
+	}
+
+	if todoStatsExport != "" {
+		return writeStatsCSV(os.Stdout, stats, history)
+	}
+
 	printTodoStats(stats, projectPath)
+	if todoStatsChart {
+		printTodoCharts(history)
+	}
 	return nil
 }
 
+// writeStatsCSV writes one row per day of history followed by one row per
+// project. The section column tells the two apart; columns that don't
+// apply to a section are left empty.
+func writeStatsCSV(out io.Writer, stats *todo.Stats, history []todo.DayHistory) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"section", "date", "project", "completed", "open", "avg_close_days"})
+	for _, d := range history {
+		w.Write([]string{
+			"day", d.Day.Format("2006-01-02"), "",
+			strconv.Itoa(d.Completed), strconv.Itoa(d.Open), formatCloseDays(d.AvgClose),
+		})
+	}
+	for _, p := range stats.ByProject {
+		w.Write([]string{
+			"project", "", p.Name,
+			strconv.Itoa(p.Completed), strconv.Itoa(p.Open), formatCloseDays(p.AvgClose),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// formatCloseDays renders a close duration in days for CSV, empty when unknown.
+func formatCloseDays(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(d.Hours()/24, 'f', 2, 64)
+}
+
+// statsChartHeight is the number of rows in the burndown chart.
+const statsChartHeight = 6
+
+func printTodoCharts(history []todo.DayHistory) {
+	if len(history) == 0 {
+		return
+	}
+	completed := make([]int, len(history))
+	open := make([]int, len(history))
+	total, peak := 0, 0
+	for i, d := range history {
+		completed[i] = d.Completed
+		open[i] = d.Open
+		total += d.Completed
+		peak = max(peak, d.Completed)
+	}
+
+	first := history[0].Day.Format("Jan 2")
+	last := history[len(history)-1].Day.Format("Jan 2")
+	axis := first + strings.Repeat(" ", max(1, len(history)-len(first)-len(last))) + last
+
+	ui.Puts(ui.Muted.Render(fmt.Sprintf("  Completions · last %d days", len(history))))
+	ui.Putsf("    %s  %s", ui.Accent.Render(ui.Sparkline(completed)),
+		ui.Muted.Render(fmt.Sprintf("%d total · peak %d/day", total, peak)))
+	ui.Putsf("    %s", ui.Muted.Render(axis))
+	ui.Puts("")
+
+	start, end := open[0], open[len(open)-1]
+	peakOpen := slices.Max(open)
+	label := len(strconv.Itoa(peakOpen))
+	ui.Puts(ui.Muted.Render(fmt.Sprintf("  Open todos · %d → %d (%+d)", start, end, end-start)))
+	for i, row := range ui.BarChart(open, statsChartHeight) {
+		y := ""
+		switch i {
+		case 0:
+			y = strconv.Itoa(peakOpen)
+		case statsChartHeight - 1:
+			y = "0"
+		}
+		ui.Putsf("    %s %s %s", ui.Muted.Render(fmt.Sprintf("%*s", label, y)), ui.Muted.Render("┤"), ui.Accent.Render(row))
+	}
+	ui.Putsf("    %s   %s", strings.Repeat(" ", label), ui.Muted.Render(axis))
+	ui.Puts("")
+}
+
 func printTodoStats(stats *todo.Stats, projectPath *string) {
 	ui.Puts("")
 	ui.Puts(ui.Title.Render("  Task Stats"))
@@ -153,7 +276,7 @@ func runTodoRecurring(_ *cobra.Command, _ []string) error {
 		line := fmt.Sprintf("  %s %s %s  %s", id, prio, freq, t.Title)
 
 		if t.DueDate != nil {
-			line += ui.Muted.Render(fmt.Sprintf(" (next: %s)", t.DueDate.Format("Jan 2")))
+			line += ui.Muted.Render(fmt.Sprintf(" (next: %s)", todo.DueLabel(t, "Jan 2")))
 		}
 		if t.ProjectPath != nil {
 			projName := filepath.Base(*t.ProjectPath)
@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/contrib"
+	"github.com/rnwolfe/mine/internal/github"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	todoSyncRepo    string
+	todoSyncProject string
+	todoSyncPush    bool
+)
+
+// issueTracker is the GitHub surface sync needs; the gh CLI in production.
+type issueTracker interface {
+	ListOpenIssues(repo string) ([]github.Issue, error)
+	IssueState(repo string, number int) (string, error)
+	CreateIssue(repo, title, body string) (int, string, error)
+	CloseIssue(repo string, number int) error
+}
+
+// newIssueTracker is injectable for testing.
+var newIssueTracker = func() (issueTracker, error) {
+	if err := contrib.CheckGH(); err != nil {
+		return nil, err
+	}
+	return github.CLI{}, nil
+}
+
+func init() {
+	todoCmd.AddCommand(todoSyncCmd)
+	todoSyncCmd.AddCommand(todoSyncGitHubCmd)
+	todoSyncGitHubCmd.Flags().StringVar(&todoSyncRepo, "repo", "", "GitHub repo (owner/name); remembered per project")
+	todoSyncGitHubCmd.Flags().StringVarP(&todoSyncProject, "project", "p", "", "Project to sync (default: current directory)")
+	todoSyncGitHubCmd.Flags().BoolVar(&todoSyncPush, "push", false, "Also create issues for local todos and close issues for done todos")
+}
+
+var todoSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync project todos with an external tracker",
+	RunE:  hook.Wrap("todo.sync", runTodoSyncHelp),
+}
+
+func runTodoSyncHelp(_ *cobra.Command, _ []string) error {
+	fmt.Println()
+	fmt.Println(ui.Title.Render("  Sync"))
+	fmt.Println()
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo sync github --repo owner/name"), ui.Muted.Render("Link the project to a repo and pull open issues"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo sync github --push"), ui.Muted.Render("Also push local todos as issues"))
+	fmt.Println()
+	return nil
+}
+
+var todoSyncGitHubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Mirror a project's GitHub issues as todos",
+	Long: `Mirror the open issues of a GitHub repo as todos in a registered project.
+
+The first run needs --repo; it is saved as the project's github_repo setting
+(see 'mine proj config'). Each sync:
+
+  - adds a todo for every new open issue (labels become tags)
+  - updates titles of linked todos when the issue was renamed
+  - completes linked todos whose issue was closed
+
+With --push, open todos in the project that aren't linked yet become new
+issues, and issues whose todo is done are closed. Someday todos are never
+pushed. Deleting a synced todo does not bring it back on the next sync.
+
+Requires the gh CLI, authenticated with 'gh auth login'.`,
+	Args: cobra.NoArgs,
+	RunE: hook.Wrap("todo.sync.github", runTodoSyncGitHub),
+}
+
+func runTodoSyncGitHub(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ps := proj.NewStore(db.Conn())
+	p, err := syncProject(ps, todoSyncProject)
+	if err != nil {
+		return err
+	}
+
+	repo := strings.TrimSpace(todoSyncRepo)
+	if repo == "" {
+		if repo, err = ps.GetSetting(p.Name, "github_repo"); err != nil {
+			return err
+		}
+	}
+	if repo == "" {
+		return fmt.Errorf("no GitHub repo linked to %s — use %s", p.Name,
+			ui.Accent.Render("mine todo sync github --repo owner/name"))
+	}
+	if err := contrib.ValidateRepo(repo); err != nil {
+		return err
+	}
+
+	tracker, err := newIssueTracker()
+	if err != nil {
+		return err
+	}
+
+	ts := todo.NewStore(db.Conn())
+	ts.BeginBatch()
+	res, err := syncGitHub(ts, tracker, repo, p.Path, todoSyncPush)
+	if err != nil {
+		return err
+	}
+
+	if todoSyncRepo != "" {
+		if err := ps.SetSetting(p.Name, "github_repo", repo); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("  %s Synced %s with %s\n", ui.Success.Render("✓"), ui.Accent.Render(p.Name), ui.Accent.Render(repo))
+	fmt.Println(ui.Muted.Render(fmt.Sprintf("    %d imported · %d updated · %d completed", res.Imported, res.Updated, res.Completed)))
+	if todoSyncPush {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("    %d issue(s) created · %d closed", res.Created, res.Closed)))
+	}
+	fmt.Println()
+	return nil
+}
+
+// syncProject resolves the project to sync: by name, or from the current directory.
+func syncProject(ps *proj.Store, name string) (*proj.Project, error) {
+	path, err := resolveTodoProject(ps, name)
+	if err != nil {
+		return nil, err
+	}
+	if path == nil {
+		return nil, fmt.Errorf("not inside a registered project — use %s or run from a project directory",
+			ui.Accent.Render("--project <name>"))
+	}
+	p, err := ps.FindForPath(*path)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, fmt.Errorf("project at %s is not registered", *path)
+	}
+	return p, nil
+}
+
+// githubSyncResult counts what a sync changed.
+type githubSyncResult struct {
+	Imported  int
+	Updated   int
+	Completed int
+	Created   int
+	Closed    int
+}
+
+// syncGitHub reconciles open issues of repo with todos in projectPath.
+// Links are keyed "owner/repo#N".
+func syncGitHub(ts *todo.Store, tracker issueTracker, repo, projectPath string, push bool) (githubSyncResult, error) {
+	var res githubSyncResult
+
+	issues, err := tracker.ListOpenIssues(repo)
+	if err != nil {
+		return res, err
+	}
+	links, err := ts.Links(todo.LinkProviderGitHub, repo+"#")
+	if err != nil {
+		return res, err
+	}
+
+	open := map[string]bool{}
+	for _, issue := range issues {
+		extID := fmt.Sprintf("%s#%d", repo, issue.Number)
+		open[extID] = true
+
+		link, ok := links[extID]
+		if !ok {
+			id, err := ts.Add(issue.Title, issue.Body, todo.PrioMedium, issueTags(issue), nil, &projectPath, todo.ScheduleLater, todo.RecurrenceNone)
+			if err != nil {
+				return res, err
+			}
+			if err := ts.AddLink(id, todo.LinkProviderGitHub, extID, issue.URL); err != nil {
+				return res, err
+			}
+			res.Imported++
+			continue
+		}
+		if link.TodoID == 0 {
+			// Todo was deleted locally; leave the issue alone.
+			continue
+		}
+
+		t, err := ts.Get(link.TodoID)
+		if err != nil {
+			return res, err
+		}
+		switch {
+		case t.Done && push:
+			if err := tracker.CloseIssue(repo, issue.Number); err != nil {
+				return res, err
+			}
+			res.Closed++
+		case !t.Done && t.Title != issue.Title:
+			title := issue.Title
+			if err := ts.Edit(t.ID, &title, nil); err != nil {
+				return res, err
+			}
+			res.Updated++
+		}
+	}
+
+	// Linked todos whose issue dropped out of the open list may have been closed.
+	linked := map[int]bool{}
+	for extID, link := range links {
+		if link.TodoID == 0 {
+			continue
+		}
+		linked[link.TodoID] = true
+		if open[extID] {
+			continue
+		}
+		t, err := ts.Get(link.TodoID)
+		if err != nil {
+			return res, err
+		}
+		if t.Done {
+			continue
+		}
+		number, err := strconv.Atoi(extID[len(repo)+1:])
+		if err != nil {
+			return res, fmt.Errorf("malformed link %q", extID)
+		}
+		state, err := tracker.IssueState(repo, number)
+		if err != nil {
+			return res, err
+		}
+		if state == github.StateClosed {
+			if _, _, err := ts.Complete(t.ID); err != nil {
+				return res, err
+			}
+			res.Completed++
+		}
+	}
+
+	if !push {
+		return res, nil
+	}
+
+	todos, err := ts.List(todo.ListOptions{ProjectPath: &projectPath})
+	if err != nil {
+		return res, err
+	}
+	for _, t := range todos {
+		if linked[t.ID] || t.ProjectPath == nil || *t.ProjectPath != projectPath {
+			continue
+		}
+		number, url, err := tracker.CreateIssue(repo, t.Title, t.Body)
+		if err != nil {
+			return res, err
+		}
+		if err := ts.AddLink(t.ID, todo.LinkProviderGitHub, fmt.Sprintf("%s#%d", repo, number), url); err != nil {
+			return res, err
+		}
+		res.Created++
+	}
+	return res, nil
+}
+
+// issueTags turns issue labels into tags: lowercased, spaces to dashes.
+func issueTags(issue github.Issue) []string {
+	var tags []string
+	for _, name := range issue.LabelNames() {
+		tag := strings.ToLower(strings.TrimSpace(name))
+		tag = strings.NewReplacer(" ", "-", ",", "").Replace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/github"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+// fakeTracker is an in-memory issueTracker.
+type fakeTracker struct {
+	issues map[int]*github.Issue
+	next   int
+	closed []int
+}
+
+func newFakeTracker(issues ...github.Issue) *fakeTracker {
+	f := &fakeTracker{issues: map[int]*github.Issue{}, next: 100}
+	for i := range issues {
+		issue := issues[i]
+		issue.State = github.StateOpen
+		f.issues[issue.Number] = &issue
+	}
+	return f
+}
+
+func (f *fakeTracker) ListOpenIssues(string) ([]github.Issue, error) {
+	var out []github.Issue
+	for _, i := range f.issues {
+		if i.State == github.StateOpen {
+			out = append(out, *i)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeTracker) IssueState(_ string, n int) (string, error) {
+	i, ok := f.issues[n]
+	if !ok {
+		return "", fmt.Errorf("issue %d not found", n)
+	}
+	return i.State, nil
+}
+
+func (f *fakeTracker) CreateIssue(repo, title, body string) (int, string, error) {
+	f.next++
+	f.issues[f.next] = &github.Issue{Number: f.next, Title: title, Body: body, State: github.StateOpen}
+	return f.next, fmt.Sprintf("https://github.com/%s/issues/%d", repo, f.next), nil
+}
+
+func (f *fakeTracker) CloseIssue(_ string, n int) error {
+	f.issues[n].State = github.StateClosed
+	f.closed = append(f.closed, n)
+	return nil
+}
+
+func useFakeTracker(t *testing.T, f *fakeTracker) {
+	t.Helper()
+	orig := newIssueTracker
+	t.Cleanup(func() { newIssueTracker = orig })
+	newIssueTracker = func() (issueTracker, error) { return f, nil }
+}
+
+func runSync(t *testing.T, project, repo string, push bool) string {
+	t.Helper()
+	todoSyncProject, todoSyncRepo, todoSyncPush = project, repo, push
+	defer func() { todoSyncProject, todoSyncRepo, todoSyncPush = "", "", false }()
+
+	var err error
+	out := captureStdout(t, func() { err = runTodoSyncGitHub(nil, nil) })
+	if err != nil {
+		t.Fatalf("runTodoSyncGitHub: %v", err)
+	}
+	return out
+}
+
+func projectTodos(t *testing.T, path string) []todo.Todo {
+	t.Helper()
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	todos, err := todo.NewStore(db.Conn()).List(todo.ListOptions{ProjectPath: &path, ShowDone: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return todos
+}
+
+func TestRunTodoSyncGitHub_ImportsAndRemembersRepo(t *testing.T) {
+	todoTestEnv(t)
+	path := registerProject(t, "app")
+	tracker := newFakeTracker(github.Issue{Number: 1, Title: "Crash on start", Labels: []struct {
+		Name string `json:"name"`
+	}{{Name: "Good First Issue"}}})
+	useFakeTracker(t, tracker)
+
+	out := runSync(t, "app", "o/app", false)
+	if !strings.Contains(out, "1 imported") {
+		t.Errorf("output = %q, want 1 imported", out)
+	}
+	todos := projectTodos(t, path)
+	if len(todos) != 1 || todos[0].Title != "Crash on start" {
+		t.Fatalf("todos = %+v", todos)
+	}
+	if len(todos[0].Tags) != 1 || todos[0].Tags[0] != "good-first-issue" {
+		t.Errorf("tags = %v, want [good-first-issue]", todos[0].Tags)
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := proj.NewStore(db.Conn()).GetSetting("app", "github_repo")
+	db.Close()
+	if err != nil || repo != "o/app" {
+		t.Fatalf("github_repo = %q (%v), want o/app", repo, err)
+	}
+
+	// Second run uses the saved repo and doesn't duplicate.
+	tracker.issues[1].Title = "Crash on startup"
+	out = runSync(t, "app", "", false)
+	if !strings.Contains(out, "0 imported · 1 updated") {
+		t.Errorf("output = %q, want title update", out)
+	}
+	todos = projectTodos(t, path)
+	if len(todos) != 1 || todos[0].Title != "Crash on startup" {
+		t.Fatalf("todos = %+v", todos)
+	}
+}
+
+func TestRunTodoSyncGitHub_CompletesClosedIssues(t *testing.T) {
+	todoTestEnv(t)
+	path := registerProject(t, "app")
+	tracker := newFakeTracker(github.Issue{Number: 1, Title: "a"}, github.Issue{Number: 2, Title: "b"})
+	useFakeTracker(t, tracker)
+
+	runSync(t, "app", "o/app", false)
+	tracker.issues[2].State = github.StateClosed
+	out := runSync(t, "app", "", false)
+	if !strings.Contains(out, "1 completed") {
+		t.Errorf("output = %q, want 1 completed", out)
+	}
+	for _, td := range projectTodos(t, path) {
+		if td.Done != (td.Title == "b") {
+			t.Errorf("%q done = %v", td.Title, td.Done)
+		}
+	}
+}
+
+func TestRunTodoSyncGitHub_DeletedTodoNotReimported(t *testing.T) {
+	todoTestEnv(t)
+	path := registerProject(t, "app")
+	useFakeTracker(t, newFakeTracker(github.Issue{Number: 1, Title: "a"}))
+
+	runSync(t, "app", "o/app", false)
+	todos := projectTodos(t, path)
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := todo.NewStore(db.Conn()).Delete(todos[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	runSync(t, "app", "", false)
+	if got := projectTodos(t, path); len(got) != 0 {
+		t.Errorf("todos = %+v, want deleted todo to stay deleted", got)
+	}
+}
+
+func TestRunTodoSyncGitHub_Push(t *testing.T) {
+	todoTestEnv(t)
+	path := registerProject(t, "app")
+	tracker := newFakeTracker(github.Issue{Number: 1, Title: "from github"})
+	useFakeTracker(t, tracker)
+	runSync(t, "app", "o/app", false)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	ts.Add("local task", "details", todo.PrioMedium, nil, nil, &path, todo.ScheduleLater, todo.RecurrenceNone)
+	ts.Add("maybe someday", "", todo.PrioMedium, nil, nil, &path, todo.ScheduleSomeday, todo.RecurrenceNone)
+	ts.Add("global", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	for _, td := range projectTodos(t, path) {
+		if td.Title == "from github" {
+			ts.Complete(td.ID)
+		}
+	}
+	db.Close()
+
+	out := runSync(t, "app", "", true)
+	if !strings.Contains(out, "1 issue(s) created · 1 closed") {
+		t.Errorf("output = %q", out)
+	}
+	if len(tracker.closed) != 1 || tracker.closed[0] != 1 {
+		t.Errorf("closed = %v, want [1]", tracker.closed)
+	}
+	created := tracker.issues[101]
+	if created == nil || created.Title != "local task" || created.Body != "details" {
+		t.Fatalf("created issue = %+v", created)
+	}
+
+	// Pushed todo is now linked; a second push creates nothing.
+	out = runSync(t, "app", "", true)
+	if !strings.Contains(out, "0 issue(s) created") {
+		t.Errorf("output = %q, want nothing new pushed", out)
+	}
+}
+
+func TestRunTodoSyncGitHub_Errors(t *testing.T) {
+	todoTestEnv(t)
+	registerProject(t, "app")
+	useFakeTracker(t, newFakeTracker())
+
+	tests := []struct {
+		name, project, repo, want string
+	}{
+		{"no repo", "app", "", "no GitHub repo linked"},
+		{"bad repo", "app", "not-a-repo", "invalid repo"},
+		{"unknown project", "nope", "o/r", "not found in registry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todoSyncProject, todoSyncRepo = tt.project, tt.repo
+			defer func() { todoSyncProject, todoSyncRepo = "", "" }()
+			err := runTodoSyncGitHub(nil, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
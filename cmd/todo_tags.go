@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	todoCmd.AddCommand(todoTagsCmd)
+	todoCmd.AddCommand(todoTagCmd)
+	todoTagCmd.AddCommand(todoTagRenameCmd)
+	todoTagCmd.AddCommand(todoTagRmCmd)
+}
+
+// --- mine todo tags ---
+
+var todoTagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List all tags with counts",
+	Args:  cobra.NoArgs,
+	RunE:  hook.Wrap("todo.tags", runTodoTags),
+}
+
+func runTodoTags(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	counts, err := todo.NewStore(db.Conn()).TagCounts()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(counts) == 0 {
+		fmt.Println(ui.Muted.Render("  No tags yet."))
+		fmt.Printf("  Tag a todo: %s\n", ui.Accent.Render(`mine todo add "something" --tags work,urgent`))
+		fmt.Println()
+		return nil
+	}
+
+	width := 0
+	for _, c := range counts {
+		if w := lipgloss.Width(c.Tag); w > width {
+			width = w
+		}
+	}
+	for _, c := range counts {
+		name := lipgloss.NewStyle().Width(width).Render(c.Tag)
+		fmt.Printf("  %s  %s\n", ui.Accent.Render(name), ui.Muted.Render(fmt.Sprintf("%d open · %d total", c.Open, c.Total)))
+	}
+	fmt.Println()
+	fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d tag(s)", len(counts))))
+	fmt.Println()
+	return nil
+}
+
+// --- mine todo tag ---
+
+var todoTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Rename or remove tags across all todos",
+	RunE:  hook.Wrap("todo.tag", runTodoTagHelp),
+}
+
+func runTodoTagHelp(_ *cobra.Command, _ []string) error {
+	fmt.Println()
+	fmt.Println(ui.Title.Render("  Tags"))
+	fmt.Println()
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo tags"), ui.Muted.Render("List tags with counts"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo tag rename <old> <new>"), ui.Muted.Render("Rename (or merge) a tag everywhere"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo tag rm <tag>"), ui.Muted.Render("Remove a tag from every todo"))
+	fmt.Println()
+	return nil
+}
+
+var todoTagRenameCmd = &cobra.Command{
+	Use:     "rename <old> <new>",
+	Aliases: []string{"mv"},
+	Short:   "Rename a tag on every todo",
+	Long:    `Rename a tag on every todo. If a todo already has the new tag, the two are merged.`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    hook.Wrap("todo.tag.rename", runTodoTagRename),
+}
+
+func runTodoTagRename(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	ts.BeginBatch()
+	n, err := ts.RenameTag(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  No todos tagged %q.", args[0])))
+		return nil
+	}
+	fmt.Printf("  %s Renamed %s → %s on %d todo(s)\n",
+		ui.Success.Render("✓"), ui.Accent.Render(args[0]), ui.Accent.Render(args[1]), n)
+	return nil
+}
+
+var todoTagRmCmd = &cobra.Command{
+	Use:     "rm <tag>",
+	Aliases: []string{"remove", "delete"},
+	Short:   "Remove a tag from every todo",
+	Args:    cobra.ExactArgs(1),
+	RunE:    hook.Wrap("todo.tag.rm", runTodoTagRm),
+}
+
+func runTodoTagRm(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	ts.BeginBatch()
+	n, err := ts.RemoveTag(args[0])
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		fmt.Println(ui.Muted.Render(fmt.Sprintf("  No todos tagged %q.", args[0])))
+		return nil
+	}
+	fmt.Printf("  %s Removed %s from %d todo(s)\n", ui.Success.Render("✓"), ui.Accent.Render(args[0]), n)
+	fmt.Printf("  Changed your mind? %s\n", ui.Accent.Render("mine todo undo"))
+	return nil
+}
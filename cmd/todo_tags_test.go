@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func addTaggedTodo(t *testing.T, title string, tags ...string) int {
+	t.Helper()
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	id, err := todo.NewStore(db.Conn()).Add(title, "", todo.PrioMedium, tags, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestRunTodoTags_ListsCounts(t *testing.T) {
+	todoTestEnv(t)
+	addTaggedTodo(t, "a", "work", "deep")
+	addTaggedTodo(t, "b", "work")
+
+	out := captureStdout(t, func() {
+		if err := runTodoTags(nil, nil); err != nil {
+			t.Fatalf("runTodoTags: %v", err)
+		}
+	})
+	if !strings.Contains(out, "work") || !strings.Contains(out, "2 open · 2 total") || !strings.Contains(out, "2 tag(s)") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestRunTodoTagRename(t *testing.T) {
+	todoTestEnv(t)
+	id := addTaggedTodo(t, "a", "wrok")
+
+	out := captureStdout(t, func() {
+		if err := runTodoTagRename(nil, []string{"wrok", "work"}); err != nil {
+			t.Fatalf("runTodoTagRename: %v", err)
+		}
+	})
+	if !strings.Contains(out, "1 todo(s)") {
+		t.Errorf("expected confirmation:\n%s", out)
+	}
+	if got := getTodo(t, id); strings.Join(got.Tags, ",") != "work" {
+		t.Errorf("tags = %v, want [work]", got.Tags)
+	}
+}
+
+func TestRunTodoTagRm_UnknownTag(t *testing.T) {
+	todoTestEnv(t)
+	addTaggedTodo(t, "a", "work")
+
+	out := captureStdout(t, func() {
+		if err := runTodoTagRm(nil, []string{"nope"}); err != nil {
+			t.Fatalf("runTodoTagRm: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No todos tagged") {
+		t.Errorf("expected no-op message:\n%s", out)
+	}
+}
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/proj"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	todoTemplateFromFlag int
+	todoTemplateFileFlag string
+)
+
+func init() {
+	todoCmd.AddCommand(todoTemplateCmd)
+	todoTemplateCmd.AddCommand(todoTemplateAddCmd)
+	todoTemplateCmd.AddCommand(todoTemplateListCmd)
+	todoTemplateCmd.AddCommand(todoTemplateApplyCmd)
+	todoTemplateCmd.AddCommand(todoTemplateRmCmd)
+
+	todoTemplateAddCmd.Flags().IntVar(&todoTemplateFromFlag, "from", 0, "Capture an existing todo and its subtasks")
+	todoTemplateAddCmd.Flags().StringVar(&todoTemplateFileFlag, "file", "", "Read items from a checklist file ('-' for stdin)")
+	todoTemplateApplyCmd.Flags().StringVar(&todoProjectName, "project", "", "Assign created todos to a named project")
+}
+
+// --- mine todo template ---
+
+var todoTemplateCmd = &cobra.Command{
+	Use:     "template",
+	Aliases: []string{"tpl"},
+	Short:   "Reusable checklists of todos",
+	Long:    `Save named sets of todos (with subtasks, priorities, and tags) and instantiate them with one command.`,
+	RunE:    hook.Wrap("todo.template", runTodoTemplateHelp),
+}
+
+func runTodoTemplateHelp(_ *cobra.Command, _ []string) error {
+	fmt.Println()
+	fmt.Println(ui.Title.Render("  Todo Templates"))
+	fmt.Println()
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo template add <name> --file <path>"), ui.Muted.Render("Save a checklist file as a template"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo template add <name> --from <id>"), ui.Muted.Render("Save an existing todo and its subtasks"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo template list"), ui.Muted.Render("List saved templates"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo template apply <name>"), ui.Muted.Render("Create todos from a template"))
+	fmt.Printf("  %s  %s\n", ui.Accent.Render("mine todo template rm <name>"), ui.Muted.Render("Delete a template"))
+	fmt.Println()
+	return nil
+}
+
+// --- mine todo template add ---
+
+var todoTemplateAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Save a new template",
+	Long: `Save a template from an existing todo tree (--from) or a checklist file (--file).
+
+Checklist files list one task per line. Indent a line (two spaces or a tab)
+to make it a subtask of the line above. Trailing !priority and #tag tokens
+set priority and tags; leading "- " or "- [ ] " bullets are ignored:
+
+  Release v1.2 !high #release
+    Bump version
+    Update CHANGELOG #docs
+    Tag and push !crit`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("todo.template.add", runTodoTemplateAdd),
+}
+
+func runTodoTemplateAdd(_ *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if (todoTemplateFromFlag == 0) == (todoTemplateFileFlag == "") {
+		return fmt.Errorf("give exactly one source: %s or %s",
+			ui.Accent.Render("--from <id>"), ui.Accent.Render("--file <path>"))
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+
+	var items []todo.TemplateItem
+	if todoTemplateFromFlag != 0 {
+		item, err := ts.TemplateFromTodo(todoTemplateFromFlag)
+		if err != nil {
+			return err
+		}
+		items = []todo.TemplateItem{item}
+	} else {
+		var r io.Reader = os.Stdin
+		if todoTemplateFileFlag != "-" {
+			f, err := os.Open(todoTemplateFileFlag)
+			if err != nil {
+				return fmt.Errorf("reading checklist: %w", err)
+			}
+			defer f.Close()
+			r = f
+		}
+		items, err = parseTemplateChecklist(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := ts.SaveTemplate(name, items); err != nil {
+		return err
+	}
+
+	tpl := todo.Template{Items: items}
+	fmt.Printf("  %s Saved template %s (%d todos)\n", ui.Success.Render("✓"), ui.Accent.Render(name), tpl.Count())
+	fmt.Printf("  Use it: %s\n", ui.Accent.Render(fmt.Sprintf("mine todo template apply %q", name)))
+	return nil
+}
+
+// checklistNode is a parse-time tree node; children are pointers so deeper
+// lines can attach while their ancestors are still on the stack.
+type checklistNode struct {
+	item     todo.TemplateItem
+	indent   int
+	children []*checklistNode
+}
+
+func (n *checklistNode) toItem() todo.TemplateItem {
+	it := n.item
+	for _, c := range n.children {
+		it.Children = append(it.Children, c.toItem())
+	}
+	return it
+}
+
+// parseTemplateChecklist parses the indented checklist format described in
+// 'mine todo template add --help' into a tree of template items.
+func parseTemplateChecklist(r io.Reader) ([]todo.TemplateItem, error) {
+	var roots []*checklistNode
+	var stack []*checklistNode
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := strings.ReplaceAll(scanner.Text(), "\t", "  ")
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" {
+			continue
+		}
+
+		item, err := parseChecklistLine(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		node := &checklistNode{item: item, indent: len(raw) - len(strings.TrimLeft(raw, " "))}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= node.indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+		}
+		stack = append(stack, node)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading checklist: %w", err)
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("checklist is empty")
+	}
+
+	items := make([]todo.TemplateItem, len(roots))
+	for i, n := range roots {
+		items[i] = n.toItem()
+	}
+	return items, nil
+}
+
+// parseChecklistLine parses one checklist line: an optional bullet, the
+// title, then any trailing !priority and #tag tokens.
+func parseChecklistLine(line string) (todo.TemplateItem, error) {
+	for _, bullet := range []string{"- [ ] ", "- [x] ", "* [ ] ", "- ", "* "} {
+		if strings.HasPrefix(line, bullet) {
+			line = strings.TrimSpace(line[len(bullet):])
+			break
+		}
+	}
+
+	item := todo.TemplateItem{Priority: todo.PrioMedium}
+	fields := strings.Fields(line)
+	end := len(fields)
+	for ; end > 0; end-- {
+		tok := fields[end-1]
+		if len(tok) < 2 {
+			break
+		}
+		if tok[0] == '#' {
+			item.Tags = append([]string{tok[1:]}, item.Tags...)
+			continue
+		}
+		if tok[0] != '!' {
+			break
+		}
+		p, err := parsePriorityStrict(tok[1:])
+		if err != nil {
+			return todo.TemplateItem{}, err
+		}
+		item.Priority = p
+	}
+	item.Title = strings.Join(fields[:end], " ")
+	if item.Title == "" {
+		return todo.TemplateItem{}, fmt.Errorf("missing task title in %q", line)
+	}
+	return item, nil
+}
+
+// --- mine todo template list ---
+
+var todoTemplateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List saved templates",
+	Args:    cobra.NoArgs,
+	RunE:    hook.Wrap("todo.template.list", runTodoTemplateList),
+}
+
+func runTodoTemplateList(_ *cobra.Command, _ []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tpls, err := todo.NewStore(db.Conn()).ListTemplates()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(tpls) == 0 {
+		fmt.Println(ui.Muted.Render("  No templates yet."))
+		fmt.Printf("  Create one: %s\n", ui.Accent.Render(`mine todo template add "release" --file checklist.txt`))
+		fmt.Println()
+		return nil
+	}
+	for _, t := range tpls {
+		fmt.Printf("  %s %s\n", ui.Accent.Render(t.Name), ui.Muted.Render(fmt.Sprintf("(%d todos)", t.Count())))
+		for _, it := range t.Items {
+			fmt.Printf("    %s %s\n", todo.PriorityIcon(it.Priority), it.Title)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// --- mine todo template apply ---
+
+var todoTemplateApplyCmd = &cobra.Command{
+	Use:   "apply <name>",
+	Short: "Create todos from a template",
+	Long: `Create todos from a saved template, with subtasks linked to their parents.
+
+Todos are assigned to the current project (or --project), like 'mine todo add'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: hook.Wrap("todo.template.apply", runTodoTemplateApply),
+}
+
+func runTodoTemplateApply(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	tpl, err := ts.GetTemplate(args[0])
+	if err != nil {
+		if errors.Is(err, todo.ErrTemplateNotFound) {
+			return fmt.Errorf("template %q not found — use %s to see templates", args[0], ui.Accent.Render("mine todo template list"))
+		}
+		return err
+	}
+
+	projectPath, err := resolveTodoProject(proj.NewStore(db.Conn()), todoProjectName)
+	if err != nil {
+		return err
+	}
+
+	roots, err := ts.ApplyTemplate(tpl, projectPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("  %s Applied %s — %d todos created\n", ui.Success.Render("✓"), ui.Accent.Render(tpl.Name), tpl.Count())
+	for _, id := range roots {
+		t, err := ts.Get(id)
+		if err != nil {
+			continue
+		}
+		fmt.Printf("    %s %s\n", ui.Accent.Render(fmt.Sprintf("#%d", id)), t.Title)
+	}
+	if projectPath != nil {
+		fmt.Printf("    Project: %s\n", ui.Muted.Render(filepath.Base(*projectPath)))
+	}
+	return nil
+}
+
+// --- mine todo template rm ---
+
+var todoTemplateRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove", "delete"},
+	Short:   "Delete a template",
+	Args:    cobra.ExactArgs(1),
+	RunE:    hook.Wrap("todo.template.rm", runTodoTemplateRm),
+}
+
+func runTodoTemplateRm(_ *cobra.Command, args []string) error {
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := todo.NewStore(db.Conn()).DeleteTemplate(args[0]); err != nil {
+		if errors.Is(err, todo.ErrTemplateNotFound) {
+			return fmt.Errorf("template %q not found — use %s to see templates", args[0], ui.Accent.Render("mine todo template list"))
+		}
+		return err
+	}
+	fmt.Printf("  %s Template %s deleted\n", ui.Success.Render("✓"), ui.Accent.Render(args[0]))
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func TestParseTemplateChecklist(t *testing.T) {
+	in := `Release v1.2 !high #release
+  - [ ] Bump version
+  - Update CHANGELOG #docs #release
+	Tag and push !crit
+    Announce
+
+Retro`
+	items, err := parseTemplateChecklist(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("parseTemplateChecklist: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 roots, got %d", len(items))
+	}
+
+	rel := items[0]
+	if rel.Title != "Release v1.2" || rel.Priority != todo.PrioHigh || len(rel.Tags) != 1 || rel.Tags[0] != "release" {
+		t.Errorf("unexpected root: %+v", rel)
+	}
+	if len(rel.Children) != 3 {
+		t.Fatalf("expected 3 subtasks, got %+v", rel.Children)
+	}
+	if got := rel.Children[1]; got.Title != "Update CHANGELOG" || strings.Join(got.Tags, ",") != "docs,release" {
+		t.Errorf("unexpected tags parse: %+v", got)
+	}
+	if got := rel.Children[2]; got.Priority != todo.PrioCrit || len(got.Children) != 1 || got.Children[0].Title != "Announce" {
+		t.Errorf("unexpected nested subtask: %+v", got)
+	}
+	if items[1].Title != "Retro" || items[1].Priority != todo.PrioMedium {
+		t.Errorf("unexpected second root: %+v", items[1])
+	}
+}
+
+func TestParseTemplateChecklist_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"empty", "\n\n"},
+		{"bad priority", "Ship it !urgent"},
+		{"tags only", "#release"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseTemplateChecklist(strings.NewReader(tt.in)); err == nil {
+				t.Errorf("expected error for %q", tt.in)
+			}
+		})
+	}
+}
+
+func TestRunTodoTemplate_AddFromFileAndApply(t *testing.T) {
+	todoTestEnv(t)
+
+	path := filepath.Join(t.TempDir(), "release.txt")
+	os.WriteFile(path, []byte("Release !high\n  Bump version\n  Tag\n"), 0o644)
+
+	todoTemplateFileFlag = path
+	defer func() { todoTemplateFileFlag = "" }()
+	out := captureStdout(t, func() {
+		if err := runTodoTemplateAdd(nil, []string{"release"}); err != nil {
+			t.Fatalf("runTodoTemplateAdd: %v", err)
+		}
+	})
+	if !strings.Contains(out, "3 todos") {
+		t.Errorf("expected count in confirmation:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTodoTemplateApply(nil, []string{"release"}); err != nil {
+			t.Fatalf("runTodoTemplateApply: %v", err)
+		}
+	})
+	if !strings.Contains(out, "3 todos created") {
+		t.Errorf("expected apply confirmation:\n%s", out)
+	}
+
+	root := getTodo(t, 1)
+	if root.Title != "Release" || root.Priority != todo.PrioHigh {
+		t.Errorf("unexpected root todo: %+v", root)
+	}
+	if child := getTodo(t, 3); child.ParentID == nil || *child.ParentID != 1 {
+		t.Errorf("expected #3 to be a subtask of #1, got %v", child.ParentID)
+	}
+}
+
+func TestRunTodoTemplate_RequiresOneSource(t *testing.T) {
+	todoTestEnv(t)
+	err := runTodoTemplateAdd(nil, []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "exactly one source") {
+		t.Fatalf("expected source error, got %v", err)
+	}
+}
+
+func TestRunTodoTemplateApply_NotFound(t *testing.T) {
+	todoTestEnv(t)
+	err := runTodoTemplateApply(nil, []string{"nope"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
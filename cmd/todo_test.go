@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -1682,3 +1684,717 @@ func TestRunProjRm_DemotesOrphanedTodos(t *testing.T) {
 		}
 	}
 }
+
+func TestRunTodoAdd_WithParent_LinksSubtask(t *testing.T) {
+	todoTestEnv(t)
+	todoProjectName = ""
+	todoPriority = "med"
+	todoDue = ""
+	todoTags = ""
+	todoScheduleFlag = "later"
+	todoEveryFlag = ""
+	defer func() { todoParentFlag = "" }()
+
+	projDir := registerProject(t, "parentproj")
+	origDir, _ := os.Getwd()
+	tmpDir := t.TempDir()
+	os.Chdir(tmpDir)
+	defer os.Chdir(origDir)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	parentID, _ := ts.Add("ship release", "", todo.PrioHigh, nil, nil, &projDir, todo.ScheduleLater, todo.RecurrenceNone)
+	db.Close()
+
+	todoParentFlag = strconv.Itoa(parentID)
+	out := captureStdout(t, func() {
+		if err := runTodoAdd(nil, []string{"write notes"}); err != nil {
+			t.Fatalf("runTodoAdd: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Subtask of") {
+		t.Errorf("expected 'Subtask of' in output:\n%s", out)
+	}
+
+	db, err = store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ts = todo.NewStore(db.Conn())
+	subs, err := ts.Subtasks(parentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 {
+		t.Fatalf("expected 1 subtask, got %d", len(subs))
+	}
+	if subs[0].ProjectPath == nil || *subs[0].ProjectPath != projDir {
+		t.Errorf("expected subtask to inherit parent project %q, got %v", projDir, subs[0].ProjectPath)
+	}
+}
+
+func TestRunTodoAdd_WithMissingParent_Error(t *testing.T) {
+	todoTestEnv(t)
+	todoProjectName = ""
+	todoScheduleFlag = "later"
+	todoEveryFlag = ""
+	todoParentFlag = "42"
+	defer func() { todoParentFlag = "" }()
+
+	err := runTodoAdd(nil, []string{"orphan"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestRunTodoAdd_ParentBySlug(t *testing.T) {
+	todoTestEnv(t)
+	todoProjectName = ""
+	todoScheduleFlag = "later"
+	todoEveryFlag = ""
+	todoParentFlag = "ship-rel"
+	defer func() { todoParentFlag = "" }()
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	parentID, _ := ts.Add("Ship release", "", todo.PrioHigh, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	db.Close()
+
+	captureStdout(t, func() {
+		if err := runTodoAdd(nil, []string{"write notes"}); err != nil {
+			t.Fatalf("runTodoAdd: %v", err)
+		}
+	})
+
+	db, err = store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	subs, err := todo.NewStore(db.Conn()).Subtasks(parentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || subs[0].Title != "write notes" {
+		t.Errorf("expected 'write notes' as a subtask of #%d, got %+v", parentID, subs)
+	}
+}
+
+func TestRunTodoDone_WarnsOnOpenSubtasks(t *testing.T) {
+	todoTestEnv(t)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	parentID, _ := ts.Add("parent", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	childID, _ := ts.Add("child", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	if err := ts.SetParent(childID, &parentID); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runTodoDone(nil, []string{strconv.Itoa(parentID)}); err != nil {
+			t.Fatalf("runTodoDone: %v", err)
+		}
+	})
+	if !strings.Contains(out, "1 open subtask") {
+		t.Errorf("expected open subtask warning in output:\n%s", out)
+	}
+}
+
+func TestRunTodoSubtasks_ListsChildren(t *testing.T) {
+	todoTestEnv(t)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	parentID, _ := ts.Add("parent", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	for _, title := range []string{"step one", "step two"} {
+		id, _ := ts.Add(title, "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+		if err := ts.SetParent(id, &parentID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+
+	out := captureStdout(t, func() {
+		if err := runTodoSubtasks(nil, []string{strconv.Itoa(parentID)}); err != nil {
+			t.Fatalf("runTodoSubtasks: %v", err)
+		}
+	})
+	for _, want := range []string{"step one", "step two", "0/2 subtasks done"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunTodoBlock_ExcludedFromNextUntilDone(t *testing.T) {
+	todoTestEnv(t)
+	defer func() { todoBlockOnFlag = nil }()
+
+	origDir, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(origDir)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	first, _ := ts.Add("write spec", "", todo.PrioLow, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	second, _ := ts.Add("implement spec", "", todo.PrioCrit, nil, nil, nil, todo.ScheduleToday, todo.RecurrenceNone)
+	db.Close()
+
+	todoBlockOnFlag = []string{strconv.Itoa(first)}
+	if err := runTodoBlock(nil, []string{strconv.Itoa(second)}); err != nil {
+		t.Fatalf("runTodoBlock: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTodoNext(nil, nil); err != nil {
+			t.Fatalf("runTodoNext: %v", err)
+		}
+	})
+	if strings.Contains(out, "implement spec") || !strings.Contains(out, "write spec") {
+		t.Errorf("expected blocked task excluded from next:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTodoDone(nil, []string{strconv.Itoa(first)}); err != nil {
+			t.Fatalf("runTodoDone: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Unblocked") {
+		t.Errorf("expected 'Unblocked' in output:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTodoNext(nil, nil); err != nil {
+			t.Fatalf("runTodoNext: %v", err)
+		}
+	})
+	if !strings.Contains(out, "implement spec") {
+		t.Errorf("expected unblocked task in next:\n%s", out)
+	}
+}
+
+func TestRunTodoBlock_Cycle_Error(t *testing.T) {
+	todoTestEnv(t)
+	defer func() { todoBlockOnFlag = nil }()
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	a, _ := ts.Add("a", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	b, _ := ts.Add("b", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	if err := ts.AddDependency(a, b); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	todoBlockOnFlag = []string{strconv.Itoa(a)}
+	err = runTodoBlock(nil, []string{strconv.Itoa(b)})
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestRunTodoBlockUnblock_OnBySlug(t *testing.T) {
+	todoTestEnv(t)
+	defer func() { todoBlockOnFlag, todoUnblockOnFlag = nil, "" }()
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	spec, _ := ts.Add("Write spec", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	review, _ := ts.Add("Review design", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	impl, _ := ts.Add("Implement spec", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	db.Close()
+
+	todoBlockOnFlag = []string{"write-spec", "review"}
+	captureStdout(t, func() {
+		if err := runTodoBlock(nil, []string{strconv.Itoa(impl)}); err != nil {
+			t.Fatalf("runTodoBlock: %v", err)
+		}
+	})
+	blockedBy := func() []int {
+		db, err := store.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		got, err := todo.NewStore(db.Conn()).Get(impl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got.BlockedBy
+	}
+	if got := blockedBy(); !slices.Equal(got, []int{spec, review}) {
+		t.Fatalf("BlockedBy = %v, want [%d %d]", got, spec, review)
+	}
+
+	todoUnblockOnFlag = "review-des"
+	captureStdout(t, func() {
+		if err := runTodoUnblock(nil, []string{strconv.Itoa(impl)}); err != nil {
+			t.Fatalf("runTodoUnblock: %v", err)
+		}
+	})
+	if got := blockedBy(); !slices.Equal(got, []int{spec}) {
+		t.Errorf("BlockedBy after unblock = %v, want [%d]", got, spec)
+	}
+
+	todoUnblockOnFlag = "no-such-todo"
+	if err := runTodoUnblock(nil, []string{strconv.Itoa(impl)}); err == nil {
+		t.Error("expected an error for an --on that matches nothing")
+	}
+}
+
+func TestPrintTodoList_FlagsBlocked(t *testing.T) {
+	todoTestEnv(t)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ts := todo.NewStore(db.Conn())
+	a, _ := ts.Add("a", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	b, _ := ts.Add("b", "", todo.PrioMedium, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	if err := ts.AddDependency(b, a); err != nil {
+		t.Fatal(err)
+	}
+	todos, err := ts.List(todo.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		printTodoList(todos, ts, nil, false)
+	})
+	if !strings.Contains(out, "blocked by #"+strconv.Itoa(a)) {
+		t.Errorf("expected blocked annotation in output:\n%s", out)
+	}
+}
+
+func TestParseDueDate_WithTime(t *testing.T) {
+	now := time.Now()
+	tomorrow := now.AddDate(0, 0, 1)
+
+	tests := []struct {
+		in             string
+		wantY          int
+		wantM          time.Month
+		wantD          int
+		wantH, wantMin int
+	}{
+		{"2026-06-01 14:00", 2026, time.June, 1, 14, 0},
+		{"2026-06-01T09:15", 2026, time.June, 1, 9, 15},
+		{"today 5pm", now.Year(), now.Month(), now.Day(), 17, 0},
+		{"tomorrow 9:30am", tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 30},
+		{"today 5 PM", now.Year(), now.Month(), now.Day(), 17, 0},
+		{"5pm", now.Year(), now.Month(), now.Day(), 17, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := parseDueDate(tt.in)
+			if got == nil {
+				t.Fatalf("parseDueDate(%q) = nil", tt.in)
+			}
+			if got.Year() != tt.wantY || got.Month() != tt.wantM || got.Day() != tt.wantD ||
+				got.Hour() != tt.wantH || got.Minute() != tt.wantMin {
+				t.Errorf("parseDueDate(%q) = %v", tt.in, got)
+			}
+		})
+	}
+
+	for _, bad := range []string{"someday 5pm", "25:00", "not a date"} {
+		if got := parseDueDate(bad); got != nil {
+			t.Errorf("parseDueDate(%q) = %v, want nil", bad, got)
+		}
+	}
+
+	// Date-only values stay at midnight.
+	if got := parseDueDate("2026-06-01"); got == nil || got.Hour() != 0 || got.Minute() != 0 {
+		t.Errorf("date-only parse = %v", got)
+	}
+}
+
+// --- Context tests ---
+
+func TestRunTodoAdd_WithContext(t *testing.T) {
+	todoTestEnv(t)
+	todoPriority = "med"
+	todoDue = ""
+	todoTags = ""
+	todoProjectName = ""
+
+	origDir, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(origDir)
+
+	todoContextFlag = "@Errands"
+	defer func() { todoContextFlag = "" }()
+
+	out := captureStdout(t, func() {
+		if err := runTodoAdd(nil, []string{"buy stamps"}); err != nil {
+			t.Fatalf("runTodoAdd: %v", err)
+		}
+	})
+	if !strings.Contains(out, "@errands") {
+		t.Errorf("expected context in output, got:\n%s", out)
+	}
+	if got := getTodo(t, 1).Context; got != "errands" {
+		t.Errorf("expected context %q, got %q", "errands", got)
+	}
+}
+
+func TestRunTodoAdd_InvalidContext(t *testing.T) {
+	todoTestEnv(t)
+	todoContextFlag = "at home"
+	defer func() { todoContextFlag = "" }()
+
+	err := runTodoAdd(nil, []string{"task"})
+	if err == nil || !strings.Contains(err.Error(), "invalid context") {
+		t.Fatalf("expected invalid context error, got %v", err)
+	}
+}
+
+func TestRunTodoList_ContextFilter(t *testing.T) {
+	todoTestEnv(t)
+	todoProjectName = ""
+	todoShowAll = false
+
+	origDir, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(origDir)
+
+	seedTodos(t, 2)
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := todo.NewStore(db.Conn()).SetContext(1, "home"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	todoContextFlag = "@home"
+	defer func() { todoContextFlag = "" }()
+	out := captureStdout(t, func() {
+		if err := runTodoList(nil, nil); err != nil {
+			t.Fatalf("runTodoList: %v", err)
+		}
+	})
+	if !strings.Contains(out, "task 1") || !strings.Contains(out, "@home") {
+		t.Errorf("expected task 1 tagged @home, got:\n%s", out)
+	}
+	if strings.Contains(out, "task 2") {
+		t.Errorf("expected task 2 filtered out, got:\n%s", out)
+	}
+}
+
+func TestRunTodoEdit_BulkContext(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 3)
+	todoEditContext = "work"
+	defer func() { todoEditContext = "" }()
+
+	captureStdout(t, func() {
+		if err := runTodoEdit(nil, []string{"1-2"}); err != nil {
+			t.Fatalf("runTodoEdit: %v", err)
+		}
+	})
+	if getTodo(t, 1).Context != "work" || getTodo(t, 2).Context != "work" {
+		t.Error("expected #1 and #2 in @work")
+	}
+	if getTodo(t, 3).Context != "" {
+		t.Error("expected #3 untouched")
+	}
+
+	todoEditContext = "none"
+	captureStdout(t, func() {
+		if err := runTodoEdit(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoEdit: %v", err)
+		}
+	})
+	if got := getTodo(t, 1).Context; got != "" {
+		t.Errorf("expected context cleared, got %q", got)
+	}
+}
+
+func TestRunTodoAdd_Estimate(t *testing.T) {
+	todoTestEnv(t)
+	todoEstimateFlag = "1h30m"
+	defer func() { todoEstimateFlag = "" }()
+
+	out := captureStdout(t, func() {
+		if err := runTodoAdd(nil, []string{"write", "report"}); err != nil {
+			t.Fatalf("runTodoAdd: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Estimate:") || !strings.Contains(out, "1h30m") {
+		t.Errorf("expected estimate in output, got:\n%s", out)
+	}
+	if got := getTodo(t, 1).Estimate; got != 90*time.Minute {
+		t.Errorf("Estimate = %v, want 1h30m", got)
+	}
+
+	todoEstimateFlag = "soonish"
+	if err := runTodoAdd(nil, []string{"x"}); err == nil || !strings.Contains(err.Error(), "invalid estimate") {
+		t.Errorf("expected invalid estimate error, got %v", err)
+	}
+}
+
+func TestRunTodoEdit_BulkEstimate(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 3)
+	todoEditEstimate = "30m"
+	defer func() { todoEditEstimate = "" }()
+
+	captureStdout(t, func() {
+		if err := runTodoEdit(nil, []string{"1-2"}); err != nil {
+			t.Fatalf("runTodoEdit: %v", err)
+		}
+	})
+	if getTodo(t, 1).Estimate != 30*time.Minute || getTodo(t, 2).Estimate != 30*time.Minute {
+		t.Error("expected #1 and #2 estimated at 30m")
+	}
+
+	todoEditEstimate = "none"
+	captureStdout(t, func() {
+		if err := runTodoEdit(nil, []string{"1"}); err != nil {
+			t.Fatalf("runTodoEdit: %v", err)
+		}
+	})
+	if got := getTodo(t, 1).Estimate; got != 0 {
+		t.Errorf("expected estimate cleared, got %v", got)
+	}
+}
+
+func TestRunTodoNext_Budget(t *testing.T) {
+	todoTestEnv(t)
+	t.Chdir(t.TempDir())
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := todo.NewStore(db.Conn())
+	big, _ := ts.Add("big task", "", todo.PrioCrit, nil, nil, nil, todo.ScheduleToday, todo.RecurrenceNone)
+	small, _ := ts.Add("small task", "", todo.PrioHigh, nil, nil, nil, todo.ScheduleToday, todo.RecurrenceNone)
+	quick, _ := ts.Add("quick task", "", todo.PrioLow, nil, nil, nil, todo.ScheduleLater, todo.RecurrenceNone)
+	ts.Add("unknown task", "", todo.PrioCrit, nil, nil, nil, todo.ScheduleToday, todo.RecurrenceNone)
+	ts.SetEstimate(big, 3*time.Hour)
+	ts.SetEstimate(small, time.Hour)
+	ts.SetEstimate(quick, 45*time.Minute)
+	db.Close()
+
+	todoBudgetFlag = "2h"
+	defer func() { todoBudgetFlag = "" }()
+	out := captureStdout(t, func() {
+		if err := runTodoNext(nil, nil); err != nil {
+			t.Fatalf("runTodoNext: %v", err)
+		}
+	})
+
+	if strings.Contains(out, "big task") || strings.Contains(out, "unknown task") {
+		t.Errorf("expected over-budget and unestimated tasks left out, got:\n%s", out)
+	}
+	if !strings.Contains(out, "small task") || !strings.Contains(out, "quick task") {
+		t.Errorf("expected small and quick tasks planned, got:\n%s", out)
+	}
+	if !strings.Contains(out, "1h45m planned") || !strings.Contains(out, "1 task(s) without an estimate") {
+		t.Errorf("expected plan summary, got:\n%s", out)
+	}
+	if strings.Index(out, "small task") > strings.Index(out, "quick task") {
+		t.Error("expected plan in urgency order")
+	}
+
+	if err := runTodoNext(nil, []string{"2"}); err == nil {
+		t.Error("expected error combining count and --budget")
+	}
+}
+
+func TestRunTodoPin_ListsPinnedFirst(t *testing.T) {
+	todoTestEnv(t)
+	t.Chdir(t.TempDir())
+	seedTodos(t, 3)
+
+	captureStdout(t, func() {
+		if err := runTodoPin(nil, []string{"2-3"}); err != nil {
+			t.Fatalf("runTodoPin: %v", err)
+		}
+	})
+	if !getTodo(t, 2).Pinned || !getTodo(t, 3).Pinned || getTodo(t, 1).Pinned {
+		t.Fatal("expected #2 and #3 pinned")
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTodoList(nil, nil); err != nil {
+			t.Fatalf("runTodoList: %v", err)
+		}
+	})
+	if strings.Index(out, "task 1") < strings.Index(out, "task 3") {
+		t.Errorf("expected pinned todos above task 1, got:\n%s", out)
+	}
+	if !strings.Contains(out, "📌") {
+		t.Errorf("expected pin marker, got:\n%s", out)
+	}
+
+	captureStdout(t, func() {
+		if err := runTodoUnpin(nil, []string{"2"}); err != nil {
+			t.Fatalf("runTodoUnpin: %v", err)
+		}
+	})
+	if getTodo(t, 2).Pinned {
+		t.Error("expected #2 unpinned")
+	}
+
+	if err := runTodoPin(nil, []string{"99"}); err == nil {
+		t.Error("expected error pinning a missing todo")
+	}
+}
+
+func TestRunTodoAdd_ProjectDefaults(t *testing.T) {
+	todoTestEnv(t)
+	projDir := registerProject(t, "api")
+	t.Chdir(projDir)
+
+	db, err := store.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := proj.NewStore(db.Conn())
+	ps.SetSetting("api", "todo_tags", "api")
+	ps.SetSetting("api", "todo_priority", "high")
+	ps.SetSetting("api", "todo_schedule", "soon")
+	db.Close()
+
+	todoPriority, todoScheduleFlag, todoTags, todoProjectName = "", "", "", ""
+	defer func() { todoPriority, todoScheduleFlag, todoTags = "med", "later", "" }()
+
+	captureStdout(t, func() {
+		if err := runTodoAdd(nil, []string{"defaults apply"}); err != nil {
+			t.Fatalf("runTodoAdd: %v", err)
+		}
+	})
+	got := getTodo(t, 1)
+	if got.Priority != todo.PrioHigh || got.Schedule != todo.ScheduleSoon || strings.Join(got.Tags, ",") != "api" {
+		t.Errorf("got priority %d, schedule %q, tags %v; want project defaults", got.Priority, got.Schedule, got.Tags)
+	}
+
+	// Flags win over defaults; tags merge.
+	todoPriority, todoScheduleFlag, todoTags = "low", "today", "docs,API"
+	captureStdout(t, func() {
+		if err := runTodoAdd(nil, []string{"flags win"}); err != nil {
+			t.Fatalf("runTodoAdd: %v", err)
+		}
+	})
+	got = getTodo(t, 2)
+	if got.Priority != todo.PrioLow || got.Schedule != todo.ScheduleToday || strings.Join(got.Tags, ",") != "api,docs" {
+		t.Errorf("got priority %d, schedule %q, tags %v; want flags merged over defaults", got.Priority, got.Schedule, got.Tags)
+	}
+}
+
+func TestRunTodoAdd_ProjectFileDefaultTags(t *testing.T) {
+	todoTestEnv(t)
+	projDir := registerProject(t, "web")
+	t.Chdir(projDir)
+	if err := os.WriteFile(filepath.Join(projDir, ".mine.toml"), []byte("[todo]\ndefault_tags = [\"frontend\"]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	todoPriority, todoScheduleFlag, todoTags, todoProjectName = "", "", "ui", ""
+	defer func() { todoPriority, todoScheduleFlag, todoTags = "med", "later", "" }()
+
+	captureStdout(t, func() {
+		if err := runTodoAdd(nil, []string{"tagged by project file"}); err != nil {
+			t.Fatalf("runTodoAdd: %v", err)
+		}
+	})
+	if got := getTodo(t, 1); strings.Join(got.Tags, ",") != "frontend,ui" {
+		t.Errorf("tags = %v, want .mine.toml default_tags merged with --tags", got.Tags)
+	}
+}
+
+func TestRunTodoStats_Chart(t *testing.T) {
+	todoTestEnv(t)
+	todoStatsProjectFlag = ""
+	todoStatsChart = true
+	defer func() { todoStatsChart = false }()
+
+	now := time.Now()
+	statsInsertCompleted(t, "done today", now.AddDate(0, 0, -3), now)
+	seedTodos(t, 2)
+
+	out := captureStdout(t, func() {
+		if err := runTodoStats(nil, nil); err != nil {
+			t.Fatalf("runTodoStats: %v", err)
+		}
+	})
+	for _, want := range []string{"Completions · last 30 days", "1 total", "Open todos", "█", now.Format("Jan 2")} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in chart output:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunTodoStats_ExportCSV(t *testing.T) {
+	todoTestEnv(t)
+	todoStatsProjectFlag = ""
+	todoStatsExport, todoStatsDays = "csv", 3
+	defer func() { todoStatsExport, todoStatsDays = "", 30 }()
+
+	now := time.Now()
+	statsInsertCompleted(t, "done today", now.Add(-36*time.Hour), now)
+	seedTodos(t, 1)
+
+	out := captureStdout(t, func() {
+		if err := runTodoStats(nil, nil); err != nil {
+			t.Fatalf("runTodoStats: %v", err)
+		}
+	})
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v\n%s", err, out)
+	}
+	// Header, 3 days, 1 project row (global).
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, want 5:\n%s", len(rows), out)
+	}
+	today := rows[3]
+	if today[0] != "day" || today[1] != now.Format("2006-01-02") || today[3] != "1" || today[4] != "1" || today[5] != "1.50" {
+		t.Errorf("today row = %v", today)
+	}
+	if rows[4][0] != "project" || rows[4][2] != "(global)" || rows[4][3] != "1" || rows[4][4] != "1" {
+		t.Errorf("project row = %v", rows[4])
+	}
+}
+
+func TestRunTodoStats_ExportUnknownFormat(t *testing.T) {
+	todoTestEnv(t)
+	todoStatsExport = "xlsx"
+	defer func() { todoStatsExport = "" }()
+
+	err := runTodoStats(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), `unsupported export format "xlsx"`) {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+}
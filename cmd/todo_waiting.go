@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/hook"
+	"github.com/rnwolfe/mine/internal/store"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	todoCmd.AddCommand(todoWaitingCmd)
+}
+
+var todoWaitingCmd = &cobra.Command{
+	Use:   "waiting [person]",
+	Short: "Show todos you're waiting on someone else for",
+	Long: `List open todos delegated with --waiting-on, grouped by person, oldest
+first so the ones worth chasing stand out. Pass a person to see only theirs.
+
+Delegated todos stay in 'mine todo' but are left out of 'mine todo next'.
+
+  mine todo waiting
+  mine todo waiting alice`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: hook.Wrap("todo.waiting", runTodoWaiting),
+}
+
+func runTodoWaiting(_ *cobra.Command, args []string) error {
+	person := ""
+	if len(args) == 1 {
+		person = args[0]
+	}
+
+	db, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ts := todo.NewStore(db.Conn())
+	todos, err := ts.Waiting(person)
+	if err != nil {
+		return fmt.Errorf("listing waiting todos: %w", err)
+	}
+
+	fmt.Println()
+	if len(todos) == 0 {
+		if person != "" {
+			fmt.Println(ui.Muted.Render(fmt.Sprintf("  Not waiting on %s for anything.", strings.TrimPrefix(person, "@"))))
+		} else {
+			fmt.Println(ui.Muted.Render("  Not waiting on anyone."))
+		}
+		fmt.Printf("  Delegate one: %s\n", ui.Accent.Render("mine todo edit <id> --waiting-on alice"))
+		fmt.Println()
+		return nil
+	}
+
+	now := time.Now()
+	current := ""
+	for _, t := range todos {
+		if !strings.EqualFold(t.WaitingOn, current) {
+			if current != "" {
+				fmt.Println()
+			}
+			current = t.WaitingOn
+			fmt.Println(ui.Title.Render("  ⏳ " + current))
+		}
+		id := lipgloss.NewStyle().Width(todo.ColWidthID).Render(ui.Muted.Render(fmt.Sprintf("#%d", t.ID)))
+		line := fmt.Sprintf("    %s %s  %s", id, todo.FormatPriorityIcon(t.Priority), t.Title)
+		if age := waitingAgeDays(t, now); age > 0 {
+			line += ui.Muted.Render(fmt.Sprintf(" (%dd)", age))
+		}
+		if t.ProjectPath != nil {
+			line += ui.Muted.Render(" @" + filepath.Base(*t.ProjectPath))
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	fmt.Println(ui.Muted.Render(fmt.Sprintf("  %d todo(s) waiting on others", len(todos))))
+	fmt.Println()
+	return nil
+}
+
+// waitingAgeDays is how many whole days a todo has existed.
+func waitingAgeDays(t todo.Todo, now time.Time) int {
+	if t.CreatedAt.IsZero() {
+		return 0
+	}
+	return int(now.Sub(t.CreatedAt).Hours() / 24)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunTodoAdd_WaitingOn(t *testing.T) {
+	todoTestEnv(t)
+	todoWaitingOnFlag = "@alice"
+	defer func() { todoWaitingOnFlag = "" }()
+
+	out := captureStdout(t, func() {
+		if err := runTodoAdd(nil, []string{"review", "design"}); err != nil {
+			t.Fatalf("runTodoAdd: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Waiting on:") || !strings.Contains(out, "alice") {
+		t.Errorf("expected waiting-on in output, got:\n%s", out)
+	}
+	if got := getTodo(t, 1).WaitingOn; got != "alice" {
+		t.Errorf("WaitingOn = %q, want alice", got)
+	}
+
+	todoWaitingOnFlag = "alice,bob"
+	if err := runTodoAdd(nil, []string{"x"}); err == nil || !strings.Contains(err.Error(), "invalid person") {
+		t.Errorf("expected invalid person error, got %v", err)
+	}
+}
+
+func TestRunTodoWaiting_GroupsAndNextSkips(t *testing.T) {
+	todoTestEnv(t)
+	seedTodos(t, 3)
+	todoEditWaitingOn = "bob"
+	defer func() { todoEditWaitingOn = "" }()
+	captureStdout(t, func() {
+		if err := runTodoEdit(nil, []string{"1", "2"}); err != nil {
+			t.Fatalf("runTodoEdit: %v", err)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := runTodoWaiting(nil, nil); err != nil {
+			t.Fatalf("runTodoWaiting: %v", err)
+		}
+	})
+	for _, want := range []string{"bob", "task 1", "task 2", "2 todo(s) waiting"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "task 3") {
+		t.Errorf("task 3 isn't delegated:\n%s", out)
+	}
+
+	out = captureStdout(t, func() { runTodoNext(nil, []string{"3"}) })
+	if strings.Contains(out, "task 1") || strings.Contains(out, "task 2") || !strings.Contains(out, "task 3") {
+		t.Errorf("expected next to skip delegated todos, got:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTodoWaiting(nil, []string{"carol"}); err != nil {
+			t.Fatalf("runTodoWaiting: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Not waiting on carol") {
+		t.Errorf("expected empty state, got:\n%s", out)
+	}
+}
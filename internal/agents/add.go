@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 )
 
 // nameRe is the pattern for valid content names:
@@ -59,11 +60,15 @@ func ValidateName(name string) error {
 //	├── references/
 //	└── assets/
 //
+// agents, when given, limits which agents the skill is linked to.
 // Returns an error if the skill already exists.
-func AddSkill(name string) (*AddSkillResult, error) {
+func AddSkill(name string, agents ...string) (*AddSkillResult, error) {
 	if err := ValidateName(name); err != nil {
 		return nil, err
 	}
+	if err := validateAgentNames(agents); err != nil {
+		return nil, err
+	}
 
 	skillDir := filepath.Join(Dir(), "skills", name)
 	if err := checkNotExists(skillDir); err != nil {
@@ -81,7 +86,7 @@ func AddSkill(name string) (*AddSkillResult, error) {
 
 	// Write SKILL.md template.
 	skillMDPath := filepath.Join(skillDir, "SKILL.md")
-	content := buildSkillMD(name)
+	content := buildSkillMD(name, agents)
 	if err := os.WriteFile(skillMDPath, []byte(content), 0o644); err != nil {
 		return nil, fmt.Errorf("creating SKILL.md: %w", err)
 	}
@@ -98,11 +103,15 @@ func AddSkill(name string) (*AddSkillResult, error) {
 //
 //	commands/<name>.md
 //
+// agents, when given, limits which agents the command is linked to.
 // Returns an error if the command file already exists.
-func AddCommand(name string) (*AddCommandResult, error) {
+func AddCommand(name string, agents ...string) (*AddCommandResult, error) {
 	if err := ValidateName(name); err != nil {
 		return nil, err
 	}
+	if err := validateAgentNames(agents); err != nil {
+		return nil, err
+	}
 
 	cmdFile := filepath.Join(Dir(), "commands", name+".md")
 	if err := checkNotExists(cmdFile); err != nil {
@@ -113,7 +122,7 @@ func AddCommand(name string) (*AddCommandResult, error) {
 		return nil, fmt.Errorf("creating commands directory: %w", err)
 	}
 
-	content := buildCommandMD(name)
+	content := buildCommandMD(name, agents)
 	if err := os.WriteFile(cmdFile, []byte(content), 0o644); err != nil {
 		return nil, fmt.Errorf("creating command file: %w", err)
 	}
@@ -191,23 +200,49 @@ func checkNotExists(path string) error {
 	return nil
 }
 
-// buildSkillMD generates the SKILL.md template content for the given name.
-func buildSkillMD(name string) string {
+// validateAgentNames returns an error naming the first unsupported agent.
+func validateAgentNames(names []string) error {
+	known := map[string]bool{}
+	var all []string
+	for _, spec := range buildRegistry("") {
+		known[spec.Name] = true
+		all = append(all, spec.Name)
+	}
+	for _, n := range names {
+		if !known[n] {
+			return fmt.Errorf("unknown agent %q — supported agents: %s", n, strings.Join(all, ", "))
+		}
+	}
+	return nil
+}
+
+// buildSkillMD generates the SKILL.md template content for the given name,
+// limited to agents when any are given.
+func buildSkillMD(name string, agents []string) string {
+	agentsLine := ""
+	if len(agents) > 0 {
+		agentsLine = "agents: [" + strings.Join(agents, ", ") + "]\n"
+	}
 	return fmt.Sprintf(`---
 name: %s
 description: >
   TODO: Describe what this skill does and when to use it.
----
+%s---
 
 ## Instructions
 
 TODO: Add step-by-step instructions for the agent.
-`, name)
+`, name, agentsLine)
 }
 
-// buildCommandMD generates the command markdown template for the given name.
-func buildCommandMD(name string) string {
-	return fmt.Sprintf(`# %s
+// buildCommandMD generates the command markdown template for the given name,
+// with front matter limiting it to agents when any are given.
+func buildCommandMD(name string, agents []string) string {
+	frontmatter := ""
+	if len(agents) > 0 {
+		frontmatter = "---\nagents: [" + strings.Join(agents, ", ") + "]\n---\n\n"
+	}
+	return frontmatter + fmt.Sprintf(`# %s
 
 TODO: Describe what this command does and when to use it.
 
@@ -15,18 +15,30 @@ type AdoptOptions struct {
 	Agent  string // filter to a single agent name; empty means all detected agents
 	DryRun bool   // show what would be imported without making changes
 	Copy   bool   // import files into store but don't replace originals with symlinks
+	// Projects maps registered project names to their roots, whose
+	// CLAUDE.md, AGENTS.md, and .cursorrules are adopted into
+	// projects/<name>/AGENTS.md.
+	Projects map[string]string
+	// Resolve, when set, is asked to settle each conflict instead of
+	// leaving it for the user to fix by hand.
+	Resolve ConflictResolver
 }
 
 // AdoptItem describes a single file or directory that can be adopted.
 type AdoptItem struct {
 	Agent      string // which agent this came from
+	Project    string // for project instructions, the project they came from
 	SourcePath string // absolute path of the file in the agent's config dir
 	StoreRel   string // relative path within the canonical store
 	StoreAbs   string // absolute path within the canonical store
-	Kind       string // "instruction", "skills", "commands", "settings", "mcp", "agents", "rules"
+	Kind       string // "instruction", "skills", "commands", "settings", "mcp", "agents", "rules", "project"
 	Conflict   bool   // the store already has different content for this item
-	Status     string // "imported", "skipped", "conflict", "already-managed"
+	Status     string // "imported", "merged", "skipped", "conflict", "already-managed"
 	Err        error  // non-nil if the operation failed
+
+	// DuplicateOf names the project whose identical instructions this
+	// project's share in the store, instead of a second copy.
+	DuplicateOf string
 }
 
 // Adopt scans detected agents for existing configs, imports them into the
@@ -36,9 +48,12 @@ type AdoptItem struct {
 //  1. Detect which agents have content to adopt
 //  2. Scan each agent's config directory for adoptable items
 //  3. Copy items into the canonical store (first agent's instruction file wins;
-//     subsequent agents with differing content are flagged as conflicts)
-//  4. Replace original files with symlinks to the store (unless --copy)
-//  5. Auto-commit the imported content to the store's git history
+//     subsequent agents with differing content are flagged as conflicts, or
+//     settled by opts.Resolve when set)
+//  4. Do the same for instruction files in opts.Projects, into each project's
+//     area of the store
+//  5. Replace original files with symlinks to the store (unless --copy)
+//  6. Auto-commit the imported content to the store's git history
 func Adopt(opts AdoptOptions) ([]AdoptItem, error) {
 	if !IsInitialized() {
 		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
@@ -78,6 +93,7 @@ func Adopt(opts AdoptOptions) ([]AdoptItem, error) {
 		items := scanAdoptableItems(storeDir, spec)
 		allItems = append(allItems, items...)
 	}
+	allItems = append(allItems, scanProjectItems(storeDir, opts.Projects, opts.Agent)...)
 
 	if opts.DryRun {
 		return allItems, nil
@@ -85,8 +101,23 @@ func Adopt(opts AdoptOptions) ([]AdoptItem, error) {
 
 	// Import items into the canonical store.
 	var adoptedAgents []string
+	var adoptedProjects []string
+	adopted := func(item AdoptItem) {
+		if item.Project != "" {
+			adoptedProjects = appendUniq(adoptedProjects, item.Project)
+		} else {
+			adoptedAgents = appendUniq(adoptedAgents, item.Agent)
+		}
+	}
 	for i := range allItems {
 		item := &allItems[i]
+		if item.Conflict && opts.Resolve != nil {
+			*item = resolveConflict(*item, opts.Resolve)
+			if item.Status == "merged" {
+				adopted(*item)
+			}
+			continue
+		}
 		if item.Conflict || item.Status == "already-managed" {
 			if item.Conflict {
 				item.Status = "conflict"
@@ -96,7 +127,7 @@ func Adopt(opts AdoptOptions) ([]AdoptItem, error) {
 
 		*item = performAdopt(*item)
 		if item.Status == "imported" {
-			adoptedAgents = appendUniq(adoptedAgents, item.Agent)
+			adopted(*item)
 		}
 	}
 
@@ -109,10 +140,17 @@ func Adopt(opts AdoptOptions) ([]AdoptItem, error) {
 			}
 		}
 	}
+	if !opts.Copy {
+		linkAdoptedProjects(allItems, opts.Projects, adoptedProjects)
+	}
 
 	// Auto-commit the imported content.
-	if len(adoptedAgents) > 0 {
-		commitMsg := "adopt: imported configs from " + strings.Join(adoptedAgents, ", ")
+	if len(adoptedAgents) > 0 || len(adoptedProjects) > 0 {
+		from := adoptedAgents
+		for _, name := range adoptedProjects {
+			from = append(from, "project "+name)
+		}
+		commitMsg := "adopt: imported configs from " + strings.Join(from, ", ")
 		if _, err := gitutil.RunCmd(storeDir, "add", "."); err != nil {
 			fmt.Fprintf(os.Stderr, "warning: failed to stage adopted configs: %v\n", err)
 		} else {
@@ -163,8 +201,8 @@ func scanAdoptableItems(storeDir string, spec linkSpec) []AdoptItem {
 		})
 	}
 
-	// 3. Commands directory (Claude only).
-	if spec.CommandsDir != "" && dirNonEmpty(spec.CommandsDir) && !isAlreadyManagedByStore(spec.CommandsDir, storeDir) {
+	// 3. Commands directory, for agents that keep commands as markdown.
+	if spec.CommandsDir != "" && spec.CommandsFormat == "" && dirNonEmpty(spec.CommandsDir) && !isAlreadyManagedByStore(spec.CommandsDir, storeDir) {
 		items = append(items, AdoptItem{
 			Agent:      spec.Name,
 			SourcePath: spec.CommandsDir,
@@ -194,8 +232,9 @@ func scanAdoptableItems(storeDir string, spec linkSpec) []AdoptItem {
 		}
 	}
 
-	// 5. MCP config → mcp/.mcp.json.
-	if spec.MCPConfigPath != "" && fileExists(spec.MCPConfigPath) && !isAlreadyManagedByStore(spec.MCPConfigPath, storeDir) {
+	// 5. MCP config → mcp/.mcp.json. Agents that keep MCP servers in another
+	// format (settings JSON, TOML) are rendered from the store instead.
+	if spec.MCPConfigPath != "" && spec.MCPFormat == "" && fileExists(spec.MCPConfigPath) && !isAlreadyManagedByStore(spec.MCPConfigPath, storeDir) {
 		storeRel := "mcp/.mcp.json"
 		storeAbs := filepath.Join(storeDir, storeRel)
 		item := AdoptItem{
@@ -247,8 +286,18 @@ func scanAdoptableItems(storeDir string, spec linkSpec) []AdoptItem {
 // For directory kinds (skills, commands, agents, rules) it merges content,
 // skipping files that already exist in the store.
 func performAdopt(item AdoptItem) AdoptItem {
+	if item.DuplicateOf != "" {
+		if err := adoptProjectDuplicate(item); err != nil {
+			item.Status = "skipped"
+			item.Err = fmt.Errorf("sharing %s's instructions: %w", item.DuplicateOf, err)
+			return item
+		}
+		item.Status = "imported"
+		return item
+	}
+
 	switch item.Kind {
-	case "instruction", "settings", "mcp":
+	case "instruction", "settings", "mcp", "project":
 		if err := os.MkdirAll(filepath.Dir(item.StoreAbs), 0o755); err != nil {
 			item.Status = "skipped"
 			item.Err = fmt.Errorf("creating store directory: %w", err)
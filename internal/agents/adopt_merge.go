@@ -0,0 +1,182 @@
+package agents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Ways to resolve an adopt conflict between the store and an agent's file.
+const (
+	ResolveOurs   = "ours"   // keep the store's content
+	ResolveTheirs = "theirs" // take the agent's content
+	ResolveBoth   = "both"   // store content followed by the agent's; MCP servers are combined
+)
+
+// ConflictResolver decides what the store should hold for a conflicting
+// adopt item. It returns the content to write, or ok false to leave the
+// conflict unresolved.
+type ConflictResolver func(item AdoptItem) (content []byte, ok bool, err error)
+
+// conflictMarker starts a block git merge-file leaves unresolved.
+const conflictMarker = "<<<<<<< "
+
+// ConflictDiff returns unified-diff lines from the store's content to the
+// agent's for a conflicting item.
+func ConflictDiff(item AdoptItem) ([]string, error) {
+	return diffPaths(item.StoreAbs, item.SourcePath)
+}
+
+// ResolveContent returns the store content strategy produces for item.
+func ResolveContent(item AdoptItem, strategy string) ([]byte, error) {
+	ours, err := os.ReadFile(item.StoreAbs)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", item.StoreRel, err)
+	}
+	theirs, err := os.ReadFile(item.SourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", item.SourcePath, err)
+	}
+
+	switch strategy {
+	case ResolveOurs:
+		return ours, nil
+	case ResolveTheirs:
+		return theirs, nil
+	case ResolveBoth:
+		if item.Kind == "mcp" {
+			return combineMCPConfigs(ours, theirs)
+		}
+		out := append(bytes.TrimRight(ours, "\n"), "\n\n"...)
+		return append(out, theirs...), nil
+	default:
+		return nil, fmt.Errorf("unknown resolution %q — use %s, %s, or %s", strategy, ResolveOurs, ResolveTheirs, ResolveBoth)
+	}
+}
+
+// MergeMarkers returns the store's and agent's content merged line by line,
+// with git-style conflict markers around the parts that differ, ready to be
+// finished in an editor. The two files share no history, so the merge base
+// is empty and only lines common to both are merged cleanly.
+func MergeMarkers(item AdoptItem) ([]byte, error) {
+	base, err := os.CreateTemp("", "mine-adopt-base-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating merge base: %w", err)
+	}
+	base.Close()
+	defer os.Remove(base.Name())
+
+	cmd := exec.Command("git", "merge-file", "-p",
+		"-L", "store ("+item.StoreRel+")", "-L", "base", "-L", item.Agent+" ("+filepath.Base(item.SourcePath)+")",
+		item.StoreAbs, base.Name(), item.SourcePath)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// merge-file exits with the number of conflicts; only a negative
+	// status (shown as 255) or a failure to start is an error.
+	runErr := cmd.Run()
+	var exitErr *exec.ExitError
+	if runErr != nil && (!errors.As(runErr, &exitErr) || exitErr.ExitCode() > 127) {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = runErr.Error()
+		}
+		return nil, fmt.Errorf("git merge-file: %s", msg)
+	}
+	return stdout.Bytes(), nil
+}
+
+// HasConflictMarkers reports whether content still holds an unresolved
+// conflict block.
+func HasConflictMarkers(content []byte) bool {
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, conflictMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveConflict asks resolve what the store should hold for a conflicting
+// item and writes it, updating the item's status.
+func resolveConflict(item AdoptItem, resolve ConflictResolver) AdoptItem {
+	// An earlier resolution may have made the store match this agent.
+	if !fileConflict(item.SourcePath, item.StoreAbs) {
+		item.Conflict = false
+		item.Status = "already-managed"
+		return item
+	}
+
+	content, ok, err := resolve(item)
+	switch {
+	case err != nil:
+		item.Status = "conflict"
+		item.Err = err
+		return item
+	case !ok:
+		item.Status = "conflict"
+		return item
+	}
+
+	if item.Kind == "mcp" && !json.Valid(content) {
+		item.Status = "conflict"
+		item.Err = fmt.Errorf("merged %s is not valid JSON", item.StoreRel)
+		return item
+	}
+	if HasConflictMarkers(content) {
+		item.Status = "conflict"
+		item.Err = fmt.Errorf("merged %s still has conflict markers", item.StoreRel)
+		return item
+	}
+	if err := os.WriteFile(item.StoreAbs, content, 0o644); err != nil {
+		item.Status = "conflict"
+		item.Err = fmt.Errorf("writing %s: %w", item.StoreRel, err)
+		return item
+	}
+	item.Conflict = false
+	item.Status = "merged"
+	return item
+}
+
+// combineMCPConfigs adds the agent's MCP servers to the store's, keeping the
+// store's definition when both name the same server.
+func combineMCPConfigs(ours, theirs []byte) ([]byte, error) {
+	var store, agent map[string]json.RawMessage
+	if err := json.Unmarshal(ours, &store); err != nil {
+		return nil, fmt.Errorf("parsing store MCP config: %w", err)
+	}
+	if err := json.Unmarshal(theirs, &agent); err != nil {
+		return nil, fmt.Errorf("parsing agent MCP config: %w", err)
+	}
+
+	servers := map[string]json.RawMessage{}
+	for _, raw := range []json.RawMessage{agent["mcpServers"], store["mcpServers"]} {
+		if raw == nil {
+			continue
+		}
+		var s map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("parsing mcpServers: %w", err)
+		}
+		for name, def := range s {
+			servers[name] = def
+		}
+	}
+	encoded, err := json.Marshal(servers)
+	if err != nil {
+		return nil, err
+	}
+	store["mcpServers"] = encoded
+
+	out, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
@@ -0,0 +1,163 @@
+package agents
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupAdoptConflict gives the store and claude different instructions.
+func setupAdoptConflict(t *testing.T) (storeInstr, claudeInstr string) {
+	t.Helper()
+	storeDir, homeDir := setupAdoptEnv(t)
+	storeInstr = filepath.Join(storeDir, "instructions", "AGENTS.md")
+	if err := os.WriteFile(storeInstr, []byte("# Rules\n\nUse tabs.\n\nBe brief.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	claudeDir := filepath.Join(homeDir, ".claude")
+	writeAgentFile(t, claudeDir, "CLAUDE.md", "# Rules\n\nUse spaces.\n\nBe brief.\n")
+	makeDetectedAgent(t, "claude", claudeDir)
+	return storeInstr, filepath.Join(claudeDir, "CLAUDE.md")
+}
+
+func adoptInstructionItem(t *testing.T, items []AdoptItem) AdoptItem {
+	t.Helper()
+	for _, item := range items {
+		if item.Kind == "instruction" && item.Agent == "claude" {
+			return item
+		}
+	}
+	t.Fatal("no instruction item for claude")
+	return AdoptItem{}
+}
+
+func TestAdopt_ResolveTheirsMergesAndLinks(t *testing.T) {
+	storeInstr, claudeInstr := setupAdoptConflict(t)
+
+	var seen AdoptItem
+	items, err := Adopt(AdoptOptions{Resolve: func(item AdoptItem) ([]byte, bool, error) {
+		seen = item
+		content, err := ResolveContent(item, ResolveTheirs)
+		return content, true, err
+	}})
+	if err != nil {
+		t.Fatalf("Adopt() error = %v", err)
+	}
+	if seen.Kind != "instruction" {
+		t.Fatalf("resolver saw %+v, want the instruction conflict", seen)
+	}
+	item := adoptInstructionItem(t, items)
+	if item.Status != "merged" || item.Conflict || item.Err != nil {
+		t.Errorf("item = %+v, want merged", item)
+	}
+	if data, _ := os.ReadFile(storeInstr); !strings.Contains(string(data), "Use spaces.") {
+		t.Errorf("store instructions = %q, want claude's", data)
+	}
+	if dest, _ := os.Readlink(claudeInstr); dest != storeInstr {
+		t.Errorf("CLAUDE.md → %q, want link to the store", dest)
+	}
+}
+
+func TestAdopt_ResolveDeclinedLeavesConflict(t *testing.T) {
+	storeInstr, _ := setupAdoptConflict(t)
+
+	items, err := Adopt(AdoptOptions{Resolve: func(AdoptItem) ([]byte, bool, error) { return nil, false, nil }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item := adoptInstructionItem(t, items); item.Status != "conflict" || !item.Conflict {
+		t.Errorf("item = %+v, want unresolved conflict", item)
+	}
+	if data, _ := os.ReadFile(storeInstr); !strings.Contains(string(data), "Use tabs.") {
+		t.Errorf("store changed: %q", data)
+	}
+}
+
+func TestAdopt_ResolveRejectsLeftoverMarkers(t *testing.T) {
+	storeInstr, _ := setupAdoptConflict(t)
+
+	items, err := Adopt(AdoptOptions{Resolve: func(item AdoptItem) ([]byte, bool, error) {
+		content, err := MergeMarkers(item)
+		return content, true, err
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := adoptInstructionItem(t, items)
+	if item.Status != "conflict" || item.Err == nil || !strings.Contains(item.Err.Error(), "conflict markers") {
+		t.Errorf("item = %+v, want rejected for conflict markers", item)
+	}
+	if data, _ := os.ReadFile(storeInstr); !strings.Contains(string(data), "Use tabs.") {
+		t.Errorf("store changed: %q", data)
+	}
+
+	failing := errors.New("editor crashed")
+	items, _ = Adopt(AdoptOptions{Resolve: func(AdoptItem) ([]byte, bool, error) { return nil, false, failing }})
+	if item := adoptInstructionItem(t, items); !errors.Is(item.Err, failing) {
+		t.Errorf("resolver error not reported: %+v", item)
+	}
+}
+
+func TestResolveContent(t *testing.T) {
+	setupAdoptConflict(t)
+	items, err := Adopt(AdoptOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := adoptInstructionItem(t, items)
+
+	tests := map[string]string{
+		ResolveOurs:   "# Rules\n\nUse tabs.\n\nBe brief.\n",
+		ResolveTheirs: "# Rules\n\nUse spaces.\n\nBe brief.\n",
+		ResolveBoth:   "# Rules\n\nUse tabs.\n\nBe brief.\n\n# Rules\n\nUse spaces.\n\nBe brief.\n",
+	}
+	for strategy, want := range tests {
+		got, err := ResolveContent(item, strategy)
+		if err != nil || string(got) != want {
+			t.Errorf("ResolveContent(%s) = %q, %v; want %q", strategy, got, err, want)
+		}
+	}
+	if _, err := ResolveContent(item, "mine"); err == nil {
+		t.Error("ResolveContent(unknown) error = nil")
+	}
+}
+
+func TestMergeMarkers_OnlyAroundDifferences(t *testing.T) {
+	setupAdoptConflict(t)
+	items, _ := Adopt(AdoptOptions{DryRun: true})
+	item := adoptInstructionItem(t, items)
+
+	out, err := MergeMarkers(item)
+	if err != nil {
+		t.Fatalf("MergeMarkers() error = %v", err)
+	}
+	text := string(out)
+	if !HasConflictMarkers(out) || !strings.Contains(text, "Use tabs.") || !strings.Contains(text, "Use spaces.") {
+		t.Fatalf("MergeMarkers() =\n%s", text)
+	}
+	if strings.Count(text, "Be brief.") != 1 {
+		t.Errorf("common lines should appear once:\n%s", text)
+	}
+}
+
+func TestCombineMCPConfigs(t *testing.T) {
+	out, err := combineMCPConfigs(
+		[]byte(`{"mcpServers":{"github":{"command":"store"}}}`),
+		[]byte(`{"mcpServers":{"github":{"command":"agent"},"docs":{"url":"https://x"}}}`),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		MCPServers map[string]map[string]string `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.MCPServers["github"]["command"] != "store" || got.MCPServers["docs"]["url"] != "https://x" {
+		t.Errorf("combined = %s", out)
+	}
+}
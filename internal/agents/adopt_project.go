@@ -0,0 +1,145 @@
+package agents
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// scanProjectItems returns the instruction files in each registered project
+// (name → root) that can be adopted into projects/<name>/AGENTS.md. A
+// project's first file wins; its others are conflicts unless identical.
+// Content identical to another project's is shared in the store rather than
+// stored twice. When agent is set, only that agent's file is considered.
+func scanProjectItems(storeDir string, projects map[string]string, agent string) []AdoptItem {
+	names := make([]string, 0, len(projects))
+	for name := range projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type stored struct {
+		project string
+		content []byte
+	}
+	var seen []stored
+
+	var items []AdoptItem
+	for _, name := range names {
+		root := projects[name]
+		storeRel := ProjectInstructionsPath(name)
+		storeAbs := filepath.Join(storeDir, filepath.FromSlash(storeRel))
+
+		var found []AdoptItem
+		var contents [][]byte
+		for _, t := range projectInstructionTargets {
+			if agent != "" && t.Agent != agent {
+				continue
+			}
+			path := filepath.Join(root, t.Filename)
+			if !fileExists(path) || isAlreadyManagedByStore(path, storeDir) {
+				continue
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			found = append(found, AdoptItem{
+				Agent:      t.Agent,
+				Project:    name,
+				SourcePath: path,
+				StoreRel:   storeRel,
+				StoreAbs:   storeAbs,
+				Kind:       "project",
+			})
+			contents = append(contents, content)
+		}
+		if len(found) == 0 {
+			continue
+		}
+
+		// The project's first file is what gets imported; the others only
+		// need checking against it.
+		first := found[0]
+		consistent := true
+		for i := 1; i < len(found); i++ {
+			if !bytes.Equal(contents[i], contents[0]) {
+				found[i].Conflict = true
+				consistent = false
+			}
+		}
+		switch {
+		case fileExists(storeAbs) || isSymlink(storeAbs):
+			if fileConflict(first.SourcePath, storeAbs) {
+				first.Conflict = true
+			} else {
+				first.Status = "already-managed"
+			}
+		case consistent:
+			// A project with conflicts keeps its own file, so resolving them
+			// can't change another project's.
+			for _, s := range seen {
+				if bytes.Equal(s.content, contents[0]) {
+					first.DuplicateOf = s.project
+					break
+				}
+			}
+		}
+		if consistent && !first.Conflict && first.DuplicateOf == "" {
+			seen = append(seen, stored{name, contents[0]})
+		}
+		items = append(items, first)
+		for _, item := range found[1:] {
+			if item.Conflict {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// adoptProjectDuplicate makes item's store file a link to the identical
+// instructions already adopted for item.DuplicateOf, so the content is kept
+// once.
+func adoptProjectDuplicate(item AdoptItem) error {
+	if err := os.MkdirAll(filepath.Dir(item.StoreAbs), 0o755); err != nil {
+		return err
+	}
+	return os.Symlink(filepath.Join("..", item.DuplicateOf, "AGENTS.md"), item.StoreAbs)
+}
+
+// isSymlink reports whether path is a symlink, dangling or not.
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
+
+// linkAdoptedProjects replaces the instruction files adopted from each
+// project in names with links to its store file. A project with conflicts
+// left is skipped, so no file that differs from the store is overwritten.
+func linkAdoptedProjects(items []AdoptItem, projects map[string]string, names []string) {
+	for _, name := range names {
+		var targets []string
+		unresolved := false
+		for _, item := range items {
+			if item.Project != name {
+				continue
+			}
+			if item.Status == "conflict" {
+				unresolved = true
+			}
+			targets = append(targets, item.Agent)
+		}
+		if unresolved {
+			fmt.Fprintf(os.Stderr, "warning: project %q has unresolved conflicts — link it with mine agents link --project once they're settled\n", name)
+			continue
+		}
+		for _, agent := range targets {
+			if _, err := LinkProject(name, projects[name], LinkOptions{Agent: agent, Force: true}); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: failed to link project %q: %v\n", name, err)
+			}
+		}
+	}
+}
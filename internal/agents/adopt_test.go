@@ -601,3 +601,83 @@ func TestAdopt_Integration_MockAgentDirs(t *testing.T) {
 		}
 	}
 }
+
+// --- Adopt from projects ---
+
+func TestAdopt_Projects(t *testing.T) {
+	storeDir, homeDir := setupAdoptEnv(t)
+	api := filepath.Join(homeDir, "code", "api")
+	web := filepath.Join(homeDir, "code", "web")
+	cli := filepath.Join(homeDir, "code", "cli")
+	writeAgentFile(t, api, "CLAUDE.md", "# Go service\n")
+	writeAgentFile(t, api, "AGENTS.md", "# Go service\n")
+	writeAgentFile(t, web, "AGENTS.md", "# Go service\n")
+	writeAgentFile(t, cli, "CLAUDE.md", "# CLI\n")
+	writeAgentFile(t, cli, ".cursorrules", "Use tabs.\n")
+	projects := map[string]string{"api": api, "web": web, "cli": cli}
+
+	items, err := Adopt(AdoptOptions{Projects: projects, Copy: true})
+	if err != nil {
+		t.Fatalf("Adopt: %v", err)
+	}
+	status := map[string]string{}
+	for _, item := range items {
+		status[item.Project+"/"+filepath.Base(item.SourcePath)] = item.Status
+	}
+	want := map[string]string{
+		"api/CLAUDE.md":    "imported",
+		"web/AGENTS.md":    "imported",
+		"cli/CLAUDE.md":    "imported",
+		"cli/.cursorrules": "conflict",
+	}
+	for k, v := range want {
+		if status[k] != v {
+			t.Errorf("%s status = %q, want %q (all: %v)", k, status[k], v, status)
+		}
+	}
+	if len(items) != len(want) {
+		t.Errorf("items = %v, want identical api/AGENTS.md folded into CLAUDE.md", status)
+	}
+
+	// web's instructions are api's, kept once.
+	webStore := filepath.Join(storeDir, "projects", "web", "AGENTS.md")
+	if info, err := os.Lstat(webStore); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("projects/web/AGENTS.md is not shared with api: %v", err)
+	}
+	if got := readString(t, webStore); got != "# Go service\n" {
+		t.Errorf("projects/web/AGENTS.md = %q", got)
+	}
+	if got := readString(t, filepath.Join(storeDir, "projects", "cli", "AGENTS.md")); got != "# CLI\n" {
+		t.Errorf("projects/cli/AGENTS.md = %q, want the first file's content", got)
+	}
+}
+
+func TestAdopt_ProjectsLinkOriginals(t *testing.T) {
+	storeDir, homeDir := setupAdoptEnv(t)
+	api := filepath.Join(homeDir, "code", "api")
+	writeAgentFile(t, api, "CLAUDE.md", "# API\n")
+
+	if _, err := Adopt(AdoptOptions{Projects: map[string]string{"api": api}}); err != nil {
+		t.Fatalf("Adopt: %v", err)
+	}
+	target := filepath.Join(api, "CLAUDE.md")
+	dest, err := os.Readlink(target)
+	if err != nil {
+		t.Fatalf("CLAUDE.md not replaced with a link: %v", err)
+	}
+	if want := filepath.Join(storeDir, "projects", "api", "AGENTS.md"); dest != want {
+		t.Errorf("CLAUDE.md -> %s, want %s", dest, want)
+	}
+	if _, err := os.Lstat(filepath.Join(api, ".cursorrules")); !os.IsNotExist(err) {
+		t.Error("adopt linked .cursorrules, which the project didn't have")
+	}
+
+	// Linked files are managed now; adopting again finds nothing.
+	items, err := Adopt(AdoptOptions{Projects: map[string]string{"api": api}, DryRun: true})
+	if err != nil {
+		t.Fatalf("Adopt again: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("second adopt = %+v, want nothing", items)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/rnwolfe/mine/internal/config"
 	"github.com/rnwolfe/mine/internal/gitutil"
@@ -20,7 +21,7 @@ var (
 
 // Agent represents a detected coding agent.
 type Agent struct {
-	Name      string `json:"name"`       // claude, codex, gemini, opencode
+	Name      string `json:"name"`       // claude, codex, gemini, opencode, cursor, aider, windsurf, copilot
 	Detected  bool   `json:"detected"`   // found on system
 	ConfigDir string `json:"config_dir"` // e.g., ~/.claude/
 	Binary    string `json:"binary"`     // full path if found, empty if not
@@ -32,12 +33,26 @@ type LinkEntry struct {
 	Target string `json:"target"` // Absolute path in agent's expected location
 	Agent  string `json:"agent"`  // Which agent this serves
 	Mode   string `json:"mode"`   // "symlink" or "copy"
+
+	Project string `json:"project,omitempty"` // project name, for links into a project root
+
+	LinkedAt time.Time `json:"linked_at,omitzero"` // when mine last created or refreshed the link
 }
 
 // Manifest holds the state of the agents store.
 type Manifest struct {
+	// Version is the schema version; see ManifestVersion.
+	Version int `json:"version"`
+
 	Agents []Agent     `json:"agents"`
 	Links  []LinkEntry `json:"links"`
+
+	// Disabled lists, per agent, the assets 'mine agents disable' detached:
+	// instructions, skills, commands, settings, mcp, or all of them.
+	Disabled map[string][]string `json:"disabled,omitempty"`
+	// Detached remembers the links of disabled assets, so enabling them
+	// again restores each in its mode.
+	Detached []LinkEntry `json:"detached,omitempty"`
 }
 
 // agentsMD is the starter content for instructions/AGENTS.md.
@@ -151,6 +166,18 @@ func ReadManifest() (*Manifest, error) {
 		return nil, fmt.Errorf("reading manifest: %w", err)
 	}
 
+	// Manifests from older versions of mine are upgraded in place.
+	migrated, from, err := migrateManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	if from != ManifestVersion {
+		if err := saveMigratedManifest(data, migrated, from); err != nil {
+			return nil, err
+		}
+		data = migrated
+	}
+
 	var m Manifest
 	if err := json.Unmarshal(data, &m); err != nil {
 		return nil, fmt.Errorf("parsing manifest: %w", err)
@@ -169,6 +196,7 @@ func ReadManifest() (*Manifest, error) {
 
 // WriteManifest serializes and writes the manifest file.
 func WriteManifest(m *Manifest) error {
+	m.Version = ManifestVersion
 	data, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling manifest: %w", err)
@@ -202,13 +230,17 @@ func initGitRepo(dir string) error {
 	if _, err := gitutil.RunCmd(dir, "init"); err != nil {
 		return fmt.Errorf("git init: %w", err)
 	}
+	return setGitIdentity(dir)
+}
+
+// setGitIdentity configures the committer identity for the agents repo.
+func setGitIdentity(dir string) error {
 	if _, err := gitutil.RunCmd(dir, "config", "user.name", "mine-agents"); err != nil {
 		return fmt.Errorf("git config user.name: %w", err)
 	}
 	if _, err := gitutil.RunCmd(dir, "config", "user.email", "agents@mine.local"); err != nil {
 		return fmt.Errorf("git config user.email: %w", err)
 	}
-
 	return nil
 }
 
@@ -0,0 +1,340 @@
+package agents
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// commandsFormatTOML renders each command as a <name>.toml file with
+// description and prompt keys, as Gemini CLI expects.
+const commandsFormatTOML = "toml"
+
+// argumentsPlaceholder is where a command's arguments go in the store's
+// markdown; agents with another syntax get it rewritten.
+const argumentsPlaceholder = "$ARGUMENTS"
+
+// ErrCommandNotFound is returned when a named command isn't in the store.
+var ErrCommandNotFound = errors.New("command not found")
+
+// Command is a reusable prompt in the store: a commands/<name>.md file that
+// agents expose as a slash command.
+type Command struct {
+	Name        string
+	Description string
+	// Agents limits which agents get the command linked; empty means all.
+	Agents []string
+	Path   string // absolute path of the command file
+}
+
+// ForAgent reports whether the command should be linked for agent.
+func (c Command) ForAgent(agent string) bool {
+	if len(c.Agents) == 0 {
+		return true
+	}
+	for _, a := range c.Agents {
+		if a == agent {
+			return true
+		}
+	}
+	return false
+}
+
+// ListCommands returns the commands in the store sorted by name.
+func ListCommands() ([]Command, error) {
+	return listStoreCommands(Dir())
+}
+
+// GetCommand returns the named command.
+func GetCommand(name string) (*Command, error) {
+	name = strings.TrimSuffix(name, ".md")
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(Dir(), "commands", name+".md")
+	if !fileExists(path) {
+		return nil, fmt.Errorf("%w: %s", ErrCommandNotFound, name)
+	}
+	c := readCommand(path)
+	return &c, nil
+}
+
+// CommandAgents returns the agents that can receive commands, in registry
+// order.
+func CommandAgents() []string {
+	var names []string
+	for _, spec := range buildLinkRegistry("") {
+		if spec.CommandsDir != "" {
+			names = append(names, spec.Name)
+		}
+	}
+	return names
+}
+
+// LinkCommands links the store's commands into every detected agent that
+// supports them, or only opts.Agent.
+func LinkCommands(opts LinkOptions) ([]LinkAction, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+
+	storeDir := Dir()
+	var actions []LinkAction
+	for _, spec := range buildLinkRegistry(home) {
+		if opts.Agent != "" && spec.Name != opts.Agent {
+			continue
+		}
+		if !isAgentDetected(m, spec.Name) || isAssetDisabled(m, spec.Name, AssetCommands) {
+			continue
+		}
+		if a, ok := linkCommands(storeDir, spec, opts, m); ok {
+			actions = append(actions, a)
+		}
+	}
+
+	if err := WriteManifest(m); err != nil {
+		return actions, fmt.Errorf("saving manifest: %w", err)
+	}
+	return actions, nil
+}
+
+// linkCommands links the commands agent gets into its commands directory.
+// ok is false when the agent doesn't support commands or gets none.
+func linkCommands(storeDir string, spec linkSpec, opts LinkOptions, m *Manifest) (LinkAction, bool) {
+	if spec.CommandsDir == "" {
+		return LinkAction{}, false
+	}
+	rel, ok, err := commandsSource(storeDir, spec)
+	if err != nil {
+		return LinkAction{
+			Source: rel,
+			Target: spec.CommandsDir,
+			Agent:  spec.Name,
+			Status: "skipped",
+			Err:    err,
+		}, true
+	}
+	if !ok {
+		return LinkAction{}, false
+	}
+	return createDirLink(filepath.Join(storeDir, rel), rel, spec.CommandsDir, spec.Name, opts, m), true
+}
+
+// commandsSource returns the store-relative commands directory to link for
+// spec's agent. That's commands/, unless the agent needs commands in another
+// format or some command is limited to certain agents — then
+// rendered/commands/<agent>/ is rebuilt with just the agent's commands, in
+// its format, and returned instead. ok is false when the agent gets none.
+func commandsSource(storeDir string, spec linkSpec) (rel string, ok bool, err error) {
+	commands, err := listStoreCommands(storeDir)
+	if err != nil {
+		return "commands", false, err
+	}
+	if spec.CommandsFormat == "" && !commandsScoped(commands) {
+		return "commands", dirNonEmpty(filepath.Join(storeDir, "commands")), nil
+	}
+
+	rel = renderedDir + "/commands/" + spec.Name
+	files, err := renderCommands(storeDir, spec)
+	if err != nil {
+		return rel, false, err
+	}
+	out := filepath.Join(storeDir, rel)
+	if err := os.RemoveAll(out); err != nil {
+		return rel, false, fmt.Errorf("clearing %s: %w", rel, err)
+	}
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return rel, false, fmt.Errorf("creating %s: %w", rel, err)
+	}
+	for name, f := range files {
+		if f.link != "" {
+			err = os.Symlink(f.link, filepath.Join(out, name))
+		} else {
+			err = os.WriteFile(filepath.Join(out, name), f.content, 0o644)
+		}
+		if err != nil {
+			return rel, false, fmt.Errorf("writing %s/%s: %w", rel, name, err)
+		}
+	}
+	if err := ignoreRendered(storeDir); err != nil {
+		return rel, false, err
+	}
+	return rel, len(files) > 0, nil
+}
+
+// commandFile is one entry of a rendered commands directory: a link to a
+// path in the store, or generated content.
+type commandFile struct {
+	link    string
+	content []byte
+}
+
+// renderCommands returns the entries of spec's agent's rendered commands
+// directory, by file name. Agents that take markdown get links to the
+// store's files (and any subdirectories, which some agents use as
+// namespaces); others get each command converted to their format.
+func renderCommands(storeDir string, spec linkSpec) (map[string]commandFile, error) {
+	dir := filepath.Join(storeDir, "commands")
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("listing commands: %w", err)
+	}
+
+	files := map[string]commandFile{}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
+			if spec.CommandsFormat == "" {
+				files[e.Name()] = commandFile{link: path}
+			}
+			continue
+		}
+		c := readCommand(path)
+		if !c.ForAgent(spec.Name) {
+			continue
+		}
+		switch spec.CommandsFormat {
+		case commandsFormatTOML:
+			content, err := tomlCommand(c)
+			if err != nil {
+				return nil, err
+			}
+			files[c.Name+".toml"] = commandFile{content: content}
+		default:
+			files[e.Name()] = commandFile{link: path}
+		}
+	}
+	return files, nil
+}
+
+// tomlCommand converts a markdown command to Gemini CLI's TOML format, with
+// $ARGUMENTS rewritten to {{args}}.
+func tomlCommand(c Command) ([]byte, error) {
+	data, err := os.ReadFile(c.Path)
+	if err != nil {
+		return nil, fmt.Errorf("reading command %s: %w", c.Name, err)
+	}
+	prompt := strings.ReplaceAll(markdownBody(string(data)), argumentsPlaceholder, "{{args}}")
+	prompt = strings.Trim(prompt, "\n")
+
+	var b bytes.Buffer
+	b.WriteString("# Generated by mine agents from commands/" + c.Name + ".md — edit that instead.\n")
+	if c.Description != "" {
+		b.WriteString("description = " + tomlValue(c.Description) + "\n")
+	}
+	if strings.Contains(prompt, "'''") {
+		b.WriteString("prompt = " + tomlValue(prompt) + "\n")
+	} else {
+		// A literal string keeps the prompt readable: no escapes.
+		b.WriteString("prompt = '''\n" + prompt + "\n'''\n")
+	}
+	return b.Bytes(), nil
+}
+
+// markdownBody returns content without its leading front matter.
+func markdownBody(content string) string {
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		return content
+	}
+	if i := strings.Index(rest, "\n---\n"); i >= 0 {
+		return rest[i+len("\n---\n"):]
+	}
+	return content
+}
+
+// commandsScoped reports whether any command is limited to certain agents.
+func commandsScoped(commands []Command) bool {
+	for _, c := range commands {
+		if len(c.Agents) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// commandsStale reports whether rendered/commands/<agent>/ no longer holds
+// exactly what the agent should get.
+func commandsStale(storeDir, agent string) bool {
+	for _, spec := range buildLinkRegistry("") {
+		if spec.Name != agent {
+			continue
+		}
+		commands, err := listStoreCommands(storeDir)
+		if err != nil || (spec.CommandsFormat == "" && !commandsScoped(commands)) {
+			return true
+		}
+		want, err := renderCommands(storeDir, spec)
+		if err != nil {
+			return true
+		}
+		dir := filepath.Join(storeDir, renderedDir, "commands", agent)
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) != len(want) {
+			return true
+		}
+		for _, e := range entries {
+			f, ok := want[e.Name()]
+			if !ok {
+				return true
+			}
+			path := filepath.Join(dir, e.Name())
+			if f.link != "" {
+				if dest, err := os.Readlink(path); err != nil || dest != f.link {
+					return true
+				}
+			} else if got, err := os.ReadFile(path); err != nil || !bytes.Equal(got, f.content) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// listStoreCommands reads every commands/*.md file under storeDir.
+func listStoreCommands(storeDir string) ([]Command, error) {
+	entries, err := os.ReadDir(filepath.Join(storeDir, "commands"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing commands: %w", err)
+	}
+	var commands []Command
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") {
+			commands = append(commands, readCommand(filepath.Join(storeDir, "commands", e.Name())))
+		}
+	}
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	return commands, nil
+}
+
+// readCommand reads a command's metadata: front-matter description and
+// agents, falling back to the first line of prose for the description.
+func readCommand(path string) Command {
+	desc := parseFrontmatterDescription(path)
+	if desc == "" {
+		desc = parseMarkdownDescription(path)
+	}
+	return Command{
+		Name:        strings.TrimSuffix(filepath.Base(path), ".md"),
+		Description: desc,
+		Agents:      frontmatterList(frontmatterLines(path), "agents"),
+		Path:        path,
+	}
+}
@@ -0,0 +1,127 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownBody(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"---\ndescription: x\n---\nBody\n", "Body\n"},
+		{"No front matter\n", "No front matter\n"},
+		{"---\nunterminated\n", "---\nunterminated\n"},
+	}
+	for _, tt := range tests {
+		if got := markdownBody(tt.in); got != tt.want {
+			t.Errorf("markdownBody(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAddCommand_WithAgents(t *testing.T) {
+	setupLinkEnv(t)
+
+	if _, err := AddCommand("review", "claude"); err != nil {
+		t.Fatalf("AddCommand: %v", err)
+	}
+	c, err := GetCommand("review")
+	if err != nil {
+		t.Fatalf("GetCommand: %v", err)
+	}
+	if !reflect.DeepEqual(c.Agents, []string{"claude"}) {
+		t.Errorf("Agents = %v, want [claude]", c.Agents)
+	}
+	if c.Description == "" {
+		t.Error("Description is empty, want the template's first line")
+	}
+	if _, err := AddCommand("other", "nope"); err == nil {
+		t.Error("AddCommand with unknown agent = nil, want error")
+	}
+}
+
+func TestTomlCommand(t *testing.T) {
+	storeDir, _ := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "commands/fix.md", "---\ndescription: Fix an \"issue\"\n---\nFix issue $ARGUMENTS.\n")
+
+	content, err := tomlCommand(readCommand(filepath.Join(storeDir, "commands", "fix.md")))
+	if err != nil {
+		t.Fatalf("tomlCommand: %v", err)
+	}
+	got := string(content)
+	for _, want := range []string{
+		`description = "Fix an \"issue\""`,
+		"prompt = '''\nFix issue {{args}}.\n'''",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("TOML missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestLink_CommandsPerAgentFormat(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "commands/deploy.md", "---\ndescription: Deploy\n---\nDeploy $ARGUMENTS\n")
+	writeStoreFile(t, storeDir, "commands/review.md", "---\nagents: [claude]\n---\nReview\n")
+	makeDetectedAgent(t, "claude", filepath.Join(homeDir, ".claude"))
+	makeDetectedAgent(t, "gemini", filepath.Join(homeDir, ".gemini"))
+
+	actions, err := LinkCommands(LinkOptions{})
+	if err != nil {
+		t.Fatalf("LinkCommands: %v", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("actions = %+v, want claude and gemini", actions)
+	}
+	for _, a := range actions {
+		if a.Err != nil {
+			t.Errorf("%s: %v", a.Agent, a.Err)
+		}
+	}
+
+	names := func(dir string) []string {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("reading %s: %v", dir, err)
+		}
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Name())
+		}
+		return out
+	}
+	if got := names(filepath.Join(homeDir, ".claude", "commands")); !reflect.DeepEqual(got, []string{"deploy.md", "review.md"}) {
+		t.Errorf("claude commands = %v", got)
+	}
+	geminiDir := filepath.Join(homeDir, ".gemini", "commands")
+	if got := names(geminiDir); !reflect.DeepEqual(got, []string{"deploy.toml"}) {
+		t.Errorf("gemini commands = %v, want only deploy.toml", got)
+	}
+	data, err := os.ReadFile(filepath.Join(geminiDir, "deploy.toml"))
+	if err != nil || !strings.Contains(string(data), "Deploy {{args}}") {
+		t.Errorf("deploy.toml = %q, %v", data, err)
+	}
+
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gemini LinkEntry
+	for _, l := range m.Links {
+		if l.Target == geminiDir {
+			gemini = l
+		}
+	}
+	if gemini.Source != renderedDir+"/commands/gemini" {
+		t.Fatalf("gemini entry source = %q, want rendered/commands/gemini", gemini.Source)
+	}
+	if h := CheckLinkHealth(gemini, storeDir); h.Message != "" {
+		t.Errorf("health = %+v, want fresh", h)
+	}
+	writeStoreFile(t, storeDir, "commands/deploy.md", "Deploy everything\n")
+	if h := CheckLinkHealth(gemini, storeDir); !strings.Contains(h.Message, "commands changed") {
+		t.Errorf("health after edit = %+v, want commands changed", h)
+	}
+}
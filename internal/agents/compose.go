@@ -0,0 +1,185 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// renderedDir holds per-agent instruction files composed at link time,
+// relative to the store. It is derived output, so it's kept out of git.
+const renderedDir = "rendered"
+
+// sharedMarker is the line in a per-agent fragment that is replaced by the
+// shared instructions. Without it, the fragment is appended after them.
+const sharedMarker = "{{shared}}"
+
+// instructionSource returns the store-relative instructions file to link
+// for agent. That's instructions/AGENTS.md, unless the store also has an
+// instructions/<agent>.md fragment or uses placeholders — then the final
+// instructions are rendered into rendered/<agent>.md, which is returned
+// instead. ok is false when there are no instructions to link.
+func instructionSource(storeDir, agent string) (rel string, ok bool, err error) {
+	shared := filepath.Join(storeDir, "instructions", "AGENTS.md")
+	fragment := filepath.Join(storeDir, "instructions", agent+".md")
+	if !fileExists(fragment) && !fileHasPlaceholders(shared) {
+		return "instructions/AGENTS.md", fileExists(shared), nil
+	}
+
+	content, err := renderInstructions(storeDir, agent)
+	if err != nil {
+		return "", false, err
+	}
+	rel = renderedDir + "/" + agent + ".md"
+	out := filepath.Join(storeDir, rel)
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return "", false, fmt.Errorf("creating %s: %w", renderedDir, err)
+	}
+	if err := os.WriteFile(out, []byte(content), 0o644); err != nil {
+		return "", false, fmt.Errorf("writing %s: %w", rel, err)
+	}
+	if err := ignoreRendered(storeDir); err != nil {
+		return "", false, err
+	}
+	return rel, true, nil
+}
+
+// renderInstructions composes agent's final instructions from the shared
+// file and the agent's fragment (either may be missing), then fills in any
+// placeholders.
+func renderInstructions(storeDir, agent string) (string, error) {
+	fragment, err := os.ReadFile(filepath.Join(storeDir, "instructions", agent+".md"))
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("reading %s instructions: %w", agent, err)
+	}
+	shared, err := os.ReadFile(filepath.Join(storeDir, "instructions", "AGENTS.md"))
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("reading shared instructions: %w", err)
+	}
+	content := string(shared)
+	if fragment != nil {
+		content = composeInstructions(content, string(fragment))
+	}
+	if !hasPlaceholders(content) {
+		return content, nil
+	}
+	return expandPlaceholders(agent+" instructions", content, templateData(""))
+}
+
+// projectInstructionSource returns the store-relative file to link for the
+// named project's instructions: projects/<name>/AGENTS.md, or, when it uses
+// placeholders, rendered/projects/<name>.md with them filled in.
+func projectInstructionSource(storeDir, name string) (string, error) {
+	rel := ProjectInstructionsPath(name)
+	if !fileHasPlaceholders(filepath.Join(storeDir, filepath.FromSlash(rel))) {
+		return rel, nil
+	}
+	content, err := renderProjectInstructions(storeDir, name)
+	if err != nil {
+		return "", err
+	}
+	rel = renderedDir + "/projects/" + name + ".md"
+	out := filepath.Join(storeDir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", filepath.Dir(rel), err)
+	}
+	if err := os.WriteFile(out, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", rel, err)
+	}
+	if err := ignoreRendered(storeDir); err != nil {
+		return "", err
+	}
+	return rel, nil
+}
+
+// renderProjectInstructions fills in the placeholders of the named
+// project's instructions, with .Project set to name.
+func renderProjectInstructions(storeDir, name string) (string, error) {
+	rel := ProjectInstructionsPath(name)
+	data, err := os.ReadFile(filepath.Join(storeDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", rel, err)
+	}
+	return expandPlaceholders(rel, string(data), templateData(name))
+}
+
+// fileHasPlaceholders reports whether the file at path uses placeholders.
+// A missing or unreadable file has none.
+func fileHasPlaceholders(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && hasPlaceholders(string(data))
+}
+
+// composeInstructions places shared where the fragment has a {{shared}}
+// line, or before the fragment when it has none.
+func composeInstructions(shared, fragment string) string {
+	lines := strings.Split(fragment, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == sharedMarker {
+			lines[i] = strings.TrimRight(shared, "\n")
+			return strings.Join(lines, "\n")
+		}
+	}
+	if strings.TrimSpace(shared) == "" {
+		return fragment
+	}
+	return strings.TrimRight(shared, "\n") + "\n\n" + fragment
+}
+
+// renderedStale reports whether a rendered file no longer matches its
+// sources — instructions and fragment, settings and MCP servers, or the
+// skills or commands an agent gets — i.e. they were edited after linking.
+func renderedStale(storeDir, rel string) bool {
+	var want string
+	if strings.HasPrefix(rel, renderedDir+"/skills/") {
+		return skillsStale(storeDir, filepath.Base(rel))
+	}
+	if strings.HasPrefix(rel, renderedDir+"/commands/") {
+		return commandsStale(storeDir, filepath.Base(rel))
+	}
+	if strings.HasPrefix(rel, renderedDir+"/projects/") {
+		content, err := renderProjectInstructions(storeDir, strings.TrimSuffix(filepath.Base(rel), ".md"))
+		if err != nil {
+			return true
+		}
+		want = content
+	} else if strings.HasPrefix(rel, renderedDir+"/settings/") {
+		content, err := renderSettings(storeDir, strings.TrimSuffix(filepath.Base(rel), ".json"))
+		if err != nil {
+			return true
+		}
+		want = string(content)
+	} else {
+		content, err := renderInstructions(storeDir, strings.TrimSuffix(filepath.Base(rel), ".md"))
+		if err != nil {
+			return true
+		}
+		want = content
+	}
+	got, err := os.ReadFile(filepath.Join(storeDir, rel))
+	return err != nil || string(got) != want
+}
+
+// ignoreRendered adds rendered/ to the store's .gitignore.
+func ignoreRendered(storeDir string) error {
+	path := filepath.Join(storeDir, ".gitignore")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading .gitignore: %w", err)
+	}
+	entry := renderedDir + "/"
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == entry {
+			return nil
+		}
+	}
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	data = append(data, entry+"\n"...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing .gitignore: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestComposeInstructions(t *testing.T) {
+	tests := []struct {
+		name, shared, fragment, want string
+	}{
+		{"appended", "# Shared\n", "Use tabs.\n", "# Shared\n\nUse tabs.\n"},
+		{"marker", "# Shared\n", "# Claude\n{{shared}}\nUse tabs.\n", "# Claude\n# Shared\nUse tabs.\n"},
+		{"indented marker", "S", "  {{shared}}  \nX", "S\nX"},
+		{"no shared", "", "Use tabs.\n", "Use tabs.\n"},
+	}
+	for _, tt := range tests {
+		if got := composeInstructions(tt.shared, tt.fragment); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLink_AgentFragmentComposesInstructions(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Shared\n")
+	writeStoreFile(t, storeDir, "instructions/claude.md", "Claude only.\n")
+
+	claudeDir := filepath.Join(homeDir, ".claude")
+	codexDir := filepath.Join(homeDir, ".codex")
+	makeDetectedAgent(t, "claude", claudeDir)
+	makeDetectedAgent(t, "codex", codexDir)
+
+	actions, err := Link(LinkOptions{})
+	if err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	for _, a := range actions {
+		if a.Err != nil {
+			t.Fatalf("action %+v failed: %v", a, a.Err)
+		}
+	}
+
+	// Claude gets its own composed file; codex still shares AGENTS.md.
+	claudeDest, err := os.Readlink(filepath.Join(claudeDir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(storeDir, "rendered", "claude.md"); claudeDest != want {
+		t.Errorf("CLAUDE.md → %q, want %q", claudeDest, want)
+	}
+	data, err := os.ReadFile(filepath.Join(claudeDir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# Shared\n\nClaude only.\n" {
+		t.Errorf("CLAUDE.md content = %q", data)
+	}
+	codexDest, _ := os.Readlink(filepath.Join(codexDir, "AGENTS.md"))
+	if want := filepath.Join(storeDir, "instructions", "AGENTS.md"); codexDest != want {
+		t.Errorf("codex AGENTS.md → %q, want %q", codexDest, want)
+	}
+
+	gitignore, err := os.ReadFile(filepath.Join(storeDir, ".gitignore"))
+	if err != nil || !strings.Contains(string(gitignore), "rendered/") {
+		t.Errorf(".gitignore = %q, %v; want rendered/ ignored", gitignore, err)
+	}
+}
+
+func TestLink_FragmentAddedLaterRepointsLink(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Shared\n")
+	claudeDir := filepath.Join(homeDir, ".claude")
+	makeDetectedAgent(t, "claude", claudeDir)
+
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatalf("first Link() error = %v", err)
+	}
+
+	writeStoreFile(t, storeDir, "instructions/claude.md", "Claude only.\n")
+	actions, err := Link(LinkOptions{})
+	if err != nil {
+		t.Fatalf("second Link() error = %v", err)
+	}
+	for _, a := range actions {
+		if a.Err != nil {
+			t.Fatalf("relinking without --force failed: %v", a.Err)
+		}
+	}
+	dest, _ := os.Readlink(filepath.Join(claudeDir, "CLAUDE.md"))
+	if want := filepath.Join(storeDir, "rendered", "claude.md"); dest != want {
+		t.Errorf("CLAUDE.md → %q, want %q", dest, want)
+	}
+
+	m, _ := ReadManifest()
+	if len(m.Links) != 1 || m.Links[0].Source != "rendered/claude.md" {
+		t.Errorf("manifest links = %+v, want one rendered/claude.md entry", m.Links)
+	}
+}
+
+func TestCheckLinkHealth_StaleRenderedInstructions(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Shared\n")
+	writeStoreFile(t, storeDir, "instructions/claude.md", "Claude only.\n")
+	makeDetectedAgent(t, "claude", filepath.Join(homeDir, ".claude"))
+
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	m, _ := ReadManifest()
+	entry := m.Links[0]
+	if h := CheckLinkHealth(entry, storeDir); h.State != LinkHealthLinked {
+		t.Fatalf("fresh link state = %q, want linked", h.State)
+	}
+
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Shared, edited\n")
+	h := CheckLinkHealth(entry, storeDir)
+	if h.State != LinkHealthDiverged || !strings.Contains(h.Message, "mine agents link") {
+		t.Fatalf("after edit: state = %q, message = %q; want diverged with relink hint", h.State, h.Message)
+	}
+}
@@ -12,6 +12,9 @@ type agentSpec struct {
 	Name      string // unique agent identifier, e.g. "claude"
 	Binary    string // executable name to search in PATH, e.g. "claude"
 	ConfigDir string // full path to agent config directory
+	// ConfigFile is a config file whose presence also marks the agent as
+	// installed, for agents configured by a dotfile rather than a directory.
+	ConfigFile string
 }
 
 // buildRegistry returns the canonical list of supported coding agents.
@@ -38,12 +41,33 @@ func buildRegistry(home string) []agentSpec {
 			Binary:    "opencode",
 			ConfigDir: filepath.Join(home, ".config", "opencode"),
 		},
+		{
+			Name:      "cursor",
+			Binary:    "cursor-agent",
+			ConfigDir: filepath.Join(home, ".cursor"),
+		},
+		{
+			Name:       "aider",
+			Binary:     "aider",
+			ConfigDir:  filepath.Join(home, ".aider"),
+			ConfigFile: filepath.Join(home, ".aider.conf.yml"),
+		},
+		{
+			Name:      "windsurf",
+			Binary:    "windsurf",
+			ConfigDir: filepath.Join(home, ".codeium", "windsurf"),
+		},
+		{
+			Name:      "copilot",
+			Binary:    "copilot",
+			ConfigDir: filepath.Join(home, ".copilot"),
+		},
 	}
 }
 
 // DetectAgents scans the system for installed coding agents.
-// An agent is considered detected if its binary is in PATH and/or its config
-// directory exists. Results can be persisted via WriteManifest.
+// An agent is considered detected if its binary is in PATH, its config
+// directory exists, or its config file (if it has one) exists. Results can be persisted via WriteManifest.
 func DetectAgents() []Agent {
 	home, _ := os.UserHomeDir()
 	return detectAgents(home)
@@ -56,10 +80,11 @@ func detectAgents(home string) []Agent {
 	for i, spec := range specs {
 		binaryPath, hasBinary := detectBinary(spec.Binary)
 		hasConfigDir := detectConfigDir(spec.ConfigDir)
+		hasConfigFile := spec.ConfigFile != "" && fileExists(spec.ConfigFile)
 
 		result[i] = Agent{
 			Name:      spec.Name,
-			Detected:  hasBinary || hasConfigDir,
+			Detected:  hasBinary || hasConfigDir || hasConfigFile,
 			ConfigDir: spec.ConfigDir,
 			Binary:    binaryPath,
 		}
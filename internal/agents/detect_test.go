@@ -11,7 +11,7 @@ func TestDetectAgents_ReturnsAllAgents(t *testing.T) {
 	home := t.TempDir()
 	agents := detectAgents(home)
 
-	wantNames := []string{"claude", "codex", "gemini", "opencode"}
+	wantNames := []string{"claude", "codex", "gemini", "opencode", "cursor", "aider", "windsurf", "copilot"}
 	if len(agents) != len(wantNames) {
 		t.Fatalf("detectAgents() returned %d agents, want %d", len(agents), len(wantNames))
 	}
@@ -52,6 +52,27 @@ func TestDetectAgents_ConfigDirExists(t *testing.T) {
 	}
 }
 
+func TestDetectAgents_ConfigFileExists(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("PATH", t.TempDir())
+	if err := os.WriteFile(filepath.Join(home, ".aider.conf.yml"), []byte("read: CONVENTIONS.md\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, a := range detectAgents(home) {
+		switch a.Name {
+		case "aider":
+			if !a.Detected {
+				t.Error("aider.Detected = false, want true when ~/.aider.conf.yml exists")
+			}
+		default:
+			if a.Detected {
+				t.Errorf("agent %q.Detected = true, want false", a.Name)
+			}
+		}
+	}
+}
+
 func TestDetectAgents_NothingDetected(t *testing.T) {
 	home := t.TempDir() // empty home dir, no config dirs
 
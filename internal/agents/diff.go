@@ -81,6 +81,34 @@ func Diff(opts DiffOptions) ([]DiffEntry, error) {
 	return entries, nil
 }
 
+// Directions ApplyDiff can reconcile a diverged copy in.
+const (
+	ApplyFromStore  = "store"  // the store's version wins: overwrite the copy
+	ApplyFromTarget = "target" // the copy wins: save it to the store
+)
+
+// ApplyDiff reconciles a diverged copy-mode link by copying its store source
+// over the target, or the target over the source, per direction. Only
+// diverged copies are applied; other links need link, adopt, or doctor.
+func ApplyDiff(link LinkEntry, direction string) error {
+	if direction != ApplyFromStore && direction != ApplyFromTarget {
+		return fmt.Errorf("unknown direction %q — use %s or %s", direction, ApplyFromStore, ApplyFromTarget)
+	}
+	storeDir := Dir()
+	if h := CheckLinkHealth(link, storeDir); h.State != LinkHealthDiverged {
+		return fmt.Errorf("%s is %s, not a diverged copy", link.Target, h.State)
+	}
+
+	source := filepath.Join(storeDir, link.Source)
+	if direction == ApplyFromStore {
+		return replaceWithCopy(source, link.Target)
+	}
+	if strings.HasPrefix(link.Source, renderedDir+"/") {
+		return fmt.Errorf("%s is rendered from store files — edit those instead", link.Source)
+	}
+	return replaceWithCopy(link.Target, source)
+}
+
 // diffPaths returns unified-diff lines between paths a (canonical) and b (target).
 // It attempts to use `git diff --no-index` for proper unified diff output, and
 // falls back to a simple line-based diff when git is not available.
@@ -0,0 +1,54 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyDiff(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "store\n")
+	target := filepath.Join(homeDir, "CLAUDE.md")
+	link := LinkEntry{Source: "instructions/AGENTS.md", Target: target, Agent: "claude", Mode: "copy"}
+
+	if err := os.WriteFile(target, []byte("copy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyDiff(link, ApplyFromTarget); err != nil {
+		t.Fatalf("ApplyDiff(target): %v", err)
+	}
+	if got := readString(t, filepath.Join(storeDir, "instructions", "AGENTS.md")); got != "copy\n" {
+		t.Errorf("store = %q, want the copy's content", got)
+	}
+
+	// Once in sync there's nothing to apply.
+	if err := ApplyDiff(link, ApplyFromStore); err == nil {
+		t.Error("ApplyDiff on a matching copy = nil, want error")
+	}
+
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "store again\n")
+	if err := ApplyDiff(link, "sideways"); err == nil {
+		t.Error("ApplyDiff(sideways) = nil, want error")
+	}
+	if err := ApplyDiff(link, ApplyFromStore); err != nil {
+		t.Fatalf("ApplyDiff(store): %v", err)
+	}
+	if got := readString(t, target); got != "store again\n" {
+		t.Errorf("copy = %q, want the store's content", got)
+	}
+}
+
+func TestApplyDiff_RenderedSourceStaysInStore(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, renderedDir+"/instructions/claude.md", "composed\n")
+	target := filepath.Join(homeDir, "CLAUDE.md")
+	if err := os.WriteFile(target, []byte("edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	link := LinkEntry{Source: renderedDir + "/instructions/claude.md", Target: target, Agent: "claude", Mode: "copy"}
+
+	if err := ApplyDiff(link, ApplyFromTarget); err == nil {
+		t.Error("ApplyDiff(target) on a rendered source = nil, want error")
+	}
+}
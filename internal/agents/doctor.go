@@ -0,0 +1,278 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Checks reported by Diagnose.
+const (
+	CheckBroken        = "broken"         // symlink whose destination is gone
+	CheckMissing       = "missing"        // link target deleted
+	CheckOverwritten   = "overwritten"    // link replaced by a real file, e.g. by an agent updater
+	CheckMissingSource = "missing-source" // manifest entry whose store source is gone
+	CheckNewAgent      = "new-agent"      // agent installed since the last detect
+)
+
+// Finding is one problem 'mine agents doctor' reports.
+type Finding struct {
+	Check   string
+	Agent   string
+	Target  string // affected path; empty for new agents
+	Message string
+	Hint    string // how to fix it by hand; may be empty
+	Fixable bool   // Diagnose can repair it without losing anything
+	Fixed   bool   // repaired by this run
+	FixErr  error  // the repair was attempted and failed
+}
+
+// Diagnose checks every manifest link and the installed agents. When fix is
+// set it repairs what it safely can: recreating missing or dangling links,
+// relinking overwritten targets whose content still matches the store,
+// dropping entries for sources that no longer exist, and linking newly
+// installed agents. Targets holding content that isn't in the store are
+// never touched.
+func Diagnose(fix bool) ([]Finding, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+
+	storeDir := Dir()
+	var findings []Finding
+	var dropped []string
+	for _, entry := range m.Links {
+		f, ok := diagnoseLink(entry, storeDir)
+		if !ok {
+			continue
+		}
+		if fix && f.Fixable {
+			if f.Check == CheckMissingSource && !strings.HasPrefix(entry.Source, renderedDir+"/") {
+				f.FixErr = dropLink(entry, storeDir)
+				if f.FixErr == nil {
+					dropped = append(dropped, entry.Target)
+				}
+			} else {
+				f.FixErr = relinkEntry(entry, storeDir, home, m)
+			}
+			f.Fixed = f.FixErr == nil
+		}
+		findings = append(findings, f)
+	}
+	for _, target := range dropped {
+		removeManifestLink(m, target)
+	}
+
+	findings = append(findings, diagnoseNewAgents(m, home, storeDir, fix)...)
+
+	if fix {
+		if err := WriteManifest(m); err != nil {
+			return findings, fmt.Errorf("saving manifest: %w", err)
+		}
+	}
+	return findings, nil
+}
+
+// diagnoseLink checks one manifest entry. ok is false when it is healthy.
+// Diverged copies and stale rendered files are left to status: they mean
+// edits, not breakage.
+func diagnoseLink(entry LinkEntry, storeDir string) (f Finding, ok bool) {
+	f = Finding{Agent: entry.Agent, Target: entry.Target}
+	source := filepath.Join(storeDir, entry.Source)
+
+	if _, err := os.Stat(source); err != nil {
+		f.Check = CheckMissingSource
+		f.Message = entry.Source + " is no longer in the store"
+		if strings.HasPrefix(entry.Source, renderedDir+"/") {
+			// Rendered files are regenerated by linking; a copy in place
+			// of one may hold edits, though.
+			f.Hint = "mine agents link --agent " + entry.Agent
+			f.Fixable = entry.Mode != "copy"
+		} else {
+			f.Hint = "mine agents unlink --agent " + entry.Agent + ", or restore it with mine agents restore " + entry.Source
+			f.Fixable = true
+		}
+		return f, true
+	}
+
+	h := CheckLinkHealth(entry, storeDir)
+	switch h.State {
+	case LinkHealthUnlinked:
+		f.Check = CheckMissing
+		f.Message = "link was deleted"
+		f.Hint = "mine agents link --agent " + entry.Agent
+		f.Fixable = true
+
+	case LinkHealthBroken:
+		f.Check = CheckBroken
+		f.Message = "broken symlink"
+		if h.Message != "" {
+			f.Message += " — " + h.Message
+		}
+		f.Hint = "mine agents link --agent " + entry.Agent + " --force"
+		// Only a dangling symlink is safe to replace; anything else (say,
+		// a permission error) needs a look.
+		f.Fixable = isDanglingSymlink(entry.Target)
+
+	case LinkHealthReplaced:
+		f.Check = CheckOverwritten
+		if h.Message != "" {
+			dest := strings.TrimPrefix(strings.TrimPrefix(h.Message, "symlink → "), "points to ")
+			f.Message = "replaced by a symlink to " + dest
+		} else {
+			f.Message = "replaced by a regular file"
+		}
+		if entry.Mode != "copy" && contentMatches(source, entry.Target) {
+			f.Message += " with the same content"
+			f.Hint = "mine agents link --agent " + entry.Agent + " --force"
+			f.Fixable = true
+		} else {
+			f.Hint = "mine agents diff to compare, then mine agents adopt to keep it or mine agents link --force to discard it"
+		}
+
+	default:
+		return f, false
+	}
+	return f, true
+}
+
+// diagnoseNewAgents reports agents detected on the system that the manifest
+// doesn't know about. Fixing records the detection and links them; existing
+// files in their config dirs are left alone.
+func diagnoseNewAgents(m *Manifest, home, storeDir string, fix bool) []Finding {
+	detected := detectAgents(home)
+	var findings []Finding
+	var fresh []string
+	for _, a := range detected {
+		if !a.Detected || isAgentDetected(m, a.Name) {
+			continue
+		}
+		fresh = append(fresh, a.Name)
+		findings = append(findings, Finding{
+			Check:   CheckNewAgent,
+			Agent:   a.Name,
+			Message: "installed since the last detect",
+			Hint:    "mine agents detect && mine agents link --agent " + a.Name,
+			Fixable: true,
+		})
+	}
+	if !fix || len(fresh) == 0 {
+		return findings
+	}
+
+	m.Agents = detected
+	specs := buildLinkRegistry(home)
+	for i := range findings {
+		for _, spec := range specs {
+			if spec.Name != findings[i].Agent {
+				continue
+			}
+			for _, a := range linkAgent(storeDir, spec, LinkOptions{}, m) {
+				if a.Err != nil && findings[i].FixErr == nil {
+					findings[i].FixErr = fmt.Errorf("%s: %w", a.Target, a.Err)
+				}
+			}
+		}
+		findings[i].Fixed = findings[i].FixErr == nil
+	}
+	return findings
+}
+
+// relinkEntry recreates entry's link in its recorded mode. Callers have
+// already checked that the target holds nothing worth keeping.
+func relinkEntry(entry LinkEntry, storeDir, home string, m *Manifest) error {
+	if name, ok := strings.CutPrefix(entry.Source, renderedDir+"/projects/"); ok {
+		// Rendered project instructions come from projects/<name>/AGENTS.md.
+		rel, err := projectInstructionSource(storeDir, strings.TrimSuffix(name, ".md"))
+		if err != nil {
+			return err
+		}
+		entry.Source = rel
+	} else if strings.HasPrefix(entry.Source, renderedDir+"/") {
+		// Rendered files are composed from their sources by linkAgent.
+		for _, spec := range buildLinkRegistry(home) {
+			if spec.Name != entry.Agent {
+				continue
+			}
+			if err := removeIfSafe(entry, storeDir); err != nil {
+				return err
+			}
+			for _, a := range linkAgent(storeDir, spec, LinkOptions{Copy: entry.Mode == "copy"}, m) {
+				if a.Target == entry.Target && a.Err != nil {
+					return a.Err
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("unknown agent %q", entry.Agent)
+	}
+
+	source := filepath.Join(storeDir, entry.Source)
+	opts := LinkOptions{Copy: entry.Mode == "copy", Force: true}
+	var a LinkAction
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		a = createDirLink(source, entry.Source, entry.Target, entry.Agent, opts, m)
+	} else {
+		a = createFileLink(source, entry.Source, entry.Target, entry.Agent, opts, m)
+	}
+	if a.Err != nil {
+		return a.Err
+	}
+	setManifestLinkProject(m, entry.Target, entry.Project)
+	return nil
+}
+
+// removeIfSafe removes entry's target when it is a dangling symlink or a
+// copy of its store source, so linkAgent can recreate it without --force.
+func removeIfSafe(entry LinkEntry, storeDir string) error {
+	if isDanglingSymlink(entry.Target) ||
+		(fileExists(entry.Target) && contentMatches(filepath.Join(storeDir, entry.Source), entry.Target)) {
+		if err := os.Remove(entry.Target); err != nil {
+			return fmt.Errorf("removing %s: %w", entry.Target, err)
+		}
+	}
+	return nil
+}
+
+// dropLink forgets an entry whose source is gone, removing its target only
+// when it is a symlink into the store (and so now dangling). Copies are
+// the user's to keep.
+func dropLink(entry LinkEntry, storeDir string) error {
+	if dest, err := os.Readlink(entry.Target); err == nil && dest == filepath.Join(storeDir, entry.Source) {
+		if err := os.Remove(entry.Target); err != nil {
+			return fmt.Errorf("removing %s: %w", entry.Target, err)
+		}
+	}
+	return nil
+}
+
+// removeManifestLink deletes the manifest entry for target.
+func removeManifestLink(m *Manifest, target string) {
+	links := m.Links[:0]
+	for _, l := range m.Links {
+		if l.Target != target {
+			links = append(links, l)
+		}
+	}
+	m.Links = links
+}
+
+// isDanglingSymlink reports whether path is a symlink whose destination
+// doesn't exist.
+func isDanglingSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return false
+	}
+	_, err = os.Stat(path)
+	return os.IsNotExist(err)
+}
@@ -0,0 +1,163 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupDoctorEnv links claude's instructions, settings, and commands, with
+// agent binaries hidden from detection.
+func setupDoctorEnv(t *testing.T) (storeDir, claudeDir string) {
+	t.Helper()
+	storeDir, homeDir := setupLinkEnv(t)
+	t.Setenv("PATH", t.TempDir())
+	claudeDir = filepath.Join(homeDir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	makeDetectedAgent(t, "claude", claudeDir)
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Shared\n")
+	writeStoreFile(t, storeDir, "settings/claude.json", `{"theme":"dark"}`)
+	writeStoreFile(t, storeDir, "commands/review.md", "# Review\n")
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	return storeDir, claudeDir
+}
+
+func findingFor(findings []Finding, check string) *Finding {
+	for i := range findings {
+		if findings[i].Check == check {
+			return &findings[i]
+		}
+	}
+	return nil
+}
+
+func TestDiagnose_Healthy(t *testing.T) {
+	setupDoctorEnv(t)
+
+	findings, err := Diagnose(false)
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("Diagnose() = %+v, want no findings", findings)
+	}
+}
+
+func TestDiagnose_FixesSafeProblems(t *testing.T) {
+	storeDir, claudeDir := setupDoctorEnv(t)
+	instr := filepath.Join(claudeDir, "CLAUDE.md")
+	settings := filepath.Join(claudeDir, "settings.json")
+	commands := filepath.Join(claudeDir, "commands")
+
+	// Deleted link, an updater's identical rewrite, a deleted source, and a
+	// newly installed agent.
+	if err := os.Remove(instr); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(settings); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settings, []byte(`{"theme":"dark"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(storeDir, "commands")); err != nil {
+		t.Fatal(err)
+	}
+	codexDir := filepath.Join(filepath.Dir(claudeDir), ".codex")
+	if err := os.MkdirAll(codexDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := Diagnose(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, check := range []string{CheckMissing, CheckOverwritten, CheckMissingSource, CheckNewAgent} {
+		f := findingFor(findings, check)
+		if f == nil {
+			t.Fatalf("no %s finding in %+v", check, findings)
+		}
+		if !f.Fixable || f.Fixed {
+			t.Errorf("%s: Fixable = %v, Fixed = %v before --fix", check, f.Fixable, f.Fixed)
+		}
+	}
+
+	findings, err = Diagnose(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range findings {
+		if !f.Fixed {
+			t.Errorf("%s %s not fixed: %v", f.Check, f.Target, f.FixErr)
+		}
+	}
+
+	for _, target := range []string{instr, settings, filepath.Join(codexDir, "AGENTS.md")} {
+		if info, err := os.Lstat(target); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("%s is not a symlink after fix", target)
+		}
+	}
+	if _, err := os.Lstat(commands); !os.IsNotExist(err) {
+		t.Errorf("dangling commands link left behind: %v", err)
+	}
+	m, _ := ReadManifest()
+	for _, l := range m.Links {
+		if l.Source == "commands" {
+			t.Errorf("manifest still has entry for deleted source: %+v", l)
+		}
+	}
+
+	if again, _ := Diagnose(false); len(again) != 0 {
+		t.Errorf("after fix: %+v", again)
+	}
+}
+
+func TestDiagnose_BrokenSymlinkReplaced(t *testing.T) {
+	_, claudeDir := setupDoctorEnv(t)
+	instr := filepath.Join(claudeDir, "CLAUDE.md")
+	if err := os.Remove(instr); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(t.TempDir(), "gone.md"), instr); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := Diagnose(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := findingFor(findings, CheckBroken)
+	if f == nil || !f.Fixed {
+		t.Fatalf("broken finding = %+v", f)
+	}
+	if data, err := os.ReadFile(instr); err != nil || string(data) != "# Shared\n" {
+		t.Errorf("CLAUDE.md = %q, %v after fix", data, err)
+	}
+}
+
+func TestDiagnose_KeepsEditedOverwrite(t *testing.T) {
+	_, claudeDir := setupDoctorEnv(t)
+	instr := filepath.Join(claudeDir, "CLAUDE.md")
+	if err := os.Remove(instr); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(instr, []byte("# Written by an updater\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := Diagnose(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := findingFor(findings, CheckOverwritten)
+	if f == nil || f.Fixable || f.Fixed {
+		t.Fatalf("overwritten finding = %+v, want reported but not fixable", f)
+	}
+	if data, _ := os.ReadFile(instr); string(data) != "# Written by an updater\n" {
+		t.Errorf("--fix changed an edited file: %q", data)
+	}
+}
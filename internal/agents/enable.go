@@ -0,0 +1,273 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Assets 'mine agents disable' can detach from an agent.
+const (
+	AssetInstructions = "instructions"
+	AssetSkills       = "skills"
+	AssetCommands     = "commands"
+	AssetSettings     = "settings"
+	AssetMCP          = "mcp"
+)
+
+// assetAll disables or enables every asset at once.
+const assetAll = "all"
+
+// AssetKinds lists the assets an agent can have disabled, in link order.
+var AssetKinds = []string{AssetInstructions, AssetSkills, AssetCommands, AssetSettings, AssetMCP}
+
+// isAssetDisabled reports whether asset is disabled for agent.
+func isAssetDisabled(m *Manifest, agent, asset string) bool {
+	disabled := m.Disabled[agent]
+	return slices.Contains(disabled, assetAll) || slices.Contains(disabled, asset)
+}
+
+// DisabledAssets returns the assets disabled for agent, in link order.
+func DisabledAssets(m *Manifest, agent string) []string {
+	var out []string
+	for _, asset := range AssetKinds {
+		if isAssetDisabled(m, agent, asset) {
+			out = append(out, asset)
+		}
+	}
+	return out
+}
+
+// assetsOf returns the assets a link from source delivers to spec's agent.
+// Agents that keep MCP servers in their settings link one file for both.
+func assetsOf(source string, spec linkSpec) []string {
+	rest := strings.TrimPrefix(source, renderedDir+"/")
+	switch {
+	case strings.HasPrefix(rest, "skills"):
+		return []string{AssetSkills}
+	case strings.HasPrefix(rest, "commands"):
+		return []string{AssetCommands}
+	case strings.HasPrefix(rest, "settings/"):
+		if spec.MCPFormat == mcpFormatSettings {
+			return []string{AssetSettings, AssetMCP}
+		}
+		return []string{AssetSettings}
+	case strings.HasPrefix(rest, "mcp/"):
+		return []string{AssetMCP}
+	case strings.HasPrefix(source, "instructions/") || strings.HasPrefix(source, renderedDir+"/"):
+		return []string{AssetInstructions}
+	}
+	return nil
+}
+
+// parseAsset validates asset, treating empty as every asset.
+func parseAsset(asset string) (string, error) {
+	if asset == "" {
+		return assetAll, nil
+	}
+	if asset != assetAll && !slices.Contains(AssetKinds, asset) {
+		return "", fmt.Errorf("unknown asset %q — choose one of %s, or all", asset, strings.Join(AssetKinds, ", "))
+	}
+	return asset, nil
+}
+
+// lookupLinkSpec returns the link spec for the named agent.
+func lookupLinkSpec(home, agent string) (linkSpec, error) {
+	for _, spec := range buildLinkRegistry(home) {
+		if spec.Name == agent {
+			return spec, nil
+		}
+	}
+	return linkSpec{}, fmt.Errorf("unknown agent %q — run mine agents detect to see supported agents", agent)
+}
+
+// Disable detaches asset (every asset when empty or "all") from agent: its
+// global links are removed but remembered, and mine agents link skips it
+// until Enable. Symlinks are removed; copies only when they still match the
+// store, so no edits are lost. Project links are left alone.
+func Disable(agent, asset string) ([]UnlinkAction, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	asset, err := parseAsset(asset)
+	if err != nil {
+		return nil, err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+	spec, err := lookupLinkSpec(home, agent)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	if m.Disabled == nil {
+		m.Disabled = map[string][]string{}
+	}
+	if asset == assetAll {
+		m.Disabled[agent] = []string{assetAll}
+	} else if !isAssetDisabled(m, agent, asset) {
+		m.Disabled[agent] = append(m.Disabled[agent], asset)
+	}
+
+	storeDir := Dir()
+	var actions []UnlinkAction
+	var remaining []LinkEntry
+	for _, l := range m.Links {
+		detach := l.Agent == agent && l.Project == "" && slices.ContainsFunc(assetsOf(l.Source, spec), func(a string) bool {
+			return isAssetDisabled(m, agent, a)
+		})
+		if !detach {
+			remaining = append(remaining, l)
+			continue
+		}
+		a := detachEntry(l, storeDir)
+		actions = append(actions, a)
+		if a.Err != nil {
+			remaining = append(remaining, l)
+			continue
+		}
+		m.Detached = append(m.Detached, l)
+	}
+	m.Links = remaining
+	if m.Links == nil {
+		m.Links = []LinkEntry{}
+	}
+
+	if err := WriteManifest(m); err != nil {
+		return actions, fmt.Errorf("saving manifest: %w", err)
+	}
+	return actions, nil
+}
+
+// detachEntry removes link's target, refusing copies that no longer match
+// the store.
+func detachEntry(link LinkEntry, storeDir string) UnlinkAction {
+	action := UnlinkAction{Target: link.Target, Agent: link.Agent, Status: "detached"}
+
+	info, err := os.Lstat(link.Target)
+	if os.IsNotExist(err) {
+		return action
+	}
+	if err != nil {
+		action.Status = "skipped"
+		action.Err = fmt.Errorf("checking target: %w", err)
+		return action
+	}
+	if info.Mode()&os.ModeSymlink == 0 && !contentMatches(filepath.Join(storeDir, filepath.FromSlash(link.Source)), link.Target) {
+		action.Status = "skipped"
+		action.Err = fmt.Errorf("%s differs from the store — run mine agents diff to reconcile it first", link.Target)
+		return action
+	}
+	if err := os.RemoveAll(link.Target); err != nil {
+		action.Status = "skipped"
+		action.Err = fmt.Errorf("removing target: %w", err)
+	}
+	return action
+}
+
+// Enable clears what Disable set for agent — asset, or every asset when
+// empty or "all" — and links it again. Links Disable removed come back in
+// the mode they had; enabling one asset of a fully disabled agent leaves
+// the rest disabled.
+func Enable(agent, asset string) ([]LinkAction, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	asset, err := parseAsset(asset)
+	if err != nil {
+		return nil, err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+	spec, err := lookupLinkSpec(home, agent)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	enabling := DisabledAssets(m, agent)
+	if asset != assetAll {
+		if !slices.Contains(enabling, asset) {
+			return nil, fmt.Errorf("%s is not disabled for %s", asset, agent)
+		}
+		enabling = []string{asset}
+	}
+	if len(enabling) == 0 {
+		return nil, fmt.Errorf("%s has nothing disabled", agent)
+	}
+
+	var still []string
+	for _, a := range DisabledAssets(m, agent) {
+		if !slices.Contains(enabling, a) {
+			still = append(still, a)
+		}
+	}
+	if len(still) == 0 {
+		delete(m.Disabled, agent)
+	} else {
+		m.Disabled[agent] = still
+	}
+
+	var restored []LinkEntry
+	var detached []LinkEntry
+	for _, l := range m.Detached {
+		if l.Agent == agent && !slices.ContainsFunc(assetsOf(l.Source, spec), func(a string) bool {
+			return isAssetDisabled(m, agent, a)
+		}) {
+			restored = append(restored, l)
+			continue
+		}
+		detached = append(detached, l)
+	}
+	m.Detached = detached
+
+	var actions []LinkAction
+	if isAgentDetected(m, agent) {
+		storeDir := Dir()
+		for _, asset := range enabling {
+			if spec.MCPFormat == mcpFormatSettings && asset == AssetMCP && slices.Contains(enabling, AssetSettings) {
+				continue // linked with settings: it's the same file
+			}
+			want := func(a string) bool {
+				if a == asset {
+					return true
+				}
+				// The settings file an agent keeps MCP servers in serves both.
+				shared := spec.MCPFormat == mcpFormatSettings &&
+					(a == AssetSettings && asset == AssetMCP || a == AssetMCP && asset == AssetSettings)
+				return shared && !isAssetDisabled(m, agent, a)
+			}
+			opts := LinkOptions{Agent: agent, Copy: wasCopied(restored, spec, asset)}
+			actions = append(actions, linkAgentAssets(storeDir, spec, opts, m, want)...)
+		}
+	}
+
+	if err := WriteManifest(m); err != nil {
+		return actions, fmt.Errorf("saving manifest: %w", err)
+	}
+	return actions, nil
+}
+
+// wasCopied reports whether asset's links were copies when they were
+// detached.
+func wasCopied(links []LinkEntry, spec linkSpec, asset string) bool {
+	for _, l := range links {
+		if l.Mode == "copy" && slices.Contains(assetsOf(l.Source, spec), asset) {
+			return true
+		}
+	}
+	return false
+}
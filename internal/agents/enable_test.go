@@ -0,0 +1,165 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDisableEnable_RoundTrip(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "skills/review/SKILL.md", "---\nname: review\n---\nReview\n")
+	claudeDir := filepath.Join(homeDir, ".claude")
+	makeDetectedAgent(t, "claude", claudeDir)
+	if _, err := Link(LinkOptions{Copy: true}); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+
+	actions, err := Disable("claude", "")
+	if err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("Disable actions = %+v, want instructions and skills", actions)
+	}
+	for _, target := range []string{filepath.Join(claudeDir, "CLAUDE.md"), filepath.Join(claudeDir, "skills")} {
+		if _, err := os.Lstat(target); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after disable", target)
+		}
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Links) != 0 || len(m.Detached) != 2 {
+		t.Errorf("links = %d, detached = %d, want 0 and 2", len(m.Links), len(m.Detached))
+	}
+
+	// Linking again leaves a disabled agent alone.
+	if actions, err := Link(LinkOptions{}); err != nil || len(actions) != 0 {
+		t.Errorf("Link while disabled = %+v, %v, want nothing", actions, err)
+	}
+
+	if _, err := Enable("claude", ""); err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	m, err = ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Links) != 2 || len(m.Detached) != 0 || len(m.Disabled) != 0 {
+		t.Fatalf("after enable: links = %+v, detached = %+v, disabled = %v", m.Links, m.Detached, m.Disabled)
+	}
+	for _, l := range m.Links {
+		if l.Mode != "copy" {
+			t.Errorf("%s restored as %s, want copy", l.Target, l.Mode)
+		}
+	}
+}
+
+func TestDisable_SingleAsset(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "skills/review/SKILL.md", "---\nname: review\n---\nReview\n")
+	claudeDir := filepath.Join(homeDir, ".claude")
+	makeDetectedAgent(t, "claude", claudeDir)
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+
+	if _, err := Disable("claude", AssetSkills); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(claudeDir, "skills")); !os.IsNotExist(err) {
+		t.Error("skills link still exists after disabling skills")
+	}
+	if _, err := os.Lstat(filepath.Join(claudeDir, "CLAUDE.md")); err != nil {
+		t.Errorf("instructions link removed with skills: %v", err)
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := DisabledAssets(m, "claude"); !reflect.DeepEqual(got, []string{AssetSkills}) {
+		t.Errorf("DisabledAssets = %v, want [skills]", got)
+	}
+	if _, err := Enable("claude", AssetCommands); err == nil {
+		t.Error("Enable of an asset that isn't disabled = nil, want error")
+	}
+}
+
+func TestEnable_OneAssetOfDisabledAgent(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "skills/review/SKILL.md", "---\nname: review\n---\nReview\n")
+	claudeDir := filepath.Join(homeDir, ".claude")
+	makeDetectedAgent(t, "claude", claudeDir)
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	if _, err := Disable("claude", "all"); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+
+	actions, err := Enable("claude", AssetInstructions)
+	if err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Source != "instructions/AGENTS.md" {
+		t.Fatalf("Enable actions = %+v, want only instructions", actions)
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{AssetSkills, AssetCommands, AssetSettings, AssetMCP}
+	if got := DisabledAssets(m, "claude"); !reflect.DeepEqual(got, want) {
+		t.Errorf("DisabledAssets = %v, want %v", got, want)
+	}
+	if len(m.Detached) != 1 {
+		t.Errorf("detached = %+v, want the skills link", m.Detached)
+	}
+}
+
+func TestDisable_KeepsEditedCopy(t *testing.T) {
+	_, homeDir := setupLinkEnv(t)
+	claudeDir := filepath.Join(homeDir, ".claude")
+	makeDetectedAgent(t, "claude", claudeDir)
+	if _, err := Link(LinkOptions{Copy: true}); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	target := filepath.Join(claudeDir, "CLAUDE.md")
+	if err := os.WriteFile(target, []byte("edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, err := Disable("claude", AssetInstructions)
+	if err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Err == nil {
+		t.Fatalf("Disable actions = %+v, want a refusal", actions)
+	}
+	if got := readString(t, target); got != "edited\n" {
+		t.Errorf("edited copy = %q, want it kept", got)
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Links) != 1 {
+		t.Errorf("links = %+v, want the refused link kept", m.Links)
+	}
+}
+
+func TestDisable_Validation(t *testing.T) {
+	setupLinkEnv(t)
+	if _, err := Disable("nope", ""); err == nil {
+		t.Error("Disable unknown agent = nil, want error")
+	}
+	if _, err := Disable("claude", "widgets"); err == nil {
+		t.Error("Disable unknown asset = nil, want error")
+	}
+	if _, err := Enable("claude", ""); err == nil {
+		t.Error("Enable with nothing disabled = nil, want error")
+	}
+}
@@ -0,0 +1,246 @@
+package agents
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// heredocEnd closes the here-documents a bootstrap script writes files with.
+const heredocEnd = "MINE_AGENTS_EOF"
+
+// homeToken stands in for the home directory in the manifest a bootstrap
+// script writes; the script expands it to the new machine's $HOME.
+const homeToken = "@@MINE_AGENTS_HOME@@"
+
+// BootstrapSummary counts what a bootstrap script recreates.
+type BootstrapSummary struct {
+	Files int // store files and symlinks written
+	Links int // agent links recreated
+	// Skipped counts project links left out: project paths are specific to
+	// this machine, so re-link those with mine once the repo is cloned.
+	Skipped int
+}
+
+// ExportBootstrap writes a POSIX shell script to w that recreates the store
+// and every agent link on a machine without mine installed — e.g. an
+// ephemeral dev container. The store is written to
+// ${XDG_DATA_HOME:-$HOME/.local/share}/mine/agents, where mine would look
+// for it, and link targets under this machine's home directory are
+// re-rooted at the new $HOME. Version history (.git) isn't included, and
+// rendered files are exported as rendered here.
+func ExportBootstrap(w io.Writer) (*BootstrapSummary, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+
+	storeDir := Dir()
+	summary := &BootstrapSummary{}
+	var b bytes.Buffer
+	b.WriteString(bootstrapHeader(time.Now()))
+
+	b.WriteString("\n# Store\n")
+	err = filepath.WalkDir(storeDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(storeDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		switch {
+		case rel == ".":
+			return nil
+		case rel == ".git":
+			return filepath.SkipDir
+		case path == ManifestPath():
+			return nil
+		case d.IsDir():
+			fmt.Fprintf(&b, "mkdir -p %s\n", storePath(rel))
+			return nil
+		}
+		if err := writeBootstrapFile(&b, storeDir, path, rel); err != nil {
+			return err
+		}
+		summary.Files++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("exporting store: %w", err)
+	}
+
+	exported := &Manifest{Version: ManifestVersion}
+	for _, a := range m.Agents {
+		a.ConfigDir = homeRelative(a.ConfigDir, home, homeToken)
+		a.Binary = ""
+		exported.Agents = append(exported.Agents, a)
+	}
+	b.WriteString("\n# Links\n")
+	for _, l := range m.Links {
+		if l.Project != "" {
+			fmt.Fprintf(&b, "# skipped project link %s (project %s)\n", l.Target, l.Project)
+			summary.Skipped++
+			continue
+		}
+		mode := "symlink"
+		if l.Mode == "copy" {
+			mode = "copy"
+		}
+		fmt.Fprintf(&b, "place %s %s %s\n", storePath(l.Source), hostPath(l.Target, home), mode)
+		l.Target = homeRelative(l.Target, home, homeToken)
+		exported.Links = append(exported.Links, l)
+		summary.Links++
+	}
+
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling manifest: %w", err)
+	}
+	manifest := strings.NewReplacer(`\`, `\\`, "$", `\$`, "`", "\\`").Replace(string(data))
+	manifest = strings.ReplaceAll(manifest, homeToken, "${HOME}")
+	b.WriteString("\n# Manifest, so mine picks up where this script left off\n")
+	fmt.Fprintf(&b, "cat > \"$STORE\"/.mine-agents <<%s\n%s\n%s\n", heredocEnd, manifest, heredocEnd)
+	b.WriteString("\necho \"mine agents: store restored to $STORE\"\n")
+
+	if _, err := w.Write(b.Bytes()); err != nil {
+		return nil, fmt.Errorf("writing bootstrap script: %w", err)
+	}
+	return summary, nil
+}
+
+// bootstrapHeader is the start of a bootstrap script: where the store goes
+// and the place helper every link uses.
+func bootstrapHeader(now time.Time) string {
+	return `#!/bin/sh
+# Recreates a mine agents store and its links. Generated by
+# 'mine agents export --bootstrap' on ` + now.Format("2006-01-02") + ` — re-export rather than
+# editing. Anything in the way of a link is moved aside to <path>.bak.
+set -eu
+
+STORE="${XDG_DATA_HOME:-$HOME/.local/share}/mine/agents"
+mkdir -p "$STORE"
+
+# place <source> <target> <symlink|copy>
+place() {
+	mkdir -p "$(dirname "$2")"
+	if [ -L "$2" ]; then
+		rm -f "$2"
+	elif [ -e "$2" ]; then
+		rm -rf "$2.bak"
+		mv "$2" "$2.bak"
+	fi
+	if [ "$3" = copy ]; then
+		cp -RL "$1" "$2"
+	else
+		ln -s "$1" "$2"
+	fi
+}
+`
+}
+
+// writeBootstrapFile appends the commands that recreate the store file at
+// path: a symlink, a here-document for text, or base64 for anything else.
+func writeBootstrapFile(b *bytes.Buffer, storeDir, path, rel string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		dest, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		target := shellQuote(dest)
+		if filepath.IsAbs(dest) && withinPath(dest, storeDir) {
+			r, _ := filepath.Rel(storeDir, dest)
+			target = storePath(filepath.ToSlash(r))
+		}
+		fmt.Fprintf(b, "ln -sfn %s %s\n", target, storePath(rel))
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(data) == 0:
+		fmt.Fprintf(b, ": > %s\n", storePath(rel))
+	case isHeredocSafe(data):
+		fmt.Fprintf(b, "cat > %s <<'%s'\n%s%s\n", storePath(rel), heredocEnd, data, heredocEnd)
+	default:
+		fmt.Fprintf(b, "base64 -d > %s <<'%s'\n", storePath(rel), heredocEnd)
+		enc := base64.StdEncoding.EncodeToString(data)
+		for len(enc) > 76 {
+			b.WriteString(enc[:76] + "\n")
+			enc = enc[76:]
+		}
+		fmt.Fprintf(b, "%s\n%s\n", enc, heredocEnd)
+	}
+	if info.Mode()&0o111 != 0 {
+		fmt.Fprintf(b, "chmod +x %s\n", storePath(rel))
+	}
+	return nil
+}
+
+// isHeredocSafe reports whether data can be written verbatim in a quoted
+// here-document: UTF-8 text, ending in a newline, that never contains the
+// closing line.
+func isHeredocSafe(data []byte) bool {
+	if !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 || !bytes.HasSuffix(data, []byte("\n")) {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == heredocEnd {
+			return false
+		}
+	}
+	return true
+}
+
+// storePath returns the shell word for rel within the exported store.
+func storePath(rel string) string {
+	return `"$STORE"/` + shellQuote(rel)
+}
+
+// hostPath returns the shell word for path, rooted at $HOME when it is
+// inside home.
+func hostPath(path, home string) string {
+	if rel, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return `"$HOME"/` + shellQuote(filepath.ToSlash(rel))
+	}
+	return shellQuote(path)
+}
+
+// homeRelative replaces a leading home directory in path with prefix.
+func homeRelative(path, home, prefix string) string {
+	if rel, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return prefix + "/" + filepath.ToSlash(rel)
+	}
+	if path == home {
+		return prefix
+	}
+	return path
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
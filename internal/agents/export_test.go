@@ -0,0 +1,101 @@
+package agents
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsHeredocSafe(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"text\n", true},
+		{"no trailing newline", false},
+		{"a\n" + heredocEnd + "\nb\n", false},
+		{"bin\x00ary\n", false},
+	}
+	for _, tt := range tests {
+		if got := isHeredocSafe([]byte(tt.in)); got != tt.want {
+			t.Errorf("isHeredocSafe(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExportBootstrap_RecreatesStoreAndLinks(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Shared\n")
+	writeStoreFile(t, storeDir, "instructions/claude.md", "Claude's $HOME isn't expanded.\n")
+	writeStoreFile(t, storeDir, "skills/tidy/SKILL.md", "---\nname: tidy\n---\n")
+	writeStoreFile(t, storeDir, "skills/tidy/run.sh", "#!/bin/sh\necho tidy")
+	if err := os.Chmod(filepath.Join(storeDir, "skills", "tidy", "run.sh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	makeDetectedAgent(t, "claude", filepath.Join(homeDir, ".claude"))
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	writeStoreFile(t, storeDir, "projects/proj/AGENTS.md", "# Proj\n")
+	if _, err := LinkProject("proj", t.TempDir(), LinkOptions{Agent: "claude"}); err != nil {
+		t.Fatalf("LinkProject: %v", err)
+	}
+
+	var script bytes.Buffer
+	summary, err := ExportBootstrap(&script)
+	if err != nil {
+		t.Fatalf("ExportBootstrap: %v", err)
+	}
+	if summary.Links == 0 || summary.Files == 0 || summary.Skipped != 1 {
+		t.Fatalf("summary = %+v, want files, links, and the project link skipped", summary)
+	}
+
+	newHome := t.TempDir()
+	cmd := exec.Command("sh")
+	cmd.Stdin = &script
+	cmd.Env = []string{"HOME=" + newHome, "PATH=" + os.Getenv("PATH")}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("running script: %v\n%s", err, out)
+	}
+
+	newStore := filepath.Join(newHome, ".local", "share", "mine", "agents")
+	claudeMD := filepath.Join(newHome, ".claude", "CLAUDE.md")
+	dest, err := os.Readlink(claudeMD)
+	if err != nil {
+		t.Fatalf("CLAUDE.md not linked: %v", err)
+	}
+	if want := filepath.Join(newStore, "rendered", "claude.md"); dest != want {
+		t.Errorf("CLAUDE.md → %q, want %q", dest, want)
+	}
+	if got := readString(t, claudeMD); got != "# Shared\n\nClaude's $HOME isn't expanded.\n" {
+		t.Errorf("CLAUDE.md = %q", got)
+	}
+	script2 := filepath.Join(newHome, ".claude", "skills", "tidy", "run.sh")
+	if got := readString(t, script2); got != "#!/bin/sh\necho tidy" {
+		t.Errorf("run.sh = %q", got)
+	}
+	if info, err := os.Stat(script2); err != nil || info.Mode()&0o111 == 0 {
+		t.Errorf("run.sh not executable: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(newStore, ".mine-agents"))
+	if err != nil {
+		t.Fatalf("manifest not written: %v", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	for _, l := range m.Links {
+		if !strings.HasPrefix(l.Target, newHome+"/") {
+			t.Errorf("manifest target %q not under the new home", l.Target)
+		}
+	}
+}
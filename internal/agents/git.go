@@ -1,7 +1,10 @@
 package agents
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -58,7 +61,7 @@ func Commit(message string) (string, error) {
 		return "", fmt.Errorf("git status: %w", err)
 	}
 	if strings.TrimSpace(status) == "" {
-		return "", fmt.Errorf("nothing to commit — all files up to date")
+		return "", ErrNothingToCommit
 	}
 
 	if _, err := gitCmd(dir, "commit", "-m", message); err != nil {
@@ -121,3 +124,80 @@ func Log(file string) ([]LogEntry, error) {
 	}
 	return entries, nil
 }
+
+// RollbackResult describes what Rollback did.
+type RollbackResult struct {
+	Hash        string // short hash of the rollback snapshot
+	Target      string // short hash of the snapshot rolled back to
+	Message     string // message of the snapshot rolled back to
+	Previous    string // short hash of the state before rolling back — roll back to it to undo
+	Saved       bool   // uncommitted changes were snapshotted before rolling back
+	CopiedLinks int    // copy-mode links re-distributed
+	Relink      bool   // rendered links are stale and need `mine agents link`
+}
+
+// Rollback restores the whole store to snapshot id and records the result as
+// a new snapshot, so a rollback can itself be undone. Uncommitted changes are
+// snapshotted first rather than lost. The manifest and .gitignore are left
+// as they are: they describe this machine's links, not agent content.
+func Rollback(id string) (*RollbackResult, error) {
+	dir := Dir()
+	if !HasCommits() {
+		return nil, ErrNoVersionHistory
+	}
+	if id == "" || strings.HasPrefix(id, "-") {
+		return nil, fmt.Errorf("invalid snapshot id %q", id)
+	}
+
+	out, err := gitCmd(dir, "log", "-1", "--format=%H|%h|%s", id+"^{commit}", "--")
+	if err != nil {
+		return nil, fmt.Errorf("unknown snapshot %q — see `mine agents log`", id)
+	}
+	parts := strings.SplitN(strings.TrimSpace(out), "|", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("unknown snapshot %q — see `mine agents log`", id)
+	}
+	result := &RollbackResult{Target: parts[1], Message: parts[2]}
+
+	if _, err := Commit("snapshot before rollback to " + result.Target); err == nil {
+		result.Saved = true
+	} else if !errors.Is(err, ErrNothingToCommit) {
+		return nil, err
+	}
+	head, err := gitCmd(dir, "rev-parse", "--short", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("getting current snapshot: %w", err)
+	}
+	result.Previous = strings.TrimSpace(head)
+
+	if _, err := gitCmd(dir, "restore", "--source="+parts[0], "--staged", "--worktree", "--",
+		".", ":(exclude)"+filepath.Base(ManifestPath()), ":(exclude).gitignore"); err != nil {
+		return nil, fmt.Errorf("restoring snapshot %s: %w", result.Target, err)
+	}
+	hash, err := Commit(fmt.Sprintf("rollback: restore %s (%s)", result.Target, result.Message))
+	if errors.Is(err, ErrNothingToCommit) {
+		return nil, fmt.Errorf("store already matches snapshot %s", result.Target)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Hash = hash
+
+	manifest, err := ReadManifest()
+	if err != nil {
+		return result, fmt.Errorf("reading manifest: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return result, fmt.Errorf("determining home directory: %w", err)
+	}
+	if result.CopiedLinks, err = redistributeCopies(manifest, dir, home); err != nil {
+		return result, err
+	}
+	for _, link := range manifest.Links {
+		if strings.HasPrefix(link.Source, renderedDir+"/") && renderedStale(dir, link.Source) {
+			result.Relink = true
+		}
+	}
+	return result, nil
+}
@@ -1,6 +1,7 @@
 package agents
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -134,3 +135,74 @@ func TestHasCommits(t *testing.T) {
 		t.Error("HasCommits() = false after Init(), want true")
 	}
 }
+
+func TestRollback_RestoresStoreAndCanBeUndone(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	makeDetectedAgent(t, "claude", filepath.Join(homeDir, ".claude"))
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "good\n")
+	if _, err := Link(LinkOptions{Copy: true}); err != nil {
+		t.Fatal(err)
+	}
+	good, err := Commit("good instructions")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "worse\n")
+	writeStoreFile(t, storeDir, "skills/new/SKILL.md", "# New\n")
+	if _, err := Commit("worse instructions"); err != nil {
+		t.Fatal(err)
+	}
+	// Uncommitted edits are saved before rolling back, not lost.
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "worst\n")
+
+	result, err := Rollback(good)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if !result.Saved || result.Target != good || result.Message != "good instructions" {
+		t.Errorf("result = %+v", result)
+	}
+	if data, _ := os.ReadFile(filepath.Join(storeDir, "instructions", "AGENTS.md")); string(data) != "good\n" {
+		t.Errorf("store AGENTS.md = %q, want good", data)
+	}
+	if fileExists(filepath.Join(storeDir, "skills", "new", "SKILL.md")) {
+		t.Error("file added after the snapshot survived the rollback")
+	}
+	if result.CopiedLinks != 1 {
+		t.Errorf("CopiedLinks = %d, want 1", result.CopiedLinks)
+	}
+	if data, _ := os.ReadFile(filepath.Join(homeDir, ".claude", "CLAUDE.md")); string(data) != "good\n" {
+		t.Errorf("copied CLAUDE.md = %q, want good", data)
+	}
+	// The manifest describes this machine's links, so it isn't rolled back.
+	if m, _ := ReadManifest(); len(m.Links) != 1 || len(m.Agents) != 1 {
+		t.Errorf("manifest changed: %+v", m)
+	}
+
+	if _, err := Rollback(result.Previous); err != nil {
+		t.Fatalf("undo Rollback() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(storeDir, "instructions", "AGENTS.md")); string(data) != "worst\n" {
+		t.Errorf("after undo AGENTS.md = %q, want worst", data)
+	}
+}
+
+func TestRollback_Errors(t *testing.T) {
+	setupEnv(t)
+	if _, err := Rollback("HEAD"); !errors.Is(err, ErrNoVersionHistory) {
+		t.Errorf("before init: error = %v, want ErrNoVersionHistory", err)
+	}
+
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"deadbeef", "--help", ""} {
+		if _, err := Rollback(id); err == nil {
+			t.Errorf("Rollback(%q) error = nil, want error", id)
+		}
+	}
+	if _, err := Rollback("HEAD"); err == nil || !strings.Contains(err.Error(), "already matches") {
+		t.Errorf("Rollback(HEAD) error = %v, want already matches", err)
+	}
+}
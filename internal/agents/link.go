@@ -11,6 +11,9 @@ type LinkOptions struct {
 	Agent string // filter to a single agent name; empty means all detected agents
 	Copy  bool   // create file copies instead of symlinks
 	Force bool   // overwrite existing non-symlink files
+	// Dest links into this directory as if it were the home directory, e.g.
+	// a devcontainer's mounted home; empty means the real home directory.
+	Dest string
 }
 
 // UnlinkOptions controls the behavior of the Unlink operation.
@@ -41,6 +44,10 @@ type UnlinkAction struct {
 //
 // Only detected agents are processed. Skips config types that don't exist in the
 // store (e.g. empty skills/). Records all results in the manifest.
+//
+// With opts.Dest, agents' config locations are rooted at Dest instead of the
+// home directory, and opts.Agent may name any supported agent — the one in
+// the container needn't be installed here.
 func Link(opts LinkOptions) ([]LinkAction, error) {
 	if !IsInitialized() {
 		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
@@ -51,9 +58,9 @@ func Link(opts LinkOptions) ([]LinkAction, error) {
 		return nil, fmt.Errorf("reading manifest: %w", err)
 	}
 
-	home, err := os.UserHomeDir()
+	home, err := linkRoot(opts.Dest)
 	if err != nil {
-		return nil, fmt.Errorf("determining home directory: %w", err)
+		return nil, err
 	}
 
 	storeDir := Dir()
@@ -65,8 +72,8 @@ func Link(opts LinkOptions) ([]LinkAction, error) {
 			continue
 		}
 
-		// Only process detected agents.
-		if !isAgentDetected(m, spec.Name) {
+		// Only process detected agents, or the one named for another root.
+		if !isAgentDetected(m, spec.Name) && (opts.Dest == "" || opts.Agent != spec.Name) {
 			continue
 		}
 
@@ -81,6 +88,27 @@ func Link(opts LinkOptions) ([]LinkAction, error) {
 	return allActions, nil
 }
 
+// linkRoot returns the directory agents' config locations are rooted at:
+// dest when it's set, otherwise the home directory.
+func linkRoot(dest string) (string, error) {
+	if dest == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("determining home directory: %w", err)
+		}
+		return home, nil
+	}
+	abs, err := filepath.Abs(dest)
+	if err != nil {
+		return "", fmt.Errorf("resolving destination: %w", err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("destination %s is not a directory", abs)
+	}
+	return abs, nil
+}
+
 // isAgentDetected returns true if the named agent is marked detected in the manifest.
 func isAgentDetected(m *Manifest, name string) bool {
 	for _, a := range m.Agents {
@@ -91,51 +119,77 @@ func isAgentDetected(m *Manifest, name string) bool {
 	return false
 }
 
-// linkAgent processes all link targets for a single agent spec.
+// linkAgent processes all link targets for a single agent spec, except
+// assets disabled for the agent.
 func linkAgent(storeDir string, spec linkSpec, opts LinkOptions, m *Manifest) []LinkAction {
+	return linkAgentAssets(storeDir, spec, opts, m, func(asset string) bool {
+		return !isAssetDisabled(m, spec.Name, asset)
+	})
+}
+
+// linkAgentAssets links the assets want accepts for a single agent spec.
+func linkAgentAssets(storeDir string, spec linkSpec, opts LinkOptions, m *Manifest, want func(asset string) bool) []LinkAction {
 	var actions []LinkAction
 
-	// 1. Instructions file — only if it exists in the store.
-	instrSource := filepath.Join(storeDir, "instructions", "AGENTS.md")
-	if fileExists(instrSource) {
+	// 1. Instructions file — only if it exists in the store. An
+	// instructions/{agent}.md fragment gets the agent its own composed file.
+	if want(AssetInstructions) {
 		instrTarget := filepath.Join(spec.ConfigDir, spec.InstructionFilename)
-		a := createFileLink(instrSource, "instructions/AGENTS.md", instrTarget, spec.Name, opts, m)
-		actions = append(actions, a)
+		instrRel, ok, err := instructionSource(storeDir, spec.Name)
+		if err != nil {
+			actions = append(actions, LinkAction{
+				Source: "instructions/" + spec.Name + ".md",
+				Target: instrTarget,
+				Agent:  spec.Name,
+				Status: "skipped",
+				Err:    err,
+			})
+		} else if ok {
+			a := createFileLink(filepath.Join(storeDir, instrRel), instrRel, instrTarget, spec.Name, opts, m)
+			actions = append(actions, a)
+		}
 	}
 
-	// 2. Skills directory — only if store's skills/ is non-empty and agent supports it.
-	if spec.SkillsDir != "" {
-		skillsSource := filepath.Join(storeDir, "skills")
-		if dirNonEmpty(skillsSource) {
-			a := createDirLink(skillsSource, "skills", spec.SkillsDir, spec.Name, opts, m)
+	// 2. Skills directory — only if store's skills/ is non-empty and agent
+	// supports it. Skills limited to other agents are left out.
+	if spec.SkillsDir != "" && want(AssetSkills) {
+		skillsRel, ok, err := skillsSource(storeDir, spec.Name)
+		if err != nil {
+			actions = append(actions, LinkAction{
+				Source: skillsRel,
+				Target: spec.SkillsDir,
+				Agent:  spec.Name,
+				Status: "skipped",
+				Err:    err,
+			})
+		} else if ok {
+			a := createDirLink(filepath.Join(storeDir, skillsRel), skillsRel, spec.SkillsDir, spec.Name, opts, m)
 			actions = append(actions, a)
 		}
 	}
 
-	// 3. Commands directory — only for agents that support it (Claude) and if non-empty.
-	if spec.CommandsDir != "" {
-		cmdSource := filepath.Join(storeDir, "commands")
-		if dirNonEmpty(cmdSource) {
-			a := createDirLink(cmdSource, "commands", spec.CommandsDir, spec.Name, opts, m)
+	// 3. Commands directory — only for agents that support it and if
+	// non-empty, converted to the agent's format where it differs.
+	if want(AssetCommands) {
+		if a, ok := linkCommands(storeDir, spec, opts, m); ok {
 			actions = append(actions, a)
 		}
 	}
 
-	// 4. Settings file — only if settings/{agent}.json exists in the store.
+	// 4. Settings file — only for agents with a JSON settings file and if
+	// settings/{agent}.json exists in the store. Agents that keep MCP servers
+	// in their settings get them linked in step 5 instead.
 	settingsSource := filepath.Join(storeDir, "settings", spec.Name+".json")
-	if fileExists(settingsSource) {
+	if spec.SettingsFilename != "" && spec.MCPFormat != mcpFormatSettings && fileExists(settingsSource) && want(AssetSettings) {
 		settingsTarget := filepath.Join(spec.ConfigDir, spec.SettingsFilename)
 		a := createFileLink(settingsSource, "settings/"+spec.Name+".json", settingsTarget, spec.Name, opts, m)
 		actions = append(actions, a)
 	}
 
-	// 5. MCP config — only for agents that support it and if mcp/.mcp.json exists.
-	if spec.MCPConfigPath != "" {
-		mcpSource := filepath.Join(storeDir, "mcp", ".mcp.json")
-		if fileExists(mcpSource) {
-			a := createFileLink(mcpSource, "mcp/.mcp.json", spec.MCPConfigPath, spec.Name, opts, m)
-			actions = append(actions, a)
-		}
+	// 5. MCP config — only for agents that support it, in the agent's format.
+	// Agents that keep MCP servers in their settings share one file for both.
+	if want(AssetMCP) && (spec.MCPFormat != mcpFormatSettings || want(AssetSettings)) {
+		actions = append(actions, linkMCP(storeDir, spec, opts, m)...)
 	}
 
 	return actions
@@ -162,7 +216,10 @@ func createFileLink(sourcePath, sourceRel, target, agentName string, opts LinkOp
 		return action
 	}
 
-	existed, alreadyLinked, safeErr := checkFileSafety(sourcePath, target, opts.Force)
+	// A link to another file in the store (e.g. shared instructions before a
+	// per-agent fragment was added) is ours to repoint.
+	force := opts.Force || isAlreadyManagedByStore(target, Dir())
+	existed, alreadyLinked, safeErr := checkFileSafety(sourcePath, target, force)
 	if safeErr != nil {
 		action.Status = "skipped"
 		action.Err = safeErr
@@ -222,7 +279,9 @@ func createDirLink(sourcePath, sourceRel, target, agentName string, opts LinkOpt
 		return action
 	}
 
-	existed, alreadyLinked, safeErr := checkDirSafety(sourcePath, target, opts.Force)
+	// As with files, a link to elsewhere in the store is ours to repoint.
+	force := opts.Force || isAlreadyManagedByStore(target, Dir())
+	existed, alreadyLinked, safeErr := checkDirSafety(sourcePath, target, force)
 	if safeErr != nil {
 		action.Status = "skipped"
 		action.Err = safeErr
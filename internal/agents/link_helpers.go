@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // fileExists reports whether path exists and is a regular file (not a directory or symlink).
@@ -20,25 +21,38 @@ func dirNonEmpty(path string) bool {
 
 // upsertManifestLink adds or updates a link entry in the manifest.
 func upsertManifestLink(m *Manifest, source, target, agentName, mode string) {
+	linkedAt := time.Now().UTC()
 	for i, l := range m.Links {
 		if l.Target == target {
 			m.Links[i] = LinkEntry{
-				Source: source,
-				Target: target,
-				Agent:  agentName,
-				Mode:   mode,
+				Source:   source,
+				Target:   target,
+				Agent:    agentName,
+				Mode:     mode,
+				LinkedAt: linkedAt,
 			}
 			return
 		}
 	}
 	m.Links = append(m.Links, LinkEntry{
-		Source: source,
-		Target: target,
-		Agent:  agentName,
-		Mode:   mode,
+		Source:   source,
+		Target:   target,
+		Agent:    agentName,
+		Mode:     mode,
+		LinkedAt: linkedAt,
 	})
 }
 
+// setManifestLinkProject associates the manifest entry for target with a project.
+func setManifestLinkProject(m *Manifest, target, project string) {
+	for i, l := range m.Links {
+		if l.Target == target {
+			m.Links[i].Project = project
+			return
+		}
+	}
+}
+
 // copyFile copies src to dst, preserving file permissions.
 func copyFile(src, dst string) error {
 	in, err := os.Open(src)
@@ -80,10 +94,15 @@ func copyDir(src, dst string) error {
 			return os.MkdirAll(target, 0o755)
 		}
 
-		info, err := d.Info()
+		// Follow symlinks, such as those in rendered/skills/<agent>/, so
+		// the copy holds their content.
+		info, err := os.Stat(path)
 		if err != nil {
 			return err
 		}
+		if info.IsDir() {
+			return copyDir(path, target)
+		}
 
 		return copyFileMode(path, target, info.Mode())
 	})
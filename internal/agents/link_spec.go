@@ -6,11 +6,13 @@ import "path/filepath"
 type linkSpec struct {
 	Name                string // agent identifier, e.g. "claude"
 	ConfigDir           string // agent's config directory, e.g. ~/.claude
-	InstructionFilename string // filename for instructions file, e.g. "CLAUDE.md"
+	InstructionFilename string // instructions file path within ConfigDir, e.g. "CLAUDE.md"
 	SkillsDir           string // symlink target for skills/, empty if not supported
 	CommandsDir         string // symlink target for commands/, empty if not supported
-	SettingsFilename    string // filename for settings JSON, e.g. "settings.json"
-	MCPConfigPath       string // absolute path for .mcp.json, empty if not applicable
+	CommandsFormat      string // how commands reach CommandsDir; empty links the markdown as-is
+	SettingsFilename    string // filename for settings JSON, e.g. "settings.json"; empty if not supported
+	MCPConfigPath       string // absolute path of the agent's MCP config, empty if not applicable
+	MCPFormat           string // how MCP servers reach MCPConfigPath; empty links mcp/.mcp.json as-is
 }
 
 // buildLinkRegistry returns the canonical per-agent link spec list.
@@ -31,27 +33,70 @@ func buildLinkRegistry(home string) []linkSpec {
 			ConfigDir:           filepath.Join(home, ".codex"),
 			InstructionFilename: "AGENTS.md",
 			SkillsDir:           filepath.Join(home, ".codex", "skills"),
-			CommandsDir:         "",
+			CommandsDir:         filepath.Join(home, ".codex", "prompts"),
 			SettingsFilename:    "settings.json",
-			MCPConfigPath:       "",
+			MCPConfigPath:       filepath.Join(home, ".codex", "config.toml"),
+			MCPFormat:           mcpFormatTOML,
 		},
 		{
 			Name:                "gemini",
 			ConfigDir:           filepath.Join(home, ".gemini"),
 			InstructionFilename: "GEMINI.md",
 			SkillsDir:           filepath.Join(home, ".gemini", "skills"),
-			CommandsDir:         "",
+			CommandsDir:         filepath.Join(home, ".gemini", "commands"),
+			CommandsFormat:      commandsFormatTOML,
 			SettingsFilename:    "settings.json",
-			MCPConfigPath:       "",
+			MCPConfigPath:       filepath.Join(home, ".gemini", "settings.json"),
+			MCPFormat:           mcpFormatSettings,
 		},
 		{
 			Name:                "opencode",
 			ConfigDir:           filepath.Join(home, ".config", "opencode"),
 			InstructionFilename: "AGENTS.md",
 			SkillsDir:           filepath.Join(home, ".config", "opencode", "skills"),
-			CommandsDir:         "",
+			CommandsDir:         filepath.Join(home, ".config", "opencode", "command"),
 			SettingsFilename:    "settings.json",
 			MCPConfigPath:       "",
 		},
+		{
+			// Cursor has no global instructions file; a rule in ~/.cursor/rules
+			// is the closest equivalent.
+			Name:                "cursor",
+			ConfigDir:           filepath.Join(home, ".cursor"),
+			InstructionFilename: filepath.Join("rules", "mine.md"),
+			SkillsDir:           "",
+			CommandsDir:         filepath.Join(home, ".cursor", "commands"),
+			SettingsFilename:    "",
+			MCPConfigPath:       filepath.Join(home, ".cursor", "mcp.json"),
+		},
+		{
+			// Aider only reads conventions files named by `read:` in
+			// ~/.aider.conf.yml, which stays the user's to edit.
+			Name:                "aider",
+			ConfigDir:           filepath.Join(home, ".aider"),
+			InstructionFilename: "CONVENTIONS.md",
+			SkillsDir:           "",
+			CommandsDir:         "",
+			SettingsFilename:    "",
+			MCPConfigPath:       "",
+		},
+		{
+			Name:                "windsurf",
+			ConfigDir:           filepath.Join(home, ".codeium", "windsurf"),
+			InstructionFilename: filepath.Join("memories", "global_rules.md"),
+			SkillsDir:           "",
+			CommandsDir:         "",
+			SettingsFilename:    "",
+			MCPConfigPath:       filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"),
+		},
+		{
+			Name:                "copilot",
+			ConfigDir:           filepath.Join(home, ".copilot"),
+			InstructionFilename: "copilot-instructions.md",
+			SkillsDir:           "",
+			CommandsDir:         "",
+			SettingsFilename:    "config.json",
+			MCPConfigPath:       filepath.Join(home, ".copilot", "mcp-config.json"),
+		},
 	}
 }
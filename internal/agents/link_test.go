@@ -63,7 +63,7 @@ func makeDetectedAgent(t *testing.T, name, configDir string) {
 
 func TestBuildLinkRegistry_ContainsAllAgents(t *testing.T) {
 	specs := buildLinkRegistry("/home/testuser")
-	wantNames := []string{"claude", "codex", "gemini", "opencode"}
+	wantNames := []string{"claude", "codex", "gemini", "opencode", "cursor", "aider", "windsurf", "copilot"}
 
 	if len(specs) != len(wantNames) {
 		t.Fatalf("buildLinkRegistry() returned %d specs, want %d", len(specs), len(wantNames))
@@ -105,6 +105,10 @@ func TestBuildLinkRegistry_InstructionFilenames(t *testing.T) {
 		"codex":    "AGENTS.md",
 		"gemini":   "GEMINI.md",
 		"opencode": "AGENTS.md",
+		"cursor":   "rules/mine.md",
+		"aider":    "CONVENTIONS.md",
+		"windsurf": "memories/global_rules.md",
+		"copilot":  "copilot-instructions.md",
 	}
 
 	for _, s := range specs {
@@ -118,6 +122,22 @@ func TestBuildLinkRegistry_InstructionFilenames(t *testing.T) {
 	}
 }
 
+func TestBuildLinkRegistry_MCPConfigPaths(t *testing.T) {
+	home := "/home/testuser"
+	want := map[string]string{
+		"claude":   filepath.Join(home, ".claude", ".mcp.json"),
+		"cursor":   filepath.Join(home, ".cursor", "mcp.json"),
+		"windsurf": filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"),
+		"copilot":  filepath.Join(home, ".copilot", "mcp-config.json"),
+		"aider":    "",
+	}
+	for _, s := range buildLinkRegistry(home) {
+		if w, ok := want[s.Name]; ok && s.MCPConfigPath != w {
+			t.Errorf("agent %q MCPConfigPath = %q, want %q", s.Name, s.MCPConfigPath, w)
+		}
+	}
+}
+
 // --- checkFileSafety ---
 
 func TestCheckFileSafety_TargetMissing(t *testing.T) {
@@ -457,6 +477,43 @@ func TestLink_CreatesInstructionSymlink(t *testing.T) {
 	}
 }
 
+func TestLink_NestedInstructionPathAndNoSettings(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Instructions\n")
+	writeStoreFile(t, storeDir, "settings/aider.json", "{}\n")
+
+	windsurfDir := filepath.Join(homeDir, ".codeium", "windsurf")
+	makeDetectedAgent(t, "windsurf", windsurfDir)
+	aiderDir := filepath.Join(homeDir, ".aider")
+	makeDetectedAgent(t, "aider", aiderDir)
+
+	actions, err := Link(LinkOptions{})
+	if err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	for _, a := range actions {
+		if a.Err != nil {
+			t.Errorf("action %+v failed: %v", a, a.Err)
+		}
+		if a.Agent == "aider" && a.Source == "settings/aider.json" {
+			t.Error("aider has no JSON settings file, want no settings link")
+		}
+	}
+
+	for _, target := range []string{
+		filepath.Join(windsurfDir, "memories", "global_rules.md"),
+		filepath.Join(aiderDir, "CONVENTIONS.md"),
+	} {
+		dest, err := os.Readlink(target)
+		if err != nil {
+			t.Fatalf("Readlink(%q) error = %v", target, err)
+		}
+		if want := filepath.Join(storeDir, "instructions", "AGENTS.md"); dest != want {
+			t.Errorf("%s points to %q, want %q", target, dest, want)
+		}
+	}
+}
+
 func TestLink_CopyMode_CreatesFileCopy(t *testing.T) {
 	storeDir, homeDir := setupLinkEnv(t)
 
@@ -1171,3 +1228,39 @@ func TestUnlink_PreservesFilePermissions(t *testing.T) {
 		t.Errorf("file permissions after unlink = %04o, want %04o", got, want)
 	}
 }
+
+func TestLink_Dest(t *testing.T) {
+	_, homeDir := setupLinkEnv(t)
+	makeDetectedAgent(t, "claude", filepath.Join(homeDir, ".claude"))
+	dest := filepath.Join(t.TempDir(), "container-home")
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, err := Link(LinkOptions{Dest: dest, Copy: true})
+	if err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Err != nil {
+		t.Fatalf("actions = %+v, want claude's instructions", actions)
+	}
+	if _, err := os.Lstat(filepath.Join(homeDir, ".claude", "CLAUDE.md")); !os.IsNotExist(err) {
+		t.Error("Link with Dest linked into the home directory")
+	}
+	target := filepath.Join(dest, ".claude", "CLAUDE.md")
+	if info, err := os.Lstat(target); err != nil || !info.Mode().IsRegular() {
+		t.Fatalf("copy at %s: %v", target, err)
+	}
+
+	// An agent that isn't detected here can still be linked by name.
+	if _, err := Link(LinkOptions{Dest: dest, Agent: "codex"}); err != nil {
+		t.Fatalf("Link codex: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(dest, ".codex", "AGENTS.md")); err != nil {
+		t.Errorf("codex not linked into dest: %v", err)
+	}
+
+	if _, err := Link(LinkOptions{Dest: filepath.Join(dest, "missing")}); err == nil {
+		t.Error("Link with a missing Dest = nil, want error")
+	}
+}
@@ -0,0 +1,281 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Checks reported by Lint.
+const (
+	CheckOverBudget = "over-budget" // an agent's instructions exceed the token budget
+	CheckDuplicate  = "duplicate"   // the same section appears in several instruction files
+)
+
+// minDuplicateLen is how long (in characters, whitespace collapsed) a
+// section body must be before repeating it is worth reporting.
+const minDuplicateLen = 80
+
+// InstructionSize is the estimated size of the instructions one agent, or
+// one project, loads.
+type InstructionSize struct {
+	Agent   string // empty for project instructions
+	Project string // empty for an agent's global instructions
+	Sources []string
+	Tokens  int
+}
+
+// LintResult holds what 'mine agents lint' measured and found.
+type LintResult struct {
+	Budget   int
+	Sizes    []InstructionSize
+	Findings []Finding
+}
+
+// Lint estimates the tokens each detected agent's instructions (shared file,
+// fragment, and placeholders filled in) and each project's instructions
+// take, flags those over budget with the sections worth moving into skills,
+// and flags sections repeated across instruction files.
+func Lint(budget int) (*LintResult, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	if budget <= 0 {
+		return nil, fmt.Errorf("token budget must be positive, got %d", budget)
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	storeDir := Dir()
+	result := &LintResult{Budget: budget}
+	measure := func(size InstructionSize, content string) {
+		size.Tokens = estimateTokens(content)
+		result.Sizes = append(result.Sizes, size)
+		if size.Tokens <= budget {
+			return
+		}
+		f := Finding{
+			Check:  CheckOverBudget,
+			Agent:  size.Agent,
+			Target: strings.Join(size.Sources, " + "),
+			Message: fmt.Sprintf("~%d tokens, %d over the %d-token budget",
+				size.Tokens, size.Tokens-budget, budget),
+		}
+		if s, ok := largestSection(content); ok {
+			f.Hint = fmt.Sprintf("move %q (~%d tokens) into a skill: mine agents skill add %s",
+				s.heading, estimateTokens(s.body), skillNameFor(s.heading))
+		}
+		result.Findings = append(result.Findings, f)
+	}
+
+	measured := false
+	for _, spec := range buildLinkRegistry("") {
+		if !isAgentDetected(m, spec.Name) {
+			continue
+		}
+		rels := instructionSources(storeDir, spec.Name)
+		if len(rels) == 0 {
+			continue
+		}
+		content, err := renderInstructions(storeDir, spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		measure(InstructionSize{Agent: spec.Name, Sources: rels}, content)
+		measured = true
+	}
+	if !measured {
+		// No agents detected yet: the shared file is what they'd all load.
+		if data, err := os.ReadFile(filepath.Join(storeDir, "instructions", "AGENTS.md")); err == nil {
+			measure(InstructionSize{Sources: []string{"instructions/AGENTS.md"}}, string(data))
+		}
+	}
+
+	projects, _ := filepath.Glob(filepath.Join(storeDir, "projects", "*", "AGENTS.md"))
+	for _, p := range projects {
+		name := filepath.Base(filepath.Dir(p))
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading project %s instructions: %w", name, err)
+		}
+		measure(InstructionSize{Project: name, Sources: []string{ProjectInstructionsPath(name)}}, string(data))
+	}
+
+	dups, err := duplicateSections(storeDir)
+	if err != nil {
+		return nil, err
+	}
+	result.Findings = append(result.Findings, dups...)
+	return result, nil
+}
+
+// instructionSources returns the store files agent's instructions are
+// built from, if any.
+func instructionSources(storeDir, agent string) []string {
+	var rels []string
+	for _, rel := range []string{"instructions/AGENTS.md", "instructions/" + agent + ".md"} {
+		if fileExists(filepath.Join(storeDir, filepath.FromSlash(rel))) {
+			rels = append(rels, rel)
+		}
+	}
+	return rels
+}
+
+// estimateTokens approximates how many tokens content costs a model: about
+// four characters each, the usual rule of thumb for English prose and code.
+func estimateTokens(content string) int {
+	return (utf8.RuneCountInString(content) + 3) / 4
+}
+
+// section is one heading and the text under it, up to the next heading.
+type section struct {
+	heading string // the heading line, e.g. "## Testing"; empty for text before the first heading
+	body    string
+}
+
+// headingPattern matches a markdown ATX heading.
+var headingPattern = regexp.MustCompile(`^#{1,6}\s+\S`)
+
+// splitSections splits markdown into sections at its headings, ignoring
+// lines that only look like headings inside fenced code blocks.
+func splitSections(content string) []section {
+	var sections []section
+	cur := section{}
+	var body []string
+	inFence := false
+	flush := func() {
+		cur.body = strings.Join(body, "\n")
+		if cur.heading != "" || strings.TrimSpace(cur.body) != "" {
+			sections = append(sections, cur)
+		}
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence && headingPattern.MatchString(line) {
+			flush()
+			cur = section{heading: strings.TrimSpace(line)}
+			body = nil
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+	return sections
+}
+
+// largestSection returns content's biggest headed section — the best
+// candidate to split out into a skill.
+func largestSection(content string) (section, bool) {
+	var best section
+	for _, s := range splitSections(content) {
+		if s.heading != "" && len(s.body) > len(best.body) {
+			best = s
+		}
+	}
+	return best, best.heading != ""
+}
+
+// skillNameFor turns a heading into a valid skill name, e.g. "## Code Review"
+// becomes "code-review".
+func skillNameFor(heading string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimLeft(heading, "# ")) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	name := strings.TrimSuffix(b.String(), "-")
+	if len(name) > 64 {
+		name = strings.TrimSuffix(name[:64], "-")
+	}
+	if name == "" {
+		return "<name>"
+	}
+	return name
+}
+
+// duplicateSections reports sections whose text appears in more than one
+// instruction file: the shared file, per-agent fragments, and project
+// instructions.
+func duplicateSections(storeDir string) ([]Finding, error) {
+	files, _ := filepath.Glob(filepath.Join(storeDir, "instructions", "*.md"))
+	projects, _ := filepath.Glob(filepath.Join(storeDir, "projects", "*", "AGENTS.md"))
+	files = append(files, projects...)
+
+	type occurrence struct{ file, heading string }
+	seen := map[string][]occurrence{}
+	var order []string
+	read := map[string]bool{}
+	for _, path := range files {
+		// Projects adopted with identical instructions share one file.
+		if real, err := filepath.EvalSymlinks(path); err == nil {
+			if read[real] {
+				continue
+			}
+			read[real] = true
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		rel, _ := filepath.Rel(storeDir, path)
+		rel = filepath.ToSlash(rel)
+		for _, s := range splitSections(string(data)) {
+			key := strings.Join(strings.Fields(s.body), " ")
+			if len(key) < minDuplicateLen {
+				continue
+			}
+			if len(seen[key]) == 0 {
+				order = append(order, key)
+			}
+			if n := len(seen[key]); n > 0 && seen[key][n-1].file == rel {
+				continue
+			}
+			seen[key] = append(seen[key], occurrence{rel, s.heading})
+		}
+	}
+
+	var findings []Finding
+	for _, key := range order {
+		occ := seen[key]
+		if len(occ) < 2 {
+			continue
+		}
+		var where []string
+		shared := false
+		for _, o := range occ {
+			where = append(where, o.file)
+			shared = shared || o.file == "instructions/AGENTS.md"
+		}
+		sort.Strings(where)
+		heading := occ[0].heading
+		if heading == "" {
+			heading = "text before the first heading"
+		}
+		f := Finding{
+			Check:   CheckDuplicate,
+			Target:  strings.Join(where, ", "),
+			Message: fmt.Sprintf("%q appears in %d files (~%d tokens each)", heading, len(occ), estimateTokens(key)),
+			Hint:    "keep it once in instructions/AGENTS.md — every agent gets that file",
+		}
+		if shared {
+			f.Hint = "remove the other copies — instructions/AGENTS.md already reaches every agent"
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
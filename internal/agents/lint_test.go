@@ -0,0 +1,79 @@
+package agents
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSplitSections(t *testing.T) {
+	content := "Intro\n## One\nfirst\n```sh\n# not a heading\n```\n### Two\nsecond\n"
+	got := splitSections(content)
+	if len(got) != 3 {
+		t.Fatalf("splitSections = %+v, want intro, One, Two", got)
+	}
+	if got[1].heading != "## One" || !strings.Contains(got[1].body, "# not a heading") {
+		t.Errorf("section One = %+v, want the fenced line kept in its body", got[1])
+	}
+	if got[2].heading != "### Two" {
+		t.Errorf("section Two = %+v", got[2])
+	}
+}
+
+func TestSkillNameFor(t *testing.T) {
+	for heading, want := range map[string]string{
+		"## Code Review":        "code-review",
+		"# Testing & CI (Go)":   "testing-ci-go",
+		"### !!!":               "<name>",
+		"## Trailing stuff -- ": "trailing-stuff",
+	} {
+		if got := skillNameFor(heading); got != want {
+			t.Errorf("skillNameFor(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
+
+func TestLint_OverBudget(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Shared\nShort.\n")
+	writeStoreFile(t, storeDir, "instructions/claude.md", "## Testing\n"+strings.Repeat("Run the tests. ", 100)+"\n")
+	makeDetectedAgent(t, "claude", filepath.Join(homeDir, ".claude"))
+	makeDetectedAgent(t, "codex", filepath.Join(homeDir, ".codex"))
+
+	result, err := Lint(200)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	if len(result.Sizes) != 2 {
+		t.Fatalf("Sizes = %+v, want claude and codex", result.Sizes)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("Findings = %+v, want claude over budget", result.Findings)
+	}
+	f := result.Findings[0]
+	if f.Check != CheckOverBudget || f.Agent != "claude" || !strings.Contains(f.Hint, "mine agents skill add testing") {
+		t.Errorf("finding = %+v, want claude over budget with a testing skill hint", f)
+	}
+	if _, err := Lint(0); err == nil {
+		t.Error("Lint(0) = nil error, want error")
+	}
+}
+
+func TestLint_DuplicateSections(t *testing.T) {
+	storeDir, _ := setupLinkEnv(t)
+	style := "## Style\n" + strings.Repeat("Prefer small functions and clear names. ", 3) + "\n"
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Shared\n"+style)
+	writeStoreFile(t, storeDir, "instructions/codex.md", "# Codex\n\n"+style)
+
+	result, err := Lint(5000)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	if len(result.Findings) != 1 || result.Findings[0].Check != CheckDuplicate {
+		t.Fatalf("Findings = %+v, want one duplicate", result.Findings)
+	}
+	f := result.Findings[0]
+	if f.Target != "instructions/AGENTS.md, instructions/codex.md" || !strings.Contains(f.Message, "## Style") {
+		t.Errorf("finding = %+v", f)
+	}
+}
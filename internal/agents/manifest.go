@@ -0,0 +1,73 @@
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ManifestVersion is the manifest schema this mine reads and writes. Bump it
+// with a migration whenever the manifest's shape changes incompatibly.
+const ManifestVersion = 1
+
+// manifestMigration upgrades a manifest's raw fields from one version to
+// the next, in place.
+type manifestMigration func(raw map[string]json.RawMessage) error
+
+// manifestMigrations[v] upgrades a version v manifest to v+1.
+var manifestMigrations = []manifestMigration{
+	// 0 → 1: manifests from before versioning already have version 1's
+	// shape; only the version field is new.
+	func(map[string]json.RawMessage) error { return nil },
+}
+
+// migrateManifest upgrades data to ManifestVersion, returning it unchanged
+// when it's already current. A manifest written by a newer mine is refused
+// rather than read with fields this version doesn't know, which would drop
+// them on the next write.
+func migrateManifest(data []byte) (migrated []byte, from int, err error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, 0, fmt.Errorf("parsing manifest: %w", err)
+	}
+	if v, ok := raw["version"]; ok {
+		if err := json.Unmarshal(v, &from); err != nil {
+			return nil, 0, fmt.Errorf("parsing manifest: invalid version: %w", err)
+		}
+	}
+	switch {
+	case from < 0:
+		return nil, from, fmt.Errorf("parsing manifest: invalid version %d", from)
+	case from > ManifestVersion:
+		return nil, from, fmt.Errorf("manifest version %d is newer than this mine supports (%d) — upgrade mine", from, ManifestVersion)
+	case from == ManifestVersion:
+		return data, from, nil
+	}
+
+	for v := from; v < ManifestVersion; v++ {
+		if err := manifestMigrations[v](raw); err != nil {
+			return nil, from, fmt.Errorf("migrating manifest from version %d: %w", v, err)
+		}
+	}
+	raw["version"] = json.RawMessage(fmt.Sprint(ManifestVersion))
+	migrated, err = json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, from, err
+	}
+	return append(migrated, '\n'), from, nil
+}
+
+// saveMigratedManifest replaces the manifest with its migrated form, first
+// keeping the original as .mine-agents.v<from>.bak.
+func saveMigratedManifest(original, migrated []byte, from int) error {
+	backup := fmt.Sprintf("%s.v%d.bak", ManifestPath(), from)
+	if _, err := os.Stat(backup); os.IsNotExist(err) {
+		if err := os.WriteFile(backup, original, 0o644); err != nil {
+			return fmt.Errorf("backing up manifest: %w", err)
+		}
+	}
+	if err := os.WriteFile(ManifestPath(), migrated, 0o644); err != nil {
+		return fmt.Errorf("writing migrated manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package agents
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestManifestMigrations_CoverEveryVersion(t *testing.T) {
+	if len(manifestMigrations) != ManifestVersion {
+		t.Errorf("%d migrations for version %d — add one per version bump", len(manifestMigrations), ManifestVersion)
+	}
+}
+
+func TestReadManifest_MigratesUnversioned(t *testing.T) {
+	setupLinkEnv(t)
+	legacy := `{"agents":[{"name":"claude","detected":true,"config_dir":"/x"}],"links":[]}`
+	if err := os.WriteFile(ManifestPath(), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if m.Version != ManifestVersion || len(m.Agents) != 1 || m.Agents[0].Name != "claude" {
+		t.Errorf("migrated manifest = %+v", m)
+	}
+	if got := readString(t, ManifestPath()+".v0.bak"); got != legacy {
+		t.Errorf("backup = %q, want the original", got)
+	}
+	if got := readString(t, ManifestPath()); !strings.Contains(got, `"version": 1`) {
+		t.Errorf("manifest not rewritten at version 1:\n%s", got)
+	}
+}
+
+func TestReadManifest_RefusesNewerVersion(t *testing.T) {
+	setupLinkEnv(t)
+	if err := os.WriteFile(ManifestPath(), []byte(`{"version":99,"agents":[],"links":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := ReadManifest()
+	if err == nil || !strings.Contains(err.Error(), "upgrade mine") {
+		t.Errorf("ReadManifest of a newer manifest = %v, want upgrade error", err)
+	}
+}
+
+func TestWriteManifest_StampsVersion(t *testing.T) {
+	setupLinkEnv(t)
+	if err := WriteManifest(&Manifest{}); err != nil {
+		t.Fatalf("WriteManifest: %v", err)
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != ManifestVersion {
+		t.Errorf("Version = %d, want %d", m.Version, ManifestVersion)
+	}
+}
@@ -0,0 +1,307 @@
+package agents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// mcpConfigRel is the canonical MCP server list, relative to the store. It
+// uses Claude's .mcp.json format, which most agents read as-is.
+const mcpConfigRel = "mcp/.mcp.json"
+
+// MCP formats for linkSpec.MCPFormat. The zero value links mcpConfigRel to
+// MCPConfigPath unchanged.
+const (
+	mcpFormatSettings = "settings" // merged into the agent's settings JSON as mcpServers
+	mcpFormatTOML     = "toml"     // written as [mcp_servers.*] tables into a TOML config
+)
+
+// ErrMCPServerNotFound is returned when removing a server that isn't configured.
+var ErrMCPServerNotFound = errors.New("MCP server not found")
+
+// mcpNameRe restricts server names to characters every agent's config accepts.
+var mcpNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// MCPServer is a single MCP server definition in the canonical list.
+type MCPServer struct {
+	Name    string            `json:"-"`
+	Type    string            `json:"type,omitempty"` // "stdio", "http", or "sse"; empty means stdio
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ListMCPServers returns the canonical MCP servers sorted by name.
+func ListMCPServers() ([]MCPServer, error) {
+	_, raw, err := readMCPConfig(Dir())
+	if err != nil {
+		return nil, err
+	}
+	servers, err := decodeMCPServers(raw)
+	if err != nil {
+		return nil, err
+	}
+	return sortedMCPServers(servers), nil
+}
+
+// AddMCPServer adds s to the canonical MCP server list, replacing an existing
+// server of the same name only when replace is set.
+func AddMCPServer(s MCPServer, replace bool) error {
+	if !IsInitialized() {
+		return fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	if !mcpNameRe.MatchString(s.Name) {
+		return fmt.Errorf("invalid MCP server name %q — use letters, digits, '.', '_', and '-' (max 64 chars)", s.Name)
+	}
+	if (s.Command == "") == (s.URL == "") {
+		return fmt.Errorf("MCP server %q needs either a command or a URL", s.Name)
+	}
+
+	top, servers, err := readMCPConfig(Dir())
+	if err != nil {
+		return err
+	}
+	if _, exists := servers[s.Name]; exists && !replace {
+		return fmt.Errorf("MCP server %q already exists — use --force to replace it", s.Name)
+	}
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	servers[s.Name] = encoded
+	return writeMCPConfig(Dir(), top, servers)
+}
+
+// RemoveMCPServer removes the named server from the canonical MCP server list.
+func RemoveMCPServer(name string) error {
+	top, servers, err := readMCPConfig(Dir())
+	if err != nil {
+		return err
+	}
+	if _, ok := servers[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrMCPServerNotFound, name)
+	}
+	delete(servers, name)
+	return writeMCPConfig(Dir(), top, servers)
+}
+
+// LinkMCP links (or renders) the canonical MCP servers into each detected
+// agent's MCP config, without touching its other links.
+func LinkMCP(opts LinkOptions) ([]LinkAction, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+
+	storeDir := Dir()
+	var actions []LinkAction
+	for _, spec := range buildLinkRegistry(home) {
+		if opts.Agent != "" && spec.Name != opts.Agent {
+			continue
+		}
+		if !isAgentDetected(m, spec.Name) || isAssetDisabled(m, spec.Name, AssetMCP) {
+			continue
+		}
+		if spec.MCPFormat == mcpFormatSettings && isAssetDisabled(m, spec.Name, AssetSettings) {
+			continue
+		}
+		actions = append(actions, linkMCP(storeDir, spec, opts, m)...)
+	}
+
+	if err := WriteManifest(m); err != nil {
+		return actions, fmt.Errorf("saving manifest: %w", err)
+	}
+	return actions, nil
+}
+
+// linkMCP delivers the canonical MCP servers to one agent in its format.
+// Agents whose MCP servers live in their settings get the settings link too.
+func linkMCP(storeDir string, spec linkSpec, opts LinkOptions, m *Manifest) []LinkAction {
+	if spec.MCPConfigPath == "" {
+		return nil
+	}
+
+	switch spec.MCPFormat {
+	case mcpFormatSettings:
+		rel, ok, err := settingsSource(storeDir, spec.Name)
+		if err != nil {
+			return []LinkAction{{
+				Source: rel,
+				Target: spec.MCPConfigPath,
+				Agent:  spec.Name,
+				Status: "skipped",
+				Err:    err,
+			}}
+		}
+		if !ok {
+			return nil
+		}
+		return []LinkAction{createFileLink(filepath.Join(storeDir, rel), rel, spec.MCPConfigPath, spec.Name, opts, m)}
+
+	case mcpFormatTOML:
+		if !fileExists(filepath.Join(storeDir, mcpConfigRel)) {
+			return nil
+		}
+		return []LinkAction{renderTOMLMCP(storeDir, spec)}
+
+	default:
+		if !fileExists(filepath.Join(storeDir, mcpConfigRel)) {
+			return nil
+		}
+		return []LinkAction{createFileLink(filepath.Join(storeDir, mcpConfigRel), mcpConfigRel, spec.MCPConfigPath, spec.Name, opts, m)}
+	}
+}
+
+// settingsSource returns the store-relative settings file to link for agent.
+// That's settings/<agent>.json, unless there are MCP servers to merge in —
+// then the two are composed into rendered/settings/<agent>.json, which is
+// returned instead. ok is false when there is nothing to link.
+func settingsSource(storeDir, agent string) (rel string, ok bool, err error) {
+	rel = "settings/" + agent + ".json"
+	_, servers, err := readMCPConfig(storeDir)
+	if err != nil {
+		return rel, false, err
+	}
+	if len(servers) == 0 {
+		return rel, fileExists(filepath.Join(storeDir, rel)), nil
+	}
+
+	content, err := renderSettings(storeDir, agent)
+	if err != nil {
+		return rel, false, err
+	}
+	rel = renderedDir + "/settings/" + agent + ".json"
+	out := filepath.Join(storeDir, rel)
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return rel, false, fmt.Errorf("creating %s: %w", filepath.Dir(rel), err)
+	}
+	if err := os.WriteFile(out, content, 0o644); err != nil {
+		return rel, false, fmt.Errorf("writing %s: %w", rel, err)
+	}
+	if err := ignoreRendered(storeDir); err != nil {
+		return rel, false, err
+	}
+	return rel, true, nil
+}
+
+// renderSettings returns agent's canonical settings (or an empty object) with
+// the canonical MCP servers set as mcpServers.
+func renderSettings(storeDir, agent string) ([]byte, error) {
+	settings := map[string]json.RawMessage{}
+	data, err := os.ReadFile(filepath.Join(storeDir, "settings", agent+".json"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading %s settings: %w", agent, err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("parsing settings/%s.json: %w", agent, err)
+		}
+	}
+
+	_, servers, err := readMCPConfig(storeDir)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(servers)
+	if err != nil {
+		return nil, err
+	}
+	settings["mcpServers"] = encoded
+
+	out, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// readMCPConfig parses the store's canonical MCP config into its top-level
+// keys and its servers by name, both left raw so fields mine doesn't model
+// survive a rewrite. A missing file is an empty list.
+func readMCPConfig(storeDir string) (top, servers map[string]json.RawMessage, err error) {
+	top = map[string]json.RawMessage{}
+	servers = map[string]json.RawMessage{}
+
+	data, err := os.ReadFile(filepath.Join(storeDir, mcpConfigRel))
+	if os.IsNotExist(err) {
+		return top, servers, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading %s: %w", mcpConfigRel, err)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return top, servers, nil
+	}
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, nil, fmt.Errorf("parsing %s: %w", mcpConfigRel, err)
+	}
+	if block, ok := top["mcpServers"]; ok {
+		if err := json.Unmarshal(block, &servers); err != nil {
+			return nil, nil, fmt.Errorf("parsing mcpServers in %s: %w", mcpConfigRel, err)
+		}
+	}
+	return top, servers, nil
+}
+
+// decodeMCPServers decodes raw server entries into MCPServer values.
+func decodeMCPServers(raw map[string]json.RawMessage) (map[string]MCPServer, error) {
+	servers := make(map[string]MCPServer, len(raw))
+	for name, data := range raw {
+		var s MCPServer
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("parsing MCP server %q: %w", name, err)
+		}
+		s.Name = name
+		servers[name] = s
+	}
+	return servers, nil
+}
+
+// writeMCPConfig writes servers back to the store's canonical MCP config,
+// keeping the other top-level keys.
+func writeMCPConfig(storeDir string, top, servers map[string]json.RawMessage) error {
+	encoded, err := json.Marshal(servers)
+	if err != nil {
+		return err
+	}
+	top["mcpServers"] = encoded
+	data, err := json.MarshalIndent(top, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(storeDir, mcpConfigRel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating mcp directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", mcpConfigRel, err)
+	}
+	return nil
+}
+
+// sortedMCPServers returns servers ordered by name.
+func sortedMCPServers(servers map[string]MCPServer) []MCPServer {
+	list := make([]MCPServer, 0, len(servers))
+	for _, s := range servers {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
@@ -0,0 +1,261 @@
+package agents
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestAddMCPServer_ListAndRemove(t *testing.T) {
+	setupLinkEnv(t)
+
+	if err := AddMCPServer(MCPServer{Name: "github", Command: "npx", Args: []string{"-y", "server-github"}}, false); err != nil {
+		t.Fatalf("AddMCPServer(github) error = %v", err)
+	}
+	if err := AddMCPServer(MCPServer{Name: "docs", Type: "http", URL: "https://example.com/mcp"}, false); err != nil {
+		t.Fatalf("AddMCPServer(docs) error = %v", err)
+	}
+
+	servers, err := ListMCPServers()
+	if err != nil {
+		t.Fatalf("ListMCPServers() error = %v", err)
+	}
+	if len(servers) != 2 || servers[0].Name != "docs" || servers[1].Name != "github" {
+		t.Fatalf("ListMCPServers() = %+v, want docs then github", servers)
+	}
+	if servers[1].Command != "npx" || strings.Join(servers[1].Args, " ") != "-y server-github" {
+		t.Errorf("github = %+v", servers[1])
+	}
+
+	if err := RemoveMCPServer("docs"); err != nil {
+		t.Fatalf("RemoveMCPServer() error = %v", err)
+	}
+	if err := RemoveMCPServer("docs"); !errors.Is(err, ErrMCPServerNotFound) {
+		t.Errorf("second RemoveMCPServer() error = %v, want ErrMCPServerNotFound", err)
+	}
+	servers, _ = ListMCPServers()
+	if len(servers) != 1 || servers[0].Name != "github" {
+		t.Errorf("after remove: %+v", servers)
+	}
+}
+
+func TestAddMCPServer_Validation(t *testing.T) {
+	setupLinkEnv(t)
+	if err := AddMCPServer(MCPServer{Name: "github", Command: "npx"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		server MCPServer
+	}{
+		{"duplicate", MCPServer{Name: "github", Command: "other"}},
+		{"bad name", MCPServer{Name: "my server", Command: "x"}},
+		{"no command or url", MCPServer{Name: "empty"}},
+		{"both command and url", MCPServer{Name: "both", Command: "x", URL: "https://x"}},
+	}
+	for _, tt := range tests {
+		if err := AddMCPServer(tt.server, false); err == nil {
+			t.Errorf("%s: AddMCPServer() error = nil, want error", tt.name)
+		}
+	}
+
+	if err := AddMCPServer(MCPServer{Name: "github", Command: "other"}, true); err != nil {
+		t.Errorf("replace: AddMCPServer() error = %v", err)
+	}
+}
+
+func TestAddMCPServer_PreservesUnmodeledFields(t *testing.T) {
+	storeDir, _ := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "mcp/.mcp.json",
+		`{"note":"keep","mcpServers":{"local":{"command":"./srv","cwd":"/work","disabled":true}}}`)
+
+	if err := AddMCPServer(MCPServer{Name: "github", Command: "npx"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(storeDir, "mcp", ".mcp.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Note       string                            `json:"note"`
+		MCPServers map[string]map[string]interface{} `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Note != "keep" || got.MCPServers["local"]["cwd"] != "/work" || got.MCPServers["local"]["disabled"] != true {
+		t.Errorf("unmodeled fields lost:\n%s", data)
+	}
+}
+
+func TestTOMLMCPServers_ValidTOML(t *testing.T) {
+	out := tomlMCPServers(map[string]MCPServer{
+		"github":  {Name: "github", Command: "npx", Args: []string{"-y", `we"ird`}, Env: map[string]string{"GITHUB_TOKEN": "t"}},
+		"my.docs": {Name: "my.docs", URL: "https://example.com/mcp?a=1&b=2", Headers: map[string]string{"Authorization": "Bearer x"}},
+	})
+
+	var parsed struct {
+		MCPServers map[string]struct {
+			Command     string            `toml:"command"`
+			Args        []string          `toml:"args"`
+			Env         map[string]string `toml:"env"`
+			URL         string            `toml:"url"`
+			HTTPHeaders map[string]string `toml:"http_headers"`
+		} `toml:"mcp_servers"`
+	}
+	if _, err := toml.Decode(out, &parsed); err != nil {
+		t.Fatalf("rendered TOML does not parse: %v\n%s", err, out)
+	}
+	gh := parsed.MCPServers["github"]
+	if gh.Command != "npx" || len(gh.Args) != 2 || gh.Args[1] != `we"ird` || gh.Env["GITHUB_TOKEN"] != "t" {
+		t.Errorf("github = %+v", gh)
+	}
+	docs := parsed.MCPServers["my.docs"]
+	if docs.URL != "https://example.com/mcp?a=1&b=2" || docs.HTTPHeaders["Authorization"] != "Bearer x" {
+		t.Errorf("my.docs = %+v", docs)
+	}
+}
+
+func TestReplaceTOMLBlock(t *testing.T) {
+	user := "model = \"o3\"\n\n[profiles.fast]\nmodel = \"mini\"\n"
+	block := "[mcp_servers.a]\ncommand = \"a\"\n"
+
+	once := replaceTOMLBlock(user, block)
+	if !strings.HasPrefix(once, user) || !strings.HasSuffix(once, tomlBlockEnd+"\n") {
+		t.Fatalf("block not appended after user content:\n%s", once)
+	}
+	if twice := replaceTOMLBlock(once, block); twice != once {
+		t.Errorf("re-rendering changed the file:\n%s", twice)
+	}
+
+	swapped := replaceTOMLBlock(once, "[mcp_servers.b]\ncommand = \"b\"\n")
+	if strings.Contains(swapped, "mcp_servers.a") || !strings.Contains(swapped, "mcp_servers.b") {
+		t.Errorf("block not replaced:\n%s", swapped)
+	}
+	if removed := replaceTOMLBlock(once, ""); removed != user {
+		t.Errorf("removing block = %q, want %q", removed, user)
+	}
+}
+
+func TestLink_MCPRenderedPerAgentFormat(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	claudeDir := filepath.Join(homeDir, ".claude")
+	codexDir := filepath.Join(homeDir, ".codex")
+	geminiDir := filepath.Join(homeDir, ".gemini")
+	makeDetectedAgent(t, "claude", claudeDir)
+	makeDetectedAgent(t, "codex", codexDir)
+	makeDetectedAgent(t, "gemini", geminiDir)
+
+	writeStoreFile(t, storeDir, "settings/gemini.json", `{"theme":"dark"}`)
+	if err := os.MkdirAll(codexDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	codexConfig := filepath.Join(codexDir, "config.toml")
+	if err := os.WriteFile(codexConfig, []byte("model = \"o3\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddMCPServer(MCPServer{Name: "github", Command: "npx", Args: []string{"-y", "server-github"}}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, err := LinkMCP(LinkOptions{})
+	if err != nil {
+		t.Fatalf("LinkMCP() error = %v", err)
+	}
+	if len(actions) != 3 {
+		t.Fatalf("LinkMCP() returned %d actions, want 3: %+v", len(actions), actions)
+	}
+	for _, a := range actions {
+		if a.Err != nil {
+			t.Fatalf("%s: %v", a.Agent, a.Err)
+		}
+	}
+
+	// Claude reads the canonical file directly.
+	if dest, _ := os.Readlink(filepath.Join(claudeDir, ".mcp.json")); dest != filepath.Join(storeDir, "mcp", ".mcp.json") {
+		t.Errorf("claude .mcp.json → %q", dest)
+	}
+
+	// Codex gets TOML tables, with its own settings untouched.
+	data, err := os.ReadFile(codexConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var codex struct {
+		Model      string                    `toml:"model"`
+		MCPServers map[string]map[string]any `toml:"mcp_servers"`
+	}
+	if _, err := toml.Decode(string(data), &codex); err != nil {
+		t.Fatalf("config.toml does not parse: %v\n%s", err, data)
+	}
+	if codex.Model != "o3" || codex.MCPServers["github"]["command"] != "npx" {
+		t.Errorf("codex config = %+v", codex)
+	}
+
+	// Gemini's settings link carries the servers alongside its settings.
+	geminiSettings := filepath.Join(geminiDir, "settings.json")
+	if dest, _ := os.Readlink(geminiSettings); dest != filepath.Join(storeDir, "rendered", "settings", "gemini.json") {
+		t.Errorf("gemini settings.json → %q", dest)
+	}
+	var gemini struct {
+		Theme      string                    `json:"theme"`
+		MCPServers map[string]map[string]any `json:"mcpServers"`
+	}
+	data, _ = os.ReadFile(geminiSettings)
+	if err := json.Unmarshal(data, &gemini); err != nil {
+		t.Fatal(err)
+	}
+	if gemini.Theme != "dark" || gemini.MCPServers["github"]["command"] != "npx" {
+		t.Errorf("gemini settings = %s", data)
+	}
+
+	// A second pass changes nothing.
+	again, err := LinkMCP(LinkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range again {
+		if a.Status != "updated" {
+			t.Errorf("relink %s: status %q, want updated", a.Agent, a.Status)
+		}
+	}
+}
+
+func TestCheckLinkHealth_StaleRenderedSettings(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	makeDetectedAgent(t, "gemini", filepath.Join(homeDir, ".gemini"))
+	if err := AddMCPServer(MCPServer{Name: "github", Command: "npx"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ := ReadManifest()
+	var entry LinkEntry
+	for _, l := range m.Links {
+		if l.Agent == "gemini" {
+			entry = l
+		}
+	}
+	if entry.Source != "rendered/settings/gemini.json" {
+		t.Fatalf("gemini link source = %q", entry.Source)
+	}
+	if h := CheckLinkHealth(entry, storeDir); h.State != LinkHealthLinked {
+		t.Fatalf("fresh state = %q", h.State)
+	}
+
+	if err := AddMCPServer(MCPServer{Name: "docs", URL: "https://example.com/mcp"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if h := CheckLinkHealth(entry, storeDir); h.State != LinkHealthDiverged || !strings.Contains(h.Message, "MCP") {
+		t.Errorf("after add: state = %q, message = %q", h.State, h.Message)
+	}
+}
@@ -0,0 +1,167 @@
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Markers delimiting the block of a TOML config that mine manages. Only the
+// lines between them are rewritten; the rest of the file is the user's.
+const (
+	tomlBlockStart = "# >>> mine agents mcp >>>"
+	tomlBlockEnd   = "# <<< mine agents mcp <<<"
+)
+
+// tomlBareKeyRe matches keys that need no quoting in TOML.
+var tomlBareKeyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// renderTOMLMCP writes the canonical MCP servers into the managed block of the
+// agent's TOML config (e.g. [mcp_servers.*] in ~/.codex/config.toml). The
+// file holds the agent's other settings, so it is edited in place rather
+// than linked, and is not tracked in the manifest.
+func renderTOMLMCP(storeDir string, spec linkSpec) LinkAction {
+	action := LinkAction{
+		Source: mcpConfigRel,
+		Target: spec.MCPConfigPath,
+		Agent:  spec.Name,
+		Mode:   "render",
+	}
+
+	_, raw, err := readMCPConfig(storeDir)
+	if err == nil {
+		var servers map[string]MCPServer
+		if servers, err = decodeMCPServers(raw); err == nil {
+			err = writeTOMLBlock(spec.MCPConfigPath, tomlMCPServers(servers), &action)
+		}
+	}
+	if err != nil {
+		action.Status = "skipped"
+		action.Err = err
+	}
+	return action
+}
+
+// writeTOMLBlock replaces the managed block in path with block (removing it
+// when block is empty), setting action's status to "rendered" when the file
+// changed and "updated" when it was already current.
+func writeTOMLBlock(path, block string, action *LinkAction) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	updated := replaceTOMLBlock(string(data), block)
+	if updated == string(data) {
+		action.Status = "updated"
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating parent directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	action.Status = "rendered"
+	return nil
+}
+
+// replaceTOMLBlock removes any managed block from content and, when block is
+// non-empty, appends it at the end — where its tables can't capture the
+// user's keys.
+func replaceTOMLBlock(content, block string) string {
+	var kept []string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		switch strings.TrimSpace(line) {
+		case tomlBlockStart:
+			inBlock = true
+			continue
+		case tomlBlockEnd:
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			kept = append(kept, line)
+		}
+	}
+
+	rest := strings.TrimRight(strings.Join(kept, "\n"), "\n \t")
+	if block == "" {
+		if rest == "" {
+			return ""
+		}
+		return rest + "\n"
+	}
+	if rest != "" {
+		rest += "\n\n"
+	}
+	return rest + tomlBlockStart + "\n" + block + tomlBlockEnd + "\n"
+}
+
+// tomlMCPServers renders servers as Codex-style [mcp_servers.<name>] tables,
+// or "" when there are none.
+func tomlMCPServers(servers map[string]MCPServer) string {
+	if len(servers) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("# Managed by `mine agents mcp` — edit servers there, not here.\n")
+	for i, s := range sortedMCPServers(servers) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[mcp_servers.%s]\n", tomlKey(s.Name))
+		if s.Command != "" {
+			fmt.Fprintf(&b, "command = %s\n", tomlValue(s.Command))
+		}
+		if len(s.Args) > 0 {
+			fmt.Fprintf(&b, "args = %s\n", tomlValue(s.Args))
+		}
+		if len(s.Env) > 0 {
+			fmt.Fprintf(&b, "env = %s\n", tomlInlineTable(s.Env))
+		}
+		if s.URL != "" {
+			fmt.Fprintf(&b, "url = %s\n", tomlValue(s.URL))
+		}
+		if len(s.Headers) > 0 {
+			fmt.Fprintf(&b, "http_headers = %s\n", tomlInlineTable(s.Headers))
+		}
+	}
+	return b.String()
+}
+
+// tomlKey returns k as a TOML key, quoted unless it is a bare key.
+func tomlKey(k string) string {
+	if tomlBareKeyRe.MatchString(k) {
+		return k
+	}
+	return tomlValue(k)
+}
+
+// tomlValue encodes a string or string slice as TOML. JSON's string escapes
+// are a subset of TOML's basic-string escapes, so the JSON encoding is valid.
+func tomlValue(v any) string {
+	data, _ := json.Marshal(v)
+	return strings.ReplaceAll(string(data), `","`, `", "`)
+}
+
+// tomlInlineTable encodes m as a TOML inline table with sorted keys.
+func tomlInlineTable(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = tomlKey(k) + " = " + tomlValue(m[k])
+	}
+	return "{ " + strings.Join(pairs, ", ") + " }"
+}
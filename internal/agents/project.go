@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // projectSpec defines the project-level configuration structure for a single coding agent.
@@ -272,6 +273,72 @@ func ProjectLink(projectPath string, opts ProjectLinkOptions) ([]LinkAction, err
 	return allActions, nil
 }
 
+// projectInstructionTargets lists the instruction files linked into a project
+// root by LinkProject, and the agent each one serves.
+var projectInstructionTargets = []struct{ Agent, Filename string }{
+	{"claude", "CLAUDE.md"},
+	{"codex", "AGENTS.md"},
+	{"cursor", ".cursorrules"},
+}
+
+// ProjectInstructionsPath returns the store-relative path of the named
+// project's instructions file.
+func ProjectInstructionsPath(name string) string {
+	return "projects/" + name + "/AGENTS.md"
+}
+
+// LinkProject links the store's projects/<name>/AGENTS.md into the root of the
+// project at projectPath as CLAUDE.md, AGENTS.md, and .cursorrules.
+//
+// Unlike Link, every target is linked whether or not its agent is detected —
+// the files live in the repo, where collaborators may use any agent. Results
+// are recorded in the manifest tagged with the project name.
+func LinkProject(name, projectPath string, opts LinkOptions) ([]LinkAction, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid project name %q", name)
+	}
+	if err := validateProjectPath(projectPath); err != nil {
+		return nil, err
+	}
+
+	storeDir := Dir()
+	source := filepath.Join(storeDir, filepath.FromSlash(ProjectInstructionsPath(name)))
+	if !fileExists(source) {
+		return nil, fmt.Errorf("no instructions for project %q — create %s first", name, source)
+	}
+	sourceRel, err := projectInstructionSource(storeDir, name)
+	if err != nil {
+		return nil, err
+	}
+	source = filepath.Join(storeDir, filepath.FromSlash(sourceRel))
+
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var actions []LinkAction
+	for _, t := range projectInstructionTargets {
+		if opts.Agent != "" && t.Agent != opts.Agent {
+			continue
+		}
+		target := filepath.Join(projectPath, t.Filename)
+		a := createFileLink(source, sourceRel, target, t.Agent, opts, m)
+		if a.Err == nil {
+			setManifestLinkProject(m, target, name)
+		}
+		actions = append(actions, a)
+	}
+
+	if err := WriteManifest(m); err != nil {
+		return actions, fmt.Errorf("saving manifest: %w", err)
+	}
+	return actions, nil
+}
+
 // validateProjectPath checks that the given path exists and is a directory.
 func validateProjectPath(path string) error {
 	info, err := os.Stat(path)
@@ -274,3 +274,101 @@ func TestProjectInitLink_Integration(t *testing.T) {
 		}
 	}
 }
+
+// --- LinkProject ---
+
+func TestLinkProject_LinksInstructionsIntoRoot(t *testing.T) {
+	storeDir, projectDir := setupProjectEnv(t)
+	writeStoreFile(t, storeDir, "projects/myproject/AGENTS.md", "# My Project\n")
+
+	actions, err := LinkProject("myproject", projectDir, LinkOptions{})
+	if err != nil {
+		t.Fatalf("LinkProject() error = %v", err)
+	}
+	if len(actions) != 3 {
+		t.Fatalf("LinkProject() returned %d actions, want 3", len(actions))
+	}
+
+	source := filepath.Join(storeDir, "projects", "myproject", "AGENTS.md")
+	for _, name := range []string{"CLAUDE.md", "AGENTS.md", ".cursorrules"} {
+		dest, err := os.Readlink(filepath.Join(projectDir, name))
+		if err != nil {
+			t.Fatalf("%s not linked: %v", name, err)
+		}
+		if dest != source {
+			t.Errorf("%s → %q, want %q", name, dest, source)
+		}
+	}
+
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Links) != 3 {
+		t.Fatalf("manifest has %d links, want 3", len(m.Links))
+	}
+	for _, l := range m.Links {
+		if l.Project != "myproject" || l.Source != "projects/myproject/AGENTS.md" {
+			t.Errorf("manifest link = %+v, want project myproject", l)
+		}
+	}
+}
+
+func TestLinkProject_AgentFilter(t *testing.T) {
+	storeDir, projectDir := setupProjectEnv(t)
+	writeStoreFile(t, storeDir, "projects/myproject/AGENTS.md", "# My Project\n")
+
+	actions, err := LinkProject("myproject", projectDir, LinkOptions{Agent: "cursor"})
+	if err != nil {
+		t.Fatalf("LinkProject() error = %v", err)
+	}
+	if len(actions) != 1 || actions[0].Target != filepath.Join(projectDir, ".cursorrules") {
+		t.Fatalf("actions = %+v, want only .cursorrules", actions)
+	}
+	if _, err := os.Lstat(filepath.Join(projectDir, "CLAUDE.md")); !os.IsNotExist(err) {
+		t.Error("CLAUDE.md linked despite --agent cursor")
+	}
+}
+
+func TestLinkProject_MissingInstructions(t *testing.T) {
+	_, projectDir := setupProjectEnv(t)
+
+	if _, err := LinkProject("myproject", projectDir, LinkOptions{}); err == nil {
+		t.Fatal("LinkProject() error = nil without project instructions, want error")
+	}
+}
+
+func TestLinkProject_InvalidName(t *testing.T) {
+	_, projectDir := setupProjectEnv(t)
+
+	for _, name := range []string{"", "..", "a/b"} {
+		if _, err := LinkProject(name, projectDir, LinkOptions{}); err == nil {
+			t.Errorf("LinkProject(%q) error = nil, want error", name)
+		}
+	}
+}
+
+func TestLinkProject_RefusesExistingFileWithoutForce(t *testing.T) {
+	storeDir, projectDir := setupProjectEnv(t)
+	writeStoreFile(t, storeDir, "projects/myproject/AGENTS.md", "# My Project\n")
+	existing := filepath.Join(projectDir, "CLAUDE.md")
+	if err := os.WriteFile(existing, []byte("local\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, err := LinkProject("myproject", projectDir, LinkOptions{Agent: "claude"})
+	if err != nil {
+		t.Fatalf("LinkProject() error = %v", err)
+	}
+	if len(actions) != 1 || actions[0].Err == nil {
+		t.Fatalf("actions = %+v, want a refused CLAUDE.md", actions)
+	}
+
+	actions, err = LinkProject("myproject", projectDir, LinkOptions{Agent: "claude", Force: true})
+	if err != nil || actions[0].Err != nil {
+		t.Fatalf("forced LinkProject() = %+v, %v", actions, err)
+	}
+	if info, _ := os.Lstat(existing); info.Mode()&os.ModeSymlink == 0 {
+		t.Error("CLAUDE.md not replaced with a symlink under --force")
+	}
+}
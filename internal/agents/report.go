@@ -0,0 +1,200 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report is an audit of the store: what each agent has linked, how, and
+// whether it's current, plus the store content nothing links.
+type Report struct {
+	Agents []AgentReport `json:"agents"`
+	// Unlinked lists store content no link delivers, store-relative —
+	// e.g. a fragment for an agent that isn't detected, or a skill limited
+	// to agents that aren't linked.
+	Unlinked []string `json:"unlinked"`
+}
+
+// AgentReport is one agent's part of a Report.
+type AgentReport struct {
+	Name     string       `json:"name"`
+	Detected bool         `json:"detected"`
+	Links    []ReportLink `json:"links"`
+}
+
+// ReportLink describes one managed link.
+type ReportLink struct {
+	Source  string          `json:"source"`
+	Target  string          `json:"target"`
+	Mode    string          `json:"mode"`
+	Project string          `json:"project,omitempty"`
+	State   LinkHealthState `json:"state"`
+	// Modified is the latest change to the store files the link delivers.
+	Modified time.Time `json:"modified,omitzero"`
+	// LinkedAt is when mine last created or refreshed the link; zero for
+	// links made before mine recorded it.
+	LinkedAt time.Time `json:"linked_at,omitzero"`
+	// Stale means the target lags the store: a copy or rendered file whose
+	// sources changed after it was made. Symlinks to the store never are.
+	Stale bool `json:"stale"`
+}
+
+// BuildReport assembles a Report for every agent in the manifest, or only
+// agent when it's set.
+func BuildReport(agent string) (*Report, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	storeDir := Dir()
+	report := &Report{Agents: []AgentReport{}, Unlinked: []string{}}
+	byName := map[string]int{}
+	addAgent := func(name string, detected bool) int {
+		if i, ok := byName[name]; ok {
+			return i
+		}
+		byName[name] = len(report.Agents)
+		report.Agents = append(report.Agents, AgentReport{Name: name, Detected: detected, Links: []ReportLink{}})
+		return byName[name]
+	}
+	for _, a := range m.Agents {
+		if agent == "" || a.Name == agent {
+			addAgent(a.Name, a.Detected)
+		}
+	}
+
+	var delivered []string
+	for _, l := range m.Links {
+		inputs := linkInputs(storeDir, l)
+		delivered = append(delivered, inputs...)
+		if agent != "" && l.Agent != agent {
+			continue
+		}
+		i := addAgent(l.Agent, isAgentDetected(m, l.Agent))
+		report.Agents[i].Links = append(report.Agents[i].Links, reportLink(storeDir, l, inputs))
+	}
+	if agent != "" && len(report.Agents) == 0 {
+		return nil, fmt.Errorf("unknown agent %q — run mine agents detect to see detected agents", agent)
+	}
+
+	items, err := storeContent(storeDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if !coveredBy(item, delivered) {
+			report.Unlinked = append(report.Unlinked, item)
+		}
+	}
+	return report, nil
+}
+
+// reportLink describes l, whose target is built from inputs.
+func reportLink(storeDir string, l LinkEntry, inputs []string) ReportLink {
+	r := ReportLink{
+		Source:   l.Source,
+		Target:   l.Target,
+		Mode:     l.Mode,
+		Project:  l.Project,
+		State:    CheckLinkHealth(l, storeDir).State,
+		LinkedAt: l.LinkedAt,
+	}
+	for _, in := range inputs {
+		if t := latestModTime(filepath.Join(storeDir, filepath.FromSlash(in))); t.After(r.Modified) {
+			r.Modified = t
+		}
+	}
+	switch {
+	case strings.HasPrefix(l.Source, renderedDir+"/"):
+		r.Stale = renderedStale(storeDir, l.Source)
+	case l.Mode == "copy":
+		// A diverged copy only lags if the store changed since; otherwise
+		// the copy itself was edited.
+		r.Stale = r.State == LinkHealthDiverged && !l.LinkedAt.IsZero() && r.Modified.After(l.LinkedAt)
+	}
+	return r
+}
+
+// linkInputs returns the store-relative paths a link delivers: its source,
+// or for rendered files, the store files they're built from.
+func linkInputs(storeDir string, l LinkEntry) []string {
+	rest, ok := strings.CutPrefix(l.Source, renderedDir+"/")
+	if !ok {
+		return []string{l.Source}
+	}
+	var inputs []string
+	switch {
+	case strings.HasPrefix(rest, "skills/"):
+		skills, _ := listStoreSkills(storeDir)
+		for _, s := range skills {
+			if s.ForAgent(l.Agent) {
+				inputs = append(inputs, "skills/"+s.Name)
+			}
+		}
+	case strings.HasPrefix(rest, "commands/"):
+		commands, _ := listStoreCommands(storeDir)
+		for _, c := range commands {
+			if c.ForAgent(l.Agent) {
+				inputs = append(inputs, "commands/"+c.Name+".md")
+			}
+		}
+	case strings.HasPrefix(rest, "settings/"):
+		inputs = []string{"settings/" + l.Agent + ".json", mcpConfigRel}
+	case strings.HasPrefix(rest, "projects/"):
+		inputs = []string{ProjectInstructionsPath(strings.TrimSuffix(filepath.Base(rest), ".md"))}
+	default:
+		inputs = []string{"instructions/AGENTS.md", "instructions/" + l.Agent + ".md"}
+	}
+	var existing []string
+	for _, in := range inputs {
+		if _, err := os.Stat(filepath.Join(storeDir, filepath.FromSlash(in))); err == nil {
+			existing = append(existing, in)
+		}
+	}
+	return existing
+}
+
+// storeContent lists the store's linkable items, store-relative: each
+// instruction file, skill, command, agent definition, rule, settings file,
+// the MCP config, and each project's instructions.
+func storeContent(storeDir string) ([]string, error) {
+	list, err := List(ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var items []string
+	for _, group := range [][]ContentItem{list.Instructions, list.Skills, list.Commands, list.Agents, list.Rules, list.Settings} {
+		for _, c := range group {
+			if rel, err := filepath.Rel(storeDir, c.Path); err == nil {
+				items = append(items, filepath.ToSlash(rel))
+			}
+		}
+	}
+	if fileExists(filepath.Join(storeDir, mcpConfigRel)) {
+		items = append(items, mcpConfigRel)
+	}
+	projects, _ := filepath.Glob(filepath.Join(storeDir, "projects", "*", "AGENTS.md"))
+	for _, p := range projects {
+		items = append(items, ProjectInstructionsPath(filepath.Base(filepath.Dir(p))))
+	}
+	sort.Strings(items)
+	return items, nil
+}
+
+// coveredBy reports whether item is one of paths or inside one of them.
+func coveredBy(item string, paths []string) bool {
+	for _, p := range paths {
+		if item == p || strings.HasPrefix(item, p+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCoveredBy(t *testing.T) {
+	paths := []string{"skills", "commands/deploy.md"}
+	for item, want := range map[string]bool{
+		"skills/tidy":        true,
+		"commands/deploy.md": true,
+		"commands/review.md": false,
+		"skillset/x":         false,
+	} {
+		if got := coveredBy(item, paths); got != want {
+			t.Errorf("coveredBy(%q) = %v, want %v", item, got, want)
+		}
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "instructions/gemini.md", "Gemini only.\n")
+	writeStoreFile(t, storeDir, "skills/tidy/SKILL.md", "---\nname: tidy\n---\n")
+	writeStoreFile(t, storeDir, "rules/style.md", "# Style\n")
+	claudeDir := filepath.Join(homeDir, ".claude")
+	makeDetectedAgent(t, "claude", claudeDir)
+	if _, err := Link(LinkOptions{Copy: true}); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+
+	// Edit the store after linking: the instructions copy now lags.
+	source := filepath.Join(storeDir, "instructions", "AGENTS.md")
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "# Edited\n")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(source, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := BuildReport("")
+	if err != nil {
+		t.Fatalf("BuildReport: %v", err)
+	}
+	if len(report.Agents) != 1 || report.Agents[0].Name != "claude" {
+		t.Fatalf("agents = %+v, want only claude", report.Agents)
+	}
+	links := map[string]ReportLink{}
+	for _, l := range report.Agents[0].Links {
+		links[l.Source] = l
+	}
+	instr, ok := links["instructions/AGENTS.md"]
+	if !ok {
+		t.Fatalf("links = %+v, want instructions/AGENTS.md", links)
+	}
+	if !instr.Stale || instr.LinkedAt.IsZero() || !instr.Modified.After(instr.LinkedAt) {
+		t.Errorf("instructions = %+v, want stale with times recorded", instr)
+	}
+	if skills := links["skills"]; skills.Stale || skills.Mode != "copy" {
+		t.Errorf("skills = %+v, want a fresh copy", skills)
+	}
+	if want := []string{"instructions/gemini.md", "rules/style.md"}; !reflect.DeepEqual(report.Unlinked, want) {
+		t.Errorf("Unlinked = %v, want %v", report.Unlinked, want)
+	}
+
+	if _, err := BuildReport("nope"); err == nil {
+		t.Error("BuildReport(nope) = nil error, want unknown agent")
+	}
+}
@@ -0,0 +1,372 @@
+package agents
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/gitutil"
+)
+
+// ErrSkillNotFound is returned when a named skill isn't in the store.
+var ErrSkillNotFound = errors.New("skill not found")
+
+// Skill is an Agent Skill in the store: a skills/<name>/ directory whose
+// SKILL.md front matter describes it.
+type Skill struct {
+	Name        string
+	Description string
+	// Agents limits which agents get the skill linked; empty means all.
+	Agents []string
+	Path   string // absolute path of the skill directory
+}
+
+// ForAgent reports whether the skill should be linked for agent.
+func (s Skill) ForAgent(agent string) bool {
+	if len(s.Agents) == 0 {
+		return true
+	}
+	for _, a := range s.Agents {
+		if a == agent {
+			return true
+		}
+	}
+	return false
+}
+
+// ListSkills returns the skills in the store sorted by name.
+func ListSkills() ([]Skill, error) {
+	return listStoreSkills(Dir())
+}
+
+// GetSkill returns the named skill.
+func GetSkill(name string) (*Skill, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(Dir(), "skills", name)
+	if !DirExists(dir) {
+		return nil, fmt.Errorf("%w: %s", ErrSkillNotFound, name)
+	}
+	s := readSkill(dir)
+	return &s, nil
+}
+
+// RemoveSkill deletes the named skill's directory from the store.
+func RemoveSkill(name string) error {
+	s, err := GetSkill(name)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(s.Path); err != nil {
+		return fmt.Errorf("removing skill %q: %w", name, err)
+	}
+	return nil
+}
+
+// InstallOptions controls InstallSkill.
+type InstallOptions struct {
+	Path    string // directory within the source that holds the skill
+	Name    string // store name; default is the front-matter name, then the directory name
+	Replace bool   // overwrite an existing skill of the same name
+}
+
+// InstallSkill copies a skill into the store from a local directory, a
+// single markdown file, or a git repository URL.
+func InstallSkill(source string, opts InstallOptions) (*Skill, error) {
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+
+	root := source
+	if IsGitSource(source) {
+		tmp, err := os.MkdirTemp("", "mine-skill-*")
+		if err != nil {
+			return nil, fmt.Errorf("creating temp dir: %w", err)
+		}
+		defer os.RemoveAll(tmp)
+		root = filepath.Join(tmp, "src")
+		if _, err := gitutil.RunCmd(tmp, "clone", "--depth", "1", "--", source, root); err != nil {
+			return nil, fmt.Errorf("cloning %s: %w", source, err)
+		}
+	}
+	src := filepath.Join(root, filepath.FromSlash(opts.Path))
+	if rel, err := filepath.Rel(root, src); err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("invalid --path %q", opts.Path)
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, fmt.Errorf("reading skill source: %w", err)
+	}
+	skillMD := src
+	if info.IsDir() {
+		skillMD = filepath.Join(src, "SKILL.md")
+		if !fileExists(skillMD) {
+			return nil, fmt.Errorf("no SKILL.md in %s%s", source, skillSubdirHint(src))
+		}
+	} else if !strings.HasSuffix(src, ".md") {
+		return nil, fmt.Errorf("%s is not a skill directory or markdown file", src)
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = frontmatterValue(frontmatterLines(skillMD), "name")
+	}
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(src), ".md")
+		if IsGitSource(source) && opts.Path == "" {
+			name = strings.TrimSuffix(filepath.Base(strings.TrimRight(source, "/")), ".git")
+		}
+	}
+	if err := ValidateName(name); err != nil {
+		return nil, fmt.Errorf("%w — pick one with --name", err)
+	}
+
+	dest := filepath.Join(Dir(), "skills", name)
+	if _, err := os.Lstat(dest); err == nil {
+		if !opts.Replace {
+			return nil, fmt.Errorf("skill %q already exists — use --force to replace it", name)
+		}
+		if err := os.RemoveAll(dest); err != nil {
+			return nil, fmt.Errorf("removing existing skill: %w", err)
+		}
+	}
+
+	if info.IsDir() {
+		err = copySkillDir(src, dest)
+	} else if err = os.MkdirAll(dest, 0o755); err == nil {
+		err = copyFile(src, filepath.Join(dest, "SKILL.md"))
+	}
+	if err != nil {
+		os.RemoveAll(dest)
+		return nil, fmt.Errorf("installing skill %q: %w", name, err)
+	}
+
+	s := readSkill(dest)
+	return &s, nil
+}
+
+// IsGitSource reports whether source names a git repository rather than a
+// local path.
+func IsGitSource(source string) bool {
+	if _, err := os.Stat(source); err == nil {
+		return false
+	}
+	return strings.Contains(source, "://") || strings.HasPrefix(source, "git@") || strings.HasSuffix(source, ".git")
+}
+
+// skillSubdirHint suggests --path values when dir holds skills in
+// subdirectories rather than at its root.
+func skillSubdirHint(dir string) string {
+	var found []string
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || (d.IsDir() && d.Name() == ".git") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && d.Name() == "SKILL.md" {
+			rel, _ := filepath.Rel(dir, filepath.Dir(path))
+			found = append(found, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if len(found) == 0 {
+		return ""
+	}
+	sort.Strings(found)
+	return " — choose one with --path: " + strings.Join(found, ", ")
+}
+
+// copySkillDir copies a skill directory, leaving out git metadata.
+func copySkillDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if !d.Type().IsRegular() {
+			return nil // skip symlinks and other special files
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return copyFileMode(path, target, info.Mode())
+	})
+}
+
+// listStoreSkills reads every skill directory under storeDir/skills.
+func listStoreSkills(storeDir string) ([]Skill, error) {
+	entries, err := os.ReadDir(filepath.Join(storeDir, "skills"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing skills: %w", err)
+	}
+	var skills []Skill
+	for _, e := range entries {
+		if e.IsDir() {
+			skills = append(skills, readSkill(filepath.Join(storeDir, "skills", e.Name())))
+		}
+	}
+	return skills, nil
+}
+
+// readSkill reads a skill's metadata from its SKILL.md front matter.
+func readSkill(dir string) Skill {
+	skillMD := filepath.Join(dir, "SKILL.md")
+	return Skill{
+		Name:        filepath.Base(dir),
+		Description: parseFrontmatterDescription(skillMD),
+		Agents:      frontmatterList(frontmatterLines(skillMD), "agents"),
+		Path:        dir,
+	}
+}
+
+// frontmatterLines returns the lines between a markdown file's leading ---
+// delimiters, or nil when it has no front matter.
+func frontmatterLines(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "---" {
+		return nil
+	}
+	var lines []string
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "---" {
+			return lines
+		}
+		lines = append(lines, scanner.Text())
+	}
+	return nil
+}
+
+// frontmatterValue returns the single-line value of key, unquoted.
+func frontmatterValue(lines []string, key string) string {
+	for _, line := range lines {
+		if v, ok := strings.CutPrefix(line, key+":"); ok {
+			return strings.Trim(strings.TrimSpace(v), `"'`)
+		}
+	}
+	return ""
+}
+
+// frontmatterList returns key's values, written inline ([a, b] or a, b) or
+// as a block of "- a" lines.
+func frontmatterList(lines []string, key string) []string {
+	for i, line := range lines {
+		v, ok := strings.CutPrefix(line, key+":")
+		if !ok {
+			continue
+		}
+		var items []string
+		if v = strings.TrimSpace(v); v != "" {
+			for _, item := range strings.Split(strings.Trim(v, "[]"), ",") {
+				if item = strings.Trim(strings.TrimSpace(item), `"'`); item != "" {
+					items = append(items, item)
+				}
+			}
+			return items
+		}
+		for _, next := range lines[i+1:] {
+			item, ok := strings.CutPrefix(strings.TrimSpace(next), "- ")
+			if !ok {
+				break
+			}
+			items = append(items, strings.Trim(strings.TrimSpace(item), `"'`))
+		}
+		return items
+	}
+	return nil
+}
+
+// skillsSource returns the store-relative skills directory to link for
+// agent. That's skills/, unless some skill is limited to certain agents —
+// then rendered/skills/<agent>/ is rebuilt with links to just the skills
+// agent gets, and returned instead. ok is false when agent gets none.
+func skillsSource(storeDir, agent string) (rel string, ok bool, err error) {
+	skills, err := listStoreSkills(storeDir)
+	if err != nil {
+		return "skills", false, err
+	}
+	if !skillsScoped(skills) {
+		return "skills", dirNonEmpty(filepath.Join(storeDir, "skills")), nil
+	}
+
+	rel = renderedDir + "/skills/" + agent
+	out := filepath.Join(storeDir, rel)
+	if err := os.RemoveAll(out); err != nil {
+		return rel, false, fmt.Errorf("clearing %s: %w", rel, err)
+	}
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return rel, false, fmt.Errorf("creating %s: %w", rel, err)
+	}
+	for _, s := range skills {
+		if !s.ForAgent(agent) {
+			continue
+		}
+		if err := os.Symlink(s.Path, filepath.Join(out, s.Name)); err != nil {
+			return rel, false, fmt.Errorf("linking skill %s: %w", s.Name, err)
+		}
+		ok = true
+	}
+	if err := ignoreRendered(storeDir); err != nil {
+		return rel, false, err
+	}
+	return rel, ok, nil
+}
+
+// skillsScoped reports whether any skill is limited to certain agents.
+func skillsScoped(skills []Skill) bool {
+	for _, s := range skills {
+		if len(s.Agents) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// skillsStale reports whether rendered/skills/<agent>/ no longer holds
+// exactly the skills agent should get.
+func skillsStale(storeDir, agent string) bool {
+	skills, err := listStoreSkills(storeDir)
+	if err != nil || !skillsScoped(skills) {
+		return true
+	}
+	var want []string
+	for _, s := range skills {
+		if s.ForAgent(agent) {
+			want = append(want, s.Name)
+		}
+	}
+	entries, err := os.ReadDir(filepath.Join(storeDir, renderedDir, "skills", agent))
+	if err != nil {
+		return true
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	return strings.Join(want, "\n") != strings.Join(got, "\n")
+}
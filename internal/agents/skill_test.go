@@ -0,0 +1,218 @@
+package agents
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/gitutil"
+)
+
+// writeSkillSource creates a skill directory outside the store.
+func writeSkillSource(t *testing.T, dir, skillMD string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skillMD), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "scripts", "run.sh"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestFrontmatterList(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []string
+	}{
+		{"inline", []string{"agents: [claude, codex]"}, []string{"claude", "codex"}},
+		{"bare", []string{"agents: claude"}, []string{"claude"}},
+		{"block", []string{"agents:", "  - claude", `  - "gemini"`, "other: x"}, []string{"claude", "gemini"}},
+		{"missing", []string{"name: x"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := frontmatterList(tt.lines, "agents"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("frontmatterList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddSkill_WithAgents(t *testing.T) {
+	setupLinkEnv(t)
+
+	if _, err := AddSkill("review", "claude", "codex"); err != nil {
+		t.Fatalf("AddSkill: %v", err)
+	}
+	s, err := GetSkill("review")
+	if err != nil {
+		t.Fatalf("GetSkill: %v", err)
+	}
+	if !reflect.DeepEqual(s.Agents, []string{"claude", "codex"}) {
+		t.Errorf("Agents = %v, want [claude codex]", s.Agents)
+	}
+	if !s.ForAgent("codex") || s.ForAgent("gemini") {
+		t.Errorf("ForAgent: want codex only among codex/gemini")
+	}
+
+	if _, err := AddSkill("other", "nope"); err == nil || !strings.Contains(err.Error(), "unknown agent") {
+		t.Errorf("AddSkill with unknown agent error = %v, want unknown agent", err)
+	}
+}
+
+func TestInstallSkill_LocalDir(t *testing.T) {
+	storeDir, _ := setupLinkEnv(t)
+	src := writeSkillSource(t, filepath.Join(t.TempDir(), "pdf-tools"),
+		"---\nname: pdf\ndescription: Work with PDFs.\n---\n")
+
+	s, err := InstallSkill(src, InstallOptions{})
+	if err != nil {
+		t.Fatalf("InstallSkill: %v", err)
+	}
+	if s.Name != "pdf" || s.Description != "Work with PDFs." {
+		t.Errorf("skill = %+v, want front-matter name and description", s)
+	}
+	info, err := os.Stat(filepath.Join(storeDir, "skills", "pdf", "scripts", "run.sh"))
+	if err != nil {
+		t.Fatalf("scripts not copied: %v", err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Error("run.sh lost its executable bit")
+	}
+
+	if _, err := InstallSkill(src, InstallOptions{}); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("second install error = %v, want already exists", err)
+	}
+	if _, err := InstallSkill(src, InstallOptions{Replace: true}); err != nil {
+		t.Errorf("install with Replace: %v", err)
+	}
+}
+
+func TestInstallSkill_MarkdownFile(t *testing.T) {
+	storeDir, _ := setupLinkEnv(t)
+	src := filepath.Join(t.TempDir(), "commit-helper.md")
+	if err := os.WriteFile(src, []byte("Write good commit messages.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := InstallSkill(src, InstallOptions{})
+	if err != nil {
+		t.Fatalf("InstallSkill: %v", err)
+	}
+	if s.Name != "commit-helper" {
+		t.Errorf("Name = %q, want commit-helper", s.Name)
+	}
+	if !fileExists(filepath.Join(storeDir, "skills", "commit-helper", "SKILL.md")) {
+		t.Error("SKILL.md not written")
+	}
+}
+
+func TestInstallSkill_NoSkillMDSuggestsPath(t *testing.T) {
+	setupLinkEnv(t)
+	root := t.TempDir()
+	writeSkillSource(t, filepath.Join(root, "skills", "a"), "---\nname: a\n---\n")
+
+	_, err := InstallSkill(root, InstallOptions{})
+	if err == nil || !strings.Contains(err.Error(), "skills/a") {
+		t.Errorf("error = %v, want hint naming skills/a", err)
+	}
+	if _, err := InstallSkill(root, InstallOptions{Path: "../x"}); err == nil {
+		t.Error("InstallSkill with --path outside the source = nil, want error")
+	}
+}
+
+func TestInstallSkill_GitURL(t *testing.T) {
+	setupLinkEnv(t)
+	repo := filepath.Join(t.TempDir(), "skills-repo")
+	writeSkillSource(t, filepath.Join(repo, "lint"), "---\ndescription: Lint things.\n---\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "-m", "init"},
+	} {
+		if _, err := gitutil.RunCmd(repo, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	s, err := InstallSkill("file://"+repo, InstallOptions{Path: "lint"})
+	if err != nil {
+		t.Fatalf("InstallSkill: %v", err)
+	}
+	if s.Name != "lint" || s.Description != "Lint things." {
+		t.Errorf("skill = %+v", s)
+	}
+	if DirExists(filepath.Join(s.Path, ".git")) {
+		t.Error(".git copied into the store")
+	}
+}
+
+func TestRemoveSkill(t *testing.T) {
+	storeDir, _ := setupLinkEnv(t)
+	if _, err := AddSkill("gone"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveSkill("gone"); err != nil {
+		t.Fatalf("RemoveSkill: %v", err)
+	}
+	if DirExists(filepath.Join(storeDir, "skills", "gone")) {
+		t.Error("skill directory still exists")
+	}
+	if err := RemoveSkill("gone"); !errors.Is(err, ErrSkillNotFound) {
+		t.Errorf("RemoveSkill(missing) = %v, want ErrSkillNotFound", err)
+	}
+}
+
+func TestLink_SkillsScopedToAgents(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "skills/everyone/SKILL.md", "---\nname: everyone\n---\n")
+	writeStoreFile(t, storeDir, "skills/claude-only/SKILL.md", "---\nagents: [claude]\n---\n")
+	writeStoreFile(t, storeDir, "skills/codex-only/SKILL.md", "---\nagents: [codex]\n---\n")
+	claudeDir := filepath.Join(homeDir, ".claude")
+	makeDetectedAgent(t, "claude", claudeDir)
+
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(claudeDir, "skills"))
+	if err != nil {
+		t.Fatalf("reading linked skills: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if want := []string{"claude-only", "everyone"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("claude skills = %v, want %v", names, want)
+	}
+
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry LinkEntry
+	for _, l := range m.Links {
+		if l.Target == filepath.Join(claudeDir, "skills") {
+			entry = l
+		}
+	}
+	if entry.Source != renderedDir+"/skills/claude" {
+		t.Fatalf("skills entry source = %q, want rendered/skills/claude", entry.Source)
+	}
+	if h := CheckLinkHealth(entry, storeDir); h.State != LinkHealthLinked || h.Message != "" {
+		t.Errorf("health = %+v, want linked", h)
+	}
+
+	writeStoreFile(t, storeDir, "skills/new/SKILL.md", "---\nname: new\n---\n")
+	if h := CheckLinkHealth(entry, storeDir); !strings.Contains(h.Message, "skills changed") {
+		t.Errorf("health after adding a skill = %+v, want skills changed", h)
+	}
+}
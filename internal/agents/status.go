@@ -54,6 +54,9 @@ type StatusResult struct {
 	Store  StoreInfo
 	Agents []Agent
 	Links  []LinkHealth
+	// Disabled maps agents with assets detached by 'mine agents disable'
+	// to those assets.
+	Disabled map[string][]string
 }
 
 // CheckStatus assembles a full status report by re-detecting agents and evaluating
@@ -78,10 +81,18 @@ func CheckStatus() (*StatusResult, error) {
 		links = append(links, h)
 	}
 
+	disabled := map[string][]string{}
+	for agent := range m.Disabled {
+		if assets := DisabledAssets(m, agent); len(assets) > 0 {
+			disabled[agent] = assets
+		}
+	}
+
 	return &StatusResult{
-		Store:  store,
-		Agents: detected,
-		Links:  links,
+		Store:    store,
+		Agents:   detected,
+		Links:    links,
+		Disabled: disabled,
 	}, nil
 }
 
@@ -105,10 +116,28 @@ func CheckLinkHealth(entry LinkEntry, storeDir string) LinkHealth {
 	}
 
 	if entry.Mode == "copy" {
-		return checkCopyHealth(h, sourcePath, entry.Target, info)
+		h = checkCopyHealth(h, sourcePath, entry.Target, info)
+	} else {
+		h = checkSymlinkHealth(h, sourcePath, entry.Target, info)
 	}
 
-	return checkSymlinkHealth(h, sourcePath, entry.Target, info)
+	// Rendered files go stale when their sources are edited.
+	if h.State == LinkHealthLinked && strings.HasPrefix(entry.Source, renderedDir+"/") && renderedStale(storeDir, entry.Source) {
+		h.State = LinkHealthDiverged
+		switch {
+		case strings.HasPrefix(entry.Source, renderedDir+"/settings/"):
+			h.Message = "settings or MCP servers changed since linking — run mine agents link"
+		case strings.HasPrefix(entry.Source, renderedDir+"/skills/"):
+			h.Message = "skills changed since linking — run mine agents link"
+		case strings.HasPrefix(entry.Source, renderedDir+"/commands/"):
+			h.Message = "commands changed since linking — run mine agents link"
+		case strings.HasPrefix(entry.Source, renderedDir+"/projects/"):
+			h.Message = "project instructions changed since linking — run mine agents link --project"
+		default:
+			h.Message = "instructions changed since linking — run mine agents link"
+		}
+	}
+	return h
 }
 
 // checkCopyHealth evaluates a copy-mode link entry.
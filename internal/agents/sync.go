@@ -29,6 +29,52 @@ func SyncSetRemote(url string) error {
 	return nil
 }
 
+// SyncInitResult describes what SyncInit did.
+type SyncInitResult struct {
+	Cloned     bool // the store was created by cloning the remote
+	Scaffolded bool // the store was scaffolded because it (or the remote) was empty
+}
+
+// SyncInit prepares the agents store for syncing with the remote at url.
+//
+// On a machine with no store yet, the remote is cloned into place, so a second
+// machine starts from the same instructions, skills, and commands; an empty
+// remote is scaffolded like a fresh init. An existing store is initialized if
+// needed and gets url as its origin, ready to push.
+func SyncInit(url string) (*SyncInitResult, error) {
+	dir := Dir()
+	result := &SyncInitResult{}
+
+	if entries, err := os.ReadDir(dir); os.IsNotExist(err) || (err == nil && len(entries) == 0) {
+		if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+			return nil, fmt.Errorf("creating %s: %w", filepath.Dir(dir), err)
+		}
+		if _, err := gitCmd(filepath.Dir(dir), "clone", url, dir); err != nil {
+			return nil, fmt.Errorf("cloning %s: %w", url, err)
+		}
+		if err := setGitIdentity(dir); err != nil {
+			return nil, err
+		}
+		result.Cloned = true
+	} else if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+
+	// Init is idempotent: it fills in directories git doesn't track (empty
+	// skills/, etc.) and only scaffolds a manifest when there isn't one.
+	result.Scaffolded = !IsInitialized()
+	if err := Init(); err != nil {
+		return nil, err
+	}
+
+	if !result.Cloned {
+		if err := SyncSetRemote(url); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 // SyncRemoteURL returns the configured remote URL, or empty string if none.
 func SyncRemoteURL() string {
 	dir := Dir()
@@ -131,14 +177,25 @@ func SyncPullWithResult() (*SyncPullResult, error) {
 		return nil, fmt.Errorf("determining home directory: %w", err)
 	}
 
-	result := &SyncPullResult{}
+	copied, err := redistributeCopies(manifest, dir, home)
+	if err != nil {
+		return nil, err
+	}
+	return &SyncPullResult{CopiedLinks: copied}, nil
+}
+
+// redistributeCopies re-copies the store's current content to every copy-mode
+// link target, returning how many were written. Symlink-mode links need no
+// action. Links whose source no longer exists are skipped.
+func redistributeCopies(manifest *Manifest, dir, home string) (int, error) {
+	copied := 0
 	for _, link := range manifest.Links {
 		if link.Mode != "copy" {
 			continue
 		}
 
 		if err := validateLinkForRedistribution(link, dir, home); err != nil {
-			return nil, fmt.Errorf("unsafe manifest link: %w", err)
+			return copied, fmt.Errorf("unsafe manifest link: %w", err)
 		}
 
 		srcPath := filepath.Join(dir, link.Source)
@@ -154,20 +211,20 @@ func SyncPullWithResult() (*SyncPullResult, error) {
 		if info, err := os.Stat(link.Target); err == nil {
 			mode = info.Mode().Perm()
 			if err := os.Remove(link.Target); err != nil {
-				return nil, fmt.Errorf("removing existing %s: %w", link.Target, err)
+				return copied, fmt.Errorf("removing existing %s: %w", link.Target, err)
 			}
 		}
 
 		// Ensure target's parent directory exists.
 		if err := os.MkdirAll(filepath.Dir(link.Target), 0o755); err != nil {
-			return nil, fmt.Errorf("creating directory for %s: %w", link.Target, err)
+			return copied, fmt.Errorf("creating directory for %s: %w", link.Target, err)
 		}
 
 		if err := os.WriteFile(link.Target, data, mode); err != nil {
-			return nil, fmt.Errorf("re-copying %s to %s: %w", link.Source, link.Target, err)
+			return copied, fmt.Errorf("re-copying %s to %s: %w", link.Source, link.Target, err)
 		}
-		result.CopiedLinks++
+		copied++
 	}
 
-	return result, nil
+	return copied, nil
 }
@@ -462,3 +462,113 @@ func TestValidateLinkForRedistribution(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncInit_ExistingStoreSetsRemote(t *testing.T) {
+	setupEnv(t)
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := SyncInit("https://example.com/agents.git")
+	if err != nil {
+		t.Fatalf("SyncInit() error: %v", err)
+	}
+	if result.Cloned || result.Scaffolded {
+		t.Errorf("SyncInit() = %+v, want neither cloned nor scaffolded", result)
+	}
+	if url := SyncRemoteURL(); url != "https://example.com/agents.git" {
+		t.Errorf("SyncRemoteURL() = %q", url)
+	}
+}
+
+func TestSyncInit_EmptyRemoteScaffolds(t *testing.T) {
+	setupEnv(t)
+
+	// An existing but empty store dir is treated like a missing one, so the
+	// clone of an empty remote leaves a scaffolded store pushing to it.
+	tmpDir := t.TempDir()
+	bareRepo := filepath.Join(tmpDir, "agents.git")
+	if _, err := gitCmd(tmpDir, "init", "--bare", bareRepo); err != nil {
+		t.Fatalf("creating bare repo: %v", err)
+	}
+	if err := os.MkdirAll(Dir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := SyncInit(bareRepo)
+	if err != nil {
+		t.Fatalf("SyncInit() error: %v", err)
+	}
+	if !result.Cloned || !result.Scaffolded {
+		t.Errorf("SyncInit() = %+v, want cloned and scaffolded", result)
+	}
+	if !IsInitialized() || !HasCommits() {
+		t.Fatal("store not initialized with a commit after SyncInit")
+	}
+	if err := SyncPush(); err != nil {
+		t.Fatalf("SyncPush() after SyncInit error: %v", err)
+	}
+}
+
+func TestSyncInit_ClonesOnSecondMachine(t *testing.T) {
+	agentsDir := setupEnv(t)
+	tmpDir := t.TempDir()
+
+	// Machine A: a store with instructions, pushed to the remote.
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentsDir, "instructions", "AGENTS.md"), []byte("# Shared everywhere\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("add instructions"); err != nil {
+		t.Fatal(err)
+	}
+	bareRepo := filepath.Join(tmpDir, "agents.git")
+	if _, err := gitCmd(tmpDir, "init", "--bare", bareRepo); err != nil {
+		t.Fatalf("creating bare repo: %v", err)
+	}
+	if err := SyncSetRemote(bareRepo); err != nil {
+		t.Fatal(err)
+	}
+	if err := SyncPush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Machine B: no store yet.
+	t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "machine-b"))
+	result, err := SyncInit(bareRepo)
+	if err != nil {
+		t.Fatalf("SyncInit() error: %v", err)
+	}
+	if !result.Cloned || result.Scaffolded {
+		t.Errorf("SyncInit() = %+v, want cloned without scaffolding", result)
+	}
+
+	data, err := os.ReadFile(filepath.Join(Dir(), "instructions", "AGENTS.md"))
+	if err != nil || string(data) != "# Shared everywhere\n" {
+		t.Errorf("cloned AGENTS.md = %q, %v", data, err)
+	}
+	if url := SyncRemoteURL(); url != bareRepo {
+		t.Errorf("SyncRemoteURL() = %q, want %q", url, bareRepo)
+	}
+
+	// The clone can commit with the store's own identity.
+	if err := os.WriteFile(filepath.Join(Dir(), "instructions", "AGENTS.md"), []byte("# Edited on B\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("edit on b"); err != nil {
+		t.Fatalf("Commit() on clone error: %v", err)
+	}
+}
+
+func TestSyncInit_CloneFailure(t *testing.T) {
+	setupEnv(t)
+
+	if _, err := SyncInit(filepath.Join(t.TempDir(), "missing.git")); err == nil {
+		t.Fatal("SyncInit() error = nil for a missing remote, want error")
+	}
+	if IsInitialized() {
+		t.Error("store initialized despite failed clone")
+	}
+}
@@ -0,0 +1,63 @@
+package agents
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"text/template"
+
+	"github.com/rnwolfe/mine/internal/config"
+)
+
+// TemplateData is what placeholders in store instruction files can refer
+// to, e.g. {{.User.Name}} or {{.Hostname}}. They're filled in when the file
+// is linked or copied, so one canonical file can differ per machine.
+type TemplateData struct {
+	User     TemplateUser
+	Hostname string
+	// Project is the project name for project instructions
+	// (projects/<name>/AGENTS.md); empty for global instructions.
+	Project string
+}
+
+// TemplateUser is the user from mine's config.
+type TemplateUser struct {
+	Name  string
+	Email string
+}
+
+// placeholderPattern matches a template action that refers to a field, like
+// {{.Hostname}} or {{- if .Project}}. Files without one are linked as they
+// are, so other uses of {{ }} (such as {{shared}} or {{args}}) keep working.
+var placeholderPattern = regexp.MustCompile(`\{\{-?[^}]*\.[A-Z]`)
+
+// hasPlaceholders reports whether content uses template placeholders.
+func hasPlaceholders(content string) bool {
+	return placeholderPattern.MatchString(content)
+}
+
+// templateData returns the values for this machine, for project (empty
+// outside project instructions).
+func templateData(project string) TemplateData {
+	data := TemplateData{Project: project}
+	if cfg, err := config.Load(); err == nil {
+		data.User = TemplateUser{Name: cfg.User.Name, Email: cfg.User.Email}
+	}
+	data.Hostname, _ = os.Hostname()
+	return data
+}
+
+// expandPlaceholders fills in content's placeholders from data. name
+// identifies the file in errors.
+func expandPlaceholders(name, content string, data TemplateData) (string, error) {
+	tmpl, err := template.New(name).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parsing placeholders in %s: %w", name, err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("filling in placeholders in %s: %w", name, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,112 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/config"
+)
+
+// setTemplateUser points mine's config at a temp dir holding user.name.
+func setTemplateUser(t *testing.T, name string) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := os.MkdirAll(config.GetPaths().ConfigDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Save(&config.Config{User: config.UserConfig{Name: name}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHasPlaceholders(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"Hi {{.User.Name}}", true},
+		{"{{- if .Project}}x{{end}}", true},
+		{"{{shared}}", false},
+		{"Fix {{args}}", false},
+		{"No templates here", false},
+	}
+	for _, tt := range tests {
+		if got := hasPlaceholders(tt.in); got != tt.want {
+			t.Errorf("hasPlaceholders(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPlaceholders(t *testing.T) {
+	data := TemplateData{User: TemplateUser{Name: "Ada"}, Hostname: "box"}
+	got, err := expandPlaceholders("x", "{{.User.Name}}@{{.Hostname}}{{with .Project}} in {{.}}{{end}}", data)
+	if err != nil || got != "Ada@box" {
+		t.Errorf("expandPlaceholders = %q, %v; want Ada@box", got, err)
+	}
+	if _, err := expandPlaceholders("x", "{{.Nope}}", data); err == nil {
+		t.Error("unknown field = nil error, want error")
+	}
+	if _, err := expandPlaceholders("x", "{{.User.Name", data); err == nil {
+		t.Error("unterminated action = nil error, want error")
+	}
+}
+
+func TestLink_InstructionPlaceholders(t *testing.T) {
+	storeDir, homeDir := setupLinkEnv(t)
+	setTemplateUser(t, "Ada")
+	writeStoreFile(t, storeDir, "instructions/AGENTS.md", "Written for {{.User.Name}} on {{.Hostname}}.\n")
+	claudeDir := filepath.Join(homeDir, ".claude")
+	makeDetectedAgent(t, "claude", claudeDir)
+
+	if _, err := Link(LinkOptions{}); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	dest, err := os.Readlink(filepath.Join(claudeDir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(storeDir, "rendered", "claude.md"); dest != want {
+		t.Errorf("CLAUDE.md → %q, want %q", dest, want)
+	}
+	host, _ := os.Hostname()
+	if got := readString(t, dest); got != "Written for Ada on "+host+".\n" {
+		t.Errorf("CLAUDE.md = %q", got)
+	}
+
+	m, _ := ReadManifest()
+	if h := CheckLinkHealth(m.Links[0], storeDir); h.State != LinkHealthLinked {
+		t.Fatalf("fresh link state = %q, want linked", h.State)
+	}
+	setTemplateUser(t, "Grace")
+	if h := CheckLinkHealth(m.Links[0], storeDir); h.State != LinkHealthDiverged {
+		t.Errorf("after user change state = %q, want diverged", h.State)
+	}
+}
+
+func TestLinkProject_Placeholders(t *testing.T) {
+	storeDir, projectDir := setupProjectEnv(t)
+	writeStoreFile(t, storeDir, "projects/myproject/AGENTS.md", "# {{.Project}}\n")
+
+	actions, err := LinkProject("myproject", projectDir, LinkOptions{Agent: "claude"})
+	if err != nil {
+		t.Fatalf("LinkProject() error = %v", err)
+	}
+	if len(actions) != 1 || actions[0].Err != nil {
+		t.Fatalf("actions = %+v", actions)
+	}
+	if got := readString(t, filepath.Join(projectDir, "CLAUDE.md")); got != "# myproject\n" {
+		t.Errorf("CLAUDE.md = %q, want the project name filled in", got)
+	}
+
+	m, _ := ReadManifest()
+	if m.Links[0].Source != "rendered/projects/myproject.md" {
+		t.Fatalf("source = %q, want rendered/projects/myproject.md", m.Links[0].Source)
+	}
+	writeStoreFile(t, storeDir, "projects/myproject/AGENTS.md", "# {{.Project}}, edited\n")
+	h := CheckLinkHealth(m.Links[0], storeDir)
+	if h.State != LinkHealthDiverged || !strings.Contains(h.Message, "--project") {
+		t.Errorf("after edit = %+v, want diverged with a --project hint", h)
+	}
+}
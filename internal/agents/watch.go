@@ -0,0 +1,329 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch policies: which way changes flow between the store and copy-mode
+// link targets.
+const (
+	WatchTwoWay    = "two-way"    // whichever side changed is copied to the other
+	WatchFromStore = "from-store" // store edits reach the copies; copy edits are only reported
+	WatchToStore   = "to-store"   // copy edits are saved to the store; store edits are only reported
+)
+
+// Directions a watch sync can copy in.
+const (
+	DirToCopy  = "to-copy"
+	DirToStore = "to-store"
+)
+
+// watchDebounce is how long a watcher waits for writes to settle before
+// syncing, so an editor's save — often several events — is copied once.
+const watchDebounce = 300 * time.Millisecond
+
+// WatchEvent reports one sync between the store and a copy.
+type WatchEvent struct {
+	Agent     string
+	Source    string // store-relative source
+	Target    string
+	Direction string // DirToCopy or DirToStore
+	Synced    bool   // false when the policy held the change back
+	Message   string // why nothing was copied, when Synced is false
+	Err       error
+}
+
+// ValidateWatchPolicy returns an error unless policy is a known policy.
+func ValidateWatchPolicy(policy string) error {
+	switch policy {
+	case WatchTwoWay, WatchFromStore, WatchToStore:
+		return nil
+	}
+	return fmt.Errorf("unknown watch policy %q — use %s, %s, or %s", policy, WatchTwoWay, WatchFromStore, WatchToStore)
+}
+
+// ReconcileCopies brings every diverged copy-mode link in line with its
+// store source, once. With no event to say which side changed, the side
+// modified most recently is taken as the edit.
+func ReconcileCopies(policy string) ([]WatchEvent, error) {
+	if err := ValidateWatchPolicy(policy); err != nil {
+		return nil, err
+	}
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	storeDir := Dir()
+	var events []WatchEvent
+	for _, entry := range watchedEntries(m) {
+		source := filepath.Join(storeDir, entry.Source)
+		if contentMatches(source, entry.Target) {
+			continue
+		}
+		dir := DirToCopy
+		if latestModTime(entry.Target).After(latestModTime(source)) {
+			dir = DirToStore
+		}
+		events = append(events, syncCopy(entry, storeDir, dir, policy))
+	}
+	return events, nil
+}
+
+// Watcher keeps copy-mode link targets and their store sources in sync as
+// either changes.
+type Watcher struct {
+	policy   string
+	storeDir string
+	fs       *fsnotify.Watcher
+	entries  []LinkEntry
+}
+
+// NewWatcher starts watching the store and every copy-mode link target.
+// Call Run to process changes and Close when done.
+func NewWatcher(policy string) (*Watcher, error) {
+	if err := ValidateWatchPolicy(policy); err != nil {
+		return nil, err
+	}
+	if !IsInitialized() {
+		return nil, fmt.Errorf("agents store not initialized — run %s first", "mine agents init")
+	}
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("starting file watcher: %w", err)
+	}
+	w := &Watcher{policy: policy, storeDir: Dir(), fs: fsw}
+	if err := w.reload(); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Links returns the number of copy-mode links being watched.
+func (w *Watcher) Links() int {
+	return len(w.entries)
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	return w.fs.Close()
+}
+
+// Run syncs changes until ctx is done, calling report for each one.
+// Relinking (which rewrites the manifest) updates the set of watched links.
+func (w *Watcher) Run(ctx context.Context, report func(WatchEvent)) error {
+	// Pending syncs by target; the last side to change wins.
+	pending := map[string]string{}
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	manifestChanged := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return nil
+			}
+			report(WatchEvent{Err: fmt.Errorf("file watcher: %w", err)})
+
+		case ev, ok := <-w.fs.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Name == ManifestPath() {
+				manifestChanged = true
+			}
+			for _, entry := range w.entries {
+				if dir, ok := w.sideOf(entry, ev.Name); ok {
+					pending[entry.Target] = dir
+				}
+			}
+			// A new directory inside a watched one needs its own watch.
+			if ev.Has(fsnotify.Create) && DirExists(ev.Name) && w.covers(ev.Name) {
+				w.addTree(ev.Name)
+			}
+			if len(pending) > 0 || manifestChanged {
+				timer.Reset(watchDebounce)
+			}
+
+		case <-timer.C:
+			if manifestChanged {
+				manifestChanged = false
+				if err := w.reload(); err != nil {
+					report(WatchEvent{Err: err})
+				}
+			}
+			for _, entry := range w.entries {
+				dir, ok := pending[entry.Target]
+				if !ok {
+					continue
+				}
+				source := filepath.Join(w.storeDir, entry.Source)
+				if contentMatches(source, entry.Target) {
+					continue // e.g. our own write, seen from the other side
+				}
+				report(syncCopy(entry, w.storeDir, dir, w.policy))
+			}
+			pending = map[string]string{}
+		}
+	}
+}
+
+// reload rereads the manifest and watches every copy-mode link.
+func (w *Watcher) reload() error {
+	m, err := ReadManifest()
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+	w.entries = watchedEntries(m)
+
+	// The store root, for manifest changes.
+	if err := w.fs.Add(w.storeDir); err != nil {
+		return fmt.Errorf("watching %s: %w", w.storeDir, err)
+	}
+	for _, entry := range w.entries {
+		// Parent directories catch editors that save by renaming a new
+		// file into place, and targets deleted and recreated.
+		for _, p := range []string{filepath.Join(w.storeDir, entry.Source), entry.Target} {
+			_ = w.fs.Add(filepath.Dir(p))
+			if DirExists(p) {
+				w.addTree(p)
+			}
+		}
+	}
+	return nil
+}
+
+// addTree watches dir and every directory under it.
+func (w *Watcher) addTree(dir string) {
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			_ = w.fs.Add(path)
+		}
+		return nil
+	})
+}
+
+// covers reports whether path is inside a watched source or target.
+func (w *Watcher) covers(path string) bool {
+	for _, entry := range w.entries {
+		if _, ok := w.sideOf(entry, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sideOf reports which way a change to path should be copied for entry:
+// DirToCopy when it's in the store source, DirToStore when it's in the
+// target.
+func (w *Watcher) sideOf(entry LinkEntry, path string) (string, bool) {
+	if withinPath(path, filepath.Join(w.storeDir, entry.Source)) {
+		return DirToCopy, true
+	}
+	if withinPath(path, entry.Target) {
+		return DirToStore, true
+	}
+	return "", false
+}
+
+// watchedEntries returns the copy-mode links watch keeps in sync. Rendered
+// files are composed from several sources by mine agents link, so they
+// have no single store file to copy back to and are left out.
+func watchedEntries(m *Manifest) []LinkEntry {
+	var entries []LinkEntry
+	for _, l := range m.Links {
+		if l.Mode == "copy" && !strings.HasPrefix(l.Source, renderedDir+"/") {
+			entries = append(entries, l)
+		}
+	}
+	return entries
+}
+
+// syncCopy copies entry's store source over its target (DirToCopy) or the
+// target over the source (DirToStore), if policy allows it.
+func syncCopy(entry LinkEntry, storeDir, dir, policy string) WatchEvent {
+	ev := WatchEvent{Agent: entry.Agent, Source: entry.Source, Target: entry.Target, Direction: dir}
+	source := filepath.Join(storeDir, entry.Source)
+
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		ev.Message = entry.Source + " was removed from the store — run mine agents doctor"
+		return ev
+	}
+	// A deleted copy is restored whatever the policy; deletions never
+	// reach the store.
+	if _, err := os.Stat(entry.Target); os.IsNotExist(err) {
+		ev.Direction = DirToCopy
+	} else if dir == DirToStore && policy == WatchFromStore {
+		ev.Message = "copy edited; policy " + WatchFromStore + " leaves it — mine agents adopt to keep it"
+		return ev
+	} else if dir == DirToCopy && policy == WatchToStore {
+		ev.Message = "store changed; policy " + WatchToStore + " leaves the copy — mine agents link --copy --force to update it"
+		return ev
+	}
+
+	from, to := source, entry.Target
+	if ev.Direction == DirToStore {
+		from, to = entry.Target, source
+	}
+	if err := replaceWithCopy(from, to); err != nil {
+		ev.Err = err
+		return ev
+	}
+	ev.Synced = true
+	return ev
+}
+
+// replaceWithCopy makes to an exact copy of from, file or directory.
+func replaceWithCopy(from, to string) error {
+	info, err := os.Stat(from)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+			return err
+		}
+		return copyFileMode(from, to, info.Mode())
+	}
+	if err := os.RemoveAll(to); err != nil {
+		return fmt.Errorf("clearing %s: %w", to, err)
+	}
+	return copyDir(from, to)
+}
+
+// latestModTime returns the newest modification time of path or, for a
+// directory, anything in it. Missing paths return the zero time.
+func latestModTime(path string) time.Time {
+	var latest time.Time
+	_ = filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest
+}
+
+// withinPath reports whether path is base or inside it.
+func withinPath(path, base string) bool {
+	rel, err := filepath.Rel(base, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,182 @@
+package agents
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// setupWatchEnv initializes a store holding commands/deploy.md and records
+// a copy of it at target for claude. Returns (storeDir, target).
+func setupWatchEnv(t *testing.T) (string, string) {
+	t.Helper()
+	storeDir, homeDir := setupLinkEnv(t)
+	writeStoreFile(t, storeDir, "commands/deploy.md", "v1\n")
+
+	target := filepath.Join(homeDir, ".claude", "commands", "deploy.md")
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, []byte("v1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	upsertManifestLink(m, "commands/deploy.md", target, "claude", "copy")
+	if err := WriteManifest(m); err != nil {
+		t.Fatal(err)
+	}
+	return storeDir, target
+}
+
+// setMTime sets path's modification time to now plus offset.
+func setMTime(t *testing.T, path string, offset time.Duration) {
+	t.Helper()
+	when := time.Now().Add(offset)
+	if err := os.Chtimes(path, when, when); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readString(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestValidateWatchPolicy(t *testing.T) {
+	for _, p := range []string{WatchTwoWay, WatchFromStore, WatchToStore} {
+		if err := ValidateWatchPolicy(p); err != nil {
+			t.Errorf("ValidateWatchPolicy(%q) = %v", p, err)
+		}
+	}
+	if err := ValidateWatchPolicy("sideways"); err == nil {
+		t.Error("ValidateWatchPolicy(sideways) = nil, want error")
+	}
+}
+
+func TestReconcileCopies_NewerSideWins(t *testing.T) {
+	storeDir, target := setupWatchEnv(t)
+	source := filepath.Join(storeDir, "commands", "deploy.md")
+
+	// The copy was edited last: it goes to the store.
+	writeStoreFile(t, storeDir, "commands/deploy.md", "store\n")
+	setMTime(t, source, -time.Hour)
+	if err := os.WriteFile(target, []byte("copy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	events, err := ReconcileCopies(WatchTwoWay)
+	if err != nil {
+		t.Fatalf("ReconcileCopies: %v", err)
+	}
+	if len(events) != 1 || !events[0].Synced || events[0].Direction != DirToStore {
+		t.Fatalf("events = %+v, want one sync to the store", events)
+	}
+	if got := readString(t, source); got != "copy\n" {
+		t.Errorf("store = %q, want the copy's content", got)
+	}
+
+	// The store was edited last: it goes to the copy.
+	writeStoreFile(t, storeDir, "commands/deploy.md", "store again\n")
+	setMTime(t, target, -time.Hour)
+	if _, err := ReconcileCopies(WatchTwoWay); err != nil {
+		t.Fatalf("ReconcileCopies: %v", err)
+	}
+	if got := readString(t, target); got != "store again\n" {
+		t.Errorf("copy = %q, want the store's content", got)
+	}
+}
+
+func TestReconcileCopies_PolicyHoldsBack(t *testing.T) {
+	storeDir, target := setupWatchEnv(t)
+	source := filepath.Join(storeDir, "commands", "deploy.md")
+	setMTime(t, source, -time.Hour)
+	if err := os.WriteFile(target, []byte("copy edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := ReconcileCopies(WatchFromStore)
+	if err != nil {
+		t.Fatalf("ReconcileCopies: %v", err)
+	}
+	if len(events) != 1 || events[0].Synced || events[0].Message == "" {
+		t.Fatalf("events = %+v, want one held-back change", events)
+	}
+	if got := readString(t, source); got != "v1\n" {
+		t.Errorf("store = %q, want it untouched", got)
+	}
+}
+
+func TestReconcileCopies_RestoresDeletedCopy(t *testing.T) {
+	_, target := setupWatchEnv(t)
+	if err := os.Remove(target); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReconcileCopies(WatchToStore); err != nil {
+		t.Fatalf("ReconcileCopies: %v", err)
+	}
+	if got := readString(t, target); got != "v1\n" {
+		t.Errorf("copy = %q, want it restored", got)
+	}
+}
+
+func TestWatcher_SyncsEditsBothWays(t *testing.T) {
+	storeDir, target := setupWatchEnv(t)
+	source := filepath.Join(storeDir, "commands", "deploy.md")
+
+	w, err := NewWatcher(WatchTwoWay)
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	defer w.Close()
+	if w.Links() != 1 {
+		t.Fatalf("Links() = %d, want 1", w.Links())
+	}
+
+	events := make(chan WatchEvent, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = w.Run(ctx, func(ev WatchEvent) { events <- ev })
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	wait := func(want string) {
+		t.Helper()
+		select {
+		case ev := <-events:
+			if ev.Err != nil || !ev.Synced || ev.Direction != want {
+				t.Fatalf("event = %+v, want a %s sync", ev, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %s sync within 5s", want)
+		}
+	}
+
+	if err := os.WriteFile(target, []byte("edited copy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wait(DirToStore)
+	if got := readString(t, source); got != "edited copy\n" {
+		t.Errorf("store = %q, want the edited copy", got)
+	}
+
+	if err := os.WriteFile(source, []byte("edited store\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wait(DirToCopy)
+	if got := readString(t, target); got != "edited store\n" {
+		t.Errorf("copy = %q, want the edited store file", got)
+	}
+}
@@ -15,6 +15,151 @@ type Config struct {
 	Analytics AnalyticsConfig `toml:"analytics"`
 	Todo      TodoConfig      `toml:"todo"`
 	Grow      GrowConfig      `toml:"grow"`
+	TUI       TUIConfig       `toml:"tui"`
+	UI        UIConfig        `toml:"ui"`
+	Proj      ProjConfig      `toml:"proj"`
+	Env       EnvConfig       `toml:"env"`
+	Tmux      TmuxConfig      `toml:"tmux"`
+	Mux       MuxConfig       `toml:"mux"`
+	Agents    AgentsConfig    `toml:"agents"`
+	Stash     StashConfig     `toml:"stash"`
+	Plugins   PluginsConfig   `toml:"plugins"`
+}
+
+// PluginsConfig holds plugin system settings.
+type PluginsConfig struct {
+	// IndexURL is where 'mine plugin search' reads the plugin index from,
+	// over HTTPS or from a local path. Empty means DefaultPluginsIndexURL.
+	IndexURL string `toml:"index_url,omitempty"`
+}
+
+// DefaultPluginsIndexURL is the community plugin index.
+const DefaultPluginsIndexURL = "https://raw.githubusercontent.com/rnwolfe/mine/main/docs/plugins/index.json"
+
+// IndexURLOrDefault returns plugins.index_url, or the community index when
+// it's unset.
+func (p PluginsConfig) IndexURLOrDefault() string {
+	if p.IndexURL == "" {
+		return DefaultPluginsIndexURL
+	}
+	return p.IndexURL
+}
+
+// AgentsConfig holds coding agent config management settings.
+type AgentsConfig struct {
+	// WatchPolicy is which way 'mine agents watch' copies changes between
+	// the store and copy-mode links: two-way, from-store, or to-store.
+	// Empty means two-way.
+	WatchPolicy string `toml:"watch_policy,omitempty"`
+	// TokenBudget is the estimated token count 'mine agents lint' allows
+	// an agent's instructions. Zero means DefaultAgentsTokenBudget.
+	TokenBudget int `toml:"token_budget,omitempty"`
+}
+
+// AgentsWatchPolicies are the accepted agents.watch_policy values.
+var AgentsWatchPolicies = []string{"two-way", "from-store", "to-store"}
+
+// DefaultAgentsTokenBudget is the agents.token_budget used when none is set.
+const DefaultAgentsTokenBudget = 5000
+
+// StashConfig holds dotfile stash settings.
+type StashConfig struct {
+	// Scrub controls whether 'mine stash commit' redacts likely secrets
+	// from tracked files. Defaults to true when not set.
+	Scrub *bool `toml:"scrub,omitempty"`
+	// ScrubPatterns are extra regular expressions to redact on top of the
+	// built-in rules. A capture group limits redaction to the group.
+	ScrubPatterns []string `toml:"scrub_patterns,omitempty"`
+	// MaxFileSize is the size, e.g. "512KB" or "5MB", above which a tracked
+	// file gets a warning. Empty means DefaultStashMaxFileSize; "0" turns
+	// the warnings off.
+	MaxFileSize string `toml:"max_file_size,omitempty"`
+}
+
+// DefaultStashMaxFileSize is the stash.max_file_size used when none is set.
+const DefaultStashMaxFileSize = "1MB"
+
+// MaxFileBytes returns stash.max_file_size in bytes, falling back to the
+// default when it's missing or malformed. Zero means no limit.
+func (s StashConfig) MaxFileBytes() int64 {
+	if n, err := ParseByteSize(s.MaxFileSize); err == nil {
+		return n
+	}
+	n, _ := ParseByteSize(DefaultStashMaxFileSize)
+	return n
+}
+
+// ScrubEnabled returns whether stash secret scrubbing is on, treating nil
+// (missing from config) as true.
+func (s StashConfig) ScrubEnabled() bool {
+	if s.Scrub == nil {
+		return true
+	}
+	return *s.Scrub
+}
+
+// EnvConfig holds env profile configuration.
+type EnvConfig struct {
+	// Profile is the env profile used when a project has none selected with
+	// 'mine env switch'. Empty means "local".
+	Profile string `toml:"profile,omitempty"`
+}
+
+// TmuxConfig holds tmux session configuration.
+type TmuxConfig struct {
+	// Layout is the saved layout 'mine tmux project' applies to new sessions
+	// when --layout is not given.
+	Layout string `toml:"layout,omitempty"`
+	// AutoSession is what the shell hook does on entering a project
+	// directory outside tmux: off, ask, or attach. A project's
+	// tmux_auto_session setting overrides it.
+	AutoSession string `toml:"auto_session,omitempty"`
+}
+
+// TmuxAutoSessionModes are the accepted tmux.auto_session values.
+var TmuxAutoSessionModes = []string{"off", "ask", "attach"}
+
+// MuxConfig holds terminal multiplexer configuration.
+type MuxConfig struct {
+	// Backend is the multiplexer 'mine mux' drives: tmux or zellij.
+	// Empty means tmux.
+	Backend string `toml:"backend,omitempty"`
+}
+
+// MuxBackends are the accepted mux.backend values.
+var MuxBackends = []string{"tmux", "zellij"}
+
+// ProjConfig holds project registry configuration.
+type ProjConfig struct {
+	// ScanRoots are the directories 'mine proj scan' searches when no root
+	// is given, e.g. ["~/code", "~/work"].
+	ScanRoots []string `toml:"scan_roots,omitempty"`
+	// WorktreeDir is where 'mine proj worktree add' creates worktrees.
+	// Empty means next to the project's own directory.
+	WorktreeDir string `toml:"worktree_dir,omitempty"`
+}
+
+// UIConfig holds output styling configuration.
+type UIConfig struct {
+	Theme ThemeConfig `toml:"theme"`
+}
+
+// ThemeConfig selects the color palette. Name is a built-in theme
+// ("default", "light", "mono"); empty means default. ASCII drops colors and
+// emoji for plain terminals.
+type ThemeConfig struct {
+	Name  string `toml:"name,omitempty"`
+	ASCII bool   `toml:"ascii,omitempty"`
+}
+
+// TUIConfig holds interactive TUI configuration.
+type TUIConfig struct {
+	// Keys remaps todo browser actions to comma-separated keys, e.g.
+	// delete = "dd". Unlisted actions keep their default keys.
+	Keys map[string]string `toml:"keys,omitempty"`
+	// Sort and Group are the todo browser's last-used view, saved on exit.
+	Sort  string `toml:"sort,omitempty"`
+	Group string `toml:"group,omitempty"`
 }
 
 // GrowConfig holds career growth tracking configuration.
@@ -25,7 +170,24 @@ type GrowConfig struct {
 
 // TodoConfig holds todo-related configuration.
 type TodoConfig struct {
-	Urgency UrgencyWeightsConfig `toml:"urgency"`
+	// ActiveContext is the GTD context you are currently in (e.g. "work").
+	// Todos in this context get the urgency context boost. Empty disables it.
+	ActiveContext string               `toml:"active_context,omitempty"`
+	Urgency       UrgencyWeightsConfig `toml:"urgency"`
+	// DefaultTags are added to every new todo.
+	DefaultTags []string `toml:"default_tags,omitempty"`
+	// Escalation rules raise overdue todos automatically when listing.
+	// Empty disables escalation.
+	Escalation []EscalationRuleConfig `toml:"escalation,omitempty"`
+}
+
+// EscalationRuleConfig is one [[todo.escalation]] rule: once a todo is
+// OverdueDays past due, raise its priority to at least Priority and/or move
+// it to Schedule (or a more urgent bucket).
+type EscalationRuleConfig struct {
+	OverdueDays int    `toml:"overdue_days"`
+	Priority    string `toml:"priority,omitempty"`
+	Schedule    string `toml:"schedule,omitempty"`
 }
 
 // UrgencyWeightsConfig holds optional overrides for urgency scoring weights.
@@ -41,6 +203,7 @@ type UrgencyWeightsConfig struct {
 	PriorityLow   *int `toml:"priority_low,omitempty"`
 	AgeCap        *int `toml:"age_cap,omitempty"`
 	ProjectBoost  *int `toml:"project_boost,omitempty"`
+	ContextBoost  *int `toml:"context_boost,omitempty"`
 }
 
 // AnalyticsConfig controls anonymous usage analytics.
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProjectFileName is the per-project config overlay kept at a project root.
+const ProjectFileName = ".mine.toml"
+
+// projectOverlay is the subset of Config a project file may set. Everything
+// else (AI provider, shell, analytics, ...) stays user-global so a cloned
+// repo can't change it.
+type projectOverlay struct {
+	Todo struct {
+		Urgency     UrgencyWeightsConfig `toml:"urgency"`
+		DefaultTags []string             `toml:"default_tags"`
+	} `toml:"todo"`
+	Env  EnvConfig  `toml:"env"`
+	Tmux TmuxConfig `toml:"tmux"`
+}
+
+// LoadForDir returns the global config with the nearest project file at or
+// above dir merged on top of it.
+func LoadForDir(dir string) (*Config, error) {
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	path := FindProjectFile(dir)
+	if path == "" {
+		return cfg, nil
+	}
+	if err := applyProjectFile(cfg, path); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadForCWD is LoadForDir for the working directory.
+func LoadForCWD() (*Config, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return Load()
+	}
+	return LoadForDir(cwd)
+}
+
+// FindProjectFile returns the path of the nearest .mine.toml at or above
+// dir, or "" if there is none. The search stops at the enclosing git root.
+func FindProjectFile(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// applyProjectFile merges the project file at path into cfg. Keys a project
+// file may not set are an error rather than silently ignored.
+func applyProjectFile(cfg *Config, path string) error {
+	var o projectOverlay
+	md, err := toml.DecodeFile(path, &o)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, k := range undecoded {
+			keys[i] = k.String()
+		}
+		sort.Strings(keys)
+		return fmt.Errorf("%s: unsupported key(s) %s — project files may only set todo.urgency.*, todo.default_tags, env.profile, and tmux.layout",
+			path, strings.Join(keys, ", "))
+	}
+
+	cfg.Todo.Urgency.overlay(o.Todo.Urgency)
+	if md.IsDefined("todo", "default_tags") {
+		cfg.Todo.DefaultTags = o.Todo.DefaultTags
+	}
+	if o.Env.Profile != "" {
+		cfg.Env.Profile = o.Env.Profile
+	}
+	if o.Tmux.Layout != "" {
+		cfg.Tmux.Layout = o.Tmux.Layout
+	}
+	return nil
+}
+
+// overlay replaces each weight that o sets.
+func (u *UrgencyWeightsConfig) overlay(o UrgencyWeightsConfig) {
+	for _, f := range []struct{ dst, src **int }{
+		{&u.Overdue, &o.Overdue},
+		{&u.ScheduleToday, &o.ScheduleToday},
+		{&u.ScheduleSoon, &o.ScheduleSoon},
+		{&u.ScheduleLater, &o.ScheduleLater},
+		{&u.PriorityCrit, &o.PriorityCrit},
+		{&u.PriorityHigh, &o.PriorityHigh},
+		{&u.PriorityMed, &o.PriorityMed},
+		{&u.PriorityLow, &o.PriorityLow},
+		{&u.AgeCap, &o.AgeCap},
+		{&u.ProjectBoost, &o.ProjectBoost},
+		{&u.ContextBoost, &o.ContextBoost},
+	} {
+		if *f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadForDirMergesProjectFile(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+	writeFile(t, filepath.Join(tmp, "config", "mine", "config.toml"), `
+[user]
+name = "Ada"
+
+[todo]
+default_tags = ["global"]
+
+[todo.urgency]
+overdue = 50
+priority_high = 20
+
+[tmux]
+layout = "dev"
+`)
+	repo := filepath.Join(tmp, "repo")
+	writeFile(t, filepath.Join(repo, ProjectFileName), `
+[todo]
+default_tags = ["api", "backend"]
+
+[todo.urgency]
+priority_high = 40
+
+[env]
+profile = "staging"
+`)
+
+	cfg, err := LoadForDir(filepath.Join(repo, "internal", "deep"))
+	if err != nil {
+		t.Fatalf("LoadForDir: %v", err)
+	}
+	if cfg.User.Name != "Ada" {
+		t.Errorf("global values should survive, got name %q", cfg.User.Name)
+	}
+	if *cfg.Todo.Urgency.Overdue != 50 || *cfg.Todo.Urgency.PriorityHigh != 40 {
+		t.Errorf("urgency not merged: overdue=%d high=%d", *cfg.Todo.Urgency.Overdue, *cfg.Todo.Urgency.PriorityHigh)
+	}
+	if strings.Join(cfg.Todo.DefaultTags, ",") != "api,backend" {
+		t.Errorf("DefaultTags = %v", cfg.Todo.DefaultTags)
+	}
+	if cfg.Env.Profile != "staging" || cfg.Tmux.Layout != "dev" {
+		t.Errorf("env/tmux = %q/%q", cfg.Env.Profile, cfg.Tmux.Layout)
+	}
+}
+
+func TestLoadForDirRejectsGlobalOnlyKeys(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmp, "config"))
+	repo := filepath.Join(tmp, "repo")
+	writeFile(t, filepath.Join(repo, ProjectFileName), "[ai]\nprovider = \"evil\"\n")
+
+	_, err := LoadForDir(repo)
+	if err == nil || !strings.Contains(err.Error(), "ai.provider") {
+		t.Fatalf("expected unsupported key error, got %v", err)
+	}
+}
+
+func TestFindProjectFileStopsAtGitRoot(t *testing.T) {
+	tmp := t.TempDir()
+	writeFile(t, filepath.Join(tmp, ProjectFileName), "")
+	repo := filepath.Join(tmp, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := FindProjectFile(filepath.Join(tmp, "plain")); got != filepath.Join(tmp, ProjectFileName) {
+		t.Errorf("outside a repo, expected parent file, got %q", got)
+	}
+	if got := FindProjectFile(repo); got != "" {
+		t.Errorf("search should stop at the git root, got %q", got)
+	}
+	writeFile(t, filepath.Join(repo, ProjectFileName), "")
+	if got := FindProjectFile(repo); got != filepath.Join(repo, ProjectFileName) {
+		t.Errorf("expected repo file, got %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -116,6 +117,227 @@ var SchemaKeys = map[string]*KeyEntry{
 		set:        func(cfg *Config, v string) error { cfg.AI.CommitSystemInstructions = v; return nil },
 		unset:      func(cfg *Config) { cfg.AI.CommitSystemInstructions = "" },
 	},
+	"todo.active_context": {
+		Type:       KeyTypeString,
+		Desc:       "GTD context to boost in urgency scoring (e.g. work)",
+		DefaultStr: "",
+		get:        func(cfg *Config) string { return cfg.Todo.ActiveContext },
+		set:        func(cfg *Config, v string) error { cfg.Todo.ActiveContext = v; return nil },
+		unset:      func(cfg *Config) { cfg.Todo.ActiveContext = "" },
+	},
+	"todo.default_tags": {
+		Type:       KeyTypeString,
+		Desc:       "Comma-separated tags added by `mine todo add`",
+		DefaultStr: "",
+		get:        func(cfg *Config) string { return strings.Join(cfg.Todo.DefaultTags, ",") },
+		set: func(cfg *Config, v string) error {
+			cfg.Todo.DefaultTags = nil
+			for _, tag := range strings.Split(v, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					cfg.Todo.DefaultTags = append(cfg.Todo.DefaultTags, tag)
+				}
+			}
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.Todo.DefaultTags = nil },
+	},
+	"env.profile": {
+		Type:       KeyTypeString,
+		Desc:       "Env profile used when a project has none selected (default: local)",
+		DefaultStr: "",
+		get:        func(cfg *Config) string { return cfg.Env.Profile },
+		set:        func(cfg *Config, v string) error { cfg.Env.Profile = v; return nil },
+		unset:      func(cfg *Config) { cfg.Env.Profile = "" },
+	},
+	"tmux.layout": {
+		Type:       KeyTypeString,
+		Desc:       "Saved layout applied to new `mine tmux project` sessions",
+		DefaultStr: "",
+		get:        func(cfg *Config) string { return cfg.Tmux.Layout },
+		set:        func(cfg *Config, v string) error { cfg.Tmux.Layout = v; return nil },
+		unset:      func(cfg *Config) { cfg.Tmux.Layout = "" },
+	},
+	"tmux.auto_session": {
+		Type:       KeyTypeString,
+		Desc:       "On cd into a project outside tmux: off, ask, or attach its session",
+		DefaultStr: "off",
+		get: func(cfg *Config) string {
+			if cfg.Tmux.AutoSession == "" {
+				return "off"
+			}
+			return cfg.Tmux.AutoSession
+		},
+		set: func(cfg *Config, v string) error {
+			v = strings.ToLower(strings.TrimSpace(v))
+			for _, mode := range TmuxAutoSessionModes {
+				if v == mode {
+					cfg.Tmux.AutoSession = v
+					return nil
+				}
+			}
+			return fmt.Errorf("invalid value %q for tmux.auto_session — valid values: %s", v, strings.Join(TmuxAutoSessionModes, ", "))
+		},
+		unset: func(cfg *Config) { cfg.Tmux.AutoSession = "" },
+	},
+	"mux.backend": {
+		Type:       KeyTypeString,
+		Desc:       "Multiplexer `mine mux` drives: tmux or zellij",
+		DefaultStr: "tmux",
+		get: func(cfg *Config) string {
+			if cfg.Mux.Backend == "" {
+				return "tmux"
+			}
+			return cfg.Mux.Backend
+		},
+		set: func(cfg *Config, v string) error {
+			v = strings.ToLower(strings.TrimSpace(v))
+			for _, b := range MuxBackends {
+				if v == b {
+					cfg.Mux.Backend = v
+					return nil
+				}
+			}
+			return fmt.Errorf("invalid value %q for mux.backend — valid values: %s", v, strings.Join(MuxBackends, ", "))
+		},
+		unset: func(cfg *Config) { cfg.Mux.Backend = "" },
+	},
+	"agents.watch_policy": {
+		Type:       KeyTypeString,
+		Desc:       "Which way `mine agents watch` syncs copies: two-way, from-store, or to-store",
+		DefaultStr: "two-way",
+		get: func(cfg *Config) string {
+			if cfg.Agents.WatchPolicy == "" {
+				return "two-way"
+			}
+			return cfg.Agents.WatchPolicy
+		},
+		set: func(cfg *Config, v string) error {
+			v = strings.ToLower(strings.TrimSpace(v))
+			for _, p := range AgentsWatchPolicies {
+				if v == p {
+					cfg.Agents.WatchPolicy = v
+					return nil
+				}
+			}
+			return fmt.Errorf("invalid value %q for agents.watch_policy — valid values: %s", v, strings.Join(AgentsWatchPolicies, ", "))
+		},
+		unset: func(cfg *Config) { cfg.Agents.WatchPolicy = "" },
+	},
+	"agents.token_budget": {
+		Type:       KeyTypeInt,
+		Desc:       "Estimated tokens `mine agents lint` allows each agent's instructions",
+		DefaultStr: strconv.Itoa(DefaultAgentsTokenBudget),
+		get: func(cfg *Config) string {
+			if cfg.Agents.TokenBudget == 0 {
+				return strconv.Itoa(DefaultAgentsTokenBudget)
+			}
+			return strconv.Itoa(cfg.Agents.TokenBudget)
+		},
+		set: func(cfg *Config, v string) error {
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid value %q for agents.token_budget — must be a positive whole number", v)
+			}
+			cfg.Agents.TokenBudget = n
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.Agents.TokenBudget = 0 },
+	},
+	"stash.scrub": {
+		Type:       KeyTypeBool,
+		Desc:       "Redact likely secrets from dotfiles on `mine stash commit`",
+		DefaultStr: "true",
+		get:        func(cfg *Config) string { return fmt.Sprintf("%t", cfg.Stash.ScrubEnabled()) },
+		set: func(cfg *Config, v string) error {
+			b, err := ParseBoolValue(v)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for stash.scrub: %w", v, err)
+			}
+			cfg.Stash.Scrub = BoolPtr(b)
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.Stash.Scrub = nil },
+	},
+	"stash.max_file_size": {
+		Type:       KeyTypeString,
+		Desc:       "Warn about tracked dotfiles bigger than this, e.g. 512KB or 5MB (0 turns it off)",
+		DefaultStr: DefaultStashMaxFileSize,
+		get: func(cfg *Config) string {
+			if cfg.Stash.MaxFileSize == "" {
+				return DefaultStashMaxFileSize
+			}
+			return cfg.Stash.MaxFileSize
+		},
+		set: func(cfg *Config, v string) error {
+			v = strings.TrimSpace(v)
+			if _, err := ParseByteSize(v); err != nil {
+				return fmt.Errorf("invalid value %q for stash.max_file_size: %w", v, err)
+			}
+			cfg.Stash.MaxFileSize = v
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.Stash.MaxFileSize = "" },
+	},
+	"proj.scan_roots": {
+		Type:       KeyTypeString,
+		Desc:       "Comma-separated directories searched by `mine proj scan`",
+		DefaultStr: "",
+		get:        func(cfg *Config) string { return strings.Join(cfg.Proj.ScanRoots, ",") },
+		set: func(cfg *Config, v string) error {
+			cfg.Proj.ScanRoots = nil
+			for _, root := range strings.Split(v, ",") {
+				if root = strings.TrimSpace(root); root != "" {
+					cfg.Proj.ScanRoots = append(cfg.Proj.ScanRoots, root)
+				}
+			}
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.Proj.ScanRoots = nil },
+	},
+	"proj.worktree_dir": {
+		Type:       KeyTypeString,
+		Desc:       "Directory for `mine proj worktree add` (default: beside the project)",
+		DefaultStr: "",
+		get:        func(cfg *Config) string { return cfg.Proj.WorktreeDir },
+		set:        func(cfg *Config, v string) error { cfg.Proj.WorktreeDir = v; return nil },
+		unset:      func(cfg *Config) { cfg.Proj.WorktreeDir = "" },
+	},
+	"plugins.index_url": {
+		Type:       KeyTypeString,
+		Desc:       "Plugin index `mine plugin search` reads (URL or local path)",
+		DefaultStr: DefaultPluginsIndexURL,
+		get:        func(cfg *Config) string { return cfg.Plugins.IndexURLOrDefault() },
+		set:        func(cfg *Config, v string) error { cfg.Plugins.IndexURL = strings.TrimSpace(v); return nil },
+		unset:      func(cfg *Config) { cfg.Plugins.IndexURL = "" },
+	},
+	"ui.theme.name": {
+		Type:       KeyTypeString,
+		Desc:       "Color theme (default, light, mono)",
+		DefaultStr: "default",
+		get: func(cfg *Config) string {
+			if cfg.UI.Theme.Name == "" {
+				return "default"
+			}
+			return cfg.UI.Theme.Name
+		},
+		set:   func(cfg *Config, v string) error { cfg.UI.Theme.Name = v; return nil },
+		unset: func(cfg *Config) { cfg.UI.Theme.Name = "" },
+	},
+	"ui.theme.ascii": {
+		Type:       KeyTypeBool,
+		Desc:       "Plain ASCII output: no colors or emoji",
+		DefaultStr: "false",
+		get:        func(cfg *Config) string { return fmt.Sprintf("%t", cfg.UI.Theme.ASCII) },
+		set: func(cfg *Config, v string) error {
+			b, err := ParseBoolValue(v)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for ui.theme.ascii: %w", v, err)
+			}
+			cfg.UI.Theme.ASCII = b
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.UI.Theme.ASCII = false },
+	},
 	"analytics": {
 		Type:       KeyTypeBool,
 		Desc:       "Enable anonymous usage analytics",
@@ -149,6 +371,25 @@ func LookupKey(key string) (*KeyEntry, bool) {
 	return entry, ok
 }
 
+// ParseByteSize parses a size such as 800, 512KB, 1.5MB, or 2G. Units are
+// powers of 1024 and case-insensitive; a bare number is bytes.
+func ParseByteSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	num := strings.TrimRight(v, "KMGB ")
+	unit := strings.TrimSpace(v[len(num):])
+	scale := map[string]float64{
+		"": 1, "B": 1,
+		"K": 1 << 10, "KB": 1 << 10,
+		"M": 1 << 20, "MB": 1 << 20,
+		"G": 1 << 30, "GB": 1 << 30,
+	}[unit]
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || scale == 0 || n < 0 {
+		return 0, fmt.Errorf("not a size: %q (e.g. 512KB, 5MB)", s)
+	}
+	return int64(n * scale), nil
+}
+
 // ParseBoolValue accepts common boolean string representations.
 // Valid truthy values: true, 1, yes, on.
 // Valid falsy values: false, 0, no, off.
@@ -176,6 +176,250 @@ func TestSetGetUnset_AISystemInstructions(t *testing.T) {
 	}
 }
 
+func TestSetGetUnset_ProjScanRoots(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("proj.scan_roots")
+	if !ok {
+		t.Fatal("proj.scan_roots not found in registry")
+	}
+
+	if err := entry.Set(cfg, "~/code, ~/work,"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if len(cfg.Proj.ScanRoots) != 2 || cfg.Proj.ScanRoots[1] != "~/work" {
+		t.Fatalf("Set: expected two trimmed roots, got %q", cfg.Proj.ScanRoots)
+	}
+	if got := entry.Get(cfg); got != "~/code,~/work" {
+		t.Fatalf("Get: got %q", got)
+	}
+
+	entry.Unset(cfg)
+	if cfg.Proj.ScanRoots != nil {
+		t.Fatalf("Unset: expected no roots, got %q", cfg.Proj.ScanRoots)
+	}
+}
+
+func TestSetGetUnset_TmuxAutoSession(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("tmux.auto_session")
+	if !ok {
+		t.Fatal("tmux.auto_session not found in registry")
+	}
+
+	if got := entry.Get(cfg); got != "off" {
+		t.Fatalf("Get: expected off by default, got %q", got)
+	}
+	if err := entry.Set(cfg, "Ask"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.Tmux.AutoSession != "ask" {
+		t.Fatalf("Set: expected ask, got %q", cfg.Tmux.AutoSession)
+	}
+	if err := entry.Set(cfg, "always"); err == nil {
+		t.Fatal("Set: expected an error for an unknown mode")
+	}
+	entry.Unset(cfg)
+	if cfg.Tmux.AutoSession != "" {
+		t.Fatalf("Unset: expected empty, got %q", cfg.Tmux.AutoSession)
+	}
+}
+
+func TestSetGetUnset_MuxBackend(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("mux.backend")
+	if !ok {
+		t.Fatal("mux.backend not found in registry")
+	}
+
+	if got := entry.Get(cfg); got != "tmux" {
+		t.Fatalf("Get: expected tmux by default, got %q", got)
+	}
+	if err := entry.Set(cfg, " Zellij"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.Mux.Backend != "zellij" {
+		t.Fatalf("Set: expected zellij, got %q", cfg.Mux.Backend)
+	}
+	if err := entry.Set(cfg, "screen"); err == nil {
+		t.Fatal("Set: expected an error for an unknown backend")
+	}
+	entry.Unset(cfg)
+	if cfg.Mux.Backend != "" {
+		t.Fatalf("Unset: expected empty, got %q", cfg.Mux.Backend)
+	}
+}
+
+func TestSetGetUnset_AgentsWatchPolicy(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("agents.watch_policy")
+	if !ok {
+		t.Fatal("agents.watch_policy not found in registry")
+	}
+
+	if got := entry.Get(cfg); got != "two-way" {
+		t.Fatalf("Get: expected two-way by default, got %q", got)
+	}
+	if err := entry.Set(cfg, "From-Store"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.Agents.WatchPolicy != "from-store" {
+		t.Fatalf("Set: expected from-store, got %q", cfg.Agents.WatchPolicy)
+	}
+	if err := entry.Set(cfg, "sideways"); err == nil {
+		t.Fatal("Set: expected an error for an unknown policy")
+	}
+	entry.Unset(cfg)
+	if cfg.Agents.WatchPolicy != "" {
+		t.Fatalf("Unset: expected empty, got %q", cfg.Agents.WatchPolicy)
+	}
+}
+
+func TestSetGetUnset_AgentsTokenBudget(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("agents.token_budget")
+	if !ok {
+		t.Fatal("agents.token_budget not found in registry")
+	}
+
+	if got := entry.Get(cfg); got != "5000" {
+		t.Fatalf("Get: expected 5000 by default, got %q", got)
+	}
+	if err := entry.Set(cfg, " 8000 "); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.Agents.TokenBudget != 8000 {
+		t.Fatalf("Set: expected 8000, got %d", cfg.Agents.TokenBudget)
+	}
+	for _, bad := range []string{"lots", "0", "-5"} {
+		if err := entry.Set(cfg, bad); err == nil {
+			t.Errorf("Set(%q): expected an error", bad)
+		}
+	}
+	entry.Unset(cfg)
+	if cfg.Agents.TokenBudget != 0 {
+		t.Fatalf("Unset: expected 0, got %d", cfg.Agents.TokenBudget)
+	}
+}
+
+func TestSetGetUnset_StashScrub(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("stash.scrub")
+	if !ok {
+		t.Fatal("stash.scrub not found in registry")
+	}
+
+	if got := entry.Get(cfg); got != "true" {
+		t.Fatalf("Get: expected true by default, got %q", got)
+	}
+	if err := entry.Set(cfg, "false"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.Stash.ScrubEnabled() {
+		t.Fatal("Set: expected scrubbing off")
+	}
+	if err := entry.Set(cfg, "sometimes"); err == nil {
+		t.Fatal("Set: expected an error for a non-bool")
+	}
+	entry.Unset(cfg)
+	if !cfg.Stash.ScrubEnabled() {
+		t.Fatal("Unset: expected scrubbing back on")
+	}
+}
+
+func TestSetGetUnset_StashMaxFileSize(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("stash.max_file_size")
+	if !ok {
+		t.Fatal("stash.max_file_size not found in registry")
+	}
+
+	if got := entry.Get(cfg); got != DefaultStashMaxFileSize {
+		t.Fatalf("Get: expected %s by default, got %q", DefaultStashMaxFileSize, got)
+	}
+	if err := entry.Set(cfg, "512KB"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got := cfg.Stash.MaxFileBytes(); got != 512<<10 {
+		t.Fatalf("MaxFileBytes: expected %d, got %d", 512<<10, got)
+	}
+	if err := entry.Set(cfg, "huge"); err == nil {
+		t.Fatal("Set: expected an error for a non-size")
+	}
+	if err := entry.Set(cfg, "0"); err != nil || cfg.Stash.MaxFileBytes() != 0 {
+		t.Fatalf("Set(0): err %v, limit %d; want no limit", err, cfg.Stash.MaxFileBytes())
+	}
+	entry.Unset(cfg)
+	if got := cfg.Stash.MaxFileBytes(); got != 1<<20 {
+		t.Fatalf("Unset: expected the 1MB default, got %d", got)
+	}
+}
+
+func TestSetGetUnset_PluginsIndexURL(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("plugins.index_url")
+	if !ok {
+		t.Fatal("plugins.index_url not found in registry")
+	}
+
+	if got := entry.Get(cfg); got != DefaultPluginsIndexURL {
+		t.Fatalf("Get: expected the community index by default, got %q", got)
+	}
+	if err := entry.Set(cfg, " https://example.com/plugins.json "); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if cfg.Plugins.IndexURL != "https://example.com/plugins.json" {
+		t.Fatalf("Set: IndexURL = %q", cfg.Plugins.IndexURL)
+	}
+	entry.Unset(cfg)
+	if got := entry.Get(cfg); got != DefaultPluginsIndexURL {
+		t.Fatalf("Unset: expected the default back, got %q", got)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"800", 800},
+		{"800B", 800},
+		{"512kb", 512 << 10},
+		{"1.5 MB", 3 << 19},
+		{"2G", 2 << 30},
+		{"0", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "MB", "-1KB", "5TB", "five"} {
+		if _, err := ParseByteSize(bad); err == nil {
+			t.Errorf("ParseByteSize(%q) should error", bad)
+		}
+	}
+}
+
+func TestSetGetUnset_TodoDefaultTags(t *testing.T) {
+	cfg := &Config{}
+	entry, ok := LookupKey("todo.default_tags")
+	if !ok {
+		t.Fatal("todo.default_tags not found in registry")
+	}
+
+	if err := entry.Set(cfg, "work, ,review"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got := entry.Get(cfg); got != "work,review" {
+		t.Fatalf("Get: got %q", got)
+	}
+	entry.Unset(cfg)
+	if cfg.Todo.DefaultTags != nil {
+		t.Fatalf("Unset: expected no tags, got %q", cfg.Todo.DefaultTags)
+	}
+}
+
 func TestAllSchemaKeys_GetSetUnsetDoNotPanic(t *testing.T) {
 	cfg := defaultConfig()
 	for key, entry := range SchemaKeys {
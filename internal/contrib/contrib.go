@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/rnwolfe/mine/internal/github"
 )
 
 // MineRepo is the canonical mine repository slug.
@@ -109,15 +111,9 @@ func FetchIssue(repo string, number int) (*Issue, error) {
 		"--repo", repo,
 		"--json", "number,title,body,labels",
 	}
-	cmd := execCommand("gh", args...)
-	out, err := cmd.Output()
+	out, err := github.Output(execCommand("gh", args...))
 	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			return nil, fmt.Errorf("issue #%d not found in %s: %s",
-				number, repo, strings.TrimSpace(string(exitErr.Stderr)))
-		}
-		return nil, fmt.Errorf("fetching issue #%d: %w", number, err)
+		return nil, fmt.Errorf("fetching issue #%d from %s: %w", number, repo, err)
 	}
 
 	var issue Issue
@@ -140,15 +136,9 @@ func fetchIssues(repo, label string) ([]Issue, error) {
 		args = append(args, "--label", label)
 	}
 
-	cmd := execCommand("gh", args...)
-	out, err := cmd.Output()
+	out, err := github.Output(execCommand("gh", args...))
 	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			return nil, fmt.Errorf("gh issue list failed: %s",
-				strings.TrimSpace(string(exitErr.Stderr)))
-		}
-		return nil, fmt.Errorf("gh issue list failed: %w", err)
+		return nil, err
 	}
 
 	var issues []Issue
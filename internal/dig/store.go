@@ -2,6 +2,8 @@ package dig
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -155,3 +157,112 @@ func (s *Store) GetStats() (*Stats, error) {
 
 	return stats, nil
 }
+
+// Active is the focus session currently running, kept in kv so other
+// commands (like the tmux status line) can show its timer.
+type Active struct {
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Task      string        `json:"task,omitempty"`
+}
+
+// Remaining is how long the session has left at now.
+func (a Active) Remaining(now time.Time) time.Duration {
+	return a.StartedAt.Add(a.Duration).Sub(now)
+}
+
+// SetActive records a as the running session.
+func (s *Store) SetActive(a Active) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("encoding active session: %w", err)
+	}
+	if _, err := s.db.Exec(
+		`INSERT OR REPLACE INTO kv (key, value, updated_at) VALUES ('dig_active', ?, CURRENT_TIMESTAMP)`,
+		string(data),
+	); err != nil {
+		return fmt.Errorf("saving active session: %w", err)
+	}
+	return nil
+}
+
+// ClearActive forgets the running session.
+func (s *Store) ClearActive() error {
+	if _, err := s.db.Exec(`DELETE FROM kv WHERE key = 'dig_active'`); err != nil {
+		return fmt.Errorf("clearing active session: %w", err)
+	}
+	return nil
+}
+
+// GetActive returns the running session, or nil if there is none. A session
+// past its end is treated as over, in case its process died without
+// clearing it.
+func (s *Store) GetActive(now time.Time) (*Active, error) {
+	var data string
+	err := s.db.QueryRow(`SELECT value FROM kv WHERE key = 'dig_active'`).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading active session: %w", err)
+	}
+	var a Active
+	if err := json.Unmarshal([]byte(data), &a); err != nil {
+		return nil, fmt.Errorf("decoding active session: %w", err)
+	}
+	if a.Remaining(now) <= 0 {
+		return nil, nil
+	}
+	return &a, nil
+}
+
+// Session is a recorded dig session linked to a todo.
+type Session struct {
+	TodoID    int
+	TodoTitle string
+	Duration  time.Duration
+	Completed bool
+	StartedAt time.Time
+}
+
+// RecentForProject returns up to limit of the most recent sessions on todos
+// belonging to projectPath, newest first.
+func (s *Store) RecentForProject(projectPath string, limit int) ([]Session, error) {
+	rows, err := s.db.Query(`
+		SELECT d.todo_id, t.title, d.duration_secs, d.completed, d.started_at
+		FROM dig_sessions d
+		JOIN todos t ON t.id = d.todo_id
+		WHERE t.project_path = ?
+		ORDER BY d.started_at DESC, d.id DESC
+		LIMIT ?`, projectPath, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query project dig sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []Session
+	for rows.Next() {
+		var sess Session
+		var secs, completed int
+		var started string
+		if err := rows.Scan(&sess.TodoID, &sess.TodoTitle, &secs, &completed, &started); err != nil {
+			return nil, fmt.Errorf("scan dig session: %w", err)
+		}
+		sess.Duration = time.Duration(secs) * time.Second
+		sess.Completed = completed == 1
+		sess.StartedAt = parseTimestamp(started)
+		sessions = append(sessions, sess)
+	}
+	return sessions, rows.Err()
+}
+
+// parseTimestamp parses a DATETIME column, which the driver may return as
+// RFC3339 or in SQLite's native "2006-01-02 15:04:05" form.
+func parseTimestamp(s string) time.Time {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
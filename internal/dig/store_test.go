@@ -23,7 +23,9 @@ func openTestDB(t *testing.T) *sql.DB {
 	migrations := []string{
 		`PRAGMA foreign_keys = ON`,
 		`CREATE TABLE IF NOT EXISTS todos (
-			id INTEGER PRIMARY KEY AUTOINCREMENT
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			title TEXT NOT NULL DEFAULT '',
+			project_path TEXT
 		)`,
 		`CREATE TABLE IF NOT EXISTS dig_sessions (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -308,3 +310,78 @@ func TestGetStats_LinkedTasks(t *testing.T) {
 		t.Errorf("LinkedTasks = %d, want 2 (distinct todo IDs)", stats.LinkedTasks)
 	}
 }
+
+func TestRecentForProject(t *testing.T) {
+	db := openTestDB(t)
+	s := dig.NewStore(db)
+
+	db.Exec(`INSERT INTO todos (id, title, project_path) VALUES (1, 'fix login', '/code/api')`)
+	db.Exec(`INSERT INTO todos (id, title, project_path) VALUES (2, 'write docs', '/code/web')`)
+
+	one, two := 1, 2
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := s.RecordSession(25*time.Minute, &one, true, base); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RecordSession(50*time.Minute, &one, false, base.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RecordSession(10*time.Minute, &two, true, base.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RecordSession(10*time.Minute, nil, true, base.Add(3*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := s.RecentForProject("/code/api", 5)
+	if err != nil {
+		t.Fatalf("RecentForProject: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 api sessions, got %d", len(sessions))
+	}
+	if sessions[0].Duration != 50*time.Minute || sessions[0].Completed {
+		t.Errorf("newest session first, got %+v", sessions[0])
+	}
+	if sessions[1].TodoTitle != "fix login" || !sessions[1].StartedAt.Equal(base) {
+		t.Errorf("unexpected session: %+v", sessions[1])
+	}
+
+	sessions, err = s.RecentForProject("/code/api", 1)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("limit not applied: %v, %v", sessions, err)
+	}
+}
+
+func TestActiveSession(t *testing.T) {
+	db := openTestDB(t)
+	s := dig.NewStore(db)
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	if a, err := s.GetActive(now); err != nil || a != nil {
+		t.Fatalf("expected no active session, got %+v, %v", a, err)
+	}
+
+	if err := s.SetActive(dig.Active{StartedAt: now, Duration: 25 * time.Minute, Task: "write docs"}); err != nil {
+		t.Fatalf("SetActive: %v", err)
+	}
+	a, err := s.GetActive(now.Add(10 * time.Minute))
+	if err != nil || a == nil {
+		t.Fatalf("expected active session, got %+v, %v", a, err)
+	}
+	if a.Task != "write docs" || a.Remaining(now.Add(10*time.Minute)) != 15*time.Minute {
+		t.Errorf("unexpected active session %+v", a)
+	}
+
+	// A session whose process died is over once its time is up.
+	if a, _ := s.GetActive(now.Add(30 * time.Minute)); a != nil {
+		t.Errorf("expected expired session to be ignored, got %+v", a)
+	}
+
+	if err := s.ClearActive(); err != nil {
+		t.Fatalf("ClearActive: %v", err)
+	}
+	if a, _ := s.GetActive(now); a != nil {
+		t.Errorf("expected cleared session, got %+v", a)
+	}
+}
@@ -102,6 +102,15 @@ func (m *Manager) ActiveProfile(projectPath string) (string, error) {
 	if !errors.Is(err, sql.ErrNoRows) {
 		return "", err
 	}
+	// Nothing switched to yet: use the configured profile, which a project's
+	// .mine.toml can set.
+	cfg, err := config.LoadForDir(projectPath)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Env.Profile != "" {
+		return cfg.Env.Profile, nil
+	}
 	return defaultProfile, nil
 }
 
@@ -121,6 +130,29 @@ func (m *Manager) SwitchProfile(projectPath, name string) error {
 	return err
 }
 
+// MoveProject carries a project's profiles and active-profile choice over
+// from oldPath to newPath. If it fails, the profiles stay at oldPath.
+func (m *Manager) MoveProject(oldPath, newPath string) error {
+	oldDir, newDir := m.projectDir(oldPath), m.projectDir(newPath)
+	moved := false
+	if _, err := os.Stat(oldDir); err == nil {
+		if _, err := os.Stat(newDir); err == nil {
+			return fmt.Errorf("env profiles already exist for %s", newPath)
+		}
+		if err := os.Rename(oldDir, newDir); err != nil {
+			return fmt.Errorf("move env profiles: %w", err)
+		}
+		moved = true
+	}
+	if _, err := m.db.Exec(`UPDATE env_projects SET project_path = ? WHERE project_path = ?`, newPath, oldPath); err != nil {
+		if moved {
+			os.Rename(newDir, oldDir)
+		}
+		return err
+	}
+	return nil
+}
+
 func (m *Manager) CurrentProfile(projectPath string) (string, map[string]string, error) {
 	name, err := m.ActiveProfile(projectPath)
 	if err != nil {
@@ -403,6 +435,14 @@ func atomicWrite(path string, data []byte) error {
 	return nil
 }
 
+// Quote quotes v as a single word for a "posix" or "fish" shell script.
+func Quote(shellName, v string) string {
+	if shellName == "fish" {
+		return fishQuote(v)
+	}
+	return shellQuote(v)
+}
+
 func shellQuote(v string) string {
 	return "'" + strings.ReplaceAll(v, "'", `'"'"'`) + "'"
 }
@@ -83,6 +83,63 @@ func TestActiveProfileSwitchAndCurrent(t *testing.T) {
 	}
 }
 
+func TestActiveProfileFallsBackToProjectFile(t *testing.T) {
+	mgr, projectPath, done := setupTestManager(t, "secret-pass")
+	defer done()
+
+	if err := os.MkdirAll(projectPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectPath, ".mine.toml"), []byte("[env]\nprofile = \"dev\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	active, err := mgr.ActiveProfile(projectPath)
+	if err != nil {
+		t.Fatalf("ActiveProfile: %v", err)
+	}
+	if active != "dev" {
+		t.Fatalf("expected profile from .mine.toml, got %q", active)
+	}
+
+	if err := mgr.SetVar(projectPath, "staging", "PORT", "8080"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.SwitchProfile(projectPath, "staging"); err != nil {
+		t.Fatal(err)
+	}
+	if active, _ := mgr.ActiveProfile(projectPath); active != "staging" {
+		t.Fatalf("explicit switch should win, got %q", active)
+	}
+}
+
+func TestMoveProjectCarriesProfiles(t *testing.T) {
+	mgr, projectPath, done := setupTestManager(t, "secret-pass")
+	defer done()
+
+	if err := mgr.SetVar(projectPath, "staging", "PORT", "8080"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.SwitchProfile(projectPath, "staging"); err != nil {
+		t.Fatal(err)
+	}
+
+	newPath := projectPath + "-moved"
+	if err := mgr.MoveProject(projectPath, newPath); err != nil {
+		t.Fatalf("MoveProject: %v", err)
+	}
+	name, vars, err := mgr.CurrentProfile(newPath)
+	if err != nil {
+		t.Fatalf("CurrentProfile: %v", err)
+	}
+	if name != "staging" || vars["PORT"] != "8080" {
+		t.Fatalf("profile not carried over: %q %v", name, vars)
+	}
+	if profiles, _ := mgr.ListProfiles(projectPath); len(profiles) != 0 {
+		t.Fatalf("old path still has profiles: %v", profiles)
+	}
+}
+
 func TestDiffAndTemplate(t *testing.T) {
 	mgr, projectPath, done := setupTestManager(t, "secret-pass")
 	defer done()
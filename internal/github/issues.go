@@ -0,0 +1,138 @@
+// Package github wraps the gh CLI for the issue operations todo sync needs.
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Issue is the subset of a GitHub issue mirrored into todos.
+type Issue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	URL    string `json:"url"`
+	State  string `json:"state"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// LabelNames returns the issue's label names.
+func (i Issue) LabelNames() []string {
+	names := make([]string, 0, len(i.Labels))
+	for _, l := range i.Labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+// Issue states as reported by gh.
+const (
+	StateOpen   = "OPEN"
+	StateClosed = "CLOSED"
+)
+
+// listLimit caps how many open issues a single sync fetches.
+const listLimit = 500
+
+// execCommand is injectable for tests.
+var execCommand = exec.Command
+
+// CLI talks to GitHub through the gh CLI.
+type CLI struct{}
+
+// ListOpenIssues returns the open issues of repo.
+func (CLI) ListOpenIssues(repo string) ([]Issue, error) {
+	out, err := gh("issue", "list",
+		"--repo", repo,
+		"--state", "open",
+		"--json", "number,title,body,url,state,labels",
+		"--limit", strconv.Itoa(listLimit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var issues []Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing issues response: %w", err)
+	}
+	return issues, nil
+}
+
+// IssueState returns the state (StateOpen or StateClosed) of issue number.
+func (CLI) IssueState(repo string, number int) (string, error) {
+	out, err := gh("issue", "view", strconv.Itoa(number), "--repo", repo, "--json", "state")
+	if err != nil {
+		return "", err
+	}
+	var issue Issue
+	if err := json.Unmarshal(out, &issue); err != nil {
+		return "", fmt.Errorf("parsing issue response: %w", err)
+	}
+	return issue.State, nil
+}
+
+// CreateIssue opens an issue and returns its number and URL.
+func (CLI) CreateIssue(repo, title, body string) (int, string, error) {
+	out, err := gh("issue", "create", "--repo", repo, "--title", title, "--body", body)
+	if err != nil {
+		return 0, "", err
+	}
+	url := lastLine(string(out))
+	number, err := issueNumberFromURL(url)
+	if err != nil {
+		return 0, "", err
+	}
+	return number, url, nil
+}
+
+// CloseIssue closes issue number.
+func (CLI) CloseIssue(repo string, number int) error {
+	_, err := gh("issue", "close", strconv.Itoa(number), "--repo", repo)
+	return err
+}
+
+// gh runs a gh subcommand and returns its stdout.
+func gh(args ...string) ([]byte, error) {
+	return Output(execCommand("gh", args...))
+}
+
+// Output runs cmd, a gh invocation, and returns its stdout, surfacing gh's
+// stderr on failure. Callers build cmd themselves so tests can swap out
+// exec.Command.
+func Output(cmd *exec.Cmd) ([]byte, error) {
+	out, err := cmd.Output()
+	if err == nil {
+		return out, nil
+	}
+	sub := strings.Join(cmd.Args[1:min(3, len(cmd.Args))], " ")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("gh %s failed: %s", sub, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return nil, fmt.Errorf("gh %s failed: %w", sub, err)
+}
+
+// issueNumberFromURL extracts 42 from ".../issues/42".
+func issueNumberFromURL(url string) (int, error) {
+	i := strings.LastIndex(url, "/issues/")
+	if i < 0 {
+		return 0, fmt.Errorf("unexpected gh output %q — expected an issue URL", url)
+	}
+	n, err := strconv.Atoi(url[i+len("/issues/"):])
+	if err != nil {
+		return 0, fmt.Errorf("unexpected gh output %q — expected an issue URL", url)
+	}
+	return n, nil
+}
+
+// lastLine returns the last non-empty line of s.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
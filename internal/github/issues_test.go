@@ -0,0 +1,83 @@
+package github
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func fakeGH(t *testing.T, script string, gotArgs *[]string) {
+	t.Helper()
+	orig := execCommand
+	t.Cleanup(func() { execCommand = orig })
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		if gotArgs != nil {
+			*gotArgs = args
+		}
+		return exec.Command("sh", "-c", script)
+	}
+}
+
+func TestListOpenIssues(t *testing.T) {
+	var args []string
+	fakeGH(t, `echo '[{"number":3,"title":"Fix it","body":"b","url":"https://github.com/o/r/issues/3","state":"OPEN","labels":[{"name":"bug"}]}]'`, &args)
+
+	issues, err := CLI{}.ListOpenIssues("o/r")
+	if err != nil {
+		t.Fatalf("ListOpenIssues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Number != 3 || issues[0].Title != "Fix it" {
+		t.Fatalf("issues = %+v", issues)
+	}
+	if got := issues[0].LabelNames(); len(got) != 1 || got[0] != "bug" {
+		t.Errorf("LabelNames = %v", got)
+	}
+	if joined := strings.Join(args, " "); !strings.Contains(joined, "--repo o/r") || !strings.Contains(joined, "--state open") {
+		t.Errorf("args = %q", joined)
+	}
+}
+
+func TestListOpenIssues_Error(t *testing.T) {
+	fakeGH(t, "echo 'repo not found' >&2; exit 1", nil)
+
+	_, err := CLI{}.ListOpenIssues("o/r")
+	if err == nil || !strings.Contains(err.Error(), "repo not found") {
+		t.Fatalf("err = %v, want gh stderr surfaced", err)
+	}
+}
+
+func TestIssueState(t *testing.T) {
+	fakeGH(t, `echo '{"state":"CLOSED"}'`, nil)
+
+	state, err := CLI{}.IssueState("o/r", 3)
+	if err != nil {
+		t.Fatalf("IssueState: %v", err)
+	}
+	if state != StateClosed {
+		t.Errorf("state = %q, want %q", state, StateClosed)
+	}
+}
+
+func TestCreateIssue(t *testing.T) {
+	var args []string
+	fakeGH(t, "echo 'Creating issue in o/r'; echo; echo 'https://github.com/o/r/issues/17'", &args)
+
+	n, url, err := CLI{}.CreateIssue("o/r", "title", "body")
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if n != 17 || url != "https://github.com/o/r/issues/17" {
+		t.Errorf("got (%d, %q)", n, url)
+	}
+	if joined := strings.Join(args, " "); !strings.Contains(joined, "--title title") {
+		t.Errorf("args = %q", joined)
+	}
+}
+
+func TestCreateIssue_BadOutput(t *testing.T) {
+	fakeGH(t, "echo 'something else'", nil)
+
+	if _, _, err := (CLI{}).CreateIssue("o/r", "t", ""); err == nil {
+		t.Fatal("expected error for output without an issue URL")
+	}
+}
@@ -0,0 +1,91 @@
+// Package mux puts the terminal multiplexer behind one interface, so the
+// session commands work the same whether you use tmux or zellij.
+package mux
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Session is a multiplexer session.
+type Session struct {
+	Name string
+	// Windows is the window (tab) count, or 0 when the backend can't tell.
+	Windows  int
+	Attached bool
+}
+
+// FilterValue implements tui.Item for fuzzy matching.
+func (s Session) FilterValue() string { return s.Name }
+
+// Title implements tui.Item.
+func (s Session) Title() string { return s.Name }
+
+// Description implements tui.Item — window count and attached status.
+func (s Session) Description() string {
+	var parts []string
+	if s.Windows > 0 {
+		w := "window"
+		if s.Windows != 1 {
+			w = "windows"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", s.Windows, w))
+	}
+	if s.Attached {
+		parts = append(parts, "(attached)")
+	}
+	return strings.Join(parts, "  ")
+}
+
+// Multiplexer is a backend that manages sessions.
+type Multiplexer interface {
+	// Name is the backend's name, e.g. "tmux".
+	Name() string
+	// Available reports whether the backend's binary is in PATH.
+	Available() bool
+	// Inside reports whether the current process runs inside a session.
+	Inside() bool
+	// ListSessions returns the running sessions; none running is not an error.
+	ListSessions() ([]Session, error)
+	// NewSession creates a detached session in dir (or the current
+	// directory), named after that directory when name is empty. It returns
+	// the resolved name.
+	NewSession(name, dir string) (string, error)
+	// AttachSession attaches or switches to name, replacing the process
+	// where the backend requires it.
+	AttachSession(name string) error
+	// KillSession destroys name.
+	KillSession(name string) error
+	// RenameSession renames oldName to newName.
+	RenameSession(oldName, newName string) error
+}
+
+// New returns the backend for name ("tmux" or "zellij"). Empty means tmux.
+func New(name string) (Multiplexer, error) {
+	switch strings.ToLower(name) {
+	case "", "tmux":
+		return Tmux{}, nil
+	case "zellij":
+		return Zellij{}, nil
+	}
+	return nil, fmt.Errorf("unknown multiplexer %q — valid values: tmux, zellij", name)
+}
+
+// FuzzyFindSession returns the first session matching query — exact, then
+// prefix, then substring, ignoring case.
+func FuzzyFindSession(query string, sessions []Session) (*Session, error) {
+	q := strings.ToLower(query)
+	matchers := []func(name string) bool{
+		func(name string) bool { return name == q },
+		func(name string) bool { return strings.HasPrefix(name, q) },
+		func(name string) bool { return strings.Contains(name, q) },
+	}
+	for _, match := range matchers {
+		for i := range sessions {
+			if match(strings.ToLower(sessions[i].Name)) {
+				return &sessions[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no session matching %q", query)
+}
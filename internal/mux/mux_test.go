@@ -0,0 +1,161 @@
+package mux
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for name, want := range map[string]string{"": "tmux", "tmux": "tmux", "Zellij": "zellij"} {
+		m, err := New(name)
+		if err != nil {
+			t.Fatalf("New(%q): %v", name, err)
+		}
+		if m.Name() != want {
+			t.Errorf("New(%q).Name() = %q, want %q", name, m.Name(), want)
+		}
+	}
+	if _, err := New("screen"); err == nil {
+		t.Fatal("expected an error for an unknown backend")
+	}
+}
+
+func TestSessionDescription(t *testing.T) {
+	tests := []struct {
+		s    Session
+		want string
+	}{
+		{Session{Windows: 1}, "1 window"},
+		{Session{Windows: 3, Attached: true}, "3 windows  (attached)"},
+		{Session{Attached: true}, "(attached)"},
+		{Session{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Description(); got != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyFindSession(t *testing.T) {
+	sessions := []Session{{Name: "api-server"}, {Name: "api"}, {Name: "web"}}
+	for query, want := range map[string]string{"API": "api", "api-": "api-server", "eb": "web"} {
+		s, err := FuzzyFindSession(query, sessions)
+		if err != nil {
+			t.Fatalf("%q: %v", query, err)
+		}
+		if s.Name != want {
+			t.Errorf("%q: got %q, want %q", query, s.Name, want)
+		}
+	}
+	if _, err := FuzzyFindSession("nope", sessions); err == nil {
+		t.Fatal("expected an error for no match")
+	}
+}
+
+func TestParseZellijSessions(t *testing.T) {
+	raw := "api [Created 2h 5m ago] (current)\n" +
+		"old [Created 3days ago] (EXITED - attach to resurrect)\n" +
+		"\n" +
+		"web [Created 10s ago]\n"
+	got := parseZellijSessions(raw)
+	want := []Session{{Name: "api", Attached: true}, {Name: "web"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+// stubZellij records zellij invocations and answers with out/err.
+func stubZellij(t *testing.T, out string, err error) *[]string {
+	t.Helper()
+	var calls []string
+	orig := zellijCmd
+	zellijCmd = func(dir string, args ...string) (string, error) {
+		calls = append(calls, strings.TrimSpace(dir+" "+strings.Join(args, " ")))
+		return out, err
+	}
+	t.Cleanup(func() { zellijCmd = orig })
+	return &calls
+}
+
+func TestZellijListSessions_NoneRunning(t *testing.T) {
+	stubZellij(t, "", fmt.Errorf("exit status 1: No active zellij sessions found."))
+
+	sessions, err := Zellij{}.ListSessions()
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("expected no sessions, got %+v", sessions)
+	}
+}
+
+func TestZellijNewSession(t *testing.T) {
+	calls := stubZellij(t, "", nil)
+
+	name, err := Zellij{}.NewSession("", "/code/billing")
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	if name != "billing" {
+		t.Fatalf("expected name from dir, got %q", name)
+	}
+	want := []string{"/code/billing attach --create-background billing"}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Fatalf("calls = %q, want %q", *calls, want)
+	}
+}
+
+func TestZellijKillSession(t *testing.T) {
+	calls := stubZellij(t, "", nil)
+
+	if err := (Zellij{}).KillSession("api"); err != nil {
+		t.Fatalf("KillSession: %v", err)
+	}
+	if want := []string{"kill-session api"}; !reflect.DeepEqual(*calls, want) {
+		t.Fatalf("calls = %q, want %q", *calls, want)
+	}
+}
+
+func TestZellijRenameSession(t *testing.T) {
+	calls := stubZellij(t, "", nil)
+
+	t.Setenv("ZELLIJ", "")
+	if err := (Zellij{}).RenameSession("api", "billing"); err == nil {
+		t.Fatal("expected an error renaming from outside zellij")
+	}
+
+	t.Setenv("ZELLIJ", "0")
+	t.Setenv("ZELLIJ_SESSION_NAME", "web")
+	if err := (Zellij{}).RenameSession("api", "billing"); err == nil {
+		t.Fatal("expected an error renaming another session")
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("expected no zellij calls, got %q", *calls)
+	}
+
+	t.Setenv("ZELLIJ_SESSION_NAME", "api")
+	if err := (Zellij{}).RenameSession("api", "billing"); err != nil {
+		t.Fatalf("RenameSession: %v", err)
+	}
+	if want := []string{"action rename-session billing"}; !reflect.DeepEqual(*calls, want) {
+		t.Fatalf("calls = %q, want %q", *calls, want)
+	}
+}
+
+func TestZellijAttachSession_InsideRefuses(t *testing.T) {
+	t.Setenv("ZELLIJ", "0")
+	t.Setenv("ZELLIJ_SESSION_NAME", "api")
+	orig := execSyscall
+	execSyscall = func(string, []string, []string) error {
+		t.Fatal("should not exec inside zellij")
+		return nil
+	}
+	t.Cleanup(func() { execSyscall = orig })
+
+	if err := (Zellij{}).AttachSession("web"); err == nil {
+		t.Fatal("expected an error attaching from inside zellij")
+	}
+}
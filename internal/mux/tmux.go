@@ -0,0 +1,30 @@
+package mux
+
+import "github.com/rnwolfe/mine/internal/tmux"
+
+// Tmux is the tmux backend, a thin adapter over package tmux.
+type Tmux struct{}
+
+func (Tmux) Name() string    { return "tmux" }
+func (Tmux) Available() bool { return tmux.Available() }
+func (Tmux) Inside() bool    { return tmux.InsideTmux() }
+
+func (Tmux) ListSessions() ([]Session, error) {
+	raw, err := tmux.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]Session, len(raw))
+	for i, s := range raw {
+		sessions[i] = Session{Name: s.Name, Windows: s.Windows, Attached: s.Attached}
+	}
+	return sessions, nil
+}
+
+func (Tmux) NewSession(name, dir string) (string, error) { return tmux.NewSession(name, dir) }
+func (Tmux) AttachSession(name string) error             { return tmux.AttachSession(name) }
+func (Tmux) KillSession(name string) error               { return tmux.KillSession(name) }
+
+func (Tmux) RenameSession(oldName, newName string) error {
+	return tmux.RenameSession(oldName, newName)
+}
@@ -0,0 +1,127 @@
+package mux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Zellij is the zellij backend. zellij has no detached-server handle like
+// tmux's, so it is driven through its CLI: list-sessions, attach
+// --create-background, kill-session, and 'action rename-session'.
+type Zellij struct{}
+
+func (Zellij) Name() string { return "zellij" }
+
+func (Zellij) Available() bool {
+	_, err := exec.LookPath("zellij")
+	return err == nil
+}
+
+func (Zellij) Inside() bool { return os.Getenv("ZELLIJ") != "" }
+
+func (Zellij) ListSessions() ([]Session, error) {
+	out, err := zellijCmd("", "list-sessions", "--no-formatting")
+	if err != nil {
+		// zellij exits non-zero when nothing is running.
+		if strings.Contains(err.Error(), "No active zellij sessions") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	return parseZellijSessions(out), nil
+}
+
+func (Zellij) NewSession(name, dir string) (string, error) {
+	if name == "" {
+		d := dir
+		if d == "" {
+			wd, err := os.Getwd()
+			if err != nil {
+				return "", err
+			}
+			d = wd
+		}
+		name = filepath.Base(d)
+	}
+	if _, err := zellijCmd(dir, "attach", "--create-background", name); err != nil {
+		return "", fmt.Errorf("creating session %q: %w", name, err)
+	}
+	return name, nil
+}
+
+// AttachSession replaces the process with 'zellij attach'. zellij can't
+// switch sessions from the CLI, so inside one you have to detach first.
+func (z Zellij) AttachSession(name string) error {
+	if z.Inside() {
+		return fmt.Errorf("already inside zellij session %q — detach first (Ctrl-o d)", os.Getenv("ZELLIJ_SESSION_NAME"))
+	}
+	bin, err := exec.LookPath("zellij")
+	if err != nil {
+		return fmt.Errorf("zellij not found: %w", err)
+	}
+	return execSyscall(bin, []string{"zellij", "attach", name}, os.Environ())
+}
+
+func (Zellij) KillSession(name string) error {
+	if _, err := zellijCmd("", "kill-session", name); err != nil {
+		return fmt.Errorf("killing session %q: %w", name, err)
+	}
+	return nil
+}
+
+// RenameSession renames the session you're in; zellij only renames from
+// inside the session itself.
+func (z Zellij) RenameSession(oldName, newName string) error {
+	if newName == "" {
+		return fmt.Errorf("new session name cannot be empty")
+	}
+	if !z.Inside() || os.Getenv("ZELLIJ_SESSION_NAME") != oldName {
+		return fmt.Errorf("zellij can only rename the session you're in — attach to %q and run it there", oldName)
+	}
+	if _, err := zellijCmd("", "action", "rename-session", newName); err != nil {
+		return fmt.Errorf("renaming session %q to %q: %w", oldName, newName, err)
+	}
+	return nil
+}
+
+// parseZellijSessions parses 'zellij list-sessions --no-formatting' output:
+//
+//	api [Created 2h 5m ago] (current)
+//	old [Created 3days ago] (EXITED - attach to resurrect)
+//
+// Exited sessions are left out: they're resurrectable, not running.
+func parseZellijSessions(raw string) []Session {
+	var sessions []Session
+	for _, line := range strings.Split(raw, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.Contains(line, "(EXITED") {
+			continue
+		}
+		sessions = append(sessions, Session{
+			Name:     fields[0],
+			Attached: strings.Contains(line, "(current)"),
+		})
+	}
+	return sessions
+}
+
+// zellijCmd runs zellij with args in dir (if set) and returns combined
+// output; replaceable for testing.
+var zellijCmd = zellijCmdReal
+
+func zellijCmdReal(dir string, args ...string) (string, error) {
+	cmd := exec.Command("zellij", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// execSyscall is the process replacement function, replaceable for testing.
+var execSyscall = syscall.Exec
@@ -0,0 +1,51 @@
+// Package notify delivers desktop notifications. Platform implementations
+// shell out to OS tools (notify-send on Linux, osascript on macOS) and fall
+// back to a terminal bell when no notifier is available.
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Notification is a single message to deliver.
+type Notification struct {
+	Title string
+	Body  string
+}
+
+// Notifier delivers notifications to the user.
+type Notifier interface {
+	// Name identifies the delivery mechanism, e.g. "notify-send" or "bell".
+	Name() string
+	// Send delivers a notification.
+	Send(n Notification) error
+}
+
+// bellNotifier rings the terminal bell and prints the notification.
+// Used on unsupported platforms or when no desktop notifier is installed.
+type bellNotifier struct {
+	w io.Writer
+}
+
+// NewBell returns a Notifier that writes a bell character and the message to w.
+func NewBell(w io.Writer) Notifier {
+	return &bellNotifier{w: w}
+}
+
+func (b *bellNotifier) Name() string { return "bell" }
+
+func (b *bellNotifier) Send(n Notification) error {
+	if n.Body == "" {
+		_, err := fmt.Fprintf(b.w, "\a%s\n", n.Title)
+		return err
+	}
+	_, err := fmt.Fprintf(b.w, "\a%s — %s\n", n.Title, n.Body)
+	return err
+}
+
+// defaultBell is the fallback used by platform constructors.
+func defaultBell() Notifier {
+	return NewBell(os.Stdout)
+}
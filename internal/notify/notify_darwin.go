@@ -0,0 +1,38 @@
+//go:build darwin
+
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+type osascript struct{}
+
+// NewPlatform returns an osascript backed Notifier, or a terminal bell
+// fallback if osascript is unavailable.
+func NewPlatform() Notifier {
+	if _, err := exec.LookPath("osascript"); err != nil {
+		return defaultBell()
+	}
+	return &osascript{}
+}
+
+func (o *osascript) Name() string { return "osascript" }
+
+func (o *osascript) Send(msg Notification) error {
+	script := fmt.Sprintf("display notification %s with title %s", appleScriptString(msg.Body), appleScriptString(msg.Title))
+	out, err := exec.Command("osascript", "-e", script).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("sending notification: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,30 @@
+//go:build linux
+
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+type notifySend struct{}
+
+// NewPlatform returns a notify-send backed Notifier, or a terminal bell
+// fallback if notify-send is not installed (graceful degradation).
+func NewPlatform() Notifier {
+	if _, err := exec.LookPath("notify-send"); err != nil {
+		return defaultBell()
+	}
+	return &notifySend{}
+}
+
+func (n *notifySend) Name() string { return "notify-send" }
+
+func (n *notifySend) Send(msg Notification) error {
+	out, err := exec.Command("notify-send", "--app-name=mine", msg.Title, msg.Body).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("sending notification: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
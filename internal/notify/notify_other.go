@@ -0,0 +1,9 @@
+//go:build !darwin && !linux
+
+package notify
+
+// NewPlatform returns the terminal bell Notifier on platforms without a
+// supported desktop notification tool.
+func NewPlatform() Notifier {
+	return defaultBell()
+}
@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBell_Send(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Notification
+		want string
+	}{
+		{"title only", Notification{Title: "Stand up"}, "\aStand up\n"},
+		{"title and body", Notification{Title: "Due now", Body: "#3 Ship it"}, "\aDue now — #3 Ship it\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			b := NewBell(&buf)
+			if err := b.Send(tt.n); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+			if b.Name() != "bell" {
+				t.Errorf("Name() = %q", b.Name())
+			}
+		})
+	}
+}
+
+func TestNewPlatform_NeverNil(t *testing.T) {
+	if NewPlatform() == nil {
+		t.Fatal("NewPlatform returned nil")
+	}
+}
@@ -0,0 +1,209 @@
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// validConfigName enforces snake_case option names, like mine's own config keys.
+var validConfigName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ConfigOption declares a setting in the manifest's [[config]] section.
+// Users set it with `mine plugin config`, and the plugin receives its value
+// in the invocation's config field.
+type ConfigOption struct {
+	Name        string   `toml:"name"`
+	Type        string   `toml:"type"` // "string" (the default), "int", or "bool"
+	Description string   `toml:"description"`
+	Default     any      `toml:"default"`
+	Required    bool     `toml:"required"`
+	Choices     []string `toml:"choices"` // allowed values of a string option
+}
+
+// Kind returns the option's type, "string" if the manifest doesn't say.
+func (o ConfigOption) Kind() string {
+	if o.Type == "" {
+		return "string"
+	}
+	return o.Type
+}
+
+// validate checks the option's declaration in the manifest.
+func (o ConfigOption) validate() error {
+	if o.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if !validConfigName.MatchString(o.Name) {
+		return fmt.Errorf("name %q must be snake_case (lowercase letters, digits, and underscores)", o.Name)
+	}
+	switch o.Kind() {
+	case "string", "int", "bool":
+	default:
+		return fmt.Errorf("type %q is invalid (want string, int, or bool)", o.Type)
+	}
+	if len(o.Choices) > 0 && o.Kind() != "string" {
+		return fmt.Errorf("choices only apply to string options")
+	}
+	if o.Default != nil {
+		if o.Required {
+			return fmt.Errorf("a required option can't have a default")
+		}
+		if err := o.check(o.Default); err != nil {
+			return fmt.Errorf("default: %w", err)
+		}
+	}
+	return nil
+}
+
+// check validates a typed value against the option's type and choices.
+func (o ConfigOption) check(v any) error {
+	switch o.Kind() {
+	case "int":
+		if _, ok := v.(int64); !ok {
+			return fmt.Errorf("%v is not an int", v)
+		}
+	case "bool":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%v is not a bool", v)
+		}
+	default:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%v is not a string", v)
+		}
+		if len(o.Choices) > 0 && !slices.Contains(o.Choices, s) {
+			return fmt.Errorf("%q is not one of %s", s, strings.Join(o.Choices, ", "))
+		}
+	}
+	return nil
+}
+
+// parse converts a value as typed by the user to the option's type.
+func (o ConfigOption) parse(raw string) (any, error) {
+	var v any = raw
+	switch o.Kind() {
+	case "int":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer, got %q", o.Name, raw)
+		}
+		v = n
+	case "bool":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false, got %q", o.Name, raw)
+		}
+		v = b
+	}
+	if err := o.check(v); err != nil {
+		return nil, fmt.Errorf("%s: %w", o.Name, err)
+	}
+	return v, nil
+}
+
+// Option returns the manifest's config option called name.
+func (m *Manifest) Option(name string) (ConfigOption, bool) {
+	for _, o := range m.Config {
+		if o.Name == name {
+			return o, true
+		}
+	}
+	return ConfigOption{}, false
+}
+
+// ConfigValues returns the configuration sent to the plugin: each option's
+// value set with `mine plugin config`, or else its default. Fails when a
+// required option isn't set, or a stored value no longer fits its option.
+func (p *InstalledPlugin) ConfigValues() (map[string]any, error) {
+	name := p.Manifest.Plugin.Name
+	values := map[string]any{}
+	for _, o := range p.Manifest.Config {
+		raw, set := p.Config[o.Name]
+		switch {
+		case set:
+			v, err := o.parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("plugin %s: config %w — fix it with `mine plugin config %s set %s <value>`", name, err, name, o.Name)
+			}
+			values[o.Name] = v
+		case o.Default != nil:
+			values[o.Name] = o.Default
+		case o.Required:
+			return nil, fmt.Errorf("plugin %s: config %s is required — set it with `mine plugin config %s set %s <value>`", name, o.Name, name, o.Name)
+		}
+	}
+	return values, nil
+}
+
+// ConfigValue returns the option key's value as the user would type it,
+// falling back to its default. Reports false when neither is set.
+func (p *InstalledPlugin) ConfigValue(key string) (string, bool, error) {
+	o, ok := p.Manifest.Option(key)
+	if !ok {
+		return "", false, fmt.Errorf("plugin %s has no config option %q", p.Manifest.Plugin.Name, key)
+	}
+	if raw, set := p.Config[key]; set {
+		return raw, true, nil
+	}
+	if o.Default != nil {
+		return fmt.Sprint(o.Default), true, nil
+	}
+	return "", false, nil
+}
+
+// SetConfig validates value against the option key declared by the plugin
+// name, and stores it in the plugin registry.
+func SetConfig(name, key, value string) error {
+	p, err := Get(name)
+	if err != nil {
+		return err
+	}
+	o, ok := p.Manifest.Option(key)
+	if !ok {
+		return fmt.Errorf("plugin %s has no config option %q", name, key)
+	}
+	if _, err := o.parse(value); err != nil {
+		return err
+	}
+	return updateEntry(name, func(e *PluginEntry) {
+		if e.Config == nil {
+			e.Config = map[string]string{}
+		}
+		e.Config[key] = value
+	})
+}
+
+// UnsetConfig removes the plugin name's value for key, so it goes back to
+// the option's default.
+func UnsetConfig(name, key string) error {
+	p, err := Get(name)
+	if err != nil {
+		return err
+	}
+	if _, ok := p.Manifest.Option(key); !ok {
+		if _, set := p.Config[key]; !set {
+			return fmt.Errorf("plugin %s has no config option %q", name, key)
+		}
+	}
+	return updateEntry(name, func(e *PluginEntry) {
+		delete(e.Config, key)
+	})
+}
+
+// updateEntry applies fn to the registry entry for name and saves it.
+func updateEntry(name string, fn func(*PluginEntry)) error {
+	reg, err := LoadRegistry()
+	if err != nil {
+		return err
+	}
+	for i := range reg.Plugins {
+		if reg.Plugins[i].Name == name {
+			fn(&reg.Plugins[i])
+			return SaveRegistry(reg)
+		}
+	}
+	return fmt.Errorf("plugin %q not found", name)
+}
@@ -0,0 +1,184 @@
+package plugin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManifestValidate_Config(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{"valid", "[[config]]\nname = \"vault\"\ndefault = \"~/notes\"\n\n[[config]]\nname = \"limit\"\ntype = \"int\"\ndefault = 10\n", ""},
+		{"missing name", "[[config]]\ntype = \"bool\"\n", "config[0]: name is required"},
+		{"bad name", "[[config]]\nname = \"Vault-Path\"\n", "snake_case"},
+		{"bad type", "[[config]]\nname = \"vault\"\ntype = \"path\"\n", `type "path" is invalid`},
+		{"default type", "[[config]]\nname = \"limit\"\ntype = \"int\"\ndefault = \"ten\"\n", "default: ten is not an int"},
+		{"default choice", "[[config]]\nname = \"mode\"\nchoices = [\"a\", \"b\"]\ndefault = \"c\"\n", `default: "c" is not one of a, b`},
+		{"choices on bool", "[[config]]\nname = \"on\"\ntype = \"bool\"\nchoices = [\"yes\"]\n", "choices only apply to string options"},
+		{"required default", "[[config]]\nname = \"vault\"\nrequired = true\ndefault = \"x\"\n", "can't have a default"},
+		{"duplicate", "[[config]]\nname = \"vault\"\n\n[[config]]\nname = \"vault\"\n", `config[1].name "vault" is declared twice`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "mine-plugin.toml")
+			manifest := "[plugin]\nname = \"cfg\"\nversion = \"1.0.0\"\ndescription = \"d\"\nauthor = \"a\"\nprotocol_version = \"1.0.0\"\n\n" + tt.config
+			os.WriteFile(path, []byte(manifest), 0o644)
+
+			_, err := ParseManifest(path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParseManifest() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseManifest() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigOptionParse(t *testing.T) {
+	tests := []struct {
+		opt     ConfigOption
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{ConfigOption{Name: "vault"}, "~/notes", "~/notes", false},
+		{ConfigOption{Name: "limit", Type: "int"}, "42", int64(42), false},
+		{ConfigOption{Name: "limit", Type: "int"}, "lots", nil, true},
+		{ConfigOption{Name: "on", Type: "bool"}, "true", true, false},
+		{ConfigOption{Name: "on", Type: "bool"}, "maybe", nil, true},
+		{ConfigOption{Name: "mode", Choices: []string{"fast", "safe"}}, "safe", "safe", false},
+		{ConfigOption{Name: "mode", Choices: []string{"fast", "safe"}}, "slow", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.opt.parse(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s.parse(%q) error = %v, wantErr %v", tt.opt.Name, tt.raw, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s.parse(%q) = %#v, want %#v", tt.opt.Name, tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestPluginConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+
+	outDir := filepath.Join(dir, "out")
+	os.MkdirAll(outDir, 0o755)
+	outFile := filepath.Join(outDir, "invocation.json")
+
+	srcDir := filepath.Join(dir, "source")
+	os.MkdirAll(srcDir, 0o755)
+	manifest := `[plugin]
+name = "cfg-plugin"
+version = "1.0.0"
+description = "A plugin with config"
+author = "tester"
+protocol_version = "1.0.0"
+
+[[commands]]
+name = "show"
+description = "Capture the invocation"
+
+[[config]]
+name = "vault"
+description = "Path to the vault"
+required = true
+
+[[config]]
+name = "limit"
+type = "int"
+default = 10
+
+[[config]]
+name = "dry_run"
+type = "bool"
+
+[permissions]
+filesystem = ["` + outDir + `"]
+`
+	os.WriteFile(filepath.Join(srcDir, "mine-plugin.toml"), []byte(manifest), 0o644)
+	os.WriteFile(filepath.Join(srcDir, "mine-plugin-cfg-plugin"), []byte("#!/bin/sh\ncat > "+outFile+"\n"), 0o755)
+
+	p, err := Install(srcDir, "local")
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+
+	// A required option must be set before the plugin runs.
+	if err := RunCommand(p, "show", nil); err == nil || !strings.Contains(err.Error(), "config vault is required") {
+		t.Errorf("RunCommand() without required config error = %v", err)
+	}
+
+	if err := SetConfig("cfg-plugin", "limit", "many"); err == nil {
+		t.Error("SetConfig() should reject a non-integer for an int option")
+	}
+	if err := SetConfig("cfg-plugin", "colour", "red"); err == nil {
+		t.Error("SetConfig() should reject an undeclared option")
+	}
+	if err := SetConfig("nope", "vault", "x"); err == nil {
+		t.Error("SetConfig() should reject an unknown plugin")
+	}
+	for key, value := range map[string]string{"vault": "~/notes", "dry_run": "true"} {
+		if err := SetConfig("cfg-plugin", key, value); err != nil {
+			t.Fatalf("SetConfig(%s) error: %v", key, err)
+		}
+	}
+
+	p, err = Get("cfg-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok, err := p.ConfigValue("limit"); err != nil || !ok || v != "10" {
+		t.Errorf("ConfigValue(limit) = %q, %v, %v, want the default 10", v, ok, err)
+	}
+	if err := RunCommand(p, "show", nil); err != nil {
+		t.Fatalf("RunCommand() error: %v", err)
+	}
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("reading captured invocation: %v", err)
+	}
+	var inv map[string]any
+	if err := json.Unmarshal(data, &inv); err != nil {
+		t.Fatalf("parsing invocation JSON: %v", err)
+	}
+	cfg, _ := inv["config"].(map[string]any)
+	if cfg["vault"] != "~/notes" || cfg["limit"] != float64(10) || cfg["dry_run"] != true {
+		t.Errorf("invocation config = %v", inv["config"])
+	}
+
+	// Reinstalling keeps the values set; unsetting drops back to the default.
+	if _, err := Install(srcDir, "local"); err != nil {
+		t.Fatalf("reinstall error: %v", err)
+	}
+	if err := UnsetConfig("cfg-plugin", "dry_run"); err != nil {
+		t.Fatalf("UnsetConfig() error: %v", err)
+	}
+	p, _ = Get("cfg-plugin")
+	values, err := p.ConfigValues()
+	if err != nil {
+		t.Fatalf("ConfigValues() error: %v", err)
+	}
+	if values["vault"] != "~/notes" || values["limit"] != int64(10) {
+		t.Errorf("ConfigValues() after reinstall = %v", values)
+	}
+	if _, set := values["dry_run"]; set {
+		t.Errorf("ConfigValues() still has dry_run after unset: %v", values)
+	}
+}
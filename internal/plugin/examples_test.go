@@ -117,7 +117,7 @@ func TestExampleTodoStats(t *testing.T) {
 	}
 
 	// Invoke notify hook
-	notifyHandler := pluginHookHandler(binPath, "notify", "notify", 30e9, got.Manifest.Permissions)
+	notifyHandler := pluginHookHandler(binPath, "notify", "notify", 30e9, got.Manifest.Permissions, nil)
 	ctx := &hook.Context{
 		Command:   "todo.done",
 		Args:      []string{"buy milk"},
@@ -180,7 +180,7 @@ func TestExampleWebhook(t *testing.T) {
 	}
 
 	// Transform hook (preexec on todo.add)
-	transformHandler := pluginHookHandler(binPath, "preexec", "transform", 5e9, got.Manifest.Permissions)
+	transformHandler := pluginHookHandler(binPath, "preexec", "transform", 5e9, got.Manifest.Permissions, nil)
 	ctx := &hook.Context{
 		Command:   "todo.add",
 		Args:      []string{"buy milk"},
@@ -196,7 +196,7 @@ func TestExampleWebhook(t *testing.T) {
 	}
 
 	// Notify hook (todo.done) — no WEBHOOK_URL set, should succeed silently
-	notifyHandler := pluginHookHandler(binPath, "notify", "notify", 30e9, got.Manifest.Permissions)
+	notifyHandler := pluginHookHandler(binPath, "notify", "notify", 30e9, got.Manifest.Permissions, nil)
 	if _, err := notifyHandler(ctx); err != nil {
 		t.Fatalf("notify hook error: %v", err)
 	}
@@ -256,7 +256,7 @@ func TestExampleTagEnforcer(t *testing.T) {
 	}
 
 	// Transform hook — prevalidate on todo.add (no tags → should add "untagged")
-	transformHandler := pluginHookHandler(installedBin, "prevalidate", "transform", 5e9, got.Manifest.Permissions)
+	transformHandler := pluginHookHandler(installedBin, "prevalidate", "transform", 5e9, got.Manifest.Permissions, nil)
 	ctx := &hook.Context{
 		Command:   "todo.add",
 		Args:      []string{"buy milk"},
@@ -287,7 +287,7 @@ func TestExampleTagEnforcer(t *testing.T) {
 	}
 
 	// Postexec hook — should pass through unchanged
-	postHandler := pluginHookHandler(installedBin, "postexec", "transform", 5e9, got.Manifest.Permissions)
+	postHandler := pluginHookHandler(installedBin, "postexec", "transform", 5e9, got.Manifest.Permissions, nil)
 	result3, err := postHandler(ctx)
 	if err != nil {
 		t.Fatalf("postexec hook error: %v", err)
@@ -0,0 +1,104 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Index is the community plugin index: a JSON file listing plugins that
+// can be installed from git.
+type Index struct {
+	Plugins []IndexEntry `json:"plugins"`
+}
+
+// IndexEntry is one plugin in the index.
+type IndexEntry struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Author      string      `json:"author"`
+	Repo        string      `json:"repo"`              // e.g. "github.com/user/mine-plugin-foo"
+	Version     string      `json:"version,omitempty"` // latest release, e.g. "1.2.0"
+	Tags        []string    `json:"tags,omitempty"`
+	Permissions Permissions `json:"permissions"`
+}
+
+// InstallCommand returns the command that installs this plugin, pinned to
+// releases compatible with the listed version when there is one.
+func (e IndexEntry) InstallCommand() string {
+	spec := e.Repo
+	if v, ok := parseVersion(e.Version); ok {
+		spec += fmt.Sprintf("@^%d.%d", v.major, v.minor)
+	}
+	return "mine plugin install " + spec
+}
+
+// FetchIndex reads the plugin index from indexURL, over HTTP(S) or, for a
+// plain path, from disk.
+func FetchIndex(indexURL string) (*Index, error) {
+	var data []byte
+	if strings.HasPrefix(indexURL, "https://") || strings.HasPrefix(indexURL, "http://") {
+		client := &http.Client{Timeout: 10 * time.Second}
+		req, err := http.NewRequest("GET", indexURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "mine-cli")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetching plugin index: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return nil, fmt.Errorf("fetching plugin index: %s returned %d", indexURL, resp.StatusCode)
+		}
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, fmt.Errorf("fetching plugin index: %w", err)
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(strings.TrimPrefix(indexURL, "file://")); err != nil {
+			return nil, fmt.Errorf("reading plugin index: %w", err)
+		}
+	}
+
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parsing plugin index: %w", err)
+	}
+	return &idx, nil
+}
+
+// Search returns the index entries whose name, description, or tags
+// contain query (case-insensitive), limited to those tagged tag when it's
+// set. An empty query matches everything. Results are sorted by name.
+func (idx *Index) Search(query, tag string) []IndexEntry {
+	query = strings.ToLower(query)
+	var matches []IndexEntry
+	for _, e := range idx.Plugins {
+		if tag != "" && !containsFold(e.Tags, tag) {
+			continue
+		}
+		text := strings.ToLower(e.Name + " " + e.Description + " " + strings.Join(e.Tags, " "))
+		if strings.Contains(text, query) {
+			matches = append(matches, e)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Name < matches[j].Name })
+	return matches
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
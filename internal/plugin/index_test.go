@@ -0,0 +1,114 @@
+package plugin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testIndex = `{
+  "plugins": [
+    {
+      "name": "obsidian-sync",
+      "description": "Sync todos to an Obsidian vault",
+      "author": "alice",
+      "repo": "github.com/alice/mine-plugin-obsidian-sync",
+      "version": "1.4.2",
+      "tags": ["notes", "sync"],
+      "permissions": {"filesystem": ["~/Obsidian"], "config_read": true}
+    },
+    {
+      "name": "slack-notify",
+      "description": "Post completed todos to Slack",
+      "author": "bob",
+      "repo": "github.com/bob/mine-plugin-slack",
+      "tags": ["chat"],
+      "permissions": {"network": true, "env_vars": ["SLACK_WEBHOOK_URL"]}
+    }
+  ]
+}`
+
+func TestFetchIndex_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testIndex))
+	}))
+	defer server.Close()
+
+	idx, err := FetchIndex(server.URL + "/index.json")
+	if err != nil {
+		t.Fatalf("FetchIndex() error: %v", err)
+	}
+	if len(idx.Plugins) != 2 {
+		t.Fatalf("FetchIndex() read %d plugins, want 2", len(idx.Plugins))
+	}
+	p := idx.Plugins[1].Permissions
+	if !p.Network || len(p.EnvVars) != 1 || p.EnvVars[0] != "SLACK_WEBHOOK_URL" {
+		t.Errorf("slack-notify permissions = %+v", p)
+	}
+}
+
+func TestFetchIndex_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	if _, err := FetchIndex(server.URL); err == nil {
+		t.Error("FetchIndex() should error on a 404")
+	}
+
+	bad := filepath.Join(t.TempDir(), "index.json")
+	os.WriteFile(bad, []byte("not json"), 0o644)
+	if _, err := FetchIndex(bad); err == nil {
+		t.Error("FetchIndex() should error on malformed JSON")
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.json")
+	os.WriteFile(path, []byte(testIndex), 0o644)
+	idx, err := FetchIndex("file://" + path)
+	if err != nil {
+		t.Fatalf("FetchIndex() error: %v", err)
+	}
+
+	tests := []struct {
+		query, tag string
+		want       []string
+	}{
+		{"", "", []string{"obsidian-sync", "slack-notify"}},
+		{"SLACK", "", []string{"slack-notify"}},
+		{"vault", "", []string{"obsidian-sync"}},
+		{"sync", "", []string{"obsidian-sync"}},
+		{"", "chat", []string{"slack-notify"}},
+		{"slack", "notes", nil},
+	}
+	for _, tt := range tests {
+		got := idx.Search(tt.query, tt.tag)
+		var names []string
+		for _, e := range got {
+			names = append(names, e.Name)
+		}
+		if len(names) != len(tt.want) || (len(names) > 0 && names[0] != tt.want[0]) {
+			t.Errorf("Search(%q, %q) = %v, want %v", tt.query, tt.tag, names, tt.want)
+		}
+	}
+}
+
+func TestIndexEntryInstallCommand(t *testing.T) {
+	e := IndexEntry{Repo: "github.com/alice/mine-plugin-obsidian-sync", Version: "1.4.2"}
+	if got, want := e.InstallCommand(), "mine plugin install github.com/alice/mine-plugin-obsidian-sync@^1.4"; got != want {
+		t.Errorf("InstallCommand() = %q, want %q", got, want)
+	}
+	e.Version = ""
+	if got, want := e.InstallCommand(), "mine plugin install github.com/alice/mine-plugin-obsidian-sync"; got != want {
+		t.Errorf("InstallCommand() = %q, want %q", got, want)
+	}
+}
+
+func TestCommunityIndexParses(t *testing.T) {
+	if _, err := FetchIndex(filepath.Join("..", "..", "docs", "plugins", "index.json")); err != nil {
+		t.Fatalf("docs/plugins/index.json: %v", err)
+	}
+}
@@ -35,6 +35,17 @@ type PluginEntry struct {
 	Dir         string `toml:"dir"`
 	InstalledAt string `toml:"installed_at"`
 	Enabled     bool   `toml:"enabled"`
+
+	// Set for plugins installed from a git repository.
+	Constraint string `toml:"constraint,omitempty"` // as requested, e.g. "^1.2" or "v1.2.0"
+	Ref        string `toml:"ref,omitempty"`        // tag or ref installed; empty for the default branch
+	Commit     string `toml:"commit,omitempty"`
+
+	Checksum  string `toml:"checksum,omitempty"`   // entrypoint SHA-256, checked before each run
+	PublicKey string `toml:"public_key,omitempty"` // signing key, pinned for upgrades
+	Insecure  bool   `toml:"insecure,omitempty"`   // installed with --insecure; runs unverified
+
+	Config map[string]string `toml:"config,omitempty"` // set with `mine plugin config`, kept across upgrades
 }
 
 // LoadRegistry reads the plugins registry from disk.
@@ -73,8 +84,22 @@ func SaveRegistry(reg *PluginsRegistry) error {
 }
 
 // Install installs a plugin from a local directory containing mine-plugin.toml.
-// For GitHub installs, the caller should clone/download first, then call this.
+// For git repositories, use Fetch and install the Checkout instead.
 func Install(sourceDir, source string) (*InstalledPlugin, error) {
+	return install(sourceDir, PluginEntry{Source: source})
+}
+
+// InstallInsecure installs like Install, but goes ahead when the plugin's
+// signature doesn't verify, and lets it run without checking its entrypoint.
+func InstallInsecure(sourceDir, source string) (*InstalledPlugin, error) {
+	return install(sourceDir, PluginEntry{Source: source, Insecure: true})
+}
+
+// install copies the plugin in sourceDir into place and registers it,
+// filling in the rest of entry from its manifest. The entrypoint must pass
+// verifySource, and a plugin signed before must still be signed by the
+// same key, unless entry is Insecure.
+func install(sourceDir string, entry PluginEntry) (*InstalledPlugin, error) {
 	manifestPath := filepath.Join(sourceDir, "mine-plugin.toml")
 	manifest, err := ParseManifest(manifestPath)
 	if err != nil {
@@ -85,6 +110,18 @@ func Install(sourceDir, source string) (*InstalledPlugin, error) {
 		return nil, err
 	}
 
+	checksum, verifyErr := verifySource(sourceDir, manifest)
+	if verifyErr == nil {
+		verifyErr = checkSigningKey(manifest)
+	}
+	if verifyErr != nil && !entry.Insecure {
+		return nil, fmt.Errorf("verifying %s: %w", manifest.Plugin.Name, verifyErr)
+	}
+	if verifyErr == nil && !entry.Insecure {
+		entry.Checksum = checksum
+		entry.PublicKey = manifest.Signature.PublicKey
+	}
+
 	// Create plugin directory
 	pluginDir := filepath.Join(PluginsDir(), manifest.Plugin.Name)
 	if err := os.MkdirAll(pluginDir, 0o755); err != nil {
@@ -117,22 +154,22 @@ func Install(sourceDir, source string) (*InstalledPlugin, error) {
 		return nil, err
 	}
 
-	// Remove existing entry if upgrading
+	// Replace the existing entry if upgrading, keeping its config
 	filtered := make([]PluginEntry, 0, len(reg.Plugins))
 	for _, p := range reg.Plugins {
 		if p.Name != manifest.Plugin.Name {
 			filtered = append(filtered, p)
+		} else {
+			entry.Config = p.Config
 		}
 	}
 
-	filtered = append(filtered, PluginEntry{
-		Name:        manifest.Plugin.Name,
-		Version:     manifest.Plugin.Version,
-		Source:      source,
-		Dir:         pluginDir,
-		InstalledAt: time.Now().UTC().Format(time.RFC3339),
-		Enabled:     true,
-	})
+	entry.Name = manifest.Plugin.Name
+	entry.Version = manifest.Plugin.Version
+	entry.Dir = pluginDir
+	entry.InstalledAt = time.Now().UTC().Format(time.RFC3339)
+	entry.Enabled = true
+	filtered = append(filtered, entry)
 	reg.Plugins = filtered
 
 	if err := SaveRegistry(reg); err != nil {
@@ -144,9 +181,28 @@ func Install(sourceDir, source string) (*InstalledPlugin, error) {
 		Dir:         pluginDir,
 		InstalledAt: time.Now(),
 		Enabled:     true,
+		Checksum:    entry.Checksum,
+		Insecure:    entry.Insecure,
+		Config:      entry.Config,
 	}, nil
 }
 
+// checkSigningKey refuses to replace a plugin installed with a signing key
+// by one signed with a different key, or not signed at all.
+func checkSigningKey(m *Manifest) error {
+	existing, err := registryEntry(m.Plugin.Name)
+	if err != nil || existing.PublicKey == "" {
+		return nil
+	}
+	if !m.Signature.Signed() {
+		return fmt.Errorf("it was signed by %s but this version isn't signed", KeyFingerprint(existing.PublicKey))
+	}
+	if m.Signature.PublicKey != existing.PublicKey {
+		return fmt.Errorf("signing key changed from %s to %s", KeyFingerprint(existing.PublicKey), KeyFingerprint(m.Signature.PublicKey))
+	}
+	return nil
+}
+
 // Remove uninstalls a plugin by name.
 func Remove(name string) error {
 	if err := validatePluginName(name); err != nil {
@@ -215,6 +271,9 @@ func List() ([]InstalledPlugin, error) {
 			Dir:         entry.Dir,
 			InstalledAt: installedAt,
 			Enabled:     entry.Enabled,
+			Checksum:    entry.Checksum,
+			Insecure:    entry.Insecure,
+			Config:      entry.Config,
 		})
 	}
 
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -18,10 +19,12 @@ var validPluginName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
 
 // Manifest represents a parsed mine-plugin.toml file.
 type Manifest struct {
-	Plugin      PluginMeta   `toml:"plugin"`
-	Hooks       []HookDef    `toml:"hooks"`
-	Commands    []CommandDef `toml:"commands"`
-	Permissions Permissions  `toml:"permissions"`
+	Plugin      PluginMeta     `toml:"plugin"`
+	Hooks       []HookDef      `toml:"hooks"`
+	Commands    []CommandDef   `toml:"commands"`
+	Config      []ConfigOption `toml:"config"`
+	Permissions Permissions    `toml:"permissions"`
+	Signature   Signature      `toml:"signature"`
 }
 
 // PluginMeta holds plugin identification and compatibility info.
@@ -53,12 +56,25 @@ type CommandDef struct {
 
 // Permissions declares what system resources a plugin needs.
 type Permissions struct {
-	Network     bool     `toml:"network"`
-	Filesystem  []string `toml:"filesystem"`
-	Store       bool     `toml:"store"`
-	ConfigRead  bool     `toml:"config_read"`
-	ConfigWrite bool     `toml:"config_write"`
-	EnvVars     []string `toml:"env_vars"`
+	Network     bool     `toml:"network" json:"network,omitempty"`
+	Filesystem  []string `toml:"filesystem" json:"filesystem,omitempty"`
+	Store       bool     `toml:"store" json:"store,omitempty"`
+	ConfigRead  bool     `toml:"config_read" json:"config_read,omitempty"`
+	ConfigWrite bool     `toml:"config_write" json:"config_write,omitempty"`
+	EnvVars     []string `toml:"env_vars" json:"env_vars,omitempty"`
+}
+
+// Signature points at a signed checksums file covering the entrypoint, so
+// mine can verify the binary at install and before each run.
+type Signature struct {
+	Checksums string `toml:"checksums"`  // sha256sum-style file, e.g. "SHA256SUMS"
+	File      string `toml:"signature"`  // minisign or SSH signature of Checksums
+	PublicKey string `toml:"public_key"` // minisign key, or SSH key like "ssh-ed25519 AAAA..."
+}
+
+// Signed reports whether the manifest declares a signature.
+func (s Signature) Signed() bool {
+	return s.Checksums != "" || s.File != "" || s.PublicKey != ""
 }
 
 // InstalledPlugin represents a plugin on disk with its parsed manifest.
@@ -67,6 +83,9 @@ type InstalledPlugin struct {
 	Dir         string
 	InstalledAt time.Time
 	Enabled     bool
+	Checksum    string            // entrypoint SHA-256 recorded at install
+	Insecure    bool              // installed with --insecure; runs unverified
+	Config      map[string]string // values set with `mine plugin config`
 }
 
 // PluginsDir returns the directory where plugins are installed.
@@ -145,6 +164,28 @@ func (m *Manifest) Validate() error {
 		}
 	}
 
+	if m.Signature.Signed() {
+		if m.Signature.Checksums == "" || m.Signature.File == "" || m.Signature.PublicKey == "" {
+			return fmt.Errorf("signature needs checksums, signature, and public_key")
+		}
+		for _, f := range []string{m.Signature.Checksums, m.Signature.File} {
+			if filepath.IsAbs(f) || strings.HasPrefix(filepath.Clean(f), "..") {
+				return fmt.Errorf("signature file %q must be inside the plugin", f)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, o := range m.Config {
+		if err := o.validate(); err != nil {
+			return fmt.Errorf("config[%d]: %w", i, err)
+		}
+		if seen[o.Name] {
+			return fmt.Errorf("config[%d].name %q is declared twice", i, o.Name)
+		}
+		seen[o.Name] = true
+	}
+
 	for i, c := range m.Commands {
 		if c.Name == "" {
 			return fmt.Errorf("commands[%d].name is required", i)
@@ -383,9 +383,12 @@ func TestRunCommand_InvocationJSON(t *testing.T) {
 	}
 
 	p := &InstalledPlugin{
-		Manifest: Manifest{Plugin: PluginMeta{Name: "test-inv", Version: "1.0.0"}},
-		Dir:      dir,
-		Enabled:  true,
+		Manifest: Manifest{
+			Plugin:      PluginMeta{Name: "test-inv", Version: "1.0.0"},
+			Permissions: Permissions{Filesystem: []string{dir}}, // so the sandbox lets it capture stdin
+		},
+		Dir:     dir,
+		Enabled: true,
 	}
 
 	if err := RunCommand(p, "sync", []string{"--force", "notes"}); err != nil {
@@ -495,9 +498,12 @@ func TestSendLifecycleEvent_InvocationJSON(t *testing.T) {
 	}
 
 	p := &InstalledPlugin{
-		Manifest: Manifest{Plugin: PluginMeta{Name: "test-lcinv", Version: "1.0.0"}},
-		Dir:      dir,
-		Enabled:  true,
+		Manifest: Manifest{
+			Plugin:      PluginMeta{Name: "test-lcinv", Version: "1.0.0"},
+			Permissions: Permissions{Filesystem: []string{dir}}, // so the sandbox lets it capture stdin
+		},
+		Dir:     dir,
+		Enabled: true,
 	}
 
 	if err := SendLifecycleEvent(p, "upgrade"); err != nil {
@@ -704,6 +710,7 @@ args = "<name>"
 [permissions]
 network = false
 config_read = true
+filesystem = ["` + filepath.Join(dir, "markers") + `"]
 `
 	if err := os.WriteFile(filepath.Join(srcDir, "mine-plugin.toml"), []byte(manifest), 0o644); err != nil {
 		t.Fatal(err)
@@ -803,7 +810,7 @@ esac
 	}
 
 	// --- Phase 5: Verify pluginHookHandler works with the binary ---
-	handler := pluginHookHandler(binPath, "preexec", "transform", 5e9, got.Manifest.Permissions)
+	handler := pluginHookHandler(binPath, "preexec", "transform", 5e9, got.Manifest.Permissions, nil)
 	ctx := &hook.Context{
 		Command:   "todo.add",
 		Args:      []string{"buy milk"},
@@ -812,7 +819,7 @@ esac
 	}
 	result, err := handler(ctx)
 	if err != nil {
-		t.Fatalf("pluginHookHandler(transform) error: %v", err)
+		t.Fatalf("pluginHookHandler(transform, nil) error: %v", err)
 	}
 	if result.Command != "todo.add" {
 		t.Errorf("transform result command = %q, want %q", result.Command, "todo.add")
@@ -822,10 +829,10 @@ esac
 	}
 
 	// Notify hook
-	notifyHandler := pluginHookHandler(binPath, "notify", "notify", 30e9, got.Manifest.Permissions)
+	notifyHandler := pluginHookHandler(binPath, "notify", "notify", 30e9, got.Manifest.Permissions, nil)
 	_, err = notifyHandler(ctx)
 	if err != nil {
-		t.Fatalf("pluginHookHandler(notify) error: %v", err)
+		t.Fatalf("pluginHookHandler(notify, nil) error: %v", err)
 	}
 	if _, err := os.Stat(filepath.Join(markerDir, "hook-notify-notify")); err != nil {
 		t.Error("hook notify marker not created")
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 
@@ -27,15 +26,16 @@ const (
 
 // Invocation is the JSON envelope sent to plugin binaries on stdin.
 type Invocation struct {
-	ProtocolVersion string         `json:"protocol_version"`
-	Type            InvocationType `json:"type"`
-	Stage           string         `json:"stage,omitempty"`
-	Mode            string         `json:"mode,omitempty"`
-	Event           string         `json:"event,omitempty"`
-	Command         string         `json:"command,omitempty"`
-	Context         *hook.Context  `json:"context,omitempty"`
-	Args            []string       `json:"args,omitempty"`
+	ProtocolVersion string            `json:"protocol_version"`
+	Type            InvocationType    `json:"type"`
+	Stage           string            `json:"stage,omitempty"`
+	Mode            string            `json:"mode,omitempty"`
+	Event           string            `json:"event,omitempty"`
+	Command         string            `json:"command,omitempty"`
+	Context         *hook.Context     `json:"context,omitempty"`
+	Args            []string          `json:"args,omitempty"`
 	Flags           map[string]string `json:"flags,omitempty"`
+	Config          map[string]any    `json:"config,omitempty"` // the plugin's [[config]] values
 }
 
 // Response is the JSON response from a plugin for transform hooks.
@@ -61,6 +61,10 @@ func RegisterPluginHooks() error {
 		binPath := filepath.Join(p.Dir, p.Manifest.Entrypoint())
 		source := "plugin:" + p.Manifest.Plugin.Name
 
+		// A plugin missing required config stays registered, so its hooks
+		// report what to set instead of silently not running.
+		settings, settingsErr := p.ConfigValues()
+
 		for _, hd := range p.Manifest.Hooks {
 			stage := hook.Stage(hd.Stage)
 			mode := hook.Mode(hd.Mode)
@@ -75,7 +79,10 @@ func RegisterPluginHooks() error {
 				}
 			}
 
-			handler := pluginHookHandler(binPath, stage, mode, timeout, p.Manifest.Permissions)
+			handler := verifiedHandler(&p, pluginHookHandler(binPath, stage, mode, timeout, p.Manifest.Permissions, settings))
+			if settingsErr != nil {
+				handler = func(*hook.Context) (*hook.Context, error) { return nil, settingsErr }
+			}
 
 			if err := hook.Register(hook.Hook{
 				Pattern: hd.Command,
@@ -94,8 +101,30 @@ func RegisterPluginHooks() error {
 	return nil
 }
 
+// verifiedHandler checks p's entrypoint before each call to h.
+func verifiedHandler(p *InstalledPlugin, h hook.Handler) hook.Handler {
+	return func(ctx *hook.Context) (*hook.Context, error) {
+		if err := p.verify(); err != nil {
+			return nil, err
+		}
+		return h(ctx)
+	}
+}
+
+// verify refuses to run an entrypoint that no longer matches the checksum
+// recorded at install, unless the plugin was installed with --insecure.
+func (p *InstalledPlugin) verify() error {
+	if p.Insecure || p.Checksum == "" {
+		return nil
+	}
+	if err := verifyEntrypoint(filepath.Join(p.Dir, p.Manifest.Entrypoint()), p.Checksum); err != nil {
+		return fmt.Errorf("plugin %s: %w", p.Manifest.Plugin.Name, err)
+	}
+	return nil
+}
+
 // pluginHookHandler creates a hook.Handler that invokes a plugin binary.
-func pluginHookHandler(binPath string, stage hook.Stage, mode hook.Mode, timeout time.Duration, perms Permissions) hook.Handler {
+func pluginHookHandler(binPath string, stage hook.Stage, mode hook.Mode, timeout time.Duration, perms Permissions, settings map[string]any) hook.Handler {
 	return func(ctx *hook.Context) (*hook.Context, error) {
 		inv := Invocation{
 			ProtocolVersion: ProtocolVersion,
@@ -103,6 +132,7 @@ func pluginHookHandler(binPath string, stage hook.Stage, mode hook.Mode, timeout
 			Stage:           string(stage),
 			Mode:            string(mode),
 			Context:         ctx,
+			Config:          settings,
 		}
 
 		invJSON, err := json.Marshal(inv)
@@ -113,7 +143,7 @@ func pluginHookHandler(binPath string, stage hook.Stage, mode hook.Mode, timeout
 		execCtx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
-		cmd := exec.CommandContext(execCtx, binPath)
+		cmd := sandboxCommand(execCtx, binPath, perms)
 		cmd.Stdin = bytes.NewReader(invJSON)
 		cmd.Env = buildPluginEnv(perms)
 
@@ -161,6 +191,13 @@ func pluginHookHandler(binPath string, stage hook.Stage, mode hook.Mode, timeout
 
 // RunCommand executes a plugin's custom command.
 func RunCommand(p *InstalledPlugin, cmdName string, args []string) error {
+	if err := p.verify(); err != nil {
+		return err
+	}
+	settings, err := p.ConfigValues()
+	if err != nil {
+		return err
+	}
 	binPath := filepath.Join(p.Dir, p.Manifest.Entrypoint())
 
 	inv := Invocation{
@@ -168,6 +205,7 @@ func RunCommand(p *InstalledPlugin, cmdName string, args []string) error {
 		Type:            InvocationCommand,
 		Command:         cmdName,
 		Args:            args,
+		Config:          settings,
 	}
 
 	invJSON, err := json.Marshal(inv)
@@ -175,7 +213,7 @@ func RunCommand(p *InstalledPlugin, cmdName string, args []string) error {
 		return fmt.Errorf("serializing command invocation: %w", err)
 	}
 
-	cmd := exec.Command(binPath)
+	cmd := sandboxCommand(context.Background(), binPath, p.Manifest.Permissions)
 	cmd.Stdin = bytes.NewReader(invJSON)
 	cmd.Env = buildPluginEnv(p.Manifest.Permissions)
 
@@ -188,12 +226,20 @@ func RunCommand(p *InstalledPlugin, cmdName string, args []string) error {
 
 // SendLifecycleEvent sends a lifecycle event to a plugin.
 func SendLifecycleEvent(p *InstalledPlugin, event string) error {
+	if err := p.verify(); err != nil {
+		return err
+	}
+	settings, err := p.ConfigValues()
+	if err != nil {
+		return err
+	}
 	binPath := filepath.Join(p.Dir, p.Manifest.Entrypoint())
 
 	inv := Invocation{
 		ProtocolVersion: ProtocolVersion,
 		Type:            InvocationLifecycle,
 		Event:           event,
+		Config:          settings,
 	}
 
 	invJSON, err := json.Marshal(inv)
@@ -204,7 +250,7 @@ func SendLifecycleEvent(p *InstalledPlugin, event string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, binPath)
+	cmd := sandboxCommand(ctx, binPath, p.Manifest.Permissions)
 	cmd.Stdin = bytes.NewReader(invJSON)
 	cmd.Env = buildPluginEnv(p.Manifest.Permissions)
 
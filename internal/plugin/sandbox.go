@@ -0,0 +1,132 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/rnwolfe/mine/internal/config"
+)
+
+var (
+	sandboxOnce sync.Once
+	sandboxTool string
+	warnOnce    sync.Once
+)
+
+// Sandbox returns the tool that confines plugin writes on this system,
+// "bwrap" on Linux or "sandbox-exec" on macOS, or "" when neither works
+// and filesystem permissions can't be enforced. Landlock isn't used: it
+// has to be applied by the process being confined, which os/exec can't do
+// between fork and exec.
+func Sandbox() string {
+	sandboxOnce.Do(func() {
+		switch runtime.GOOS {
+		case "linux":
+			// bwrap can be installed but unusable, e.g. when unprivileged
+			// user namespaces are disabled, so try it once.
+			if exec.Command("bwrap", "--ro-bind", "/", "/", "true").Run() == nil {
+				sandboxTool = "bwrap"
+			}
+		case "darwin":
+			if _, err := exec.LookPath("sandbox-exec"); err == nil {
+				sandboxTool = "sandbox-exec"
+			}
+		}
+	})
+	return sandboxTool
+}
+
+// sandboxCommand builds the command that runs the plugin entrypoint at bin
+// with writes limited to the paths perms allows. Without a sandbox it runs
+// bin directly and warns, once, that filesystem permissions aren't enforced.
+func sandboxCommand(ctx context.Context, bin string, perms Permissions) *exec.Cmd {
+	switch Sandbox() {
+	case "bwrap":
+		return exec.CommandContext(ctx, "bwrap", bwrapArgs(bin, writablePaths(perms))...)
+	case "sandbox-exec":
+		return exec.CommandContext(ctx, "sandbox-exec", "-p", seatbeltProfile(writablePaths(perms)), bin)
+	}
+	warnOnce.Do(func() {
+		log.Printf("warning: no plugin sandbox available (%s), plugin filesystem permissions are not enforced", sandboxHint())
+	})
+	return exec.CommandContext(ctx, bin)
+}
+
+// sandboxHint says what would enable sandboxing on this system.
+func sandboxHint() string {
+	switch runtime.GOOS {
+	case "linux":
+		return "install bubblewrap"
+	case "darwin":
+		return "sandbox-exec not found"
+	}
+	return "unsupported on " + runtime.GOOS
+}
+
+// writablePaths returns the absolute paths a plugin may write to: its
+// declared filesystem paths, with ~ expanded, plus mine's data directory
+// when it has store access and config directory when it has config_write.
+func writablePaths(perms Permissions) []string {
+	home, _ := os.UserHomeDir()
+	var paths []string
+	for _, p := range perms.Filesystem {
+		switch {
+		case p == "~":
+			p = home
+		case strings.HasPrefix(p, "~/"):
+			p = filepath.Join(home, p[2:])
+		}
+		if abs, err := filepath.Abs(p); err == nil {
+			paths = append(paths, abs)
+		}
+	}
+	if perms.Store {
+		paths = append(paths, config.GetPaths().DataDir)
+	}
+	if perms.ConfigWrite {
+		paths = append(paths, config.GetPaths().ConfigDir)
+	}
+	return paths
+}
+
+// bwrapArgs returns the bwrap arguments that run bin with a read-only view
+// of the filesystem, a private /tmp, and paths mounted writable. Paths that
+// don't exist yet stay absent, since bwrap can only mount what's there.
+func bwrapArgs(bin string, paths []string) []string {
+	args := []string{
+		"--die-with-parent",
+		"--ro-bind", "/", "/",
+		"--dev-bind", "/dev", "/dev",
+		"--tmpfs", "/tmp",
+	}
+	for _, p := range paths {
+		args = append(args, "--bind-try", p, p)
+	}
+	return append(args, "--", bin)
+}
+
+// seatbeltProfile returns a sandbox-exec profile that denies writes
+// everywhere except paths, the temporary directories, and /dev.
+func seatbeltProfile(paths []string) string {
+	allowed := []string{
+		`(subpath "/private/tmp")`,
+		`(subpath "/private/var/folders")`,
+		`(subpath "/dev")`,
+	}
+	for _, p := range paths {
+		// Seatbelt matches resolved paths, e.g. /private/tmp, not /tmp.
+		if real, err := filepath.EvalSymlinks(p); err == nil {
+			p = real
+		}
+		allowed = append(allowed, fmt.Sprintf("(subpath %q)", p))
+	}
+	return "(version 1)\n(allow default)\n(deny file-write*)\n(allow file-write*\n    " +
+		strings.Join(allowed, "\n    ") + ")\n"
+}
@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWritablePaths(t *testing.T) {
+	home := t.TempDir()
+	dir := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+
+	got := writablePaths(Permissions{
+		Filesystem:  []string{"~", "~/notes", "/srv/vault"},
+		Store:       true,
+		ConfigWrite: true,
+	})
+	want := []string{
+		home,
+		filepath.Join(home, "notes"),
+		"/srv/vault",
+		filepath.Join(dir, "data", "mine"),
+		filepath.Join(dir, "config", "mine"),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("writablePaths() = %v, want %v", got, want)
+	}
+
+	if got := writablePaths(Permissions{}); len(got) != 0 {
+		t.Errorf("writablePaths(none) = %v, want none", got)
+	}
+}
+
+func TestBwrapArgs(t *testing.T) {
+	args := bwrapArgs("/plugins/foo/mine-plugin-foo", []string{"/home/u/notes"})
+	joined := strings.Join(args, " ")
+
+	for _, want := range []string{
+		"--ro-bind / /",
+		"--tmpfs /tmp",
+		"--bind-try /home/u/notes /home/u/notes",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("bwrapArgs() = %q, missing %q", joined, want)
+		}
+	}
+	if !strings.HasSuffix(joined, "-- /plugins/foo/mine-plugin-foo") {
+		t.Errorf("bwrapArgs() = %q, want it to end with the entrypoint", joined)
+	}
+	// Writable binds must come after the read-only root to override it.
+	if strings.Index(joined, "--bind-try") < strings.Index(joined, "--ro-bind") {
+		t.Errorf("bwrapArgs() = %q, writable paths bound before the root", joined)
+	}
+}
+
+func TestSeatbeltProfile(t *testing.T) {
+	profile := seatbeltProfile([]string{"/Users/u/notes"})
+
+	for _, want := range []string{
+		"(deny file-write*)",
+		`(subpath "/Users/u/notes")`,
+		`(subpath "/private/tmp")`,
+	} {
+		if !strings.Contains(profile, want) {
+			t.Errorf("seatbeltProfile() missing %q:\n%s", want, profile)
+		}
+	}
+	if strings.Count(profile, "(") != strings.Count(profile, ")") {
+		t.Errorf("seatbeltProfile() has unbalanced parens:\n%s", profile)
+	}
+}
+
+func TestSandboxCommandRestrictsWrites(t *testing.T) {
+	if Sandbox() == "" {
+		t.Skip("no plugin sandbox available")
+	}
+
+	dir := t.TempDir()
+	allowed := filepath.Join(dir, "allowed")
+	denied := filepath.Join(dir, "denied")
+	for _, d := range []string{allowed, denied} {
+		if err := os.Mkdir(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bin := filepath.Join(dir, "mine-plugin-writer")
+	script := "#!/bin/sh\necho ok > \"$1/out\"\n"
+	if err := os.WriteFile(bin, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	perms := Permissions{Filesystem: []string{allowed}}
+	for _, target := range []string{allowed, denied} {
+		cmd := sandboxCommand(context.Background(), bin, perms)
+		cmd.Args = append(cmd.Args, target)
+		cmd.Run()
+	}
+
+	if _, err := os.Stat(filepath.Join(allowed, "out")); err != nil {
+		t.Errorf("write to declared path blocked: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(denied, "out")); err == nil {
+		t.Error("write to undeclared path was allowed")
+	}
+}
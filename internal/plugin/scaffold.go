@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*
+var templates embed.FS
+
+// scaffoldFile is one file of a plugin skeleton.
+type scaffoldFile struct {
+	template string // under templates/<lang>/
+	path     string // output path, itself a template
+	mode     os.FileMode
+}
+
+// scaffolds lists the files each language's skeleton is made of.
+var scaffolds = map[string][]scaffoldFile{
+	"go": {
+		{"mine-plugin.toml.tmpl", "mine-plugin.toml", 0o644},
+		{"main.go.tmpl", "main.go", 0o644},
+		{"main_test.go.tmpl", "main_test.go", 0o644},
+		{"go.mod.tmpl", "go.mod", 0o644},
+		{"gitignore.tmpl", ".gitignore", 0o644},
+		{"README.md.tmpl", "README.md", 0o644},
+	},
+	"shell": {
+		{"mine-plugin.toml.tmpl", "mine-plugin.toml", 0o644},
+		{"entrypoint.sh.tmpl", "{{.Entrypoint}}", 0o755},
+		{"test.sh.tmpl", "test.sh", 0o755},
+		{"README.md.tmpl", "README.md", 0o644},
+	},
+}
+
+// ScaffoldLanguages returns the languages Scaffold can generate.
+func ScaffoldLanguages() []string {
+	return []string{"go", "shell"}
+}
+
+// scaffoldData is passed to every skeleton template.
+type scaffoldData struct {
+	Name            string
+	Author          string
+	Entrypoint      string
+	ProtocolVersion string
+}
+
+// Scaffold writes a new plugin skeleton named name into dir: a manifest,
+// an entrypoint in lang that speaks the invocation protocol with an example
+// hook and command, and tests. dir must not exist or be empty. Returns the
+// paths created, relative to dir.
+func Scaffold(dir, name, lang, author string) ([]string, error) {
+	if !validPluginName.MatchString(name) {
+		return nil, fmt.Errorf("plugin name %q must be kebab-case (lowercase letters, digits, and hyphens)", name)
+	}
+	files, ok := scaffolds[lang]
+	if !ok {
+		return nil, fmt.Errorf("unknown language %q (want %s)", lang, strings.Join(ScaffoldLanguages(), " or "))
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("%s already exists and isn't empty", dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	data := scaffoldData{
+		Name:            name,
+		Author:          author,
+		Entrypoint:      "mine-plugin-" + name,
+		ProtocolVersion: ProtocolVersion,
+	}
+	var created []string
+	for _, f := range files {
+		path, err := renderScaffold(f.path, f.path, data)
+		if err != nil {
+			return created, err
+		}
+		src, err := templates.ReadFile("templates/" + lang + "/" + f.template)
+		if err != nil {
+			return created, err
+		}
+		content, err := renderScaffold(f.template, string(src), data)
+		if err != nil {
+			return created, fmt.Errorf("rendering %s: %w", path, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), f.mode); err != nil {
+			return created, fmt.Errorf("writing %s: %w", path, err)
+		}
+		created = append(created, path)
+	}
+	return created, nil
+}
+
+func renderScaffold(name, content string, data scaffoldData) (string, error) {
+	tmpl, err := template.New(name).Parse(content)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
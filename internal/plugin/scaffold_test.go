@@ -0,0 +1,89 @@
+package plugin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestScaffold_Go(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	dir := filepath.Join(t.TempDir(), "mine-plugin-greeter")
+	created, err := Scaffold(dir, "greeter", "go", `Ada "the dev"`)
+	if err != nil {
+		t.Fatalf("Scaffold() error: %v", err)
+	}
+	if len(created) != len(scaffolds["go"]) {
+		t.Errorf("Scaffold() created %v", created)
+	}
+
+	m, err := ParseManifest(filepath.Join(dir, "mine-plugin.toml"))
+	if err != nil {
+		t.Fatalf("generated manifest is invalid: %v", err)
+	}
+	if m.Plugin.Name != "greeter" || m.Plugin.Author != `Ada "the dev"` || m.Plugin.ProtocolVersion != ProtocolVersion {
+		t.Errorf("manifest = %+v", m.Plugin)
+	}
+
+	// The generated tests pass, and the built plugin installs and runs.
+	for _, args := range [][]string{{"test", "./..."}, {"build", "-o", m.Entrypoint(), "."}} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go %v: %v\n%s", args, err, out)
+		}
+	}
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("XDG_DATA_HOME", home)
+	p, err := Install(dir, dir)
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if err := RunCommand(p, "hello", nil); err != nil {
+		t.Errorf("RunCommand(hello) error: %v", err)
+	}
+	if err := SendLifecycleEvent(p, "health"); err != nil {
+		t.Errorf("SendLifecycleEvent(health) error: %v", err)
+	}
+}
+
+func TestScaffold_Shell(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := filepath.Join(t.TempDir(), "mine-plugin-greeter")
+	if _, err := Scaffold(dir, "greeter", "shell", "tester"); err != nil {
+		t.Fatalf("Scaffold() error: %v", err)
+	}
+	if _, err := ParseManifest(filepath.Join(dir, "mine-plugin.toml")); err != nil {
+		t.Fatalf("generated manifest is invalid: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "mine-plugin-greeter"))
+	if err != nil || info.Mode()&0o111 == 0 {
+		t.Fatalf("entrypoint should be executable: %v", err)
+	}
+
+	cmd := exec.Command("./test.sh")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated test.sh failed: %v\n%s", err, out)
+	}
+}
+
+func TestScaffold_Rejects(t *testing.T) {
+	base := t.TempDir()
+	if _, err := Scaffold(filepath.Join(base, "a"), "Bad Name", "go", "me"); err == nil {
+		t.Error("Scaffold() should reject a non-kebab-case name")
+	}
+	if _, err := Scaffold(filepath.Join(base, "b"), "ok", "cobol", "me"); err == nil {
+		t.Error("Scaffold() should reject an unknown language")
+	}
+	os.WriteFile(filepath.Join(base, "keep"), []byte("x"), 0o644)
+	if _, err := Scaffold(base, "ok", "go", "me"); err == nil {
+		t.Error("Scaffold() should refuse a non-empty directory")
+	}
+}
@@ -0,0 +1,164 @@
+package plugin
+
+import (
+	"strconv"
+	"strings"
+)
+
+// version is a parsed semantic version such as v1.2.3 or 1.2.0-rc.1.
+// Missing minor or patch numbers parse as zero; parts records how many
+// were given so constraints like ~1.2 can treat them as wildcards.
+type version struct {
+	major, minor, patch int
+	pre                 string
+	parts               int
+}
+
+// parseVersion reads a semantic version, with or without a leading "v".
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	core, pre, _ := strings.Cut(s, "-")
+	core, _, _ = strings.Cut(core, "+")
+	fields := strings.Split(core, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return version{}, false
+	}
+	nums := make([]int, 3)
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		nums[i] = n
+	}
+	return version{major: nums[0], minor: nums[1], patch: nums[2], pre: pre, parts: len(fields)}, true
+}
+
+// compare returns -1, 0, or 1 as v sorts before, with, or after o. A
+// prerelease sorts before its release.
+func (v version) compare(o version) int {
+	for _, d := range [][2]int{{v.major, o.major}, {v.minor, o.minor}, {v.patch, o.patch}} {
+		if d[0] != d[1] {
+			if d[0] < d[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.pre == o.pre:
+		return 0
+	case v.pre == "":
+		return 1
+	case o.pre == "":
+		return -1
+	case v.pre < o.pre:
+		return -1
+	}
+	return 1
+}
+
+// constraint is a set of version bounds that must all hold.
+type constraint []bound
+
+type bound struct {
+	op string // one of =, >, >=, <, <=
+	v  version
+}
+
+// parseConstraint reads a semver constraint: one or more terms separated by
+// commas or spaces, each an operator (^, ~, >=, >, <=, <, =) and a version.
+// A bare full version matches only itself; a bare partial one like 1.2
+// matches any 1.2.x.
+func parseConstraint(s string) (constraint, bool) {
+	terms := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(terms) == 0 {
+		return nil, false
+	}
+	var c constraint
+	for _, term := range terms {
+		rest := strings.TrimLeft(term, "^~<>=")
+		op := term[:len(term)-len(rest)]
+		v, ok := parseVersion(rest)
+		if !ok {
+			return nil, false
+		}
+		if op == "" && v.parts < 3 {
+			op = "~"
+		}
+		switch op {
+		case "", "=":
+			c = append(c, bound{"=", v})
+		case ">", ">=", "<", "<=":
+			c = append(c, bound{op, v})
+		case "^":
+			upper := version{major: v.major + 1}
+			if v.major == 0 && v.parts > 1 {
+				upper = version{minor: v.minor + 1}
+			}
+			c = append(c, bound{">=", v}, bound{"<", upper})
+		case "~":
+			upper := version{major: v.major, minor: v.minor + 1}
+			if v.parts == 1 {
+				upper = version{major: v.major + 1}
+			}
+			c = append(c, bound{">=", v}, bound{"<", upper})
+		default:
+			return nil, false
+		}
+	}
+	return c, true
+}
+
+// exact reports whether c pins a single version rather than a range.
+func (c constraint) exact() bool {
+	return len(c) == 1 && c[0].op == "="
+}
+
+// allows reports whether v satisfies every bound in c. Prereleases only
+// match a constraint that names them exactly.
+func (c constraint) allows(v version) bool {
+	if v.pre != "" && !c.exact() {
+		return false
+	}
+	for _, b := range c {
+		cmp := v.compare(b.v)
+		var ok bool
+		switch b.op {
+		case "=":
+			ok = cmp == 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// newestTag returns the highest of tags that c allows, or "" if none do.
+// A nil constraint allows any release.
+func newestTag(tags []string, c constraint) string {
+	var best string
+	var bestV version
+	for _, tag := range tags {
+		v, ok := parseVersion(tag)
+		if !ok {
+			continue
+		}
+		if c == nil && v.pre != "" || c != nil && !c.allows(v) {
+			continue
+		}
+		if best == "" || v.compare(bestV) > 0 {
+			best, bestV = tag, v
+		}
+	}
+	return best
+}
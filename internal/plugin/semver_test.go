@@ -0,0 +1,49 @@
+package plugin
+
+import "testing"
+
+func TestNewestTag(t *testing.T) {
+	tags := []string{"v0.9.0", "v1.0.0", "v1.2.0", "v1.2.5", "v1.3.0", "v2.0.0-rc.1", "v2.0.0", "latest"}
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"", "v2.0.0"},
+		{"^1.2", "v1.3.0"},
+		{"^1.2.3", "v1.3.0"},
+		{"~1.2", "v1.2.5"},
+		{"~1.2.0", "v1.2.5"},
+		{"1.2", "v1.2.5"},
+		{"v1.2.0", "v1.2.0"},
+		{"1.2.0", "v1.2.0"},
+		{">=1.0.0,<1.3.0", "v1.2.5"},
+		{"^0.9", "v0.9.0"},
+		{"v2.0.0-rc.1", "v2.0.0-rc.1"},
+		{"^3", ""},
+	}
+	for _, tt := range tests {
+		var c constraint
+		if tt.constraint != "" {
+			var ok bool
+			if c, ok = parseConstraint(tt.constraint); !ok {
+				t.Fatalf("parseConstraint(%q) failed", tt.constraint)
+			}
+		}
+		if got := newestTag(tags, c); got != tt.want {
+			t.Errorf("newestTag(%q) = %q, want %q", tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestParseConstraint_NotAVersion(t *testing.T) {
+	for _, s := range []string{"", "main", "a1b2c3d", "^x", "1.2.3.4"} {
+		if _, ok := parseConstraint(s); ok {
+			t.Errorf("parseConstraint(%q) should fail", s)
+		}
+	}
+	for _, s := range []string{"v1.2.0", "=1.2.0"} {
+		if c, ok := parseConstraint(s); !ok || !c.exact() {
+			t.Errorf("parseConstraint(%q) should be an exact pin", s)
+		}
+	}
+}
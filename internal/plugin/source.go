@@ -0,0 +1,210 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/gitutil"
+)
+
+// Checkout is a plugin repository cloned at a resolved ref, ready to
+// install. Call Cleanup once it's no longer needed.
+type Checkout struct {
+	Dir        string // temporary clone
+	Source     string // repository, e.g. "github.com/user/mine-plugin-foo"
+	Constraint string // what followed "@" in the spec, e.g. "v1.2.0" or "^1.2"
+	Ref        string // tag or ref the clone is at; empty for the default branch
+	Commit     string // commit the clone is at
+	Insecure   bool   // install even if the signature doesn't verify
+}
+
+// IsRemote reports whether spec names a git repository, such as
+// github.com/user/mine-plugin-foo@v1.2.0, rather than a local directory.
+func IsRemote(spec string) bool {
+	if _, err := os.Stat(spec); err == nil {
+		return false
+	}
+	for _, prefix := range []string{"https://", "http://", "ssh://", "git@", "file://"} {
+		if strings.HasPrefix(spec, prefix) {
+			return true
+		}
+	}
+	host, _, ok := strings.Cut(spec, "/")
+	return ok && strings.Contains(host, ".") && !strings.HasPrefix(host, ".")
+}
+
+// splitSpec separates a remote spec into its repository and the ref or
+// constraint after a trailing "@", e.g. "github.com/u/r@^1.2" → "github.com/u/r", "^1.2".
+func splitSpec(spec string) (repo, ref string) {
+	at := strings.LastIndex(spec, "@")
+	if at <= strings.LastIndexAny(spec, "/:") {
+		return spec, ""
+	}
+	return spec[:at], spec[at+1:]
+}
+
+// cloneURL returns the URL git clones repo from: as given when it carries
+// a scheme, otherwise over HTTPS.
+func cloneURL(repo string) string {
+	for _, prefix := range []string{"https://", "http://", "ssh://", "git@", "file://"} {
+		if strings.HasPrefix(repo, prefix) {
+			return repo
+		}
+	}
+	return "https://" + repo
+}
+
+// remoteTags lists the tag names in repo.
+func remoteTags(repo string) ([]string, error) {
+	out, err := gitutil.RunCmd("", "ls-remote", "--tags", "--refs", cloneURL(repo))
+	if err != nil {
+		return nil, fmt.Errorf("listing tags in %s: %w", repo, err)
+	}
+	var tags []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if _, ref, ok := strings.Cut(line, "\t"); ok {
+			tags = append(tags, strings.TrimPrefix(ref, "refs/tags/"))
+		}
+	}
+	return tags, nil
+}
+
+// resolveRef picks the ref to check out for want, the part of a spec after
+// "@". Empty or a semver range picks the newest matching release tag; an
+// exact version picks its tag; anything else is used as-is, e.g. a commit
+// or branch. Returns "" for the default branch when there are no releases.
+func resolveRef(repo, want string) (string, error) {
+	c, isVersion := parseConstraint(want)
+	if want != "" && !isVersion {
+		return want, nil
+	}
+	tags, err := remoteTags(repo)
+	if err != nil {
+		return "", err
+	}
+	ref := newestTag(tags, c)
+	if ref == "" && want != "" {
+		return "", fmt.Errorf("no release of %s matches %s", repo, want)
+	}
+	return ref, nil
+}
+
+// Fetch clones the plugin named by a remote spec, like
+// github.com/user/mine-plugin-foo@v1.2.0, at the ref it resolves to.
+func Fetch(spec string) (*Checkout, error) {
+	repo, want := splitSpec(spec)
+	ref, err := resolveRef(repo, want)
+	if err != nil {
+		return nil, err
+	}
+	return fetchRef(repo, want, ref)
+}
+
+// fetchRef clones repo into a temporary directory and checks out ref.
+func fetchRef(repo, constraint, ref string) (*Checkout, error) {
+	dir, err := os.MkdirTemp("", "mine-plugin-*")
+	if err != nil {
+		return nil, err
+	}
+	co := &Checkout{Dir: dir, Source: repo, Constraint: constraint, Ref: ref}
+	if _, err := gitutil.RunCmd("", "clone", "--quiet", cloneURL(repo), dir); err != nil {
+		co.Cleanup()
+		return nil, fmt.Errorf("cloning %s: %w", repo, err)
+	}
+	if ref != "" {
+		if _, err := gitutil.RunCmd(dir, "-c", "advice.detachedHead=false", "checkout", "--quiet", ref); err != nil {
+			co.Cleanup()
+			return nil, fmt.Errorf("checking out %s@%s: %w", repo, ref, err)
+		}
+	}
+	out, err := gitutil.RunCmd(dir, "rev-parse", "HEAD")
+	if err != nil {
+		co.Cleanup()
+		return nil, fmt.Errorf("reading commit of %s: %w", repo, err)
+	}
+	co.Commit = strings.TrimSpace(out)
+	return co, nil
+}
+
+// Install installs the checked-out plugin, recording the pinned ref and
+// commit in the registry so Upgrade can follow its constraint later.
+func (c *Checkout) Install() (*InstalledPlugin, error) {
+	return install(c.Dir, PluginEntry{
+		Source:     c.Source,
+		Constraint: c.Constraint,
+		Ref:        c.Ref,
+		Commit:     c.Commit,
+		Insecure:   c.Insecure,
+	})
+}
+
+// Cleanup removes the temporary clone.
+func (c *Checkout) Cleanup() {
+	os.RemoveAll(c.Dir)
+}
+
+// FetchUpgrade checks for a newer release of the installed plugin name
+// within the constraint it was installed with, and fetches it. Returns a
+// nil Checkout when the plugin is already current, or pinned to an exact
+// version, commit, or branch.
+func FetchUpgrade(name string) (*Checkout, error) {
+	entry, err := registryEntry(name)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Commit == "" {
+		return nil, fmt.Errorf("plugin %q was installed from a local directory — reinstall it to upgrade", name)
+	}
+	if c, ok := parseConstraint(entry.Constraint); entry.Constraint != "" && (!ok || c.exact()) {
+		return nil, nil
+	}
+
+	ref, err := resolveRef(entry.Source, entry.Constraint)
+	if err != nil {
+		return nil, err
+	}
+	if ref != "" && ref == entry.Ref {
+		return nil, nil
+	}
+	if ref == "" {
+		out, err := gitutil.RunCmd("", "ls-remote", cloneURL(entry.Source), "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("checking %s: %w", entry.Source, err)
+		}
+		if head, _, _ := strings.Cut(out, "\t"); head == entry.Commit {
+			return nil, nil
+		}
+	}
+	return fetchRef(entry.Source, entry.Constraint, ref)
+}
+
+// Upgradable returns the names of installed plugins that came from a git
+// repository, and so can be upgraded.
+func Upgradable() ([]string, error) {
+	reg, err := LoadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range reg.Plugins {
+		if p.Commit != "" {
+			names = append(names, p.Name)
+		}
+	}
+	return names, nil
+}
+
+// registryEntry returns the registry entry for the installed plugin name.
+func registryEntry(name string) (*PluginEntry, error) {
+	reg, err := LoadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range reg.Plugins {
+		if p.Name == name {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %q not found", name)
+}
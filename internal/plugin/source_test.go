@@ -0,0 +1,201 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rnwolfe/mine/internal/gitutil"
+)
+
+// makePluginRepo creates a git repo holding a plugin with a release tag for
+// each version, and returns its file:// URL.
+func makePluginRepo(t *testing.T, versions ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		if _, err := gitutil.RunCmd(dir, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	git("init", "--quiet")
+	for _, v := range versions {
+		writePluginManifest(t, dir, v)
+		git("add", "-A")
+		git("commit", "--quiet", "-m", "release "+v)
+		git("tag", "v"+v)
+	}
+	return "file://" + dir
+}
+
+func writePluginManifest(t *testing.T, dir, v string) {
+	t.Helper()
+	manifest := fmt.Sprintf(`[plugin]
+name = "remote-plugin"
+version = %q
+description = "A plugin installed from git"
+author = "tester"
+protocol_version = "1.0.0"
+`, v)
+	if err := os.WriteFile(filepath.Join(dir, "mine-plugin.toml"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsRemote(t *testing.T) {
+	local := t.TempDir()
+	tests := []struct {
+		spec string
+		want bool
+	}{
+		{"github.com/user/mine-plugin-foo", true},
+		{"github.com/user/mine-plugin-foo@v1.2.0", true},
+		{"https://gitlab.com/user/mine-plugin-foo", true},
+		{"git@github.com:user/mine-plugin-foo.git", true},
+		{"./my-plugin", false},
+		{"../plugins/foo", false},
+		{local, false},
+	}
+	for _, tt := range tests {
+		if got := IsRemote(tt.spec); got != tt.want {
+			t.Errorf("IsRemote(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestSplitSpec(t *testing.T) {
+	tests := []struct{ spec, repo, ref string }{
+		{"github.com/u/r@v1.2.0", "github.com/u/r", "v1.2.0"},
+		{"github.com/u/r@^1.2", "github.com/u/r", "^1.2"},
+		{"github.com/u/r", "github.com/u/r", ""},
+		{"git@github.com:u/r.git", "git@github.com:u/r.git", ""},
+		{"git@github.com:u/r.git@abc123", "git@github.com:u/r.git", "abc123"},
+	}
+	for _, tt := range tests {
+		repo, ref := splitSpec(tt.spec)
+		if repo != tt.repo || ref != tt.ref {
+			t.Errorf("splitSpec(%q) = %q, %q; want %q, %q", tt.spec, repo, ref, tt.repo, tt.ref)
+		}
+	}
+}
+
+func TestFetchAndInstall_Pinned(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+	repo := makePluginRepo(t, "1.0.0", "1.1.0", "2.0.0")
+
+	co, err := Fetch(repo + "@v1.0.0")
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	defer co.Cleanup()
+	if co.Ref != "v1.0.0" || co.Source != repo || len(co.Commit) != 40 {
+		t.Errorf("Checkout = %+v, want %s at v1.0.0", co, repo)
+	}
+	p, err := co.Install()
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if p.Manifest.Plugin.Version != "1.0.0" {
+		t.Errorf("installed v%s, want v1.0.0", p.Manifest.Plugin.Version)
+	}
+
+	entry, err := registryEntry("remote-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Source != repo || entry.Constraint != "v1.0.0" || entry.Ref != "v1.0.0" || entry.Commit != co.Commit {
+		t.Errorf("registry entry = %+v, want pinned to v1.0.0", entry)
+	}
+
+	// An exact pin is never upgraded.
+	if up, err := FetchUpgrade("remote-plugin"); err != nil || up != nil {
+		t.Errorf("FetchUpgrade() = %+v, %v; want nothing for an exact pin", up, err)
+	}
+}
+
+func TestFetchUpgrade_RespectsConstraint(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+	repo := makePluginRepo(t, "1.0.0")
+
+	co, err := Fetch(repo + "@^1.0")
+	if err != nil {
+		t.Fatalf("Fetch() error: %v", err)
+	}
+	defer co.Cleanup()
+	if _, err := co.Install(); err != nil {
+		t.Fatal(err)
+	}
+	if up, err := FetchUpgrade("remote-plugin"); err != nil || up != nil {
+		t.Fatalf("FetchUpgrade() = %+v, %v; want up to date", up, err)
+	}
+
+	// Publish 1.4.0 and 2.0.0; ^1.0 should stop at 1.4.0.
+	src := strings.TrimPrefix(repo, "file://")
+	for _, v := range []string{"1.4.0", "2.0.0"} {
+		writePluginManifest(t, src, v)
+		for _, args := range [][]string{
+			{"add", "-A"},
+			{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", v},
+			{"tag", "v" + v},
+		} {
+			if _, err := gitutil.RunCmd(src, args...); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	up, err := FetchUpgrade("remote-plugin")
+	if err != nil {
+		t.Fatalf("FetchUpgrade() error: %v", err)
+	}
+	if up == nil {
+		t.Fatal("FetchUpgrade() found nothing, want v1.4.0")
+	}
+	defer up.Cleanup()
+	if up.Ref != "v1.4.0" {
+		t.Errorf("FetchUpgrade() ref = %q, want v1.4.0", up.Ref)
+	}
+	p, err := up.Install()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Manifest.Plugin.Version != "1.4.0" {
+		t.Errorf("upgraded to v%s, want v1.4.0", p.Manifest.Plugin.Version)
+	}
+	if names, _ := Upgradable(); len(names) != 1 || names[0] != "remote-plugin" {
+		t.Errorf("Upgradable() = %v, want [remote-plugin]", names)
+	}
+}
+
+func TestFetch_NoMatchingRelease(t *testing.T) {
+	repo := makePluginRepo(t, "1.0.0")
+	if _, err := Fetch(repo + "@^2"); err == nil || !strings.Contains(err.Error(), "no release") {
+		t.Errorf("Fetch() error = %v, want no matching release", err)
+	}
+}
+
+func TestFetchUpgrade_LocalInstall(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+	src := t.TempDir()
+	writePluginManifest(t, src, "1.0.0")
+	if _, err := Install(src, src); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FetchUpgrade("remote-plugin"); err == nil {
+		t.Error("FetchUpgrade() of a local install should error")
+	}
+}
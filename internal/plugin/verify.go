@@ -0,0 +1,277 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshSigNamespace is the namespace SSH signatures must be made in, as with
+// `ssh-keygen -Y sign -n file`.
+const sshSigNamespace = "file"
+
+// verifySource checks the plugin in dir before it's installed. When the
+// manifest has a [signature] section, the checksums file must carry a valid
+// signature by its public key and list the entrypoint's SHA-256. Returns the
+// entrypoint's SHA-256, or "" if the plugin has no entrypoint binary.
+func verifySource(dir string, m *Manifest) (string, error) {
+	bin := filepath.Join(dir, m.Entrypoint())
+	sum, err := fileSHA256(bin)
+	if os.IsNotExist(err) {
+		if m.Signature.Signed() {
+			return "", fmt.Errorf("signed plugin has no entrypoint %s to verify", m.Entrypoint())
+		}
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !m.Signature.Signed() {
+		return sum, nil
+	}
+
+	sig := m.Signature
+	checksums, err := os.ReadFile(filepath.Join(dir, sig.Checksums))
+	if err != nil {
+		return "", fmt.Errorf("reading checksums: %w", err)
+	}
+	signature, err := os.ReadFile(filepath.Join(dir, sig.File))
+	if err != nil {
+		return "", fmt.Errorf("reading signature: %w", err)
+	}
+	if err := verifySignature(sig.PublicKey, checksums, signature); err != nil {
+		return "", fmt.Errorf("%s: %w", sig.Checksums, err)
+	}
+	want, ok := parseChecksums(checksums)[m.Entrypoint()]
+	if !ok {
+		return "", fmt.Errorf("%s doesn't list %s", sig.Checksums, m.Entrypoint())
+	}
+	if want != sum {
+		return "", fmt.Errorf("%s doesn't match its signed checksum", m.Entrypoint())
+	}
+	return sum, nil
+}
+
+// verifyEntrypoint checks that the binary at path still has the SHA-256
+// recorded at install.
+func verifyEntrypoint(path, want string) error {
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if sum != want {
+		return fmt.Errorf("%s changed since it was installed — reinstall the plugin, or reinstall with --insecure to run it unverified", filepath.Base(path))
+	}
+	return nil
+}
+
+// fileSHA256 returns the hex SHA-256 of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// parseChecksums reads a sha256sum-style file ("<hex>  <name>" per line,
+// with an optional "*" before binary names) into name → hash.
+func parseChecksums(data []byte) map[string]string {
+	sums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		sum, name, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if _, err := hex.DecodeString(sum); !ok || err != nil {
+			continue
+		}
+		name = path.Clean(strings.TrimPrefix(strings.TrimSpace(name), "*"))
+		sums[name] = strings.ToLower(sum)
+	}
+	return sums
+}
+
+// verifySignature checks sig over data with publicKey: an SSH signature
+// when the key is in authorized_keys form ("ssh-ed25519 AAAA..."), a
+// minisign signature otherwise.
+func verifySignature(publicKey string, data, sig []byte) error {
+	if pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey)); err == nil {
+		return verifySSHSignature(pub, data, sig)
+	}
+	return verifyMinisign(publicKey, data, sig)
+}
+
+// KeyFingerprint returns a short fingerprint for a manifest public key,
+// e.g. SHA256:… for SSH keys or the key ID for minisign keys.
+func KeyFingerprint(publicKey string) string {
+	if pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey)); err == nil {
+		return ssh.FingerprintSHA256(pub)
+	}
+	if raw, err := minisignKey(publicKey); err == nil {
+		return fmt.Sprintf("minisign %X", reverse(raw[2:10]))
+	}
+	return "invalid key"
+}
+
+// verifySSHSignature checks an armored SSHSIG signature, as made by
+// `ssh-keygen -Y sign -n file`, over data.
+func verifySSHSignature(pub ssh.PublicKey, data, armored []byte) error {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != "SSH SIGNATURE" {
+		return fmt.Errorf("not an SSH signature")
+	}
+	blob := block.Bytes
+	if !bytes.HasPrefix(blob, []byte("SSHSIG")) {
+		return fmt.Errorf("not an SSH signature")
+	}
+	blob = blob[6:]
+	if len(blob) < 4 || binary.BigEndian.Uint32(blob) != 1 {
+		return fmt.Errorf("unsupported SSH signature version")
+	}
+	blob = blob[4:]
+	var fields [5][]byte // public key, namespace, reserved, hash algorithm, signature
+	for i := range fields {
+		var ok bool
+		if fields[i], blob, ok = sshString(blob); !ok {
+			return fmt.Errorf("malformed SSH signature")
+		}
+	}
+	key, namespace, reserved, hashAlg, sigBlob := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	if !bytes.Equal(key, pub.Marshal()) {
+		return fmt.Errorf("signed by a different key")
+	}
+	if string(namespace) != sshSigNamespace {
+		return fmt.Errorf("signature namespace is %q, want %q", namespace, sshSigNamespace)
+	}
+	var h hash.Hash
+	switch string(hashAlg) {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported signature hash %q", hashAlg)
+	}
+	h.Write(data)
+
+	var signed bytes.Buffer
+	signed.WriteString("SSHSIG")
+	for _, field := range [][]byte{namespace, reserved, hashAlg, h.Sum(nil)} {
+		signed.Write(ssh.Marshal(struct{ S []byte }{field}))
+	}
+	var sig ssh.Signature
+	if err := ssh.Unmarshal(sigBlob, &sig); err != nil {
+		return fmt.Errorf("malformed SSH signature: %w", err)
+	}
+	if err := pub.Verify(signed.Bytes(), &sig); err != nil {
+		return fmt.Errorf("bad signature")
+	}
+	return nil
+}
+
+// sshString reads one length-prefixed string from b.
+func sshString(b []byte) (s, rest []byte, ok bool) {
+	if len(b) < 4 {
+		return nil, nil, false
+	}
+	n := binary.BigEndian.Uint32(b)
+	if uint64(len(b)-4) < uint64(n) {
+		return nil, nil, false
+	}
+	return b[4 : 4+n], b[4+n:], true
+}
+
+// minisignKey decodes a minisign public key, given bare or as the contents
+// of a .pub file: "Ed", an 8-byte key ID, and the 32-byte Ed25519 key.
+func minisignKey(publicKey string) ([]byte, error) {
+	line := lastLine(publicKey)
+	raw, err := base64.StdEncoding.DecodeString(line)
+	if err != nil || len(raw) != 42 || string(raw[:2]) != "Ed" {
+		return nil, fmt.Errorf("not a minisign or SSH public key")
+	}
+	return raw, nil
+}
+
+// verifyMinisign checks a minisign signature file over data, including its
+// trusted comment.
+func verifyMinisign(publicKey string, data, sigFile []byte) error {
+	key, err := minisignKey(publicKey)
+	if err != nil {
+		return err
+	}
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSpace(string(sigFile)), "\n") {
+		lines = append(lines, strings.TrimRight(l, "\r"))
+	}
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return fmt.Errorf("not a minisign signature")
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 74 {
+		return fmt.Errorf("not a minisign signature")
+	}
+	if !bytes.Equal(sig[2:10], key[2:10]) {
+		return fmt.Errorf("signed by a different key")
+	}
+	pub := ed25519.PublicKey(key[10:])
+
+	msg := data
+	switch string(sig[:2]) {
+	case "ED":
+		sum := blake2b.Sum512(data)
+		msg = sum[:]
+	case "Ed":
+	default:
+		return fmt.Errorf("unsupported minisign algorithm")
+	}
+	if !ed25519.Verify(pub, msg, sig[10:]) {
+		return fmt.Errorf("bad signature")
+	}
+
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil {
+		return fmt.Errorf("not a minisign signature")
+	}
+	comment := strings.TrimPrefix(lines[2], "trusted comment: ")
+	if !ed25519.Verify(pub, append(append([]byte{}, sig[10:]...), comment...), global) {
+		return fmt.Errorf("bad trusted comment signature")
+	}
+	return nil
+}
+
+// lastLine returns the last line of s, which for a minisign .pub file is
+// the key after its "untrusted comment:" header.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// reverse returns b reversed; minisign prints key IDs little-endian.
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out
+}
@@ -0,0 +1,246 @@
+package plugin
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ssh"
+)
+
+// minisignKeys returns a new minisign public key and a signer that writes
+// minisign signature files, prehashed as minisign does by default.
+func minisignKeys(t *testing.T) (string, func(data []byte) []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	pubKey := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...))
+	sign := func(data []byte) []byte {
+		sum := blake2b.Sum512(data)
+		sig := append(append([]byte("ED"), keyID...), ed25519.Sign(priv, sum[:])...)
+		comment := "timestamp:1700000000"
+		global := ed25519.Sign(priv, append(append([]byte{}, sig[10:]...), comment...))
+		return []byte(fmt.Sprintf("untrusted comment: signature\n%s\ntrusted comment: %s\n%s\n",
+			base64.StdEncoding.EncodeToString(sig), comment, base64.StdEncoding.EncodeToString(global)))
+	}
+	return "untrusted comment: minisign public key\n" + pubKey, sign
+}
+
+// sshKeys returns a new SSH public key and a signer that writes SSHSIG
+// signatures in the file namespace, as `ssh-keygen -Y sign -n file` does.
+func sshKeys(t *testing.T) (string, func(data []byte) []byte) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	str := func(b []byte) []byte { return ssh.Marshal(struct{ S []byte }{b}) }
+	sign := func(data []byte) []byte {
+		sum := sha512.Sum512(data)
+		signed := append([]byte("SSHSIG"), bytes.Join([][]byte{str([]byte("file")), str(nil), str([]byte("sha512")), str(sum[:])}, nil)...)
+		sig, err := signer.Sign(rand.Reader, signed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blob := append([]byte("SSHSIG"), binary.BigEndian.AppendUint32(nil, 1)...)
+		for _, f := range [][]byte{signer.PublicKey().Marshal(), []byte("file"), nil, []byte("sha512"), ssh.Marshal(sig)} {
+			blob = append(blob, str(f)...)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob})
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))), sign
+}
+
+func TestVerifySignature(t *testing.T) {
+	data := []byte("abc123  mine-plugin-demo\n")
+	for _, kind := range []string{"minisign", "ssh"} {
+		t.Run(kind, func(t *testing.T) {
+			keys := minisignKeys
+			if kind == "ssh" {
+				keys = sshKeys
+			}
+			pub, sign := keys(t)
+			otherPub, _ := keys(t)
+			sig := sign(data)
+
+			if err := verifySignature(pub, data, sig); err != nil {
+				t.Errorf("valid signature rejected: %v", err)
+			}
+			if err := verifySignature(pub, []byte("tampered"), sig); err == nil {
+				t.Error("signature over other data accepted")
+			}
+			if err := verifySignature(otherPub, data, sig); err == nil {
+				t.Error("signature by another key accepted")
+			}
+			if err := verifySignature(pub, data, []byte("garbage")); err == nil {
+				t.Error("garbage signature accepted")
+			}
+		})
+	}
+}
+
+// TestVerifySignature_SSHKeygen checks interop with signatures made by
+// OpenSSH itself.
+func TestVerifySignature_SSHKeygen(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	dir := t.TempDir()
+	key := filepath.Join(dir, "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v\n%s", err, out)
+	}
+	data := filepath.Join(dir, "SHA256SUMS")
+	os.WriteFile(data, []byte("abc123  mine-plugin-demo\n"), 0o644)
+	if out, err := exec.Command("ssh-keygen", "-Y", "sign", "-f", key, "-n", "file", data).CombinedOutput(); err != nil {
+		t.Skipf("ssh-keygen -Y sign unsupported: %v\n%s", err, out)
+	}
+
+	pub, _ := os.ReadFile(key + ".pub")
+	content, _ := os.ReadFile(data)
+	sig, _ := os.ReadFile(data + ".sig")
+	if err := verifySignature(string(pub), content, sig); err != nil {
+		t.Errorf("ssh-keygen signature rejected: %v", err)
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	sums := parseChecksums([]byte("ABC123  mine-plugin-demo\ndef456 *./README.md\n\nnot a line\n"))
+	if sums["mine-plugin-demo"] != "abc123" || sums["README.md"] != "def456" || len(sums) != 2 {
+		t.Errorf("parseChecksums() = %v", sums)
+	}
+}
+
+// writeSignedPlugin writes a plugin whose manifest signs its entrypoint
+// with pub, signing SHA256SUMS with sign.
+func writeSignedPlugin(t *testing.T, dir, pub string, sign func([]byte) []byte) {
+	t.Helper()
+	os.MkdirAll(dir, 0o755)
+	manifest := fmt.Sprintf(`[plugin]
+name = "signed-plugin"
+version = "1.0.0"
+description = "A signed plugin"
+author = "tester"
+protocol_version = "1.0.0"
+
+[[commands]]
+name = "hello"
+description = "Say hello"
+
+[signature]
+checksums = "SHA256SUMS"
+signature = "SHA256SUMS.sig"
+public_key = %q
+`, pub)
+	os.WriteFile(filepath.Join(dir, "mine-plugin.toml"), []byte(manifest), 0o644)
+	bin := filepath.Join(dir, "mine-plugin-signed-plugin")
+	os.WriteFile(bin, []byte("#!/bin/sh\necho hello\n"), 0o755)
+	sum, err := fileSHA256(bin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksums := []byte(sum + "  mine-plugin-signed-plugin\n")
+	os.WriteFile(filepath.Join(dir, "SHA256SUMS"), checksums, 0o644)
+	os.WriteFile(filepath.Join(dir, "SHA256SUMS.sig"), sign(checksums), 0o644)
+}
+
+func TestInstall_Signed(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+	src := filepath.Join(dir, "src")
+	pub, sign := sshKeys(t)
+	writeSignedPlugin(t, src, pub, sign)
+
+	p, err := Install(src, src)
+	if err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if p.Checksum == "" || p.Insecure {
+		t.Errorf("installed plugin = checksum %q, insecure %v; want verified", p.Checksum, p.Insecure)
+	}
+	if err := RunCommand(p, "hello", nil); err != nil {
+		t.Fatalf("RunCommand() error: %v", err)
+	}
+
+	// A tampered entrypoint refuses to run.
+	bin := filepath.Join(p.Dir, "mine-plugin-signed-plugin")
+	os.WriteFile(bin, []byte("#!/bin/sh\necho pwned\n"), 0o755)
+	got, _ := Get("signed-plugin")
+	if err := RunCommand(got, "hello", nil); err == nil || !strings.Contains(err.Error(), "changed since it was installed") {
+		t.Errorf("RunCommand() on a tampered entrypoint = %v, want refused", err)
+	}
+	if err := SendLifecycleEvent(got, "health"); err == nil {
+		t.Error("SendLifecycleEvent() on a tampered entrypoint should be refused")
+	}
+
+	// Reinstalling under a different key is refused, unless insecure.
+	otherPub, otherSign := sshKeys(t)
+	writeSignedPlugin(t, src, otherPub, otherSign)
+	if _, err := Install(src, src); err == nil || !strings.Contains(err.Error(), "signing key changed") {
+		t.Errorf("Install() with a new key = %v, want refused", err)
+	}
+	p, err = InstallInsecure(src, src)
+	if err != nil {
+		t.Fatalf("InstallInsecure() error: %v", err)
+	}
+	if !p.Insecure || p.Checksum != "" {
+		t.Errorf("insecure install = checksum %q, insecure %v", p.Checksum, p.Insecure)
+	}
+}
+
+func TestInstall_BadSignature(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+	src := filepath.Join(dir, "src")
+	pub, sign := minisignKeys(t)
+	writeSignedPlugin(t, src, pub, sign)
+
+	// The entrypoint no longer matches the signed checksums.
+	os.WriteFile(filepath.Join(src, "mine-plugin-signed-plugin"), []byte("#!/bin/sh\necho pwned\n"), 0o755)
+	if _, err := Install(src, src); err == nil || !strings.Contains(err.Error(), "signed checksum") {
+		t.Errorf("Install() = %v, want a checksum mismatch", err)
+	}
+	if _, err := Get("signed-plugin"); err == nil {
+		t.Error("plugin should not be registered after a failed verification")
+	}
+
+	p, err := InstallInsecure(src, src)
+	if err != nil {
+		t.Fatalf("InstallInsecure() error: %v", err)
+	}
+	if err := RunCommand(p, "hello", nil); err != nil {
+		t.Errorf("RunCommand() of an insecure install error: %v", err)
+	}
+}
+
+func TestManifestValidate_Signature(t *testing.T) {
+	m := Manifest{Plugin: PluginMeta{Name: "p", Version: "1", Description: "d", Author: "a", ProtocolVersion: "1.0.0"}}
+	m.Signature = Signature{Checksums: "SHA256SUMS"}
+	if err := m.Validate(); err == nil {
+		t.Error("Validate() should require every signature field")
+	}
+	m.Signature = Signature{Checksums: "../SHA256SUMS", File: "sig", PublicKey: "k"}
+	if err := m.Validate(); err == nil {
+		t.Error("Validate() should reject signature files outside the plugin")
+	}
+}
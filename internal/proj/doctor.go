@@ -0,0 +1,143 @@
+package proj
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Finding is one problem 'mine proj doctor' reports for a project.
+type Finding struct {
+	Check   string // short label, e.g. "branches"
+	Message string
+	Hint    string // how to fix it; may be empty
+}
+
+// DoctorOptions tunes the thresholds used by Diagnose.
+type DoctorOptions struct {
+	// StaleBranchDays flags local branches with no commits for this long.
+	StaleBranchDays int
+	// DirtyDays flags uncommitted changes whose oldest file is this old.
+	DirtyDays int
+	Now       time.Time
+}
+
+// staleBranchShown caps how many stale branch names a finding lists.
+const staleBranchShown = 5
+
+// Diagnose checks a project's directory and git repo. Projects that aren't
+// git repos only get the directory check.
+func Diagnose(p Project, opts DoctorOptions) []Finding {
+	if !dirExists(p.Path) {
+		return []Finding{{
+			Check:   "directory",
+			Message: p.Path + " no longer exists",
+			Hint:    fmt.Sprintf("mine proj mv %s <new-path>, or mine proj rm %s", p.Name, p.Name),
+		}}
+	}
+	if _, err := gitOutput(p.Path, "rev-parse", "--git-dir"); err != nil {
+		return nil
+	}
+
+	var findings []Finding
+	if out, err := gitOutput(p.Path, "remote"); err == nil && strings.TrimSpace(out) == "" {
+		findings = append(findings, Finding{
+			Check:   "remote",
+			Message: "no git remote — commits only exist on this machine",
+			Hint:    "git remote add origin <url>",
+		})
+	}
+	if f, ok := staleBranches(p.Path, opts); ok {
+		findings = append(findings, f)
+	}
+	if f, ok := oldUncommitted(p.Path, opts); ok {
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// staleBranches flags local branches, other than the checked-out one, whose
+// last commit is older than opts.StaleBranchDays.
+func staleBranches(dir string, opts DoctorOptions) (Finding, bool) {
+	out, err := gitOutput(dir, "for-each-ref", "--format=%(refname:short) %(committerdate:unix)", "refs/heads")
+	if err != nil {
+		return Finding{}, false
+	}
+	current, _ := gitOutput(dir, "branch", "--show-current")
+	current = strings.TrimSpace(current)
+	cutoff := opts.Now.AddDate(0, 0, -opts.StaleBranchDays)
+
+	var stale []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		name, ts, ok := strings.Cut(line, " ")
+		if !ok || name == current {
+			continue
+		}
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err == nil && time.Unix(sec, 0).Before(cutoff) {
+			stale = append(stale, name)
+		}
+	}
+	if len(stale) == 0 {
+		return Finding{}, false
+	}
+	sort.Strings(stale)
+	shown := strings.Join(stale[:min(len(stale), staleBranchShown)], ", ")
+	if len(stale) > staleBranchShown {
+		shown += fmt.Sprintf(", …%d more", len(stale)-staleBranchShown)
+	}
+	return Finding{
+		Check:   "branches",
+		Message: fmt.Sprintf("%d branch(es) idle over %d days: %s", len(stale), opts.StaleBranchDays, shown),
+		Hint:    "git branch -d <branch> once merged",
+	}, true
+}
+
+// oldUncommitted flags a dirty working tree whose oldest changed file was
+// last modified more than opts.DirtyDays ago.
+func oldUncommitted(dir string, opts DoctorOptions) (Finding, bool) {
+	out, err := gitOutput(dir, "status", "--porcelain")
+	if err != nil || strings.TrimSpace(out) == "" {
+		return Finding{}, false
+	}
+
+	var changed int
+	var oldest time.Time
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		changed++
+		path := line[3:]
+		if _, to, ok := strings.Cut(path, " -> "); ok {
+			path = to
+		}
+		info, err := os.Stat(filepath.Join(dir, strings.Trim(path, `"`)))
+		if err != nil {
+			continue // deleted files have no mtime
+		}
+		if oldest.IsZero() || info.ModTime().Before(oldest) {
+			oldest = info.ModTime()
+		}
+	}
+	if oldest.IsZero() || !oldest.Before(opts.Now.AddDate(0, 0, -opts.DirtyDays)) {
+		return Finding{}, false
+	}
+	days := int(opts.Now.Sub(oldest).Hours() / 24)
+	return Finding{
+		Check:   "uncommitted",
+		Message: fmt.Sprintf("%d uncommitted change(s), oldest untouched for %d days", changed, days),
+		Hint:    "commit, stash, or discard them",
+	}, true
+}
+
+// gitOutput runs git in dir and returns its stdout. Replaceable for testing.
+var gitOutput = func(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	return string(out), err
+}
@@ -0,0 +1,76 @@
+package proj
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+		"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com",
+		"GIT_AUTHOR_DATE=2026-01-01T00:00:00Z", "GIT_COMMITTER_DATE=2026-01-01T00:00:00Z",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestDiagnoseMissingDirectory(t *testing.T) {
+	p := Project{Name: "gone", Path: filepath.Join(t.TempDir(), "gone")}
+	findings := Diagnose(p, DoctorOptions{StaleBranchDays: 90, DirtyDays: 7, Now: time.Now()})
+	if len(findings) != 1 || findings[0].Check != "directory" {
+		t.Fatalf("expected a directory finding, got %+v", findings)
+	}
+}
+
+func TestDiagnoseGitRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git(t, dir, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "add", ".")
+	git(t, dir, "commit", "-q", "-m", "first")
+	git(t, dir, "branch", "old-feature")
+
+	// A change last touched 30 days ago.
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("b"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -30)
+	if err := os.Chtimes(filepath.Join(dir, "a.txt"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	findings := Diagnose(Project{Name: "r", Path: dir}, DoctorOptions{StaleBranchDays: 90, DirtyDays: 7, Now: now})
+	checks := map[string]string{}
+	for _, f := range findings {
+		checks[f.Check] = f.Message
+	}
+	if _, ok := checks["remote"]; !ok {
+		t.Error("expected a missing-remote finding")
+	}
+	if msg := checks["branches"]; !strings.Contains(msg, "old-feature") || strings.Contains(msg, "main") {
+		t.Errorf("stale branches = %q", msg)
+	}
+	if msg := checks["uncommitted"]; !strings.Contains(msg, "30 days") {
+		t.Errorf("uncommitted = %q", msg)
+	}
+
+	// Tighter thresholds than the repo's age: nothing stale or old.
+	findings = Diagnose(Project{Name: "r", Path: dir}, DoctorOptions{StaleBranchDays: 1000, DirtyDays: 60, Now: now})
+	if len(findings) != 1 || findings[0].Check != "remote" {
+		t.Errorf("expected only the remote finding, got %+v", findings)
+	}
+}
@@ -0,0 +1,139 @@
+package proj
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrGroupNotFound is returned when a named project group does not exist.
+var ErrGroupNotFound = errors.New("group not found")
+
+// Group is a named set of projects, e.g. "work" or "oss".
+type Group struct {
+	Name     string
+	Projects []string // member project names, sorted
+}
+
+// CreateGroup adds an empty group.
+func (s *Store) CreateGroup(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, " \t,") {
+		return fmt.Errorf("invalid group name %q", name)
+	}
+	var existing string
+	err := s.db.QueryRow(`SELECT name FROM project_groups WHERE name = ?`, name).Scan(&existing)
+	if err == nil {
+		return fmt.Errorf("group %q already exists", name)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("check group: %w", err)
+	}
+	if _, err := s.db.Exec(
+		`INSERT INTO project_groups (name, created_at) VALUES (?, ?)`,
+		name, time.Now().UTC().Format(time.RFC3339Nano),
+	); err != nil {
+		return fmt.Errorf("create group: %w", err)
+	}
+	return nil
+}
+
+// AddToGroup adds registered projects to an existing group. It reports
+// whether each project was newly added; projects already in the group are
+// left alone.
+func (s *Store) AddToGroup(group string, projects ...string) ([]bool, error) {
+	if err := s.requireGroup(group); err != nil {
+		return nil, err
+	}
+	for _, name := range projects {
+		if _, err := s.Get(name); err != nil {
+			return nil, err
+		}
+	}
+
+	added := make([]bool, len(projects))
+	for i, name := range projects {
+		res, err := s.db.Exec(
+			`INSERT OR IGNORE INTO project_group_members (group_name, project_name) VALUES (?, ?)`,
+			group, strings.TrimSpace(name),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("add %s to group: %w", name, err)
+		}
+		n, _ := res.RowsAffected()
+		added[i] = n > 0
+	}
+	return added, nil
+}
+
+// Groups returns every group with its members, by name.
+func (s *Store) Groups() ([]Group, error) {
+	rows, err := s.db.Query(`
+		SELECT g.name, m.project_name
+		FROM project_groups g
+		LEFT JOIN project_group_members m ON m.group_name = g.name
+		ORDER BY g.name, m.project_name`)
+	if err != nil {
+		return nil, fmt.Errorf("list groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []Group
+	for rows.Next() {
+		var name string
+		var member sql.NullString
+		if err := rows.Scan(&name, &member); err != nil {
+			return nil, fmt.Errorf("scan group: %w", err)
+		}
+		if len(groups) == 0 || groups[len(groups)-1].Name != name {
+			groups = append(groups, Group{Name: name})
+		}
+		if member.Valid {
+			g := &groups[len(groups)-1]
+			g.Projects = append(g.Projects, member.String)
+		}
+	}
+	return groups, rows.Err()
+}
+
+// GroupPaths returns the paths of a group's member projects. An empty
+// group yields an empty, non-nil slice.
+func (s *Store) GroupPaths(group string) ([]string, error) {
+	if err := s.requireGroup(group); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(`
+		SELECT p.path
+		FROM project_group_members m
+		JOIN projects p ON p.name = m.project_name
+		WHERE m.group_name = ?
+		ORDER BY p.name`, group)
+	if err != nil {
+		return nil, fmt.Errorf("list group projects: %w", err)
+	}
+	defer rows.Close()
+
+	paths := []string{}
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("scan group project: %w", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
+
+func (s *Store) requireGroup(name string) error {
+	var existing string
+	err := s.db.QueryRow(`SELECT name FROM project_groups WHERE name = ?`, strings.TrimSpace(name)).Scan(&existing)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("group %q: %w", name, ErrGroupNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("load group: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package proj
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	s, _ := setupStore(t)
+	root := t.TempDir()
+	api := mkProject(t, s, filepath.Join(root, "api"))
+	mkProject(t, s, filepath.Join(root, "web"))
+
+	if err := s.CreateGroup("work"); err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := s.CreateGroup("work"); err == nil {
+		t.Fatal("expected duplicate group error")
+	}
+	if err := s.CreateGroup("oss"); err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+
+	added, err := s.AddToGroup("work", "api", "web")
+	if err != nil {
+		t.Fatalf("AddToGroup: %v", err)
+	}
+	if !added[0] || !added[1] {
+		t.Fatalf("expected both added, got %v", added)
+	}
+	added, err = s.AddToGroup("work", "api")
+	if err != nil || added[0] {
+		t.Fatalf("re-adding should be a no-op, got %v, %v", added, err)
+	}
+
+	groups, err := s.Groups()
+	if err != nil {
+		t.Fatalf("Groups: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Name != "oss" || len(groups[0].Projects) != 0 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	if len(groups[1].Projects) != 2 || groups[1].Projects[0] != "api" {
+		t.Fatalf("unexpected work members: %+v", groups[1])
+	}
+
+	paths, err := s.GroupPaths("work")
+	if err != nil {
+		t.Fatalf("GroupPaths: %v", err)
+	}
+	if len(paths) != 2 || paths[0] != api {
+		t.Fatalf("GroupPaths = %v", paths)
+	}
+
+	paths, err = s.GroupPaths("oss")
+	if err != nil || paths == nil || len(paths) != 0 {
+		t.Fatalf("empty group should give empty non-nil paths, got %v, %v", paths, err)
+	}
+}
+
+func TestGroupErrors(t *testing.T) {
+	s, _ := setupStore(t)
+	mkProject(t, s, filepath.Join(t.TempDir(), "api"))
+
+	if _, err := s.GroupPaths("nope"); !errors.Is(err, ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound, got %v", err)
+	}
+	if _, err := s.AddToGroup("nope", "api"); !errors.Is(err, ErrGroupNotFound) {
+		t.Fatalf("expected ErrGroupNotFound, got %v", err)
+	}
+	if err := s.CreateGroup("work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddToGroup("work", "missing"); !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+	if err := s.CreateGroup("bad name"); err == nil {
+		t.Fatal("expected invalid name error")
+	}
+}
+
+func TestRemoveDropsGroupMembership(t *testing.T) {
+	s, _ := setupStore(t)
+	mkProject(t, s, filepath.Join(t.TempDir(), "api"))
+	if err := s.CreateGroup("work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddToGroup("work", "api"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove("api"); err != nil {
+		t.Fatal(err)
+	}
+	groups, err := s.Groups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups[0].Projects) != 0 {
+		t.Fatalf("removed project still in group: %+v", groups[0])
+	}
+}
+
+func mkProject(t *testing.T, s *Store, dir string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	p, err := s.Add(dir)
+	if err != nil {
+		t.Fatalf("Add(%s): %v", dir, err)
+	}
+	return p.Path
+}
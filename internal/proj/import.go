@@ -0,0 +1,173 @@
+package proj
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Import sources understood by ReadHistory.
+const (
+	SourceZoxide   = "zoxide"
+	SourceAutojump = "autojump"
+)
+
+// Candidate is a directory from a jump tool's history, with the tool's
+// frecency score.
+type Candidate struct {
+	Path  string
+	Score float64
+}
+
+// zoxideQuery returns zoxide's scored directory list. Replaceable for testing.
+var zoxideQuery = func() (io.Reader, error) {
+	out, err := exec.Command("zoxide", "query", "--list", "--score").Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("zoxide not found in PATH")
+		}
+		return nil, fmt.Errorf("zoxide query: %w", err)
+	}
+	return strings.NewReader(string(out)), nil
+}
+
+// ReadHistory loads the visited directories recorded by source, either
+// SourceZoxide or SourceAutojump.
+func ReadHistory(source string) ([]Candidate, error) {
+	switch source {
+	case SourceZoxide:
+		r, err := zoxideQuery()
+		if err != nil {
+			return nil, err
+		}
+		return ParseZoxide(r)
+	case SourceAutojump:
+		path, err := autojumpDataPath()
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("read autojump database: %w", err)
+		}
+		defer f.Close()
+		return ParseAutojump(f)
+	}
+	return nil, fmt.Errorf("unknown import source %q (want %s or %s)", source, SourceZoxide, SourceAutojump)
+}
+
+// ParseZoxide parses `zoxide query --list --score` output: one
+// "<score> <path>" per line, the score right-aligned.
+func ParseZoxide(r io.Reader) ([]Candidate, error) {
+	return parseHistory(r, func(line string) (string, string, bool) {
+		return strings.Cut(strings.TrimLeft(line, " "), " ")
+	})
+}
+
+// ParseAutojump parses autojump's autojump.txt: one "<weight>\t<path>" per
+// line.
+func ParseAutojump(r io.Reader) ([]Candidate, error) {
+	return parseHistory(r, func(line string) (string, string, bool) {
+		return strings.Cut(line, "\t")
+	})
+}
+
+func parseHistory(r io.Reader, split func(string) (string, string, bool)) ([]Candidate, error) {
+	var out []Candidate
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		score, path, ok := split(scanner.Text())
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(score), 64)
+		if err != nil || !filepath.IsAbs(path) {
+			continue
+		}
+		out = append(out, Candidate{Path: filepath.Clean(path), Score: n})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	return out, nil
+}
+
+// autojumpDataPath is where autojump keeps its database on this platform.
+func autojumpDataPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOOS == "darwin" {
+		return filepath.Join(home, "Library", "autojump", "autojump.txt"), nil
+	}
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "autojump", "autojump.txt"), nil
+}
+
+// ImportCandidates maps visited directories to the git repositories that
+// contain them and returns the repos not registered yet, highest combined
+// score first. Visits anywhere inside a repo count toward it; the home
+// directory is never offered, even if it's a dotfiles repo.
+func (s *Store) ImportCandidates(history []Candidate) ([]Candidate, error) {
+	registered, err := s.registeredPaths()
+	if err != nil {
+		return nil, err
+	}
+	home, _ := os.UserHomeDir()
+
+	scores := map[string]float64{}
+	roots := map[string]string{} // visited dir -> repo root ("" if none)
+	for _, c := range history {
+		root, ok := roots[c.Path]
+		if !ok {
+			root = gitRoot(c.Path)
+			roots[c.Path] = root
+		}
+		if root == "" || root == home || registered[root] {
+			continue
+		}
+		scores[root] += c.Score
+	}
+
+	out := make([]Candidate, 0, len(scores))
+	for path, score := range scores {
+		out = append(out, Candidate{Path: path, Score: score})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out, nil
+}
+
+// gitRoot returns the nearest directory at or above dir containing .git,
+// or "" if there is none or dir no longer exists.
+func gitRoot(dir string) string {
+	if !dirExists(dir) {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
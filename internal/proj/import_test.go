@@ -0,0 +1,99 @@
+package proj
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseZoxideAndAutojump(t *testing.T) {
+	z, err := ParseZoxide(strings.NewReader("  12.5 /home/u/dev/api\n   3.0 /home/u/dev/my repo\ngarbage\n 1.0 relative/path\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(z) != 2 || z[0].Score != 12.5 || z[1].Path != "/home/u/dev/my repo" {
+		t.Fatalf("unexpected zoxide parse: %+v", z)
+	}
+
+	a, err := ParseAutojump(strings.NewReader("22.4\t/home/u/dev/api\nnot-a-number\t/x\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a) != 1 || a[0].Score != 22.4 || a[0].Path != "/home/u/dev/api" {
+		t.Fatalf("unexpected autojump parse: %+v", a)
+	}
+}
+
+func TestImportCandidatesAggregatesByRepo(t *testing.T) {
+	s, _ := setupStore(t)
+	root := t.TempDir()
+	mkdir := func(parts ...string) string {
+		p := filepath.Join(append([]string{root}, parts...)...)
+		if err := os.MkdirAll(p, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	api := mkdir("api")
+	mkdir("api", ".git")
+	apiSrc := mkdir("api", "src")
+	web := mkdir("web")
+	mkdir("web", ".git")
+	done := mkdir("done")
+	mkdir("done", ".git")
+	notRepo := mkdir("notes")
+	if _, err := s.Add(done); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.ImportCandidates([]Candidate{
+		{Path: web, Score: 8},
+		{Path: api, Score: 5},
+		{Path: apiSrc, Score: 4},
+		{Path: done, Score: 50},
+		{Path: notRepo, Score: 30},
+		{Path: filepath.Join(root, "gone"), Score: 30},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Path != api || got[0].Score != 9 || got[1].Path != web {
+		t.Fatalf("unexpected candidates: %+v", got)
+	}
+}
+
+func TestReadHistory(t *testing.T) {
+	orig := zoxideQuery
+	t.Cleanup(func() { zoxideQuery = orig })
+	zoxideQuery = func() (io.Reader, error) { return strings.NewReader(" 4.0 /srv/app\n"), nil }
+
+	got, err := ReadHistory(SourceZoxide)
+	if err != nil || len(got) != 1 || got[0].Path != "/srv/app" {
+		t.Fatalf("zoxide: %+v, %v", got, err)
+	}
+
+	if runtime.GOOS == "darwin" {
+		return // autojump ignores XDG_DATA_HOME on macOS
+	}
+	data := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", data)
+	if err := os.MkdirAll(filepath.Join(data, "autojump"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(data, "autojump", "autojump.txt"), []byte("10.0\t/srv/app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadHistory(SourceAutojump)
+	if err != nil || len(got) != 1 || got[0].Score != 10 {
+		t.Fatalf("autojump: %+v, %v", got, err)
+	}
+}
+
+func TestReadHistoryUnknownSource(t *testing.T) {
+	if _, err := ReadHistory("fasd"); err == nil {
+		t.Error("expected error for unknown source")
+	}
+}
@@ -0,0 +1,205 @@
+package proj
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MoveResult describes what Move changed. OldPath and NewPath differ only
+// when the project's directory changed; OldName and NewName only when it
+// was renamed.
+type MoveResult struct {
+	OldName, NewName string
+	OldPath, NewPath string
+	// MovedOnDisk is true when Move renamed the directory itself, as
+	// opposed to recording a move the user already made.
+	MovedOnDisk bool
+}
+
+// IsPathTarget reports whether a 'proj mv' target names a path rather than
+// a new project name.
+func IsPathTarget(target string) bool {
+	return strings.ContainsRune(target, filepath.Separator) || strings.HasPrefix(target, ".") || strings.HasPrefix(target, "~")
+}
+
+// Move renames a project or points it at a new directory, depending on
+// whether target is a name or a path (see IsPathTarget). For a path, the
+// directory is moved on disk when it hasn't been already. Data keyed by
+// the project name (groups, notes, settings, current/previous) follows the
+// rename; data keyed by path is the caller's to migrate, calling Revert if
+// that fails. If Move itself fails, nothing is left moved.
+func (s *Store) Move(name, target string) (*MoveResult, error) {
+	p, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	res := &MoveResult{OldName: p.Name, NewName: p.Name, OldPath: p.Path, NewPath: p.Path}
+
+	if IsPathTarget(target) {
+		res.NewPath, res.MovedOnDisk, err = s.moveDir(p.Path, target)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := validateProjectName(target); err != nil {
+			return nil, err
+		}
+		res.NewName = target
+	}
+	if res.NewName == res.OldName && res.NewPath == res.OldPath {
+		return res, nil
+	}
+
+	if err := s.moveRows(res); err != nil {
+		return nil, undoDirMove(res, err)
+	}
+	if res.NewName != res.OldName {
+		if err := s.renameSettings(res.OldName, res.NewName); err != nil {
+			if revertErr := s.moveRows(res.reversed()); revertErr != nil {
+				return nil, fmt.Errorf("%w (undoing the move also failed: %v)", err, revertErr)
+			}
+			return nil, undoDirMove(res, err)
+		}
+	}
+	return res, nil
+}
+
+// Revert undoes a move Move completed, for when migrating the project's
+// path-keyed data afterwards fails.
+func (s *Store) Revert(res *MoveResult) error {
+	back := res.reversed()
+	if err := s.moveRows(back); err != nil {
+		return err
+	}
+	if back.NewName != back.OldName {
+		if err := s.renameSettings(back.OldName, back.NewName); err != nil {
+			return err
+		}
+	}
+	if res.MovedOnDisk {
+		if err := os.Rename(res.NewPath, res.OldPath); err != nil {
+			return fmt.Errorf("move directory back: %w", err)
+		}
+	}
+	return nil
+}
+
+// reversed returns the move that takes res back where it came from.
+func (res *MoveResult) reversed() *MoveResult {
+	return &MoveResult{
+		OldName: res.NewName, NewName: res.OldName,
+		OldPath: res.NewPath, NewPath: res.OldPath,
+		MovedOnDisk: res.MovedOnDisk,
+	}
+}
+
+// undoDirMove moves the directory back if Move renamed it, then returns err.
+func undoDirMove(res *MoveResult, err error) error {
+	if !res.MovedOnDisk {
+		return err
+	}
+	if renameErr := os.Rename(res.NewPath, res.OldPath); renameErr != nil {
+		return fmt.Errorf("%w (moving %s back also failed: %v)", err, res.NewPath, renameErr)
+	}
+	return err
+}
+
+// moveDir resolves target to an absolute path and, if the project hasn't
+// been moved there yet, renames oldPath to it, reporting whether it did.
+func (s *Store) moveDir(oldPath, target string) (newPath string, moved bool, err error) {
+	if strings.HasPrefix(target, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false, err
+		}
+		target = filepath.Join(home, target[2:])
+	}
+	newPath, err = filepath.Abs(target)
+	if err != nil {
+		return "", false, fmt.Errorf("resolve path: %w", err)
+	}
+	if newPath == oldPath {
+		return newPath, false, nil
+	}
+
+	var other string
+	if err := s.db.QueryRow(`SELECT name FROM projects WHERE path = ?`, newPath).Scan(&other); err == nil {
+		return "", false, fmt.Errorf("%w: %s is already registered as %s", ErrProjectExists, newPath, other)
+	}
+
+	switch oldThere, newThere := dirExists(oldPath), dirExists(newPath); {
+	case newThere && !oldThere:
+		// Already moved by hand; just record it.
+		return newPath, false, nil
+	case newThere:
+		return "", false, fmt.Errorf("both %s and %s exist — move the directory yourself, then rerun", oldPath, newPath)
+	case !oldThere:
+		return "", false, fmt.Errorf("neither %s nor %s exists", oldPath, newPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0o755); err != nil {
+		return "", false, err
+	}
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return "", false, fmt.Errorf("move directory: %w", err)
+	}
+	return newPath, true, nil
+}
+
+// moveRows applies a move to the registry and everything keyed by the
+// project name, in one transaction.
+func (s *Store) moveRows(res *MoveResult) error {
+	if res.NewName != res.OldName {
+		if _, err := s.Get(res.NewName); err == nil {
+			return fmt.Errorf("%w: %s", ErrProjectExists, res.NewName)
+		} else if !errors.Is(err, ErrProjectNotFound) {
+			return err
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmts := []struct {
+		query string
+		args  []any
+	}{
+		{`UPDATE projects SET name = ?, path = ? WHERE name = ?`, []any{res.NewName, res.NewPath, res.OldName}},
+		{`UPDATE project_group_members SET project_name = ? WHERE project_name = ?`, []any{res.NewName, res.OldName}},
+		{`UPDATE project_notes SET project_name = ? WHERE project_name = ?`, []any{res.NewName, res.OldName}},
+		{`UPDATE project_worktrees SET project_name = ? WHERE project_name = ?`, []any{res.NewName, res.OldName}},
+		{`UPDATE project_worktrees SET parent_name = ? WHERE parent_name = ?`, []any{res.NewName, res.OldName}},
+		{`UPDATE kv SET value = ? WHERE key IN ('proj.current', 'proj.previous') AND value = ?`, []any{res.NewName, res.OldName}},
+	}
+	for _, st := range stmts {
+		if _, err := tx.Exec(st.query, st.args...); err != nil {
+			return fmt.Errorf("move project: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// renameSettings moves a project's projects.toml entry to its new name.
+func (s *Store) renameSettings(oldName, newName string) error {
+	sf, err := s.readSettingsFile()
+	if err != nil {
+		return err
+	}
+	cfg, ok := sf.Projects[oldName]
+	if !ok {
+		return nil
+	}
+	delete(sf.Projects, oldName)
+	sf.Projects[newName] = cfg
+	return s.writeSettingsFile(sf)
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,148 @@
+package proj
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMoveRenamesAndCarriesNameKeyedData(t *testing.T) {
+	s, db := setupStore(t)
+	dir := mkProject(t, s, filepath.Join(t.TempDir(), "api"))
+	if err := s.CreateGroup("work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddToGroup("work", "api"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddNote("api", "remember me"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSetting("api", "tmux_layout", "dev"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Open("api"); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Move("api", "api-v1")
+	if err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if res.NewName != "api-v1" || res.NewPath != dir || res.MovedOnDisk {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	if _, err := s.Get("api"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("old name still registered: %v", err)
+	}
+	groups, _ := s.Groups()
+	if len(groups[0].Projects) != 1 || groups[0].Projects[0] != "api-v1" {
+		t.Errorf("group membership not renamed: %+v", groups)
+	}
+	if notes, _ := s.Notes("api-v1"); len(notes) != 1 {
+		t.Errorf("notes not renamed: %+v", notes)
+	}
+	if layout, _ := s.GetSetting("api-v1", "tmux_layout"); layout != "dev" {
+		t.Errorf("settings not renamed, got %q", layout)
+	}
+	var current string
+	db.QueryRow(`SELECT value FROM kv WHERE key = 'proj.current'`).Scan(&current)
+	if current != "api-v1" {
+		t.Errorf("current project = %q", current)
+	}
+}
+
+func TestMoveToPath(t *testing.T) {
+	s, _ := setupStore(t)
+	root := t.TempDir()
+	old := mkProject(t, s, filepath.Join(root, "api"))
+
+	// Not moved yet: Move does it.
+	res, err := s.Move("api", filepath.Join(root, "work", "api"))
+	if err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if !res.MovedOnDisk || res.OldPath != old || !dirExists(res.NewPath) || dirExists(old) {
+		t.Fatalf("expected directory moved on disk: %+v", res)
+	}
+
+	// Already moved by hand: Move just records it.
+	hand := filepath.Join(root, "elsewhere")
+	if err := os.Rename(res.NewPath, hand); err != nil {
+		t.Fatal(err)
+	}
+	res, err = s.Move("api", hand)
+	if err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if res.MovedOnDisk {
+		t.Error("should not report an on-disk move")
+	}
+	if p, _ := s.Get("api"); p.Path != hand {
+		t.Errorf("registry path = %q, want %q", p.Path, hand)
+	}
+}
+
+func TestMoveErrors(t *testing.T) {
+	s, _ := setupStore(t)
+	root := t.TempDir()
+	mkProject(t, s, filepath.Join(root, "api"))
+	web := mkProject(t, s, filepath.Join(root, "web"))
+
+	if _, err := s.Move("api", "web"); !errors.Is(err, ErrProjectExists) {
+		t.Errorf("rename onto existing name: %v", err)
+	}
+	if _, err := s.Move("api", web); !errors.Is(err, ErrProjectExists) {
+		t.Errorf("move onto registered path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "taken"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Move("api", filepath.Join(root, "taken")); err == nil {
+		t.Error("expected error when both old and new directories exist")
+	}
+	if _, err := s.Move("missing", "x"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("unknown project: %v", err)
+	}
+}
+
+func TestMoveToPath_UndoneWhenRegistryUpdateFails(t *testing.T) {
+	s, db := setupStore(t)
+	root := t.TempDir()
+	old := mkProject(t, s, filepath.Join(root, "api"))
+	if _, err := db.Exec(`CREATE TRIGGER fail_move BEFORE UPDATE ON projects BEGIN SELECT RAISE(ABORT, 'boom'); END`); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Move("api", filepath.Join(root, "work", "api")); err == nil {
+		t.Fatal("expected Move to fail")
+	}
+	if !dirExists(old) || dirExists(filepath.Join(root, "work", "api")) {
+		t.Error("directory should be moved back after a failed registry update")
+	}
+	if p, _ := s.Get("api"); p.Path != old {
+		t.Errorf("registry path = %q, want %q", p.Path, old)
+	}
+}
+
+func TestRevert(t *testing.T) {
+	s, _ := setupStore(t)
+	root := t.TempDir()
+	old := mkProject(t, s, filepath.Join(root, "api"))
+
+	res, err := s.Move("api", filepath.Join(root, "work", "api"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Revert(res); err != nil {
+		t.Fatalf("Revert: %v", err)
+	}
+	if !dirExists(old) || dirExists(res.NewPath) {
+		t.Error("Revert should move the directory back")
+	}
+	if p, _ := s.Get("api"); p.Path != old {
+		t.Errorf("registry path = %q, want %q", p.Path, old)
+	}
+}
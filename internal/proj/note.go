@@ -0,0 +1,57 @@
+package proj
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Note is a freeform, timestamped note on a project.
+type Note struct {
+	ID        int
+	Body      string
+	CreatedAt time.Time
+}
+
+// AddNote attaches a note to a registered project.
+func (s *Store) AddNote(name, body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return fmt.Errorf("note text cannot be empty")
+	}
+	p, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(
+		`INSERT INTO project_notes (project_name, body, created_at) VALUES (?, ?, ?)`,
+		p.Name, body, time.Now().UTC().Format(time.RFC3339Nano),
+	); err != nil {
+		return fmt.Errorf("add note: %w", err)
+	}
+	return nil
+}
+
+// Notes returns a project's notes, newest first.
+func (s *Store) Notes(name string) ([]Note, error) {
+	rows, err := s.db.Query(
+		`SELECT id, body, created_at FROM project_notes WHERE project_name = ? ORDER BY created_at DESC, id DESC`,
+		strings.TrimSpace(name),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []Note
+	for rows.Next() {
+		var n Note
+		var created string
+		if err := rows.Scan(&n.ID, &n.Body, &created); err != nil {
+			return nil, fmt.Errorf("scan note: %w", err)
+		}
+		n.CreatedAt = parseTime(created)
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
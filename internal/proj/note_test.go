@@ -0,0 +1,65 @@
+package proj
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestNotes(t *testing.T) {
+	s, _ := setupStore(t)
+	mkProject(t, s, filepath.Join(t.TempDir(), "api"))
+
+	if err := s.AddNote("api", "deploys from the release branch"); err != nil {
+		t.Fatalf("AddNote: %v", err)
+	}
+	if err := s.AddNote("api", "  staging creds live in env profile 'stg'  "); err != nil {
+		t.Fatalf("AddNote: %v", err)
+	}
+
+	notes, err := s.Notes("api")
+	if err != nil {
+		t.Fatalf("Notes: %v", err)
+	}
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Body != "staging creds live in env profile 'stg'" {
+		t.Errorf("newest note first and trimmed, got %q", notes[0].Body)
+	}
+	if notes[0].CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be set")
+	}
+}
+
+func TestAddNoteErrors(t *testing.T) {
+	s, _ := setupStore(t)
+	mkProject(t, s, filepath.Join(t.TempDir(), "api"))
+
+	if err := s.AddNote("missing", "hi"); !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected ErrProjectNotFound, got %v", err)
+	}
+	if err := s.AddNote("api", "   "); err == nil {
+		t.Fatal("expected empty note error")
+	}
+}
+
+func TestRemoveDropsNotes(t *testing.T) {
+	s, _ := setupStore(t)
+	dir := filepath.Join(t.TempDir(), "api")
+	mkProject(t, s, dir)
+	if err := s.AddNote("api", "old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove("api"); err != nil {
+		t.Fatal(err)
+	}
+	mkProject(t, s, dir)
+	notes, err := s.Notes("api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 0 {
+		t.Fatalf("re-added project inherited notes: %+v", notes)
+	}
+}
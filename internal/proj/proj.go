@@ -56,6 +56,23 @@ type Settings struct {
 	TmuxLayout    string `toml:"tmux_layout,omitempty"`
 	SSHHost       string `toml:"ssh_host,omitempty"`
 	SSHTunnel     string `toml:"ssh_tunnel,omitempty"`
+	GitHubRepo    string `toml:"github_repo,omitempty"`
+	// TodoTags, TodoPriority, and TodoSchedule are applied to todos added
+	// inside the project unless overridden on the command line.
+	TodoTags     string `toml:"todo_tags,omitempty"`
+	TodoPriority string `toml:"todo_priority,omitempty"`
+	TodoSchedule string `toml:"todo_schedule,omitempty"`
+	// TmuxAutoSession overrides the tmux.auto_session config value for
+	// the project: off, ask, or attach.
+	TmuxAutoSession string `toml:"tmux_auto_session,omitempty"`
+}
+
+// TodoDefaults are the todo fields a project pre-fills. Empty fields mean
+// no project default.
+type TodoDefaults struct {
+	Tags     []string
+	Priority string
+	Schedule string
 }
 
 type settingsFile struct {
@@ -144,10 +161,25 @@ func (s *Store) Remove(name string) error {
 	if n == 0 {
 		return fmt.Errorf("project %q not found", name)
 	}
+	if _, err := s.db.Exec(`DELETE FROM project_group_members WHERE project_name = ?`, strings.TrimSpace(name)); err != nil {
+		return fmt.Errorf("remove project from groups: %w", err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM project_notes WHERE project_name = ?`, strings.TrimSpace(name)); err != nil {
+		return fmt.Errorf("remove project notes: %w", err)
+	}
+	if _, err := s.db.Exec(`DELETE FROM project_worktrees WHERE project_name = ?1 OR parent_name = ?1`, strings.TrimSpace(name)); err != nil {
+		return fmt.Errorf("remove worktree links: %w", err)
+	}
 	return nil
 }
 
 func (s *Store) List() ([]Project, error) {
+	return s.list(true)
+}
+
+// list returns registered projects by name, looking up each one's branch
+// when branches is set.
+func (s *Store) list(branches bool) ([]Project, error) {
 	rows, err := s.db.Query(`SELECT name, path, last_accessed FROM projects ORDER BY name ASC`)
 	if err != nil {
 		return nil, fmt.Errorf("list projects: %w", err)
@@ -164,7 +196,9 @@ func (s *Store) List() ([]Project, error) {
 		if last.Valid {
 			p.LastAccessed = parseTime(last.String)
 		}
-		p.Branch = gitBranchAtPath(p.Path)
+		if branches {
+			p.Branch = gitBranchAtPath(p.Path)
+		}
 		projects = append(projects, p)
 	}
 	if err := rows.Err(); err != nil {
@@ -298,20 +332,61 @@ func (s *Store) FindForPath(path string) (*Project, error) {
 	return best, nil
 }
 
+// Scan registers every git repository Discover finds under root and
+// returns the added projects sorted by name. Repos that can't be added,
+// e.g. because their name is taken, are skipped.
 func (s *Store) Scan(root string, depth int) ([]Project, error) {
+	paths, err := s.Discover(root, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	var added []Project
+	for _, path := range paths {
+		p, err := s.Add(path)
+		if err != nil {
+			continue
+		}
+		added = append(added, *p)
+	}
+
+	sort.Slice(added, func(i, j int) bool { return added[i].Name < added[j].Name })
+	return added, nil
+}
+
+// Discover walks root up to depth directories deep and returns the paths
+// of git repositories that are not registered yet, sorted. Hidden
+// directories and the insides of repositories are not searched. A leading
+// "~" in root expands to the home directory.
+func (s *Store) Discover(root string, depth int) ([]string, error) {
 	if strings.TrimSpace(root) == "" {
 		root = "."
 	}
 	if depth < 0 {
 		return nil, fmt.Errorf("depth must be >= 0")
 	}
+	if root == "~" || strings.HasPrefix(root, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("resolve home: %w", err)
+		}
+		root = filepath.Join(home, root[1:])
+	}
 
 	absRoot, err := filepath.Abs(root)
 	if err != nil {
 		return nil, fmt.Errorf("resolve scan root: %w", err)
 	}
+	if info, err := os.Stat(absRoot); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("scan root %s is not a directory", absRoot)
+	}
 
-	var added []Project
+	registered, err := s.registeredPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []string
 	err = filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return nil
@@ -335,14 +410,9 @@ func (s *Store) Scan(root string, depth int) ([]Project, error) {
 		}
 
 		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
-			p, err := s.Add(path)
-			if err != nil {
-				if errors.Is(err, ErrProjectExists) {
-					return filepath.SkipDir
-				}
-				return nil
+			if !registered[path] {
+				found = append(found, path)
 			}
-			added = append(added, *p)
 			return filepath.SkipDir
 		}
 		return nil
@@ -351,12 +421,38 @@ func (s *Store) Scan(root string, depth int) ([]Project, error) {
 		return nil, fmt.Errorf("scan projects: %w", err)
 	}
 
-	sort.Slice(added, func(i, j int) bool { return added[i].Name < added[j].Name })
-	return added, nil
+	sort.Strings(found)
+	return found, nil
+}
+
+// registeredPaths returns the set of registered project paths.
+func (s *Store) registeredPaths() (map[string]bool, error) {
+	rows, err := s.db.Query(`SELECT path FROM projects`)
+	if err != nil {
+		return nil, fmt.Errorf("list project paths: %w", err)
+	}
+	defer rows.Close()
+
+	paths := map[string]bool{}
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, fmt.Errorf("scan project path: %w", err)
+		}
+		paths[path] = true
+	}
+	return paths, rows.Err()
 }
 
 func SupportedConfigKeys() []string {
-	return []string{"default_branch", "env_file", "tmux_layout", "ssh_host", "ssh_tunnel"}
+	return []string{"default_branch", "env_file", "tmux_layout", "ssh_host", "ssh_tunnel", "github_repo", "todo_tags", "todo_priority", "todo_schedule", "tmux_auto_session"}
+}
+
+// ValidateSetting reports whether value is acceptable for key without
+// saving it.
+func ValidateSetting(key, value string) error {
+	var cfg Settings
+	return applySetting(&cfg, key, value)
 }
 
 func (s *Store) GetSetting(projectName, key string) (string, error) {
@@ -375,6 +471,26 @@ func (s *Store) GetSetting(projectName, key string) (string, error) {
 	return settingValue(cfg, key)
 }
 
+// SettingValues returns key's value for every project that has it set,
+// keyed by project name.
+func (s *Store) SettingValues(key string) (map[string]string, error) {
+	if _, err := settingValue(Settings{}, key); err != nil {
+		return nil, err
+	}
+
+	sf, err := s.readSettingsFile()
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	for name, cfg := range sf.Projects {
+		if v, _ := settingValue(cfg, key); v != "" {
+			values[name] = v
+		}
+	}
+	return values, nil
+}
+
 func (s *Store) SetSetting(projectName, key, value string) error {
 	if strings.TrimSpace(projectName) == "" {
 		return fmt.Errorf("project name is required")
@@ -400,6 +516,20 @@ func (s *Store) SetSetting(projectName, key, value string) error {
 	return s.writeSettingsFile(sf)
 }
 
+// TodoDefaults returns the todo defaults configured for a project.
+func (s *Store) TodoDefaults(projectName string) (TodoDefaults, error) {
+	sf, err := s.readSettingsFile()
+	if err != nil {
+		return TodoDefaults{}, err
+	}
+	cfg := sf.Projects[projectName]
+	return TodoDefaults{
+		Tags:     splitTags(cfg.TodoTags),
+		Priority: cfg.TodoPriority,
+		Schedule: cfg.TodoSchedule,
+	}, nil
+}
+
 func (s *Store) readSettingsFile() (*settingsFile, error) {
 	data, err := os.ReadFile(s.paths.ProjectsFile)
 	if err != nil {
@@ -506,6 +636,25 @@ func applySetting(cfg *Settings, key, value string) error {
 		cfg.SSHHost = value
 	case "ssh_tunnel":
 		cfg.SSHTunnel = value
+	case "github_repo":
+		cfg.GitHubRepo = value
+	case "todo_tags":
+		cfg.TodoTags = strings.Join(splitTags(value), ",")
+	case "todo_priority":
+		if err := validateChoice(key, value, todoPriorityValues); err != nil {
+			return err
+		}
+		cfg.TodoPriority = strings.ToLower(strings.TrimSpace(value))
+	case "todo_schedule":
+		if err := validateChoice(key, value, todoScheduleValues); err != nil {
+			return err
+		}
+		cfg.TodoSchedule = strings.ToLower(strings.TrimSpace(value))
+	case "tmux_auto_session":
+		if err := validateChoice(key, value, config.TmuxAutoSessionModes); err != nil {
+			return err
+		}
+		cfg.TmuxAutoSession = strings.ToLower(strings.TrimSpace(value))
 	default:
 		return fmt.Errorf("unknown key %q", key)
 	}
@@ -524,7 +673,49 @@ func settingValue(cfg Settings, key string) (string, error) {
 		return cfg.SSHHost, nil
 	case "ssh_tunnel":
 		return cfg.SSHTunnel, nil
+	case "github_repo":
+		return cfg.GitHubRepo, nil
+	case "todo_tags":
+		return cfg.TodoTags, nil
+	case "todo_priority":
+		return cfg.TodoPriority, nil
+	case "todo_schedule":
+		return cfg.TodoSchedule, nil
+	case "tmux_auto_session":
+		return cfg.TmuxAutoSession, nil
 	default:
 		return "", fmt.Errorf("unknown key %q", key)
 	}
 }
+
+// Accepted todo_priority and todo_schedule values, matching the
+// --priority and --schedule flags of 'mine todo add'.
+var (
+	todoPriorityValues = []string{"low", "l", "med", "medium", "m", "high", "h", "crit", "critical", "c"}
+	todoScheduleValues = []string{"today", "t", "soon", "s", "later", "l", "someday", "sd"}
+)
+
+// validateChoice accepts an empty value (clearing the setting) or one of valid.
+func validateChoice(key, value string, valid []string) error {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if v == "" {
+		return nil
+	}
+	for _, ok := range valid {
+		if v == ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s %q — valid values: %s", key, value, strings.Join(valid, ", "))
+}
+
+// splitTags parses a comma-separated tag list, dropping blanks.
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
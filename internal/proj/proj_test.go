@@ -35,6 +35,38 @@ func setupStore(t *testing.T) (*Store, *sql.DB) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	_, err = db.Exec(`CREATE TABLE project_groups (
+		name TEXT PRIMARY KEY,
+		created_at TEXT NOT NULL
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE project_group_members (
+		group_name TEXT NOT NULL,
+		project_name TEXT NOT NULL,
+		PRIMARY KEY (group_name, project_name)
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE project_notes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		project_name TEXT NOT NULL,
+		body TEXT NOT NULL,
+		created_at TEXT NOT NULL
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE project_worktrees (
+		project_name TEXT PRIMARY KEY,
+		parent_name TEXT NOT NULL,
+		branch TEXT NOT NULL
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
 	_, err = db.Exec(`CREATE TABLE kv (
 		key TEXT PRIMARY KEY,
 		value TEXT,
@@ -309,3 +341,107 @@ func TestGetSettingUnknownKeyWithoutProjectConfig(t *testing.T) {
 		t.Fatal("expected error for unknown key even when project has no settings")
 	}
 }
+
+func TestTodoDefaults(t *testing.T) {
+	s, _ := setupStore(t)
+	p, err := s.Add(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.SetSetting(p.Name, "todo_tags", " api, ,backend "); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSetting(p.Name, "todo_priority", "High"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSetting(p.Name, "todo_schedule", "whenever"); err == nil {
+		t.Fatal("expected invalid todo_schedule to be rejected")
+	}
+
+	d, err := s.TodoDefaults(p.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Tags) != 2 || d.Tags[0] != "api" || d.Tags[1] != "backend" {
+		t.Errorf("tags = %v, want [api backend]", d.Tags)
+	}
+	if d.Priority != "high" || d.Schedule != "" {
+		t.Errorf("priority/schedule = %q/%q, want high/empty", d.Priority, d.Schedule)
+	}
+
+	if d, err := s.TodoDefaults("unconfigured"); err != nil || len(d.Tags) != 0 || d.Priority != "" {
+		t.Errorf("expected empty defaults for unconfigured project, got %+v, %v", d, err)
+	}
+}
+
+func TestSettingValues(t *testing.T) {
+	s, _ := setupStore(t)
+	api, err := s.Add(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	web, err := s.Add(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.SetSetting(api.Name, "tmux_auto_session", "Attach"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSetting(web.Name, "ssh_host", "prod-box"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSetting(web.Name, "tmux_auto_session", "sometimes"); err == nil {
+		t.Fatal("expected invalid tmux_auto_session to be rejected")
+	}
+
+	values, err := s.SettingValues("tmux_auto_session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 1 || values[api.Name] != "attach" {
+		t.Errorf("values = %v, want only %s=attach", values, api.Name)
+	}
+	if _, err := s.SettingValues("not_a_key"); err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+}
+
+func TestDiscoverSkipsRegistered(t *testing.T) {
+	s, _ := setupStore(t)
+	root := t.TempDir()
+
+	for _, name := range []string{"api", "web"} {
+		if err := os.MkdirAll(filepath.Join(root, name, ".git"), 0o755); err != nil {
+			t.Fatalf("mkdir .git: %v", err)
+		}
+	}
+	if _, err := s.Add(filepath.Join(root, "api")); err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+
+	found, err := s.Discover(root, 2)
+	if err != nil {
+		t.Fatalf("Discover() error: %v", err)
+	}
+	if len(found) != 1 || found[0] != filepath.Join(root, "web") {
+		t.Fatalf("Discover() = %v, want only web", found)
+	}
+
+	// Discover only reports; nothing new is registered.
+	projects, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 registered project, got %d", len(projects))
+	}
+}
+
+func TestDiscoverRejectsMissingRoot(t *testing.T) {
+	s, _ := setupStore(t)
+	if _, err := s.Discover(filepath.Join(t.TempDir(), "nope"), 2); err == nil {
+		t.Fatal("expected error for missing scan root")
+	}
+}
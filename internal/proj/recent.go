@@ -0,0 +1,61 @@
+package proj
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Touch records that a project was just used, without making it the
+// current project the way Open does.
+func (s *Store) Touch(name string) error {
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	if _, err := s.db.Exec(`UPDATE projects SET last_accessed = ? WHERE name = ?`, now, strings.TrimSpace(name)); err != nil {
+		return fmt.Errorf("update project access: %w", err)
+	}
+	return nil
+}
+
+// Recent returns up to limit projects that have been used, most recent
+// first. A limit of 0 returns all of them.
+func (s *Store) Recent(limit int) ([]Project, error) {
+	projects, err := s.list(false)
+	if err != nil {
+		return nil, err
+	}
+	SortByRecent(projects)
+	n := 0
+	for n < len(projects) && !projects[n].LastAccessed.IsZero() {
+		n++
+	}
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	return projects[:n], nil
+}
+
+// SortByRecent orders projects most recently used first. Projects never
+// used keep their relative order at the end.
+func SortByRecent(projects []Project) {
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].LastAccessed.After(projects[j].LastAccessed)
+	})
+}
+
+// RecencyBoost is a fuzzy-match bonus for a project last used at last, so
+// recently used projects win close matches in pickers.
+func RecencyBoost(last, now time.Time) int {
+	if last.IsZero() {
+		return 0
+	}
+	switch age := now.Sub(last); {
+	case age < 24*time.Hour:
+		return 6
+	case age < 7*24*time.Hour:
+		return 4
+	case age < 30*24*time.Hour:
+		return 2
+	}
+	return 0
+}
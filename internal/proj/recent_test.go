@@ -0,0 +1,52 @@
+package proj
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTouchAndRecent(t *testing.T) {
+	s, db := setupStore(t)
+	root := t.TempDir()
+	for _, name := range []string{"api", "web", "cli"} {
+		mkProject(t, s, filepath.Join(root, name))
+	}
+	db.Exec(`UPDATE projects SET last_accessed = ? WHERE name = 'web'`, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339Nano))
+	if err := s.Touch("cli"); err != nil {
+		t.Fatalf("Touch: %v", err)
+	}
+
+	recent, err := s.Recent(0)
+	if err != nil {
+		t.Fatalf("Recent: %v", err)
+	}
+	if len(recent) != 2 || recent[0].Name != "cli" || recent[1].Name != "web" {
+		t.Fatalf("unexpected recent order: %+v", recent)
+	}
+	if recent, _ := s.Recent(1); len(recent) != 1 || recent[0].Name != "cli" {
+		t.Fatalf("limit not applied: %+v", recent)
+	}
+	if cur, _ := s.CurrentName(); cur != "" {
+		t.Errorf("Touch should not change the current project, got %q", cur)
+	}
+}
+
+func TestRecencyBoost(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		last time.Time
+		want int
+	}{
+		{time.Time{}, 0},
+		{now.Add(-time.Hour), 6},
+		{now.AddDate(0, 0, -3), 4},
+		{now.AddDate(0, 0, -20), 2},
+		{now.AddDate(0, -3, 0), 0},
+	}
+	for _, c := range cases {
+		if got := RecencyBoost(c.last, now); got != c.want {
+			t.Errorf("RecencyBoost(%v) = %d, want %d", now.Sub(c.last), got, c.want)
+		}
+	}
+}
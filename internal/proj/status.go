@@ -0,0 +1,120 @@
+package proj
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// statusWorkers caps how many projects are inspected at once.
+const statusWorkers = 8
+
+// GitState is a snapshot of a project's working tree.
+type GitState struct {
+	// Repo is false when the path is not a git work tree (or git failed).
+	Repo bool
+	// Branch is the checked-out branch, empty when HEAD is detached.
+	Branch string
+	Dirty  bool
+	// Upstream is true when the branch tracks a remote; Ahead and Behind
+	// are only meaningful then.
+	Upstream bool
+	Ahead    int
+	Behind   int
+	// LastCommit is the committer time of HEAD, zero for an empty repo.
+	LastCommit time.Time
+}
+
+// Status pairs a project with its git state.
+type Status struct {
+	Project Project
+	Git     GitState
+}
+
+// LastActivity is the later of the last commit and the last time the
+// project was opened.
+func (s Status) LastActivity() time.Time {
+	if s.Git.LastCommit.After(s.Project.LastAccessed) {
+		return s.Git.LastCommit
+	}
+	return s.Project.LastAccessed
+}
+
+// Status returns every registered project with its git state, by name.
+func (s *Store) Status() ([]Status, error) {
+	projects, err := s.list(false)
+	if err != nil {
+		return nil, err
+	}
+	statuses := Statuses(projects)
+	for i := range statuses {
+		statuses[i].Project.Branch = statuses[i].Git.Branch
+	}
+	return statuses, nil
+}
+
+// Statuses inspects every project's git state concurrently and returns the
+// results in the same order as projects.
+func Statuses(projects []Project) []Status {
+	out := make([]Status, len(projects))
+	sem := make(chan struct{}, statusWorkers)
+	var wg sync.WaitGroup
+	for i, p := range projects {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			out[i] = Status{Project: p, Git: gitStateAtPath(p.Path)}
+		}()
+	}
+	wg.Wait()
+	return out
+}
+
+// gitStateAtPath reads a work tree's state. Replaceable for testing.
+var gitStateAtPath = func(path string) GitState {
+	out, err := exec.Command("git", "-C", path, "status", "--porcelain=v2", "--branch").Output()
+	if err != nil {
+		return GitState{}
+	}
+	st := parseGitStatus(string(out))
+
+	if out, err := exec.Command("git", "-C", path, "log", "-1", "--format=%ct").Output(); err == nil {
+		if sec, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64); err == nil {
+			st.LastCommit = time.Unix(sec, 0)
+		}
+	}
+	return st
+}
+
+// parseGitStatus parses `git status --porcelain=v2 --branch` output.
+func parseGitStatus(out string) GitState {
+	st := GitState{Repo: true}
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				st.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.upstream "):
+			st.Upstream = true
+		case strings.HasPrefix(line, "# branch.ab "):
+			for _, f := range strings.Fields(strings.TrimPrefix(line, "# branch.ab ")) {
+				n, _ := strconv.Atoi(f[1:])
+				if f[0] == '+' {
+					st.Ahead = n
+				} else {
+					st.Behind = n
+				}
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			st.Dirty = true
+		}
+	}
+	return st
+}
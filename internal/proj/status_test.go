@@ -0,0 +1,65 @@
+package proj
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseGitStatus(t *testing.T) {
+	out := `# branch.oid 1234abcd
+# branch.head main
+# branch.upstream origin/main
+# branch.ab +2 -1
+1 .M N... 100644 100644 100644 aaa bbb README.md
+`
+	st := parseGitStatus(out)
+	if !st.Repo || st.Branch != "main" || !st.Upstream {
+		t.Fatalf("unexpected state: %+v", st)
+	}
+	if !st.Dirty {
+		t.Error("expected dirty tree")
+	}
+	if st.Ahead != 2 || st.Behind != 1 {
+		t.Errorf("ahead/behind = %d/%d, want 2/1", st.Ahead, st.Behind)
+	}
+}
+
+func TestParseGitStatus_CleanDetached(t *testing.T) {
+	st := parseGitStatus("# branch.oid 1234abcd\n# branch.head (detached)\n")
+	if st.Dirty || st.Branch != "" || st.Upstream {
+		t.Fatalf("unexpected state: %+v", st)
+	}
+}
+
+func TestStatusesKeepOrder(t *testing.T) {
+	orig := gitStateAtPath
+	gitStateAtPath = func(path string) GitState {
+		return GitState{Repo: true, Branch: "b-" + path}
+	}
+	t.Cleanup(func() { gitStateAtPath = orig })
+
+	var projects []Project
+	for _, p := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		projects = append(projects, Project{Name: p, Path: p})
+	}
+	got := Statuses(projects)
+	for i, st := range got {
+		if st.Project.Name != projects[i].Name || st.Git.Branch != "b-"+projects[i].Path {
+			t.Fatalf("status %d = %+v", i, st)
+		}
+	}
+}
+
+func TestStatusLastActivity(t *testing.T) {
+	opened := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	committed := opened.Add(time.Hour)
+
+	st := Status{Project: Project{LastAccessed: opened}, Git: GitState{LastCommit: committed}}
+	if !st.LastActivity().Equal(committed) {
+		t.Errorf("LastActivity = %v, want last commit", st.LastActivity())
+	}
+	st.Git.LastCommit = opened.Add(-time.Hour)
+	if !st.LastActivity().Equal(opened) {
+		t.Errorf("LastActivity = %v, want last opened", st.LastActivity())
+	}
+}
@@ -0,0 +1,200 @@
+package proj
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Worktree is a registered project that is a git worktree of another
+// project, its parent.
+type Worktree struct {
+	Project
+	Parent string
+	Branch string
+}
+
+// WorktreeName is the project name, directory name, and tmux session name
+// used for branch's worktree of parent, e.g. "api-feature-login" for
+// branch "feature/login".
+func WorktreeName(parent, branch string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '-'
+	}, branch)
+	return parent + "-" + strings.Trim(slug, "-")
+}
+
+// AddWorktree creates a git worktree of the named project for branch under
+// root (beside the project's directory when root is empty) and registers
+// it as a linked project. The branch is checked out if it exists locally
+// or on a remote, and created from HEAD otherwise.
+func (s *Store) AddWorktree(parentName, branch, root string) (*Worktree, error) {
+	branch = strings.TrimSpace(branch)
+	if branch == "" {
+		return nil, fmt.Errorf("branch name required")
+	}
+	parent, err := s.Get(parentName)
+	if err != nil {
+		return nil, err
+	}
+	if p, err := s.WorktreeParent(parent.Name); err != nil {
+		return nil, err
+	} else if p != "" {
+		return nil, fmt.Errorf("%s is itself a worktree of %s — add worktrees to %s instead", parent.Name, p, p)
+	}
+
+	if root == "" {
+		root = filepath.Dir(parent.Path)
+	} else if strings.HasPrefix(root, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		root = filepath.Join(home, root[2:])
+	}
+	name := WorktreeName(parent.Name, branch)
+	path, err := filepath.Abs(filepath.Join(root, name))
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
+	}
+	if _, err := s.Get(name); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProjectExists, name)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%s already exists", path)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+
+	args := []string{"worktree", "add", path, branch}
+	if !branchExists(parent.Path, branch) {
+		args = []string{"worktree", "add", "-b", branch, path}
+	}
+	if err := runGit(parent.Path, args...); err != nil {
+		return nil, err
+	}
+
+	p, err := s.Add(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Exec(
+		`INSERT INTO project_worktrees (project_name, parent_name, branch) VALUES (?, ?, ?)`,
+		p.Name, parent.Name, branch,
+	); err != nil {
+		return nil, fmt.Errorf("link worktree: %w", err)
+	}
+	p.Branch = branch
+	return &Worktree{Project: *p, Parent: parent.Name, Branch: branch}, nil
+}
+
+// Worktrees returns the worktrees registered for a project, by name.
+func (s *Store) Worktrees(parentName string) ([]Worktree, error) {
+	rows, err := s.db.Query(`
+		SELECT p.name, p.path, w.branch
+		FROM project_worktrees w JOIN projects p ON p.name = w.project_name
+		WHERE w.parent_name = ?
+		ORDER BY p.name ASC`, strings.TrimSpace(parentName))
+	if err != nil {
+		return nil, fmt.Errorf("list worktrees: %w", err)
+	}
+	defer rows.Close()
+
+	var worktrees []Worktree
+	for rows.Next() {
+		w := Worktree{Parent: parentName}
+		if err := rows.Scan(&w.Name, &w.Path, &w.Branch); err != nil {
+			return nil, fmt.Errorf("scan worktree: %w", err)
+		}
+		w.Project.Branch = w.Branch
+		worktrees = append(worktrees, w)
+	}
+	return worktrees, rows.Err()
+}
+
+// RemoveWorktree removes branch's worktree of the named project from disk
+// and unregisters it. The branch itself is kept. Without force, git refuses
+// to remove a worktree with uncommitted changes.
+func (s *Store) RemoveWorktree(parentName, branch string, force bool) (*Worktree, error) {
+	parent, err := s.Get(parentName)
+	if err != nil {
+		return nil, err
+	}
+	var w Worktree
+	err = s.db.QueryRow(`
+		SELECT p.name, p.path, w.branch
+		FROM project_worktrees w JOIN projects p ON p.name = w.project_name
+		WHERE w.parent_name = ? AND (w.branch = ? OR p.name = ?)`,
+		parent.Name, branch, branch,
+	).Scan(&w.Name, &w.Path, &w.Branch)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s has no worktree for %q", parent.Name, branch)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load worktree: %w", err)
+	}
+	w.Parent = parent.Name
+
+	if dirExists(w.Path) {
+		args := []string{"worktree", "remove", w.Path}
+		if force {
+			args = []string{"worktree", "remove", "--force", w.Path}
+		}
+		if err := runGit(parent.Path, args...); err != nil {
+			return nil, err
+		}
+	} else {
+		// Deleted by hand; let git forget about it too.
+		_ = runGit(parent.Path, "worktree", "prune")
+	}
+	if err := s.Remove(w.Name); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// WorktreeParent returns the parent of a worktree project, or "" if the
+// project isn't a worktree.
+func (s *Store) WorktreeParent(name string) (string, error) {
+	var parent string
+	err := s.db.QueryRow(`SELECT parent_name FROM project_worktrees WHERE project_name = ?`, strings.TrimSpace(name)).Scan(&parent)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("load worktree parent: %w", err)
+	}
+	return parent, nil
+}
+
+// branchExists reports whether branch exists locally or on any remote of
+// the repo at dir.
+func branchExists(dir, branch string) bool {
+	if _, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		return true
+	}
+	out, err := gitOutput(dir, "for-each-ref", "--format=%(refname)", "refs/remotes/*/"+branch)
+	return err == nil && strings.TrimSpace(out) != ""
+}
+
+// runGit runs git in dir, folding its stderr into the returned error.
+func runGit(dir string, args ...string) error {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if msg == "" {
+			msg = err.Error()
+		}
+		return fmt.Errorf("git %s: %s", args[0]+" "+args[1], msg)
+	}
+	return nil
+}
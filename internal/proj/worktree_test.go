@@ -0,0 +1,106 @@
+package proj
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWorktreeName(t *testing.T) {
+	tests := map[string]string{
+		"main":           "api-main",
+		"feature/login":  "api-feature-login",
+		"fix/issue.42":   "api-fix-issue-42",
+		"/odd/branch/":   "api-odd-branch",
+		"under_score-ok": "api-under_score-ok",
+	}
+	for branch, want := range tests {
+		if got := WorktreeName("api", branch); got != want {
+			t.Errorf("WorktreeName(api, %q) = %q, want %q", branch, got, want)
+		}
+	}
+}
+
+func TestWorktreeAddListRemove(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	s, _ := setupStore(t)
+	root := t.TempDir()
+	dir := filepath.Join(root, "api")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	git(t, dir, "init", "-q", "-b", "main")
+	git(t, dir, "commit", "-q", "--allow-empty", "-m", "first")
+	git(t, dir, "branch", "existing")
+	if _, err := s.Add(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := s.AddWorktree("api", "feature/login", "")
+	if err != nil {
+		t.Fatalf("AddWorktree new branch: %v", err)
+	}
+	if created.Name != "api-feature-login" || created.Path != filepath.Join(root, "api-feature-login") {
+		t.Errorf("unexpected worktree: %+v", created)
+	}
+	if _, err := s.Get("api-feature-login"); err != nil {
+		t.Errorf("worktree not registered: %v", err)
+	}
+
+	wtRoot := filepath.Join(t.TempDir(), "worktrees")
+	existing, err := s.AddWorktree("api", "existing", wtRoot)
+	if err != nil {
+		t.Fatalf("AddWorktree existing branch: %v", err)
+	}
+	if existing.Path != filepath.Join(wtRoot, "api-existing") {
+		t.Errorf("worktree_dir not honored: %s", existing.Path)
+	}
+	if got := gitBranchAtPath(existing.Path); got != "existing" {
+		t.Errorf("worktree on branch %q, want existing", got)
+	}
+
+	if _, err := s.AddWorktree("api-existing", "nested", ""); err == nil || !strings.Contains(err.Error(), "itself a worktree") {
+		t.Errorf("expected nested worktree error, got %v", err)
+	}
+	if parent, _ := s.WorktreeParent("api-existing"); parent != "api" {
+		t.Errorf("WorktreeParent = %q, want api", parent)
+	}
+
+	list, err := s.Worktrees("api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name != "api-existing" || list[1].Branch != "feature/login" {
+		t.Fatalf("unexpected worktrees: %+v", list)
+	}
+
+	removed, err := s.RemoveWorktree("api", "feature/login", false)
+	if err != nil {
+		t.Fatalf("RemoveWorktree: %v", err)
+	}
+	if _, err := os.Stat(removed.Path); !os.IsNotExist(err) {
+		t.Errorf("worktree dir still exists: %v", err)
+	}
+	if _, err := s.Get("api-feature-login"); err == nil {
+		t.Error("removed worktree still registered")
+	}
+	git(t, dir, "rev-parse", "--verify", "feature/login") // branch kept
+
+	// A worktree deleted by hand is still unregistered cleanly.
+	if err := os.RemoveAll(existing.Path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RemoveWorktree("api", "api-existing", false); err != nil {
+		t.Fatalf("RemoveWorktree missing dir: %v", err)
+	}
+	if list, _ := s.Worktrees("api"); len(list) != 0 {
+		t.Errorf("worktrees left: %+v", list)
+	}
+	if _, err := s.RemoveWorktree("api", "nope", false); err == nil {
+		t.Error("expected error for unknown worktree")
+	}
+}
@@ -365,6 +365,46 @@ end`,
   end
   set -l target (mine proj open --previous --print-path 2>/dev/null)
   test -n "$target"; and cd "$target"
+end`,
+		},
+		{
+			Name: "pj",
+			Desc: "Enter a project: cd, load its env, list todos, attach tmux",
+			Bash: `pj() {
+  if [ "$1" = "--help" ]; then
+    echo "pj — Enter a project: cd, load its env, list todos, attach tmux"
+    echo "Usage: pj [name] [--no-tmux] [--no-env]"
+    echo "Example: pj mine"
+    return 0
+  fi
+  local script
+  script="$(mine proj switch --shell posix "$@")" || return 1
+  eval "$script"
+  __mine_tmux_auto_pwd="$PWD"
+}`,
+			Zsh: `pj() {
+  if [[ "$1" == "--help" ]]; then
+    echo "pj — Enter a project: cd, load its env, list todos, attach tmux"
+    echo "Usage: pj [name] [--no-tmux] [--no-env]"
+    echo "Example: pj mine"
+    return 0
+  fi
+  local script
+  script="$(mine proj switch --shell posix "$@")" || return 1
+  local __mine_tmux_auto_skip=1
+  eval "$script"
+}`,
+			Fish: `function pj
+  if test "$argv[1]" = "--help"
+    echo "pj — Enter a project: cd, load its env, list todos, attach tmux"
+    echo "Usage: pj [name] [--no-tmux] [--no-env]"
+    echo "Example: pj mine"
+    return 0
+  end
+  set -l script (mine proj switch --shell fish $argv); or return 1
+  set -g __mine_tmux_auto_skip 1
+  string join \n $script | source
+  set -e __mine_tmux_auto_skip
 end`,
 		},
 		// --- tmux helpers ---
@@ -67,7 +67,7 @@ func TestFunctions(t *testing.T) {
 	}
 
 	// Verify expected functions exist.
-	expected := []string{"mkcd", "extract", "ports", "gitroot", "serve", "backup", "tre", "menv", "p", "pp"}
+	expected := []string{"mkcd", "extract", "ports", "gitroot", "serve", "backup", "tre", "menv", "p", "pp", "pj"}
 	for _, name := range expected {
 		if !names[name] {
 			t.Errorf("expected function %q not found", name)
@@ -172,6 +172,36 @@ func TestPromptScript(t *testing.T) {
 	}
 }
 
+func TestTmuxAutoScript(t *testing.T) {
+	hooks := map[string]string{Bash: "PROMPT_COMMAND", Zsh: "add-zsh-hook chpwd", Fish: "--on-variable PWD"}
+	for sh, hook := range hooks {
+		t.Run(sh, func(t *testing.T) {
+			script, err := TmuxAutoScript(sh)
+			if err != nil {
+				t.Fatalf("TmuxAutoScript(%q) error: %v", sh, err)
+			}
+			if !strings.Contains(script, "mine tmux auto --from") {
+				t.Error("hook should call mine tmux auto with the previous directory")
+			}
+			if !strings.Contains(script, "TMUX") {
+				t.Error("hook should skip shells already inside tmux")
+			}
+			if !strings.Contains(script, hook) {
+				t.Errorf("hook should register via %s", hook)
+			}
+		})
+	}
+
+	// The hook is opt-in, so it isn't part of the default init script.
+	if script, _ := InitScript(Zsh); strings.Contains(script, "mine tmux auto") {
+		t.Error("init script should not include the tmux auto-session hook")
+	}
+
+	if _, err := TmuxAutoScript("powershell"); err == nil {
+		t.Error("expected error for invalid shell")
+	}
+}
+
 func TestStarshipConfig(t *testing.T) {
 	cfg := StarshipConfig()
 	if !strings.Contains(cfg, "starship.toml") {
@@ -0,0 +1,73 @@
+package shell
+
+// TmuxAutoScript generates the opt-in hook that runs `mine tmux auto` when
+// the shell changes directory outside tmux. mine decides whether the new
+// directory is a project worth a session (tmux.auto_session and the
+// project's tmux_auto_session setting), so the hook stays a cheap check.
+// pj handles tmux itself, so the directory change it makes is skipped.
+func TmuxAutoScript(shellName string) (string, error) {
+	if !ValidShell(shellName) {
+		return "", ShellError(shellName)
+	}
+
+	var out string
+	out += "# mine tmux auto-session — https://mine.rwolfe.io\n"
+	out += "# Offers or attaches a project's tmux session when you cd into it.\n\n"
+
+	switch shellName {
+	case Bash:
+		out += bashTmuxAuto()
+	case Zsh:
+		out += zshTmuxAuto()
+	case Fish:
+		out += fishTmuxAuto()
+	}
+
+	return out, nil
+}
+
+func bashTmuxAuto() string {
+	return `__mine_tmux_auto_pwd="$PWD"
+
+__mine_tmux_auto() {
+  [ "$PWD" = "$__mine_tmux_auto_pwd" ] && return
+  local from="$__mine_tmux_auto_pwd"
+  __mine_tmux_auto_pwd="$PWD"
+  [ -n "$TMUX" ] && return
+  command -v tmux >/dev/null 2>&1 || return
+  mine tmux auto --from "$from"
+}
+
+if [[ "$PROMPT_COMMAND" != *"__mine_tmux_auto"* ]]; then
+  PROMPT_COMMAND="__mine_tmux_auto${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`
+}
+
+func zshTmuxAuto() string {
+	return `__mine_tmux_auto() {
+  [[ -n "$TMUX" || -n "$__mine_tmux_auto_skip" ]] && return
+  (( ${+commands[tmux]} )) || return
+  mine tmux auto --from "$OLDPWD"
+}
+
+autoload -Uz add-zsh-hook
+add-zsh-hook chpwd __mine_tmux_auto
+`
+}
+
+func fishTmuxAuto() string {
+	return `set -g __mine_tmux_auto_pwd $PWD
+
+function __mine_tmux_auto --on-variable PWD
+  set -l from $__mine_tmux_auto_pwd
+  set -g __mine_tmux_auto_pwd $PWD
+  if set -q TMUX; or set -q __mine_tmux_auto_skip
+    return
+  end
+  status is-interactive; or return
+  command -q tmux; or return
+  mine tmux auto --from "$from"
+end
+`
+}
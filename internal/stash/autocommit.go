@@ -0,0 +1,216 @@
+package stash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// autoCommitSettle is how long an AutoCommitter waits for writes to stop
+// before committing, so an editor's save — often several events — and a
+// burst of edits across files make one snapshot.
+const autoCommitSettle = 2 * time.Second
+
+// autoMessageFiles is how many changed files an automatic commit message
+// names before summarizing the rest.
+const autoMessageFiles = 3
+
+// CommitAuto is Commit with a message generated from the files that changed,
+// e.g. "auto: update ~/.zshrc, ~/.gitconfig". Returns ErrNothingToCommit when
+// nothing did.
+func CommitAuto() (hash, message string, err error) {
+	entries, err := ReadManifest()
+	if err != nil {
+		return "", "", fmt.Errorf("reading manifest: %w", err)
+	}
+	hash, err = commit(func(changed []string) string {
+		message = autoMessage(entries, changed)
+		return message
+	})
+	return hash, message, err
+}
+
+// autoMessage describes a commit of the changed stash paths by the tracked
+// files they belong to.
+func autoMessage(entries []Entry, changed []string) string {
+	home, _ := os.UserHomeDir()
+	var names []string
+	seen := map[string]bool{}
+	for _, path := range changed {
+		name := path
+		for _, e := range entries {
+			if path == e.SafeName || strings.HasPrefix(path, e.SafeName+variantSep) || strings.HasPrefix(path, e.SafeName+"/") {
+				name = strings.Replace(e.Source, home, "~", 1)
+				break
+			}
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) > autoMessageFiles {
+		return fmt.Sprintf("auto: update %s (+%d more)", strings.Join(names[:autoMessageFiles], ", "), len(names)-autoMessageFiles)
+	}
+	return "auto: update " + strings.Join(names, ", ")
+}
+
+// AutoCommitEvent reports one automatic snapshot, or why one failed.
+type AutoCommitEvent struct {
+	Hash    string
+	Message string
+	Err     error
+}
+
+// AutoCommitter watches tracked sources and commits them as they change.
+type AutoCommitter struct {
+	interval time.Duration
+	settle   time.Duration
+	fs       *fsnotify.Watcher
+	entries  []Entry
+}
+
+// NewAutoCommitter starts watching every tracked source. Snapshots are taken
+// once changes settle, but no more than once per interval. Call Run to
+// process changes and Close when done.
+func NewAutoCommitter(interval time.Duration) (*AutoCommitter, error) {
+	if _, err := os.Stat(ManifestPath()); err != nil {
+		return nil, fmt.Errorf("stash not initialized — run `mine stash init` to get started")
+	}
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("starting file watcher: %w", err)
+	}
+	a := &AutoCommitter{interval: interval, settle: autoCommitSettle, fs: fsw}
+	if err := a.reload(); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	return a, nil
+}
+
+// Entries returns the number of tracked entries being watched.
+func (a *AutoCommitter) Entries() int {
+	return len(a.entries)
+}
+
+// Close stops watching.
+func (a *AutoCommitter) Close() error {
+	return a.fs.Close()
+}
+
+// Run commits changes until ctx is done, calling report for each snapshot.
+// Changes made while it wasn't running are committed first. Tracking or
+// untracking files (which rewrites the manifest) updates what's watched.
+func (a *AutoCommitter) Run(ctx context.Context, report func(AutoCommitEvent)) error {
+	var last time.Time
+	snapshot := func() {
+		hash, message, err := CommitAuto()
+		switch {
+		case errors.Is(err, ErrNothingToCommit):
+		case err != nil:
+			report(AutoCommitEvent{Err: err})
+		default:
+			last = time.Now()
+			report(AutoCommitEvent{Hash: hash, Message: message})
+		}
+	}
+	snapshot()
+
+	timer := time.NewTimer(a.settle)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err, ok := <-a.fs.Errors:
+			if !ok {
+				return nil
+			}
+			report(AutoCommitEvent{Err: fmt.Errorf("file watcher: %w", err)})
+
+		case ev, ok := <-a.fs.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Name == ManifestPath() {
+				if err := a.reload(); err != nil {
+					report(AutoCommitEvent{Err: err})
+				}
+				continue
+			}
+			if !a.covers(ev.Name) {
+				continue
+			}
+			// A new directory inside a tracked one needs its own watch.
+			if ev.Has(fsnotify.Create) {
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					a.addTree(ev.Name)
+				}
+			}
+			delay := a.settle
+			if wait := time.Until(last.Add(a.interval)); wait > delay {
+				delay = wait
+			}
+			timer.Reset(delay)
+
+		case <-timer.C:
+			snapshot()
+		}
+	}
+}
+
+// reload rereads the manifest and watches every tracked source.
+func (a *AutoCommitter) reload() error {
+	entries, err := ReadManifest()
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+	a.entries = entries
+
+	// The stash root, for manifest changes.
+	if err := a.fs.Add(Dir()); err != nil {
+		return fmt.Errorf("watching %s: %w", Dir(), err)
+	}
+	for _, e := range entries {
+		// Parent directories catch editors that save by renaming a new
+		// file into place, and sources deleted and recreated.
+		_ = a.fs.Add(filepath.Dir(e.Source))
+		if e.Dir {
+			a.addTree(e.Source)
+		}
+	}
+	return nil
+}
+
+// addTree watches dir and every directory under it, except .git.
+func (a *AutoCommitter) addTree(dir string) {
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		_ = a.fs.Add(path)
+		return nil
+	})
+}
+
+// covers reports whether path is a tracked file or inside a tracked
+// directory.
+func (a *AutoCommitter) covers(path string) bool {
+	for _, e := range a.entries {
+		if path == e.Source || (e.Dir && strings.HasPrefix(path, e.Source+string(filepath.Separator))) {
+			return true
+		}
+	}
+	return false
+}
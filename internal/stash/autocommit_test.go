@@ -0,0 +1,118 @@
+package stash
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCommitAuto_Message(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	if _, err := Commit("initial"); err != nil {
+		t.Fatalf("Commit() error: %v", err)
+	}
+
+	if _, _, err := CommitAuto(); !errors.Is(err, ErrNothingToCommit) {
+		t.Fatalf("CommitAuto() with no changes error = %v, want ErrNothingToCommit", err)
+	}
+
+	if err := os.WriteFile(zshrc, []byte("export A=2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	hash, msg, err := CommitAuto()
+	if err != nil {
+		t.Fatalf("CommitAuto() error: %v", err)
+	}
+	if hash == "" {
+		t.Error("CommitAuto() returned an empty hash")
+	}
+	if msg != "auto: update ~/.zshrc" {
+		t.Errorf("message = %q, want %q", msg, "auto: update ~/.zshrc")
+	}
+}
+
+func TestAutoMessage_Summarizes(t *testing.T) {
+	_, homeDir := setupEnv(t)
+	var entries []Entry
+	var changed []string
+	for _, name := range []string{".a", ".b", ".c", ".d", ".e"} {
+		entries = append(entries, Entry{Source: homeDir + "/" + name, SafeName: name})
+		changed = append(changed, name)
+	}
+	entries = append(entries, Entry{Source: homeDir + "/.config/nvim", SafeName: ".config/nvim", Dir: true})
+	changed = append(changed, ".config/nvim/init.lua", ".config/nvim/lua/plugins.lua")
+
+	want := "auto: update ~/.a, ~/.b, ~/.c (+3 more)"
+	if got := autoMessage(entries, changed); got != want {
+		t.Errorf("autoMessage() = %q, want %q", got, want)
+	}
+	if got := autoMessage(entries, changed[5:]); got != "auto: update ~/.config/nvim" {
+		t.Errorf("autoMessage() for a directory = %q", got)
+	}
+}
+
+func TestAutoCommitter_CommitsChanges(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+
+	a, err := NewAutoCommitter(0)
+	if err != nil {
+		t.Fatalf("NewAutoCommitter() error: %v", err)
+	}
+	defer a.Close()
+	a.settle = 50 * time.Millisecond
+
+	events := make(chan AutoCommitEvent, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- a.Run(ctx, func(ev AutoCommitEvent) { events <- ev }) }()
+
+	next := func() AutoCommitEvent {
+		t.Helper()
+		select {
+		case ev := <-events:
+			if ev.Err != nil {
+				t.Fatalf("event error: %v", ev.Err)
+			}
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for an auto-commit")
+		}
+		return AutoCommitEvent{}
+	}
+
+	// The uncommitted stash is snapshotted on start.
+	next()
+
+	if err := os.WriteFile(zshrc, []byte("export A=2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(); ev.Message != "auto: update ~/.zshrc" {
+		t.Errorf("message = %q", ev.Message)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() error: %v", err)
+	}
+
+	hist, err := Log(zshrc)
+	if err != nil {
+		t.Fatalf("Log() error: %v", err)
+	}
+	if len(hist) != 2 {
+		t.Errorf("got %d commits, want 2", len(hist))
+	}
+}
+
+func TestNewAutoCommitter_NotInitialized(t *testing.T) {
+	setupEnv(t)
+	if _, err := NewAutoCommitter(0); err == nil {
+		t.Error("NewAutoCommitter() without a stash should error")
+	}
+}
@@ -0,0 +1,239 @@
+package stash
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// foreignHome matches the home directories rebaseHome will move an entry
+// out of.
+var foreignHome = regexp.MustCompile(`^(?:/home/[^/]+|/Users/[^/]+|/root)$`)
+
+// rebaseHome moves e.Source, and an absolute e.Link with it, into home when
+// it was recorded under another machine's home directory, so one synced
+// manifest works for users with different names. The old home is found by
+// stripping the home-relative path that e.SafeName encodes; entries that
+// don't fit that shape are left alone.
+func rebaseHome(e *Entry, home string) {
+	if home == "" || strings.HasPrefix(e.Source, home+"/") {
+		return
+	}
+	name := e.SafeName
+	if e.Encrypted {
+		name = strings.TrimSuffix(name, encryptedSuffix)
+	}
+	rel := strings.ReplaceAll(name, "__", "/")
+	oldHome, ok := strings.CutSuffix(e.Source, "/"+rel)
+	if !ok || !foreignHome.MatchString(oldHome) {
+		return
+	}
+	e.Source = filepath.Join(home, filepath.FromSlash(rel))
+	if linked, ok := strings.CutPrefix(e.Link, oldHome+"/"); ok {
+		e.Link = filepath.Join(home, linked)
+	}
+}
+
+// Bootstrap sets up the stash on a new machine by cloning url into the stash
+// directory, then checks every manifest entry is safe to restore. It refuses
+// to touch an existing stash, and removes the clone again if the manifest
+// doesn't pass. Returns the entries to restore.
+func Bootstrap(url string) ([]Entry, error) {
+	dir := Dir()
+	if err := checkNoStash(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating stash directory: %w", err)
+	}
+	if _, err := gitCmd(dir, "clone", url, "."); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("cloning %s: %w", url, err)
+	}
+	if err := configureGitIdentity(dir); err != nil {
+		return nil, err
+	}
+
+	entries, err := ValidateManifest()
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// checkNoStash errors unless dir is missing or holds nothing but the empty
+// manifest mine stash init writes, which it removes to make way for a clone.
+func checkNoStash(dir string) error {
+	exists := fmt.Errorf("a stash already exists at %s — use `mine stash sync pull` to update it", dir)
+	if IsGitRepo() {
+		return exists
+	}
+	entries, err := ReadManifest()
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return exists
+	}
+	if err := os.Remove(ManifestPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(files) > 0 {
+		return fmt.Errorf("%s isn't empty — move its contents aside before bootstrapping", dir)
+	}
+	return nil
+}
+
+// ValidateManifest reads the manifest and checks every entry against the
+// same rules Commit and SyncPull enforce, reporting all the bad ones at once.
+func ValidateManifest() ([]Entry, error) {
+	entries, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	if entries == nil {
+		return nil, fmt.Errorf("no stash manifest (.mine-stash) in the repository — is it a mine stash?")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+	var problems []error
+	for _, e := range entries {
+		if err := validateEntryWithHome(e, home); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", e.Source, err))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid manifest entries:\n%w", errors.Join(problems...))
+	}
+	return entries, nil
+}
+
+// SourceConflict reports whether applying e would overwrite a source that
+// exists and differs from the stash — the restore would lose local content.
+func SourceConflict(e Entry) (bool, error) {
+	if e.Dir {
+		files, err := stashedFiles(e)
+		if err != nil {
+			return false, err
+		}
+		for _, rel := range files {
+			stored, err := os.ReadFile(filepath.Join(Dir(), e.SafeName, filepath.FromSlash(rel)))
+			if err != nil {
+				return false, err
+			}
+			if differs(filepath.Join(e.Source, filepath.FromSlash(rel)), stored) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	stored, err := ReadStashed(e)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return differs(e.Source, stored), nil
+}
+
+// differs reports whether the file at path exists with content other than
+// stored would be once its placeholders are filled.
+func differs(path string, stored []byte) bool {
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return string(unscrub(stored, current)) != string(current)
+}
+
+// BackupSource copies e's source, file or directory, to <source>.pre-stash
+// so a restore can overwrite it. Returns the backup path.
+func BackupSource(e Entry) (string, error) {
+	backup := e.Source + ".pre-stash"
+	if _, err := os.Lstat(backup); err == nil {
+		return "", fmt.Errorf("backup %s already exists", backup)
+	}
+	err := filepath.WalkDir(e.Source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(e.Source, p)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(backup, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(dst, info.Mode().Perm()|0o700)
+		case d.Type().IsRegular():
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(dst, data, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, dst)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("backing up %s: %w", e.Source, err)
+	}
+	return backup, nil
+}
+
+// ApplyEntry writes e's stash copy to its source path, as Apply does.
+func ApplyEntry(e Entry) error {
+	return applyEntries([]Entry{e})
+}
+
+// ConflictAction says how to settle a source that exists and differs from
+// the stash when restoring it.
+type ConflictAction string
+
+const (
+	ConflictOverwrite ConflictAction = "overwrite"
+	ConflictBackup    ConflictAction = "backup"
+	ConflictSkip      ConflictAction = "skip"
+)
+
+// Settle restores e per action: ConflictBackup copies the source aside with
+// BackupSource first, and ConflictSkip leaves it alone. Returns the backup
+// path, even if the restore then fails, or "" when nothing was backed up.
+func Settle(e Entry, action ConflictAction) (string, error) {
+	var backup string
+	switch action {
+	case ConflictSkip:
+		return "", nil
+	case ConflictBackup:
+		var err error
+		if backup, err = BackupSource(e); err != nil {
+			return "", err
+		}
+	case ConflictOverwrite:
+	default:
+		return "", fmt.Errorf("unknown conflict action %q — use %s, %s, or %s", action, ConflictOverwrite, ConflictBackup, ConflictSkip)
+	}
+	return backup, ApplyEntry(e)
+}
@@ -0,0 +1,157 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupRemote commits a stash tracking ~/.zshrc and ~/.gitconfig, then
+// points the stash directory somewhere new, as on a fresh machine. Returns
+// the old stash directory to clone from.
+func setupRemote(t *testing.T) (remote, homeDir string) {
+	t.Helper()
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export EDITOR=vim")
+	gitconfig := createTestFile(t, homeDir, ".gitconfig", "[user]\n\tname = me")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "")
+	f, err := os.OpenFile(ManifestPath(), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(gitconfig + " -> .gitconfig\n")
+	f.Close()
+	if _, err := Commit("machine one"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_DATA_HOME", filepath.Join(t.TempDir(), "data"))
+	return stashDir, homeDir
+}
+
+func TestBootstrap(t *testing.T) {
+	remote, homeDir := setupRemote(t)
+	os.Remove(filepath.Join(homeDir, ".zshrc"))
+	if err := os.WriteFile(filepath.Join(homeDir, ".gitconfig"), []byte("[user]\n\tname = local"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Bootstrap(remote)
+	if err != nil {
+		t.Fatalf("Bootstrap() error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Bootstrap() = %d entries, want 2", len(entries))
+	}
+	if !IsGitRepo() {
+		t.Error("stash directory is not a git repo after bootstrap")
+	}
+
+	for _, e := range entries {
+		conflict, err := SourceConflict(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Base(e.Source) == ".gitconfig"; conflict != want {
+			t.Errorf("SourceConflict(%s) = %v, want %v", e.Source, conflict, want)
+		}
+		if conflict {
+			backup, err := BackupSource(e)
+			if err != nil {
+				t.Fatalf("BackupSource() error: %v", err)
+			}
+			if got, _ := os.ReadFile(backup); !strings.Contains(string(got), "local") {
+				t.Errorf("backup = %q, want the local content", got)
+			}
+		}
+		if err := ApplyEntry(e); err != nil {
+			t.Fatalf("ApplyEntry(%s) error: %v", e.Source, err)
+		}
+	}
+	if got, _ := os.ReadFile(filepath.Join(homeDir, ".zshrc")); string(got) != "export EDITOR=vim" {
+		t.Errorf(".zshrc = %q", got)
+	}
+	if got, _ := os.ReadFile(filepath.Join(homeDir, ".gitconfig")); !strings.Contains(string(got), "name = me") {
+		t.Errorf(".gitconfig = %q, want the stashed content", got)
+	}
+}
+
+func TestBootstrap_ExistingStash(t *testing.T) {
+	remote, homeDir := setupRemote(t)
+	source := createTestFile(t, homeDir, ".vimrc", "set nu")
+	setupManifest(t, Dir(), source, ".vimrc", "set nu")
+
+	if _, err := Bootstrap(remote); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Bootstrap() over a stash = %v, want already exists error", err)
+	}
+}
+
+func TestBootstrap_InvalidManifest(t *testing.T) {
+	remote, _ := setupRemote(t)
+	manifest := filepath.Join(remote, ".mine-stash")
+	if err := os.WriteFile(manifest, []byte("/etc/passwd -> passwd\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gitCmd(remote, "commit", "-am", "poison"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Bootstrap(remote); err == nil || !strings.Contains(err.Error(), "escapes home directory") {
+		t.Errorf("Bootstrap() = %v, want invalid manifest error", err)
+	}
+	if _, err := os.Stat(Dir()); !os.IsNotExist(err) {
+		t.Error("rejected clone was left in place")
+	}
+}
+
+func TestRebaseHome(t *testing.T) {
+	tests := []struct {
+		source, safeName, want string
+		encrypted              bool
+	}{
+		{"/Users/alice/.config/nvim", ".config__nvim", "/home/bob/.config/nvim", false},
+		{"/home/alice/.netrc", ".netrc.age", "/home/bob/.netrc", true},
+		{"/home/alice/notes.age", "notes.age", "/home/bob/notes.age", false},
+		{"/root/.zshrc", ".zshrc", "/home/bob/.zshrc", false},
+		{"/home/bob/.zshrc", ".zshrc", "/home/bob/.zshrc", false},
+		{"/etc/passwd", "passwd", "/etc/passwd", false},
+		{"/home/alice/.zshrc", "zshrc-renamed", "/home/alice/.zshrc", false},
+	}
+	for _, tt := range tests {
+		e := Entry{Source: tt.source, SafeName: tt.safeName, Encrypted: tt.encrypted}
+		rebaseHome(&e, "/home/bob")
+		if e.Source != tt.want {
+			t.Errorf("rebaseHome(%s, %s) = %s, want %s", tt.source, tt.safeName, e.Source, tt.want)
+		}
+	}
+}
+
+func TestSettle(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "local")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "stashed")
+	e := Entry{Source: zshrc, SafeName: ".zshrc"}
+
+	if backup, err := Settle(e, ConflictSkip); err != nil || backup != "" {
+		t.Fatalf("Settle(skip) = %q, %v", backup, err)
+	}
+	if got := readHome(t, homeDir, ".zshrc"); got != "local" {
+		t.Errorf("skip changed .zshrc to %q", got)
+	}
+
+	backup, err := Settle(e, ConflictBackup)
+	if err != nil {
+		t.Fatalf("Settle(backup) error: %v", err)
+	}
+	if data, _ := os.ReadFile(backup); string(data) != "local" {
+		t.Errorf("backup = %q, want local", data)
+	}
+	if got := readHome(t, homeDir, ".zshrc"); got != "stashed" {
+		t.Errorf(".zshrc = %q, want stashed", got)
+	}
+
+	if _, err := Settle(e, "clobber"); err == nil {
+		t.Error("Settle() should reject an unknown action")
+	}
+}
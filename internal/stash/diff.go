@@ -0,0 +1,55 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Change is a tracked file whose source no longer matches its stash copy.
+type Change struct {
+	Path    string // the source file, or a file inside a tracked directory
+	Missing bool   // the source is gone
+}
+
+// Changes compares each entry's source with its stash copy and returns the
+// files that differ. Sources are compared in their stashed form, so scrubbed
+// secrets don't count as changes. Files not stashed yet are skipped.
+func Changes(entries []Entry) ([]Change, error) {
+	var changes []Change
+	for _, e := range entries {
+		if e.Dir {
+			if _, err := os.Stat(e.Source); err != nil {
+				changes = append(changes, Change{Path: e.Source, Missing: true})
+				continue
+			}
+			changed, err := DirDiff(e)
+			if err != nil {
+				return nil, err
+			}
+			for _, rel := range changed {
+				changes = append(changes, Change{Path: filepath.Join(e.Source, filepath.FromSlash(rel))})
+			}
+			continue
+		}
+
+		sourceData, err := os.ReadFile(e.Source)
+		if err != nil {
+			changes = append(changes, Change{Path: e.Source, Missing: true})
+			continue
+		}
+		stashedData, err := ReadStashed(e)
+		if err != nil {
+			if e.Encrypted && !os.IsNotExist(err) {
+				return nil, err
+			}
+			continue
+		}
+		if !e.Encrypted {
+			sourceData = StashedForm(sourceData)
+		}
+		if string(sourceData) != string(stashedData) {
+			changes = append(changes, Change{Path: e.Source})
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,68 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChanges(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	bashrc := createTestFile(t, homeDir, ".bashrc", "alias ll='ls -l'")
+	if _, err := TrackFile(bashrc); err != nil {
+		t.Fatal(err)
+	}
+	gone := createTestFile(t, homeDir, ".inputrc", "set bell-style none")
+	if _, err := TrackFile(gone); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes, err := Changes(entries); err != nil || len(changes) != 0 {
+		t.Fatalf("Changes() = %+v, %v; want none", changes, err)
+	}
+
+	if err := os.WriteFile(bashrc, []byte("alias ll='ls -la'"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(gone); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := Changes(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{bashrc: false, gone: true}
+	if len(changes) != len(want) {
+		t.Fatalf("Changes() = %+v, want %d", changes, len(want))
+	}
+	for _, c := range changes {
+		if missing, ok := want[c.Path]; !ok || missing != c.Missing {
+			t.Errorf("unexpected change %+v", c)
+		}
+	}
+}
+
+func TestChanges_Dir(t *testing.T) {
+	_, homeDir := setupEnv(t)
+	createTestFile(t, homeDir, ".config/nvim/init.lua", "vim.o.number = true")
+	dir := filepath.Join(homeDir, ".config", "nvim")
+	entry, err := TrackDir(dir, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createTestFile(t, homeDir, ".config/nvim/init.lua", "vim.o.number = false")
+	changes, err := Changes([]Entry{*entry})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Path != filepath.Join(dir, "init.lua") || changes[0].Missing {
+		t.Errorf("Changes() = %+v, want init.lua modified", changes)
+	}
+}
@@ -0,0 +1,455 @@
+package stash
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Manifest lines for directories mark the safe name with a trailing "/" and
+// may carry glob patterns after it. File lines record the mode, and the link
+// target of a symlinked source, as of the last commit, and are flagged when
+// the stash copy is encrypted. Any entry may end with a command to run once
+// it's restored, which takes the rest of the line:
+//
+//	/home/me/.config/nvim -> config__nvim/ | include=*.lua,*.vim | exclude=lazy-lock.json
+//	/home/me/bin/hello -> bin__hello | mode=0755
+//	/home/me/.netrc -> .netrc.age | encrypted | mode=0600
+//	/home/me/.zshrc -> .zshrc | mode=0644 | link=dotfiles/zshrc
+//	/home/me/.tmux.conf -> .tmux.conf | on-restore=tmux source-file ~/.tmux.conf
+const (
+	manifestFieldSep = " | "
+	includeField     = "include="
+	excludeField     = "exclude="
+	modeField        = "mode="
+	linkField        = "link="
+	encryptedField   = "encrypted"
+	onRestoreField   = "on-restore="
+)
+
+// parseManifestTarget fills in e from the part of a manifest line after " -> ".
+func parseManifestTarget(e *Entry, target string) {
+	if before, command, ok := strings.Cut(target, manifestFieldSep+onRestoreField); ok {
+		target, e.OnRestore = before, strings.TrimSpace(command)
+	}
+	fields := strings.Split(target, manifestFieldSep)
+	e.SafeName = fields[0]
+	if strings.HasSuffix(e.SafeName, "/") {
+		e.Dir = true
+		e.SafeName = strings.TrimSuffix(e.SafeName, "/")
+	}
+	for _, f := range fields[1:] {
+		switch {
+		case strings.HasPrefix(f, includeField):
+			e.Include = splitPatterns(strings.TrimPrefix(f, includeField))
+		case strings.HasPrefix(f, excludeField):
+			e.Exclude = splitPatterns(strings.TrimPrefix(f, excludeField))
+		case strings.HasPrefix(f, modeField):
+			// Only permission bits: a synced manifest mustn't hand out setuid.
+			if m, err := strconv.ParseUint(strings.TrimPrefix(f, modeField), 8, 32); err == nil {
+				e.Mode = os.FileMode(m).Perm()
+			}
+		case strings.HasPrefix(f, linkField):
+			e.Link = strings.TrimPrefix(f, linkField)
+		case f == encryptedField:
+			e.Encrypted = !e.Dir
+		}
+	}
+}
+
+func splitPatterns(s string) []string {
+	var patterns []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// manifestLine formats e as a manifest line, without the newline.
+func manifestLine(e Entry) string {
+	line := e.Source + " -> " + e.SafeName
+	if e.Dir {
+		line += "/"
+		if len(e.Include) > 0 {
+			line += manifestFieldSep + includeField + strings.Join(e.Include, ",")
+		}
+		if len(e.Exclude) > 0 {
+			line += manifestFieldSep + excludeField + strings.Join(e.Exclude, ",")
+		}
+	} else {
+		if e.Encrypted {
+			line += manifestFieldSep + encryptedField
+		}
+		if e.Mode != 0 {
+			line += manifestFieldSep + fmt.Sprintf("%s%04o", modeField, e.Mode)
+		}
+		if e.Link != "" {
+			line += manifestFieldSep + linkField + e.Link
+		}
+	}
+	if e.OnRestore != "" {
+		line += manifestFieldSep + onRestoreField + e.OnRestore
+	}
+	return line
+}
+
+// ValidatePatterns reports the first malformed glob pattern.
+func ValidatePatterns(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		if strings.Contains(p, manifestFieldSep) || strings.Contains(p, ",") {
+			return fmt.Errorf("invalid pattern %q: patterns can't contain \",\" or \" | \"", p)
+		}
+	}
+	return nil
+}
+
+// matchesAny reports whether rel, a slash-separated path inside a tracked
+// directory, matches one of patterns. A pattern with no "/" matches the
+// base name at any depth, like a .gitignore entry.
+func matchesAny(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		target := rel
+		if !strings.Contains(p, "/") {
+			target = path.Base(rel)
+		}
+		if ok, _ := path.Match(p, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dirFiles returns the files under e.Source that e tracks, as sorted
+// slash-separated relative paths. Excluded and ignored directories (see
+// IgnoreFile) aren't descended into; .git directories and anything but
+// regular files are always skipped. A missing source yields no files.
+func dirFiles(e Entry) ([]string, error) {
+	var files []string
+	ignores := ignoreRules{}
+	err := filepath.WalkDir(e.Source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == e.Source {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if p == e.Source {
+			ignores.load(p, "")
+			return nil
+		}
+		rel, err := filepath.Rel(e.Source, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if d.Name() == ".git" || matchesAny(e.Exclude, rel) || ignores.ignored(rel, true) {
+				return fs.SkipDir
+			}
+			ignores.load(p, rel)
+			return nil
+		}
+		if !d.Type().IsRegular() || matchesAny(e.Exclude, rel) || ignores.ignored(rel, false) {
+			return nil
+		}
+		if len(e.Include) > 0 && !matchesAny(e.Include, rel) {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", e.Source, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// stashedFiles returns the files in a directory entry's stash copy, as
+// sorted slash-separated relative paths.
+func stashedFiles(e Entry) ([]string, error) {
+	root := filepath.Join(Dir(), e.SafeName)
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == root {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// refreshDir brings a directory entry's stash copy in line with its source:
+// tracked files are copied in, and files no longer tracked — deleted,
+// excluded, or the whole source gone — are removed so the commit records it.
+func refreshDir(e Entry) error {
+	files, err := dirFiles(e)
+	if err != nil {
+		return err
+	}
+	root := filepath.Join(Dir(), e.SafeName)
+	keep := make(map[string]bool, len(files))
+	for _, rel := range files {
+		keep[rel] = true
+		src := filepath.Join(e.Source, filepath.FromSlash(rel))
+		dst := filepath.Join(root, filepath.FromSlash(rel))
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return fmt.Errorf("reading source %s: %w", src, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := writeStashCopy(dst, scrubForStash(src, data), src); err != nil {
+			return fmt.Errorf("copying %s: %w", rel, err)
+		}
+	}
+
+	stale, err := stashedFiles(e)
+	if err != nil {
+		return err
+	}
+	for _, rel := range stale {
+		if keep[rel] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(root, filepath.FromSlash(rel))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing %s from stash: %w", rel, err)
+		}
+	}
+	return removeEmptyDirs(root)
+}
+
+// removeEmptyDirs removes directories under root, root included, that hold
+// no files. Git doesn't track empty directories, so they'd only linger.
+func removeEmptyDirs(root string) error {
+	var dirs []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == root {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			dirs = append(dirs, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Deepest first, so a parent is empty once its children are gone.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			if err := os.Remove(dirs[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DirDiff lists the files of a tracked directory that differ from its stash
+// copy — changed, new, or deleted — as slash-separated relative paths.
+func DirDiff(e Entry) ([]string, error) {
+	files, err := dirFiles(e)
+	if err != nil {
+		return nil, err
+	}
+	stashed, err := stashedFiles(e)
+	if err != nil {
+		return nil, err
+	}
+	inStash := make(map[string]bool, len(stashed))
+	for _, rel := range stashed {
+		inStash[rel] = true
+	}
+
+	var changed []string
+	for _, rel := range files {
+		if !inStash[rel] {
+			changed = append(changed, rel)
+			continue
+		}
+		delete(inStash, rel)
+		src, err := os.ReadFile(filepath.Join(e.Source, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+		dst, err := os.ReadFile(filepath.Join(Dir(), e.SafeName, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(StashedForm(src), dst) {
+			changed = append(changed, rel)
+		}
+	}
+	for rel := range inStash {
+		changed = append(changed, rel)
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// storedDirVersion returns the files of a directory entry as committed at
+// version (HEAD when empty), keyed by slash-separated relative path.
+func storedDirVersion(e Entry, file, version string) (map[string][]byte, error) {
+	if !IsGitRepo() {
+		return nil, fmt.Errorf("no version history yet — run `mine stash commit` first")
+	}
+	if version == "" {
+		version = "HEAD"
+	}
+
+	dir := Dir()
+	out, err := gitCmd(dir, "ls-tree", "-r", "--name-only", version, "--", e.SafeName+"/")
+	if err != nil || strings.TrimSpace(out) == "" {
+		return nil, fmt.Errorf("version %s not found for %s", version, file)
+	}
+	files := make(map[string][]byte)
+	for _, name := range strings.Split(strings.TrimSpace(out), "\n") {
+		rel := strings.TrimPrefix(name, e.SafeName+"/")
+		// The tree may come from a pulled remote; never write outside the
+		// tracked directory.
+		if !filepath.IsLocal(filepath.FromSlash(rel)) {
+			return nil, fmt.Errorf("unsafe path %q in stash history", name)
+		}
+		content, err := gitCmd(dir, "show", version+":"+name)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s at %s: %w", name, version, err)
+		}
+		files[rel] = []byte(content)
+	}
+	return files, nil
+}
+
+// restoreDirToSource writes a directory entry's files at version back under
+// its source, with the same permission rules as RestoreToSource. Files in the
+// source that the snapshot doesn't have are left alone.
+func restoreDirToSource(e Entry, file, version string, force bool) error {
+	files, err := storedDirVersion(e, file, version)
+	if err != nil {
+		return err
+	}
+	root := filepath.Join(Dir(), e.SafeName)
+	rels := make([]string, 0, len(files))
+	for rel := range files {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+	for _, rel := range rels {
+		src := filepath.Join(e.Source, filepath.FromSlash(rel))
+		dst := filepath.Join(root, filepath.FromSlash(rel))
+		if err := writeRestored(src, dst, files[rel], force); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRestored writes content to a restored source file and its stash copy
+// at dst. The source keeps its current permissions; a new file, or any file
+// when force is set, takes the stash copy's, whose executable bit git keeps.
+func writeRestored(src, dst string, content []byte, force bool) error {
+	restored := content
+	if current, err := os.ReadFile(src); err == nil {
+		restored = unscrub(content, current)
+	}
+	srcPerm := os.FileMode(0o644)
+	if info, err := os.Stat(dst); err == nil {
+		srcPerm = info.Mode().Perm()
+	}
+	if !force {
+		if info, err := os.Stat(src); err == nil {
+			srcPerm = info.Mode().Perm()
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("stat source %s: %w", src, err)
+		}
+	}
+	if err := os.Remove(src); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing existing %s before restore: %w", src, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(src), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(src, restored, srcPerm); err != nil {
+		return fmt.Errorf("writing to %s: %w", src, err)
+	}
+
+	stashPerm := os.FileMode(0o600)
+	if info, err := os.Stat(dst); err == nil {
+		stashPerm = info.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(dst, content, stashPerm); err != nil {
+		return fmt.Errorf("updating stash copy: %w", err)
+	}
+	return nil
+}
+
+// applyDir writes every file in a directory entry's stash copy under its
+// source, keeping the mode of source files that already exist; new ones take
+// the stash copy's. Reports whether any file's content changed.
+func applyDir(e Entry) (bool, error) {
+	files, err := stashedFiles(e)
+	if err != nil {
+		return false, err
+	}
+	changed := false
+	for _, rel := range files {
+		stashed := filepath.Join(Dir(), e.SafeName, filepath.FromSlash(rel))
+		data, err := os.ReadFile(stashed)
+		if err != nil {
+			return false, fmt.Errorf("reading stash file %s/%s: %w", e.SafeName, rel, err)
+		}
+		src := filepath.Join(e.Source, filepath.FromSlash(rel))
+		current, err := os.ReadFile(src)
+		if err == nil {
+			data = unscrub(data, current)
+		}
+		changed = changed || err != nil || !bytes.Equal(current, data)
+		mode := os.FileMode(0o644)
+		if info, err := os.Stat(stashed); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if info, err := os.Stat(src); err == nil {
+			mode = info.Mode().Perm()
+			if err := os.Remove(src); err != nil && !os.IsNotExist(err) {
+				return false, fmt.Errorf("removing existing %s before restore: %w", src, err)
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(src), 0o755); err != nil {
+			return false, err
+		}
+		if err := os.WriteFile(src, data, mode); err != nil {
+			return false, fmt.Errorf("restoring %s: %w", src, err)
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,178 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// setupDirEnv creates ~/.config/nvim with a few files and an initialized
+// stash directory.
+func setupDirEnv(t *testing.T) (stashDir, source string) {
+	t.Helper()
+	stashDir, homeDir := setupEnv(t)
+	if err := os.MkdirAll(stashDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	createTestFile(t, homeDir, ".config/nvim/init.lua", "require('core')")
+	createTestFile(t, homeDir, ".config/nvim/lua/core.lua", "-- core")
+	createTestFile(t, homeDir, ".config/nvim/lazy-lock.json", "{}")
+	createTestFile(t, homeDir, ".config/nvim/.git/HEAD", "ref: refs/heads/main")
+	createTestFile(t, homeDir, ".config/nvim/cache/state.lua", "-- cache")
+	return stashDir, filepath.Join(homeDir, ".config", "nvim")
+}
+
+func TestTrackDir(t *testing.T) {
+	stashDir, source := setupDirEnv(t)
+
+	entry, err := TrackDir(source, []string{"*.lua"}, []string{"cache"})
+	if err != nil {
+		t.Fatalf("TrackDir() error: %v", err)
+	}
+	if !entry.Dir || entry.SafeName != ".config__nvim" {
+		t.Errorf("entry = %+v, want directory .config__nvim", entry)
+	}
+
+	files, err := stashedFiles(*entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"init.lua", "lua/core.lua"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("stashed files = %v, want %v", files, want)
+	}
+	if got, _ := os.ReadFile(filepath.Join(stashDir, ".config__nvim", "lua", "core.lua")); string(got) != "-- core" {
+		t.Errorf("stashed core.lua = %q", got)
+	}
+
+	entries, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !reflect.DeepEqual(entries[0], *entry) {
+		t.Errorf("ReadManifest() = %+v, want [%+v]", entries, *entry)
+	}
+}
+
+func TestTrackDir_ReplacesPatterns(t *testing.T) {
+	_, source := setupDirEnv(t)
+	if _, err := TrackDir(source, []string{"*.lua"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := TrackDir(source, nil, []string{"cache", "lazy-lock.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, _ := ReadManifest()
+	if len(entries) != 1 || entries[0].Include != nil || len(entries[0].Exclude) != 2 {
+		t.Errorf("ReadManifest() = %+v, want one entry with the new patterns", entries)
+	}
+	files, _ := stashedFiles(*entry)
+	if want := []string{"init.lua", "lua/core.lua"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("stashed files = %v, want %v", files, want)
+	}
+}
+
+func TestTrackDir_InvalidPattern(t *testing.T) {
+	_, source := setupDirEnv(t)
+	if _, err := TrackDir(source, []string{"[lua"}, nil); err == nil || !strings.Contains(err.Error(), "invalid pattern") {
+		t.Errorf("TrackDir([lua) = %v, want invalid pattern error", err)
+	}
+}
+
+func TestTrackFile_Directory(t *testing.T) {
+	_, source := setupDirEnv(t)
+	entry, err := TrackFile(source)
+	if err != nil {
+		t.Fatalf("TrackFile(dir) error: %v", err)
+	}
+	if !entry.Dir {
+		t.Errorf("TrackFile(dir) = %+v, want a directory entry", entry)
+	}
+	if _, err := TrackFileEncrypted(source); err == nil {
+		t.Error("TrackFileEncrypted(dir) should fail")
+	}
+}
+
+func TestCommitDir(t *testing.T) {
+	stashDir, source := setupDirEnv(t)
+	entry, err := TrackDir(source, nil, []string{"cache"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("v1"); err != nil {
+		t.Fatalf("Commit() error: %v", err)
+	}
+
+	// Edit one file, add one, delete one.
+	if err := os.WriteFile(filepath.Join(source, "init.lua"), []byte("require('v2')"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(source, "lua", "new.lua"), []byte("-- new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(source, "lazy-lock.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := DirDiff(*entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"init.lua", "lazy-lock.json", "lua/new.lua"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("DirDiff() = %v, want %v", changed, want)
+	}
+
+	if _, err := Commit("v2"); err != nil {
+		t.Fatalf("second Commit() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(stashDir, ".config__nvim", "lazy-lock.json")); !os.IsNotExist(err) {
+		t.Error("deleted file still in stash copy")
+	}
+	if changed, _ := DirDiff(*entry); len(changed) != 0 {
+		t.Errorf("DirDiff() after commit = %v, want none", changed)
+	}
+	if logs, _ := Log("~/.config/nvim/"); len(logs) != 2 {
+		t.Errorf("Log(dir) = %d entries, want 2", len(logs))
+	}
+
+	// Restore v1: edited and deleted files come back, new ones stay.
+	logs, _ := Log("")
+	if _, err := RestoreToSource(source, logs[1].Short, false); err != nil {
+		t.Fatalf("RestoreToSource(dir) error: %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(source, "init.lua")); string(got) != "require('core')" {
+		t.Errorf("restored init.lua = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(source, "lazy-lock.json")); err != nil {
+		t.Errorf("lazy-lock.json not restored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(source, "lua", "new.lua")); err != nil {
+		t.Errorf("restore removed a file the snapshot didn't have: %v", err)
+	}
+	if _, err := Restore(source, ""); err == nil {
+		t.Error("Restore(dir) should fail")
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		rel      string
+		want     bool
+	}{
+		{[]string{"*.lua"}, "init.lua", true},
+		{[]string{"*.lua"}, "lua/core.lua", true},
+		{[]string{"lua/*.lua"}, "lua/core.lua", true},
+		{[]string{"lua/*.lua"}, "init.lua", false},
+		{[]string{"cache"}, "a/cache", true},
+		{nil, "init.lua", false},
+	}
+	for _, tt := range tests {
+		if got := matchesAny(tt.patterns, tt.rel); got != tt.want {
+			t.Errorf("matchesAny(%v, %q) = %v, want %v", tt.patterns, tt.rel, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package stash
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// encryptedSuffix marks the stash copy of a file tracked with encryption.
+const encryptedSuffix = ".age"
+
+// PassphraseFunc supplies the vault passphrase when an encrypted file has to
+// be read or written. The command layer sets it; while it's nil, encrypted
+// files can't be tracked, committed, or restored.
+var PassphraseFunc func() (string, error)
+
+// passphrase asks PassphraseFunc at most once per operation, so a commit
+// touching several encrypted files prompts only once.
+type passphrase struct {
+	value string
+	err   error
+	asked bool
+}
+
+func (p *passphrase) get() (string, error) {
+	if !p.asked {
+		p.asked = true
+		if PassphraseFunc == nil {
+			p.err = errors.New("encrypted files need the vault passphrase, but none is available")
+		} else {
+			p.value, p.err = PassphraseFunc()
+		}
+	}
+	return p.value, p.err
+}
+
+// encrypt encrypts plaintext with age, using a key derived from pass by
+// scrypt — the same scheme as the vault. The result is ASCII-armored so the
+// stash repo stays text.
+func encrypt(plaintext []byte, pass string) ([]byte, error) {
+	recipient, err := age.NewScryptRecipient(pass)
+	if err != nil {
+		return nil, fmt.Errorf("creating age recipient: %w", err)
+	}
+	var buf bytes.Buffer
+	armorWriter := armor.NewWriter(&buf)
+	w, err := age.Encrypt(armorWriter, recipient)
+	if err != nil {
+		return nil, fmt.Errorf("initializing age encryption: %w", err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, fmt.Errorf("encrypting: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("finalizing encryption: %w", err)
+	}
+	if err := armorWriter.Close(); err != nil {
+		return nil, fmt.Errorf("finalizing armor: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decrypt reverses encrypt.
+func decrypt(ciphertext []byte, pass string) ([]byte, error) {
+	identity, err := age.NewScryptIdentity(pass)
+	if err != nil {
+		return nil, fmt.Errorf("creating age identity: %w", err)
+	}
+	r, err := age.Decrypt(armor.NewReader(bytes.NewReader(ciphertext)), identity)
+	if err != nil {
+		// See vault.decryptData: age has no typed error for a wrong passphrase.
+		msg := err.Error()
+		if strings.Contains(msg, "no identity matched") || strings.Contains(msg, "incorrect") {
+			return nil, fmt.Errorf("wrong passphrase — encrypted files use the vault passphrase they were tracked with")
+		}
+		return nil, fmt.Errorf("decrypting: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting: %w", err)
+	}
+	return plaintext, nil
+}
+
+// ReadStashed returns the content of e's stash copy — this host's variant if
+// it has one — decrypted when e is encrypted.
+func ReadStashed(e Entry) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(Dir(), stashName(e)))
+	if err != nil || !e.Encrypted {
+		return data, err
+	}
+	pass, err := (&passphrase{}).get()
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(data, pass)
+}
+
+// refreshEncrypted brings the encrypted stash copy at dst up to date with
+// plaintext. Encryption is randomized, so an unchanged file is left alone
+// rather than re-encrypted into a spurious change.
+func refreshEncrypted(dst string, plaintext []byte, mode os.FileMode, pass *passphrase) error {
+	p, err := pass.get()
+	if err != nil {
+		return err
+	}
+	if existing, err := os.ReadFile(dst); err == nil {
+		// A copy that won't decrypt means the wrong passphrase, not a
+		// reason to re-encrypt it under that one.
+		current, err := decrypt(existing, p)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(dst), err)
+		}
+		if bytes.Equal(current, plaintext) {
+			return nil
+		}
+	}
+	data, err := encrypt(plaintext, p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, mode)
+}
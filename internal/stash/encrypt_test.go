@@ -0,0 +1,195 @@
+package stash
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setPassphrase points PassphraseFunc at a fixed passphrase for the test.
+func setPassphrase(t *testing.T, pass string) {
+	t.Helper()
+	prev := PassphraseFunc
+	PassphraseFunc = func() (string, error) { return pass, nil }
+	t.Cleanup(func() { PassphraseFunc = prev })
+}
+
+func TestTrackFileEncrypted(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	setPassphrase(t, "hunter2")
+	if err := os.MkdirAll(stashDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	source := createTestFile(t, homeDir, ".netrc", "machine example.com password s3cret")
+
+	entry, err := TrackFileEncrypted(source)
+	if err != nil {
+		t.Fatalf("TrackFileEncrypted() error: %v", err)
+	}
+	if !entry.Encrypted || !strings.HasSuffix(entry.SafeName, encryptedSuffix) {
+		t.Errorf("entry = %+v, want encrypted with %s suffix", entry, encryptedSuffix)
+	}
+
+	stashed := filepath.Join(stashDir, entry.SafeName)
+	raw, err := os.ReadFile(stashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "s3cret") {
+		t.Error("stash copy contains plaintext")
+	}
+	if info, _ := os.Stat(stashed); info.Mode().Perm() != 0o600 {
+		t.Errorf("stash copy mode = %o, want 600", info.Mode().Perm())
+	}
+
+	entries, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !entries[0].Encrypted {
+		t.Fatalf("ReadManifest() = %+v, want one encrypted entry", entries)
+	}
+	got, err := ReadStashed(entries[0])
+	if err != nil {
+		t.Fatalf("ReadStashed() error: %v", err)
+	}
+	if string(got) != "machine example.com password s3cret" {
+		t.Errorf("ReadStashed() = %q", got)
+	}
+}
+
+func TestTrackFileEncrypted_AlreadyTrackedPlain(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	setPassphrase(t, "hunter2")
+	source := createTestFile(t, homeDir, ".netrc", "secret")
+	setupManifest(t, stashDir, source, ".netrc", "secret")
+
+	if _, err := TrackFileEncrypted(source); err == nil || !strings.Contains(err.Error(), "already tracked unencrypted") {
+		t.Errorf("TrackFileEncrypted() = %v, want already tracked error", err)
+	}
+}
+
+func TestCommitEncrypted(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	setPassphrase(t, "hunter2")
+	if err := os.MkdirAll(stashDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	source := createTestFile(t, homeDir, ".netrc", "version 1")
+	entry, err := TrackFileEncrypted(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("v1"); err != nil {
+		t.Fatalf("Commit() error: %v", err)
+	}
+
+	// Re-encrypting unchanged content would produce different ciphertext.
+	if _, err := Commit("again"); err == nil || !strings.Contains(err.Error(), "nothing to commit") {
+		t.Errorf("second Commit() = %v, want nothing to commit", err)
+	}
+
+	if err := os.WriteFile(source, []byte("version 2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("v2"); err != nil {
+		t.Fatalf("Commit() after change error: %v", err)
+	}
+	raw, err := os.ReadFile(filepath.Join(stashDir, entry.SafeName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "version 2") {
+		t.Error("committed stash copy contains plaintext")
+	}
+
+	logs, err := Log("")
+	if err != nil || len(logs) != 2 {
+		t.Fatalf("Log() = %d entries, %v; want 2", len(logs), err)
+	}
+	if _, err := RestoreToSource(source, logs[1].Short, false); err != nil {
+		t.Fatalf("RestoreToSource() error: %v", err)
+	}
+	if got, _ := os.ReadFile(source); string(got) != "version 1" {
+		t.Errorf("restored source = %q, want %q", got, "version 1")
+	}
+	raw, _ = os.ReadFile(filepath.Join(stashDir, entry.SafeName))
+	if strings.Contains(string(raw), "version 1") {
+		t.Error("restore wrote plaintext into the stash copy")
+	}
+}
+
+func TestCommitEncrypted_WrongPassphrase(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	setPassphrase(t, "hunter2")
+	if err := os.MkdirAll(stashDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	source := createTestFile(t, homeDir, ".netrc", "secret")
+	if _, err := TrackFileEncrypted(source); err != nil {
+		t.Fatal(err)
+	}
+
+	setPassphrase(t, "wrong")
+	if _, err := Commit("snapshot"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("Commit() = %v, want wrong passphrase error", err)
+	}
+}
+
+func TestTrackFile_PlainAgeName(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	prev := PassphraseFunc
+	PassphraseFunc = func() (string, error) {
+		t.Error("asked for a passphrase for a plain file")
+		return "", errors.New("no passphrase")
+	}
+	t.Cleanup(func() { PassphraseFunc = prev })
+	if err := os.MkdirAll(stashDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	source := createTestFile(t, homeDir, "notes.age", "hello\n")
+
+	entry, err := TrackFile(source)
+	if err != nil {
+		t.Fatalf("TrackFile() error: %v", err)
+	}
+	if entry.Encrypted {
+		t.Errorf("entry = %+v, want unencrypted", entry)
+	}
+	if _, err := Commit("v1"); err != nil {
+		t.Fatalf("Commit() error: %v", err)
+	}
+
+	if err := os.WriteFile(source, []byte("changed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RestoreToSource(source, "", true); err != nil {
+		t.Fatalf("RestoreToSource() error: %v", err)
+	}
+	if got, _ := os.ReadFile(source); string(got) != "hello\n" {
+		t.Errorf("restored source = %q, want %q", got, "hello\n")
+	}
+}
+
+func TestParseManifest_EncryptedFlag(t *testing.T) {
+	manifest := `/home/me/.netrc -> .netrc.age | encrypted
+/home/me/.ssh/config -> .ssh__config.age
+/home/me/notes.age -> notes.age
+`
+	entries, err := parseManifest([]byte(manifest), "/home/me")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The second line predates the flag and is still recognized.
+	want := []bool{true, true, false}
+	for i, e := range entries {
+		if e.Encrypted != want[i] {
+			t.Errorf("%s: Encrypted = %v, want %v", e.Source, e.Encrypted, want[i])
+		}
+	}
+	if got := manifestLine(entries[0]); got != "/home/me/.netrc -> .netrc.age | encrypted" {
+		t.Errorf("manifestLine() = %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+package stash
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExportResult reports what Export put in the archive.
+type ExportResult struct {
+	Version string   // short hash of the exported snapshot
+	Files   []string // archive paths, relative to the home directory
+	Skipped []string // sources left out: encrypted, or missing from the snapshot
+}
+
+// Export writes the tracked files as of version (default: the latest
+// snapshot) to a gzipped tarball at output. Paths in the archive are
+// relative to the home directory, so extracting it into one recreates the
+// dotfiles: tar -xzf dotfiles.tar.gz -C ~. Files are as stored — this host's
+// variant where there is one, with scrubbed secrets still redacted — and
+// encrypted files are left out rather than exported decrypted.
+func Export(output, version string) (*ExportResult, error) {
+	if !IsGitRepo() {
+		return nil, fmt.Errorf("no version history yet — run `mine stash commit` first")
+	}
+	if version == "" {
+		version = "HEAD"
+	}
+	dir := Dir()
+	hash, err := gitCmd(dir, "rev-parse", "--verify", "--quiet", version+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("version %s not found", version)
+	}
+	hash = strings.TrimSpace(hash)
+
+	manifest, err := gitCmd(dir, "show", hash+":"+filepath.Base(ManifestPath()))
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s has no manifest", version)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+	entries, err := parseManifest([]byte(manifest), home)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest at %s: %w", version, err)
+	}
+	modes, err := treeModes(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	type archived struct {
+		name    string
+		content []byte
+		mode    int64
+	}
+	var files []archived
+	result := &ExportResult{Version: hash[:7]}
+	for _, e := range entries {
+		// The manifest may come from a pulled remote; never archive paths
+		// that would extract outside the home directory.
+		if err := validateEntryWithHome(e, home); err != nil {
+			return nil, fmt.Errorf("invalid manifest entry for %s: %w", e.Source, err)
+		}
+		rel, err := filepath.Rel(home, e.Source)
+		if err != nil || !filepath.IsLocal(rel) {
+			return nil, fmt.Errorf("invalid manifest entry for %s: not under the home directory", e.Source)
+		}
+		rel = filepath.ToSlash(rel)
+
+		switch {
+		case e.Encrypted:
+			result.Skipped = append(result.Skipped, e.Source)
+		case e.Dir:
+			contents, err := storedDirVersion(e, e.Source, hash)
+			if err != nil {
+				result.Skipped = append(result.Skipped, e.Source)
+				continue
+			}
+			for name, content := range contents {
+				files = append(files, archived{rel + "/" + name, content, modes[e.SafeName+"/"+name]})
+			}
+		default:
+			content, err := storedVersion(e, e.Source, hash)
+			if err != nil {
+				result.Skipped = append(result.Skipped, e.Source)
+				continue
+			}
+			mode, ok := int64(e.Mode), e.Mode != 0
+			if !ok {
+				mode, ok = modes[stashName(e)]
+			}
+			if !ok {
+				mode = modes[e.SafeName]
+			}
+			files = append(files, archived{rel, content, mode})
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("nothing to export at %s", version)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+
+	date, _ := gitCmd(dir, "show", "-s", "--format=%cI", hash)
+	modTime, _ := time.Parse(time.RFC3339, strings.TrimSpace(date))
+
+	f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s: %w", output, err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	writeErr := func() error {
+		for _, file := range files {
+			if file.mode == 0 {
+				file.mode = 0o644
+			}
+			hdr := &tar.Header{
+				Name:    file.name,
+				Mode:    file.mode,
+				Size:    int64(len(file.content)),
+				ModTime: modTime,
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := tw.Write(file.content); err != nil {
+				return err
+			}
+			result.Files = append(result.Files, file.name)
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		return gz.Close()
+	}()
+	if closeErr := f.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		os.Remove(output)
+		return nil, fmt.Errorf("writing %s: %w", output, writeErr)
+	}
+	return result, nil
+}
+
+// treeModes returns the permission bits git recorded for each file in the
+// commit: 0755 for executables, else 0644.
+func treeModes(hash string) (map[string]int64, error) {
+	out, err := gitCmd(Dir(), "ls-tree", "-r", "-z", hash)
+	if err != nil {
+		return nil, fmt.Errorf("listing snapshot %s: %w", hash, err)
+	}
+	modes := map[string]int64{}
+	for _, line := range strings.Split(out, "\x00") {
+		// <mode> <type> <object>\t<path>
+		meta, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		modes[name] = 0o644
+		if strings.HasPrefix(meta, "100755") {
+			modes[name] = 0o755
+		}
+	}
+	return modes, nil
+}
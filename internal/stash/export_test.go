@@ -0,0 +1,131 @@
+package stash
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readArchive returns the files in a tar.gz as name -> header and content.
+func readArchive(t *testing.T, path string) (map[string]*tar.Header, map[string]string) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	headers, contents := map[string]*tar.Header{}, map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		headers[hdr.Name] = hdr
+		contents[hdr.Name] = string(data)
+	}
+	return headers, contents
+}
+
+func TestExport(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "v1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "v1")
+	createTestFile(t, homeDir, ".config/nvim/init.lua", "vim.o.number = true")
+	if _, err := TrackDir(filepath.Join(homeDir, ".config/nvim"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	script := createTestFile(t, homeDir, "bin/hello", "#!/bin/sh")
+	if err := os.Chmod(script, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TrackFile(script); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("v1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(zshrc, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("v2"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "dotfiles.tar.gz")
+	result, err := Export(out, "")
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	assertStrings(t, "files", result.Files, []string{".config/nvim/init.lua", ".zshrc", "bin/hello"})
+	headers, contents := readArchive(t, out)
+	if contents[".zshrc"] != "v2" || contents[".config/nvim/init.lua"] != "vim.o.number = true" {
+		t.Errorf("archive contents = %v", contents)
+	}
+	if mode := headers["bin/hello"].Mode; mode != 0o755 {
+		t.Errorf("bin/hello mode = %o, want 755", mode)
+	}
+	if mode := headers[".zshrc"].Mode; mode != 0o644 {
+		t.Errorf(".zshrc mode = %o, want 644", mode)
+	}
+
+	if _, err := Export(out, "HEAD~1"); err != nil {
+		t.Fatalf("Export(HEAD~1) error: %v", err)
+	}
+	if _, contents := readArchive(t, out); contents[".zshrc"] != "v1" {
+		t.Errorf(".zshrc at HEAD~1 = %q, want v1", contents[".zshrc"])
+	}
+}
+
+func TestExport_SkipsEncrypted(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	setPassphrase(t, "hunter2")
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	netrc := createTestFile(t, homeDir, ".netrc", "password s3cret")
+	if _, err := TrackFileEncrypted(netrc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "dotfiles.tar.gz")
+	result, err := Export(out, "")
+	if err != nil {
+		t.Fatalf("Export() error: %v", err)
+	}
+	assertStrings(t, "files", result.Files, []string{".zshrc"})
+	assertStrings(t, "skipped", result.Skipped, []string{netrc})
+}
+
+func TestExport_UnknownVersion(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "v1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "v1")
+	if _, err := Commit("v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "dotfiles.tar.gz")
+	if _, err := Export(out, "deadbeef"); err == nil {
+		t.Error("Export() of an unknown version should error")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Error("a failed export should not leave an archive behind")
+	}
+}
@@ -0,0 +1,136 @@
+package stash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// writeStashCopy writes data to the stash copy at dst with the mode of src,
+// kept writable by the owner, so git records the source's executable bit.
+func writeStashCopy(dst string, data []byte, src string) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(src); err == nil {
+		mode = info.Mode().Perm() | 0o600
+	}
+	if err := os.WriteFile(dst, data, mode); err != nil {
+		return err
+	}
+	// WriteFile leaves an existing file's mode alone.
+	return os.Chmod(dst, mode)
+}
+
+// recordMeta sets e's Mode and Link from its source as it is now: the
+// permission bits of the file it reads, and the link target when the source
+// is a symlink. Reports whether either changed. A source that can't be read
+// keeps what was recorded.
+func recordMeta(e *Entry) bool {
+	info, err := os.Stat(e.Source)
+	if err != nil {
+		return false
+	}
+	link := ""
+	if target, err := os.Readlink(e.Source); err == nil && representable(target) {
+		link = target
+	}
+	mode := info.Mode().Perm()
+	if e.Mode == mode && e.Link == link {
+		return false
+	}
+	e.Mode, e.Link = mode, link
+	return true
+}
+
+// representable reports whether a link target fits in a manifest field.
+func representable(target string) bool {
+	return target != "" && !strings.Contains(target, manifestFieldSep) && !strings.ContainsAny(target, "\r\n")
+}
+
+// recordedAt returns e with the mode and link recorded in the manifest at
+// version, so a file is restored as it was then. Snapshots from before
+// these were recorded yield neither.
+func recordedAt(e Entry, version string) Entry {
+	if version == "" {
+		return e
+	}
+	data, err := gitCmd(Dir(), "show", version+":"+filepath.Base(ManifestPath()))
+	if err != nil {
+		return e
+	}
+	home, _ := os.UserHomeDir()
+	then, err := parseManifest([]byte(data), home)
+	if err != nil {
+		return e
+	}
+	e.Mode, e.Link = 0, ""
+	for _, t := range then {
+		if t.Source == e.Source && !t.Dir {
+			e.Mode, e.Link = t.Mode, t.Link
+		}
+	}
+	return e
+}
+
+// writeSource writes a restored file's content to e's source with mode,
+// replacing whatever is there. When e records a symlink, the link is put back
+// and the content goes to the file it points at — unless that's outside the
+// home directory, which is left alone.
+func writeSource(e Entry, content []byte, mode os.FileMode) error {
+	path := e.Source
+	if e.Link != "" {
+		target, err := placeLink(e)
+		if err != nil {
+			return err
+		}
+		if target == "" {
+			return nil
+		}
+		path = target
+	}
+	// Removing first lets read-only files (e.g. 0444) be replaced: on most
+	// Unix filesystems the directory's write permission governs deletion,
+	// not the file's own mode bits.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing existing %s before restore: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, mode); err != nil {
+		return fmt.Errorf("writing to %s: %w", path, err)
+	}
+	return nil
+}
+
+// placeLink makes e's source a symlink to e.Link, replacing a file or other
+// link there. Returns the path the link resolves to, or "" when that's
+// outside the home directory.
+func placeLink(e Entry) (string, error) {
+	if current, err := os.Readlink(e.Source); err != nil || current != e.Link {
+		if err := os.Remove(e.Source); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("removing existing %s before restore: %w", e.Source, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(e.Source), 0o755); err != nil {
+			return "", err
+		}
+		if err := os.Symlink(e.Link, e.Source); err != nil {
+			return "", fmt.Errorf("linking %s: %w", e.Source, err)
+		}
+	}
+	target := e.Link
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(e.Source), target)
+	}
+	// The manifest may come from a pulled remote; only write through links
+	// that stay in the home directory.
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("determining home directory: %w", err)
+	}
+	rel, err := filepath.Rel(home, filepath.Clean(target))
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", nil
+	}
+	return target, nil
+}
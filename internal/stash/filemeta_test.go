@@ -0,0 +1,153 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupLinked tracks an executable ~/bin/hello and a ~/.zshrc symlinked to
+// ~/dotfiles/zshrc, and commits them.
+func setupLinked(t *testing.T) (hello, zshrc string) {
+	t.Helper()
+	stashDir, homeDir := setupEnv(t)
+	hello = createTestFile(t, homeDir, "bin/hello", "#!/bin/sh\necho hi")
+	if err := os.Chmod(hello, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	setupManifest(t, stashDir, hello, "bin__hello", "#!/bin/sh\necho hi")
+	createTestFile(t, homeDir, "dotfiles/zshrc", "export A=1")
+	zshrc = filepath.Join(homeDir, ".zshrc")
+	if err := os.Symlink("dotfiles/zshrc", zshrc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TrackFile(zshrc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+	return hello, zshrc
+}
+
+func TestCommit_RecordsModeAndLink(t *testing.T) {
+	hello, zshrc := setupLinked(t)
+
+	entry, err := FindEntry(hello)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Mode != 0o755 || entry.Link != "" {
+		t.Errorf("bin/hello entry = %+v, want mode 0755 and no link", entry)
+	}
+	entry, err = FindEntry(zshrc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Mode != 0o644 || entry.Link != "dotfiles/zshrc" {
+		t.Errorf(".zshrc entry = %+v, want mode 0644 linked to dotfiles/zshrc", entry)
+	}
+
+	// git keeps the executable bit of the stash copy too.
+	tree, err := gitCmd(Dir(), "ls-tree", "HEAD", "bin__hello")
+	if err != nil || !strings.HasPrefix(tree, "100755") {
+		t.Errorf("stash copy in git = %q, %v; want mode 100755", tree, err)
+	}
+
+	// A chmod is picked up by the next commit.
+	if err := os.Chmod(hello, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("chmod"); err != nil {
+		t.Fatalf("Commit() after chmod error: %v", err)
+	}
+	if entry, _ := FindEntry(hello); entry.Mode != 0o700 {
+		t.Errorf("mode after chmod = %04o, want 0700", entry.Mode)
+	}
+}
+
+func TestApply_RestoresModeAndLink(t *testing.T) {
+	hello, zshrc := setupLinked(t)
+	homeDir := filepath.Dir(zshrc)
+	// A new machine: none of the files exist yet.
+	for _, p := range []string{hello, zshrc, filepath.Join(homeDir, "dotfiles")} {
+		if err := os.RemoveAll(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := Apply(""); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	info, err := os.Stat(hello)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o755 {
+		t.Errorf("bin/hello mode = %04o, want 0755", info.Mode().Perm())
+	}
+	if target, err := os.Readlink(zshrc); err != nil || target != "dotfiles/zshrc" {
+		t.Errorf("Readlink(.zshrc) = %q, %v; want dotfiles/zshrc", target, err)
+	}
+	if got := readHome(t, homeDir, "dotfiles/zshrc"); got != "export A=1" {
+		t.Errorf("link target content = %q", got)
+	}
+}
+
+func TestRestoreToSource_RelinksReplacedSymlink(t *testing.T) {
+	_, zshrc := setupLinked(t)
+	homeDir := filepath.Dir(zshrc)
+	// An editor that saves by rename turns the link into a plain file.
+	if err := os.Remove(zshrc); err != nil {
+		t.Fatal(err)
+	}
+	createTestFile(t, homeDir, ".zshrc", "export A=2")
+
+	if _, err := RestoreToSource(zshrc, "", false); err != nil {
+		t.Fatalf("RestoreToSource() error: %v", err)
+	}
+	if target, err := os.Readlink(zshrc); err != nil || target != "dotfiles/zshrc" {
+		t.Errorf("Readlink(.zshrc) = %q, %v; want dotfiles/zshrc", target, err)
+	}
+	if got := readHome(t, homeDir, "dotfiles/zshrc"); got != "export A=1" {
+		t.Errorf("link target content = %q, want export A=1", got)
+	}
+}
+
+func TestWriteSource_LinkOutsideHome(t *testing.T) {
+	_, homeDir := setupEnv(t)
+	outside := filepath.Join(t.TempDir(), "zshrc")
+	e := Entry{Source: filepath.Join(homeDir, ".zshrc"), Link: outside}
+
+	if err := writeSource(e, []byte("export A=1"), 0o644); err != nil {
+		t.Fatalf("writeSource() error: %v", err)
+	}
+	if target, err := os.Readlink(e.Source); err != nil || target != outside {
+		t.Errorf("Readlink(.zshrc) = %q, %v; want %s", target, err, outside)
+	}
+	if _, err := os.Stat(outside); !os.IsNotExist(err) {
+		t.Error("writeSource wrote through a link that leaves the home directory")
+	}
+}
+
+func TestManifestLine_ModeAndLink(t *testing.T) {
+	var e Entry
+	parseManifestTarget(&e, "bin__hello | mode=4755 | link=../dotfiles/hello | on-restore=hash -r")
+	if e.Mode != 0o755 || e.Link != "../dotfiles/hello" || e.OnRestore != "hash -r" {
+		t.Errorf("parsed entry = %+v, want mode 0755 without setuid", e)
+	}
+	e.Source = "/home/me/bin/hello"
+	want := "/home/me/bin/hello -> bin__hello | mode=0755 | link=../dotfiles/hello | on-restore=hash -r"
+	if got := manifestLine(e); got != want {
+		t.Errorf("manifestLine() = %q, want %q", got, want)
+	}
+}
+
+func TestRebaseHome_Link(t *testing.T) {
+	e := Entry{Source: "/home/alice/.zshrc", SafeName: ".zshrc", Link: "/home/alice/dotfiles/zshrc"}
+	rebaseHome(&e, "/home/bob")
+	if e.Link != "/home/bob/dotfiles/zshrc" {
+		t.Errorf("Link = %s, want /home/bob/dotfiles/zshrc", e.Link)
+	}
+}
@@ -0,0 +1,204 @@
+package stash
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// variantSep separates a stash file's name from the host its variant is for,
+// as in .zshrc@work-laptop.
+const variantSep = "@"
+
+// Hostname returns the name host-specific variants are keyed by: the
+// MINE_STASH_HOST environment variable if set, else the lowercased short
+// hostname.
+func Hostname() string {
+	if h := os.Getenv("MINE_STASH_HOST"); h != "" {
+		return h
+	}
+	h, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	short, _, _ := strings.Cut(h, ".")
+	return strings.ToLower(short)
+}
+
+// variantName returns the stash name of e's variant for host.
+func variantName(e Entry, host string) string {
+	return e.SafeName + variantSep + host
+}
+
+// Variant returns the host whose variant of e applies on this machine, or ""
+// when the base file does.
+func Variant(e Entry) string {
+	if e.Dir {
+		return ""
+	}
+	host := Hostname()
+	if host == "" {
+		return ""
+	}
+	name := variantName(e, host)
+	if validateSafeName(name) != nil {
+		return ""
+	}
+	if _, err := os.Stat(filepath.Join(Dir(), name)); err != nil {
+		return ""
+	}
+	return host
+}
+
+// stashName returns the name of the stash file that holds e on this machine:
+// its variant for this host if there is one, else the base file.
+func stashName(e Entry) string {
+	if host := Variant(e); host != "" {
+		return variantName(e, host)
+	}
+	return e.SafeName
+}
+
+// TrackVariant starts tracking source if it isn't already, then stores its
+// current content as this host's variant. From then on this machine commits
+// to and restores from the variant, while other machines keep the base file.
+func TrackVariant(source string) (*Entry, error) {
+	host := Hostname()
+	if host == "" {
+		return nil, fmt.Errorf("can't determine this machine's hostname — set MINE_STASH_HOST")
+	}
+
+	entry, err := FindEntry(source)
+	if err != nil {
+		if entry, err = TrackFile(source); err != nil {
+			return nil, err
+		}
+	}
+	if entry.Dir {
+		return nil, fmt.Errorf("host variants are only supported for files, not directories")
+	}
+
+	name := variantName(*entry, host)
+	if err := validateSafeName(name); err != nil {
+		return nil, fmt.Errorf("hostname %q can't name a variant: %w", host, err)
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", source, err)
+	}
+	dst := filepath.Join(Dir(), name)
+	if entry.Encrypted {
+		err = refreshEncrypted(dst, data, 0o600, &passphrase{})
+	} else {
+		mode := os.FileMode(0o644)
+		if info, statErr := os.Stat(source); statErr == nil {
+			mode = info.Mode()
+		}
+		err = os.WriteFile(dst, scrubForStash(source, data), mode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("writing to stash: %w", err)
+	}
+	return entry, nil
+}
+
+// Apply writes the stash's current copy of each tracked file — this host's
+// variant where there is one — to its source path. With file set, only that
+// entry is applied. Returns the entries applied.
+func Apply(file string) ([]Entry, error) {
+	var entries []Entry
+	if file != "" {
+		entry, err := FindEntry(file)
+		if err != nil {
+			return nil, err
+		}
+		entries = []Entry{*entry}
+	} else {
+		var err error
+		if entries, err = ReadManifest(); err != nil {
+			return nil, fmt.Errorf("reading manifest: %w", err)
+		}
+		if entries == nil {
+			return nil, fmt.Errorf("stash not initialized — run `mine stash init` to get started")
+		}
+	}
+	if err := applyEntries(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// applyEntries writes each entry's stash copy to its source path, keeping the
+// mode of sources that already exist and putting recorded symlinks back.
+// Entries whose stash copy is missing are skipped. Entries whose source
+// changed get their on-restore command.
+func applyEntries(entries []Entry) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("determining home directory: %w", err)
+	}
+	dir := Dir()
+	pass := &passphrase{}
+	for _, e := range entries {
+		// Validate SafeName and Source path safety invariants.
+		if err := validateEntryWithHome(e, home); err != nil {
+			return fmt.Errorf("invalid manifest entry for %s: %w", e.Source, err)
+		}
+		if e.Dir {
+			changed, err := applyDir(e)
+			if err != nil {
+				return err
+			}
+			if changed {
+				afterRestore(e)
+			}
+			continue
+		}
+		srcPath := filepath.Clean(e.Source)
+
+		name := stashName(e)
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			// If the stash file is missing, skip this entry; other errors are fatal.
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("reading stash file %s: %w", name, err)
+		}
+		if e.Encrypted {
+			p, err := pass.get()
+			if err != nil {
+				return err
+			}
+			if data, err = decrypt(data, p); err != nil {
+				return fmt.Errorf("decrypting %s: %w", name, err)
+			}
+		}
+
+		current, readErr := os.ReadFile(srcPath)
+		if readErr == nil {
+			data = unscrub(data, current)
+		}
+
+		// Preserve existing file mode if the source file already exists;
+		// otherwise use the one recorded at commit time.
+		mode := os.FileMode(0o644)
+		if e.Mode != 0 {
+			mode = e.Mode
+		}
+		if info, err := os.Stat(srcPath); err == nil {
+			mode = info.Mode().Perm()
+		}
+
+		e.Source = srcPath
+		if err := writeSource(e, data, mode); err != nil {
+			return fmt.Errorf("restoring %s: %w", e.Source, err)
+		}
+		if readErr != nil || !bytes.Equal(current, data) {
+			afterRestore(e)
+		}
+	}
+	return nil
+}
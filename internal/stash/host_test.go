@@ -0,0 +1,127 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHostname_Override(t *testing.T) {
+	t.Setenv("MINE_STASH_HOST", "work-laptop")
+	if got := Hostname(); got != "work-laptop" {
+		t.Errorf("Hostname() = %q, want work-laptop", got)
+	}
+}
+
+func TestTrackVariant(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	t.Setenv("MINE_STASH_HOST", "work-laptop")
+	source := createTestFile(t, homeDir, ".zshrc", "export WORK=1")
+	setupManifest(t, stashDir, source, ".zshrc", "export BASE=1")
+
+	entry, err := TrackVariant(source)
+	if err != nil {
+		t.Fatalf("TrackVariant() error: %v", err)
+	}
+	if got := Variant(*entry); got != "work-laptop" {
+		t.Errorf("Variant() = %q, want work-laptop", got)
+	}
+	if got, _ := os.ReadFile(filepath.Join(stashDir, ".zshrc@work-laptop")); string(got) != "export WORK=1" {
+		t.Errorf("variant content = %q", got)
+	}
+	if got, _ := os.ReadFile(filepath.Join(stashDir, ".zshrc")); string(got) != "export BASE=1" {
+		t.Errorf("base content = %q, want it untouched", got)
+	}
+
+	// Commits from this host go to the variant.
+	if err := os.WriteFile(source, []byte("export WORK=2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("work tweak"); err != nil {
+		t.Fatalf("Commit() error: %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(stashDir, ".zshrc@work-laptop")); string(got) != "export WORK=2" {
+		t.Errorf("variant after commit = %q", got)
+	}
+	if got, _ := os.ReadFile(filepath.Join(stashDir, ".zshrc")); string(got) != "export BASE=1" {
+		t.Errorf("base after commit = %q, want it untouched", got)
+	}
+}
+
+func TestTrackVariant_Untracked(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	t.Setenv("MINE_STASH_HOST", "home")
+	if err := os.MkdirAll(stashDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	source := createTestFile(t, homeDir, ".gitconfig", "[user]")
+
+	if _, err := TrackVariant(source); err != nil {
+		t.Fatalf("TrackVariant() error: %v", err)
+	}
+	for _, name := range []string{".gitconfig", ".gitconfig@home"} {
+		if _, err := os.Stat(filepath.Join(stashDir, name)); err != nil {
+			t.Errorf("%s not stashed: %v", name, err)
+		}
+	}
+}
+
+func TestTrackVariant_BadHostname(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	t.Setenv("MINE_STASH_HOST", "../evil")
+	source := createTestFile(t, homeDir, ".zshrc", "x")
+	setupManifest(t, stashDir, source, ".zshrc", "x")
+
+	if _, err := TrackVariant(source); err == nil || !strings.Contains(err.Error(), "can't name a variant") {
+		t.Errorf("TrackVariant() = %v, want hostname error", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "local edits")
+	gitconfig := createTestFile(t, homeDir, ".gitconfig", "local edits")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "base zshrc")
+	f, err := os.OpenFile(ManifestPath(), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(gitconfig + " -> .gitconfig\n")
+	f.Close()
+	for name, content := range map[string]string{
+		".gitconfig":             "base gitconfig",
+		".zshrc@work-laptop":     "work zshrc",
+		".gitconfig@home-laptop": "home gitconfig",
+	} {
+		if err := os.WriteFile(filepath.Join(stashDir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("MINE_STASH_HOST", "work-laptop")
+	applied, err := Apply("")
+	if err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Errorf("Apply() applied %d entries, want 2", len(applied))
+	}
+	if got, _ := os.ReadFile(zshrc); string(got) != "work zshrc" {
+		t.Errorf(".zshrc = %q, want the work-laptop variant", got)
+	}
+	if got, _ := os.ReadFile(gitconfig); string(got) != "base gitconfig" {
+		t.Errorf(".gitconfig = %q, want the base file", got)
+	}
+
+	t.Setenv("MINE_STASH_HOST", "home-laptop")
+	if _, err := Apply("~/.gitconfig"); err != nil {
+		t.Fatalf("Apply(.gitconfig) error: %v", err)
+	}
+	if got, _ := os.ReadFile(gitconfig); string(got) != "home gitconfig" {
+		t.Errorf(".gitconfig = %q, want the home-laptop variant", got)
+	}
+	if got, _ := os.ReadFile(zshrc); string(got) != "work zshrc" {
+		t.Errorf("Apply(.gitconfig) touched .zshrc: %q", got)
+	}
+}
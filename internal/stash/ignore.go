@@ -0,0 +1,140 @@
+package stash
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFile names the file, in gitignore syntax, that keeps paths inside a
+// tracked directory out of the stash. One can sit in any subdirectory and
+// applies below it, overriding those further up.
+const IgnoreFile = ".mine-stash-ignore"
+
+// ignoreRule is one compiled line of an ignore file.
+type ignoreRule struct {
+	re      *regexp.Regexp // matches paths relative to the ignore file's directory
+	negate  bool           // "!pattern": re-include what an earlier rule ignored
+	dirOnly bool           // "pattern/": match directories only
+}
+
+// ignoreRules holds the rules of every ignore file found so far in a tracked
+// directory, keyed by the slash-separated directory that holds it ("" for
+// the top).
+type ignoreRules map[string][]ignoreRule
+
+// load reads the ignore file in dir, the directory at rel, if there is one.
+func (r ignoreRules) load(dir, rel string) {
+	data, err := os.ReadFile(filepath.Join(dir, IgnoreFile))
+	if err != nil {
+		return
+	}
+	if rules := parseIgnore(data); len(rules) > 0 {
+		r[rel] = rules
+	}
+}
+
+// ignored reports whether the slash-separated path rel is ignored. Rules in
+// deeper directories are checked after those above them, and the last rule
+// that matches decides, as in git.
+func (r ignoreRules) ignored(rel string, isDir bool) bool {
+	ignored := false
+	check := func(base, sub string) {
+		for _, rule := range r[base] {
+			if (!rule.dirOnly || isDir) && rule.re.MatchString(sub) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	check("", rel)
+	for i := 0; i < len(rel); i++ {
+		if rel[i] == '/' {
+			check(rel[:i], rel[i+1:])
+		}
+	}
+	return ignored
+}
+
+// parseIgnore compiles the lines of an ignore file. Lines that don't form a
+// valid pattern are skipped.
+func parseIgnore(data []byte) []ignoreRule {
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rule ignoreRule
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		// A slash anywhere but the end anchors the pattern to the ignore
+		// file's directory; otherwise it matches a name at any depth.
+		prefix := "^(?:.*/)?"
+		if strings.Contains(line, "/") {
+			prefix = "^"
+			line = strings.TrimPrefix(line, "/")
+		}
+		re, err := regexp.Compile(prefix + globRegexp(line) + "$")
+		if err != nil {
+			continue
+		}
+		rule.re = re
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// globRegexp translates a gitignore glob to a regular expression: "*" and
+// "?" stay within one path segment, "**" spans any number of them, and
+// "[...]" is a character class.
+func globRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/") && (i == 0 || glob[i-1] == '/'):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case glob[i:] == "**" && i > 0 && glob[i-1] == '/':
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
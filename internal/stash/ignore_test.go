@@ -0,0 +1,98 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIgnoreRules(t *testing.T) {
+	rules := ignoreRules{
+		"": parseIgnore([]byte(`# caches and history
+*.log
+cache/
+/session.vim
+**/undo/**
+plugins/**/*.bak
+!keep.log
+\#literal
+`)),
+		"lua": parseIgnore([]byte("*.tmp\n!important.log\n")),
+	}
+	tests := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"debug.log", false, true},
+		{"deep/down/debug.log", false, true},
+		{"keep.log", false, false},
+		{"cache", true, true},
+		{"cache", false, false}, // "cache/" only matches directories
+		{"session.vim", false, true},
+		{"lua/session.vim", false, false}, // anchored to the top
+		{"state/undo/x.txt", false, true},
+		{"plugins/a/b/c.bak", false, true},
+		{"plugins/c.bak", false, true},
+		{"#literal", false, true},
+		{"lua/x.tmp", false, true},
+		{"x.tmp", false, false}, // lua's rules don't apply above it
+		{"lua/important.log", false, false},
+		{"init.lua", false, false},
+	}
+	for _, tt := range tests {
+		if got := rules.ignored(tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("ignored(%q, dir=%v) = %v, want %v", tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestGlobRegexp(t *testing.T) {
+	tests := []struct{ glob, want string }{
+		{"*.lua", `[^/]*\.lua`},
+		{"file?.txt", `file[^/]\.txt`},
+		{"[!a-c]x", `[^a-c]x`},
+		{"**/foo", `(?:.*/)?foo`},
+		{"a/**/b", `a/(?:.*/)?b`},
+		{"a/**", `a/.*`},
+		{`\*`, `\*`},
+	}
+	for _, tt := range tests {
+		if got := globRegexp(tt.glob); got != tt.want {
+			t.Errorf("globRegexp(%q) = %q, want %q", tt.glob, got, tt.want)
+		}
+	}
+}
+
+func TestDirFiles_IgnoreFile(t *testing.T) {
+	_, source := setupDirEnv(t)
+	home := filepath.Dir(filepath.Dir(source))
+	createTestFile(t, home, ".config/nvim/"+IgnoreFile, "cache/\n*.json\n")
+	createTestFile(t, home, ".config/nvim/lua/"+IgnoreFile, "*.lua\n!core.lua\n")
+	createTestFile(t, home, ".config/nvim/lua/scratch.lua", "-- scratch")
+
+	entry, err := TrackDir(source, nil, nil)
+	if err != nil {
+		t.Fatalf("TrackDir() error: %v", err)
+	}
+	files, err := stashedFiles(*entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{IgnoreFile, "init.lua", "lua/" + IgnoreFile, "lua/core.lua"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("stashed files = %v, want %v", files, want)
+	}
+
+	// Ignoring a file that's already stashed drops it at the next commit.
+	if err := os.WriteFile(filepath.Join(source, IgnoreFile), []byte("cache/\n*.json\ninit.lua\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("ignore init.lua"); err != nil {
+		t.Fatalf("Commit() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(Dir(), entry.SafeName, "init.lua")); !os.IsNotExist(err) {
+		t.Error("ignored init.lua is still in the stash")
+	}
+}
@@ -0,0 +1,418 @@
+package stash
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Dotfile repo layouts Import recognizes.
+const (
+	// LayoutChezmoi is a chezmoi source directory: dot_zshrc,
+	// private_dot_ssh/config, executable_ prefixes and so on.
+	LayoutChezmoi = "chezmoi"
+	// LayoutStow is a GNU Stow directory: one package per top-level
+	// directory, each mirroring $HOME.
+	LayoutStow = "stow"
+	// LayoutHome is a repo that mirrors $HOME directly, as with the bare-repo
+	// technique (git --git-dir=~/.cfg --work-tree=$HOME).
+	LayoutHome = "home"
+)
+
+// ImportResult reports what Import found and did.
+type ImportResult struct {
+	Layout   string
+	Imported []Entry
+	Skipped  []ImportSkip
+}
+
+// ImportSkip is a file in the imported repo that wasn't tracked, and why.
+type ImportSkip struct {
+	Path   string // relative to the repo
+	Reason string
+}
+
+// importedFile is a file an importer maps to a path under $HOME.
+type importedFile struct {
+	path string // in the repo, relative to its root
+	rel  string // under $HOME
+	mode os.FileMode
+}
+
+// remoteURLPattern matches scp-style git remotes like git@github.com:me/dots.
+var remoteURLPattern = regexp.MustCompile(`^[\w.-]+@[\w.-]+:`)
+
+// Import tracks the files of an existing dotfiles repo — a local directory,
+// a bare repo, or a git URL — converting its layout (see LayoutChezmoi,
+// LayoutStow, LayoutHome) into manifest entries and stash copies. Files
+// under $HOME are left alone, so `mine stash diff` and `mine stash apply`
+// can bring them in line afterwards. Files already tracked are skipped.
+func Import(from string) (*ImportResult, error) {
+	root, cleanup, err := importRoot(from)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	result := &ImportResult{}
+	var files []importedFile
+	switch {
+	case isChezmoi(root):
+		result.Layout = LayoutChezmoi
+		files, result.Skipped, err = chezmoiFiles(root)
+	case isStow(root):
+		result.Layout = LayoutStow
+		files, result.Skipped, err = stowFiles(root)
+	case isHomeMirror(root):
+		result.Layout = LayoutHome
+		files, result.Skipped, err = homeFiles(root)
+	default:
+		return nil, fmt.Errorf("%s doesn't look like a chezmoi, stow, or $HOME-style dotfiles repo", from)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("found no dotfiles to import in %s", from)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining home directory: %w", err)
+	}
+	dir := Dir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating stash directory: %w", err)
+	}
+	if _, err := os.Stat(ManifestPath()); os.IsNotExist(err) {
+		if err := os.WriteFile(ManifestPath(), []byte(ManifestHeader), 0o644); err != nil {
+			return nil, fmt.Errorf("creating manifest: %w", err)
+		}
+	}
+	entries, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	tracked := map[string]bool{}
+	for _, e := range entries {
+		tracked[e.Source] = true
+		tracked[e.SafeName] = true
+	}
+
+	for _, f := range files {
+		source := filepath.Join(home, f.rel)
+		safeName := SafeNameFor(source)
+		switch {
+		case tracked[source]:
+			result.Skipped = append(result.Skipped, ImportSkip{f.path, "already tracked"})
+			continue
+		case tracked[safeName] || validateSafeName(safeName) != nil:
+			result.Skipped = append(result.Skipped, ImportSkip{f.path, "no usable stash name"})
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, f.path))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", f.path, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, safeName), scrubForStash(source, data), f.mode); err != nil {
+			return nil, fmt.Errorf("writing to stash: %w", err)
+		}
+		e := Entry{Source: source, SafeName: safeName}
+		entries = append(entries, e)
+		result.Imported = append(result.Imported, e)
+		tracked[source], tracked[safeName] = true, true
+	}
+	if len(result.Imported) > 0 {
+		if err := writeManifestEntries(entries); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// importRoot returns a local directory holding the repo's files. URLs and
+// bare repos are cloned to a temporary directory that cleanup removes.
+func importRoot(from string) (root string, cleanup func(), err error) {
+	cleanup = func() {}
+	clone := strings.Contains(from, "://") || remoteURLPattern.MatchString(from)
+	if !clone {
+		info, err := os.Stat(from)
+		if err != nil {
+			return "", nil, fmt.Errorf("can't find %s", from)
+		}
+		if !info.IsDir() {
+			return "", nil, fmt.Errorf("%s is not a directory", from)
+		}
+		bare, _ := gitCmd(from, "rev-parse", "--is-bare-repository")
+		if strings.TrimSpace(bare) != "true" {
+			return from, cleanup, nil
+		}
+	}
+
+	tmp, err := os.MkdirTemp("", "mine-stash-import-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	cleanup = func() { os.RemoveAll(tmp) }
+	if _, err := gitCmd(tmp, "clone", "--quiet", "--depth", "1", from, "."); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("cloning %s: %w", from, err)
+	}
+	return tmp, cleanup, nil
+}
+
+// repoMeta reports whether a top-level name belongs to the repo rather than
+// to $HOME.
+func repoMeta(name string) bool {
+	switch name {
+	case ".git", ".github", ".gitmodules", ".gitattributes":
+		return true
+	}
+	upper := strings.ToUpper(name)
+	return strings.HasPrefix(upper, "README") || strings.HasPrefix(upper, "LICENSE")
+}
+
+// topLevel returns the names at the top of root, minus repo metadata.
+func topLevel(root string) []os.DirEntry {
+	all, _ := os.ReadDir(root)
+	var entries []os.DirEntry
+	for _, e := range all {
+		if !repoMeta(e.Name()) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// isChezmoi reports whether root is a chezmoi source directory.
+func isChezmoi(root string) bool {
+	for _, e := range topLevel(root) {
+		if strings.HasPrefix(e.Name(), ".chezmoi") || strings.HasPrefix(e.Name(), "dot_") || strings.HasPrefix(e.Name(), "private_dot_") {
+			return true
+		}
+	}
+	return false
+}
+
+// isStow reports whether root is a stow directory: nothing but package
+// directories at the top, at least one holding a dotfile.
+func isStow(root string) bool {
+	found := false
+	for _, e := range topLevel(root) {
+		name := e.Name()
+		if strings.HasPrefix(name, ".stow") {
+			continue
+		}
+		if !e.IsDir() || strings.HasPrefix(name, ".") {
+			return false
+		}
+		pkg, _ := os.ReadDir(filepath.Join(root, name))
+		for _, p := range pkg {
+			if strings.HasPrefix(p.Name(), ".") || strings.HasPrefix(p.Name(), "dot-") {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// isHomeMirror reports whether root has dotfiles at its top, as a repo of
+// $HOME does.
+func isHomeMirror(root string) bool {
+	for _, e := range topLevel(root) {
+		if strings.HasPrefix(e.Name(), ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// walkFiles calls fn with the slash-separated path, relative to root, of
+// every regular file under root, skipping repo metadata at the top. Symlinks
+// and other special files are reported as skipped.
+func walkFiles(root string, fn func(path string, mode os.FileMode)) ([]ImportSkip, error) {
+	var skipped []ImportSkip
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if d.Name() == ".git" || (!strings.Contains(rel, "/") && repoMeta(rel)) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			skipped = append(skipped, ImportSkip{rel, "not a regular file"})
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fn(rel, info.Mode().Perm())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading repo: %w", err)
+	}
+	return skipped, nil
+}
+
+// homeFiles maps a $HOME mirror: every file sits at its home path.
+func homeFiles(root string) ([]importedFile, []ImportSkip, error) {
+	var files []importedFile
+	skipped, err := walkFiles(root, func(p string, mode os.FileMode) {
+		files = append(files, importedFile{path: p, rel: p, mode: mode})
+	})
+	return files, skipped, err
+}
+
+// stowFiles maps stow packages: each package's files sit at their path
+// within it. Stow's --dotfiles naming (dot-zshrc) is undone.
+func stowFiles(root string) ([]importedFile, []ImportSkip, error) {
+	var files []importedFile
+	var skipped []ImportSkip
+	for _, pkg := range topLevel(root) {
+		if !pkg.IsDir() {
+			continue
+		}
+		pkgRoot := filepath.Join(root, pkg.Name())
+		s, err := walkFiles(pkgRoot, func(p string, mode os.FileMode) {
+			path := pkg.Name() + "/" + p
+			if filepath.Base(p) == ".stow-local-ignore" {
+				return
+			}
+			parts := strings.Split(p, "/")
+			for i, part := range parts {
+				if strings.HasPrefix(part, "dot-") {
+					parts[i] = "." + strings.TrimPrefix(part, "dot-")
+				}
+			}
+			files = append(files, importedFile{path: path, rel: strings.Join(parts, "/"), mode: mode})
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, sk := range s {
+			sk.Path = pkg.Name() + "/" + sk.Path
+			skipped = append(skipped, sk)
+		}
+	}
+	return files, skipped, nil
+}
+
+// chezmoiPrefixes are the chezmoi source-state attributes a name can start
+// with, in the order chezmoi allows them.
+var chezmoiPrefixes = []string{
+	"after_", "before_", "once_", "onchange_", "run_",
+	"create_", "modify_", "remove_", "symlink_",
+	"external_", "exact_",
+	"encrypted_", "private_", "readonly_", "empty_", "executable_",
+	"literal_", "dot_",
+}
+
+// chezmoiName decodes one chezmoi source name into its target name and
+// attributes.
+func chezmoiName(name string) (string, map[string]bool) {
+	attrs := map[string]bool{}
+	for _, p := range chezmoiPrefixes {
+		if strings.HasPrefix(name, p) {
+			attrs[strings.TrimSuffix(p, "_")] = true
+			name = strings.TrimPrefix(name, p)
+			if p == "literal_" {
+				break
+			}
+		}
+	}
+	if attrs["dot"] {
+		name = "." + name
+	}
+	for _, suffix := range []string{".tmpl", ".literal", ".age", ".asc"} {
+		if strings.HasSuffix(name, suffix) {
+			attrs[strings.TrimPrefix(suffix, ".")] = true
+			name = strings.TrimSuffix(name, suffix)
+		}
+	}
+	return name, attrs
+}
+
+// chezmoiFiles maps a chezmoi source directory, honoring .chezmoiroot.
+// Scripts, templates, symlinks, encrypted files and the like can't be
+// imported as plain files and are skipped with the reason.
+func chezmoiFiles(root string) ([]importedFile, []ImportSkip, error) {
+	prefix := ""
+	if data, err := os.ReadFile(filepath.Join(root, ".chezmoiroot")); err == nil {
+		prefix = strings.Trim(strings.TrimSpace(string(data)), "/")
+		if !filepath.IsLocal(prefix) {
+			return nil, nil, fmt.Errorf(".chezmoiroot points outside the repo: %q", prefix)
+		}
+		prefix += "/"
+		root = filepath.Join(root, prefix)
+	}
+
+	var files []importedFile
+	var skipped []ImportSkip
+	s, err := walkFiles(root, func(p string, mode os.FileMode) {
+		path := prefix + p
+		var target []string
+		var fileAttrs map[string]bool
+		for _, part := range strings.Split(p, "/") {
+			if strings.HasPrefix(part, ".chezmoi") {
+				return // chezmoi's own config, data, and scripts
+			}
+			name, attrs := chezmoiName(part)
+			target = append(target, name)
+			fileAttrs = attrs
+		}
+		reason := ""
+		switch {
+		case fileAttrs["run"] || fileAttrs["modify"]:
+			reason = "chezmoi script"
+		case fileAttrs["symlink"]:
+			reason = "chezmoi symlink"
+		case fileAttrs["remove"] || fileAttrs["create"]:
+			reason = "chezmoi remove/create rule"
+		case fileAttrs["encrypted"]:
+			reason = "encrypted — decrypt it, then `mine stash track --encrypt`"
+		case fileAttrs["tmpl"]:
+			reason = "template — track the rendered file instead"
+		}
+		if reason != "" {
+			skipped = append(skipped, ImportSkip{path, reason})
+			return
+		}
+		switch {
+		case fileAttrs["private"] && fileAttrs["executable"]:
+			mode = 0o700
+		case fileAttrs["private"]:
+			mode = 0o600
+		case fileAttrs["executable"]:
+			mode = 0o755
+		default:
+			mode = 0o644
+		}
+		files = append(files, importedFile{path: path, rel: strings.Join(target, "/"), mode: mode})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, sk := range s {
+		sk.Path = prefix + sk.Path
+		skipped = append(skipped, sk)
+	}
+	sort.Slice(skipped, func(i, j int) bool { return skipped[i].Path < skipped[j].Path })
+	return files, skipped, nil
+}
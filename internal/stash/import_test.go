@@ -0,0 +1,190 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// writeRepo creates files (path -> content) under a new directory.
+func writeRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for p, content := range files {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+// importedSources returns the imported sources relative to homeDir, sorted.
+func importedSources(t *testing.T, homeDir string, r *ImportResult) []string {
+	t.Helper()
+	var got []string
+	for _, e := range r.Imported {
+		rel, _ := filepath.Rel(homeDir, e.Source)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	sort.Strings(got)
+	return got
+}
+
+func assertStrings(t *testing.T, what string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s = %v, want %v", what, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s = %v, want %v", what, got, want)
+		}
+	}
+}
+
+func TestImport_Chezmoi(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	repo := writeRepo(t, map[string]string{
+		".chezmoiroot":                        "home\n",
+		"README.md":                           "my dotfiles",
+		"home/dot_zshrc":                      "export A=1",
+		"home/private_dot_ssh/private_config": "Host *",
+		"home/dot_local/bin/executable_hello": "#!/bin/sh",
+		"home/dot_gitconfig.tmpl":             "{{ .email }}",
+		"home/run_once_install.sh":            "brew bundle",
+		"home/.chezmoiignore":                 "README.md",
+	})
+
+	result, err := Import(repo)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if result.Layout != LayoutChezmoi {
+		t.Errorf("Layout = %q, want chezmoi", result.Layout)
+	}
+	assertStrings(t, "imported", importedSources(t, homeDir, result),
+		[]string{".local/bin/hello", ".ssh/config", ".zshrc"})
+	if len(result.Skipped) != 2 {
+		t.Errorf("Skipped = %v, want the template and the script", result.Skipped)
+	}
+
+	entry, err := FindEntry("~/.ssh/config")
+	if err != nil {
+		t.Fatalf("FindEntry() error: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(stashDir, entry.SafeName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("private file mode = %o, want 600", info.Mode().Perm())
+	}
+	if got, _ := ReadStashed(*entry); string(got) != "Host *" {
+		t.Errorf("stash copy = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(homeDir, ".zshrc")); !os.IsNotExist(err) {
+		t.Error("Import should leave the home directory alone")
+	}
+}
+
+func TestImport_Stow(t *testing.T) {
+	_, homeDir := setupEnv(t)
+	repo := writeRepo(t, map[string]string{
+		"README.md":                  "my dotfiles",
+		"zsh/.zshrc":                 "export A=1",
+		"nvim/.config/nvim/init.lua": "vim.o.number = true",
+		"git/dot-gitconfig":          "[user]",
+		"git/.stow-local-ignore":     "README",
+	})
+
+	result, err := Import(repo)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if result.Layout != LayoutStow {
+		t.Errorf("Layout = %q, want stow", result.Layout)
+	}
+	assertStrings(t, "imported", importedSources(t, homeDir, result),
+		[]string{".config/nvim/init.lua", ".gitconfig", ".zshrc"})
+}
+
+func TestImport_BareRepo(t *testing.T) {
+	_, homeDir := setupEnv(t)
+	work := writeRepo(t, map[string]string{
+		".zshrc":             "export A=1",
+		".config/git/ignore": "*.swp",
+		"bin/hello":          "#!/bin/sh",
+		"LICENSE":            "MIT",
+	})
+	for _, args := range [][]string{{"init", "--quiet"}, {"add", "-A"}, {"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-qm", "dots"}} {
+		if _, err := gitCmd(work, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	bare := filepath.Join(t.TempDir(), "cfg.git")
+	if _, err := gitCmd(work, "clone", "--quiet", "--bare", ".", bare); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Import(bare)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if result.Layout != LayoutHome {
+		t.Errorf("Layout = %q, want home", result.Layout)
+	}
+	assertStrings(t, "imported", importedSources(t, homeDir, result),
+		[]string{".config/git/ignore", ".zshrc", "bin/hello"})
+}
+
+func TestImport_SkipsTracked(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "mine")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "mine")
+	repo := writeRepo(t, map[string]string{".zshrc": "theirs", ".bashrc": "export B=1"})
+
+	result, err := Import(repo)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	assertStrings(t, "imported", importedSources(t, homeDir, result), []string{".bashrc"})
+	if len(result.Skipped) != 1 || result.Skipped[0].Reason != "already tracked" {
+		t.Errorf("Skipped = %v", result.Skipped)
+	}
+	if got, _ := os.ReadFile(filepath.Join(stashDir, ".zshrc")); string(got) != "mine" {
+		t.Errorf("tracked stash copy = %q, want it untouched", got)
+	}
+}
+
+func TestImport_UnknownLayout(t *testing.T) {
+	setupEnv(t)
+	repo := writeRepo(t, map[string]string{"notes.txt": "hi"})
+	if _, err := Import(repo); err == nil {
+		t.Error("Import() of an unrecognized repo should error")
+	}
+}
+
+func TestChezmoiName(t *testing.T) {
+	tests := []struct {
+		in, want string
+		attr     string
+	}{
+		{"dot_zshrc", ".zshrc", "dot"},
+		{"private_dot_ssh", ".ssh", "private"},
+		{"executable_dot_local_script", ".local_script", "executable"},
+		{"literal_dot_keep", "dot_keep", "literal"},
+		{"dot_gitconfig.tmpl", ".gitconfig", "tmpl"},
+		{"encrypted_private_dot_netrc.age", ".netrc", "encrypted"},
+	}
+	for _, tt := range tests {
+		got, attrs := chezmoiName(tt.in)
+		if got != tt.want || !attrs[tt.attr] {
+			t.Errorf("chezmoiName(%q) = %q, %v; want %q with %s", tt.in, got, attrs, tt.want, tt.attr)
+		}
+	}
+}
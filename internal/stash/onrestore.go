@@ -0,0 +1,68 @@
+package stash
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// onRestoreTimeout bounds an on-restore command, so a hung reload can't
+// stall a restore or pull.
+const onRestoreTimeout = time.Minute
+
+// AfterRestore is called with each entry that has an on-restore command,
+// once restore writes its source — or apply and sync pull change it — so
+// the programs that read it can reload. The command layer sets it, usually
+// to RunOnRestore plus some output; while it's nil, commands don't run.
+var AfterRestore func(e Entry)
+
+func afterRestore(e Entry) {
+	if AfterRestore != nil && e.OnRestore != "" {
+		AfterRestore(e)
+	}
+}
+
+// RunOnRestore runs e's on-restore command with sh in the home directory,
+// with MINE_STASH_SOURCE set to the restored path, and returns its combined
+// output.
+func RunOnRestore(e Entry) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), onRestoreTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", e.OnRestore)
+	cmd.Dir, _ = os.UserHomeDir()
+	cmd.Env = append(os.Environ(), "MINE_STASH_SOURCE="+e.Source)
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return out, fmt.Errorf("timed out after %s", onRestoreTimeout)
+	}
+	return out, err
+}
+
+// SetOnRestore sets the command run after file's source is restored; an
+// empty command removes it.
+func SetOnRestore(file, command string) (*Entry, error) {
+	if strings.ContainsAny(command, "\r\n") {
+		return nil, fmt.Errorf("on-restore command must be a single line")
+	}
+	entry, err := FindEntry(file)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	for i := range entries {
+		if entries[i].Source == entry.Source {
+			entries[i].OnRestore = command
+		}
+	}
+	if err := writeManifestEntries(entries); err != nil {
+		return nil, err
+	}
+	entry.OnRestore = command
+	return entry, nil
+}
@@ -0,0 +1,131 @@
+package stash
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// recordRestores sets AfterRestore to collect the sources it's called with.
+func recordRestores(t *testing.T) *[]string {
+	t.Helper()
+	var got []string
+	AfterRestore = func(e Entry) { got = append(got, e.Source) }
+	t.Cleanup(func() { AfterRestore = nil })
+	return &got
+}
+
+func TestSetOnRestore_RoundTrip(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	source := createTestFile(t, homeDir, ".tmux.conf", "set -g mouse on")
+	setupManifest(t, stashDir, source, ".tmux.conf", "set -g mouse on")
+
+	command := "tmux source-file ~/.tmux.conf | true"
+	if _, err := SetOnRestore(source, command); err != nil {
+		t.Fatalf("SetOnRestore() error: %v", err)
+	}
+	entry, err := FindEntry(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.OnRestore != command || entry.SafeName != ".tmux.conf" {
+		t.Errorf("entry = %+v, want on-restore %q", entry, command)
+	}
+
+	if _, err := SetOnRestore(source, ""); err != nil {
+		t.Fatalf("SetOnRestore(\"\") error: %v", err)
+	}
+	manifest, _ := os.ReadFile(ManifestPath())
+	if strings.Contains(string(manifest), onRestoreField) {
+		t.Errorf("manifest still has the command:\n%s", manifest)
+	}
+
+	if _, err := SetOnRestore(source, "echo a\necho b"); err == nil {
+		t.Error("SetOnRestore() with a multi-line command should error")
+	}
+}
+
+func TestSetOnRestore_KeepsDirPatterns(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	createTestFile(t, homeDir, ".config/nvim/init.lua", "x")
+	if err := os.MkdirAll(stashDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := TrackDir(homeDir+"/.config/nvim", []string{"*.lua"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetOnRestore(entry.Source, "nvim --headless +qa"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FindEntry(entry.Source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Dir || len(got.Include) != 1 || got.OnRestore != "nvim --headless +qa" {
+		t.Errorf("entry = %+v", got)
+	}
+}
+
+func TestRestoreToSource_RunsOnRestore(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	source := createTestFile(t, homeDir, ".tmux.conf", "v1")
+	setupManifest(t, stashDir, source, ".tmux.conf", "v1")
+	if _, err := SetOnRestore(source, "true"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("v1"); err != nil {
+		t.Fatal(err)
+	}
+	restored := recordRestores(t)
+
+	if _, err := RestoreToSource(source, "", false); err != nil {
+		t.Fatalf("RestoreToSource() error: %v", err)
+	}
+	if len(*restored) != 1 || (*restored)[0] != source {
+		t.Errorf("AfterRestore called with %v, want [%s]", *restored, source)
+	}
+}
+
+func TestApply_RunsOnRestoreWhenChanged(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	source := createTestFile(t, homeDir, ".tmux.conf", "v1")
+	setupManifest(t, stashDir, source, ".tmux.conf", "v1")
+	if _, err := SetOnRestore(source, "true"); err != nil {
+		t.Fatal(err)
+	}
+	restored := recordRestores(t)
+
+	if _, err := Apply(""); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if len(*restored) != 0 {
+		t.Errorf("AfterRestore called for an unchanged file: %v", *restored)
+	}
+
+	if err := os.WriteFile(source, []byte("local edit"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Apply(""); err != nil {
+		t.Fatalf("Apply() error: %v", err)
+	}
+	if len(*restored) != 1 {
+		t.Errorf("AfterRestore called %d times, want 1", len(*restored))
+	}
+}
+
+func TestRunOnRestore(t *testing.T) {
+	_, homeDir := setupEnv(t)
+	out, err := RunOnRestore(Entry{Source: homeDir + "/.zshrc", OnRestore: `echo "$MINE_STASH_SOURCE"; pwd`})
+	if err != nil {
+		t.Fatalf("RunOnRestore() error: %v", err)
+	}
+	want := homeDir + "/.zshrc\n" + homeDir + "\n"
+	if string(out) != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+
+	if _, err := RunOnRestore(Entry{OnRestore: "exit 3"}); err == nil {
+		t.Error("RunOnRestore() should report a failing command")
+	}
+}
@@ -0,0 +1,182 @@
+package stash
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// scrubRule is one kind of secret a Scrubber redacts. When the pattern has a
+// capture group, only the group is redacted, so e.g. the variable name in an
+// export line stays readable.
+type scrubRule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// defaultScrubRules catch the credentials most often pasted into dotfiles.
+// Values that reference another variable or a command ($FOO, $(pass ...))
+// aren't secrets themselves and are left alone.
+var defaultScrubRules = []scrubRule{
+	{"aws-access-key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"aws-secret-key", regexp.MustCompile(`(?i)aws_secret_access_key\s*[=:]\s*["']?([A-Za-z0-9/+=]{40})`)},
+	{"github-token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"slack-token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"api-key", regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`)},
+	{"export-secret", regexp.MustCompile(`(?m)^\s*export\s+\w*(?:KEY|TOKEN|SECRET|PASSWORD|PASSWD)\s*=\s*("[^"$\n]+"|'[^'\n]+'|[^\s"'$][^\s]*)`)},
+}
+
+// placeholderPattern matches what Scrubber puts in place of a secret.
+var placeholderPattern = regexp.MustCompile(`<REDACTED:([a-z0-9-]+)>`)
+
+func placeholder(rule string) string {
+	return "<REDACTED:" + rule + ">"
+}
+
+// Scrub redacts likely secrets from unencrypted tracked files as Commit
+// copies them into the stash, so credentials pasted into a dotfile aren't
+// versioned or synced. The command layer sets it from config; nil turns
+// scrubbing off.
+var Scrub *Scrubber
+
+// Scrubber replaces secrets matched by its rules with <REDACTED:rule>
+// placeholders. It records each redaction Commit makes so the caller can
+// warn about them.
+type Scrubber struct {
+	rules    []scrubRule
+	Redacted []Redaction
+}
+
+// Redaction records secrets redacted from one tracked file.
+type Redaction struct {
+	Source string   // the file, or the file inside a tracked directory
+	Rules  []string // rule name per secret, in file order
+}
+
+// NewScrubber returns a Scrubber with the built-in rules plus extra, which
+// are regular expressions redacted as "custom".
+func NewScrubber(extra []string) (*Scrubber, error) {
+	s := &Scrubber{rules: append([]scrubRule(nil), defaultScrubRules...)}
+	for _, p := range extra {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scrub pattern %q: %w", p, err)
+		}
+		s.rules = append(s.rules, scrubRule{"custom", re})
+	}
+	return s, nil
+}
+
+// secretMatch is one secret found in a file: the byte range to redact and
+// the rule that found it.
+type secretMatch struct {
+	start, end int
+	rule       string
+}
+
+// find returns the secrets in data in file order, without overlaps; where
+// two rules match at the same place the earlier rule wins.
+func (s *Scrubber) find(data []byte) []secretMatch {
+	var all []secretMatch
+	for _, r := range s.rules {
+		for _, loc := range r.pattern.FindAllSubmatchIndex(data, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			if start < end && !placeholderPattern.Match(data[start:end]) {
+				all = append(all, secretMatch{start, end, r.name})
+			}
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].start < all[j].start })
+
+	var matches []secretMatch
+	last := -1
+	for _, m := range all {
+		if m.start < last {
+			continue
+		}
+		matches = append(matches, m)
+		last = m.end
+	}
+	return matches
+}
+
+// Scrub returns data with its secrets replaced by placeholders, and the rule
+// name of each one replaced.
+func (s *Scrubber) Scrub(data []byte) ([]byte, []string) {
+	matches := s.find(data)
+	if len(matches) == 0 {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	rules := make([]string, 0, len(matches))
+	prev := 0
+	for _, m := range matches {
+		buf.Write(data[prev:m.start])
+		buf.WriteString(placeholder(m.rule))
+		rules = append(rules, m.rule)
+		prev = m.end
+	}
+	buf.Write(data[prev:])
+	return buf.Bytes(), rules
+}
+
+// scrubForStash is Scrub for content bound for the stash: it's a no-op when
+// scrubbing is off, and records what it redacted from source.
+func scrubForStash(source string, data []byte) []byte {
+	if Scrub == nil {
+		return data
+	}
+	scrubbed, rules := Scrub.Scrub(data)
+	if len(rules) > 0 {
+		Scrub.Redacted = append(Scrub.Redacted, Redaction{Source: source, Rules: rules})
+	}
+	return scrubbed
+}
+
+// StashedForm returns data as Commit would store it: scrubbed when scrubbing
+// is on. Comparing it with the stash copy tells whether a file changed.
+func StashedForm(data []byte) []byte {
+	if Scrub == nil {
+		return data
+	}
+	scrubbed, _ := Scrub.Scrub(data)
+	return scrubbed
+}
+
+// unscrub fills the placeholders in stored, content about to be written to a
+// source file, with the secrets in current, the file as it is now. That only
+// happens when current has the same kinds of secret in the same order;
+// otherwise the placeholders are left for the user to fill in.
+func unscrub(stored, current []byte) []byte {
+	holders := placeholderPattern.FindAllSubmatchIndex(stored, -1)
+	if len(holders) == 0 || current == nil {
+		return stored
+	}
+	s := Scrub
+	if s == nil {
+		s = &Scrubber{rules: defaultScrubRules}
+	}
+	secrets := s.find(current)
+	if len(secrets) != len(holders) {
+		return stored
+	}
+	for i, h := range holders {
+		if string(stored[h[2]:h[3]]) != secrets[i].rule {
+			return stored
+		}
+	}
+
+	var buf bytes.Buffer
+	prev := 0
+	for i, h := range holders {
+		buf.Write(stored[prev:h[0]])
+		buf.Write(current[secrets[i].start:secrets[i].end])
+		prev = h[1]
+	}
+	buf.Write(stored[prev:])
+	return buf.Bytes()
+}
@@ -0,0 +1,197 @@
+package stash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Usage describes how much space the stash's history takes.
+type Usage struct {
+	RepoBytes int64    // on-disk size of the stash's git objects
+	Snapshots int      // commits in the history
+	Largest   []Blob   // biggest file versions in the history, largest first
+	Growth    []Growth // what each month added to the history, oldest first
+}
+
+// Blob is one stored file and its size.
+type Blob struct {
+	Path  string // stash path, or source path for LargeFiles
+	Bytes int64
+}
+
+// Growth is the history added in one month: its snapshots and the size of
+// the file versions they introduced.
+type Growth struct {
+	Month     string // "2006-01"
+	Snapshots int
+	Bytes     int64
+}
+
+// DiskUsage reports the stash's size, its top largest file versions across
+// all of history, and how the history grew month by month.
+func DiskUsage(top int) (*Usage, error) {
+	if !IsGitRepo() {
+		return nil, fmt.Errorf("no version history yet — run `mine stash commit` first")
+	}
+	dir := Dir()
+	repo, err := repoBytes()
+	if err != nil {
+		return nil, err
+	}
+	sizes, err := blobSizes()
+	if err != nil {
+		return nil, err
+	}
+	u := &Usage{RepoBytes: repo}
+
+	// Every blob reachable from a ref, with a path it was stored under.
+	objects, err := gitCmd(dir, "rev-list", "--objects", "--all")
+	if err != nil {
+		return nil, fmt.Errorf("listing stash history: %w", err)
+	}
+	listed := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(objects), "\n") {
+		hash, path, ok := strings.Cut(line, " ")
+		if size, blob := sizes[hash]; ok && blob && !listed[hash] {
+			listed[hash] = true
+			u.Largest = append(u.Largest, Blob{Path: path, Bytes: size})
+		}
+	}
+	sort.SliceStable(u.Largest, func(i, j int) bool { return u.Largest[i].Bytes > u.Largest[j].Bytes })
+	if len(u.Largest) > top {
+		u.Largest = u.Largest[:top]
+	}
+
+	u.Growth, u.Snapshots, err = growth(sizes)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// repoBytes returns the size of the stash repo's objects, loose and packed.
+func repoBytes() (int64, error) {
+	out, err := gitCmd(Dir(), "count-objects", "-v")
+	if err != nil {
+		return 0, fmt.Errorf("measuring stash: %w", err)
+	}
+	var kib int64
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(line, ": ")
+		if key == "size" || key == "size-pack" {
+			n, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			kib += n
+		}
+	}
+	return kib << 10, nil
+}
+
+// blobSizes returns the size of every blob in the stash repo by hash.
+func blobSizes() (map[string]int64, error) {
+	out, err := gitCmd(Dir(), "cat-file", "--batch-all-objects", "--batch-check=%(objectname) %(objecttype) %(objectsize)")
+	if err != nil {
+		return nil, fmt.Errorf("reading stash objects: %w", err)
+	}
+	sizes := map[string]int64{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		if n, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			sizes[fields[0]] = n
+		}
+	}
+	return sizes, nil
+}
+
+// growth totals, per month, the snapshots made and the size of the file
+// versions, from sizes, they stored for the first time. Also returns the
+// snapshot count.
+func growth(sizes map[string]int64) ([]Growth, int, error) {
+	out, err := gitCmd(Dir(), "log", "--all", "--reverse", "--raw", "--no-abbrev", "--no-renames", "--format=>%aI")
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading stash history: %w", err)
+	}
+	var months []Growth
+	snapshots := 0
+	seen := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, ">"):
+			snapshots++
+			month := strings.TrimPrefix(line, ">")
+			if len(month) >= 7 {
+				month = month[:7]
+			}
+			if len(months) == 0 || months[len(months)-1].Month != month {
+				months = append(months, Growth{Month: month})
+			}
+			months[len(months)-1].Snapshots++
+		case strings.HasPrefix(line, ":") && len(months) > 0:
+			// :<old mode> <new mode> <old hash> <new hash> <status>\t<path>
+			fields := strings.Fields(line)
+			if len(fields) < 5 {
+				continue
+			}
+			hash := fields[3]
+			if size, ok := sizes[hash]; ok && !seen[hash] {
+				seen[hash] = true
+				months[len(months)-1].Bytes += size
+			}
+		}
+	}
+	return months, snapshots, nil
+}
+
+// LargeFiles returns the files entries track whose source is bigger than
+// limit, largest first. Directory entries are checked file by file. A limit
+// of zero or less reports nothing.
+func LargeFiles(entries []Entry, limit int64) ([]Blob, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	var large []Blob
+	check := func(path string) {
+		if info, err := os.Stat(path); err == nil && info.Size() > limit {
+			large = append(large, Blob{Path: path, Bytes: info.Size()})
+		}
+	}
+	for _, e := range entries {
+		if !e.Dir {
+			check(e.Source)
+			continue
+		}
+		files, err := dirFiles(e)
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range files {
+			check(filepath.Join(e.Source, filepath.FromSlash(rel)))
+		}
+	}
+	sort.SliceStable(large, func(i, j int) bool { return large[i].Bytes > large[j].Bytes })
+	return large, nil
+}
+
+// GC compacts the stash repo with git gc, pruning unreachable objects such
+// as those left by a purge. Returns the repo size before and after.
+func GC() (before, after int64, err error) {
+	if !IsGitRepo() {
+		return 0, 0, fmt.Errorf("no version history yet — run `mine stash commit` first")
+	}
+	if before, err = repoBytes(); err != nil {
+		return 0, 0, err
+	}
+	if _, err := gitCmd(Dir(), "gc", "--prune=now", "--quiet"); err != nil {
+		return 0, 0, fmt.Errorf("git gc: %w", err)
+	}
+	if after, err = repoBytes(); err != nil {
+		return 0, 0, err
+	}
+	return before, after, nil
+}
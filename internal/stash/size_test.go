@@ -0,0 +1,99 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiskUsage(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	big := createTestFile(t, homeDir, ".histfile", strings.Repeat("ls\n", 4000))
+	if _, err := TrackFile(big); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(big, []byte(strings.Repeat("cd\n", 5000)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("more history"); err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := DiskUsage(2)
+	if err != nil {
+		t.Fatalf("DiskUsage() error: %v", err)
+	}
+	if usage.RepoBytes <= 0 || usage.Snapshots != 2 {
+		t.Errorf("usage = %d bytes in %d snapshots, want some bytes in 2", usage.RepoBytes, usage.Snapshots)
+	}
+	if len(usage.Largest) != 2 {
+		t.Fatalf("Largest = %v, want the top 2", usage.Largest)
+	}
+	for i, want := range []int64{15000, 12000} {
+		if b := usage.Largest[i]; b.Path != ".histfile" || b.Bytes != want {
+			t.Errorf("Largest[%d] = %+v, want .histfile at %d bytes", i, b, want)
+		}
+	}
+	if len(usage.Growth) != 1 || usage.Growth[0].Snapshots != 2 {
+		t.Fatalf("Growth = %+v, want one month with 2 snapshots", usage.Growth)
+	}
+	// Both .histfile versions, .zshrc, and the manifest (twice) were added.
+	if got := usage.Growth[0].Bytes; got < 27000 {
+		t.Errorf("Growth bytes = %d, want at least both .histfile versions", got)
+	}
+}
+
+func TestDiskUsage_NoHistory(t *testing.T) {
+	setupEnv(t)
+	if _, err := DiskUsage(10); err == nil {
+		t.Error("DiskUsage() without a stash repo should error")
+	}
+}
+
+func TestLargeFiles(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	createTestFile(t, homeDir, ".config/fish/fish_history", strings.Repeat("x", 2048))
+	createTestFile(t, homeDir, ".config/fish/config.fish", "set -x A 1")
+	if _, err := TrackDir(filepath.Join(homeDir, ".config/fish"), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	large, err := LargeFiles(entries, 1024)
+	if err != nil {
+		t.Fatalf("LargeFiles() error: %v", err)
+	}
+	if len(large) != 1 || large[0].Path != filepath.Join(homeDir, ".config/fish/fish_history") || large[0].Bytes != 2048 {
+		t.Errorf("LargeFiles() = %+v, want just fish_history", large)
+	}
+	if large, _ := LargeFiles(entries, 0); len(large) != 0 {
+		t.Errorf("LargeFiles() with no limit = %+v, want none", large)
+	}
+}
+
+func TestGC(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := GC(); err != nil {
+		t.Fatalf("GC() error: %v", err)
+	}
+	if loose, _ := gitCmd(stashDir, "count-objects"); !strings.HasPrefix(loose, "0 objects") {
+		t.Errorf("count-objects after GC = %q, want everything packed", loose)
+	}
+}
@@ -3,6 +3,7 @@ package stash
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -13,10 +14,17 @@ import (
 	"github.com/rnwolfe/mine/internal/config"
 )
 
-// Entry represents a tracked file in the stash.
+// Entry represents a tracked file or directory in the stash.
 type Entry struct {
-	Source   string // Absolute source path
-	SafeName string // Name in stash directory
+	Source    string      // Absolute source path
+	SafeName  string      // Name in stash directory
+	Encrypted bool        // stash copy is age-encrypted; SafeName ends in .age
+	Dir       bool        // Source is a directory, copied recursively
+	Include   []string    // for directories: only files matching these globs (default: all)
+	Exclude   []string    // for directories: files and subdirectories to skip
+	Mode      os.FileMode // for files: permission bits at the last commit (0: not recorded)
+	Link      string      // for files: the source's symlink target at the last commit
+	OnRestore string      // shell command to run after the source is restored
 }
 
 // LogEntry represents a single commit in the stash history.
@@ -37,6 +45,9 @@ func ManifestPath() string {
 	return filepath.Join(Dir(), ".mine-stash")
 }
 
+// ManifestHeader is the comment a new manifest starts with.
+const ManifestHeader = "# mine stash manifest\n# each line: source_path -> safe_name (e.g. ~/.zshrc -> zshrc)\n"
+
 // IsGitRepo returns true if the stash directory is a git repository.
 func IsGitRepo() bool {
 	_, err := os.Stat(filepath.Join(Dir(), ".git"))
@@ -57,8 +68,11 @@ func InitGitRepo() error {
 	if _, err := gitCmd(dir, "init"); err != nil {
 		return fmt.Errorf("git init: %w", err)
 	}
+	return configureGitIdentity(dir)
+}
 
-	// Configure committer identity for the stash repo.
+// configureGitIdentity sets the committer identity for the stash repo.
+func configureGitIdentity(dir string) error {
 	if _, err := gitCmd(dir, "config", "user.name", "mine-stash"); err != nil {
 		return fmt.Errorf("git config user.name: %w", err)
 	}
@@ -79,6 +93,12 @@ func ReadManifest() ([]Entry, error) {
 		return nil, err
 	}
 
+	home, _ := os.UserHomeDir()
+	return parseManifest(data, home)
+}
+
+// parseManifest parses manifest content, rebasing sources onto home.
+func parseManifest(data []byte, home string) ([]Entry, error) {
 	entries := []Entry{} // non-nil: distinguishes "file exists, no entries" from "file missing" (nil)
 	scanner := bufio.NewScanner(bytes.NewReader(data))
 	for scanner.Scan() {
@@ -90,14 +110,27 @@ func ReadManifest() ([]Entry, error) {
 		if len(parts) != 2 {
 			continue
 		}
-		entries = append(entries, Entry{
-			Source:   parts[0],
-			SafeName: parts[1],
-		})
+		e := Entry{Source: parts[0]}
+		parseManifestTarget(&e, parts[1])
+		if !e.Encrypted {
+			e.Encrypted = legacyEncrypted(e)
+		}
+		rebaseHome(&e, home)
+		entries = append(entries, e)
 	}
 	return entries, scanner.Err()
 }
 
+// legacyEncrypted recognizes encrypted entries from manifests written before
+// the encrypted flag: the stash copy carries a .age suffix the source's own
+// name doesn't, so a plain file that happens to be named *.age isn't one.
+func legacyEncrypted(e Entry) bool {
+	if e.Dir || !strings.HasSuffix(e.SafeName, encryptedSuffix) {
+		return false
+	}
+	return !strings.HasSuffix(e.Source, "/"+strings.ReplaceAll(e.SafeName, "__", "/"))
+}
+
 // FindEntry looks up a manifest entry by source path or safe name.
 func FindEntry(name string) (*Entry, error) {
 	entries, err := ReadManifest()
@@ -106,6 +139,9 @@ func FindEntry(name string) (*Entry, error) {
 	}
 
 	home, _ := os.UserHomeDir()
+	if len(name) > 1 {
+		name = strings.TrimSuffix(name, "/") // directories may be named with a trailing slash
+	}
 
 	// First, look for exact / explicit matches.
 	for _, e := range entries {
@@ -150,12 +186,26 @@ func SafeNameFor(source string) string {
 // race). A mutex or file-level lock is required for correct concurrent use.
 // See follow-up issue for the planned fix.
 func TrackFile(source string) (*Entry, error) {
+	return trackFile(source, false)
+}
+
+// TrackFileEncrypted is TrackFile for sensitive files such as .netrc: the
+// stash copy is encrypted with the vault passphrase (see PassphraseFunc), so
+// it can be committed and synced without exposing its contents.
+func TrackFileEncrypted(source string) (*Entry, error) {
+	return trackFile(source, true)
+}
+
+func trackFile(source string, encrypted bool) (*Entry, error) {
 	info, err := os.Stat(source)
 	if err != nil {
 		return nil, fmt.Errorf("can't find %s", source)
 	}
 	if info.IsDir() {
-		return nil, fmt.Errorf("can't track directories yet (coming soon)")
+		if encrypted {
+			return nil, fmt.Errorf("can't encrypt directories — track the sensitive files in %s individually", source)
+		}
+		return TrackDir(source, nil, nil)
 	}
 
 	dir := Dir()
@@ -164,13 +214,36 @@ func TrackFile(source string) (*Entry, error) {
 	}
 
 	safeName := SafeNameFor(source)
+	if encrypted {
+		safeName += encryptedSuffix
+	}
 	dest := filepath.Join(dir, safeName)
 
+	// Switching an existing entry between plain and encrypted would leave
+	// the other copy behind — in plaintext, one way round.
+	entries, err := ReadManifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Source == source && e.Encrypted != encrypted {
+			if e.Encrypted {
+				return nil, fmt.Errorf("%s is already tracked encrypted", source)
+			}
+			return nil, fmt.Errorf("%s is already tracked unencrypted — remove it from the stash manifest first (earlier snapshots keep it in plaintext)", source)
+		}
+	}
+
 	data, err := os.ReadFile(source)
 	if err != nil {
 		return nil, fmt.Errorf("reading %s: %w", source, err)
 	}
-	if err := os.WriteFile(dest, data, info.Mode()); err != nil {
+	if encrypted {
+		err = refreshEncrypted(dest, data, 0o600, &passphrase{})
+	} else {
+		err = os.WriteFile(dest, scrubForStash(source, data), info.Mode())
+	}
+	if err != nil {
 		return nil, fmt.Errorf("writing to stash: %w", err)
 	}
 
@@ -181,19 +254,100 @@ func TrackFile(source string) (*Entry, error) {
 	// is a known TOCTOU limitation; a follow-up issue tracks the fix.
 	manifestPath := ManifestPath()
 	manifest, _ := os.ReadFile(manifestPath)
-	entry := source + " -> " + safeName + "\n"
+	tracked := Entry{Source: source, SafeName: safeName, Encrypted: encrypted}
 	if !strings.Contains(string(manifest), source) {
 		f, err := os.OpenFile(manifestPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
 		if err != nil {
 			return nil, err
 		}
 		defer f.Close()
-		if _, err := f.WriteString(entry); err != nil {
+		if _, err := f.WriteString(manifestLine(tracked) + "\n"); err != nil {
 			return nil, err
 		}
 	}
 
-	return &Entry{Source: source, SafeName: safeName}, nil
+	return &tracked, nil
+}
+
+// TrackDir copies the files under source that match include (all of them
+// when empty) and none of exclude into the stash, and registers the directory
+// in the manifest. Patterns are globs matched against paths relative to
+// source; one without a "/" matches a name at any depth. Tracking a directory
+// again replaces its patterns.
+func TrackDir(source string, include, exclude []string) (*Entry, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("can't find %s", source)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory — include and exclude patterns only apply to directories", source)
+	}
+	if err := ValidatePatterns(include); err != nil {
+		return nil, err
+	}
+	if err := ValidatePatterns(exclude); err != nil {
+		return nil, err
+	}
+
+	dir := Dir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	entry := Entry{
+		Source:   source,
+		SafeName: SafeNameFor(source),
+		Dir:      true,
+		Include:  include,
+		Exclude:  exclude,
+	}
+	entries, err := ReadManifest()
+	if err != nil {
+		return nil, err
+	}
+	replaced := false
+	for i, e := range entries {
+		if e.Source != source {
+			continue
+		}
+		if !e.Dir {
+			return nil, fmt.Errorf("%s is already tracked as a file", source)
+		}
+		entries[i] = entry
+		replaced = true
+	}
+	if !replaced {
+		entries = append(entries, entry)
+	}
+
+	if err := refreshDir(entry); err != nil {
+		return nil, fmt.Errorf("writing to stash: %w", err)
+	}
+	if err := writeManifestEntries(entries); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// writeManifestEntries rewrites the manifest with entries, keeping its
+// comment lines.
+func writeManifestEntries(entries []Entry) error {
+	var buf strings.Builder
+	if data, err := os.ReadFile(ManifestPath()); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "#") {
+				buf.WriteString(line + "\n")
+			}
+		}
+	}
+	for _, e := range entries {
+		buf.WriteString(manifestLine(e) + "\n")
+	}
+	if err := os.WriteFile(ManifestPath(), []byte(buf.String()), 0o644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return nil
 }
 
 // validateSafeName checks that a SafeName is safe for use as a filename in the stash directory.
@@ -247,9 +401,19 @@ func ValidateEntry(e Entry) error {
 	return validateEntryWithHome(e, home)
 }
 
+// ErrNothingToCommit is returned by Commit when no tracked file changed
+// since the last snapshot.
+var ErrNothingToCommit = errors.New("nothing to commit — all files up to date")
+
 // Commit snapshots the current stash state with a message.
 // Initializes the git repo on first commit.
 func Commit(message string) (string, error) {
+	return commit(func([]string) string { return message })
+}
+
+// commit refreshes every tracked file into the stash and commits, with the
+// message msg builds from the stash paths that changed.
+func commit(msg func(changed []string) string) (string, error) {
 	dir := Dir()
 
 	if err := InitGitRepo(); err != nil {
@@ -266,12 +430,20 @@ func Commit(message string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("determining home directory: %w", err)
 	}
-	for _, e := range entries {
+	pass := &passphrase{}
+	metaChanged := false
+	for i, e := range entries {
 		if err := validateEntryWithHome(e, home); err != nil {
 			return "", fmt.Errorf("invalid manifest entry for %s: %w", e.Source, err)
 		}
+		if e.Dir {
+			if err := refreshDir(e); err != nil {
+				return "", err
+			}
+			continue
+		}
 		src := e.Source
-		dst := filepath.Join(dir, e.SafeName)
+		dst := filepath.Join(dir, stashName(e))
 		data, err := os.ReadFile(src)
 		if err != nil {
 			if os.IsNotExist(err) {
@@ -284,17 +456,28 @@ func Commit(message string) (string, error) {
 			// Other read errors should abort the commit.
 			return "", fmt.Errorf("reading source %s: %w", src, err)
 		}
-		// Preserve file mode: prefer existing stash file mode, then source file mode, then default.
-		mode := os.FileMode(0o644)
-		if info, err := os.Stat(dst); err == nil {
-			mode = info.Mode()
-		} else if info, err := os.Stat(src); err == nil {
-			mode = info.Mode()
+		if recordMeta(&entries[i]) {
+			metaChanged = true
+		}
+		if e.Encrypted {
+			// Keep the encrypted copy's mode (0600 when tracked).
+			mode := os.FileMode(0o600)
+			if info, err := os.Stat(dst); err == nil {
+				mode = info.Mode()
+			}
+			err = refreshEncrypted(dst, data, mode, pass)
+		} else {
+			err = writeStashCopy(dst, scrubForStash(src, data), src)
 		}
-		if err := os.WriteFile(dst, data, mode); err != nil {
+		if err != nil {
 			return "", fmt.Errorf("copying %s: %w", e.SafeName, err)
 		}
 	}
+	if metaChanged {
+		if err := writeManifestEntries(entries); err != nil {
+			return "", err
+		}
+	}
 
 	// Stage everything.
 	if _, err := gitCmd(dir, "add", "-A"); err != nil {
@@ -307,11 +490,21 @@ func Commit(message string) (string, error) {
 		return "", fmt.Errorf("git status: %w", err)
 	}
 	if strings.TrimSpace(status) == "" {
-		return "", fmt.Errorf("nothing to commit — all files up to date")
+		return "", ErrNothingToCommit
+	}
+	var changed []string
+	for _, line := range strings.Split(strings.TrimRight(status, "\n"), "\n") {
+		if len(line) > 3 {
+			path := line[3:]
+			if _, to, ok := strings.Cut(path, " -> "); ok {
+				path = to
+			}
+			changed = append(changed, strings.Trim(path, `"`))
+		}
 	}
 
 	// Commit.
-	if _, err := gitCmd(dir, "commit", "-m", message); err != nil {
+	if _, err := gitCmd(dir, "commit", "-m", msg(changed)); err != nil {
 		return "", fmt.Errorf("git commit: %w", err)
 	}
 
@@ -338,6 +531,9 @@ func Log(file string) ([]LogEntry, error) {
 			return nil, err
 		}
 		args = append(args, "--", entry.SafeName)
+		if name := stashName(*entry); name != entry.SafeName {
+			args = append(args, name)
+		}
 	}
 
 	out, err := gitCmd(dir, args...)
@@ -371,24 +567,47 @@ func Log(file string) ([]LogEntry, error) {
 
 // Restore restores a tracked file to a previous version.
 // If version is empty, restores from the latest commit.
+// Encrypted files are returned decrypted. Directories have no single
+// content; restore them with RestoreToSource.
 func Restore(file string, version string) ([]byte, error) {
-	dir := Dir()
-
-	if !IsGitRepo() {
-		return nil, fmt.Errorf("no version history yet — run `mine stash commit` first")
-	}
-
 	entry, err := FindEntry(file)
 	if err != nil {
 		return nil, err
 	}
+	if entry.Dir {
+		return nil, fmt.Errorf("%s is a directory", entry.Source)
+	}
+	stored, err := storedVersion(*entry, file, version)
+	if err != nil {
+		return nil, err
+	}
+	if !entry.Encrypted {
+		return stored, nil
+	}
+	pass, err := (&passphrase{}).get()
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(stored, pass)
+}
+
+// storedVersion returns entry's stash copy as committed at version (HEAD
+// when empty), still encrypted if it is. This host's variant is preferred,
+// falling back to the base file for versions from before the variant existed.
+func storedVersion(entry Entry, file, version string) ([]byte, error) {
+	if !IsGitRepo() {
+		return nil, fmt.Errorf("no version history yet — run `mine stash commit` first")
+	}
 
 	if version == "" {
 		version = "HEAD"
 	}
 
 	// Get the file content at the specified version.
-	content, err := gitCmd(dir, "show", version+":"+entry.SafeName)
+	content, err := gitCmd(Dir(), "show", version+":"+stashName(entry))
+	if err != nil {
+		content, err = gitCmd(Dir(), "show", version+":"+entry.SafeName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("version %s not found for %s", version, file)
 	}
@@ -400,53 +619,70 @@ func Restore(file string, version string) ([]byte, error) {
 // Returns the Entry for the restored file to avoid duplicate FindEntry calls.
 //
 // When force is false (default), the restored file inherits the current source
-// file's permissions, falling back to 0644 if the source does not exist yet.
-// When force is true, the restored file uses the permissions recorded in the
-// stash copy (captured at track/commit time), overriding the current source
-// file's permissions.
+// file's permissions, falling back to the mode recorded at commit time (or
+// 0644) if the source does not exist yet. When force is true, the restored
+// file uses the recorded mode, overriding the current source file's
+// permissions. A source that was a symlink at that version is linked again,
+// with the content written to the file it points at.
+//
+// A directory is restored file by file under the same rules; files in it
+// that the snapshot doesn't have are left in place.
 func RestoreToSource(file string, version string, force bool) (*Entry, error) {
 	entry, err := FindEntry(file)
 	if err != nil {
 		return nil, err
 	}
+	if entry.Dir {
+		if err := restoreDirToSource(*entry, file, version, force); err != nil {
+			return nil, err
+		}
+		afterRestore(*entry)
+		return entry, nil
+	}
 
-	content, err := Restore(file, version)
+	stored, err := storedVersion(*entry, file, version)
 	if err != nil {
 		return nil, err
 	}
+	then := recordedAt(*entry, version)
+	content := stored
+	if entry.Encrypted {
+		pass, err := (&passphrase{}).get()
+		if err != nil {
+			return nil, err
+		}
+		if content, err = decrypt(stored, pass); err != nil {
+			return nil, err
+		}
+	}
 
-	stashPath := filepath.Join(Dir(), entry.SafeName)
+	stashPath := filepath.Join(Dir(), stashName(*entry))
+
+	// Secrets scrubbed from the snapshot come back from the file as it is now.
+	if current, err := os.ReadFile(entry.Source); err == nil {
+		content = unscrub(content, current)
+	}
 
-	// Determine permissions for the restored file.
-	// In all cases the existing source file is removed before recreating so that
-	// read-only source files (e.g. 0444) can be written without a permission
-	// error — on most Unix filesystems, the directory write permission governs
-	// deletion, not the file's own mode bits.
-	srcPerm := os.FileMode(0o644)
-	if force {
-		// Use permissions from the stash copy (captured at track/commit time),
-		// ignoring the current source file's permissions.
-		if info, err := os.Stat(stashPath); err == nil {
+	// Determine permissions for the restored file: the mode recorded at
+	// commit time, or for snapshots from before modes were recorded, the
+	// stash copy's. Without force an existing source keeps its own.
+	srcPerm := then.Mode
+	if srcPerm == 0 {
+		srcPerm = 0o644
+		if info, err := os.Stat(stashPath); force && err == nil {
 			srcPerm = info.Mode().Perm()
 		}
-		if err := os.Remove(entry.Source); err != nil && !os.IsNotExist(err) {
-			return nil, fmt.Errorf("removing existing %s before restore: %w", entry.Source, err)
-		}
-	} else {
-		// Preserve existing source file permissions when present,
-		// otherwise fall back to 0644.
+	}
+	if !force {
 		if info, err := os.Stat(entry.Source); err == nil {
 			srcPerm = info.Mode().Perm()
-			if err := os.Remove(entry.Source); err != nil && !os.IsNotExist(err) {
-				return nil, fmt.Errorf("removing existing %s before restore: %w", entry.Source, err)
-			}
 		} else if !os.IsNotExist(err) {
 			return nil, fmt.Errorf("stat source %s: %w", entry.Source, err)
 		}
 	}
 
-	if err := os.WriteFile(entry.Source, content, srcPerm); err != nil {
-		return nil, fmt.Errorf("writing to %s: %w", entry.Source, err)
+	if err := writeSource(then, content, srcPerm); err != nil {
+		return nil, err
 	}
 
 	// Also update the stash copy.
@@ -457,17 +693,26 @@ func RestoreToSource(file string, version string, force bool) (*Entry, error) {
 		return nil, fmt.Errorf("stat stash copy %s: %w", stashPath, err)
 	}
 
-	if err := os.WriteFile(stashPath, content, stashPerm); err != nil {
+	if err := os.WriteFile(stashPath, stored, stashPerm); err != nil {
 		return nil, fmt.Errorf("updating stash copy: %w", err)
 	}
 
+	afterRestore(*entry)
 	return entry, nil
 }
 
 // gitCmd runs a git command in the stash directory and returns stdout.
+// Commands that would open an editor, like rebase --continue, keep the
+// message git proposes.
 func gitCmd(dir string, args ...string) (string, error) {
+	return gitCmdEnv(dir, nil, args...)
+}
+
+// gitCmdEnv is gitCmd with extra environment variables.
+func gitCmdEnv(dir string, env []string, args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), "GIT_EDITOR=true"), env...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -754,38 +754,38 @@ func TestRestoreToSourceForce(t *testing.T) {
 		name     string
 		// srcPerm is the permission set on the source file before restore.
 		srcPerm  os.FileMode
-		// stashPerm is the permission to set on the stash copy (simulates commit-time mode).
-		stashPerm os.FileMode
+		// commitPerm is the source's permission when committed, recorded in the manifest.
+		commitPerm os.FileMode
 		force    bool
 		wantPerm os.FileMode
 	}{
 		{
-			name:      "force=false preserves source permissions",
-			srcPerm:   0o444,
-			stashPerm: 0o644,
-			force:     false,
-			wantPerm:  0o444,
+			name:       "force=false preserves source permissions",
+			srcPerm:    0o444,
+			commitPerm: 0o644,
+			force:      false,
+			wantPerm:   0o444,
 		},
 		{
-			name:      "force=true uses stash-recorded permissions",
-			srcPerm:   0o444,
-			stashPerm: 0o644,
-			force:     true,
-			wantPerm:  0o644,
+			name:       "force=true uses stash-recorded permissions",
+			srcPerm:    0o444,
+			commitPerm: 0o644,
+			force:      true,
+			wantPerm:   0o644,
 		},
 		{
-			name:      "force=true with executable stash mode",
-			srcPerm:   0o600,
-			stashPerm: 0o755,
-			force:     true,
-			wantPerm:  0o755,
+			name:       "force=true with executable stash mode",
+			srcPerm:    0o600,
+			commitPerm: 0o755,
+			force:      true,
+			wantPerm:   0o755,
 		},
 		{
-			name:      "force=false with executable source mode",
-			srcPerm:   0o755,
-			stashPerm: 0o644,
-			force:     false,
-			wantPerm:  0o755,
+			name:       "force=false with executable source mode",
+			srcPerm:    0o755,
+			commitPerm: 0o644,
+			force:      false,
+			wantPerm:   0o755,
 		},
 	}
 
@@ -794,6 +794,9 @@ func TestRestoreToSourceForce(t *testing.T) {
 			stashDir, homeDir := setupTestEnv(t)
 			source := createTestFile(t, homeDir, ".zshrc", "content v1")
 			setupManifest(t, stashDir, source, ".zshrc", "content v1")
+			if err := os.Chmod(source, tt.commitPerm); err != nil {
+				t.Fatal(err)
+			}
 
 			if _, err := Commit("initial"); err != nil {
 				t.Fatalf("Commit() error: %v", err)
@@ -803,11 +806,6 @@ func TestRestoreToSourceForce(t *testing.T) {
 			if err := os.Chmod(source, tt.srcPerm); err != nil {
 				t.Fatal(err)
 			}
-			// Override stash copy permission to simulate the commit-time mode.
-			stashCopy := filepath.Join(stashDir, ".zshrc")
-			if err := os.Chmod(stashCopy, tt.stashPerm); err != nil {
-				t.Fatal(err)
-			}
 
 			entry, err := RestoreToSource(".zshrc", "", tt.force)
 			if err != nil {
@@ -841,6 +839,10 @@ func TestRestoreToSourceForce_NoSourceFile(t *testing.T) {
 
 	source := createTestFile(t, homeDir, ".zshrc", "content v1")
 	setupManifest(t, stashDir, source, ".zshrc", "content v1")
+	// Commit with a specific permission to verify the recorded mode is used.
+	if err := os.Chmod(source, 0o755); err != nil {
+		t.Fatalf("os.Chmod(source) error: %v", err)
+	}
 
 	if _, err := Commit("initial"); err != nil {
 		t.Fatalf("Commit() error: %v", err)
@@ -851,12 +853,6 @@ func TestRestoreToSourceForce_NoSourceFile(t *testing.T) {
 		t.Fatalf("os.Remove(source) error: %v", err)
 	}
 
-	// Set a specific permission on the stash copy to verify it's used.
-	stashCopy := filepath.Join(stashDir, ".zshrc")
-	if err := os.Chmod(stashCopy, 0o755); err != nil {
-		t.Fatalf("os.Chmod(stashCopy) error: %v", err)
-	}
-
 	entry, err := RestoreToSource(".zshrc", "", true)
 	if err != nil {
 		t.Fatalf("RestoreToSource(force=true, no source) error: %v", err)
@@ -1,6 +1,7 @@
 package stash
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -57,8 +58,65 @@ func SyncPush() error {
 	return nil
 }
 
-// SyncPull pulls from the configured remote.
+// PullStrategy says how SyncPull reconciles a stash whose history has
+// diverged from the remote's: both sides committed since they last synced.
+type PullStrategy string
+
+const (
+	// PullFastForward only pulls when this stash has nothing the remote
+	// lacks; otherwise SyncPull changes nothing and returns a *DivergedError.
+	PullFastForward PullStrategy = ""
+	// PullRebase replays this machine's snapshots on top of the remote's.
+	PullRebase PullStrategy = "rebase"
+	// PullMerge joins both histories with a merge commit.
+	PullMerge PullStrategy = "merge"
+	// PullOurs merges, keeping this machine's copy of every conflicting file.
+	PullOurs PullStrategy = "ours"
+	// PullTheirs merges, keeping the remote's copy of every conflicting file.
+	PullTheirs PullStrategy = "theirs"
+)
+
+// PullStrategies lists the strategies a user can pick, in the order offered.
+var PullStrategies = []PullStrategy{PullRebase, PullMerge, PullOurs, PullTheirs}
+
+// ConflictResolver picks the side to keep for a stash file both machines
+// changed: PullOurs for this machine's copy, PullTheirs for the remote's.
+// Returning anything else, or an error, abandons the pull.
+type ConflictResolver func(file string) (PullStrategy, error)
+
+// DivergedError reports a pull that needs a strategy because both this stash
+// and the remote have snapshots the other lacks.
+type DivergedError struct {
+	Ahead  int      // snapshots only this stash has
+	Behind int      // snapshots only the remote has
+	Files  []string // stash files changed on both sides
+}
+
+func (e *DivergedError) Error() string {
+	return fmt.Sprintf("stash and remote have diverged (%d local, %d remote snapshot(s)) — pull again with --strategy rebase, merge, ours, or theirs", e.Ahead, e.Behind)
+}
+
+// ConflictError reports a pull abandoned over files that conflict and that
+// nothing chose a side for. The stash is left as it was before the pull.
+type ConflictError struct {
+	Files []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("pull abandoned, stash unchanged — %s conflict(s) need a side: pull again with --strategy ours or theirs", strings.Join(e.Files, ", "))
+}
+
+// SyncPull pulls from the configured remote, fast-forward only.
 func SyncPull() error {
+	return SyncPullWith(PullFastForward, nil)
+}
+
+// SyncPullWith pulls from the configured remote, reconciling diverged
+// histories with strategy, then restores tracked files to their source
+// locations. During a rebase or merge, resolve picks the side of each
+// conflicting file; with it nil, conflicts abandon the pull. Whatever goes
+// wrong, the stash repo is put back the way it was.
+func SyncPullWith(strategy PullStrategy, resolve ConflictResolver) error {
 	dir := Dir()
 	if !IsGitRepo() {
 		return fmt.Errorf("no version history yet — run `mine stash commit` first")
@@ -70,8 +128,61 @@ func SyncPull() error {
 		return fmt.Errorf("no remote configured — run `mine stash sync remote <url>` first")
 	}
 
-	if _, err := gitCmd(dir, "pull", "--rebase", "origin"); err != nil {
-		return fmt.Errorf("pull failed — you may need to resolve conflicts manually in %s: %w", dir, err)
+	switch strategy {
+	case PullFastForward, PullRebase, PullMerge:
+	case PullOurs, PullTheirs:
+		resolve = func(string) (PullStrategy, error) { return strategy, nil }
+	default:
+		return fmt.Errorf("unknown pull strategy %q — use rebase, merge, ours, or theirs", strategy)
+	}
+
+	if err := checkPullable(dir); err != nil {
+		return err
+	}
+	branch, err := gitCmd(dir, "branch", "--show-current")
+	if err != nil {
+		return fmt.Errorf("getting branch: %w", err)
+	}
+	branch = strings.TrimSpace(branch)
+
+	if _, err := gitCmd(dir, "fetch", "origin"); err != nil {
+		return fmt.Errorf("fetching from remote: %w", err)
+	}
+	upstream := "origin/" + branch
+	if _, err := gitCmd(dir, "rev-parse", "--verify", "--quiet", upstream); err != nil {
+		return fmt.Errorf("the remote has no %s branch yet — run `mine stash sync push` first", branch)
+	}
+
+	counts, err := gitCmd(dir, "rev-list", "--left-right", "--count", "HEAD..."+upstream)
+	if err != nil {
+		return fmt.Errorf("comparing with remote: %w", err)
+	}
+	var ahead, behind int
+	if _, err := fmt.Sscan(counts, &ahead, &behind); err != nil {
+		return fmt.Errorf("comparing with remote: unexpected output %q", counts)
+	}
+
+	switch {
+	case behind == 0:
+		// Up to date, or only ahead: nothing to pull.
+	case ahead == 0:
+		if _, err := gitCmd(dir, "merge", "--ff-only", upstream); err != nil {
+			return fmt.Errorf("pull failed: %w", err)
+		}
+	case strategy == PullFastForward:
+		files, err := divergedFiles(dir, upstream)
+		if err != nil {
+			return err
+		}
+		return &DivergedError{Ahead: ahead, Behind: behind, Files: files}
+	case strategy == PullRebase:
+		if err := rebaseOnto(dir, upstream, resolve); err != nil {
+			return abandonPull(dir, "rebase", err)
+		}
+	default:
+		if err := mergeFrom(dir, upstream, resolve); err != nil {
+			return abandonPull(dir, "merge", err)
+		}
 	}
 
 	// After pull, restore tracked files to their source locations.
@@ -79,47 +190,172 @@ func SyncPull() error {
 	if err != nil {
 		return fmt.Errorf("reading manifest: %w", err)
 	}
+	return applyEntries(entries)
+}
+
+// checkPullable refuses to pull into a stash that's mid-merge or
+// mid-rebase, detached, or has uncommitted changes, saying how to fix it.
+func checkPullable(dir string) error {
+	switch unfinishedOp(dir) {
+	case "rebase":
+		return fmt.Errorf("a rebase is in progress in %s — finish it with `git rebase --continue` or undo it with `git rebase --abort`, then pull again", dir)
+	case "merge":
+		return fmt.Errorf("a merge is in progress in %s — finish it with `git commit` or undo it with `git merge --abort`, then pull again", dir)
+	}
+	branch, err := gitCmd(dir, "branch", "--show-current")
+	if err != nil {
+		return fmt.Errorf("getting branch: %w", err)
+	}
+	if strings.TrimSpace(branch) == "" {
+		return fmt.Errorf("the stash repo in %s isn't on a branch — run `git switch main` there, then pull again", dir)
+	}
+	status, err := gitCmd(dir, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return fmt.Errorf("git status: %w", err)
+	}
+	if strings.TrimSpace(status) != "" {
+		return fmt.Errorf("the stash has uncommitted changes — run `mine stash commit` first, then pull")
+	}
+	return nil
+}
 
-	// Resolve home dir once for all entry validation.
-	home, err := os.UserHomeDir()
+// unfinishedOp returns "rebase" or "merge" when one is stopped partway in
+// the stash repo, else "".
+func unfinishedOp(dir string) string {
+	gitDir := filepath.Join(dir, ".git")
+	for _, marker := range []string{"rebase-merge", "rebase-apply"} {
+		if _, err := os.Stat(filepath.Join(gitDir, marker)); err == nil {
+			return "rebase"
+		}
+	}
+	if _, err := os.Stat(filepath.Join(gitDir, "MERGE_HEAD")); err == nil {
+		return "merge"
+	}
+	return ""
+}
+
+// divergedFiles returns the stash files changed both locally and on
+// upstream since they split.
+func divergedFiles(dir, upstream string) ([]string, error) {
+	base, err := gitCmd(dir, "merge-base", "HEAD", upstream)
+	if err != nil {
+		return nil, fmt.Errorf("finding common history: %w", err)
+	}
+	base = strings.TrimSpace(base)
+	local, err := gitCmd(dir, "diff", "--name-only", base, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("listing local changes: %w", err)
+	}
+	theirs, err := gitCmd(dir, "diff", "--name-only", base, upstream)
 	if err != nil {
-		return fmt.Errorf("determining home directory: %w", err)
+		return nil, fmt.Errorf("listing remote changes: %w", err)
 	}
-	for _, e := range entries {
-		// Validate SafeName and Source path safety invariants.
-		if err := validateEntryWithHome(e, home); err != nil {
-			return fmt.Errorf("invalid manifest entry for %s: %w", e.Source, err)
+	changed := map[string]bool{}
+	for _, f := range strings.Fields(local) {
+		changed[f] = true
+	}
+	var both []string
+	for _, f := range strings.Fields(theirs) {
+		if changed[f] {
+			both = append(both, f)
 		}
-		srcPath := filepath.Clean(e.Source)
+	}
+	return both, nil
+}
+
+// conflictedFiles returns the files git couldn't merge.
+func conflictedFiles(dir string) []string {
+	out, _ := gitCmd(dir, "diff", "--name-only", "--diff-filter=U")
+	return strings.Fields(out)
+}
 
-		stashPath := filepath.Join(dir, e.SafeName)
-		data, err := os.ReadFile(stashPath)
+// resolveConflicts settles every conflicted file with the side resolve
+// picks. During a rebase git's "ours" is the remote, so the sides swap.
+func resolveConflicts(dir string, files []string, resolve ConflictResolver, rebasing bool) error {
+	if resolve == nil {
+		return &ConflictError{Files: files}
+	}
+	for _, f := range files {
+		side, err := resolve(f)
 		if err != nil {
-			// If the stash file is missing, skip this entry; other errors are fatal.
-			if os.IsNotExist(err) {
-				continue
-			}
-			return fmt.Errorf("reading stash file %s: %w", e.SafeName, err)
+			return err
 		}
-
-		// Preserve existing file mode if the source file already exists.
-		// Remove before recreating so that read-only source files (e.g. 0444)
-		// can be written without a permission error (same strategy as RestoreToSource).
-		mode := os.FileMode(0o644)
-		if info, err := os.Stat(srcPath); err == nil {
-			mode = info.Mode().Perm()
-			if err := os.Remove(srcPath); err != nil && !os.IsNotExist(err) {
-				return fmt.Errorf("removing existing %s before sync restore: %w", e.Source, err)
+		if side != PullOurs && side != PullTheirs {
+			return &ConflictError{Files: files}
+		}
+		flag := "--ours"
+		if (side == PullOurs) == rebasing {
+			flag = "--theirs"
+		}
+		if _, err := gitCmd(dir, "checkout", flag, "--", f); err != nil {
+			// The chosen side deleted the file.
+			if _, err := gitCmd(dir, "rm", "--quiet", "--", f); err != nil {
+				return fmt.Errorf("resolving %s: %w", f, err)
 			}
+			continue
+		}
+		if _, err := gitCmd(dir, "add", "--", f); err != nil {
+			return fmt.Errorf("resolving %s: %w", f, err)
 		}
+	}
+	return nil
+}
 
-		if err := os.WriteFile(srcPath, data, mode); err != nil {
-			return fmt.Errorf("restoring %s: %w", e.Source, err)
+// mergeFrom merges upstream into the current branch.
+func mergeFrom(dir, upstream string, resolve ConflictResolver) error {
+	_, err := gitCmd(dir, "merge", "--no-edit", upstream)
+	if err == nil {
+		return nil
+	}
+	files := conflictedFiles(dir)
+	if len(files) == 0 {
+		return err
+	}
+	if err := resolveConflicts(dir, files, resolve, false); err != nil {
+		return err
+	}
+	_, err = gitCmd(dir, "commit", "--no-edit")
+	return err
+}
+
+// rebaseOnto replays local snapshots on top of upstream, resolving
+// conflicts commit by commit. A snapshot left empty by its resolution is
+// dropped.
+func rebaseOnto(dir, upstream string, resolve ConflictResolver) error {
+	_, err := gitCmd(dir, "rebase", upstream)
+	for err != nil {
+		files := conflictedFiles(dir)
+		if len(files) == 0 {
+			return err
+		}
+		if err := resolveConflicts(dir, files, resolve, true); err != nil {
+			return err
+		}
+		if _, staged := gitCmd(dir, "diff", "--cached", "--quiet"); staged == nil {
+			_, err = gitCmd(dir, "rebase", "--skip")
+		} else {
+			_, err = gitCmd(dir, "rebase", "--continue")
 		}
 	}
 	return nil
 }
 
+// abandonPull undoes a failed merge or rebase so the stash is back where it
+// started, and explains what happened. If even that fails, the error says
+// how to finish the job by hand.
+func abandonPull(dir, op string, cause error) error {
+	if unfinishedOp(dir) == op {
+		if _, err := gitCmd(dir, op, "--abort"); err != nil {
+			return fmt.Errorf("%w — and undoing the %s failed (%v); run `git %s --abort` in %s before using the stash", cause, op, err, op, dir)
+		}
+	}
+	var conflict *ConflictError
+	if errors.As(cause, &conflict) {
+		return cause
+	}
+	return fmt.Errorf("pull abandoned, stash unchanged — %s failed: %w", op, cause)
+}
+
 // SyncRemoteURL returns the configured remote URL, or empty string if none.
 func SyncRemoteURL() string {
 	dir := Dir()
@@ -0,0 +1,216 @@
+package stash
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupDiverged gives the stash and its remote one snapshot each since they
+// last synced. Both start with .zshrc and .bashrc at "base"; this machine
+// sets .zshrc to "local", and another machine sets remoteFile to "remote".
+// Returns the stash dir and the home dir.
+func setupDiverged(t *testing.T, remoteFile string) (string, string) {
+	t.Helper()
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "base")
+	bashrc := createTestFile(t, homeDir, ".bashrc", "base")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "base")
+	if _, err := TrackFile(bashrc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+
+	remoteDir := t.TempDir()
+	if _, err := gitCmd(remoteDir, "init", "--bare"); err != nil {
+		t.Fatalf("git init --bare: %v", err)
+	}
+	if err := SyncSetRemote("file://" + remoteDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := SyncPush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another machine pushes its own snapshot.
+	branch, _ := gitCmd(stashDir, "branch", "--show-current")
+	other := t.TempDir()
+	if _, err := gitCmd(other, "clone", "--quiet", "-b", strings.TrimSpace(branch), "file://"+remoteDir, "."); err != nil {
+		t.Fatalf("git clone: %v", err)
+	}
+	if err := configureGitIdentity(other); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(other, remoteFile), []byte("remote"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"commit", "-qam", "remote edit"}, {"push", "--quiet", "origin", "HEAD"}} {
+		if _, err := gitCmd(other, args...); err != nil {
+			t.Fatalf("git %s: %v", args[0], err)
+		}
+	}
+
+	// Meanwhile this machine snapshots a change of its own.
+	if err := os.WriteFile(zshrc, []byte("local"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("local edit"); err != nil {
+		t.Fatal(err)
+	}
+	return stashDir, homeDir
+}
+
+func readHome(t *testing.T, homeDir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(homeDir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSyncPull_Diverged(t *testing.T) {
+	stashDir, homeDir := setupDiverged(t, ".zshrc")
+	head, _ := gitCmd(stashDir, "rev-parse", "HEAD")
+
+	err := SyncPull()
+	var diverged *DivergedError
+	if !errors.As(err, &diverged) {
+		t.Fatalf("SyncPull() error = %v, want *DivergedError", err)
+	}
+	if diverged.Ahead != 1 || diverged.Behind != 1 {
+		t.Errorf("ahead/behind = %d/%d, want 1/1", diverged.Ahead, diverged.Behind)
+	}
+	if len(diverged.Files) != 1 || diverged.Files[0] != ".zshrc" {
+		t.Errorf("Files = %v, want [.zshrc]", diverged.Files)
+	}
+	if after, _ := gitCmd(stashDir, "rev-parse", "HEAD"); after != head {
+		t.Error("a diverged pull without a strategy should leave the stash alone")
+	}
+	if got := readHome(t, homeDir, ".zshrc"); got != "local" {
+		t.Errorf(".zshrc = %q, want local", got)
+	}
+}
+
+func TestSyncPullWith_Theirs(t *testing.T) {
+	_, homeDir := setupDiverged(t, ".zshrc")
+	if err := SyncPullWith(PullTheirs, nil); err != nil {
+		t.Fatalf("SyncPullWith(theirs) error: %v", err)
+	}
+	if got := readHome(t, homeDir, ".zshrc"); got != "remote" {
+		t.Errorf(".zshrc = %q, want remote", got)
+	}
+}
+
+func TestSyncPullWith_Ours(t *testing.T) {
+	_, homeDir := setupDiverged(t, ".zshrc")
+	if err := SyncPullWith(PullOurs, nil); err != nil {
+		t.Fatalf("SyncPullWith(ours) error: %v", err)
+	}
+	if got := readHome(t, homeDir, ".zshrc"); got != "local" {
+		t.Errorf(".zshrc = %q, want local", got)
+	}
+	// The remote's snapshot is part of history now, so a push goes through.
+	if err := SyncPush(); err != nil {
+		t.Errorf("SyncPush() after merge error: %v", err)
+	}
+}
+
+func TestSyncPullWith_RebaseResolvesPerFile(t *testing.T) {
+	stashDir, homeDir := setupDiverged(t, ".zshrc")
+	var asked []string
+	resolve := func(file string) (PullStrategy, error) {
+		asked = append(asked, file)
+		return PullOurs, nil
+	}
+	if err := SyncPullWith(PullRebase, resolve); err != nil {
+		t.Fatalf("SyncPullWith(rebase) error: %v", err)
+	}
+	if len(asked) != 1 || asked[0] != ".zshrc" {
+		t.Errorf("resolver asked about %v, want [.zshrc]", asked)
+	}
+	if got := readHome(t, homeDir, ".zshrc"); got != "local" {
+		t.Errorf(".zshrc = %q, want local", got)
+	}
+	merges, _ := gitCmd(stashDir, "rev-list", "--merges", "HEAD")
+	if strings.TrimSpace(merges) != "" {
+		t.Error("rebase should keep history linear")
+	}
+	if op := unfinishedOp(stashDir); op != "" {
+		t.Errorf("stash left mid-%s", op)
+	}
+}
+
+func TestSyncPullWith_RebaseNoConflict(t *testing.T) {
+	_, homeDir := setupDiverged(t, ".bashrc")
+	if err := SyncPullWith(PullRebase, nil); err != nil {
+		t.Fatalf("SyncPullWith(rebase) error: %v", err)
+	}
+	if got := readHome(t, homeDir, ".zshrc"); got != "local" {
+		t.Errorf(".zshrc = %q, want local", got)
+	}
+	if got := readHome(t, homeDir, ".bashrc"); got != "remote" {
+		t.Errorf(".bashrc = %q, want remote", got)
+	}
+}
+
+func TestSyncPullWith_UnresolvedConflictAbandons(t *testing.T) {
+	for _, strategy := range []PullStrategy{PullMerge, PullRebase} {
+		t.Run(string(strategy), func(t *testing.T) {
+			stashDir, homeDir := setupDiverged(t, ".zshrc")
+			head, _ := gitCmd(stashDir, "rev-parse", "HEAD")
+
+			err := SyncPullWith(strategy, nil)
+			var conflict *ConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("SyncPullWith(%s) error = %v, want *ConflictError", strategy, err)
+			}
+			if op := unfinishedOp(stashDir); op != "" {
+				t.Errorf("stash left mid-%s", op)
+			}
+			if after, _ := gitCmd(stashDir, "rev-parse", "HEAD"); after != head {
+				t.Error("an abandoned pull should leave HEAD where it was")
+			}
+			if branch, _ := gitCmd(stashDir, "branch", "--show-current"); strings.TrimSpace(branch) == "" {
+				t.Error("an abandoned pull should leave the stash on its branch")
+			}
+			if got := readHome(t, homeDir, ".zshrc"); got != "local" {
+				t.Errorf(".zshrc = %q, want local", got)
+			}
+		})
+	}
+}
+
+func TestSyncPullWith_ResolverAborts(t *testing.T) {
+	stashDir, _ := setupDiverged(t, ".zshrc")
+	stop := errors.New("user quit")
+	err := SyncPullWith(PullMerge, func(string) (PullStrategy, error) { return "", stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("error = %v, want the resolver's error", err)
+	}
+	if op := unfinishedOp(stashDir); op != "" {
+		t.Errorf("stash left mid-%s", op)
+	}
+}
+
+func TestSyncPull_UncommittedChanges(t *testing.T) {
+	stashDir, _ := setupDiverged(t, ".zshrc")
+	if err := os.WriteFile(filepath.Join(stashDir, ".zshrc"), []byte("edited"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := SyncPullWith(PullMerge, nil)
+	if err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Errorf("error = %v, want uncommitted changes", err)
+	}
+}
+
+func TestSyncPullWith_UnknownStrategy(t *testing.T) {
+	setupDiverged(t, ".zshrc")
+	if err := SyncPullWith("yolo", nil); err == nil {
+		t.Error("SyncPullWith() with an unknown strategy should error")
+	}
+}
@@ -0,0 +1,185 @@
+package stash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Untrack stops tracking file: it drops the manifest entry and deletes the
+// stash copy and any host variants, leaving the source file alone. Like
+// TrackFile, it doesn't commit; the next snapshot records the removal.
+//
+// With purge, it also rewrites the stash's git history so no snapshot ever
+// contained the file — for a secret tracked by mistake. That commits the
+// removal itself, so other changes to the stash must be committed first, and
+// leaves any remote with the old history until it's force-pushed. If the
+// rewrite fails, the stash is reset to where it was, file still tracked.
+// Reports whether history was rewritten: it isn't if the file was never
+// committed.
+func Untrack(file string, purge bool) (*Entry, bool, error) {
+	entry, err := FindEntry(file)
+	if err != nil {
+		return nil, false, err
+	}
+	dir := Dir()
+	var paths []string
+	var head string
+	if purge && IsGitRepo() {
+		if paths, err = historyPaths(*entry); err != nil {
+			return nil, false, err
+		}
+	}
+	if len(paths) > 0 {
+		status, err := gitCmd(dir, "status", "--porcelain", "--untracked-files=no")
+		if err != nil {
+			return nil, false, fmt.Errorf("git status: %w", err)
+		}
+		if strings.TrimSpace(status) != "" {
+			return nil, false, fmt.Errorf("the stash has uncommitted changes — run `mine stash commit` first, then purge")
+		}
+		if head, err = gitCmd(dir, "rev-parse", "HEAD"); err != nil {
+			return nil, false, fmt.Errorf("git rev-parse: %w", err)
+		}
+		head = strings.TrimSpace(head)
+	}
+
+	entries, err := ReadManifest()
+	if err != nil {
+		return nil, false, fmt.Errorf("reading manifest: %w", err)
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Source != entry.Source {
+			kept = append(kept, e)
+		}
+	}
+	if err := writeManifestEntries(kept); err != nil {
+		return nil, false, resetStash(head, err)
+	}
+	if err := removeStashCopies(*entry); err != nil {
+		return nil, false, resetStash(head, err)
+	}
+
+	if len(paths) == 0 {
+		return entry, false, nil
+	}
+	if err := purgeHistory(*entry, paths, head); err != nil {
+		return nil, false, err
+	}
+	return entry, true, nil
+}
+
+// resetStash undoes a failed purge by resetting the stash to head, which
+// brings back the manifest entry and the committed stash copies, then
+// returns err. With no head, there's nothing to reset to.
+func resetStash(head string, err error) error {
+	if head == "" {
+		return err
+	}
+	if _, resetErr := gitCmd(Dir(), "reset", "--hard", "--quiet", head); resetErr != nil {
+		return fmt.Errorf("%w (restoring the stash also failed: %v)", err, resetErr)
+	}
+	return err
+}
+
+// removeStashCopies deletes e's stash copy and host variants.
+func removeStashCopies(e Entry) error {
+	if err := validateSafeName(e.SafeName); err != nil {
+		return fmt.Errorf("invalid manifest entry for %s: %w", e.Source, err)
+	}
+	dir := Dir()
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading stash: %w", err)
+	}
+	for _, f := range files {
+		name := f.Name()
+		if name != e.SafeName && !strings.HasPrefix(name, e.SafeName+variantSep) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("removing %s from stash: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// historyPaths returns every path e has had in any commit: its base copy,
+// the files inside it if it's a directory, and variants for any host.
+func historyPaths(e Entry) ([]string, error) {
+	out, err := gitCmd(Dir(), "log", "--all", "--format=", "--name-only")
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	seen := map[string]bool{}
+	var paths []string
+	for _, p := range strings.Split(out, "\n") {
+		if seen[p] {
+			continue
+		}
+		if p == e.SafeName || strings.HasPrefix(p, e.SafeName+"/") || strings.HasPrefix(p, e.SafeName+variantSep) {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// purgeHistory commits e's removal and rewrites every commit to drop paths,
+// then discards the old commits so they're gone from the local repo too.
+// Until the rewrite succeeds, a failure resets the stash to head.
+func purgeHistory(e Entry, paths []string, head string) error {
+	dir := Dir()
+	home, _ := os.UserHomeDir()
+	display := strings.Replace(e.Source, home, "~", 1)
+	if _, err := gitCmd(dir, "add", "--update"); err != nil {
+		return resetStash(head, fmt.Errorf("git add: %w", err))
+	}
+	if _, err := gitCmd(dir, "commit", "-m", "untrack "+display); err != nil {
+		return resetStash(head, fmt.Errorf("git commit: %w", err))
+	}
+
+	list, err := os.CreateTemp("", "mine-stash-purge-*")
+	if err != nil {
+		return resetStash(head, fmt.Errorf("creating path list: %w", err))
+	}
+	defer os.Remove(list.Name())
+	for _, p := range paths {
+		fmt.Fprintf(list, "%s\x00", p)
+	}
+	if err := list.Close(); err != nil {
+		return resetStash(head, fmt.Errorf("writing path list: %w", err))
+	}
+
+	filter := "git --literal-pathspecs rm -r -q --cached --ignore-unmatch --pathspec-file-nul --pathspec-from-file=" + shellQuote(list.Name())
+	if _, err := gitCmdEnv(dir, []string{"FILTER_BRANCH_SQUELCH_WARNING=1"},
+		"filter-branch", "--force", "--prune-empty", "--index-filter", filter, "--", "--all"); err != nil {
+		return resetStash(head, fmt.Errorf("rewriting history: %w", err))
+	}
+
+	// filter-branch keeps the old commits under refs/original; drop them,
+	// and the reflog entries that point at them, so gc can delete them.
+	refs, err := gitCmd(dir, "for-each-ref", "--format=%(refname)", "refs/original/")
+	if err != nil {
+		return fmt.Errorf("listing backup refs: %w", err)
+	}
+	for _, ref := range strings.Fields(refs) {
+		if _, err := gitCmd(dir, "update-ref", "-d", ref); err != nil {
+			return fmt.Errorf("removing %s: %w", ref, err)
+		}
+	}
+	if _, err := gitCmd(dir, "reflog", "expire", "--expire=now", "--all"); err != nil {
+		return fmt.Errorf("expiring reflog: %w", err)
+	}
+	if _, err := gitCmd(dir, "gc", "--prune=now", "--quiet"); err != nil {
+		return fmt.Errorf("git gc: %w", err)
+	}
+	return nil
+}
+
+// shellQuote quotes s for the shell git runs filters in.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,190 @@
+package stash
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUntrack(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	t.Setenv("MINE_STASH_HOST", "work-laptop")
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	bashrc := createTestFile(t, homeDir, ".bashrc", "export B=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	if _, err := TrackFile(bashrc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TrackVariant(zshrc); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, purged, err := Untrack("~/.zshrc", false)
+	if err != nil {
+		t.Fatalf("Untrack() error: %v", err)
+	}
+	if entry.Source != zshrc || purged {
+		t.Errorf("Untrack() = %s, purged %v", entry.Source, purged)
+	}
+	if _, err := FindEntry(zshrc); err == nil {
+		t.Error(".zshrc is still in the manifest")
+	}
+	if _, err := FindEntry(bashrc); err != nil {
+		t.Errorf(".bashrc should still be tracked: %v", err)
+	}
+	for _, name := range []string{".zshrc", ".zshrc@work-laptop"} {
+		if _, err := os.Stat(filepath.Join(stashDir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s should be gone from the stash", name)
+		}
+	}
+	if got := readHome(t, homeDir, ".zshrc"); got != "export A=1" {
+		t.Errorf("source = %q, want it untouched", got)
+	}
+}
+
+func TestUntrack_NotTracked(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	source := createTestFile(t, homeDir, ".zshrc", "x")
+	setupManifest(t, stashDir, source, ".zshrc", "x")
+	if _, _, err := Untrack("~/.vimrc", false); err == nil {
+		t.Error("Untrack() of an untracked file should error")
+	}
+}
+
+func TestUntrack_Purge(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	netrc := createTestFile(t, homeDir, ".netrc", "password hunter2")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	if _, err := TrackFile(netrc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(netrc, []byte("password hunter3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("rotate"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, purged, err := Untrack(netrc, true)
+	if err != nil {
+		t.Fatalf("Untrack(purge) error: %v", err)
+	}
+	if !purged {
+		t.Error("Untrack(purge) should report history rewritten")
+	}
+
+	// No object in the repo holds the secret any more.
+	objects, err := gitCmd(stashDir, "rev-list", "--all", "--objects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(objects), "\n") {
+		hash, _, _ := strings.Cut(line, " ")
+		content, _ := gitCmd(stashDir, "cat-file", "-p", hash)
+		if strings.Contains(content, "hunter") {
+			t.Errorf("object %s still holds the secret", line)
+		}
+	}
+	if refs, _ := gitCmd(stashDir, "for-each-ref", "refs/original/"); refs != "" {
+		t.Errorf("filter-branch backup refs should be removed:\n%s", refs)
+	}
+
+	// The rest of the history survives.
+	hist, err := Log(zshrc)
+	if err != nil || len(hist) == 0 {
+		t.Errorf("Log(.zshrc) = %v, %v; want history kept", hist, err)
+	}
+	if status, _ := gitCmd(stashDir, "status", "--porcelain"); strings.TrimSpace(status) != "" {
+		t.Errorf("stash left with changes:\n%s", status)
+	}
+}
+
+func TestUntrack_PurgeNeverCommitted(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+	netrc := createTestFile(t, homeDir, ".netrc", "password hunter2")
+	if _, err := TrackFile(netrc); err != nil {
+		t.Fatal(err)
+	}
+
+	_, purged, err := Untrack(netrc, true)
+	if err != nil {
+		t.Fatalf("Untrack(purge) error: %v", err)
+	}
+	if purged {
+		t.Error("nothing to purge for a file never committed")
+	}
+	if _, err := os.Stat(filepath.Join(stashDir, ".netrc")); !os.IsNotExist(err) {
+		t.Error(".netrc should be gone from the stash")
+	}
+}
+
+func TestUntrack_PurgeNeedsCleanStash(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	zshrc := createTestFile(t, homeDir, ".zshrc", "export A=1")
+	netrc := createTestFile(t, homeDir, ".netrc", "password hunter2")
+	setupManifest(t, stashDir, zshrc, ".zshrc", "export A=1")
+	if _, err := TrackFile(netrc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(stashDir, ".zshrc"), []byte("export A=2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := Untrack(netrc, true)
+	if err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Fatalf("Untrack(purge) error = %v, want uncommitted changes", err)
+	}
+	if _, err := FindEntry(netrc); err != nil {
+		t.Error("a refused purge should leave the entry tracked")
+	}
+}
+
+func TestUntrack_PurgeFailureKeepsEntry(t *testing.T) {
+	stashDir, homeDir := setupEnv(t)
+	netrc := createTestFile(t, homeDir, ".netrc", "password hunter2")
+	if _, err := TrackFile(netrc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit("initial"); err != nil {
+		t.Fatal(err)
+	}
+	head, _ := gitCmd(stashDir, "rev-parse", "HEAD")
+
+	// A hook that rejects every commit makes the purge fail partway.
+	hook := filepath.Join(stashDir, ".git", "hooks", "pre-commit")
+	if err := os.MkdirAll(filepath.Dir(hook), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := Untrack(netrc, true); err == nil {
+		t.Fatal("Untrack(purge) should fail when the commit is rejected")
+	}
+	if _, err := FindEntry(netrc); err != nil {
+		t.Errorf("a failed purge should leave the entry tracked: %v", err)
+	}
+	if got, err := os.ReadFile(filepath.Join(stashDir, ".netrc")); err != nil || string(got) != "password hunter2" {
+		t.Errorf("stash copy = %q, %v; want it restored", got, err)
+	}
+	if now, _ := gitCmd(stashDir, "rev-parse", "HEAD"); now != head {
+		t.Errorf("HEAD moved from %s to %s", head, now)
+	}
+	if status, _ := gitCmd(stashDir, "status", "--porcelain"); strings.TrimSpace(status) != "" {
+		t.Errorf("stash left with changes:\n%s", status)
+	}
+}
@@ -123,6 +123,42 @@ func (db *DB) migrate() error {
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_projects_name ON projects(name)`,
 		`CREATE INDEX IF NOT EXISTS idx_projects_path ON projects(path)`,
+		// Named groups of projects (e.g. "work", "oss")
+		`CREATE TABLE IF NOT EXISTS project_groups (
+			name TEXT PRIMARY KEY,
+			created_at TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS project_group_members (
+			group_name TEXT NOT NULL,
+			project_name TEXT NOT NULL,
+			PRIMARY KEY (group_name, project_name)
+		)`,
+		// Freeform notes on projects
+		`CREATE TABLE IF NOT EXISTS project_notes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			project_name TEXT NOT NULL,
+			body TEXT NOT NULL,
+			created_at TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_project_notes_project ON project_notes(project_name)`,
+		// Projects that are git worktrees of another registered project
+		`CREATE TABLE IF NOT EXISTS project_worktrees (
+			project_name TEXT PRIMARY KEY,
+			parent_name TEXT NOT NULL,
+			branch TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_project_worktrees_parent ON project_worktrees(parent_name)`,
+		// Tmux session snapshots for 'mine tmux save/restore'
+		`CREATE TABLE IF NOT EXISTS tmux_snapshots (
+			session TEXT PRIMARY KEY,
+			layout TEXT NOT NULL,
+			saved_at TEXT NOT NULL
+		)`,
+		// When mine last attached to each tmux session, for 'mine tmux last'
+		`CREATE TABLE IF NOT EXISTS tmux_attaches (
+			session TEXT PRIMARY KEY,
+			attached_at TEXT NOT NULL
+		)`,
 		// Timestamped notes/annotations on todos
 		`CREATE TABLE IF NOT EXISTS todo_notes (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -131,6 +167,102 @@ func (db *DB) migrate() error {
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_todo_notes_todo_id ON todo_notes(todo_id)`,
+		// Todo dependencies — todo_id cannot start until depends_on is done.
+		`CREATE TABLE IF NOT EXISTS todo_deps (
+			todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+			depends_on INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (todo_id, depends_on)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_todo_deps_depends_on ON todo_deps(depends_on)`,
+		// Undo journal — pre-mutation snapshots of todos, grouped by batch.
+		`CREATE TABLE IF NOT EXISTS todo_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			batch TEXT NOT NULL,
+			action TEXT NOT NULL,
+			todo_id INTEGER NOT NULL,
+			snapshot TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_todo_history_batch ON todo_history(batch)`,
+		// Archived completed todos — moved out of todos by "mine todo archive".
+		// IDs are preserved; todos uses AUTOINCREMENT so they are never reused.
+		`CREATE TABLE IF NOT EXISTS todos_archive (
+			id INTEGER PRIMARY KEY,
+			title TEXT NOT NULL,
+			body TEXT DEFAULT '',
+			priority INTEGER DEFAULT 2,
+			done INTEGER DEFAULT 1,
+			due_date TEXT,
+			due_time TEXT,
+			tags TEXT DEFAULT '',
+			project_path TEXT,
+			schedule TEXT DEFAULT 'later',
+			recurrence TEXT DEFAULT 'none',
+			created_at DATETIME,
+			updated_at DATETIME,
+			completed_at DATETIME,
+			parent_id INTEGER,
+			archived_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_todos_archive_completed_at ON todos_archive(completed_at)`,
+		`CREATE TABLE IF NOT EXISTS todo_notes_archive (
+			id INTEGER PRIMARY KEY,
+			todo_id INTEGER NOT NULL,
+			body TEXT NOT NULL,
+			created_at DATETIME
+		)`,
+		// Named todo templates — items is a JSON tree of tasks and subtasks.
+		`CREATE TABLE IF NOT EXISTS todo_templates (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			items TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// Todo reminders and delivered due-date alerts. kind is 'remind' for
+		// user-set reminders and 'due' for alerts fired when a task came due.
+		`CREATE TABLE IF NOT EXISTS todo_reminders (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+			kind TEXT NOT NULL DEFAULT 'remind',
+			remind_at TEXT NOT NULL,
+			fired_at DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_todo_reminders_pending ON todo_reminders(fired_at, remind_at)`,
+		// Links between todos and items in external trackers (e.g. GitHub issues).
+		// todo_id is cleared when the todo is deleted so the item is not re-imported.
+		`CREATE TABLE IF NOT EXISTS todo_links (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			todo_id INTEGER REFERENCES todos(id) ON DELETE SET NULL,
+			provider TEXT NOT NULL,
+			external_id TEXT NOT NULL,
+			url TEXT DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(provider, external_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_todo_links_todo_id ON todo_links(todo_id)`,
+		// Named todo filter expressions ("mine todo filter save").
+		`CREATE TABLE IF NOT EXISTS todo_filters (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			expr TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// Files and URLs attached to todos ("mine todo attach").
+		`CREATE TABLE IF NOT EXISTS todo_attachments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+			target TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_todo_attachments_todo_id ON todo_attachments(todo_id)`,
+		`CREATE TABLE IF NOT EXISTS todo_attachments_archive (
+			id INTEGER PRIMARY KEY,
+			todo_id INTEGER NOT NULL,
+			target TEXT NOT NULL,
+			created_at DATETIME
+		)`,
 		// Dig focus sessions — nullable todo_id links sessions to tasks.
 		`CREATE TABLE IF NOT EXISTS dig_sessions (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -187,6 +319,16 @@ func (db *DB) migrate() error {
 		`ALTER TABLE todos ADD COLUMN project_path TEXT`,
 		`ALTER TABLE todos ADD COLUMN schedule TEXT DEFAULT 'later'`,
 		`ALTER TABLE todos ADD COLUMN recurrence TEXT DEFAULT 'none'`,
+		`ALTER TABLE todos ADD COLUMN due_time TEXT`,
+		`ALTER TABLE todos ADD COLUMN parent_id INTEGER REFERENCES todos(id) ON DELETE SET NULL`,
+		`ALTER TABLE todos ADD COLUMN context TEXT`,
+		`ALTER TABLE todos_archive ADD COLUMN context TEXT`,
+		`ALTER TABLE todos ADD COLUMN estimate_mins INTEGER`,
+		`ALTER TABLE todos_archive ADD COLUMN estimate_mins INTEGER`,
+		`ALTER TABLE todos ADD COLUMN pinned INTEGER DEFAULT 0`,
+		`ALTER TABLE todos_archive ADD COLUMN pinned INTEGER DEFAULT 0`,
+		`ALTER TABLE todos ADD COLUMN waiting_on TEXT`,
+		`ALTER TABLE todos_archive ADD COLUMN waiting_on TEXT`,
 	}
 	for _, m := range alterMigrations {
 		if _, err := db.conn.Exec(m); err != nil {
@@ -200,6 +342,9 @@ func (db *DB) migrate() error {
 	if _, err := db.conn.Exec(`CREATE INDEX IF NOT EXISTS idx_todos_project_path ON todos(project_path)`); err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
+	if _, err := db.conn.Exec(`CREATE INDEX IF NOT EXISTS idx_todos_parent_id ON todos(parent_id)`); err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,134 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MinVersion is the oldest tmux release mine supports.
+const MinVersion = "3.0"
+
+// Version returns the installed tmux version, e.g. "3.3a".
+func Version() (string, error) {
+	out, err := tmuxCmd("-V")
+	if err != nil {
+		return "", fmt.Errorf("getting tmux version: %w", err)
+	}
+	return strings.TrimPrefix(strings.TrimSpace(out), "tmux "), nil
+}
+
+// VersionAtLeast reports whether version v (as returned by Version) is at
+// least min ("major.minor"). Versions without a number, like development
+// builds named "master", count as new enough.
+func VersionAtLeast(v, min string) bool {
+	major, minor, ok := parseVersion(v)
+	if !ok {
+		return true
+	}
+	wantMajor, wantMinor, _ := parseVersion(min)
+	if major != wantMajor {
+		return major > wantMajor
+	}
+	return minor >= wantMinor
+}
+
+// parseVersion reads "major.minor" from versions like "3.3a" or "next-3.4".
+func parseVersion(v string) (int, int, bool) {
+	start := strings.IndexAny(v, "0123456789")
+	if start < 0 {
+		return 0, 0, false
+	}
+	majorStr, rest, _ := strings.Cut(v[start:], ".")
+	major, err := strconv.Atoi(majorStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	end := 0
+	for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+		end++
+	}
+	minor, _ := strconv.Atoi(rest[:end])
+	return major, minor, true
+}
+
+// OptionConflict is a global tmux option set in a way that breaks mine.
+type OptionConflict struct {
+	Option  string
+	Value   string
+	Problem string
+	Fix     string // tmux.conf line that resolves it
+}
+
+// conflictingOptions are the options OptionConflicts checks: the show-options
+// flags that read each one, its safe value, and what goes wrong otherwise.
+var conflictingOptions = []struct {
+	option, flags, safe, problem string
+}{
+	{"exit-unattached", "-sv", "off", "the server exits when you detach, taking detached sessions with it"},
+	{"destroy-unattached", "-gv", "off", "sessions mine creates in the background are destroyed immediately"},
+	{"pane-base-index", "-gwv", "0", "layouts address panes from 0, so panes get skipped or misplaced"},
+}
+
+// OptionConflicts reads the global options from the user's tmux
+// configuration and returns those that break mine's session handling. The
+// server is started if needed so tmux.conf is loaded.
+func OptionConflicts() ([]OptionConflict, error) {
+	args := []string{"start-server"}
+	for _, o := range conflictingOptions {
+		args = append(args, ";", "show-options", o.flags, o.option)
+	}
+	out, err := tmuxCmd(args...)
+	if err != nil {
+		return nil, fmt.Errorf("reading tmux options: %w", err)
+	}
+
+	values := strings.Split(out, "\n")
+	var conflicts []OptionConflict
+	for i, o := range conflictingOptions {
+		if i >= len(values) {
+			break
+		}
+		if v := strings.TrimSpace(values[i]); v != "" && v != o.safe {
+			conflicts = append(conflicts, OptionConflict{
+				Option:  o.option,
+				Value:   v,
+				Problem: o.problem,
+				Fix:     fmt.Sprintf("set -g %s %s", o.option, o.safe),
+			})
+		}
+	}
+	return conflicts, nil
+}
+
+// OrphanedSession is a running session whose starting directory is gone.
+type OrphanedSession struct {
+	Name string
+	Dir  string
+}
+
+// OrphanedSessions returns running sessions whose starting directory no
+// longer exists, e.g. a project or worktree deleted while its session ran.
+func OrphanedSessions() ([]OrphanedSession, error) {
+	sessions, err := ListSessions()
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	out, err := tmuxCmd("list-sessions", "-F", "#{session_name}\t#{session_path}")
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+
+	var orphans []OrphanedSession
+	for _, line := range strings.Split(out, "\n") {
+		name, dir, ok := strings.Cut(line, "\t")
+		if !ok || dir == "" {
+			continue
+		}
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			orphans = append(orphans, OrphanedSession{Name: name, Dir: dir})
+		}
+	}
+	return orphans, nil
+}
@@ -0,0 +1,100 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		v    string
+		want bool
+	}{
+		{"3.3a", true},
+		{"3.0", true},
+		{"2.9a", false},
+		{"1.8", false},
+		{"next-3.5", true},
+		{"10.1", true},
+		{"master", true},
+	}
+	for _, tt := range tests {
+		if got := VersionAtLeast(tt.v, MinVersion); got != tt.want {
+			t.Errorf("VersionAtLeast(%q) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestVersion(t *testing.T) {
+	orig := tmuxCmd
+	t.Cleanup(func() { tmuxCmd = orig })
+	tmuxCmd = func(args ...string) (string, error) { return "tmux 3.4\n", nil }
+
+	v, err := Version()
+	if err != nil || v != "3.4" {
+		t.Fatalf("Version = %q, %v", v, err)
+	}
+}
+
+func TestOptionConflicts(t *testing.T) {
+	orig := tmuxCmd
+	t.Cleanup(func() { tmuxCmd = orig })
+
+	var got []string
+	tmuxCmd = func(args ...string) (string, error) {
+		got = args
+		return "off\non\n1", nil
+	}
+
+	conflicts, err := OptionConflicts()
+	if err != nil {
+		t.Fatalf("OptionConflicts: %v", err)
+	}
+	if got[0] != "start-server" || !strings.Contains(strings.Join(got, " "), "; show-options -gwv pane-base-index") {
+		t.Fatalf("unexpected tmux args: %q", got)
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts, got %+v", conflicts)
+	}
+	if conflicts[0].Option != "destroy-unattached" || conflicts[0].Fix != "set -g destroy-unattached off" {
+		t.Errorf("conflict[0] = %+v", conflicts[0])
+	}
+	if conflicts[1].Option != "pane-base-index" || conflicts[1].Value != "1" {
+		t.Errorf("conflict[1] = %+v", conflicts[1])
+	}
+}
+
+func TestOrphanedSessions(t *testing.T) {
+	origCmd, origList := tmuxCmd, listSessionsFunc
+	t.Cleanup(func() { tmuxCmd, listSessionsFunc = origCmd, origList })
+
+	live := t.TempDir()
+	listSessionsFunc = func() ([]Session, error) { return []Session{{Name: "api"}, {Name: "old"}}, nil }
+	tmuxCmd = func(args ...string) (string, error) {
+		return "api\t" + live + "\nold\t/no/such/dir", nil
+	}
+
+	orphans, err := OrphanedSessions()
+	if err != nil {
+		t.Fatalf("OrphanedSessions: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0].Name != "old" || orphans[0].Dir != "/no/such/dir" {
+		t.Fatalf("orphans = %+v", orphans)
+	}
+}
+
+func TestOrphanedSessions_NoServer(t *testing.T) {
+	origCmd, origList := tmuxCmd, listSessionsFunc
+	t.Cleanup(func() { tmuxCmd, listSessionsFunc = origCmd, origList })
+
+	listSessionsFunc = func() ([]Session, error) { return nil, nil }
+	tmuxCmd = func(args ...string) (string, error) {
+		t.Fatalf("unexpected tmux call: %q", args)
+		return "", nil
+	}
+
+	orphans, err := OrphanedSessions()
+	if err != nil || len(orphans) != 0 {
+		t.Fatalf("orphans = %+v, %v", orphans, err)
+	}
+}
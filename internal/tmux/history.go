@@ -0,0 +1,62 @@
+package tmux
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// History records when mine last attached to or left each session, since
+// tmux itself doesn't expose a usable most-recent ordering.
+type History struct {
+	db *sql.DB
+}
+
+// NewHistory returns a History backed by db.
+func NewHistory(db *sql.DB) *History {
+	return &History{db: db}
+}
+
+// Touch records session as active at t.
+func (h *History) Touch(session string, t time.Time) error {
+	if _, err := h.db.Exec(
+		`INSERT INTO tmux_attaches (session, attached_at) VALUES (?, ?)
+		 ON CONFLICT(session) DO UPDATE SET attached_at = excluded.attached_at`,
+		session, t.UTC().Format(time.RFC3339Nano),
+	); err != nil {
+		return fmt.Errorf("recording session attach: %w", err)
+	}
+	return nil
+}
+
+// Rename carries a session's history over to its new name.
+func (h *History) Rename(oldName, newName string) error {
+	if _, err := h.db.Exec(`DELETE FROM tmux_attaches WHERE session = ?`, newName); err != nil {
+		return fmt.Errorf("renaming session history: %w", err)
+	}
+	if _, err := h.db.Exec(`UPDATE tmux_attaches SET session = ? WHERE session = ?`, newName, oldName); err != nil {
+		return fmt.Errorf("renaming session history: %w", err)
+	}
+	return nil
+}
+
+// Last returns the most recently active of the running sessions, skipping
+// exclude (the session you're in), or "" if none has been recorded.
+func (h *History) Last(running []Session, exclude string) (string, error) {
+	rows, err := h.db.Query(`SELECT session FROM tmux_attaches ORDER BY attached_at DESC`)
+	if err != nil {
+		return "", fmt.Errorf("reading session history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return "", fmt.Errorf("scanning session history: %w", err)
+		}
+		if name != exclude && FindSessionByName(name, running) != nil {
+			return name, nil
+		}
+	}
+	return "", rows.Err()
+}
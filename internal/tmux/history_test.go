@@ -0,0 +1,59 @@
+package tmux
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func setupHistory(t *testing.T) *History {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE tmux_attaches (
+		session TEXT PRIMARY KEY,
+		attached_at TEXT NOT NULL
+	)`); err != nil {
+		t.Fatal(err)
+	}
+	return NewHistory(db)
+}
+
+func TestHistoryLast(t *testing.T) {
+	h := setupHistory(t)
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	running := []Session{{Name: "api"}, {Name: "web"}, {Name: "docs"}}
+
+	if name, err := h.Last(running, ""); err != nil || name != "" {
+		t.Fatalf("empty history: got %q, %v", name, err)
+	}
+
+	for i, name := range []string{"docs", "gone", "web", "api"} {
+		if err := h.Touch(name, now.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if name, _ := h.Last(running, ""); name != "api" {
+		t.Errorf("Last = %q, want api", name)
+	}
+	if name, _ := h.Last(running, "api"); name != "web" {
+		t.Errorf("Last excluding the current session = %q, want web", name)
+	}
+	// Sessions that are no longer running are skipped.
+	if name, _ := h.Last(running[:1], "api"); name != "" {
+		t.Errorf("Last with only the current session running = %q, want none", name)
+	}
+
+	if err := h.Rename("web", "frontend"); err != nil {
+		t.Fatal(err)
+	}
+	running[1].Name = "frontend"
+	if name, _ := h.Last(running, "api"); name != "frontend" {
+		t.Errorf("Last after rename = %q, want frontend", name)
+	}
+}
@@ -11,7 +11,20 @@ import (
 	"github.com/rnwolfe/mine/internal/config"
 )
 
-// Layout represents a saved tmux window/pane arrangement.
+// Layout represents a saved tmux window/pane arrangement. Layouts are
+// either captured with SaveLayout or written by hand, e.g. in a project's
+// .mine/layouts directory:
+//
+//	[[windows]]
+//	name = "dev"
+//	layout = "main-vertical"
+//
+//	[[windows.panes]]
+//	command = "nvim ."
+//
+//	[[windows.panes]]
+//	dir = "web"
+//	command = "npm run dev"
 type Layout struct {
 	Name    string         `toml:"name"`
 	SavedAt time.Time      `toml:"saved_at"`
@@ -20,18 +33,34 @@ type Layout struct {
 
 // WindowLayout describes a single window within a layout.
 type WindowLayout struct {
-	Name       string       `toml:"name"`
-	Layout     string       `toml:"layout"` // tmux layout string (e.g. "main-vertical")
-	PaneCount  int          `toml:"pane_count"`
-	Panes      []PaneLayout `toml:"panes"`
+	Name   string `toml:"name"`
+	Layout string `toml:"layout"` // tmux layout string (e.g. "main-vertical")
+	// PaneCount may be omitted in hand-written layouts; the window then
+	// gets one pane per entry in Panes.
+	PaneCount int          `toml:"pane_count"`
+	Panes     []PaneLayout `toml:"panes"`
 }
 
 // PaneLayout describes a single pane within a window.
 type PaneLayout struct {
-	Dir     string `toml:"dir"`
+	// Dir may be relative to the session's starting directory.
+	Dir string `toml:"dir"`
+	// Command is typed into the pane after the cd. Shells are skipped, so
+	// captured layouts don't start a nested shell.
 	Command string `toml:"command,omitempty"`
 }
 
+// ProjectLayoutDir is where a project keeps its own layouts, relative to
+// the project root.
+const ProjectLayoutDir = ".mine/layouts"
+
+// shells are pane commands that mean "nothing running" rather than a
+// startup command.
+var shells = map[string]bool{
+	"bash": true, "zsh": true, "fish": true, "sh": true, "dash": true,
+	"ksh": true, "tcsh": true, "csh": true, "nu": true, "pwsh": true,
+}
+
 // layoutDir returns the directory where layouts are stored.
 func layoutDir() string {
 	return filepath.Join(config.GetPaths().ConfigDir, "tmux", "layouts")
@@ -51,6 +80,21 @@ func SaveLayout(name string) error {
 	return writeLayout(layout)
 }
 
+// SaveProjectLayout captures the current session's layout into the
+// project rooted at root, returning the file written.
+func SaveProjectLayout(name, root string) (string, error) {
+	layout, err := captureLayout(name)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(root, ProjectLayoutDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name+".toml")
+	return path, writeLayoutFile(path, layout)
+}
+
 // LoadLayout restores a saved layout into the current session.
 func LoadLayout(name string) error {
 	layout, err := ReadLayout(name)
@@ -60,19 +104,81 @@ func LoadLayout(name string) error {
 	return applyLayout(layout)
 }
 
-// LoadLayoutToSession applies a saved layout to a named session without
-// requiring the caller to be inside that session.
-func LoadLayoutToSession(layoutName, sessionName string) error {
-	layout, err := ReadLayout(layoutName)
+// ApplyLayout applies an already-read layout to the current session.
+func ApplyLayout(layout *Layout) error {
+	return applyLayout(layout)
+}
+
+// ApplyLayoutToSession applies an already-read layout to a named session.
+func ApplyLayoutToSession(layout *Layout, sessionName string) error {
+	return applyLayoutToSession(layout, sessionName)
+}
+
+// FindLayout reads the named layout, preferring a project layout in
+// ProjectLayoutDir of dir or one of its parents (up to the enclosing git
+// repo) over the global one of the same name.
+func FindLayout(name, dir string) (*Layout, error) {
+	if path := findProjectLayout(name, dir); path != "" {
+		return readLayoutFile(path, name)
+	}
+	return ReadLayout(name)
+}
+
+// ListProjectLayouts returns the names of the project layouts visible from
+// dir, or nil outside a project with layouts.
+func ListProjectLayouts(dir string) ([]string, error) {
+	root := projectLayoutRoot(dir)
+	if root == "" {
+		return nil, nil
+	}
+	return listLayoutFiles(filepath.Join(root, ProjectLayoutDir))
+}
+
+// findProjectLayout returns the path of a project layout visible from dir,
+// or "" if there is none.
+func findProjectLayout(name, dir string) string {
+	root := projectLayoutRoot(dir)
+	if root == "" {
+		return ""
+	}
+	path := filepath.Join(root, ProjectLayoutDir, name+".toml")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// projectLayoutRoot walks up from dir to the nearest directory containing
+// ProjectLayoutDir, stopping at the enclosing git repo's root.
+func projectLayoutRoot(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	dir, err := filepath.Abs(dir)
 	if err != nil {
-		return err
+		return ""
+	}
+	for {
+		if info, err := os.Stat(filepath.Join(dir, ProjectLayoutDir)); err == nil && info.IsDir() {
+			return dir
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
 	}
-	return applyLayoutToSession(layout, sessionName)
 }
 
 // ReadLayout reads a layout from disk without applying it.
 func ReadLayout(name string) (*Layout, error) {
-	path := layoutPath(name)
+	return readLayoutFile(layoutPath(name), name)
+}
+
+func readLayoutFile(path, name string) (*Layout, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -85,12 +191,18 @@ func ReadLayout(name string) (*Layout, error) {
 	if err := toml.Unmarshal(data, &layout); err != nil {
 		return nil, fmt.Errorf("parsing layout %q: %w", name, err)
 	}
+	if layout.Name == "" {
+		layout.Name = name
+	}
 	return &layout, nil
 }
 
 // ListLayouts returns the names of all saved layouts.
 func ListLayouts() ([]string, error) {
-	dir := layoutDir()
+	return listLayoutFiles(layoutDir())
+}
+
+func listLayoutFiles(dir string) ([]string, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -125,9 +237,19 @@ func DeleteLayout(name string) error {
 
 // captureLayout reads the current tmux session and builds a Layout.
 func captureLayout(name string) (*Layout, error) {
-	// Get windows in current session.
-	out, err := tmuxCmd("list-windows", "-F",
-		"#{window_name}\t#{window_layout}\t#{window_panes}")
+	return captureSession(name, "")
+}
+
+// captureSession reads the named tmux session, or the current one when
+// session is empty, and builds a Layout.
+func captureSession(name, session string) (*Layout, error) {
+	args := []string{"list-windows", "-F", "#{window_name}\t#{window_layout}\t#{window_panes}"}
+	windowPrefix := ""
+	if session != "" {
+		args = append(args, "-t", session)
+		windowPrefix = session + ":"
+	}
+	out, err := tmuxCmd(args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing windows: %w", err)
 	}
@@ -153,7 +275,7 @@ func captureLayout(name string) (*Layout, error) {
 		}
 
 		// Capture pane details for this window.
-		paneOut, err := tmuxCmd("list-panes", "-t", parts[0], "-F",
+		paneOut, err := tmuxCmd("list-panes", "-t", windowPrefix+parts[0], "-F",
 			"#{pane_current_path}\t#{pane_current_command}")
 		if err == nil {
 			for _, paneLine := range strings.Split(paneOut, "\n") {
@@ -183,7 +305,10 @@ func writeLayout(layout *Layout) error {
 		return err
 	}
 
-	path := layoutPath(layout.Name)
+	return writeLayoutFile(layoutPath(layout.Name), layout)
+}
+
+func writeLayoutFile(path string, layout *Layout) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return err
@@ -218,7 +343,7 @@ func applyLayoutToSession(layout *Layout, sessionName string) error {
 		windowTarget := sessionName + ":" + w.Name
 
 		// Create additional panes.
-		for j := 1; j < w.PaneCount; j++ {
+		for j := 1; j < w.paneCount(); j++ {
 			if _, err := tmuxCmd("split-window", "-t", windowTarget); err != nil {
 				return fmt.Errorf("splitting pane %d in window %q: %w", j, w.Name, err)
 			}
@@ -231,14 +356,10 @@ func applyLayoutToSession(layout *Layout, sessionName string) error {
 			}
 		}
 
-		// Set pane directories.
+		// Set pane directories and start their commands.
 		for j, p := range w.Panes {
-			if p.Dir != "" {
-				paneTarget := fmt.Sprintf("%s:%s.%d", sessionName, w.Name, j)
-				if _, err := tmuxCmd("send-keys", "-t", paneTarget,
-					fmt.Sprintf("cd %q && clear", p.Dir), "Enter"); err != nil {
-					return fmt.Errorf("sending cd to pane %s: %w", paneTarget, err)
-				}
+			if err := sendPaneKeys(fmt.Sprintf("%s:%s.%d", sessionName, w.Name, j), p); err != nil {
+				return err
 			}
 		}
 	}
@@ -274,7 +395,7 @@ func applyLayout(layout *Layout) error {
 		}
 
 		// Create additional panes.
-		for j := 1; j < w.PaneCount; j++ {
+		for j := 1; j < w.paneCount(); j++ {
 			if _, err := tmuxCmd("split-window", "-t", w.Name); err != nil {
 				return fmt.Errorf("splitting pane %d in window %q: %w", j, w.Name, err)
 			}
@@ -287,14 +408,10 @@ func applyLayout(layout *Layout) error {
 			}
 		}
 
-		// Set pane directories.
+		// Set pane directories and start their commands.
 		for j, p := range w.Panes {
-			if p.Dir != "" {
-				paneTarget := fmt.Sprintf("%s.%d", w.Name, j)
-				if _, err := tmuxCmd("send-keys", "-t", paneTarget,
-					fmt.Sprintf("cd %q && clear", p.Dir), "Enter"); err != nil {
-					return fmt.Errorf("sending cd to pane %s: %w", paneTarget, err)
-				}
+			if err := sendPaneKeys(fmt.Sprintf("%s.%d", w.Name, j), p); err != nil {
+				return err
 			}
 		}
 	}
@@ -308,3 +425,30 @@ func applyLayout(layout *Layout) error {
 
 	return nil
 }
+
+// paneCount is the number of panes to create for w.
+func (w WindowLayout) paneCount() int {
+	return max(w.PaneCount, len(w.Panes))
+}
+
+// sendPaneKeys types a pane's cd and startup command into paneTarget.
+func sendPaneKeys(paneTarget string, p PaneLayout) error {
+	if p.Dir != "" {
+		dir := p.Dir
+		if strings.HasPrefix(dir, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				dir = filepath.Join(home, dir[2:])
+			}
+		}
+		if _, err := tmuxCmd("send-keys", "-t", paneTarget,
+			fmt.Sprintf("cd %q && clear", dir), "Enter"); err != nil {
+			return fmt.Errorf("sending cd to pane %s: %w", paneTarget, err)
+		}
+	}
+	if cmd := strings.TrimSpace(p.Command); cmd != "" && !shells[strings.TrimPrefix(cmd, "-")] {
+		if _, err := tmuxCmd("send-keys", "-t", paneTarget, cmd, "Enter"); err != nil {
+			return fmt.Errorf("starting %q in pane %s: %w", cmd, paneTarget, err)
+		}
+	}
+	return nil
+}
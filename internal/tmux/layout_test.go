@@ -466,3 +466,113 @@ func TestApplyLayout_ErrorPropagation(t *testing.T) {
 		t.Fatalf("error should mention creating window, got: %v", err)
 	}
 }
+
+func TestFindLayout_PrefersProjectLayout(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if err := WriteLayout(&Layout{Name: "dev", Windows: []WindowLayout{{Name: "global"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a project layout, the global one is used.
+	layout, err := FindLayout("dev", sub)
+	if err != nil || layout.Windows[0].Name != "global" {
+		t.Fatalf("expected global layout, got %+v, %v", layout, err)
+	}
+
+	spec := `[[windows]]
+name = "code"
+
+[[windows.panes]]
+command = "nvim ."
+
+[[windows.panes]]
+dir = "web"
+command = "npm run dev"
+`
+	if err := os.MkdirAll(filepath.Join(root, ProjectLayoutDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ProjectLayoutDir, "dev.toml"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	layout, err = FindLayout("dev", sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layout.Name != "dev" || layout.Windows[0].Name != "code" || layout.Windows[0].paneCount() != 2 {
+		t.Fatalf("expected project layout, got %+v", layout)
+	}
+	names, err := ListProjectLayouts(sub)
+	if err != nil || len(names) != 1 || names[0] != "dev" {
+		t.Errorf("ListProjectLayouts = %v, %v", names, err)
+	}
+
+	// The search stops at the repo root.
+	outside := t.TempDir()
+	if names, _ := ListProjectLayouts(outside); names != nil {
+		t.Errorf("unexpected project layouts outside the project: %v", names)
+	}
+}
+
+func TestApplyLayoutToSession_StartsCommands(t *testing.T) {
+	origCmd := tmuxCmd
+	defer func() { tmuxCmd = origCmd }()
+
+	var commands []string
+	tmuxCmd = func(args ...string) (string, error) {
+		commands = append(commands, strings.Join(args, " "))
+		return "", nil
+	}
+
+	layout := &Layout{Windows: []WindowLayout{{
+		Name: "code",
+		Panes: []PaneLayout{
+			{Command: "nvim ."},
+			{Dir: "web", Command: "npm run dev"},
+			{Dir: "/tmp", Command: "zsh"},
+		},
+	}}}
+	if err := ApplyLayoutToSession(layout, "api"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"split-window -t api:code",
+		"send-keys -t api:code.0 nvim . Enter",
+		`send-keys -t api:code.1 cd "web" && clear Enter`,
+		"send-keys -t api:code.1 npm run dev Enter",
+	}
+	for _, w := range want {
+		found := false
+		for _, c := range commands {
+			if c == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing %q in %v", w, commands)
+		}
+	}
+	splits := 0
+	for _, c := range commands {
+		if strings.HasPrefix(c, "split-window") {
+			splits++
+		}
+		if strings.Contains(c, "zsh") {
+			t.Errorf("shell should not be started: %q", c)
+		}
+	}
+	if splits != 2 {
+		t.Errorf("expected 2 splits for 3 panes without pane_count, got %d", splits)
+	}
+}
@@ -0,0 +1,63 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+)
+
+// windowTargetArgs returns "-t target", or nothing for the current window.
+func windowTargetArgs(target string) []string {
+	if target == "" {
+		return nil
+	}
+	return []string{"-t", target}
+}
+
+// Synchronized reports whether input to target (a window, or "" for the
+// current one) is broadcast to all of its panes.
+func Synchronized(target string) (bool, error) {
+	args := append([]string{"show-window-options", "-v"}, windowTargetArgs(target)...)
+	out, err := tmuxCmd(append(args, "synchronize-panes")...)
+	if err != nil {
+		return false, fmt.Errorf("reading synchronize-panes: %w", err)
+	}
+	return strings.TrimSpace(out) == "on", nil
+}
+
+// SetSynchronized turns broadcasting input to all of target's panes on or off.
+func SetSynchronized(target string, on bool) error {
+	value := "off"
+	if on {
+		value = "on"
+	}
+	args := append([]string{"set-window-option"}, windowTargetArgs(target)...)
+	if _, err := tmuxCmd(append(args, "synchronize-panes", value)...); err != nil {
+		return fmt.Errorf("setting synchronize-panes %s: %w", value, err)
+	}
+	return nil
+}
+
+// RunInPanes types command into every pane of target (a window, or "" for
+// the current one) and presses Enter, returning the number of panes.
+// Broadcasting is paused meanwhile so no pane gets the command twice.
+func RunInPanes(target, command string) (int, error) {
+	args := append([]string{"list-panes"}, windowTargetArgs(target)...)
+	out, err := tmuxCmd(append(args, "-F", "#{pane_id}")...)
+	if err != nil {
+		return 0, fmt.Errorf("listing panes: %w", err)
+	}
+	panes := strings.Fields(out)
+
+	if synced, _ := Synchronized(target); synced {
+		if err := SetSynchronized(target, false); err != nil {
+			return 0, err
+		}
+		defer SetSynchronized(target, true) //nolint:errcheck
+	}
+	for _, id := range panes {
+		if _, err := tmuxCmd("send-keys", "-t", id, command, "Enter"); err != nil {
+			return 0, fmt.Errorf("sending to pane %s: %w", id, err)
+		}
+	}
+	return len(panes), nil
+}
@@ -0,0 +1,75 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSynchronized_Stubbed(t *testing.T) {
+	original := tmuxCmd
+	defer func() { tmuxCmd = original }()
+
+	var calls []string
+	sync := "off"
+	tmuxCmd = func(args ...string) (string, error) {
+		calls = append(calls, strings.Join(args, " "))
+		if args[0] == "set-window-option" {
+			sync = args[len(args)-1]
+		}
+		return sync + "\n", nil
+	}
+
+	if on, err := Synchronized(""); err != nil || on {
+		t.Fatalf("Synchronized = %v, %v; want off", on, err)
+	}
+	if err := SetSynchronized("dev:servers", true); err != nil {
+		t.Fatal(err)
+	}
+	if on, _ := Synchronized("dev:servers"); !on {
+		t.Error("expected synchronize-panes on after SetSynchronized")
+	}
+
+	want := []string{
+		"show-window-options -v synchronize-panes",
+		"set-window-option -t dev:servers synchronize-panes on",
+		"show-window-options -v -t dev:servers synchronize-panes",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls:\n%s\nwant:\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRunInPanes_Stubbed(t *testing.T) {
+	original := tmuxCmd
+	defer func() { tmuxCmd = original }()
+
+	var sent []string
+	sync := "on"
+	tmuxCmd = func(args ...string) (string, error) {
+		switch args[0] {
+		case "list-panes":
+			return "%1\n%2\n%5\n", nil
+		case "show-window-options":
+			return sync, nil
+		case "set-window-option":
+			sync = args[len(args)-1]
+		case "send-keys":
+			if sync == "on" {
+				t.Error("sent keys while panes were synchronized")
+			}
+			sent = append(sent, args[2]+" "+args[3])
+		}
+		return "", nil
+	}
+
+	n, err := RunInPanes("", "uptime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || strings.Join(sent, ",") != "%1 uptime,%2 uptime,%5 uptime" {
+		t.Errorf("RunInPanes sent %v (n=%d)", sent, n)
+	}
+	if sync != "on" {
+		t.Error("expected broadcasting to be restored")
+	}
+}
@@ -0,0 +1,121 @@
+package tmux
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Snapshot is a session's windows, panes, directories, and running
+// commands, saved so the session can be recreated after tmux restarts.
+type Snapshot struct {
+	Session string
+	SavedAt time.Time
+	Layout  Layout
+}
+
+// SnapshotStore persists session snapshots in the mine database.
+type SnapshotStore struct {
+	db *sql.DB
+}
+
+// NewSnapshotStore returns a SnapshotStore backed by db.
+func NewSnapshotStore(db *sql.DB) *SnapshotStore {
+	return &SnapshotStore{db: db}
+}
+
+// Save captures the named running session and stores it, replacing any
+// earlier snapshot of the same session.
+func (s *SnapshotStore) Save(session string) (*Snapshot, error) {
+	layout, err := captureSession(session, session)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(layout); err != nil {
+		return nil, fmt.Errorf("encoding snapshot: %w", err)
+	}
+	snap := &Snapshot{Session: session, SavedAt: layout.SavedAt, Layout: *layout}
+	if _, err := s.db.Exec(
+		`INSERT INTO tmux_snapshots (session, layout, saved_at) VALUES (?, ?, ?)
+		 ON CONFLICT(session) DO UPDATE SET layout = excluded.layout, saved_at = excluded.saved_at`,
+		session, buf.String(), snap.SavedAt.UTC().Format(time.RFC3339Nano),
+	); err != nil {
+		return nil, fmt.Errorf("saving snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+// Get returns the snapshot of the named session.
+func (s *SnapshotStore) Get(session string) (*Snapshot, error) {
+	var data, savedAt string
+	err := s.db.QueryRow(`SELECT layout, saved_at FROM tmux_snapshots WHERE session = ?`, session).Scan(&data, &savedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("no snapshot of session %q — save one with: mine tmux save %s", session, session)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading snapshot: %w", err)
+	}
+	return decodeSnapshot(session, data, savedAt)
+}
+
+// List returns all snapshots, by session name.
+func (s *SnapshotStore) List() ([]Snapshot, error) {
+	rows, err := s.db.Query(`SELECT session, layout, saved_at FROM tmux_snapshots ORDER BY session ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("listing snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snaps []Snapshot
+	for rows.Next() {
+		var session, data, savedAt string
+		if err := rows.Scan(&session, &data, &savedAt); err != nil {
+			return nil, fmt.Errorf("scanning snapshot: %w", err)
+		}
+		snap, err := decodeSnapshot(session, data, savedAt)
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, *snap)
+	}
+	return snaps, rows.Err()
+}
+
+// Restore recreates a snapshot as a new detached session. It fails if a
+// session with that name is already running.
+func (s *SnapshotStore) Restore(snap *Snapshot) error {
+	sessions, err := ListSessions()
+	if err != nil {
+		return err
+	}
+	if FindSessionByName(snap.Session, sessions) != nil {
+		return fmt.Errorf("session %q is already running", snap.Session)
+	}
+
+	dir := ""
+	if len(snap.Layout.Windows) > 0 && len(snap.Layout.Windows[0].Panes) > 0 {
+		dir = snap.Layout.Windows[0].Panes[0].Dir
+	}
+	if _, err := NewSession(snap.Session, dir); err != nil {
+		return err
+	}
+	if err := applyLayoutToSession(&snap.Layout, snap.Session); err != nil {
+		_ = KillSession(snap.Session) // don't leave a half-restored session behind
+		return fmt.Errorf("restoring session %q: %w", snap.Session, err)
+	}
+	return nil
+}
+
+func decodeSnapshot(session, data, savedAt string) (*Snapshot, error) {
+	snap := &Snapshot{Session: session}
+	if _, err := toml.Decode(data, &snap.Layout); err != nil {
+		return nil, fmt.Errorf("decoding snapshot of %q: %w", session, err)
+	}
+	snap.SavedAt, _ = time.Parse(time.RFC3339Nano, savedAt)
+	return snap, nil
+}
@@ -0,0 +1,101 @@
+package tmux
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func setupSnapshotStore(t *testing.T) *SnapshotStore {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE tmux_snapshots (
+		session TEXT PRIMARY KEY,
+		layout TEXT NOT NULL,
+		saved_at TEXT NOT NULL
+	)`); err != nil {
+		t.Fatal(err)
+	}
+	return NewSnapshotStore(db)
+}
+
+func TestSnapshotSaveAndRestore(t *testing.T) {
+	ss := setupSnapshotStore(t)
+
+	origCmd, origList := tmuxCmd, listSessionsFunc
+	t.Cleanup(func() { tmuxCmd, listSessionsFunc = origCmd, origList })
+
+	var commands []string
+	tmuxCmd = func(args ...string) (string, error) {
+		commands = append(commands, strings.Join(args, " "))
+		switch args[0] {
+		case "list-windows":
+			return "editor\tmain-vertical\t2\nlogs\teven-horizontal\t1", nil
+		case "list-panes":
+			if args[2] == "api:editor" {
+				return "/src/api\tnvim\n/src/api\tzsh", nil
+			}
+			return "/var/log\ttail", nil
+		}
+		return "", nil
+	}
+	running := []Session{{Name: "api"}}
+	listSessionsFunc = func() ([]Session, error) { return running, nil }
+
+	if _, err := ss.Save("api"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// Saving again replaces the snapshot.
+	if _, err := ss.Save("api"); err != nil {
+		t.Fatalf("Save again: %v", err)
+	}
+	snaps, err := ss.List()
+	if err != nil || len(snaps) != 1 {
+		t.Fatalf("List = %+v, %v", snaps, err)
+	}
+	snap, err := ss.Get("api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snap.Layout.Windows) != 2 || snap.Layout.Windows[0].Panes[0].Command != "nvim" || snap.SavedAt.IsZero() {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+
+	if err := ss.Restore(snap); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Errorf("expected already running error, got %v", err)
+	}
+
+	running = nil
+	commands = nil
+	if err := ss.Restore(snap); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	want := []string{
+		"new-session -d -s api -c /src/api",
+		"split-window -t api:editor",
+		"send-keys -t api:editor.0 nvim Enter",
+		"new-window -t api: -n logs",
+		"send-keys -t api:logs.0 tail Enter",
+	}
+	for _, w := range want {
+		found := false
+		for _, c := range commands {
+			if c == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing %q in %v", w, commands)
+		}
+	}
+
+	if _, err := ss.Get("nope"); err == nil {
+		t.Error("expected error for unknown session")
+	}
+}
@@ -0,0 +1,76 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SaveTemplate captures a running session as a reusable layout. Unlike
+// SaveLayout, pane directories are stored relative to the session's
+// starting directory, so the layout works in any project it's started in.
+// An empty session means the current one. With projectRoot set, the layout
+// is written to that project's .mine/layouts directory instead of the saved
+// layouts. It returns the layout and the file written.
+func SaveTemplate(name, session, projectRoot string) (*Layout, string, error) {
+	layout, err := captureSession(name, session)
+	if err != nil {
+		return nil, "", err
+	}
+	root, err := sessionRoot(session)
+	if err != nil {
+		return nil, "", err
+	}
+	home, _ := os.UserHomeDir()
+	for i := range layout.Windows {
+		for j := range layout.Windows[i].Panes {
+			p := &layout.Windows[i].Panes[j]
+			p.Dir = templateDir(p.Dir, root, home)
+		}
+	}
+
+	dir, path := layoutDir(), layoutPath(name)
+	if projectRoot != "" {
+		dir = filepath.Join(projectRoot, ProjectLayoutDir)
+		path = filepath.Join(dir, name+".toml")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, "", err
+	}
+	return layout, path, writeLayoutFile(path, layout)
+}
+
+// sessionRoot returns the directory a session was started in.
+func sessionRoot(session string) (string, error) {
+	args := []string{"display-message", "-p"}
+	if session != "" {
+		args = append(args, "-t", session)
+	}
+	out, err := tmuxCmd(append(args, "#{session_path}")...)
+	if err != nil {
+		return "", fmt.Errorf("getting session directory: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// templateDir rewrites a captured pane directory for reuse: relative to
+// root when inside it ("" for root itself), otherwise ~/-relative or left
+// absolute.
+func templateDir(dir, root, home string) string {
+	if dir == "" {
+		return ""
+	}
+	if root != "" {
+		if rel, err := filepath.Rel(root, dir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			if rel == "." {
+				return ""
+			}
+			return rel
+		}
+	}
+	if home != "" && strings.HasPrefix(dir, home+string(filepath.Separator)) {
+		return "~/" + strings.TrimPrefix(dir, home+string(filepath.Separator))
+	}
+	return dir
+}
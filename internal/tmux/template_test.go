@@ -0,0 +1,70 @@
+package tmux
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestTemplateDir(t *testing.T) {
+	tests := []struct {
+		dir, want string
+	}{
+		{"/home/user/api", ""},
+		{"/home/user/api/web", "web"},
+		{"/home/user/api-docs", "~/api-docs"},
+		{"/var/log", "/var/log"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := templateDir(tt.dir, "/home/user/api", "/home/user"); got != tt.want {
+			t.Errorf("templateDir(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestSaveTemplate_Stubbed(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origCmd := tmuxCmd
+	t.Cleanup(func() { tmuxCmd = origCmd })
+
+	tmuxCmd = func(args ...string) (string, error) {
+		switch args[0] {
+		case "display-message":
+			if args[len(args)-1] != "#{session_path}" || args[2] != "-t" || args[3] != "api" {
+				return "", fmt.Errorf("unexpected display-message args: %v", args)
+			}
+			return "/srv/api\n", nil
+		case "list-windows":
+			return "code\tmain-vertical\t2", nil
+		case "list-panes":
+			return "/srv/api\tnvim\n/srv/api/web\tnpm", nil
+		}
+		return "", fmt.Errorf("unexpected command: %s", args[0])
+	}
+
+	layout, path, err := SaveTemplate("service", "api", "")
+	if err != nil {
+		t.Fatalf("SaveTemplate: %v", err)
+	}
+	if path != layoutPath("service") {
+		t.Errorf("path = %q, want the saved layouts dir", path)
+	}
+	panes := layout.Windows[0].Panes
+	if panes[0].Dir != "" || panes[1].Dir != "web" || panes[1].Command != "npm" {
+		t.Errorf("pane dirs not made relative: %+v", panes)
+	}
+
+	got, err := ReadLayout("service")
+	if err != nil {
+		t.Fatalf("ReadLayout: %v", err)
+	}
+	if got.Windows[0].Panes[1].Dir != "web" {
+		t.Errorf("saved layout has %+v", got.Windows[0].Panes)
+	}
+
+	root := t.TempDir()
+	if _, path, err := SaveTemplate("service", "api", root); err != nil || path != filepath.Join(root, ProjectLayoutDir, "service.toml") {
+		t.Errorf("project template written to %q, %v", path, err)
+	}
+}
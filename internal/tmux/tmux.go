@@ -17,6 +17,8 @@ type Session struct {
 	Windows  int
 	Created  time.Time
 	Attached bool
+	// Activity is when the session last saw input or output.
+	Activity time.Time
 }
 
 // FilterValue implements tui.Item for fuzzy matching.
@@ -60,7 +62,7 @@ var listSessionsFunc = listSessionsReal
 
 func listSessionsReal() ([]Session, error) {
 	out, err := tmuxCmd("list-sessions", "-F",
-		"#{session_name}\t#{session_windows}\t#{session_created}\t#{session_attached}")
+		"#{session_name}\t#{session_windows}\t#{session_created}\t#{session_attached}\t#{session_activity}")
 	if err != nil {
 		// "no server running" is a normal condition — return empty.
 		if strings.Contains(err.Error(), "no server running") ||
@@ -195,6 +197,19 @@ func FuzzyFindSession(query string, sessions []Session) (*Session, error) {
 	return nil, fmt.Errorf("no session matching %q", query)
 }
 
+// IdleSessions returns the sessions with no attached clients and no
+// activity for at least idle. Sessions whose activity is unknown are kept.
+func IdleSessions(sessions []Session, idle time.Duration, now time.Time) []Session {
+	var stale []Session
+	for _, s := range sessions {
+		if s.Attached || s.Activity.IsZero() || now.Sub(s.Activity) < idle {
+			continue
+		}
+		stale = append(stale, s)
+	}
+	return stale
+}
+
 // --- internal helpers ---
 
 // tmuxCmd runs tmux with args and returns combined output.
@@ -241,21 +256,28 @@ func parseSessions(raw string) []Session {
 		if line == "" {
 			continue
 		}
-		parts := strings.SplitN(line, "\t", 4)
+		parts := strings.SplitN(line, "\t", 5)
 		if len(parts) < 4 {
 			continue
 		}
 
 		windows, _ := strconv.Atoi(parts[1])
 		created, _ := strconv.ParseInt(parts[2], 10, 64)
-		attached := parts[3] == "1"
+		// session_attached counts clients, so any non-zero value is attached.
+		attached := parts[3] != "0" && parts[3] != ""
 
-		sessions = append(sessions, Session{
+		s := Session{
 			Name:     parts[0],
 			Windows:  windows,
 			Created:  time.Unix(created, 0),
 			Attached: attached,
-		})
+		}
+		if len(parts) == 5 {
+			if activity, err := strconv.ParseInt(parts[4], 10, 64); err == nil {
+				s.Activity = time.Unix(activity, 0)
+			}
+		}
+		sessions = append(sessions, s)
 	}
 	return sessions
 }
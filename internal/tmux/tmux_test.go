@@ -67,6 +67,37 @@ func TestParseSessions_MalformedLine(t *testing.T) {
 	}
 }
 
+func TestParseSessions_ActivityAndClients(t *testing.T) {
+	raw := "api\t2\t1700000000\t2\t1700000500\n"
+	sessions := parseSessions(raw)
+
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+	if !sessions[0].Attached {
+		t.Fatal("expected two attached clients to count as attached")
+	}
+	if sessions[0].Activity.Unix() != 1700000500 {
+		t.Fatalf("expected activity=1700000500, got %d", sessions[0].Activity.Unix())
+	}
+}
+
+func TestIdleSessions(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	day := 24 * time.Hour
+	sessions := []Session{
+		{Name: "stale", Activity: now.Add(-10 * day)},
+		{Name: "recent", Activity: now.Add(-2 * day)},
+		{Name: "attached", Activity: now.Add(-30 * day), Attached: true},
+		{Name: "unknown"},
+	}
+
+	idle := IdleSessions(sessions, 7*day, now)
+	if len(idle) != 1 || idle[0].Name != "stale" {
+		t.Fatalf("expected only stale, got %+v", idle)
+	}
+}
+
 func TestSessionItem(t *testing.T) {
 	s := Session{
 		Name:     "myproject",
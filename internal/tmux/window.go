@@ -70,6 +70,58 @@ func NewWindow(session, name string) error {
 	return nil
 }
 
+// DirWindow is a window to open in a directory.
+type DirWindow struct {
+	Name string
+	Dir  string
+}
+
+// EnsureWindows opens a window per spec in session, each starting in its
+// Dir, creating the session with the first one if it isn't running. Windows
+// whose name is already taken are left alone, so it's safe to re-run. It
+// returns the windows it created.
+func EnsureWindows(session string, specs []DirWindow) ([]DirWindow, error) {
+	sessions, err := ListSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	taken := map[string]bool{}
+	var created []DirWindow
+	if FindSessionByName(session, sessions) == nil {
+		if len(specs) == 0 {
+			return nil, nil
+		}
+		first := specs[0]
+		if _, err := tmuxCmd("new-session", "-d", "-s", session, "-n", first.Name, "-c", first.Dir); err != nil {
+			return nil, fmt.Errorf("creating session %q: %w", session, err)
+		}
+		taken[first.Name] = true
+		created = append(created, first)
+		specs = specs[1:]
+	} else {
+		windows, err := ListWindows(session)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range windows {
+			taken[w.Name] = true
+		}
+	}
+
+	for _, spec := range specs {
+		if taken[spec.Name] {
+			continue
+		}
+		if _, err := tmuxCmd("new-window", "-d", "-t", session+":", "-n", spec.Name, "-c", spec.Dir); err != nil {
+			return created, fmt.Errorf("creating window %q in session %q: %w", spec.Name, session, err)
+		}
+		taken[spec.Name] = true
+		created = append(created, spec)
+	}
+	return created, nil
+}
+
 // KillWindow destroys the named window in the given session.
 func KillWindow(session, name string) error {
 	target := session + ":" + name
@@ -93,6 +145,33 @@ func RenameWindow(session, oldName, newName string) error {
 	return nil
 }
 
+// FuzzyFindWindow finds a window by query, like FuzzyFindSession: exact
+// name, then window index, then case-insensitive prefix and substring.
+func FuzzyFindWindow(query string, windows []Window) (*Window, error) {
+	if w := FindWindowByName(query, windows); w != nil {
+		return w, nil
+	}
+	if idx, err := strconv.Atoi(query); err == nil {
+		for i := range windows {
+			if windows[i].Index == idx {
+				return &windows[i], nil
+			}
+		}
+	}
+	q := strings.ToLower(query)
+	for i := range windows {
+		if strings.HasPrefix(strings.ToLower(windows[i].Name), q) {
+			return &windows[i], nil
+		}
+	}
+	for i := range windows {
+		if strings.Contains(strings.ToLower(windows[i].Name), q) {
+			return &windows[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no window matching %q", query)
+}
+
 // FindWindowByName returns the window with the given exact name, or nil if not found.
 func FindWindowByName(name string, windows []Window) *Window {
 	for i := range windows {
@@ -2,6 +2,8 @@ package tmux
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -321,3 +323,88 @@ func TestFindWindowByName_Empty(t *testing.T) {
 		t.Fatalf("expected nil for empty window list, got %v", w)
 	}
 }
+
+func TestFuzzyFindWindow(t *testing.T) {
+	windows := []Window{
+		{Index: 0, Name: "editor"},
+		{Index: 1, Name: "2"},
+		{Index: 2, Name: "server"},
+		{Index: 3, Name: "test-runner"},
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"server", "server"},
+		{"2", "2"}, // an exact name beats an index
+		{"3", "test-runner"},
+		{"SERV", "server"},
+		{"runner", "test-runner"},
+	}
+	for _, tt := range tests {
+		w, err := FuzzyFindWindow(tt.query, windows)
+		if err != nil {
+			t.Errorf("FuzzyFindWindow(%q): %v", tt.query, err)
+			continue
+		}
+		if w.Name != tt.want {
+			t.Errorf("FuzzyFindWindow(%q) = %q, want %q", tt.query, w.Name, tt.want)
+		}
+	}
+
+	if _, err := FuzzyFindWindow("missing", windows); err == nil {
+		t.Error("expected error for unmatched query")
+	}
+}
+
+func TestEnsureWindows(t *testing.T) {
+	origCmd, origList := tmuxCmd, listSessionsFunc
+	t.Cleanup(func() { tmuxCmd, listSessionsFunc = origCmd, origList })
+
+	var commands []string
+	tmuxCmd = func(args ...string) (string, error) {
+		commands = append(commands, strings.Join(args, " "))
+		if args[0] == "list-windows" {
+			return "0\tmain\t1\n1\tfeature/login\t0", nil
+		}
+		return "", nil
+	}
+	specs := []DirWindow{
+		{Name: "main", Dir: "/src/api"},
+		{Name: "feature/login", Dir: "/src/api-feature-login"},
+		{Name: "fix/typo", Dir: "/src/api-fix-typo"},
+	}
+
+	// New session: the first spec becomes its first window.
+	listSessionsFunc = func() ([]Session, error) { return nil, nil }
+	created, err := EnsureWindows("api", specs)
+	if err != nil {
+		t.Fatalf("EnsureWindows: %v", err)
+	}
+	if len(created) != 3 {
+		t.Fatalf("expected 3 windows created, got %+v", created)
+	}
+	want := []string{
+		"new-session -d -s api -n main -c /src/api",
+		"new-window -d -t api: -n feature/login -c /src/api-feature-login",
+		"new-window -d -t api: -n fix/typo -c /src/api-fix-typo",
+	}
+	if !reflect.DeepEqual(commands, want) {
+		t.Fatalf("commands = %q, want %q", commands, want)
+	}
+
+	// Running session: only the missing window is added.
+	commands = nil
+	listSessionsFunc = func() ([]Session, error) { return []Session{{Name: "api"}}, nil }
+	created, err = EnsureWindows("api", specs)
+	if err != nil {
+		t.Fatalf("EnsureWindows again: %v", err)
+	}
+	if len(created) != 1 || created[0].Name != "fix/typo" {
+		t.Fatalf("expected only fix/typo created, got %+v", created)
+	}
+	if got := commands[len(commands)-1]; got != want[2] {
+		t.Fatalf("last command = %q, want %q", got, want[2])
+	}
+}
@@ -0,0 +1,89 @@
+package todo
+
+import (
+	"fmt"
+	"time"
+)
+
+// archivableCondition selects completed todos eligible for archiving. Parents
+// with open subtasks stay put so their children keep a visible parent.
+const archivableCondition = `done = 1 AND completed_at IS NOT NULL AND completed_at <= ?
+	AND NOT EXISTS (SELECT 1 FROM todos c WHERE c.parent_id = todos.id AND c.done = 0)`
+
+// Archive moves todos completed before cutoff into todos_archive, along with
+// their notes and attachments. Returns the number of todos archived.
+func (s *Store) Archive(cutoff time.Time) (int, error) {
+	cutoffStr := cutoff.UTC().Format("2006-01-02 15:04:05")
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(
+		`INSERT INTO todo_notes_archive (id, todo_id, body, created_at)
+		 SELECT id, todo_id, body, created_at FROM todo_notes
+		 WHERE todo_id IN (SELECT id FROM todos WHERE `+archivableCondition+`)`,
+		cutoffStr,
+	); err != nil {
+		return 0, fmt.Errorf("archiving notes: %w", err)
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO todo_attachments_archive (id, todo_id, target, created_at)
+		 SELECT id, todo_id, target, created_at FROM todo_attachments
+		 WHERE todo_id IN (SELECT id FROM todos WHERE `+archivableCondition+`)`,
+		cutoffStr,
+	); err != nil {
+		return 0, fmt.Errorf("archiving attachments: %w", err)
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO todos_archive (`+todoColumns+`)
+		 SELECT `+todoColumns+` FROM todos WHERE `+archivableCondition,
+		cutoffStr,
+	); err != nil {
+		return 0, fmt.Errorf("archiving todos: %w", err)
+	}
+	res, err := tx.Exec(
+		`DELETE FROM todos WHERE id IN (SELECT id FROM todos_archive) AND `+archivableCondition,
+		cutoffStr,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("removing archived todos: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), tx.Commit()
+}
+
+// ListArchived returns archived todos, most recently completed first.
+// projectPath filters to a project plus global todos; nil with all=false
+// returns global-only, mirroring List.
+func (s *Store) ListArchived(projectPath *string, all bool) ([]Todo, error) {
+	query := `SELECT ` + todoColumns + ` FROM todos_archive`
+	var args []any
+	switch {
+	case all:
+	case projectPath != nil:
+		query += ` WHERE project_path = ? OR project_path IS NULL`
+		args = append(args, *projectPath)
+	default:
+		query += ` WHERE project_path IS NULL`
+	}
+	query += ` ORDER BY completed_at DESC, id DESC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing archived todos: %w", err)
+	}
+	defer rows.Close()
+
+	var todos []Todo
+	for rows.Next() {
+		t, err := scanTodoRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, t)
+	}
+	return todos, rows.Err()
+}
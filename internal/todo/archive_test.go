@@ -0,0 +1,110 @@
+package todo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArchive_MovesOldCompletedTodos(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Now()
+	old := insertCompletedAtTime(t, s, "old", now.AddDate(0, 0, -60), now.AddDate(0, 0, -45))
+	recent := insertCompletedAtTime(t, s, "recent", now.AddDate(0, 0, -5), now.AddDate(0, 0, -2))
+	open := insertOpenTodo(t, s, "open", nil)
+	if err := s.AddNote(old, "context"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddAttachment(old, "https://example.com/spec"); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := s.Archive(now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 archived, got %d", n)
+	}
+
+	if _, err := s.Get(old); err == nil {
+		t.Errorf("expected #%d removed from active todos", old)
+	}
+	for _, id := range []int{recent, open} {
+		if _, err := s.Get(id); err != nil {
+			t.Errorf("expected #%d to stay active: %v", id, err)
+		}
+	}
+
+	archived, err := s.ListArchived(nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 1 || archived[0].ID != old || archived[0].Title != "old" {
+		t.Fatalf("unexpected archive contents: %+v", archived)
+	}
+
+	var notes int
+	db.QueryRow(`SELECT COUNT(*) FROM todo_notes_archive WHERE todo_id = ?`, old).Scan(&notes)
+	if notes != 1 {
+		t.Errorf("expected note archived, got %d", notes)
+	}
+
+	var target string
+	db.QueryRow(`SELECT target FROM todo_attachments_archive WHERE todo_id = ?`, old).Scan(&target)
+	if target != "https://example.com/spec" {
+		t.Errorf("expected attachment archived, got %q", target)
+	}
+}
+
+func TestArchive_KeepsParentWithOpenSubtasks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Now()
+	parent := insertCompletedAtTime(t, s, "parent", now.AddDate(0, 0, -60), now.AddDate(0, 0, -45))
+	child := insertOpenTodo(t, s, "child", nil)
+	if err := s.SetParent(child, &parent); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := s.Archive(now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("expected parent with open subtask kept, archived %d", n)
+	}
+}
+
+func TestGetStats_IncludesArchived(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Now()
+	insertCompletedAtTime(t, s, "a", now.AddDate(0, 0, -3), now.AddDate(0, 0, -1))
+	insertCompletedAtTime(t, s, "b", now.AddDate(0, 0, -3), now)
+
+	before, err := GetStats(db, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Archive(now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	after, err := GetStats(db, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if after.Streak != before.Streak || after.CompletedMonth != before.CompletedMonth || after.AvgClose != before.AvgClose {
+		t.Errorf("stats changed after archiving: before=%+v after=%+v", before, after)
+	}
+	if len(after.ByProject) != 1 || after.ByProject[0].Completed != 2 {
+		t.Errorf("expected archived todos in project breakdown, got %+v", after.ByProject)
+	}
+}
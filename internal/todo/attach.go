@@ -0,0 +1,100 @@
+package todo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Attachment is a file path or URL attached to a todo.
+type Attachment struct {
+	ID        int
+	TodoID    int
+	Target    string
+	CreatedAt time.Time
+}
+
+// IsURL reports whether the attachment is a URL rather than a local file.
+func (a Attachment) IsURL() bool {
+	return IsAttachmentURL(a.Target)
+}
+
+// IsAttachmentURL reports whether target looks like a URL (scheme://host or
+// mailto:) rather than a file path.
+func IsAttachmentURL(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" {
+		return false
+	}
+	return u.Host != "" || strings.EqualFold(u.Scheme, "mailto")
+}
+
+// AddAttachment attaches target to a todo. Attaching the same target twice
+// is a no-op. Returns the attachment's position (1-based) on the todo.
+func (s *Store) AddAttachment(todoID int, target string) (int, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return 0, fmt.Errorf("attachment cannot be empty")
+	}
+	if _, err := s.Get(todoID); err != nil {
+		return 0, err
+	}
+
+	existing, err := s.Attachments(todoID)
+	if err != nil {
+		return 0, err
+	}
+	for i, a := range existing {
+		if a.Target == target {
+			return i + 1, nil
+		}
+	}
+
+	if _, err := s.db.Exec(
+		`INSERT INTO todo_attachments (todo_id, target) VALUES (?, ?)`, todoID, target,
+	); err != nil {
+		return 0, fmt.Errorf("attaching to #%d: %w", todoID, err)
+	}
+	return len(existing) + 1, nil
+}
+
+// RemoveAttachment detaches the n-th (1-based) attachment of a todo.
+func (s *Store) RemoveAttachment(todoID, n int) (Attachment, error) {
+	all, err := s.Attachments(todoID)
+	if err != nil {
+		return Attachment{}, err
+	}
+	if n < 1 || n > len(all) {
+		return Attachment{}, fmt.Errorf("todo #%d has no attachment %d", todoID, n)
+	}
+	a := all[n-1]
+	if _, err := s.db.Exec(`DELETE FROM todo_attachments WHERE id = ?`, a.ID); err != nil {
+		return Attachment{}, fmt.Errorf("detaching from #%d: %w", todoID, err)
+	}
+	return a, nil
+}
+
+// Attachments returns a todo's attachments in the order they were added.
+func (s *Store) Attachments(todoID int) ([]Attachment, error) {
+	rows, err := s.db.Query(
+		`SELECT id, todo_id, target, created_at FROM todo_attachments WHERE todo_id = ? ORDER BY id`,
+		todoID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Attachment
+	for rows.Next() {
+		var a Attachment
+		var created string
+		if err := rows.Scan(&a.ID, &a.TodoID, &a.Target, &created); err != nil {
+			return nil, err
+		}
+		a.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", created)
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,69 @@
+package todo
+
+import "testing"
+
+func TestIsAttachmentURL(t *testing.T) {
+	cases := map[string]bool{
+		"https://example.com/x":  true,
+		"http://localhost:8080":  true,
+		"mailto:bob@example.com": true,
+		"/home/me/notes.md":      false,
+		"notes.md":               false,
+		"C:/Users/me/file.txt":   false,
+		"":                       false,
+	}
+	for in, want := range cases {
+		if got := IsAttachmentURL(in); got != want {
+			t.Errorf("IsAttachmentURL(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestAttachments_AddRemoveAndUndoDelete(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+	id, _ := s.Add("task", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	if n, err := s.AddAttachment(id, "https://example.com"); err != nil || n != 1 {
+		t.Fatalf("AddAttachment = (%d, %v), want (1, nil)", n, err)
+	}
+	if n, _ := s.AddAttachment(id, "/tmp/spec.pdf"); n != 2 {
+		t.Errorf("second attachment position = %d, want 2", n)
+	}
+	if n, _ := s.AddAttachment(id, "https://example.com"); n != 1 {
+		t.Errorf("duplicate attachment position = %d, want 1", n)
+	}
+	if _, err := s.AddAttachment(999, "x"); err == nil {
+		t.Error("expected error attaching to a missing todo")
+	}
+
+	got, err := s.GetWithNotes(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Attachments) != 2 || !got.Attachments[0].IsURL() || got.Attachments[1].IsURL() {
+		t.Fatalf("Attachments = %+v", got.Attachments)
+	}
+
+	if err := s.Delete(id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	all, _ := s.Attachments(id)
+	if len(all) != 2 {
+		t.Errorf("attachments after undoing delete = %d, want 2", len(all))
+	}
+
+	if a, err := s.RemoveAttachment(id, 1); err != nil || a.Target != "https://example.com" {
+		t.Errorf("RemoveAttachment = (%+v, %v)", a, err)
+	}
+	if _, err := s.RemoveAttachment(id, 5); err == nil {
+		t.Error("expected error removing a missing attachment")
+	}
+	all, _ = s.Attachments(id)
+	if len(all) != 1 || all[0].Target != "/tmp/spec.pdf" {
+		t.Errorf("remaining attachments = %+v", all)
+	}
+}
@@ -0,0 +1,48 @@
+package todo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseContext normalizes a GTD context such as "@Home" to "home".
+// An empty string (or a bare "@") clears the context. Contexts are single
+// words: whitespace and commas are rejected.
+func ParseContext(s string) (string, error) {
+	ctx := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "@"))
+	if strings.ContainsAny(ctx, " \t,@") {
+		return "", fmt.Errorf("invalid context %q — use a single word like @home or @work", s)
+	}
+	return ctx, nil
+}
+
+// ContextLabel returns the display form of a context ("@home"), or "" when unset.
+func ContextLabel(ctx string) string {
+	if ctx == "" {
+		return ""
+	}
+	return "@" + ctx
+}
+
+// SetContext sets (or with "" clears) the GTD context of a todo.
+func (s *Store) SetContext(id int, ctx string) error {
+	ctx, err := ParseContext(ctx)
+	if err != nil {
+		return err
+	}
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return err
+	}
+	var val any
+	if ctx != "" {
+		val = ctx
+	}
+	if _, err := s.db.Exec(
+		`UPDATE todos SET context = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		val, id,
+	); err != nil {
+		return err
+	}
+	return s.journal(HistoryEdit, id, snap)
+}
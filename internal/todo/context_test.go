@@ -0,0 +1,86 @@
+package todo
+
+import "testing"
+
+func TestParseContext(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"@home", "home", false},
+		{"Work", "work", false},
+		{" @Errands ", "errands", false},
+		{"", "", false},
+		{"@", "", false},
+		{"at home", "", true},
+		{"home,work", "", true},
+		{"@@home", "", true},
+	}
+	for _, c := range cases {
+		got, err := ParseContext(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParseContext(%q) error = %v, wantErr %v", c.in, err, c.wantErr)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseContext(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSetContext_FilterAndClear(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	home, _ := s.Add("water plants", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	work, _ := s.Add("file report", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	s.Add("no context", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	if err := s.SetContext(home, "@Home"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetContext(work, "work"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.List(ListOptions{AllProjects: true, Context: "home"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != home || got[0].Context != "home" {
+		t.Fatalf("expected only #%d in @home, got %+v", home, got)
+	}
+
+	if err := s.SetContext(home, ""); err != nil {
+		t.Fatal(err)
+	}
+	tt, _ := s.Get(home)
+	if tt.Context != "" {
+		t.Errorf("expected context cleared, got %q", tt.Context)
+	}
+
+	if err := s.SetContext(home, "at home"); err == nil {
+		t.Error("expected error for context with a space")
+	}
+	if err := s.SetContext(999, "home"); err == nil {
+		t.Error("expected error for missing todo")
+	}
+}
+
+func TestSetContext_Undo(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	id, _ := s.Add("buy milk", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := s.SetContext(id, "errands"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	tt, _ := s.Get(id)
+	if tt.Context != "" {
+		t.Errorf("expected context reverted, got %q", tt.Context)
+	}
+}
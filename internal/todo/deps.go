@@ -0,0 +1,173 @@
+package todo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AddDependency records that todo id cannot start until dependsOn is completed.
+// Returns an error if either todo is missing or the edge would create a cycle.
+// Adding an existing dependency is a no-op.
+func (s *Store) AddDependency(id, dependsOn int) error {
+	if id == dependsOn {
+		return fmt.Errorf("todo #%d cannot depend on itself", id)
+	}
+	for _, tid := range []int{id, dependsOn} {
+		var exists int
+		if err := s.db.QueryRow(`SELECT COUNT(*) FROM todos WHERE id = ?`, tid).Scan(&exists); err != nil {
+			return err
+		}
+		if exists == 0 {
+			return fmt.Errorf("todo #%d not found", tid)
+		}
+	}
+
+	// A cycle exists if id is already reachable from dependsOn.
+	reachable, err := s.dependsOnTransitively(dependsOn, id)
+	if err != nil {
+		return err
+	}
+	if reachable {
+		return fmt.Errorf("todo #%d already depends on #%d — that would be a cycle", dependsOn, id)
+	}
+
+	_, err = s.db.Exec(
+		`INSERT OR IGNORE INTO todo_deps (todo_id, depends_on) VALUES (?, ?)`,
+		id, dependsOn,
+	)
+	if err != nil {
+		return fmt.Errorf("adding dependency: %w", err)
+	}
+	return nil
+}
+
+// RemoveDependency removes the dependency of id on dependsOn.
+// dependsOn 0 removes all of id's dependencies. Returns the number removed.
+func (s *Store) RemoveDependency(id, dependsOn int) (int, error) {
+	query := `DELETE FROM todo_deps WHERE todo_id = ?`
+	args := []any{id}
+	if dependsOn != 0 {
+		query += ` AND depends_on = ?`
+		args = append(args, dependsOn)
+	}
+	res, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("removing dependency: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+// dependsOnTransitively reports whether from depends (directly or indirectly) on target.
+func (s *Store) dependsOnTransitively(from, target int) (bool, error) {
+	seen := map[int]bool{}
+	queue := []int{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == target {
+			return true, nil
+		}
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+
+		rows, err := s.db.Query(`SELECT depends_on FROM todo_deps WHERE todo_id = ?`, cur)
+		if err != nil {
+			return false, fmt.Errorf("walking dependencies: %w", err)
+		}
+		for rows.Next() {
+			var next int
+			if err := rows.Scan(&next); err != nil {
+				rows.Close()
+				return false, err
+			}
+			queue = append(queue, next)
+		}
+		rows.Close()
+	}
+	return false, nil
+}
+
+// openBlockers returns, for each of the given todo IDs, the IDs of open todos
+// it depends on. IDs with no open blockers are absent from the map.
+func (s *Store) openBlockers(ids []int) (map[int][]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	query := fmt.Sprintf(
+		`SELECT d.todo_id, d.depends_on FROM todo_deps d
+		 JOIN todos b ON b.id = d.depends_on
+		 WHERE b.done = 0 AND d.todo_id IN (%s)
+		 ORDER BY d.todo_id, d.depends_on`,
+		strings.Join(placeholders, ","),
+	)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching blockers: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int][]int)
+	for rows.Next() {
+		var id, on int
+		if err := rows.Scan(&id, &on); err != nil {
+			return nil, err
+		}
+		result[id] = append(result[id], on)
+	}
+	return result, rows.Err()
+}
+
+// attachBlockers populates BlockedBy on each todo in place.
+func (s *Store) attachBlockers(todos []Todo) error {
+	ids := make([]int, len(todos))
+	for i, t := range todos {
+		ids[i] = t.ID
+	}
+	blockers, err := s.openBlockers(ids)
+	if err != nil {
+		return err
+	}
+	for i := range todos {
+		todos[i].BlockedBy = blockers[todos[i].ID]
+	}
+	return nil
+}
+
+// Dependents returns the open todos that directly depend on id.
+func (s *Store) Dependents(id int) ([]Todo, error) {
+	rows, err := s.db.Query(
+		`SELECT `+todoColumns+` FROM todos
+		 WHERE done = 0 AND id IN (SELECT todo_id FROM todo_deps WHERE depends_on = ?)
+		 ORDER BY id ASC`,
+		id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing dependents of #%d: %w", id, err)
+	}
+	defer rows.Close()
+
+	var todos []Todo
+	for rows.Next() {
+		t, err := scanTodoRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := s.attachBlockers(todos); err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
@@ -0,0 +1,100 @@
+package todo
+
+import "testing"
+
+func TestAddDependency_BlocksUntilDone(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	design, _ := s.Add("design", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	build, _ := s.Add("build", "", PrioHigh, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	if err := s.AddDependency(build, design); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	// Idempotent.
+	if err := s.AddDependency(build, design); err != nil {
+		t.Fatalf("AddDependency (repeat): %v", err)
+	}
+
+	got, _ := s.Get(build)
+	if len(got.BlockedBy) != 1 || got.BlockedBy[0] != design {
+		t.Fatalf("expected build blocked by #%d, got %v", design, got.BlockedBy)
+	}
+
+	actionable, err := s.List(ListOptions{ExcludeBlocked: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actionable) != 1 || actionable[0].ID != design {
+		t.Fatalf("expected only #%d actionable, got %v", design, actionable)
+	}
+
+	deps, err := s.Dependents(design)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 1 || deps[0].ID != build {
+		t.Fatalf("expected #%d as dependent, got %v", build, deps)
+	}
+
+	if _, _, err := s.Complete(design); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = s.Get(build)
+	if len(got.BlockedBy) != 0 {
+		t.Errorf("expected build unblocked after design done, got %v", got.BlockedBy)
+	}
+}
+
+func TestAddDependency_RejectsInvalid(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("b", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	c, _ := s.Add("c", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	if err := s.AddDependency(a, a); err == nil {
+		t.Error("expected error for self-dependency")
+	}
+	if err := s.AddDependency(a, 999); err == nil {
+		t.Error("expected error for missing todo")
+	}
+	if err := s.AddDependency(a, b); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(b, c); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddDependency(c, a); err == nil {
+		t.Error("expected error for a → b → c → a cycle")
+	}
+}
+
+func TestRemoveDependency(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("b", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	c, _ := s.Add("c", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	_ = s.AddDependency(a, b)
+	_ = s.AddDependency(a, c)
+
+	n, err := s.RemoveDependency(a, b)
+	if err != nil || n != 1 {
+		t.Fatalf("RemoveDependency(a, b) = %d, %v", n, err)
+	}
+	n, err = s.RemoveDependency(a, 0)
+	if err != nil || n != 1 {
+		t.Fatalf("RemoveDependency(a, all) = %d, %v", n, err)
+	}
+	got, _ := s.Get(a)
+	if len(got.BlockedBy) != 0 {
+		t.Errorf("expected no blockers, got %v", got.BlockedBy)
+	}
+}
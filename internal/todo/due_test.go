@@ -0,0 +1,118 @@
+package todo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdd_DueTimeRoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	due := time.Date(2030, 6, 1, 14, 30, 0, 0, time.Local)
+	id, err := s.Add("demo", "", PrioMedium, nil, &due, nil, ScheduleLater, RecurrenceNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(id)
+	if got.DueDate == nil || !got.DueHasTime {
+		t.Fatalf("expected due time set, got %v (hasTime=%v)", got.DueDate, got.DueHasTime)
+	}
+	if !got.DueDate.Equal(due) {
+		t.Errorf("due = %v, want %v", got.DueDate, due)
+	}
+
+	dateOnly := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	id2, _ := s.Add("date only", "", PrioMedium, nil, &dateOnly, nil, ScheduleLater, RecurrenceNone)
+	got2, _ := s.Get(id2)
+	if got2.DueHasTime {
+		t.Error("expected date-only due to have no time")
+	}
+	if got2.DueDate.Format("2006-01-02") != "2030-06-01" {
+		t.Errorf("date-only due = %v", got2.DueDate)
+	}
+}
+
+func TestIsOverdue(t *testing.T) {
+	now := time.Date(2030, 6, 10, 12, 0, 0, 0, time.Local)
+	at := func(day, hour int) *time.Time {
+		v := time.Date(2030, 6, day, hour, 0, 0, 0, time.Local)
+		return &v
+	}
+
+	tests := []struct {
+		name string
+		todo Todo
+		want bool
+	}{
+		{"no due", Todo{}, false},
+		{"date today", Todo{DueDate: at(10, 0)}, false},
+		{"date yesterday", Todo{DueDate: at(9, 0)}, true},
+		{"time earlier today", Todo{DueDate: at(10, 9), DueHasTime: true}, true},
+		{"time later today", Todo{DueDate: at(10, 17), DueHasTime: true}, false},
+		{"done", Todo{DueDate: at(9, 0), Done: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.todo.IsOverdue(now); got != tt.want {
+				t.Errorf("IsOverdue = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCount_OverdueAccountsForTime(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Now()
+	earlier := now.Add(-2 * time.Minute).Truncate(time.Minute)
+	later := now.Add(2 * time.Hour).Truncate(time.Minute)
+	if earlier.Day() != now.Day() || later.Day() != now.Day() || earlier.Hour() == 0 && earlier.Minute() == 0 {
+		t.Skip("too close to midnight for a same-day time comparison")
+	}
+
+	s.Add("past time", "", PrioMedium, nil, &earlier, nil, ScheduleLater, RecurrenceNone)
+	s.Add("future time", "", PrioMedium, nil, &later, nil, ScheduleLater, RecurrenceNone)
+
+	_, _, overdue, err := s.Count(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overdue != 1 {
+		t.Errorf("expected 1 overdue, got %d", overdue)
+	}
+}
+
+func TestComplete_RecurringKeepsDueTime(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	due := time.Date(2030, 6, 3, 9, 30, 0, 0, time.Local)
+	id, _ := s.Add("standup", "", PrioMedium, nil, &due, nil, ScheduleLater, RecurrenceDaily)
+	spawnedID, _, err := s.Complete(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(spawnedID)
+	want := time.Date(2030, 6, 4, 9, 30, 0, 0, time.Local)
+	if !got.DueHasTime || !got.DueDate.Equal(want) {
+		t.Errorf("spawned due = %v (hasTime=%v), want %v", got.DueDate, got.DueHasTime, want)
+	}
+}
+
+func TestDueLabel(t *testing.T) {
+	d := time.Date(2030, 6, 3, 17, 0, 0, 0, time.Local)
+	if got := DueLabel(Todo{DueDate: &d, DueHasTime: true}, "Jan 2"); got != "Jun 3 5:00pm" {
+		t.Errorf("DueLabel with time = %q", got)
+	}
+	if got := DueLabel(Todo{DueDate: &d}, "Jan 2"); got != "Jun 3" {
+		t.Errorf("DueLabel date-only = %q", got)
+	}
+	if got := DueLabel(Todo{}, "Jan 2"); got != "" {
+		t.Errorf("DueLabel no due = %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package todo
+
+import (
+	"sort"
+	"time"
+)
+
+// EscalationRule raises overdue todos once they are OverdueDays or more
+// past due. Rules set floors, so applying one twice changes nothing.
+type EscalationRule struct {
+	OverdueDays int
+	// Priority is the minimum priority to raise to. Zero leaves it alone.
+	Priority int
+	// Schedule is the least urgent bucket allowed (today < soon < later <
+	// someday). Empty leaves the schedule alone.
+	Schedule string
+}
+
+// Escalation is the change the rules make to one overdue todo.
+type Escalation struct {
+	Todo        Todo
+	OverdueDays int
+	// Priority is the new priority, or zero if unchanged.
+	Priority int
+	// Schedule is the new schedule bucket, or "" if unchanged.
+	Schedule string
+}
+
+// OverdueDays returns how many whole days an open todo is past due at now:
+// 0 on the due day (or not overdue at all).
+func OverdueDays(t Todo, now time.Time) int {
+	if !t.IsOverdue(now) {
+		return 0
+	}
+	if t.DueHasTime {
+		return int(now.Sub(*t.DueDate) / (24 * time.Hour))
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	due := time.Date(t.DueDate.Year(), t.DueDate.Month(), t.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+	return int(today.Sub(due) / (24 * time.Hour))
+}
+
+// PlanEscalations returns what rules would change across all open todos,
+// most overdue first. Nothing is written.
+func (s *Store) PlanEscalations(rules []EscalationRule, now time.Time) ([]Escalation, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	todos, err := s.List(ListOptions{
+		AllProjects:    true,
+		IncludeSomeday: true,
+		Sort:           SortLegacy,
+		ReferenceTime:  now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var plan []Escalation
+	for _, t := range todos {
+		days := OverdueDays(t, now)
+		if days == 0 {
+			continue
+		}
+		esc := Escalation{Todo: t, OverdueDays: days}
+		prio, rank := t.Priority, scheduleRank(t.Schedule)
+		for _, r := range rules {
+			if days < r.OverdueDays {
+				continue
+			}
+			if r.Priority > prio {
+				prio = r.Priority
+				esc.Priority = prio
+			}
+			if r.Schedule != "" && scheduleRank(r.Schedule) < rank {
+				rank = scheduleRank(r.Schedule)
+				esc.Schedule = r.Schedule
+			}
+		}
+		if esc.Priority != 0 || esc.Schedule != "" {
+			plan = append(plan, esc)
+		}
+	}
+	sort.SliceStable(plan, func(i, j int) bool { return plan[i].OverdueDays > plan[j].OverdueDays })
+	return plan, nil
+}
+
+// ApplyEscalations writes a plan from PlanEscalations, leaving updated_at
+// alone since nobody actually touched the todo. When undoable is false the
+// changes aren't journaled, so automatic escalation never becomes the undo
+// step that shadows the user's own last change.
+func (s *Store) ApplyEscalations(plan []Escalation, undoable bool) error {
+	for _, esc := range plan {
+		prio, schedule := esc.Todo.Priority, esc.Todo.Schedule
+		if esc.Priority != 0 {
+			prio = esc.Priority
+		}
+		if esc.Schedule != "" {
+			schedule = esc.Schedule
+		}
+		var snap *historySnapshot
+		if undoable {
+			var err error
+			if snap, err = s.snapshot(esc.Todo.ID, false); err != nil {
+				return err
+			}
+		}
+		if _, err := s.db.Exec(`UPDATE todos SET priority = ?, schedule = ? WHERE id = ?`, prio, schedule, esc.Todo.ID); err != nil {
+			return err
+		}
+		if snap != nil {
+			if err := s.journal(HistoryEdit, esc.Todo.ID, snap); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
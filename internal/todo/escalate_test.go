@@ -0,0 +1,119 @@
+package todo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOverdueDays(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	day := func(offset int) *time.Time {
+		d := time.Date(2026, 3, 10+offset, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	cases := []struct {
+		due  *time.Time
+		done bool
+		want int
+	}{
+		{nil, false, 0},
+		{day(0), false, 0},
+		{day(-1), false, 1},
+		{day(-8), false, 8},
+		{day(-8), true, 0},
+	}
+	for _, c := range cases {
+		if got := OverdueDays(Todo{DueDate: c.due, Done: c.done}, now); got != c.want {
+			t.Errorf("OverdueDays(due %v, done %v) = %d, want %d", c.due, c.done, got, c.want)
+		}
+	}
+}
+
+func TestPlanAndApplyEscalations(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+	now := time.Now()
+
+	fourAgo := now.AddDate(0, 0, -4)
+	tenAgo := now.AddDate(0, 0, -10)
+	a, _ := s.Add("four days late", "", PrioLow, nil, &fourAgo, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("ten days late", "", PrioCrit, nil, &tenAgo, nil, ScheduleSomeday, RecurrenceNone)
+	s.Add("on time", "", PrioLow, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	rules := []EscalationRule{
+		{OverdueDays: 3, Priority: PrioHigh},
+		{OverdueDays: 7, Schedule: ScheduleToday},
+	}
+	plan, err := s.PlanEscalations(rules, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan) != 2 {
+		t.Fatalf("got %d escalations, want 2: %+v", len(plan), plan)
+	}
+	// Most overdue first; crit is already above the floor.
+	if plan[0].Todo.ID != b || plan[0].Priority != 0 || plan[0].Schedule != ScheduleToday {
+		t.Errorf("plan[0] = %+v", plan[0])
+	}
+	if plan[1].Todo.ID != a || plan[1].Priority != PrioHigh || plan[1].Schedule != "" {
+		t.Errorf("plan[1] = %+v", plan[1])
+	}
+
+	db.Exec(`UPDATE todos SET updated_at = '2026-01-01 00:00:00'`)
+	s.BeginBatch()
+	if err := s.ApplyEscalations(plan, true); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(a)
+	if got.Priority != PrioHigh || got.Schedule != ScheduleLater {
+		t.Errorf("#%d = %s/%s, want high/later", a, PriorityLabel(got.Priority), got.Schedule)
+	}
+	if got.UpdatedAt.Year() != 2026 || got.UpdatedAt.Month() != time.January {
+		t.Errorf("escalation touched updated_at: %v", got.UpdatedAt)
+	}
+
+	// Rules are floors: a second pass has nothing to do.
+	if again, _ := s.PlanEscalations(rules, now); len(again) != 0 {
+		t.Errorf("expected idempotent rules, got %+v", again)
+	}
+
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get(b); got.Schedule != ScheduleSomeday {
+		t.Errorf("undo left schedule %q, want someday", got.Schedule)
+	}
+}
+
+func TestApplyEscalations_NotUndoable(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+	now := time.Now()
+
+	fourAgo := now.AddDate(0, 0, -4)
+	late, _ := s.Add("four days late", "", PrioLow, nil, &fourAgo, nil, ScheduleLater, RecurrenceNone)
+	other, _ := s.Add("plan week", "", PrioLow, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := s.SetSchedule(other, ScheduleToday); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := s.PlanEscalations([]EscalationRule{{OverdueDays: 3, Priority: PrioHigh}}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ApplyEscalations(plan, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Undo skips past the escalation to the user's own last change.
+	undone, err := s.Undo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(undone) != 1 || undone[0].TodoID != other {
+		t.Errorf("undo reverted %+v, want the schedule change on #%d", undone, other)
+	}
+	if got, _ := s.Get(late); got.Priority != PrioHigh {
+		t.Errorf("#%d priority = %s, want escalation kept", late, PriorityLabel(got.Priority))
+	}
+}
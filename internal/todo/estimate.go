@@ -0,0 +1,88 @@
+package todo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseEstimate parses an effort estimate such as "30m", "2h", or "1h30m".
+// A bare number is taken as minutes. Estimates are rounded to whole minutes
+// and must be at least one minute.
+func ParseEstimate(s string) (time.Duration, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	var d time.Duration
+	if n, err := strconv.Atoi(in); err == nil {
+		d = time.Duration(n) * time.Minute
+	} else if parsed, err := time.ParseDuration(in); err == nil {
+		d = parsed.Round(time.Minute)
+	} else {
+		return 0, fmt.Errorf("invalid estimate %q — use a duration like 30m, 2h, or 1h30m", s)
+	}
+	if d < time.Minute {
+		return 0, fmt.Errorf("invalid estimate %q — must be at least 1m", s)
+	}
+	return d, nil
+}
+
+// FormatEstimate renders an estimate compactly: "45m", "2h", "1h30m".
+// Returns "" for no estimate.
+func FormatEstimate(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	mins := estimateMinutes(d)
+	h, m := mins/60, mins%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+// estimateMinutes is the stored form of an estimate.
+func estimateMinutes(d time.Duration) int {
+	return int(d.Round(time.Minute) / time.Minute)
+}
+
+// SetEstimate sets (or with 0 clears) the effort estimate of a todo.
+func (s *Store) SetEstimate(id int, d time.Duration) error {
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return err
+	}
+	var val any
+	if d > 0 {
+		val = estimateMinutes(d)
+	}
+	if _, err := s.db.Exec(
+		`UPDATE todos SET estimate_mins = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		val, id,
+	); err != nil {
+		return err
+	}
+	return s.journal(HistoryEdit, id, snap)
+}
+
+// FitBudget picks todos, in the given (urgency) order, whose estimates fit
+// within budget. A todo that doesn't fit is passed over so smaller ones
+// further down can still fill the time. Todos without an estimate are left
+// out and counted in unestimated.
+func FitBudget(todos []Todo, budget time.Duration) (picked []Todo, used time.Duration, unestimated int) {
+	for _, t := range todos {
+		if t.Estimate <= 0 {
+			unestimated++
+			continue
+		}
+		if used+t.Estimate > budget {
+			continue
+		}
+		picked = append(picked, t)
+		used += t.Estimate
+	}
+	return picked, used, unestimated
+}
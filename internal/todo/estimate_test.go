@@ -0,0 +1,118 @@
+package todo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEstimate(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30m", 30 * time.Minute, false},
+		{"2h", 2 * time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"45", 45 * time.Minute, false},
+		{" 1H ", time.Hour, false},
+		{"90s", 2 * time.Minute, false},
+		{"0", 0, true},
+		{"20s", 0, true},
+		{"-1h", 0, true},
+		{"soon", 0, true},
+		{"", 0, true},
+	}
+	for _, c := range cases {
+		got, err := ParseEstimate(c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParseEstimate(%q) error = %v, wantErr %v", c.in, err, c.wantErr)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseEstimate(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                "",
+		45 * time.Minute: "45m",
+		2 * time.Hour:    "2h",
+		90 * time.Minute: "1h30m",
+	}
+	for in, want := range cases {
+		if got := FormatEstimate(in); got != want {
+			t.Errorf("FormatEstimate(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSetEstimate(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	id, _ := s.Add("write report", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := s.SetEstimate(id, 90*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(id)
+	if got.Estimate != 90*time.Minute {
+		t.Fatalf("Estimate = %v, want 1h30m", got.Estimate)
+	}
+
+	if err := s.SetEstimate(id, 0); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = s.Get(id)
+	if got.Estimate != 0 {
+		t.Errorf("Estimate = %v, want cleared", got.Estimate)
+	}
+
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = s.Get(id)
+	if got.Estimate != 90*time.Minute {
+		t.Errorf("Estimate after undo = %v, want 1h30m", got.Estimate)
+	}
+}
+
+func TestSetEstimate_CarriedToNextOccurrence(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	id, _ := s.Add("standup notes", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceDaily)
+	if err := s.SetEstimate(id, 15*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	spawned, _, err := s.Complete(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(spawned)
+	if got.Estimate != 15*time.Minute {
+		t.Errorf("spawned Estimate = %v, want 15m", got.Estimate)
+	}
+}
+
+func TestFitBudget(t *testing.T) {
+	todos := []Todo{
+		{ID: 1, Estimate: time.Hour},
+		{ID: 2, Estimate: 90 * time.Minute}, // doesn't fit after #1
+		{ID: 3},                             // no estimate
+		{ID: 4, Estimate: 45 * time.Minute},
+		{ID: 5, Estimate: 30 * time.Minute}, // would overflow
+	}
+	picked, used, unestimated := FitBudget(todos, 2*time.Hour)
+	if len(picked) != 2 || picked[0].ID != 1 || picked[1].ID != 4 {
+		t.Fatalf("picked = %+v, want #1 and #4", picked)
+	}
+	if used != 105*time.Minute {
+		t.Errorf("used = %v, want 1h45m", used)
+	}
+	if unestimated != 1 {
+		t.Errorf("unestimated = %d, want 1", unestimated)
+	}
+}
@@ -0,0 +1,439 @@
+package todo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrFilterNotFound is returned when a named filter does not exist.
+var ErrFilterNotFound = errors.New("filter not found")
+
+// filterFields lists the fields a filter term can test, for error messages.
+const filterFields = "priority, tag, context, project, waiting, schedule, due, estimate, is, title"
+
+// Filter is a parsed filter expression such as
+//
+//	priority>=high AND tag:infra AND due<7d
+//
+// Terms are field/operator/value triples joined by AND, OR, and NOT, with
+// parentheses for grouping. Adjacent terms without an operator are ANDed,
+// and a bare word matches titles containing it.
+type Filter struct {
+	expr string
+	pred predicate
+}
+
+// predicate reports whether a todo matches part of a filter.
+type predicate func(t Todo, now time.Time) bool
+
+// ParseFilter compiles a filter expression.
+func ParseFilter(expr string) (*Filter, error) {
+	tokens, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("filter expression is empty")
+	}
+	p := &filterParser{tokens: tokens}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q in filter", p.peek().text)
+	}
+	return &Filter{expr: strings.TrimSpace(expr), pred: pred}, nil
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Match reports whether t satisfies the filter. now anchors relative dates
+// such as due<7d and the overdue check.
+func (f *Filter) Match(t Todo, now time.Time) bool {
+	return f.pred(t, now)
+}
+
+// Apply returns the todos that match the filter, preserving order.
+func (f *Filter) Apply(todos []Todo, now time.Time) []Todo {
+	var out []Todo
+	for _, t := range todos {
+		if f.Match(t, now) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// --- terms ---
+
+// filterOps is checked in order so two-character operators win.
+var filterOps = []string{">=", "<=", "!=", ":", "=", "<", ">"}
+
+// parseFilterTerm compiles a single field/operator/value term.
+func parseFilterTerm(term string) (predicate, error) {
+	if strings.HasPrefix(term, "@") {
+		return parseFilterTerm("context:" + term[1:])
+	}
+
+	idx := strings.IndexAny(term, ":=<>!")
+	if idx < 0 {
+		word := strings.ToLower(term)
+		return func(t Todo, _ time.Time) bool {
+			return strings.Contains(strings.ToLower(t.Title), word)
+		}, nil
+	}
+	field := strings.ToLower(term[:idx])
+	op := ""
+	for _, candidate := range filterOps {
+		if strings.HasPrefix(term[idx:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("invalid operator in %q", term)
+	}
+	value := term[idx+len(op):]
+	if value == "" {
+		return nil, fmt.Errorf("%q is missing a value", term)
+	}
+
+	switch field {
+	case "priority", "prio", "p":
+		return priorityTerm(op, value)
+	case "schedule", "sched":
+		return scheduleTerm(op, value)
+	case "due":
+		return dueTerm(op, value)
+	case "estimate", "est":
+		return estimateTerm(op, value)
+	case "tag", "tags", "context", "ctx", "project", "proj", "waiting", "is", "title":
+		if op != ":" && op != "=" && op != "!=" {
+			return nil, fmt.Errorf("%s only supports %s and %s, not %q", field, ":", "!=", op)
+		}
+		pred, err := equalityTerm(field, value)
+		if err != nil {
+			return nil, err
+		}
+		if op == "!=" {
+			return func(t Todo, now time.Time) bool { return !pred(t, now) }, nil
+		}
+		return pred, nil
+	case "":
+		return nil, fmt.Errorf("%q is missing a field — valid fields: %s", term, filterFields)
+	default:
+		return nil, fmt.Errorf("unknown filter field %q — valid fields: %s", field, filterFields)
+	}
+}
+
+// compareOp applies op to the result of a three-way comparison.
+func compareOp(op string, c int) bool {
+	switch op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "!=":
+		return c != 0
+	default: // ":" and "="
+		return c == 0
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func priorityTerm(op, value string) (predicate, error) {
+	var want int
+	switch strings.ToLower(value) {
+	case "low", "l", "1":
+		want = PrioLow
+	case "med", "medium", "m", "2":
+		want = PrioMedium
+	case "high", "h", "3":
+		want = PrioHigh
+	case "crit", "critical", "c", "4":
+		want = PrioCrit
+	default:
+		return nil, fmt.Errorf("invalid priority %q — valid values: low, med, high, crit", value)
+	}
+	return func(t Todo, _ time.Time) bool {
+		return compareOp(op, compareInts(t.Priority, want))
+	}, nil
+}
+
+// scheduleRank orders buckets from most to least urgent, so schedule<=soon
+// means today or soon.
+func scheduleRank(schedule string) int {
+	switch schedule {
+	case ScheduleToday:
+		return 0
+	case ScheduleSoon:
+		return 1
+	case ScheduleLater:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func scheduleTerm(op, value string) (predicate, error) {
+	schedule, err := ParseSchedule(value)
+	if err != nil {
+		return nil, err
+	}
+	want := scheduleRank(schedule)
+	return func(t Todo, _ time.Time) bool {
+		return compareOp(op, compareInts(scheduleRank(t.Schedule), want))
+	}, nil
+}
+
+var relativeDayRe = regexp.MustCompile(`^(-?\d+)([dw])$`)
+
+// parseFilterDay resolves a due-date value to a day offset from today
+// ("today", "tomorrow", "7d", "2w") or to an absolute date ("2026-03-01").
+// Exactly one of offset/date is meaningful: date is zero for offsets.
+func parseFilterDay(value string) (offset int, date time.Time, err error) {
+	v := strings.ToLower(value)
+	switch v {
+	case "today":
+		return 0, time.Time{}, nil
+	case "tomorrow":
+		return 1, time.Time{}, nil
+	case "yesterday":
+		return -1, time.Time{}, nil
+	}
+	if m := relativeDayRe.FindStringSubmatch(v); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "w" {
+			n *= 7
+		}
+		return n, time.Time{}, nil
+	}
+	if d, err := time.Parse("2006-01-02", v); err == nil {
+		return 0, d, nil
+	}
+	return 0, time.Time{}, fmt.Errorf("invalid due value %q — use none, any, today, 7d, 2w, or YYYY-MM-DD", value)
+}
+
+func dueTerm(op, value string) (predicate, error) {
+	switch strings.ToLower(value) {
+	case "none", "any":
+		if op != ":" && op != "=" && op != "!=" {
+			return nil, fmt.Errorf("due:%s only supports %s and %s", value, ":", "!=")
+		}
+		wantDue := strings.EqualFold(value, "any") != (op == "!=")
+		return func(t Todo, _ time.Time) bool { return (t.DueDate != nil) == wantDue }, nil
+	}
+
+	offset, date, err := parseFilterDay(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(t Todo, now time.Time) bool {
+		if t.DueDate == nil {
+			return false
+		}
+		target := date
+		if target.IsZero() {
+			target = now.AddDate(0, 0, offset)
+		}
+		due := time.Date(t.DueDate.Year(), t.DueDate.Month(), t.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+		day := time.Date(target.Year(), target.Month(), target.Day(), 0, 0, 0, 0, time.UTC)
+		return compareOp(op, due.Compare(day))
+	}, nil
+}
+
+func estimateTerm(op, value string) (predicate, error) {
+	if strings.EqualFold(value, "none") {
+		if op != ":" && op != "=" && op != "!=" {
+			return nil, fmt.Errorf("estimate:none only supports %s and %s", ":", "!=")
+		}
+		wantNone := op != "!="
+		return func(t Todo, _ time.Time) bool { return (t.Estimate == 0) == wantNone }, nil
+	}
+	want, err := ParseEstimate(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(t Todo, _ time.Time) bool {
+		if t.Estimate == 0 {
+			return false
+		}
+		return compareOp(op, compareInts(int(t.Estimate), int(want)))
+	}, nil
+}
+
+// equalityTerm compiles the fields that only test for a match.
+func equalityTerm(field, value string) (predicate, error) {
+	lower := strings.ToLower(value)
+	switch field {
+	case "tag", "tags":
+		return func(t Todo, _ time.Time) bool {
+			for _, tag := range t.Tags {
+				if strings.EqualFold(tag, value) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "context", "ctx":
+		want := ""
+		if lower != "none" {
+			ctx, err := ParseContext(value)
+			if err != nil {
+				return nil, err
+			}
+			want = ctx
+		}
+		return func(t Todo, _ time.Time) bool { return t.Context == want }, nil
+	case "project", "proj":
+		if lower == "none" || lower == "global" {
+			return func(t Todo, _ time.Time) bool { return t.ProjectPath == nil }, nil
+		}
+		return func(t Todo, _ time.Time) bool {
+			return t.ProjectPath != nil && strings.EqualFold(filepath.Base(*t.ProjectPath), value)
+		}, nil
+	case "waiting":
+		if lower == "none" {
+			return func(t Todo, _ time.Time) bool { return t.WaitingOn == "" }, nil
+		}
+		who := strings.TrimPrefix(value, "@")
+		return func(t Todo, _ time.Time) bool { return strings.EqualFold(t.WaitingOn, who) }, nil
+	case "title":
+		return func(t Todo, _ time.Time) bool {
+			return strings.Contains(strings.ToLower(t.Title), lower)
+		}, nil
+	default: // "is"
+		switch lower {
+		case "done":
+			return func(t Todo, _ time.Time) bool { return t.Done }, nil
+		case "open":
+			return func(t Todo, _ time.Time) bool { return !t.Done }, nil
+		case "overdue":
+			return func(t Todo, now time.Time) bool { return t.IsOverdue(now) }, nil
+		case "pinned":
+			return func(t Todo, _ time.Time) bool { return t.Pinned }, nil
+		case "blocked":
+			return func(t Todo, _ time.Time) bool { return len(t.BlockedBy) > 0 }, nil
+		case "recurring":
+			return func(t Todo, _ time.Time) bool { return t.Recurrence != RecurrenceNone && t.Recurrence != "" }, nil
+		case "subtask":
+			return func(t Todo, _ time.Time) bool { return t.ParentID != nil }, nil
+		case "waiting":
+			return func(t Todo, _ time.Time) bool { return t.WaitingOn != "" }, nil
+		default:
+			return nil, fmt.Errorf("invalid is:%s — valid values: done, open, overdue, pinned, blocked, recurring, subtask, waiting", value)
+		}
+	}
+}
+
+// --- saved filters ---
+
+// SavedFilter is a named filter expression.
+type SavedFilter struct {
+	ID        int
+	Name      string
+	Expr      string
+	CreatedAt time.Time
+}
+
+var filterNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// SaveFilter stores a filter expression under name, replacing any filter
+// with the same (case-insensitive) name. The expression is validated first.
+// replaced reports whether an existing filter was overwritten.
+func (s *Store) SaveFilter(name, expr string) (replaced bool, err error) {
+	name = strings.TrimSpace(name)
+	if !filterNameRe.MatchString(name) {
+		return false, fmt.Errorf("invalid filter name %q — use letters, digits, '-' and '_'", name)
+	}
+	f, err := ParseFilter(expr)
+	if err != nil {
+		return false, err
+	}
+
+	var n int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM todo_filters WHERE name = ? COLLATE NOCASE`, name).Scan(&n); err != nil {
+		return false, err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO todo_filters (name, expr) VALUES (?, ?)
+		 ON CONFLICT(name) DO UPDATE SET expr = excluded.expr`,
+		name, f.String(),
+	)
+	return n > 0, err
+}
+
+// GetFilter returns the saved filter with the given name.
+func (s *Store) GetFilter(name string) (*SavedFilter, error) {
+	var f SavedFilter
+	var createdAt string
+	err := s.db.QueryRow(
+		`SELECT id, name, expr, created_at FROM todo_filters WHERE name = ? COLLATE NOCASE`,
+		strings.TrimSpace(name),
+	).Scan(&f.ID, &f.Name, &f.Expr, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %q", ErrFilterNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.CreatedAt = parseTimestamp(createdAt)
+	return &f, nil
+}
+
+// ListFilters returns all saved filters ordered by name.
+func (s *Store) ListFilters() ([]SavedFilter, error) {
+	rows, err := s.db.Query(`SELECT id, name, expr, created_at FROM todo_filters ORDER BY name COLLATE NOCASE`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []SavedFilter
+	for rows.Next() {
+		var f SavedFilter
+		var createdAt string
+		if err := rows.Scan(&f.ID, &f.Name, &f.Expr, &createdAt); err != nil {
+			return nil, err
+		}
+		f.CreatedAt = parseTimestamp(createdAt)
+		out = append(out, f)
+	}
+	return out, rows.Err()
+}
+
+// DeleteFilter removes a saved filter by name.
+func (s *Store) DeleteFilter(name string) error {
+	res, err := s.db.Exec(`DELETE FROM todo_filters WHERE name = ? COLLATE NOCASE`, strings.TrimSpace(name))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %q", ErrFilterNotFound, name)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package todo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// --- lexer ---
+
+type filterTokenKind int
+
+const (
+	tokWord filterTokenKind = iota
+	tokLParen
+	tokRParen
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+// lexFilter splits an expression into words and parentheses. Double quotes
+// keep spaces and parentheses inside a word: title:"fix login".
+func lexFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokRParen, text: ")"})
+			i++
+		default:
+			var word strings.Builder
+			quoted := false
+			for ; i < len(runes); i++ {
+				c := runes[i]
+				if c == '"' {
+					quoted = !quoted
+					continue
+				}
+				if !quoted && (unicode.IsSpace(c) || c == '(' || c == ')') {
+					break
+				}
+				word.WriteRune(c)
+			}
+			if quoted {
+				return nil, fmt.Errorf("unterminated quote in filter %q", expr)
+			}
+			tokens = append(tokens, filterToken{kind: tokWord, text: word.String()})
+		}
+	}
+	return tokens, nil
+}
+
+// --- parser ---
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+// keyword reports whether the next token is the given operator keyword.
+func (p *filterParser) keyword(kw string) bool {
+	return !p.done() && p.peek().kind == tokWord && strings.EqualFold(p.peek().text, kw)
+}
+
+func (p *filterParser) parseOr() (predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(t Todo, now time.Time) bool { return l(t, now) || right(t, now) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (predicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && p.peek().kind != tokRParen && !p.keyword("OR") {
+		if p.keyword("AND") {
+			p.pos++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(t Todo, now time.Time) bool { return l(t, now) && right(t, now) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (predicate, error) {
+	if p.keyword("NOT") {
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(t Todo, now time.Time) bool { return !inner(t, now) }, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (predicate, error) {
+	if p.done() {
+		return nil, fmt.Errorf("filter ends unexpectedly — expected a term")
+	}
+	tok := p.peek()
+	p.pos++
+	switch {
+	case tok.kind == tokRParen:
+		return nil, fmt.Errorf("unexpected %q in filter", ")")
+	case tok.kind == tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().kind != tokRParen {
+			return nil, fmt.Errorf("missing %q in filter", ")")
+		}
+		p.pos++
+		return inner, nil
+	case strings.EqualFold(tok.text, "AND") || strings.EqualFold(tok.text, "OR"):
+		return nil, fmt.Errorf("%s needs a term on both sides", strings.ToUpper(tok.text))
+	}
+	return parseFilterTerm(tok.text)
+}
@@ -0,0 +1,126 @@
+package todo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilter_Match(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	day := func(offset int) *time.Time {
+		d := time.Date(2026, 3, 10+offset, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	proj := "/home/me/code/mine"
+	infra := Todo{Title: "Rotate certs", Priority: PrioHigh, Tags: []string{"infra"}, DueDate: day(3), Schedule: ScheduleSoon, Estimate: 45 * time.Minute, ProjectPath: &proj}
+	errand := Todo{Title: "Buy stamps", Priority: PrioLow, Context: "errands", Schedule: ScheduleToday, Pinned: true}
+	late := Todo{Title: "File taxes", Priority: PrioCrit, DueDate: day(-2), Schedule: ScheduleLater, BlockedBy: []int{9}}
+
+	cases := []struct {
+		expr string
+		want []string
+	}{
+		{"priority>=high AND tag:infra AND due<7d", []string{"Rotate certs"}},
+		{"priority>=high", []string{"Rotate certs", "File taxes"}},
+		{"priority=low", []string{"Buy stamps"}},
+		{"priority!=crit", []string{"Rotate certs", "Buy stamps"}},
+		{"@errands OR tag:infra", []string{"Rotate certs", "Buy stamps"}},
+		{"NOT tag:infra", []string{"Buy stamps", "File taxes"}},
+		{"tag!=infra", []string{"Buy stamps", "File taxes"}},
+		{"due<=today", []string{"File taxes"}},
+		{"due:none", []string{"Buy stamps"}},
+		{"due:any due>=2026-03-12", []string{"Rotate certs"}},
+		{"is:overdue", []string{"File taxes"}},
+		{"is:pinned OR is:blocked", []string{"Buy stamps", "File taxes"}},
+		{"schedule<=soon", []string{"Rotate certs", "Buy stamps"}},
+		{"estimate<=1h", []string{"Rotate certs"}},
+		{"estimate:none", []string{"Buy stamps", "File taxes"}},
+		{"project:mine", []string{"Rotate certs"}},
+		{"project:none AND (priority=crit OR context:errands)", []string{"Buy stamps", "File taxes"}},
+		{`title:"file tax"`, []string{"File taxes"}},
+		{"stamps", []string{"Buy stamps"}},
+		{"context:none", []string{"Rotate certs", "File taxes"}},
+	}
+	for _, c := range cases {
+		f, err := ParseFilter(c.expr)
+		if err != nil {
+			t.Errorf("ParseFilter(%q): %v", c.expr, err)
+			continue
+		}
+		var got []string
+		for _, td := range f.Apply([]Todo{infra, errand, late}, now) {
+			got = append(got, td.Title)
+		}
+		if strings.Join(got, "|") != strings.Join(c.want, "|") {
+			t.Errorf("%q matched %v, want %v", c.expr, got, c.want)
+		}
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	cases := map[string]string{
+		"":                  "empty",
+		"priority>=urgent":  "invalid priority",
+		"color:red":         "unknown filter field",
+		"tag>infra":         "only supports",
+		"due<soonish":       "invalid due value",
+		"is:lazy":           "invalid is:lazy",
+		"(tag:a OR tag:b":   "missing",
+		"tag:a OR":          "expected a term",
+		"tag:":              "missing a value",
+		"AND tag:a":         "needs a term",
+		`title:"unfinished`: "unterminated quote",
+		"tag:a )":           "unexpected",
+	}
+	for expr, want := range cases {
+		_, err := ParseFilter(expr)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseFilter(%q) error = %v, want containing %q", expr, err, want)
+		}
+	}
+}
+
+func TestSavedFilters(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	replaced, err := s.SaveFilter("urgent", "priority>=high  AND due<7d ")
+	if err != nil || replaced {
+		t.Fatalf("SaveFilter = (%v, %v)", replaced, err)
+	}
+	if replaced, err = s.SaveFilter("Urgent", "priority=crit"); err != nil || !replaced {
+		t.Fatalf("SaveFilter overwrite = (%v, %v)", replaced, err)
+	}
+	if _, err := s.SaveFilter("bad name", "tag:x"); err == nil {
+		t.Error("expected invalid name error")
+	}
+	if _, err := s.SaveFilter("broken", "priority>>"); err == nil {
+		t.Error("expected invalid expression to be rejected")
+	}
+
+	f, err := s.GetFilter("URGENT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Expr != "priority=crit" {
+		t.Errorf("expr = %q, want overwritten value", f.Expr)
+	}
+
+	s.SaveFilter("home", "@home")
+	list, err := s.ListFilters()
+	if err != nil || len(list) != 2 || list[0].Name != "home" {
+		t.Fatalf("ListFilters = %+v, %v", list, err)
+	}
+
+	if err := s.DeleteFilter("urgent"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetFilter("urgent"); !errors.Is(err, ErrFilterNotFound) {
+		t.Errorf("expected ErrFilterNotFound, got %v", err)
+	}
+	if err := s.DeleteFilter("urgent"); !errors.Is(err, ErrFilterNotFound) {
+		t.Errorf("expected ErrFilterNotFound on second delete, got %v", err)
+	}
+}
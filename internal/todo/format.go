@@ -1,6 +1,10 @@
 package todo
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rnwolfe/mine/internal/ui"
 )
@@ -30,12 +34,114 @@ func FormatPriorityIcon(p int) string {
 func FormatScheduleTag(schedule string) string {
 	switch schedule {
 	case ScheduleToday:
-		return lipgloss.NewStyle().Width(ColWidthSched).Render(ui.ScheduleTodayStyle.Render("▸T"))
+		return lipgloss.NewStyle().Width(ColWidthSched).Render(ui.ScheduleTodayStyle.Render(ui.IconSchedule + "T"))
 	case ScheduleSoon:
-		return lipgloss.NewStyle().Width(ColWidthSched).Render(ui.ScheduleSoonStyle.Render("▸S"))
+		return lipgloss.NewStyle().Width(ColWidthSched).Render(ui.ScheduleSoonStyle.Render(ui.IconSchedule + "S"))
 	case ScheduleSomeday:
-		return lipgloss.NewStyle().Width(ColWidthSched).Render(ui.Muted.Render("▸?"))
+		return lipgloss.NewStyle().Width(ColWidthSched).Render(ui.Muted.Render(ui.IconSchedule + "?"))
 	default: // later
-		return lipgloss.NewStyle().Width(ColWidthSched).Render(ui.Muted.Render("▸·"))
+		return lipgloss.NewStyle().Width(ColWidthSched).Render(ui.Muted.Render(ui.IconSchedule + ui.IconDot))
+	}
+}
+
+// FormatSubtaskIndent returns the title prefix for a todo nested depth levels
+// below a parent: two spaces per extra level followed by "↳ ". Returns "" for
+// top-level todos.
+func FormatSubtaskIndent(depth int) string {
+	if depth <= 0 {
+		return ""
+	}
+	return strings.Repeat("  ", depth-1) + ui.Muted.Render(ui.IconSubtask)
+}
+
+// FormatIDs renders todo IDs as "#1, #2".
+func FormatIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("#%d", id)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatBlockedTag returns the " ⧗ blocked by #N" annotation for a todo with
+// open dependencies, or "" when the todo is actionable.
+func FormatBlockedTag(blockedBy []int) string {
+	if len(blockedBy) == 0 {
+		return ""
+	}
+	return ui.Warning.Render(" " + ui.IconBlocked + " blocked by " + FormatIDs(blockedBy))
+}
+
+// FormatContextTag returns the " @context" annotation for a todo, or "" when
+// it has no context.
+func FormatContextTag(ctx string) string {
+	if ctx == "" {
+		return ""
+	}
+	return ui.Accent.Render(" " + ContextLabel(ctx))
+}
+
+// FormatPinnedTag returns the " 📌" marker for a pinned todo, or "" otherwise.
+func FormatPinnedTag(pinned bool) string {
+	if !pinned {
+		return ""
+	}
+	return " " + ui.IconPin
+}
+
+// FormatWaitingTag returns the " ⏳ alice" delegation annotation for a todo,
+// or "" when it isn't waiting on anyone.
+func FormatWaitingTag(who string) string {
+	if who == "" {
+		return ""
+	}
+	return ui.Muted.Render(" " + ui.IconWaiting + " " + who)
+}
+
+// FormatEstimateTag returns the " ~30m" effort annotation for a todo, or ""
+// when it has no estimate.
+func FormatEstimateTag(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return ui.Muted.Render(" ~" + FormatEstimate(d))
+}
+
+// DueLabel formats a todo's due date with the given date layout, appending
+// the time of day (e.g. "Jan 2 5:00pm") when one is set. Returns "" if no due date.
+func DueLabel(t Todo, layout string) string {
+	if t.DueDate == nil {
+		return ""
+	}
+	s := t.DueDate.Format(layout)
+	if t.DueHasTime {
+		s += " " + t.DueDate.Format("3:04pm")
+	}
+	return s
+}
+
+// FormatDueAnnotation returns the styled due-date suffix used in list views:
+// overdue in red, due today in amber, otherwise muted. Returns "" for todos
+// that are done or have no due date.
+func FormatDueAnnotation(t Todo, now time.Time) string {
+	if t.DueDate == nil || t.Done {
+		return ""
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	due := *t.DueDate
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, now.Location())
+
+	switch {
+	case t.IsOverdue(now):
+		return ui.Error.Render(fmt.Sprintf(" (overdue: %s)", DueLabel(t, "Jan 2")))
+	case dueDay.Equal(today):
+		if t.DueHasTime {
+			return ui.Warning.Render(fmt.Sprintf(" (due today %s!)", due.Format("3:04pm")))
+		}
+		return ui.Warning.Render(" (due today!)")
+	case dueDay.Before(today.AddDate(0, 0, 7)):
+		return ui.Muted.Render(fmt.Sprintf(" (due %s)", DueLabel(t, "Mon")))
+	default:
+		return ui.Muted.Render(fmt.Sprintf(" (due %s)", DueLabel(t, "Jan 2")))
 	}
 }
@@ -0,0 +1,358 @@
+package todo
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Journaled mutation kinds recorded in todo_history.
+const (
+	HistoryDone     = "done"
+	HistoryRemove   = "rm"
+	HistoryEdit     = "edit"
+	HistorySchedule = "schedule"
+)
+
+// historyLimit is how many journal entries are kept; older ones are pruned.
+const historyLimit = 200
+
+// ErrNothingToUndo is returned by Undo when the journal is empty.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// historySnapshot is the JSON payload stored per journal entry: the todo row
+// as it was before the mutation, plus whatever a delete cascades away.
+type historySnapshot struct {
+	Row         map[string]any   `json:"row"`
+	Notes       []map[string]any `json:"notes,omitempty"`
+	Attachments []map[string]any `json:"attachments,omitempty"`
+	Reminders   []map[string]any `json:"reminders,omitempty"`
+	Links       []map[string]any `json:"links,omitempty"`
+	Deps        [][2]int         `json:"deps,omitempty"`
+	Children    []int            `json:"children,omitempty"`
+	SpawnedID   int              `json:"spawned_id,omitempty"`
+}
+
+// UndoneEntry describes one reverted mutation.
+type UndoneEntry struct {
+	Action string
+	TodoID int
+	Title  string
+}
+
+// BeginBatch groups every mutation journaled from now on into one undo step,
+// so a bulk command like "done 3 5 7-9" is reverted as a whole.
+func (s *Store) BeginBatch() {
+	s.batch = uuid.NewString()
+}
+
+// EndBatch closes the current batch; later mutations are undone one by one.
+func (s *Store) EndBatch() {
+	s.batch = ""
+}
+
+// snapshot captures a todo's current row. When full is true it also captures
+// notes, attachments, reminders, dependency edges, and child links that a
+// delete would destroy, plus the external links it would orphan.
+func (s *Store) snapshot(id int, full bool) (*historySnapshot, error) {
+	rows, err := s.db.Query(`SELECT * FROM todos WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting todo #%d: %w", id, err)
+	}
+	maps, err := scanMaps(rows)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting todo #%d: %w", id, err)
+	}
+	if len(maps) == 0 {
+		return nil, fmt.Errorf("todo #%d not found", id)
+	}
+	snap := &historySnapshot{Row: maps[0]}
+	if !full {
+		return snap, nil
+	}
+
+	rows, err = s.db.Query(`SELECT * FROM todo_notes WHERE todo_id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting notes: %w", err)
+	}
+	if snap.Notes, err = scanMaps(rows); err != nil {
+		return nil, fmt.Errorf("snapshotting notes: %w", err)
+	}
+
+	rows, err = s.db.Query(`SELECT * FROM todo_attachments WHERE todo_id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting attachments: %w", err)
+	}
+	if snap.Attachments, err = scanMaps(rows); err != nil {
+		return nil, fmt.Errorf("snapshotting attachments: %w", err)
+	}
+
+	rows, err = s.db.Query(`SELECT * FROM todo_reminders WHERE todo_id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting reminders: %w", err)
+	}
+	if snap.Reminders, err = scanMaps(rows); err != nil {
+		return nil, fmt.Errorf("snapshotting reminders: %w", err)
+	}
+
+	rows, err = s.db.Query(`SELECT * FROM todo_links WHERE todo_id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting links: %w", err)
+	}
+	if snap.Links, err = scanMaps(rows); err != nil {
+		return nil, fmt.Errorf("snapshotting links: %w", err)
+	}
+
+	depRows, err := s.db.Query(`SELECT todo_id, depends_on FROM todo_deps WHERE todo_id = ? OR depends_on = ?`, id, id)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting dependencies: %w", err)
+	}
+	for depRows.Next() {
+		var edge [2]int
+		if err := depRows.Scan(&edge[0], &edge[1]); err != nil {
+			depRows.Close()
+			return nil, err
+		}
+		snap.Deps = append(snap.Deps, edge)
+	}
+	depRows.Close()
+
+	childRows, err := s.db.Query(`SELECT id FROM todos WHERE parent_id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting subtasks: %w", err)
+	}
+	for childRows.Next() {
+		var cid int
+		if err := childRows.Scan(&cid); err != nil {
+			childRows.Close()
+			return nil, err
+		}
+		snap.Children = append(snap.Children, cid)
+	}
+	childRows.Close()
+
+	return snap, nil
+}
+
+// journal records a completed mutation so Undo can revert it.
+func (s *Store) journal(action string, id int, snap *historySnapshot) error {
+	payload, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("encoding history: %w", err)
+	}
+	batch := s.batch
+	if batch == "" {
+		batch = uuid.NewString()
+	}
+	if _, err := s.db.Exec(
+		`INSERT INTO todo_history (batch, action, todo_id, snapshot) VALUES (?, ?, ?, ?)`,
+		batch, action, id, string(payload),
+	); err != nil {
+		return fmt.Errorf("writing history: %w", err)
+	}
+	_, err = s.db.Exec(
+		`DELETE FROM todo_history WHERE id NOT IN (SELECT id FROM todo_history ORDER BY id DESC LIMIT ?)`,
+		historyLimit,
+	)
+	return err
+}
+
+// Undo reverts the most recent journaled mutation (or batch of mutations),
+// newest first, and removes it from the journal.
+func (s *Store) Undo() ([]UndoneEntry, error) {
+	var batch string
+	err := s.db.QueryRow(`SELECT batch FROM todo_history ORDER BY id DESC LIMIT 1`).Scan(&batch)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNothingToUndo
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+
+	type entry struct {
+		id      int
+		action  string
+		todoID  int
+		payload string
+	}
+	rows, err := s.db.Query(
+		`SELECT id, action, todo_id, snapshot FROM todo_history WHERE batch = ? ORDER BY id DESC`, batch,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	var entries []entry
+	for rows.Next() {
+		var e entry
+		if err := rows.Scan(&e.id, &e.action, &e.todoID, &e.payload); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	rows.Close()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	var undone []UndoneEntry
+	for _, e := range entries {
+		var snap historySnapshot
+		if err := json.Unmarshal([]byte(e.payload), &snap); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("decoding history entry %d: %w", e.id, err)
+		}
+		if err := restoreSnapshot(tx, e.action, e.todoID, &snap); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("undoing %s on #%d: %w", e.action, e.todoID, err)
+		}
+		title, _ := snap.Row["title"].(string)
+		undone = append(undone, UndoneEntry{Action: e.action, TodoID: e.todoID, Title: title})
+	}
+	if _, err := tx.Exec(`DELETE FROM todo_history WHERE batch = ?`, batch); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return undone, tx.Commit()
+}
+
+// restoreSnapshot writes a snapshot back. Deleted todos are re-inserted with
+// their original ID, notes, attachments, reminders, dependencies, subtask
+// links, and external links; other actions overwrite the row in place.
+func restoreSnapshot(tx *sql.Tx, action string, id int, snap *historySnapshot) error {
+	if snap.SpawnedID != 0 {
+		if _, err := tx.Exec(`DELETE FROM todos WHERE id = ?`, snap.SpawnedID); err != nil {
+			return fmt.Errorf("removing spawned occurrence #%d: %w", snap.SpawnedID, err)
+		}
+	}
+
+	if action != HistoryRemove {
+		cols, args := snapshotAssignments(snap.Row)
+		args = append(args, id)
+		res, err := tx.Exec(fmt.Sprintf(`UPDATE todos SET %s WHERE id = ?`, strings.Join(cols, ", ")), args...)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return fmt.Errorf("todo #%d no longer exists", id)
+		}
+		return nil
+	}
+
+	if err := insertMap(tx, "todos", snap.Row); err != nil {
+		return err
+	}
+	for _, n := range snap.Notes {
+		if err := insertMap(tx, "todo_notes", n); err != nil {
+			return err
+		}
+	}
+	for _, a := range snap.Attachments {
+		if err := insertMap(tx, "todo_attachments", a); err != nil {
+			return err
+		}
+	}
+	for _, r := range snap.Reminders {
+		if err := insertMap(tx, "todo_reminders", r); err != nil {
+			return err
+		}
+	}
+	for _, l := range snap.Links {
+		// The delete only unset todo_id; re-insert if the link went too.
+		res, err := tx.Exec(`UPDATE todo_links SET todo_id = ? WHERE id = ?`, id, l["id"])
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			if err := insertMap(tx, "todo_links", l); err != nil {
+				return err
+			}
+		}
+	}
+	for _, edge := range snap.Deps {
+		if _, err := tx.Exec(
+			`INSERT OR IGNORE INTO todo_deps (todo_id, depends_on)
+			 SELECT ?, ? WHERE EXISTS (SELECT 1 FROM todos WHERE id = ?) AND EXISTS (SELECT 1 FROM todos WHERE id = ?)`,
+			edge[0], edge[1], edge[0], edge[1],
+		); err != nil {
+			return err
+		}
+	}
+	for _, cid := range snap.Children {
+		if _, err := tx.Exec(`UPDATE todos SET parent_id = ? WHERE id = ? AND parent_id IS NULL`, id, cid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotAssignments builds "col = ?" pairs for every column except id.
+func snapshotAssignments(row map[string]any) ([]string, []any) {
+	var cols []string
+	var args []any
+	for k, v := range row {
+		if k == "id" {
+			continue
+		}
+		cols = append(cols, k+" = ?")
+		args = append(args, v)
+	}
+	return cols, args
+}
+
+func insertMap(tx *sql.Tx, table string, row map[string]any) error {
+	cols := make([]string, 0, len(row))
+	marks := make([]string, 0, len(row))
+	args := make([]any, 0, len(row))
+	for k, v := range row {
+		cols = append(cols, k)
+		marks = append(marks, "?")
+		args = append(args, v)
+	}
+	_, err := tx.Exec(
+		fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, table, strings.Join(cols, ", "), strings.Join(marks, ", ")),
+		args...,
+	)
+	return err
+}
+
+// scanMaps reads all rows into column→value maps, normalizing timestamps to
+// SQLite's CURRENT_TIMESTAMP format so restored rows sort and compare like
+// freshly written ones.
+func scanMaps(rows *sql.Rows) ([]map[string]any, error) {
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var out []map[string]any
+	for rows.Next() {
+		vals := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		m := make(map[string]any, len(cols))
+		for i, c := range cols {
+			switch v := vals[i].(type) {
+			case time.Time:
+				m[c] = v.UTC().Format("2006-01-02 15:04:05")
+			case []byte:
+				m[c] = string(v)
+			default:
+				m[c] = v
+			}
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,208 @@
+package todo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUndo_NothingToUndo(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	if _, err := s.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("expected ErrNothingToUndo, got %v", err)
+	}
+}
+
+func TestUndo_Complete_RemovesSpawnedOccurrence(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	due := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	id, _ := s.Add("weekly", "", PrioMedium, nil, &due, nil, ScheduleLater, RecurrenceWeekly)
+	spawned, _, err := s.Complete(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	undone, err := s.Undo()
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if len(undone) != 1 || undone[0].Action != HistoryDone || undone[0].TodoID != id || undone[0].Title != "weekly" {
+		t.Fatalf("unexpected undone entries: %+v", undone)
+	}
+
+	got, _ := s.Get(id)
+	if got.Done || got.CompletedAt != nil {
+		t.Errorf("expected #%d reopened, got done=%v completed=%v", id, got.Done, got.CompletedAt)
+	}
+	if _, err := s.Get(spawned); err == nil {
+		t.Errorf("expected spawned #%d removed", spawned)
+	}
+	if _, err := s.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("expected journal empty after undo, got %v", err)
+	}
+}
+
+func TestUndo_Delete_RestoresNotesDepsAndSubtasks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	due := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	id, _ := s.Add("doomed", "body", PrioHigh, []string{"a", "b"}, &due, nil, ScheduleToday, RecurrenceNone)
+	other, _ := s.Add("other", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	child, _ := s.Add("child", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	_ = s.AddNote(id, "remember this")
+	_ = s.AddDependency(other, id)
+	_ = s.SetParent(child, &id)
+
+	if err := s.Delete(id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+
+	got, err := s.GetWithNotes(id)
+	if err != nil {
+		t.Fatalf("expected #%d restored: %v", id, err)
+	}
+	if got.Title != "doomed" || got.Body != "body" || got.Priority != PrioHigh || got.Schedule != ScheduleToday {
+		t.Errorf("restored fields mismatch: %+v", got)
+	}
+	if len(got.Tags) != 2 || got.DueDate == nil || got.DueDate.Format("2006-01-02") != "2030-05-01" {
+		t.Errorf("restored tags/due mismatch: %v %v", got.Tags, got.DueDate)
+	}
+	if len(got.Notes) != 1 || got.Notes[0].Body != "remember this" {
+		t.Errorf("expected note restored, got %+v", got.Notes)
+	}
+	o, _ := s.Get(other)
+	if len(o.BlockedBy) != 1 || o.BlockedBy[0] != id {
+		t.Errorf("expected dependency restored, got %v", o.BlockedBy)
+	}
+	c, _ := s.Get(child)
+	if c.ParentID == nil || *c.ParentID != id {
+		t.Errorf("expected subtask link restored, got %v", c.ParentID)
+	}
+}
+
+func TestUndo_Delete_RestoresReminders(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	id, _ := s.Add("call dentist", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	at := time.Now().Add(24 * time.Hour)
+	if _, err := s.AddReminder(id, at); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Delete(id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+
+	reminders, err := s.PendingReminders(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reminders) != 1 {
+		t.Fatalf("expected reminder restored, got %+v", reminders)
+	}
+}
+
+func TestUndo_Delete_RestoresLinks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	id, _ := s.Add("fix login bug", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := s.AddLink(id, "github", "owner/repo#12", "https://github.com/owner/repo/issues/12"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Delete(id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+
+	links, err := s.LinksForTodo(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 1 || links[0].ExternalID != "owner/repo#12" {
+		t.Fatalf("expected link restored, got %+v", links)
+	}
+	var count int
+	db.QueryRow(`SELECT COUNT(*) FROM todo_links`).Scan(&count)
+	if count != 1 {
+		t.Errorf("expected the existing link row reused, got %d rows", count)
+	}
+}
+
+func TestUndo_EditAndSchedule_NewestFirst(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	id, _ := s.Add("original", "", PrioLow, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	title := "renamed"
+	prio := PrioCrit
+	if err := s.Edit(id, &title, &prio); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetSchedule(id, ScheduleToday); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(id)
+	if got.Schedule != ScheduleLater || got.Title != "renamed" {
+		t.Errorf("after first undo: schedule=%q title=%q", got.Schedule, got.Title)
+	}
+
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = s.Get(id)
+	if got.Title != "original" || got.Priority != PrioLow {
+		t.Errorf("after second undo: title=%q prio=%d", got.Title, got.Priority)
+	}
+}
+
+func TestUndo_BatchRevertsTogether(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("b", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	s.BeginBatch()
+	s.Complete(a)
+	s.Complete(b)
+
+	undone, err := s.Undo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(undone) != 2 {
+		t.Fatalf("expected 2 entries undone, got %d", len(undone))
+	}
+	for _, id := range []int{a, b} {
+		if got, _ := s.Get(id); got.Done {
+			t.Errorf("expected #%d reopened", id)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package todo
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// icsTimedEventLength is how long a calendar event for a todo with a due
+// time lasts. Date-only todos become all-day events.
+const icsTimedEventLength = 30 * time.Minute
+
+// RenderICS formats todos with due dates as an iCalendar (RFC 5545) feed.
+// Each todo yields a VEVENT, so it shows up in calendar apps, and a VTODO,
+// so task-aware apps can track it. Todos without a due date are skipped.
+// now stamps DTSTAMP. Returns a complete (possibly empty) VCALENDAR.
+func RenderICS(todos []Todo, now time.Time) string {
+	var b strings.Builder
+	w := func(line string) {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	w("BEGIN:VCALENDAR")
+	w("VERSION:2.0")
+	w("PRODID:-//rnwolfe//mine//EN")
+	w("CALSCALE:GREGORIAN")
+	w("X-WR-CALNAME:mine todos")
+
+	for _, t := range todos {
+		if t.DueDate == nil {
+			continue
+		}
+		uid := todoUUID(t)
+		summary := "SUMMARY:" + escapeICSText(t.Title)
+
+		w("BEGIN:VEVENT")
+		w("UID:" + uid + "-event@mine")
+		w("DTSTAMP:" + stamp)
+		if t.DueHasTime {
+			start := t.DueDate.UTC()
+			w("DTSTART:" + start.Format("20060102T150405Z"))
+			w("DTEND:" + start.Add(icsTimedEventLength).Format("20060102T150405Z"))
+		} else {
+			w("DTSTART;VALUE=DATE:" + t.DueDate.Format("20060102"))
+			w("DTEND;VALUE=DATE:" + t.DueDate.AddDate(0, 0, 1).Format("20060102"))
+		}
+		w(summary)
+		writeICSDetails(w, t)
+		w("TRANSP:TRANSPARENT")
+		w("END:VEVENT")
+
+		w("BEGIN:VTODO")
+		w("UID:" + uid + "-todo@mine")
+		w("DTSTAMP:" + stamp)
+		if t.DueHasTime {
+			w("DUE:" + t.DueDate.UTC().Format("20060102T150405Z"))
+		} else {
+			w("DUE;VALUE=DATE:" + t.DueDate.Format("20060102"))
+		}
+		w(summary)
+		writeICSDetails(w, t)
+		w(fmt.Sprintf("PRIORITY:%d", icsPriority(t.Priority)))
+		if t.Done {
+			w("STATUS:COMPLETED")
+			if t.CompletedAt != nil {
+				w("COMPLETED:" + t.CompletedAt.UTC().Format("20060102T150405Z"))
+			}
+		} else {
+			w("STATUS:NEEDS-ACTION")
+		}
+		w("END:VTODO")
+	}
+
+	w("END:VCALENDAR")
+	return b.String()
+}
+
+// writeICSDetails writes the properties shared by a todo's VEVENT and VTODO.
+func writeICSDetails(w func(string), t Todo) {
+	if t.Body != "" {
+		w("DESCRIPTION:" + escapeICSText(t.Body))
+	}
+	var categories []string
+	if t.ProjectPath != nil {
+		categories = append(categories, filepath.Base(*t.ProjectPath))
+	}
+	if t.Context != "" {
+		categories = append(categories, ContextLabel(t.Context))
+	}
+	categories = append(categories, t.Tags...)
+	if len(categories) > 0 {
+		escaped := make([]string, len(categories))
+		for i, c := range categories {
+			escaped[i] = escapeICSText(c)
+		}
+		w("CATEGORIES:" + strings.Join(escaped, ","))
+	}
+}
+
+// icsPriority maps mine priorities onto iCalendar's 1 (highest) to 9 (lowest).
+func icsPriority(p int) int {
+	switch p {
+	case PrioCrit:
+		return 1
+	case PrioHigh:
+		return 3
+	case PrioLow:
+		return 9
+	default:
+		return 5
+	}
+}
+
+// escapeICSText escapes a TEXT value per RFC 5545 §3.3.11.
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICSLine folds a content line at 75 octets, continuing with a leading
+// space, without splitting a UTF-8 sequence.
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+	var b strings.Builder
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		width = limit - 1 // the leading space counts toward the next line
+	}
+	b.WriteString(line)
+	return b.String()
+}
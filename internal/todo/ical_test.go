@@ -0,0 +1,71 @@
+package todo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderICS(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2026, 3, 11, 17, 30, 0, 0, time.UTC)
+	todos := []Todo{
+		{ID: 1, Title: "pay rent", DueDate: &day, Priority: PrioHigh, Tags: []string{"home"}},
+		{ID: 2, Title: "standup; notes, etc", DueDate: &at, DueHasTime: true, Body: "line one\nline two"},
+		{ID: 3, Title: "no due date"},
+	}
+
+	out := RenderICS(todos, now)
+
+	if !strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+		t.Fatalf("expected a VCALENDAR wrapper, got:\n%s", out)
+	}
+	for _, want := range []string{
+		"DTSTART;VALUE=DATE:20260310\r\n",
+		"DTEND;VALUE=DATE:20260311\r\n",
+		"DUE;VALUE=DATE:20260310\r\n",
+		"PRIORITY:3\r\n",
+		"CATEGORIES:home\r\n",
+		"DTSTART:20260311T173000Z\r\n",
+		"DTEND:20260311T180000Z\r\n",
+		`SUMMARY:standup\; notes\, etc` + "\r\n",
+		`DESCRIPTION:line one\nline two` + "\r\n",
+		"DTSTAMP:20260301T080000Z\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "no due date") {
+		t.Error("expected todos without a due date to be skipped")
+	}
+	if got := strings.Count(out, "BEGIN:VEVENT"); got != 2 {
+		t.Errorf("expected 2 events, got %d", got)
+	}
+	if got := strings.Count(out, "BEGIN:VTODO"); got != 2 {
+		t.Errorf("expected 2 tasks, got %d", got)
+	}
+}
+
+func TestRenderICS_CompletedTodo(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	done := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	out := RenderICS([]Todo{{ID: 1, Title: "done", DueDate: &day, Done: true, CompletedAt: &done}}, done)
+	if !strings.Contains(out, "STATUS:COMPLETED\r\n") || !strings.Contains(out, "COMPLETED:20260309T120000Z\r\n") {
+		t.Errorf("expected completed VTODO, got:\n%s", out)
+	}
+}
+
+func TestFoldICSLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	folded := foldICSLine(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("folded line exceeds 75 octets: %d", len(part))
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != line {
+		t.Errorf("unfolding should restore the line, got %q", unfolded)
+	}
+}
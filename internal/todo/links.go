@@ -0,0 +1,91 @@
+package todo
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// LinkProviderGitHub identifies GitHub issue links. External IDs take the
+// form "owner/repo#123".
+const LinkProviderGitHub = "github"
+
+// Link ties a todo to an item in an external tracker. TodoID is 0 when the
+// todo was deleted; the link is kept so the item is not imported again.
+type Link struct {
+	TodoID     int
+	Provider   string
+	ExternalID string
+	URL        string
+}
+
+// AddLink records that todoID mirrors an external item.
+func (s *Store) AddLink(todoID int, provider, externalID, url string) error {
+	if _, err := s.db.Exec(
+		`INSERT INTO todo_links (todo_id, provider, external_id, url) VALUES (?, ?, ?, ?)`,
+		todoID, provider, externalID, url,
+	); err != nil {
+		return fmt.Errorf("linking #%d to %s: %w", todoID, externalID, err)
+	}
+	return nil
+}
+
+// Links returns links for provider whose external ID starts with prefix
+// (e.g. "owner/repo#"), keyed by external ID.
+func (s *Store) Links(provider, prefix string) (map[string]Link, error) {
+	rows, err := s.db.Query(
+		`SELECT todo_id, provider, external_id, url FROM todo_links
+		 WHERE provider = ? AND substr(external_id, 1, ?) = ?`,
+		provider, len(prefix), prefix,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing links: %w", err)
+	}
+	return scanLinks(rows, func(l Link) string { return l.ExternalID })
+}
+
+// LinksForTodo returns every external link of a todo.
+func (s *Store) LinksForTodo(todoID int) ([]Link, error) {
+	rows, err := s.db.Query(
+		`SELECT todo_id, provider, external_id, url FROM todo_links WHERE todo_id = ? ORDER BY id`,
+		todoID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing links: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Link
+	for rows.Next() {
+		l, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
+
+func scanLinks(rows *sql.Rows, key func(Link) string) (map[string]Link, error) {
+	defer rows.Close()
+	out := map[string]Link{}
+	for rows.Next() {
+		l, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[key(l)] = l
+	}
+	return out, rows.Err()
+}
+
+func scanLink(sc rowScanner) (Link, error) {
+	var l Link
+	var todoID sql.NullInt64
+	var url sql.NullString
+	if err := sc.Scan(&todoID, &l.Provider, &l.ExternalID, &url); err != nil {
+		return Link{}, err
+	}
+	l.TodoID = int(todoID.Int64)
+	l.URL = url.String
+	return l, nil
+}
@@ -0,0 +1,61 @@
+package todo
+
+import "testing"
+
+func TestLinks(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("b", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := s.AddLink(a, LinkProviderGitHub, "o/r#1", "https://github.com/o/r/issues/1"); err != nil {
+		t.Fatalf("AddLink: %v", err)
+	}
+	if err := s.AddLink(b, LinkProviderGitHub, "o/other#1", ""); err != nil {
+		t.Fatalf("AddLink: %v", err)
+	}
+	if err := s.AddLink(b, LinkProviderGitHub, "o/r#1", ""); err == nil {
+		t.Error("expected duplicate external ID to be rejected")
+	}
+
+	links, err := s.Links(LinkProviderGitHub, "o/r#")
+	if err != nil {
+		t.Fatalf("Links: %v", err)
+	}
+	if len(links) != 1 || links["o/r#1"].TodoID != a {
+		t.Fatalf("links = %+v, want only o/r#1 -> #%d", links, a)
+	}
+
+	got, err := s.GetWithNotes(a)
+	if err != nil {
+		t.Fatalf("GetWithNotes: %v", err)
+	}
+	if len(got.Links) != 1 || got.Links[0].URL != "https://github.com/o/r/issues/1" {
+		t.Errorf("Links = %+v", got.Links)
+	}
+}
+
+func TestLinks_SurviveDelete(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	id, _ := s.Add("a", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := s.AddLink(id, LinkProviderGitHub, "o/r#7", ""); err != nil {
+		t.Fatalf("AddLink: %v", err)
+	}
+	if err := s.Delete(id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	links, err := s.Links(LinkProviderGitHub, "o/r#")
+	if err != nil {
+		t.Fatalf("Links: %v", err)
+	}
+	link, ok := links["o/r#7"]
+	if !ok {
+		t.Fatal("link removed with its todo; want it kept to block re-import")
+	}
+	if link.TodoID != 0 {
+		t.Errorf("TodoID = %d, want 0 after delete", link.TodoID)
+	}
+}
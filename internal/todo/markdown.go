@@ -0,0 +1,93 @@
+package todo
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// markdownGlobalHeading titles the group of todos with no project.
+const markdownGlobalHeading = "Global"
+
+// markdownBuckets is the order schedule groups appear in.
+var markdownBuckets = []string{ScheduleToday, ScheduleSoon, ScheduleLater, ScheduleSomeday}
+
+// RenderMarkdown formats todos as GitHub task lists: a "##" heading per
+// project (global todos first), a "###" heading per schedule bucket, and
+// subtasks nested under their parent. IDs are left out so "#N" doesn't turn
+// into issue links when pasted into GitHub. Returns "" for no todos.
+func RenderMarkdown(todos []Todo) string {
+	if len(todos) == 0 {
+		return ""
+	}
+
+	nested, depth := NestSubtasks(todos)
+
+	// project -> bucket -> lines. Subtasks stay with their root's group.
+	groups := map[string]map[string][]string{}
+	var project, bucket string
+	for _, t := range nested {
+		if depth[t.ID] == 0 {
+			project = markdownGlobalHeading
+			if t.ProjectPath != nil {
+				project = filepath.Base(*t.ProjectPath)
+			}
+			bucket = t.Schedule
+			if groups[project] == nil {
+				groups[project] = map[string][]string{}
+			}
+		}
+		groups[project][bucket] = append(groups[project][bucket], strings.Repeat("  ", depth[t.ID])+markdownItem(t))
+	}
+
+	projects := make([]string, 0, len(groups))
+	for p := range groups {
+		if p != markdownGlobalHeading {
+			projects = append(projects, p)
+		}
+	}
+	sort.Strings(projects)
+	if _, ok := groups[markdownGlobalHeading]; ok {
+		projects = append([]string{markdownGlobalHeading}, projects...)
+	}
+
+	var b strings.Builder
+	for i, p := range projects {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n", p)
+		for _, bk := range markdownBuckets {
+			lines := groups[p][bk]
+			if len(lines) == 0 {
+				continue
+			}
+			label := ScheduleLabel(bk)
+			fmt.Fprintf(&b, "\n### %s\n\n", strings.ToUpper(label[:1])+label[1:])
+			for _, l := range lines {
+				b.WriteString(l + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// markdownItem renders one todo as a task list item.
+func markdownItem(t Todo) string {
+	box := "[ ]"
+	if t.Done {
+		box = "[x]"
+	}
+	parts := []string{t.Title}
+	if t.DueDate != nil {
+		parts = append(parts, "due "+DueLabel(t, "Jan 2"))
+	}
+	if t.Context != "" {
+		parts = append(parts, ContextLabel(t.Context))
+	}
+	if len(t.Tags) > 0 {
+		parts = append(parts, "`"+strings.Join(t.Tags, "` `")+"`")
+	}
+	return fmt.Sprintf("- %s %s", box, strings.Join(parts, " · "))
+}
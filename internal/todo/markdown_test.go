@@ -0,0 +1,48 @@
+package todo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderMarkdown_Empty(t *testing.T) {
+	if got := RenderMarkdown(nil); got != "" {
+		t.Errorf("expected empty output, got %q", got)
+	}
+}
+
+func TestRenderMarkdown_GroupsAndNesting(t *testing.T) {
+	proj := "/code/mine"
+	due := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	parent := 3
+	todos := []Todo{
+		{ID: 1, Title: "water plants", Schedule: ScheduleLater, Context: "home"},
+		{ID: 2, Title: "fix bug", Schedule: ScheduleToday, ProjectPath: &proj, DueDate: &due, Tags: []string{"bug"}},
+		{ID: 3, Title: "release", Schedule: ScheduleSoon, ProjectPath: &proj},
+		{ID: 4, Title: "tag it", Schedule: ScheduleToday, ProjectPath: &proj, ParentID: &parent, Done: true},
+	}
+
+	want := strings.Join([]string{
+		"## Global",
+		"",
+		"### Later",
+		"",
+		"- [ ] water plants · @home",
+		"",
+		"## mine",
+		"",
+		"### Today",
+		"",
+		"- [ ] fix bug · due Mar 10 · `bug`",
+		"",
+		"### Soon",
+		"",
+		"- [ ] release",
+		"  - [x] tag it",
+		"",
+	}, "\n")
+	if got := RenderMarkdown(todos); got != want {
+		t.Errorf("unexpected markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,30 @@
+package todo
+
+import "sort"
+
+// SetPinned pins or unpins a todo. Pinned open todos list above all others.
+func (s *Store) SetPinned(id int, pinned bool) error {
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return err
+	}
+	val := 0
+	if pinned {
+		val = 1
+	}
+	if _, err := s.db.Exec(
+		`UPDATE todos SET pinned = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		val, id,
+	); err != nil {
+		return err
+	}
+	return s.journal(HistoryEdit, id, snap)
+}
+
+// pinFirst moves open pinned todos to the front, keeping the existing order
+// within the pinned and unpinned groups.
+func pinFirst(todos []Todo) {
+	sort.SliceStable(todos, func(i, j int) bool {
+		return todos[i].Pinned && !todos[i].Done && !(todos[j].Pinned && !todos[j].Done)
+	})
+}
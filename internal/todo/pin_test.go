@@ -0,0 +1,50 @@
+package todo
+
+import "testing"
+
+func TestSetPinned_SortsFirst(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	urgent, _ := s.Add("urgent", "", PrioCrit, nil, nil, nil, ScheduleToday, RecurrenceNone)
+	low, _ := s.Add("low", "", PrioLow, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	done, _ := s.Add("done", "", PrioLow, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	if err := s.SetPinned(low, true); err != nil {
+		t.Fatal(err)
+	}
+	s.SetPinned(done, true)
+	s.Complete(done)
+
+	for _, sort := range []SortMode{SortUrgency, SortLegacy} {
+		got, err := s.List(ListOptions{ShowDone: true, Sort: sort})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got[0].ID != low || !got[0].Pinned {
+			t.Errorf("sort %d: first = #%d, want pinned #%d", sort, got[0].ID, low)
+		}
+		if got[1].ID != urgent {
+			t.Errorf("sort %d: second = #%d, want #%d (done pins don't float)", sort, got[1].ID, urgent)
+		}
+	}
+
+	if err := s.SetPinned(low, false); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.List(ListOptions{})
+	if got[0].ID != urgent {
+		t.Errorf("after unpin first = #%d, want #%d", got[0].ID, urgent)
+	}
+
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if tt, _ := s.Get(low); !tt.Pinned {
+		t.Error("expected undo to restore the pin")
+	}
+
+	if err := s.SetPinned(999, true); err == nil {
+		t.Error("expected error for missing todo")
+	}
+}
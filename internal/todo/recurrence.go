@@ -0,0 +1,331 @@
+package todo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Custom recurrences are stored as RRULE-like strings, e.g.
+// "FREQ=WEEKLY;INTERVAL=2" or "FREQ=WEEKLY;BYDAY=MO,WE,FR" or
+// "FREQ=MONTHLY;BYMONTHDAY=1". The four fixed frequencies keep their
+// original stored values (daily, weekday, weekly, monthly).
+
+// Rule frequencies.
+const (
+	freqDaily   = "DAILY"
+	freqWeekly  = "WEEKLY"
+	freqMonthly = "MONTHLY"
+)
+
+// lastDayOfMonth is the BYMONTHDAY value meaning "the last day of the month".
+const lastDayOfMonth = -1
+
+// recurrenceRule is the parsed form of a custom recurrence.
+type recurrenceRule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	MonthDay int // 1-31, lastDayOfMonth, or 0 for "same day as the base date"
+}
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var unitFreqs = map[string]string{
+	"d": freqDaily, "day": freqDaily, "days": freqDaily,
+	"w": freqWeekly, "week": freqWeekly, "weeks": freqWeekly,
+	"m": freqMonthly, "month": freqMonthly, "months": freqMonthly,
+}
+
+// parseCustomRecurrence parses human recurrence specs — "2 weeks", "3d",
+// "mon,wed,fri", "1st of month", "last day of month" — as well as
+// stored RRULE strings.
+func parseCustomRecurrence(s string) (recurrenceRule, error) {
+	if strings.HasPrefix(strings.ToUpper(s), "FREQ=") {
+		return parseRRule(s)
+	}
+
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimSpace(strings.TrimPrefix(s, "every "))
+
+	// "1st of month", "15th", "last day of the month"
+	if day, ok := parseMonthDay(s); ok {
+		return recurrenceRule{Freq: freqMonthly, Interval: 1, MonthDay: day}, nil
+	}
+
+	// "mon,wed,fri" / "mon wed fri" / "tuesday"
+	if days, ok := parseWeekdayList(s); ok {
+		return recurrenceRule{Freq: freqWeekly, Interval: 1, ByDay: days}, nil
+	}
+
+	// "2 weeks", "2weeks", "2w", "week"
+	num := strings.TrimRightFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	unit := strings.TrimSpace(s[len(num):])
+	interval := 1
+	if num != "" {
+		n, err := strconv.Atoi(num)
+		if err != nil || n < 1 {
+			return recurrenceRule{}, fmt.Errorf("invalid interval in %q", s)
+		}
+		interval = n
+	}
+	if freq, ok := unitFreqs[unit]; ok {
+		return recurrenceRule{Freq: freq, Interval: interval}, nil
+	}
+	return recurrenceRule{}, fmt.Errorf("unrecognized recurrence %q", s)
+}
+
+// parseMonthDay recognizes "1st", "2nd of month", "31st of the month",
+// "last", and "last day of month".
+func parseMonthDay(s string) (int, bool) {
+	for _, suffix := range []string{" of every month", " of the month", " of month"} {
+		s = strings.TrimSuffix(s, suffix)
+	}
+	s = strings.TrimSuffix(s, " day")
+	if s == "last" {
+		return lastDayOfMonth, true
+	}
+	for _, ord := range []string{"st", "nd", "rd", "th"} {
+		if !strings.HasSuffix(s, ord) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(s, ord))
+		if err != nil || n < 1 || n > 31 {
+			return 0, false
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// parseWeekdayList recognizes comma- or space-separated weekday names.
+func parseWeekdayList(s string) ([]time.Weekday, bool) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) == 0 {
+		return nil, false
+	}
+	var seen [7]bool
+	for _, f := range fields {
+		d, ok := weekdayNames[f]
+		if !ok {
+			return nil, false
+		}
+		seen[d] = true
+	}
+	var days []time.Weekday
+	for d, ok := range seen {
+		if ok {
+			days = append(days, time.Weekday(d))
+		}
+	}
+	return days, true
+}
+
+// parseRRule parses the stored "KEY=VALUE;..." form.
+func parseRRule(s string) (recurrenceRule, error) {
+	r := recurrenceRule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return recurrenceRule{}, fmt.Errorf("invalid rule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return recurrenceRule{}, fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(val), ",") {
+				idx := -1
+				for i, c := range weekdayCodes {
+					if c == code {
+						idx = i
+					}
+				}
+				if idx < 0 {
+					return recurrenceRule{}, fmt.Errorf("invalid BYDAY %q", code)
+				}
+				r.ByDay = append(r.ByDay, time.Weekday(idx))
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(val)
+			if err != nil || n == 0 || n > 31 || n < lastDayOfMonth {
+				return recurrenceRule{}, fmt.Errorf("invalid BYMONTHDAY %q", val)
+			}
+			r.MonthDay = n
+		default:
+			return recurrenceRule{}, fmt.Errorf("unsupported rule part %q", key)
+		}
+	}
+	switch r.Freq {
+	case freqDaily, freqWeekly, freqMonthly:
+	default:
+		return recurrenceRule{}, fmt.Errorf("invalid FREQ %q", r.Freq)
+	}
+	return r, nil
+}
+
+// String encodes the rule in its stored RRULE-like form.
+func (r recurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			codes[i] = weekdayCodes[d]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.MonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.MonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// legacy maps simple rules onto the original fixed frequencies so existing
+// stored values stay canonical. Returns "" when no fixed frequency matches.
+func (r recurrenceRule) legacy() string {
+	if r.Interval != 1 || r.MonthDay != 0 {
+		return ""
+	}
+	if len(r.ByDay) == 5 && r.Freq == freqWeekly {
+		for i, d := range r.ByDay {
+			if d != time.Weekday(i+1) {
+				return ""
+			}
+		}
+		return RecurrenceWeekday
+	}
+	if len(r.ByDay) > 0 {
+		return ""
+	}
+	switch r.Freq {
+	case freqDaily:
+		return RecurrenceDaily
+	case freqWeekly:
+		return RecurrenceWeekly
+	case freqMonthly:
+		return RecurrenceMonthly
+	}
+	return ""
+}
+
+// Label returns a short human description, e.g. "every 2 weeks" or "mon, wed, fri".
+func (r recurrenceRule) Label() string {
+	var label string
+	switch {
+	case len(r.ByDay) > 0:
+		names := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			names[i] = strings.ToLower(d.String()[:3])
+		}
+		label = strings.Join(names, ", ")
+		if r.Interval > 1 {
+			label += fmt.Sprintf(" every %d weeks", r.Interval)
+		}
+		return label
+	case r.MonthDay != 0:
+		label = ordinal(r.MonthDay) + " of month"
+		if r.Interval > 1 {
+			label += fmt.Sprintf(" every %d months", r.Interval)
+		}
+		return label
+	}
+
+	unit := map[string]string{freqDaily: "day", freqWeekly: "week", freqMonthly: "month"}[r.Freq]
+	if r.Interval == 1 {
+		return "every " + unit
+	}
+	return fmt.Sprintf("every %d %ss", r.Interval, unit)
+}
+
+func ordinal(n int) string {
+	if n == lastDayOfMonth {
+		return "last"
+	}
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// next returns the first occurrence strictly after base (a date at midnight).
+func (r recurrenceRule) next(base time.Time) time.Time {
+	switch r.Freq {
+	case freqDaily:
+		return base.AddDate(0, 0, r.Interval)
+
+	case freqWeekly:
+		if len(r.ByDay) == 0 {
+			return base.AddDate(0, 0, 7*r.Interval)
+		}
+		// Remaining matching days in the current (Monday-start) week first.
+		weekStart := startOfWeek(base)
+		for d := base.AddDate(0, 0, 1); d.Before(weekStart.AddDate(0, 0, 7)); d = d.AddDate(0, 0, 1) {
+			if r.hasDay(d.Weekday()) {
+				return d
+			}
+		}
+		// Otherwise the first matching day of the week Interval weeks on.
+		nextWeek := weekStart.AddDate(0, 0, 7*r.Interval)
+		for d := nextWeek; ; d = d.AddDate(0, 0, 1) {
+			if r.hasDay(d.Weekday()) {
+				return d
+			}
+		}
+
+	case freqMonthly:
+		if r.MonthDay == 0 {
+			return addMonthsClamped(base, r.Interval, base.Day())
+		}
+		if this := addMonthsClamped(base, 0, r.MonthDay); this.After(base) {
+			return this
+		}
+		return addMonthsClamped(base, r.Interval, r.MonthDay)
+	}
+	return base
+}
+
+func (r recurrenceRule) hasDay(d time.Weekday) bool {
+	for _, x := range r.ByDay {
+		if x == d {
+			return true
+		}
+	}
+	return false
+}
+
+// addMonthsClamped returns day-of-month day in the month n months after
+// base, clamped to that month's length. day may be lastDayOfMonth.
+func addMonthsClamped(base time.Time, n, day int) time.Time {
+	first := time.Date(base.Year(), base.Month()+time.Month(n), 1, 0, 0, 0, 0, base.Location())
+	lastDay := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, base.Location()).Day()
+	if day == lastDayOfMonth || day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, base.Location())
+}
@@ -284,6 +284,33 @@ func TestStoreComplete_Recurring_InheritsProject(t *testing.T) {
 	}
 }
 
+func TestStoreComplete_Recurring_InheritsContext(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	id, err := s.Add("water plants", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceDaily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetContext(id, "@home"); err != nil {
+		t.Fatal(err)
+	}
+
+	spawnedID, _, err := s.Complete(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spawned, err := s.Get(spawnedID)
+	if err != nil {
+		t.Fatalf("Get spawned: %v", err)
+	}
+	if spawned.Context != "home" {
+		t.Errorf("spawned context: got %q, want %q", spawned.Context, "home")
+	}
+}
+
 func TestStoreComplete_Recurring_SpawnsMultipleGenerations(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -447,3 +474,124 @@ func TestDemoteProject_DoesNotDemoteCompletedTodos(t *testing.T) {
 		t.Errorf("expected 0 demoted tasks (completed tasks not demoted), got %d", n)
 	}
 }
+
+// --- custom rules ---
+
+func TestParseRecurrence_CustomRules(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2 weeks", "FREQ=WEEKLY;INTERVAL=2"},
+		{"every 2 weeks", "FREQ=WEEKLY;INTERVAL=2"},
+		{"3d", "FREQ=DAILY;INTERVAL=3"},
+		{"every 6 months", "FREQ=MONTHLY;INTERVAL=6"},
+		{"mon,wed,fri", "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		{"every Fri, Mon", "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{"tuesday", "FREQ=WEEKLY;BYDAY=TU"},
+		{"every 1st of month", "FREQ=MONTHLY;BYMONTHDAY=1"},
+		{"15th", "FREQ=MONTHLY;BYMONTHDAY=15"},
+		{"last day of the month", "FREQ=MONTHLY;BYMONTHDAY=-1"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU"},
+		// Simple rules collapse onto the fixed frequencies.
+		{"every week", RecurrenceWeekly},
+		{"1 day", RecurrenceDaily},
+		{"mon,tue,wed,thu,fri", RecurrenceWeekday},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseRecurrence(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ParseRecurrence(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRecurrence_InvalidCustomRules(t *testing.T) {
+	for _, s := range []string{"0 weeks", "2 fortnights", "32nd", "mon,funday", "FREQ=HOURLY", "FREQ=WEEKLY;BYDAY=XX"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := ParseRecurrence(s); err == nil {
+				t.Fatalf("expected error for %q", s)
+			}
+		})
+	}
+}
+
+func TestRecurrenceLabel_CustomRules(t *testing.T) {
+	tests := map[string]string{
+		"FREQ=WEEKLY;INTERVAL=2":          "every 2 weeks",
+		"FREQ=DAILY;INTERVAL=3":           "every 3 days",
+		"FREQ=WEEKLY;BYDAY=MO,WE,FR":      "mon, wed, fri",
+		"FREQ=MONTHLY;BYMONTHDAY=1":       "1st of month",
+		"FREQ=MONTHLY;BYMONTHDAY=22":      "22nd of month",
+		"FREQ=MONTHLY;BYMONTHDAY=-1":      "last of month",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU": "tue every 2 weeks",
+		"garbage":                         "",
+		RecurrenceWeekly:                  "weekly",
+	}
+	for in, want := range tests {
+		if got := RecurrenceLabel(in); got != want {
+			t.Errorf("RecurrenceLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNextDueDate_CustomRules(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name string
+		base time.Time
+		rule string
+		want time.Time
+	}{
+		{"every 2 weeks", day(2, 24), "FREQ=WEEKLY;INTERVAL=2", day(3, 10)},
+		{"every 3 days", day(2, 27), "FREQ=DAILY;INTERVAL=3", day(3, 2)},
+		{"MWF from Monday", day(2, 23), "FREQ=WEEKLY;BYDAY=MO,WE,FR", day(2, 25)},
+		{"MWF from Friday", day(2, 27), "FREQ=WEEKLY;BYDAY=MO,WE,FR", day(3, 2)},
+		{"MWF from Saturday", day(2, 28), "FREQ=WEEKLY;BYDAY=MO,WE,FR", day(3, 2)},
+		{"Tue every 2 weeks", day(2, 24), "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", day(3, 10)},
+		{"1st of month", day(2, 14), "FREQ=MONTHLY;BYMONTHDAY=1", day(3, 1)},
+		{"15th later this month", day(2, 3), "FREQ=MONTHLY;BYMONTHDAY=15", day(2, 15)},
+		{"31st clamps in April", day(3, 31), "FREQ=MONTHLY;BYMONTHDAY=31", day(4, 30)},
+		{"last day of month", day(2, 28), "FREQ=MONTHLY;BYMONTHDAY=-1", day(3, 31)},
+		{"every 3 months", day(1, 31), "FREQ=MONTHLY;INTERVAL=3", day(4, 30)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := nextDueDate(tc.base, tc.rule)
+			if !got.Equal(tc.want) {
+				t.Errorf("nextDueDate(%s, %s) = %s, want %s", tc.base.Format("2006-01-02"), tc.rule,
+					got.Format("2006-01-02"), tc.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestStoreComplete_CustomRule_SpawnsWithSameRule(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	rule, err := ParseRecurrence("mon,wed,fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	due := time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC) // Friday
+	id, _ := s.Add("gym", "", PrioMedium, nil, &due, nil, ScheduleLater, rule)
+
+	spawnedID, spawnedDue, err := s.Complete(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spawnedDue == nil || spawnedDue.Format("2006-01-02") != "2026-03-02" {
+		t.Errorf("spawned due = %v, want 2026-03-02", spawnedDue)
+	}
+	spawned, _ := s.Get(spawnedID)
+	if spawned.Recurrence != rule {
+		t.Errorf("spawned recurrence = %q, want %q", spawned.Recurrence, rule)
+	}
+}
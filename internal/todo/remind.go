@@ -0,0 +1,171 @@
+package todo
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Reminder kinds stored in todo_reminders.
+const (
+	ReminderKindRemind = "remind"
+	ReminderKindDue    = "due"
+)
+
+// dueAlertWindow bounds how long after a task comes due it is still worth
+// alerting about, so the first notifier run doesn't replay ancient overdue tasks.
+const dueAlertWindow = 24 * time.Hour
+
+// reminderTimeFormat is how remind_at is stored: UTC, sortable as text.
+const reminderTimeFormat = "2006-01-02 15:04:05"
+
+// Reminder is a pending or deliverable notification for a todo.
+// ID is 0 for due-date alerts that have not been recorded yet.
+type Reminder struct {
+	ID     int
+	TodoID int
+	Title  string
+	Kind   string
+	At     time.Time
+}
+
+// AddReminder schedules a reminder for an open todo. Returns the reminder ID.
+func (s *Store) AddReminder(todoID int, at time.Time) (int, error) {
+	t, err := s.Get(todoID)
+	if err != nil {
+		return 0, err
+	}
+	if t.Done {
+		return 0, fmt.Errorf("todo #%d is already done", todoID)
+	}
+	res, err := s.db.Exec(
+		`INSERT INTO todo_reminders (todo_id, kind, remind_at) VALUES (?, ?, ?)`,
+		todoID, ReminderKindRemind, at.UTC().Format(reminderTimeFormat),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("adding reminder: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	return int(id), nil
+}
+
+// PendingReminders returns unfired reminders for a todo, soonest first.
+func (s *Store) PendingReminders(todoID int) ([]Reminder, error) {
+	rows, err := s.db.Query(
+		`SELECT r.id, r.todo_id, t.title, r.kind, r.remind_at
+		 FROM todo_reminders r JOIN todos t ON t.id = r.todo_id
+		 WHERE r.todo_id = ? AND r.kind = ? AND r.fired_at IS NULL
+		 ORDER BY r.remind_at`,
+		todoID, ReminderKindRemind,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing reminders: %w", err)
+	}
+	return scanReminders(rows)
+}
+
+// DueReminders returns everything that should be notified at now: reminders
+// whose time has passed, plus open todos that came due within the last
+// dueAlertWindow and have not been alerted yet. Reminders on completed todos
+// are skipped (and never fire).
+func (s *Store) DueReminders(now time.Time) ([]Reminder, error) {
+	rows, err := s.db.Query(
+		`SELECT r.id, r.todo_id, t.title, r.kind, r.remind_at
+		 FROM todo_reminders r JOIN todos t ON t.id = r.todo_id
+		 WHERE r.kind = ? AND r.fired_at IS NULL AND r.remind_at <= ? AND t.done = 0
+		 ORDER BY r.remind_at`,
+		ReminderKindRemind, now.UTC().Format(reminderTimeFormat),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing due reminders: %w", err)
+	}
+	out, err := scanReminders(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Due-date alerts. Dates are local; fetch a day either side and filter in Go.
+	from := now.Add(-dueAlertWindow).AddDate(0, 0, -1).Format("2006-01-02")
+	to := now.AddDate(0, 0, 1).Format("2006-01-02")
+	dueRows, err := s.db.Query(
+		`SELECT `+todoColumns+` FROM todos
+		 WHERE done = 0 AND due_date IS NOT NULL AND due_date >= ? AND due_date <= ?`,
+		from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing due todos: %w", err)
+	}
+	var candidates []Todo
+	for dueRows.Next() {
+		t, err := scanTodoRow(dueRows)
+		if err != nil {
+			dueRows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, t)
+	}
+	dueRows.Close()
+	if err := dueRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, t := range candidates {
+		at := dueMoment(t)
+		if at.After(now) || now.Sub(at) > dueAlertWindow {
+			continue
+		}
+		var seen int
+		if err := s.db.QueryRow(
+			`SELECT COUNT(*) FROM todo_reminders WHERE todo_id = ? AND kind = ? AND remind_at = ?`,
+			t.ID, ReminderKindDue, at.UTC().Format(reminderTimeFormat),
+		).Scan(&seen); err != nil {
+			return nil, err
+		}
+		if seen == 0 {
+			out = append(out, Reminder{TodoID: t.ID, Title: t.Title, Kind: ReminderKindDue, At: at})
+		}
+	}
+	return out, nil
+}
+
+// MarkNotified records that a reminder was delivered so it does not fire again.
+func (s *Store) MarkNotified(r Reminder) error {
+	if r.ID > 0 {
+		_, err := s.db.Exec(`UPDATE todo_reminders SET fired_at = CURRENT_TIMESTAMP WHERE id = ?`, r.ID)
+		return err
+	}
+	_, err := s.db.Exec(
+		`INSERT INTO todo_reminders (todo_id, kind, remind_at, fired_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)`,
+		r.TodoID, r.Kind, r.At.UTC().Format(reminderTimeFormat),
+	)
+	return err
+}
+
+// dueMoment returns when a todo comes due: its due time, or local midnight
+// of its due date when no time is set.
+func dueMoment(t Todo) time.Time {
+	if t.DueHasTime {
+		return *t.DueDate
+	}
+	d := t.DueDate
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.Local)
+}
+
+func scanReminders(rows *sql.Rows) ([]Reminder, error) {
+	defer rows.Close()
+	var out []Reminder
+	for rows.Next() {
+		var r Reminder
+		var at string
+		if err := rows.Scan(&r.ID, &r.TodoID, &r.Title, &r.Kind, &at); err != nil {
+			return nil, err
+		}
+		parsed, err := time.ParseInLocation(reminderTimeFormat, at, time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("parsing reminder time %q: %w", at, err)
+		}
+		r.At = parsed.Local()
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,105 @@
+package todo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReminders_FireOnceWhenDue(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Date(2030, 6, 10, 12, 0, 0, 0, time.Local)
+	id, _ := s.Add("call bank", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if _, err := s.AddReminder(id, now.Add(30*time.Minute)); err != nil {
+		t.Fatalf("AddReminder: %v", err)
+	}
+
+	pending, _ := s.PendingReminders(id)
+	if len(pending) != 1 || !pending[0].At.Equal(now.Add(30*time.Minute)) {
+		t.Fatalf("unexpected pending reminders: %+v", pending)
+	}
+
+	if due, _ := s.DueReminders(now); len(due) != 0 {
+		t.Fatalf("expected nothing due yet, got %+v", due)
+	}
+
+	later := now.Add(time.Hour)
+	due, err := s.DueReminders(later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].TodoID != id || due[0].Kind != ReminderKindRemind || due[0].Title != "call bank" {
+		t.Fatalf("unexpected due reminders: %+v", due)
+	}
+
+	if err := s.MarkNotified(due[0]); err != nil {
+		t.Fatal(err)
+	}
+	if due, _ := s.DueReminders(later); len(due) != 0 {
+		t.Errorf("expected reminder to fire once, got %+v", due)
+	}
+	if pending, _ := s.PendingReminders(id); len(pending) != 0 {
+		t.Errorf("expected no pending reminders after firing, got %+v", pending)
+	}
+}
+
+func TestReminders_SkipDoneTodos(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Date(2030, 6, 10, 12, 0, 0, 0, time.Local)
+	id, _ := s.Add("done already", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	s.AddReminder(id, now.Add(-time.Minute))
+	s.Complete(id)
+
+	if due, _ := s.DueReminders(now); len(due) != 0 {
+		t.Errorf("expected no reminders for completed todo, got %+v", due)
+	}
+	if _, err := s.AddReminder(id, now.Add(time.Hour)); err == nil {
+		t.Error("expected error adding reminder to completed todo")
+	}
+}
+
+func TestDueReminders_DueDateAlerts(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	now := time.Date(2030, 6, 10, 12, 0, 0, 0, time.Local)
+	past := time.Date(2030, 6, 10, 11, 0, 0, 0, time.Local)
+	future := time.Date(2030, 6, 10, 15, 0, 0, 0, time.Local)
+	today := time.Date(2030, 6, 10, 0, 0, 0, 0, time.UTC)
+	ancient := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	dueNow, _ := s.Add("timed", "", PrioMedium, nil, &past, nil, ScheduleLater, RecurrenceNone)
+	s.Add("later today", "", PrioMedium, nil, &future, nil, ScheduleLater, RecurrenceNone)
+	dueToday, _ := s.Add("date only", "", PrioMedium, nil, &today, nil, ScheduleLater, RecurrenceNone)
+	s.Add("long overdue", "", PrioMedium, nil, &ancient, nil, ScheduleLater, RecurrenceNone)
+
+	due, err := s.DueReminders(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[int]bool{}
+	for _, r := range due {
+		if r.Kind != ReminderKindDue {
+			t.Errorf("expected due kind, got %+v", r)
+		}
+		got[r.TodoID] = true
+	}
+	if len(due) != 2 || !got[dueNow] || !got[dueToday] {
+		t.Fatalf("expected alerts for #%d and #%d, got %+v", dueNow, dueToday, due)
+	}
+
+	for _, r := range due {
+		if err := s.MarkNotified(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if again, _ := s.DueReminders(now); len(again) != 0 {
+		t.Errorf("expected due alerts to fire once, got %+v", again)
+	}
+}
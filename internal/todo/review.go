@@ -0,0 +1,106 @@
+package todo
+
+import (
+	"time"
+)
+
+// Reasons a todo comes up in a weekly review, in the order they're visited.
+const (
+	ReviewOverdue = "overdue"
+	ReviewStale   = "stale"
+	ReviewSomeday = "someday"
+)
+
+// DefaultStaleAfter is how long an open todo can go untouched before a
+// review flags it as stale.
+const DefaultStaleAfter = 14 * 24 * time.Hour
+
+// ReviewItem is a todo due for review and why.
+type ReviewItem struct {
+	Todo   Todo
+	Reason string
+}
+
+// ReviewItems returns the open todos a weekly review should walk through:
+// overdue todos first, then todos not updated within staleAfter, then every
+// someday todo. Each group is in urgency order.
+func (s *Store) ReviewItems(now time.Time, staleAfter time.Duration) ([]ReviewItem, error) {
+	todos, err := s.List(ListOptions{
+		AllProjects:    true,
+		IncludeSomeday: true,
+		Sort:           SortUrgency,
+		ReferenceTime:  now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[string][]ReviewItem{}
+	for _, t := range todos {
+		var reason string
+		switch {
+		case t.IsOverdue(now):
+			reason = ReviewOverdue
+		case t.Schedule == ScheduleSomeday:
+			reason = ReviewSomeday
+		case !t.UpdatedAt.IsZero() && now.Sub(t.UpdatedAt) >= staleAfter:
+			reason = ReviewStale
+		default:
+			continue
+		}
+		groups[reason] = append(groups[reason], ReviewItem{Todo: t, Reason: reason})
+	}
+
+	var items []ReviewItem
+	for _, reason := range []string{ReviewOverdue, ReviewStale, ReviewSomeday} {
+		items = append(items, groups[reason]...)
+	}
+	return items, nil
+}
+
+// DemoteSchedule returns the next, less urgent schedule bucket:
+// today → soon → later → someday. ok is false when already someday.
+func DemoteSchedule(schedule string) (next string, ok bool) {
+	switch schedule {
+	case ScheduleToday:
+		return ScheduleSoon, true
+	case ScheduleSoon:
+		return ScheduleLater, true
+	case ScheduleLater:
+		return ScheduleSomeday, true
+	default:
+		return schedule, false
+	}
+}
+
+// Snooze pushes a todo out of the way until the given day: a due date moves
+// to until (keeping any time of day), and the todo counts as freshly touched
+// so it won't be flagged stale again right away.
+func (s *Store) Snooze(id int, until time.Time) error {
+	t, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return err
+	}
+
+	if t.DueDate == nil {
+		_, err = s.db.Exec(`UPDATE todos SET updated_at = CURRENT_TIMESTAMP WHERE id = ?`, id)
+	} else {
+		due := time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC)
+		if t.DueHasTime {
+			due = time.Date(until.Year(), until.Month(), until.Day(), t.DueDate.Hour(), t.DueDate.Minute(), 0, 0, time.Local)
+		}
+		dueStr, dueTimeStr := formatDue(&due)
+		_, err = s.db.Exec(
+			`UPDATE todos SET due_date = ?, due_time = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+			dueStr, dueTimeStr, id,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	return s.journal(HistoryEdit, id, snap)
+}
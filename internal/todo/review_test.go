@@ -0,0 +1,106 @@
+package todo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewItems_GroupsAndOrder(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+	now := time.Now()
+
+	past := now.AddDate(0, 0, -3)
+	fresh, _ := s.Add("fresh", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	stale, _ := s.Add("stale", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	overdue, _ := s.Add("overdue", "", PrioMedium, nil, &past, nil, ScheduleLater, RecurrenceNone)
+	someday, _ := s.Add("someday", "", PrioMedium, nil, nil, nil, ScheduleSomeday, RecurrenceNone)
+	doneStale, _ := s.Add("done", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	s.Complete(doneStale)
+
+	if _, err := db.Exec(`UPDATE todos SET updated_at = datetime('now', '-30 days') WHERE id IN (?, ?)`, stale, doneStale); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := s.ReviewItems(now, DefaultStaleAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		id     int
+		reason string
+	}{{overdue, ReviewOverdue}, {stale, ReviewStale}, {someday, ReviewSomeday}}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i, w := range want {
+		if items[i].Todo.ID != w.id || items[i].Reason != w.reason {
+			t.Errorf("item %d = #%d %s, want #%d %s", i, items[i].Todo.ID, items[i].Reason, w.id, w.reason)
+		}
+	}
+	for _, it := range items {
+		if it.Todo.ID == fresh {
+			t.Error("fresh todo should not be reviewed")
+		}
+	}
+}
+
+func TestDemoteSchedule(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{ScheduleToday, ScheduleSoon, true},
+		{ScheduleSoon, ScheduleLater, true},
+		{ScheduleLater, ScheduleSomeday, true},
+		{ScheduleSomeday, ScheduleSomeday, false},
+	}
+	for _, c := range cases {
+		got, ok := DemoteSchedule(c.in)
+		if got != c.want || ok != c.ok {
+			t.Errorf("DemoteSchedule(%q) = (%q, %v), want (%q, %v)", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestSnooze(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	past := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	dated, _ := s.Add("dated", "", PrioMedium, nil, &past, nil, ScheduleLater, RecurrenceNone)
+	undated, _ := s.Add("undated", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	db.Exec(`UPDATE todos SET updated_at = datetime('now', '-30 days')`)
+
+	until := time.Date(2026, 2, 1, 12, 0, 0, 0, time.Local)
+	if err := s.Snooze(dated, until); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(dated)
+	if got.DueDate == nil || got.DueDate.Format("2006-01-02") != "2026-02-01" || got.DueHasTime {
+		t.Errorf("due = %v (time %v), want 2026-02-01 date-only", got.DueDate, got.DueHasTime)
+	}
+
+	if err := s.Snooze(undated, until); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = s.Get(undated)
+	if got.DueDate != nil {
+		t.Errorf("undated todo got due date %v", got.DueDate)
+	}
+	if time.Since(got.UpdatedAt) > time.Hour {
+		t.Errorf("updated_at not refreshed: %v", got.UpdatedAt)
+	}
+
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = s.Get(dated)
+	if got.DueDate.Format("2006-01-02") != "2026-01-05" {
+		t.Errorf("due after undo = %v, want 2026-01-05", got.DueDate)
+	}
+}
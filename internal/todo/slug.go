@@ -0,0 +1,100 @@
+package todo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// slugWords caps how many title words a generated slug keeps.
+const slugWords = 4
+
+// Slug derives a short, shell-friendly handle from a title:
+// "Fix login on Safari!" → "fix-login-on-safari". Only the first few words
+// are kept. Slugs are computed, not stored, so renaming a todo changes it.
+func Slug(title string) string {
+	words := slugify(title)
+	if len(words) > slugWords {
+		words = words[:slugWords]
+	}
+	return strings.Join(words, "-")
+}
+
+// slugify lowercases s and splits it into runs of letters and digits.
+func slugify(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Resolve finds the todos a non-numeric reference like "fix-login" or
+// "Fix log" points at. Matches are tried from most to least specific and
+// the first tier with any hit wins:
+//
+//  1. the todo's slug or full title equals ref
+//  2. the slug or title starts with ref
+//  3. every word of ref prefixes a title word, in order ("fix-saf" finds
+//     "Fix login on Safari")
+//
+// Open todos are searched before done ones, so a finished "Fix login" never
+// shadows an open "Fix login on Safari". An empty result means nothing
+// matched; more than one means the caller should disambiguate.
+func (s *Store) Resolve(ref string) ([]Todo, error) {
+	refWords := slugify(ref)
+	if len(refWords) == 0 {
+		return nil, nil
+	}
+	refSlug := strings.Join(refWords, "-")
+
+	rows, err := s.db.Query(`SELECT ` + todoColumns + ` FROM todos ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// tiers[0] holds open todos, tiers[1] done ones.
+	var tiers [2][3][]Todo
+	for rows.Next() {
+		t, err := scanTodoRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		state := 0
+		if t.Done {
+			state = 1
+		}
+		words := slugify(t.Title)
+		full := strings.Join(words, "-")
+		switch {
+		case Slug(t.Title) == refSlug || full == refSlug:
+			tiers[state][0] = append(tiers[state][0], t)
+		case strings.HasPrefix(full, refSlug):
+			tiers[state][1] = append(tiers[state][1], t)
+		case wordsPrefixMatch(refWords, words):
+			tiers[state][2] = append(tiers[state][2], t)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, state := range tiers {
+		for _, tier := range state {
+			if len(tier) > 0 {
+				return tier, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// wordsPrefixMatch reports whether each of want prefixes a distinct word of
+// have, in order.
+func wordsPrefixMatch(want, have []string) bool {
+	i := 0
+	for _, w := range have {
+		if i < len(want) && strings.HasPrefix(w, want[i]) {
+			i++
+		}
+	}
+	return i == len(want)
+}
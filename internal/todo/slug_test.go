@@ -0,0 +1,63 @@
+package todo
+
+import "testing"
+
+func TestSlug(t *testing.T) {
+	cases := map[string]string{
+		"Fix login":                      "fix-login",
+		"Fix login on Safari, again!":    "fix-login-on-safari",
+		"  --weird   spacing__here  ":    "weird-spacing-here",
+		"Café menü":                      "café-menü",
+		"!!!":                            "",
+		"Review PR #42 for the API team": "review-pr-42-for",
+	}
+	for in, want := range cases {
+		if got := Slug(in); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestResolve_Tiers(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	login, _ := s.Add("Fix login on Safari", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	logout, _ := s.Add("Fix logout button", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	oldLogin, _ := s.Add("Fix login", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	s.Complete(oldLogin)
+	docs, _ := s.Add("Write docs", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	archived, _ := s.Add("Archive old logs", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	s.Complete(archived)
+
+	ids := func(ts []Todo) []int {
+		var out []int
+		for _, t := range ts {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+	cases := []struct {
+		ref  string
+		want []int
+	}{
+		{"write-docs", []int{docs}},           // exact slug
+		{"Fix login on Safari", []int{login}}, // exact title
+		{"fix-login", []int{login}},           // open prefix beats a done exact match
+		{"fix-log", []int{login, logout}},     // prefix, ambiguous
+		{"fix-saf", []int{login}},             // word prefixes in order
+		{"safari-fix", nil},                   // out of order
+		{"Fix login", []int{login}},
+		{"archive-old", []int{archived}},
+		{"deploy", nil}, // nothing
+	}
+	for _, c := range cases {
+		got, err := s.Resolve(c.ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g := ids(got); len(g) != len(c.want) || (len(g) > 0 && (g[0] != c.want[0] || g[len(g)-1] != c.want[len(c.want)-1])) {
+			t.Errorf("Resolve(%q) = %v, want %v", c.ref, g, c.want)
+		}
+	}
+}
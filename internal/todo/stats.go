@@ -27,49 +27,61 @@ type ProjectStats struct {
 	AvgClose  time.Duration
 }
 
+// statsSource is the row set stats read from: active todos plus the archive,
+// so archiving never resets streaks or completion counts.
+const statsSource = `(SELECT done, created_at, completed_at, project_path FROM todos
+	UNION ALL SELECT done, created_at, completed_at, project_path FROM todos_archive)`
+
 // GetStats computes completion stats, optionally scoped to a project path.
 // If projectPath is nil, returns stats across all todos.
 // now is used as the reference time for streak and weekly/monthly calculations.
 func GetStats(db *sql.DB, projectPath *string, now time.Time) (*Stats, error) {
+	return GetStatsFor(db, scopePaths(projectPath), now)
+}
+
+// GetStatsFor computes completion stats for todos in any of paths, such as
+// a project group's members. nil paths means all todos. The per-project
+// breakdown is included unless scoped to a single project.
+func GetStatsFor(db *sql.DB, paths []string, now time.Time) (*Stats, error) {
 	stats := &Stats{}
 
 	var err error
 
 	// Completion streak (consecutive days with >= 1 completion).
-	stats.Streak, stats.LongestStreak, err = computeStreak(db, projectPath, now)
+	stats.Streak, stats.LongestStreak, err = computeStreak(db, paths, now)
 	if err != nil {
 		return nil, fmt.Errorf("computing streak: %w", err)
 	}
 
 	// Weekly count (Monday-start weeks).
 	weekStart := startOfWeek(now)
-	stats.CompletedWeek, err = countCompletedSince(db, projectPath, weekStart)
+	stats.CompletedWeek, err = countCompletedSince(db, paths, weekStart)
 	if err != nil {
 		return nil, fmt.Errorf("counting weekly completions: %w", err)
 	}
 
 	// Monthly count (calendar month boundary).
 	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-	stats.CompletedMonth, err = countCompletedSince(db, projectPath, monthStart)
+	stats.CompletedMonth, err = countCompletedSince(db, paths, monthStart)
 	if err != nil {
 		return nil, fmt.Errorf("counting monthly completions: %w", err)
 	}
 
 	// Average close time for completed todos.
-	stats.AvgClose, err = avgCloseTime(db, projectPath)
+	stats.AvgClose, err = avgCloseTime(db, paths)
 	if err != nil {
 		return nil, fmt.Errorf("computing avg close time: %w", err)
 	}
 
 	// Total focus time from dig_sessions (graceful fallback if table absent).
-	stats.TotalFocus, stats.HasFocusData, err = totalFocusTime(db, projectPath)
+	stats.TotalFocus, stats.HasFocusData, err = totalFocusTime(db, paths)
 	if err != nil {
 		return nil, fmt.Errorf("computing focus time: %w", err)
 	}
 
 	// Per-project breakdown is only meaningful when not scoped to a single project.
-	if projectPath == nil {
-		stats.ByProject, err = projectBreakdown(db)
+	if paths == nil || len(paths) > 1 {
+		stats.ByProject, err = projectBreakdown(db, paths)
 		if err != nil {
 			return nil, fmt.Errorf("computing project breakdown: %w", err)
 		}
@@ -78,6 +90,14 @@ func GetStats(db *sql.DB, projectPath *string, now time.Time) (*Stats, error) {
 	return stats, nil
 }
 
+// scopePaths turns an optional single project path into a path scope.
+func scopePaths(projectPath *string) []string {
+	if projectPath == nil {
+		return nil
+	}
+	return []string{*projectPath}
+}
+
 // startOfWeek returns the Monday at 00:00:00 of the week containing t.
 func startOfWeek(t time.Time) time.Time {
 	weekday := int(t.Weekday())
@@ -93,12 +113,13 @@ func startOfWeek(t time.Time) time.Time {
 // completed_at dates. A streak is consecutive calendar days with >= 1
 // completion, counted backward from today. If today has no completions but
 // yesterday does, the streak is still active (user hasn't completed today yet).
-func computeStreak(db *sql.DB, projectPath *string, now time.Time) (current int, longest int, err error) {
-	query := `SELECT DISTINCT DATE(completed_at) FROM todos WHERE done = 1 AND completed_at IS NOT NULL`
+func computeStreak(db *sql.DB, paths []string, now time.Time) (current int, longest int, err error) {
+	query := `SELECT DISTINCT DATE(completed_at) FROM ` + statsSource + ` WHERE done = 1 AND completed_at IS NOT NULL`
 	var args []any
-	if projectPath != nil {
-		query += ` AND project_path = ?`
-		args = append(args, *projectPath)
+	if paths != nil {
+		cond, pathArgs := projectPathsCondition(paths)
+		query += ` AND ` + cond
+		args = append(args, pathArgs...)
 	}
 	query += ` ORDER BY DATE(completed_at) DESC`
 
@@ -178,13 +199,14 @@ func computeStreak(db *sql.DB, projectPath *string, now time.Time) (current int,
 }
 
 // countCompletedSince returns the number of completed todos with completed_at >= since.
-func countCompletedSince(db *sql.DB, projectPath *string, since time.Time) (int, error) {
+func countCompletedSince(db *sql.DB, paths []string, since time.Time) (int, error) {
 	sinceStr := since.UTC().Format("2006-01-02 15:04:05")
-	query := `SELECT COUNT(*) FROM todos WHERE done = 1 AND completed_at >= ?`
+	query := `SELECT COUNT(*) FROM ` + statsSource + ` WHERE done = 1 AND completed_at >= ?`
 	args := []any{sinceStr}
-	if projectPath != nil {
-		query += ` AND project_path = ?`
-		args = append(args, *projectPath)
+	if paths != nil {
+		cond, pathArgs := projectPathsCondition(paths)
+		query += ` AND ` + cond
+		args = append(args, pathArgs...)
 	}
 	var count int
 	err := db.QueryRow(query, args...).Scan(&count)
@@ -193,13 +215,14 @@ func countCompletedSince(db *sql.DB, projectPath *string, since time.Time) (int,
 
 // avgCloseTime returns the average duration between created_at and completed_at
 // for all completed todos matching the optional project filter.
-func avgCloseTime(db *sql.DB, projectPath *string) (time.Duration, error) {
+func avgCloseTime(db *sql.DB, paths []string) (time.Duration, error) {
 	query := `SELECT COALESCE(AVG(julianday(completed_at) - julianday(created_at)), 0)
-	          FROM todos WHERE done = 1 AND completed_at IS NOT NULL`
+	          FROM ` + statsSource + ` WHERE done = 1 AND completed_at IS NOT NULL`
 	var args []any
-	if projectPath != nil {
-		query += ` AND project_path = ?`
-		args = append(args, *projectPath)
+	if paths != nil {
+		cond, pathArgs := projectPathsCondition(paths)
+		query += ` AND ` + cond
+		args = append(args, pathArgs...)
 	}
 	var days float64
 	if err := db.QueryRow(query, args...).Scan(&days); err != nil {
@@ -211,7 +234,7 @@ func avgCloseTime(db *sql.DB, projectPath *string) (time.Duration, error) {
 // totalFocusTime returns the total accumulated focus time from dig_sessions.
 // Returns (0, false, nil) if the dig_sessions table does not exist.
 // Returns (duration, true, nil) when focus data is present.
-func totalFocusTime(db *sql.DB, projectPath *string) (time.Duration, bool, error) {
+func totalFocusTime(db *sql.DB, paths []string) (time.Duration, bool, error) {
 	// Check table existence first — graceful fallback for Phase 6.
 	var tableCount int
 	if err := db.QueryRow(
@@ -222,13 +245,14 @@ func totalFocusTime(db *sql.DB, projectPath *string) (time.Duration, bool, error
 
 	var secs int64
 	var err error
-	if projectPath != nil {
+	if paths != nil {
+		cond, pathArgs := projectPathsCondition(paths)
 		err = db.QueryRow(
 			`SELECT COALESCE(SUM(ds.duration_secs), 0)
 			 FROM dig_sessions ds
 			 JOIN todos t ON ds.todo_id = t.id
-			 WHERE t.project_path = ?`,
-			*projectPath,
+			 WHERE t.`+cond,
+			pathArgs...,
 		).Scan(&secs)
 	} else {
 		err = db.QueryRow(
@@ -243,7 +267,14 @@ func totalFocusTime(db *sql.DB, projectPath *string) (time.Duration, bool, error
 
 // projectBreakdown returns per-project open/completed counts and average close time,
 // grouped by project_path. Null project_path is shown as "(global)".
-func projectBreakdown(db *sql.DB) ([]ProjectStats, error) {
+func projectBreakdown(db *sql.DB, paths []string) ([]ProjectStats, error) {
+	where := ""
+	var args []any
+	if paths != nil {
+		var cond string
+		cond, args = projectPathsCondition(paths)
+		where = `WHERE ` + cond
+	}
 	rows, err := db.Query(`
 		SELECT
 			project_path,
@@ -251,10 +282,11 @@ func projectBreakdown(db *sql.DB) ([]ProjectStats, error) {
 			SUM(CASE WHEN done = 1 THEN 1 ELSE 0 END) AS completed,
 			COALESCE(AVG(CASE WHEN done = 1 AND completed_at IS NOT NULL
 				THEN julianday(completed_at) - julianday(created_at) END), 0) AS avg_days
-		FROM todos
+		FROM `+statsSource+`
+		`+where+`
 		GROUP BY project_path
 		ORDER BY completed DESC, open DESC
-	`)
+	`, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -278,3 +310,79 @@ func projectBreakdown(db *sql.DB) ([]ProjectStats, error) {
 	}
 	return result, rows.Err()
 }
+
+// DayHistory is one calendar day of task activity, used for charts and
+// CSV exports.
+type DayHistory struct {
+	Day       time.Time     // midnight at the start of the day, in now's location
+	Completed int           // todos completed during the day
+	Open      int           // todos still open at the end of the day
+	AvgClose  time.Duration // mean created→completed time of the day's completions
+}
+
+// GetHistory returns one entry per day for the last days days, oldest first
+// and ending today, reconstructed from created_at/completed_at across active
+// and archived todos. Done todos without a completion time are skipped for
+// the open count since there's no telling when they closed.
+func GetHistory(db *sql.DB, projectPath *string, now time.Time, days int) ([]DayHistory, error) {
+	return GetHistoryFor(db, scopePaths(projectPath), now, days)
+}
+
+// GetHistoryFor is GetHistory for todos in any of paths; nil means all.
+func GetHistoryFor(db *sql.DB, paths []string, now time.Time, days int) ([]DayHistory, error) {
+	query := `SELECT done, created_at, completed_at FROM ` + statsSource
+	var args []any
+	if paths != nil {
+		var cond string
+		cond, args = projectPathsCondition(paths)
+		query += ` WHERE ` + cond
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	closeTotal := make([]time.Duration, days)
+	history := make([]DayHistory, days)
+	for i := range history {
+		history[i].Day = today.AddDate(0, 0, i-days+1)
+	}
+
+	for rows.Next() {
+		var done bool
+		var createdAt, completedAt sql.NullString
+		if err := rows.Scan(&done, &createdAt, &completedAt); err != nil {
+			return nil, err
+		}
+		if done && !completedAt.Valid {
+			continue
+		}
+		created := parseTimestamp(createdAt.String)
+		var completed time.Time
+		if done {
+			completed = parseTimestamp(completedAt.String)
+		}
+		for i := range history {
+			start := history[i].Day
+			end := start.AddDate(0, 0, 1)
+			if done && !completed.Before(start) && completed.Before(end) {
+				history[i].Completed++
+				closeTotal[i] += completed.Sub(created)
+			}
+			if created.Before(end) && (!done || !completed.Before(end)) {
+				history[i].Open++
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for i := range history {
+		if history[i].Completed > 0 {
+			history[i].AvgClose = closeTotal[i] / time.Duration(history[i].Completed)
+		}
+	}
+	return history, nil
+}
@@ -368,6 +368,41 @@ func TestGetStats_WithData(t *testing.T) {
 	}
 }
 
+func TestGetStatsFor_ScopesToPaths(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	now, _ := time.Parse("2006-01-02", "2026-02-24")
+	alpha, beta := "/p/alpha", "/p/beta"
+	for _, path := range []string{alpha, beta, "/p/other"} {
+		id := insertCompletedAtTime(t, s, "done", now.AddDate(0, 0, -1), now)
+		if _, err := db.Exec(`UPDATE todos SET project_path = ? WHERE id = ?`, path, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	insertOpenTodo(t, s, "global", nil)
+
+	stats, err := GetStatsFor(db, []string{alpha, beta}, now)
+	if err != nil {
+		t.Fatalf("GetStatsFor: %v", err)
+	}
+	if stats.CompletedMonth != 2 {
+		t.Errorf("CompletedMonth = %d, want 2", stats.CompletedMonth)
+	}
+	if len(stats.ByProject) != 2 {
+		t.Errorf("expected breakdown of the 2 scoped projects, got %v", stats.ByProject)
+	}
+
+	history, err := GetHistoryFor(db, []string{alpha}, now, 1)
+	if err != nil {
+		t.Fatalf("GetHistoryFor: %v", err)
+	}
+	if history[0].Completed != 1 {
+		t.Errorf("history completed = %d, want 1", history[0].Completed)
+	}
+}
+
 // TestProjectBreakdown_GlobalLabel verifies null project_path shows as "(global)".
 func TestProjectBreakdown_GlobalLabel(t *testing.T) {
 	db := setupTestDB(t)
@@ -377,7 +412,7 @@ func TestProjectBreakdown_GlobalLabel(t *testing.T) {
 	// Add a global (nil project) open todo.
 	insertOpenTodo(t, s, "global task", nil)
 
-	breakdown, err := projectBreakdown(db)
+	breakdown, err := projectBreakdown(db, nil)
 	if err != nil {
 		t.Fatalf("projectBreakdown: %v", err)
 	}
@@ -405,7 +440,7 @@ func TestProjectBreakdown_ProjectName(t *testing.T) {
 	proj := "/home/user/projects/myapp"
 	insertOpenTodo(t, s, "proj task", &proj)
 
-	breakdown, err := projectBreakdown(db)
+	breakdown, err := projectBreakdown(db, nil)
 	if err != nil {
 		t.Fatalf("projectBreakdown: %v", err)
 	}
@@ -420,3 +455,37 @@ func TestProjectBreakdown_ProjectName(t *testing.T) {
 		t.Errorf("expected 'myapp' in breakdown, got: %v", breakdown)
 	}
 }
+
+func TestGetHistory(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	// Open since 5 days ago; completed yesterday after 3 days open; completed
+	// today; created and completed before the window.
+	open, _ := s.Add("open", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	db.Exec(`UPDATE todos SET created_at = ? WHERE id = ?`, now.AddDate(0, 0, -5).Format("2006-01-02 15:04:05"), open)
+	insertCompletedAtTime(t, s, "yesterday", now.AddDate(0, 0, -4), now.AddDate(0, 0, -1))
+	insertCompletedAtTime(t, s, "today", now.AddDate(0, 0, -2), now.Add(-time.Hour))
+	insertCompletedAtTime(t, s, "ancient", now.AddDate(0, 0, -60), now.AddDate(0, 0, -50))
+
+	h, err := GetHistory(db, nil, now, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 7 || h[6].Day.Format("2006-01-02") != "2026-03-10" || h[0].Day.Format("2006-01-02") != "2026-03-04" {
+		t.Fatalf("unexpected window: %+v", h)
+	}
+
+	wantCompleted := []int{0, 0, 0, 0, 0, 1, 1}
+	wantOpen := []int{0, 1, 2, 2, 3, 2, 1}
+	for i := range h {
+		if h[i].Completed != wantCompleted[i] || h[i].Open != wantOpen[i] {
+			t.Errorf("day %s = %d completed / %d open, want %d / %d",
+				h[i].Day.Format("Jan 2"), h[i].Completed, h[i].Open, wantCompleted[i], wantOpen[i])
+		}
+	}
+	if h[5].AvgClose != 3*24*time.Hour || h[0].AvgClose != 0 {
+		t.Errorf("avg close = %v / %v, want 72h / 0", h[5].AvgClose, h[0].AvgClose)
+	}
+}
@@ -0,0 +1,131 @@
+package todo
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SetParent links a todo to a parent todo, making it a subtask.
+// parentID nil detaches the todo back to top level.
+// Returns an error if either todo does not exist or the link would create a cycle.
+func (s *Store) SetParent(id int, parentID *int) error {
+	if parentID != nil {
+		if *parentID == id {
+			return fmt.Errorf("todo #%d cannot be its own parent", id)
+		}
+		// Walk up from the proposed parent; reaching id means a cycle.
+		cur := *parentID
+		for {
+			var next sql.NullInt64
+			err := s.db.QueryRow(`SELECT parent_id FROM todos WHERE id = ?`, cur).Scan(&next)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("todo #%d not found", cur)
+			}
+			if err != nil {
+				return fmt.Errorf("checking parent chain: %w", err)
+			}
+			if !next.Valid {
+				break
+			}
+			if int(next.Int64) == id {
+				return fmt.Errorf("todo #%d is already an ancestor of #%d", id, *parentID)
+			}
+			cur = int(next.Int64)
+		}
+	}
+
+	res, err := s.db.Exec(
+		`UPDATE todos SET parent_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		parentID, id,
+	)
+	if err != nil {
+		return err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return fmt.Errorf("todo #%d not found", id)
+	}
+	return nil
+}
+
+// Subtasks returns the direct children of a todo, open first, then by creation order.
+func (s *Store) Subtasks(parentID int) ([]Todo, error) {
+	rows, err := s.db.Query(
+		`SELECT `+todoColumns+` FROM todos WHERE parent_id = ? ORDER BY done ASC, created_at ASC, id ASC`,
+		parentID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing subtasks of #%d: %w", parentID, err)
+	}
+	defer rows.Close()
+
+	var todos []Todo
+	for rows.Next() {
+		t, err := scanTodoRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, t)
+	}
+	return todos, rows.Err()
+}
+
+// OpenSubtaskCount returns how many direct children of a todo are still open.
+func (s *Store) OpenSubtaskCount(parentID int) (int, error) {
+	var n int
+	err := s.db.QueryRow(
+		`SELECT COUNT(*) FROM todos WHERE parent_id = ? AND done = 0`, parentID,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("counting subtasks of #%d: %w", parentID, err)
+	}
+	return n, nil
+}
+
+// NestSubtasks reorders todos so each subtask directly follows its parent,
+// preserving the relative order of siblings. Subtasks whose parent is not
+// present in the slice are treated as top-level. The returned map gives the
+// nesting depth of each todo ID (0 for top-level).
+func NestSubtasks(todos []Todo) ([]Todo, map[int]int) {
+	present := make(map[int]bool, len(todos))
+	for _, t := range todos {
+		present[t.ID] = true
+	}
+
+	children := make(map[int][]Todo)
+	var roots []Todo
+	for _, t := range todos {
+		if t.ParentID != nil && present[*t.ParentID] && *t.ParentID != t.ID {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+			continue
+		}
+		roots = append(roots, t)
+	}
+
+	out := make([]Todo, 0, len(todos))
+	depth := make(map[int]int, len(todos))
+	visited := make(map[int]bool, len(todos))
+	var walk func(t Todo, d int)
+	walk = func(t Todo, d int) {
+		if visited[t.ID] {
+			return
+		}
+		visited[t.ID] = true
+		out = append(out, t)
+		depth[t.ID] = d
+		for _, c := range children[t.ID] {
+			walk(c, d+1)
+		}
+	}
+	for _, r := range roots {
+		walk(r, 0)
+	}
+	// Anything unreached is part of a cycle with no root — keep it visible.
+	for _, t := range todos {
+		if !visited[t.ID] {
+			walk(t, 0)
+		}
+	}
+	return out, depth
+}
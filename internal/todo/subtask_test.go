@@ -0,0 +1,132 @@
+package todo
+
+import "testing"
+
+func intPtr(i int) *int { return &i }
+
+func TestSetParent_AndSubtasks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	parent, _ := s.Add("release", "", PrioHigh, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	a, _ := s.Add("tag", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("announce", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	for _, id := range []int{a, b} {
+		if err := s.SetParent(id, &parent); err != nil {
+			t.Fatalf("SetParent(%d): %v", id, err)
+		}
+	}
+	if _, _, err := s.Complete(a); err != nil {
+		t.Fatal(err)
+	}
+
+	subs, err := s.Subtasks(parent)
+	if err != nil {
+		t.Fatalf("Subtasks: %v", err)
+	}
+	if len(subs) != 2 {
+		t.Fatalf("expected 2 subtasks, got %d", len(subs))
+	}
+	if subs[0].ID != b {
+		t.Errorf("expected open subtask #%d first, got #%d", b, subs[0].ID)
+	}
+	if subs[0].ParentID == nil || *subs[0].ParentID != parent {
+		t.Errorf("expected ParentID %d, got %v", parent, subs[0].ParentID)
+	}
+
+	open, err := s.OpenSubtaskCount(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if open != 1 {
+		t.Errorf("expected 1 open subtask, got %d", open)
+	}
+
+	// Detach.
+	if err := s.SetParent(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := s.Get(b)
+	if got.ParentID != nil {
+		t.Errorf("expected nil ParentID after detach, got %d", *got.ParentID)
+	}
+}
+
+func TestSetParent_RejectsCycles(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("b", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	c, _ := s.Add("c", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+
+	if err := s.SetParent(a, &a); err == nil {
+		t.Error("expected error for self-parent")
+	}
+	if err := s.SetParent(b, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetParent(c, &b); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetParent(a, &c); err == nil {
+		t.Error("expected error for a → c → b → a cycle")
+	}
+	if err := s.SetParent(a, intPtr(999)); err == nil {
+		t.Error("expected error for missing parent")
+	}
+}
+
+func TestDelete_ParentOrphansSubtasks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	parent, _ := s.Add("parent", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	child, _ := s.Add("child", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := s.SetParent(child, &parent); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(parent); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Get(child)
+	if err != nil {
+		t.Fatalf("child should survive parent deletion: %v", err)
+	}
+	if got.ParentID != nil {
+		t.Errorf("expected child promoted to top level, got parent %d", *got.ParentID)
+	}
+}
+
+func TestNestSubtasks(t *testing.T) {
+	todos := []Todo{
+		{ID: 3, ParentID: intPtr(1)},
+		{ID: 1},
+		{ID: 2},
+		{ID: 4, ParentID: intPtr(3)},
+		{ID: 5, ParentID: intPtr(99)}, // parent not in list → top level
+	}
+
+	got, depths := NestSubtasks(todos)
+
+	wantOrder := []int{1, 3, 4, 2, 5}
+	if len(got) != len(wantOrder) {
+		t.Fatalf("expected %d todos, got %d", len(wantOrder), len(got))
+	}
+	for i, id := range wantOrder {
+		if got[i].ID != id {
+			t.Errorf("position %d: expected #%d, got #%d", i, id, got[i].ID)
+		}
+	}
+
+	wantDepth := map[int]int{1: 0, 3: 1, 4: 2, 2: 0, 5: 0}
+	for id, d := range wantDepth {
+		if depths[id] != d {
+			t.Errorf("depth of #%d: expected %d, got %d", id, d, depths[id])
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package todo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagCount summarizes how many todos carry a tag.
+type TagCount struct {
+	Tag   string
+	Open  int
+	Total int
+}
+
+// TagCounts returns every tag in use with open and total counts, most-used first.
+func (s *Store) TagCounts() ([]TagCount, error) {
+	rows, err := s.db.Query(`SELECT tags, done FROM todos WHERE tags IS NOT NULL AND tags != ''`)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]*TagCount{}
+	for rows.Next() {
+		var tagStr string
+		var done int
+		if err := rows.Scan(&tagStr, &done); err != nil {
+			return nil, err
+		}
+		for _, tag := range strings.Split(tagStr, ",") {
+			if tag == "" {
+				continue
+			}
+			c, ok := counts[tag]
+			if !ok {
+				c = &TagCount{Tag: tag}
+				counts[tag] = c
+			}
+			c.Total++
+			if done == 0 {
+				c.Open++
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]TagCount, 0, len(counts))
+	for _, c := range counts {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Tag < out[j].Tag
+	})
+	return out, nil
+}
+
+// RenameTag replaces oldTag with newTag on every todo that carries it,
+// merging into newTag where a todo already has both. Each change is
+// journaled, so a BeginBatch before the call makes it one undo step.
+// Returns the number of todos updated.
+func (s *Store) RenameTag(oldTag, newTag string) (int, error) {
+	oldTag, newTag = strings.TrimSpace(oldTag), strings.TrimSpace(newTag)
+	if newTag == "" || strings.Contains(newTag, ",") {
+		return 0, fmt.Errorf("invalid tag name %q", newTag)
+	}
+	if oldTag == newTag {
+		return 0, nil
+	}
+	return s.rewriteTag(oldTag, func(tags []string) []string {
+		var out []string
+		seen := map[string]bool{}
+		for _, t := range tags {
+			if t == oldTag {
+				t = newTag
+			}
+			if !seen[t] {
+				seen[t] = true
+				out = append(out, t)
+			}
+		}
+		return out
+	})
+}
+
+// AddTag tags a single todo, leaving it untouched when it already carries
+// the tag. Reports whether the tag was added.
+func (s *Store) AddTag(id int, tag string) (bool, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || strings.Contains(tag, ",") {
+		return false, fmt.Errorf("invalid tag name %q", tag)
+	}
+	t, err := s.Get(id)
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return false, nil
+		}
+	}
+
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.db.Exec(
+		`UPDATE todos SET tags = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		strings.Join(append(t.Tags, tag), ","), id,
+	); err != nil {
+		return false, err
+	}
+	return true, s.journal(HistoryEdit, id, snap)
+}
+
+// RemoveTag strips tag from every todo that carries it.
+// Returns the number of todos updated.
+func (s *Store) RemoveTag(tag string) (int, error) {
+	tag = strings.TrimSpace(tag)
+	return s.rewriteTag(tag, func(tags []string) []string {
+		var out []string
+		for _, t := range tags {
+			if t != tag {
+				out = append(out, t)
+			}
+		}
+		return out
+	})
+}
+
+// rewriteTag applies fn to the tag list of every todo tagged with tag.
+func (s *Store) rewriteTag(tag string, fn func([]string) []string) (int, error) {
+	if tag == "" {
+		return 0, fmt.Errorf("tag name cannot be empty")
+	}
+
+	// Narrow with LIKE, then match exactly in Go.
+	rows, err := s.db.Query(`SELECT id, tags FROM todos WHERE ',' || tags || ',' LIKE ?`, "%,"+tag+",%")
+	if err != nil {
+		return 0, fmt.Errorf("finding tagged todos: %w", err)
+	}
+	type tagged struct {
+		id   int
+		tags []string
+	}
+	var matches []tagged
+	for rows.Next() {
+		var id int
+		var tagStr string
+		if err := rows.Scan(&id, &tagStr); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		tags := strings.Split(tagStr, ",")
+		for _, t := range tags {
+			if t == tag {
+				matches = append(matches, tagged{id: id, tags: tags})
+				break
+			}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, m := range matches {
+		snap, err := s.snapshot(m.id, false)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := s.db.Exec(
+			`UPDATE todos SET tags = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+			strings.Join(fn(m.tags), ","), m.id,
+		); err != nil {
+			return 0, err
+		}
+		if err := s.journal(HistoryEdit, m.id, snap); err != nil {
+			return 0, err
+		}
+	}
+	return len(matches), nil
+}
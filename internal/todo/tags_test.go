@@ -0,0 +1,117 @@
+package todo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTagCounts(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, []string{"work", "urgent"}, nil, nil, ScheduleLater, RecurrenceNone)
+	s.Add("b", "", PrioMedium, []string{"work"}, nil, nil, ScheduleLater, RecurrenceNone)
+	s.Add("c", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	s.Complete(a)
+
+	counts, err := s.TagCounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("expected 2 tags, got %+v", counts)
+	}
+	if counts[0] != (TagCount{Tag: "work", Open: 1, Total: 2}) {
+		t.Errorf("unexpected first count: %+v", counts[0])
+	}
+	if counts[1] != (TagCount{Tag: "urgent", Open: 0, Total: 1}) {
+		t.Errorf("unexpected second count: %+v", counts[1])
+	}
+}
+
+func TestRenameTag_MergesAndMatchesExactly(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, []string{"wrok", "home"}, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("b", "", PrioMedium, []string{"wrok", "work"}, nil, nil, ScheduleLater, RecurrenceNone)
+	c, _ := s.Add("c", "", PrioMedium, []string{"wrokshop"}, nil, nil, ScheduleLater, RecurrenceNone)
+
+	n, err := s.RenameTag("wrok", "work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 todos updated, got %d", n)
+	}
+
+	want := map[int]string{a: "work,home", b: "work", c: "wrokshop"}
+	for id, tags := range want {
+		got, _ := s.Get(id)
+		if strings.Join(got.Tags, ",") != tags {
+			t.Errorf("#%d tags = %v, want %s", id, got.Tags, tags)
+		}
+	}
+
+	if _, err := s.RenameTag("work", "a,b"); err == nil {
+		t.Error("expected error for tag name containing a comma")
+	}
+}
+
+func TestRemoveTag_UndoRestores(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, []string{"x", "y"}, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("b", "", PrioMedium, []string{"x"}, nil, nil, ScheduleLater, RecurrenceNone)
+
+	s.BeginBatch()
+	n, err := s.RemoveTag("x")
+	if err != nil || n != 2 {
+		t.Fatalf("RemoveTag = %d, %v", n, err)
+	}
+	if got, _ := s.Get(b); len(got.Tags) != 0 {
+		t.Errorf("expected #%d untagged, got %v", b, got.Tags)
+	}
+
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get(a); strings.Join(got.Tags, ",") != "x,y" {
+		t.Errorf("expected tags restored on #%d, got %v", a, got.Tags)
+	}
+	if got, _ := s.Get(b); strings.Join(got.Tags, ",") != "x" {
+		t.Errorf("expected tags restored on #%d, got %v", b, got.Tags)
+	}
+}
+
+func TestAddTag(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	a, _ := s.Add("a", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	b, _ := s.Add("b", "", PrioMedium, []string{"home"}, nil, nil, ScheduleLater, RecurrenceNone)
+
+	for _, id := range []int{a, b} {
+		if added, err := s.AddTag(id, " work "); err != nil || !added {
+			t.Fatalf("AddTag(#%d) = %v, %v", id, added, err)
+		}
+	}
+	if added, err := s.AddTag(b, "work"); err != nil || added {
+		t.Errorf("expected re-tagging to be a no-op, got %v, %v", added, err)
+	}
+	if got, _ := s.Get(a); strings.Join(got.Tags, ",") != "work" {
+		t.Errorf("#%d tags = %v, want work", a, got.Tags)
+	}
+	if got, _ := s.Get(b); strings.Join(got.Tags, ",") != "home,work" {
+		t.Errorf("#%d tags = %v, want home,work", b, got.Tags)
+	}
+
+	if _, err := s.AddTag(a, "x,y"); err == nil {
+		t.Error("expected error for tag name containing a comma")
+	}
+}
@@ -0,0 +1,458 @@
+package todo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// twTimeFormat is Taskwarrior's JSON date format (always UTC).
+const twTimeFormat = "20060102T150405Z"
+
+// sqliteTimeFormat matches SQLite's CURRENT_TIMESTAMP (UTC).
+const sqliteTimeFormat = "2006-01-02 15:04:05"
+
+// todoNamespace seeds the deterministic UUIDs given to exported todos, so
+// exporting the same todo twice yields the same UUID (Taskwarrior, iCalendar).
+var todoNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/rnwolfe/mine/todo"))
+
+// twTask is one task in Taskwarrior's JSON import/export format.
+type twTask struct {
+	UUID        string         `json:"uuid,omitempty"`
+	Description string         `json:"description"`
+	Status      string         `json:"status"`
+	Entry       string         `json:"entry,omitempty"`
+	Modified    string         `json:"modified,omitempty"`
+	End         string         `json:"end,omitempty"`
+	Due         string         `json:"due,omitempty"`
+	Priority    string         `json:"priority,omitempty"`
+	Project     string         `json:"project,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Recur       string         `json:"recur,omitempty"`
+	Depends     twDepends      `json:"depends,omitempty"`
+	Annotations []twAnnotation `json:"annotations,omitempty"`
+}
+
+type twAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// twDepends decodes both the array form of "depends" (Taskwarrior 2.6+)
+// and the older comma-separated string form.
+type twDepends []string
+
+func (d *twDepends) UnmarshalJSON(b []byte) error {
+	var list []string
+	if err := json.Unmarshal(b, &list); err == nil {
+		*d = list
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*d = nil
+	for _, u := range strings.Split(s, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			*d = append(*d, u)
+		}
+	}
+	return nil
+}
+
+// TaskwarriorImport summarizes the outcome of ImportTaskwarrior.
+type TaskwarriorImport struct {
+	Imported int
+	// Skipped counts deleted tasks and recurrence templates, which have no
+	// mine equivalent.
+	Skipped int
+	// UnknownProjects lists Taskwarrior projects that did not match a
+	// registered project; those tasks were imported as global todos tagged
+	// "project:<name>".
+	UnknownProjects []string
+}
+
+// ExportTaskwarrior writes every todo — open, done, and archived — to w as a
+// Taskwarrior JSON array. The body and notes become annotations, dependencies
+// become "depends", and project paths are exported by their directory name.
+// Returns the number of tasks written.
+func (s *Store) ExportTaskwarrior(w io.Writer) (int, error) {
+	todos, err := s.List(ListOptions{ShowDone: true, IncludeSomeday: true, AllProjects: true, Sort: SortLegacy})
+	if err != nil {
+		return 0, err
+	}
+	archived, err := s.ListArchived(nil, true)
+	if err != nil {
+		return 0, err
+	}
+	todos = append(todos, archived...)
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID < todos[j].ID })
+
+	notes, err := s.allNotes()
+	if err != nil {
+		return 0, err
+	}
+	deps, err := s.allDependencies()
+	if err != nil {
+		return 0, err
+	}
+
+	uuids := make(map[int]string, len(todos))
+	for _, t := range todos {
+		uuids[t.ID] = todoUUID(t)
+	}
+
+	tasks := make([]twTask, 0, len(todos))
+	for _, t := range todos {
+		task := twTask{
+			UUID:        uuids[t.ID],
+			Description: t.Title,
+			Status:      "pending",
+			Entry:       twTime(t.CreatedAt),
+			Modified:    twTime(t.UpdatedAt),
+			Priority:    twPriority(t.Priority),
+			Tags:        t.Tags,
+		}
+		if t.Done {
+			task.Status = "completed"
+			if t.CompletedAt != nil {
+				task.End = twTime(*t.CompletedAt)
+			}
+		}
+		if t.DueDate != nil {
+			task.Due = twTime(dueMoment(t))
+			task.Recur = twRecur(t.Recurrence)
+		}
+		if t.ProjectPath != nil {
+			task.Project = filepath.Base(*t.ProjectPath)
+		}
+		if t.Body != "" {
+			task.Annotations = append(task.Annotations, twAnnotation{Entry: task.Entry, Description: t.Body})
+		}
+		for _, n := range notes[t.ID] {
+			task.Annotations = append(task.Annotations, twAnnotation{Entry: twTime(n.CreatedAt), Description: n.Body})
+		}
+		for _, dep := range deps[t.ID] {
+			if u, ok := uuids[dep]; ok {
+				task.Depends = append(task.Depends, u)
+			}
+		}
+		tasks = append(tasks, task)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(tasks); err != nil {
+		return 0, fmt.Errorf("writing taskwarrior export: %w", err)
+	}
+	return len(tasks), nil
+}
+
+// ImportTaskwarrior reads Taskwarrior JSON (an array, or one task per line as
+// older versions emit) and creates a todo for each pending, waiting, or
+// completed task. resolveProject maps a Taskwarrior project name to a
+// registered project path, returning nil when there is no match.
+// The import is all-or-nothing.
+func (s *Store) ImportTaskwarrior(r io.Reader, resolveProject func(name string) *string) (TaskwarriorImport, error) {
+	var res TaskwarriorImport
+	tasks, err := decodeTaskwarrior(r)
+	if err != nil {
+		return res, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	ids := map[string]int{}
+	unknown := map[string]bool{}
+	for _, task := range tasks {
+		if task.Status == "deleted" || task.Status == "recurring" || strings.TrimSpace(task.Description) == "" {
+			res.Skipped++
+			continue
+		}
+
+		tags := make([]string, 0, len(task.Tags))
+		for _, tag := range task.Tags {
+			if tag = strings.ReplaceAll(strings.TrimSpace(tag), ",", ""); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		var projectPath *string
+		if task.Project != "" {
+			projectPath = resolveProject(task.Project)
+			if projectPath == nil {
+				tags = append(tags, "project:"+task.Project)
+				if !unknown[task.Project] {
+					unknown[task.Project] = true
+					res.UnknownProjects = append(res.UnknownProjects, task.Project)
+				}
+			}
+		}
+
+		var due *time.Time
+		if t, ok := parseTWTime(task.Due); ok {
+			local := t.Local()
+			due = &local
+		}
+		dueStr, dueTimeStr := formatDue(due)
+
+		recurrence := RecurrenceNone
+		if rec, err := ParseRecurrence(twRecurAlias(task.Recur)); err == nil && due != nil {
+			recurrence = rec
+		}
+
+		created := time.Now()
+		if t, ok := parseTWTime(task.Entry); ok {
+			created = t
+		}
+		updated := created
+		if t, ok := parseTWTime(task.Modified); ok {
+			updated = t
+		}
+		done := 0
+		var completedAt any
+		if task.Status == "completed" {
+			done = 1
+			end := updated
+			if t, ok := parseTWTime(task.End); ok {
+				end = t
+			}
+			completedAt = end.UTC().Format(sqliteTimeFormat)
+		}
+
+		result, err := tx.Exec(
+			`INSERT INTO todos (title, priority, done, due_date, due_time, tags, project_path, schedule, recurrence, created_at, updated_at, completed_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			strings.TrimSpace(task.Description), twImportPriority(task.Priority), done, dueStr, dueTimeStr,
+			strings.Join(tags, ","), projectPath, ScheduleLater, recurrence,
+			created.UTC().Format(sqliteTimeFormat), updated.UTC().Format(sqliteTimeFormat), completedAt,
+		)
+		if err != nil {
+			return res, fmt.Errorf("importing %q: %w", task.Description, err)
+		}
+		id64, _ := result.LastInsertId()
+		id := int(id64)
+		if task.UUID != "" {
+			ids[task.UUID] = id
+		}
+
+		for _, a := range task.Annotations {
+			at := created
+			if t, ok := parseTWTime(a.Entry); ok {
+				at = t
+			}
+			if _, err := tx.Exec(
+				`INSERT INTO todo_notes (todo_id, body, created_at) VALUES (?, ?, ?)`,
+				id, a.Description, at.UTC().Format(sqliteTimeFormat),
+			); err != nil {
+				return res, fmt.Errorf("importing annotation: %w", err)
+			}
+		}
+		res.Imported++
+	}
+
+	// Dependencies are linked once every task has an ID. Edges to tasks that
+	// were skipped or are not in the file are dropped.
+	for _, task := range tasks {
+		id, ok := ids[task.UUID]
+		if !ok {
+			continue
+		}
+		for _, u := range task.Depends {
+			if dep, ok := ids[u]; ok {
+				if _, err := tx.Exec(`INSERT OR IGNORE INTO todo_deps (todo_id, depends_on) VALUES (?, ?)`, id, dep); err != nil {
+					return res, fmt.Errorf("importing dependency: %w", err)
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// decodeTaskwarrior parses a JSON array of tasks, or newline-delimited task objects.
+func decodeTaskwarrior(r io.Reader) ([]twTask, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var tasks []twTask
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &tasks); err != nil {
+			return nil, fmt.Errorf("invalid taskwarrior JSON: %w", err)
+		}
+		return tasks, nil
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSuffix(strings.TrimSpace(sc.Text()), ",")
+		if text == "" {
+			continue
+		}
+		var task twTask
+		if err := json.Unmarshal([]byte(text), &task); err != nil {
+			return nil, fmt.Errorf("invalid taskwarrior JSON on line %d: %w", line, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, sc.Err()
+}
+
+// allNotes returns the notes of every active and archived todo, oldest first.
+func (s *Store) allNotes() (map[int][]Note, error) {
+	rows, err := s.db.Query(
+		`SELECT todo_id, body, created_at FROM todo_notes
+		 UNION ALL
+		 SELECT todo_id, body, created_at FROM todo_notes_archive
+		 ORDER BY created_at`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing notes: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[int][]Note{}
+	for rows.Next() {
+		var id int
+		var n Note
+		var created string
+		if err := rows.Scan(&id, &n.Body, &created); err != nil {
+			return nil, err
+		}
+		n.CreatedAt = parseTimestamp(created)
+		out[id] = append(out[id], n)
+	}
+	return out, rows.Err()
+}
+
+// allDependencies returns every dependency edge, keyed by the dependent todo.
+func (s *Store) allDependencies() (map[int][]int, error) {
+	rows, err := s.db.Query(`SELECT todo_id, depends_on FROM todo_deps ORDER BY todo_id, depends_on`)
+	if err != nil {
+		return nil, fmt.Errorf("listing dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[int][]int{}
+	for rows.Next() {
+		var id, dep int
+		if err := rows.Scan(&id, &dep); err != nil {
+			return nil, err
+		}
+		out[id] = append(out[id], dep)
+	}
+	return out, rows.Err()
+}
+
+func todoUUID(t Todo) string {
+	return uuid.NewSHA1(todoNamespace, []byte(fmt.Sprintf("%d/%s", t.ID, t.CreatedAt.UTC().Format(twTimeFormat)))).String()
+}
+
+func twTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(twTimeFormat)
+}
+
+func parseTWTime(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(twTimeFormat, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// twPriority maps mine priorities onto Taskwarrior's H/M/L. Taskwarrior has
+// no level above H, so crit exports as H.
+func twPriority(p int) string {
+	switch p {
+	case PrioCrit, PrioHigh:
+		return "H"
+	case PrioLow:
+		return "L"
+	default:
+		return "M"
+	}
+}
+
+func twImportPriority(p string) int {
+	switch strings.ToUpper(p) {
+	case "H":
+		return PrioHigh
+	case "L":
+		return PrioLow
+	default:
+		return PrioMedium
+	}
+}
+
+// twRecur returns the Taskwarrior "recur" value for a recurrence, or "" when
+// the rule (e.g. specific weekdays) has no Taskwarrior equivalent.
+func twRecur(recurrence string) string {
+	switch recurrence {
+	case RecurrenceNone, "":
+		return ""
+	case RecurrenceDaily:
+		return "daily"
+	case RecurrenceWeekday:
+		return "weekdays"
+	case RecurrenceWeekly:
+		return "weekly"
+	case RecurrenceMonthly:
+		return "monthly"
+	}
+	r, err := parseRRule(recurrence)
+	if err != nil || len(r.ByDay) > 0 || r.MonthDay != 0 {
+		return ""
+	}
+	switch r.Freq {
+	case freqDaily:
+		return fmt.Sprintf("%ddays", r.Interval)
+	case freqWeekly:
+		return fmt.Sprintf("%dweeks", r.Interval)
+	case freqMonthly:
+		return fmt.Sprintf("%dmonths", r.Interval)
+	}
+	return ""
+}
+
+// twRecurAlias rewrites Taskwarrior recurrence names that ParseRecurrence
+// does not know. Unsupported periods (yearly, hourly, ...) fail to parse and
+// the task is imported without recurrence.
+func twRecurAlias(recur string) string {
+	switch strings.ToLower(recur) {
+	case "biweekly", "fortnight":
+		return "2 weeks"
+	case "quarterly":
+		return "3 months"
+	case "semiannual":
+		return "6 months"
+	}
+	return recur
+}
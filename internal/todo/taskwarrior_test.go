@@ -0,0 +1,173 @@
+package todo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+const twSample = `[
+{"uuid":"a0000000-0000-0000-0000-000000000001","description":"write report","status":"pending","entry":"20260301T090000Z","priority":"H","project":"work","tags":["writing","q1"],"due":"20260310T170000Z","annotations":[{"entry":"20260302T100000Z","description":"outline done"}]},
+{"uuid":"a0000000-0000-0000-0000-000000000002","description":"send report","status":"pending","entry":"20260301T090000Z","depends":"a0000000-0000-0000-0000-000000000001","recur":"weekly","due":"20260311T000000Z"},
+{"uuid":"a0000000-0000-0000-0000-000000000003","description":"old chore","status":"completed","entry":"20260101T090000Z","end":"20260105T120000Z","priority":"L"},
+{"uuid":"a0000000-0000-0000-0000-000000000004","description":"gone","status":"deleted","entry":"20260101T090000Z"}
+]`
+
+func TestImportTaskwarrior(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	workPath := "/projects/work"
+	resolve := func(name string) *string {
+		if name == "work" {
+			return &workPath
+		}
+		return nil
+	}
+
+	res, err := s.ImportTaskwarrior(strings.NewReader(twSample), resolve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Imported != 3 || res.Skipped != 1 {
+		t.Fatalf("expected 3 imported, 1 skipped; got %+v", res)
+	}
+
+	report, err := s.GetWithNotes(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Title != "write report" || report.Priority != PrioHigh {
+		t.Errorf("unexpected title/priority: %q %d", report.Title, report.Priority)
+	}
+	if report.ProjectPath == nil || *report.ProjectPath != workPath {
+		t.Errorf("expected project %q, got %v", workPath, report.ProjectPath)
+	}
+	if strings.Join(report.Tags, ",") != "writing,q1" {
+		t.Errorf("unexpected tags %v", report.Tags)
+	}
+	wantDue := time.Date(2026, 3, 10, 17, 0, 0, 0, time.UTC)
+	if report.DueDate == nil || !report.DueHasTime || !report.DueDate.Equal(wantDue) {
+		t.Errorf("expected due %v with time, got %v", wantDue, report.DueDate)
+	}
+	if len(report.Notes) != 1 || report.Notes[0].Body != "outline done" {
+		t.Errorf("expected annotation imported as note, got %+v", report.Notes)
+	}
+
+	send, _ := s.Get(2)
+	if send.Recurrence != RecurrenceWeekly {
+		t.Errorf("expected weekly recurrence, got %q", send.Recurrence)
+	}
+	if len(send.BlockedBy) != 1 || send.BlockedBy[0] != 1 {
+		t.Errorf("expected #2 blocked by #1, got %v", send.BlockedBy)
+	}
+
+	chore, _ := s.Get(3)
+	if !chore.Done || chore.CompletedAt == nil || chore.Priority != PrioLow {
+		t.Fatalf("expected completed low-priority chore, got %+v", chore)
+	}
+	if got := chore.CompletedAt.UTC().Format("2006-01-02"); got != "2026-01-05" {
+		t.Errorf("expected completion date kept, got %s", got)
+	}
+}
+
+func TestImportTaskwarrior_UnknownProjectAndLines(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	lines := `{"description":"paint fence","status":"pending","project":"home.garden"}
+{"description":"mow lawn","status":"waiting","project":"home.garden"}`
+	res, err := s.ImportTaskwarrior(strings.NewReader(lines), func(string) *string { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Imported != 2 || len(res.UnknownProjects) != 1 || res.UnknownProjects[0] != "home.garden" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	got, _ := s.Get(1)
+	if got.ProjectPath != nil || len(got.Tags) != 1 || got.Tags[0] != "project:home.garden" {
+		t.Errorf("expected global todo tagged with project, got %+v", got)
+	}
+}
+
+func TestImportTaskwarrior_InvalidJSON(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+	if _, err := s.ImportTaskwarrior(strings.NewReader(`[{"description":`), nil); err == nil {
+		t.Fatal("expected error for malformed JSON")
+	}
+}
+
+func TestExportTaskwarrior_RoundTrip(t *testing.T) {
+	src := NewStore(setupTestDB(t))
+	proj := "/projects/mine"
+	due := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	a, _ := src.Add("ship release", "checklist in wiki", PrioCrit, []string{"release"}, &due, &proj, ScheduleToday, RecurrenceMonthly)
+	b, _ := src.Add("announce", "", PrioLow, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := src.AddDependency(b, a); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddNote(a, "tagged v1.2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := src.Complete(b); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	n, err := src.ExportTaskwarrior(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 exported, got %d", n)
+	}
+
+	var tasks []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &tasks); err != nil {
+		t.Fatalf("export is not a JSON array: %v", err)
+	}
+	if tasks[0]["priority"] != "H" || tasks[0]["project"] != "mine" || tasks[0]["recur"] != "monthly" {
+		t.Errorf("unexpected first task: %v", tasks[0])
+	}
+	if tasks[1]["status"] != "completed" || tasks[1]["end"] == nil {
+		t.Errorf("expected completed second task, got %v", tasks[1])
+	}
+
+	// Exporting again yields the same UUIDs.
+	var again bytes.Buffer
+	src.ExportTaskwarrior(&again)
+	if again.String() != buf.String() {
+		t.Error("expected export to be deterministic")
+	}
+
+	dst := NewStore(setupTestDB(t))
+	res, err := dst.ImportTaskwarrior(&buf, func(name string) *string {
+		if name == "mine" {
+			return &proj
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Imported != 2 {
+		t.Fatalf("expected 2 imported, got %+v", res)
+	}
+	got, _ := dst.GetWithNotes(1)
+	if got.Title != "ship release" || got.ProjectPath == nil || *got.ProjectPath != proj {
+		t.Errorf("unexpected round-tripped todo %+v", got)
+	}
+	if got.DueDate == nil || got.DueHasTime || got.DueDate.Format("2006-01-02") != "2026-04-01" {
+		t.Errorf("expected date-only due 2026-04-01, got %v (hasTime=%v)", got.DueDate, got.DueHasTime)
+	}
+	if len(got.Notes) != 2 || got.Notes[0].Body != "checklist in wiki" || got.Notes[1].Body != "tagged v1.2" {
+		t.Errorf("expected body and note as notes, got %+v", got.Notes)
+	}
+	done, _ := dst.Get(2)
+	if !done.Done {
+		t.Error("expected completed status to round-trip")
+	}
+}
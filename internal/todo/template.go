@@ -0,0 +1,188 @@
+package todo
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrTemplateNotFound is returned when a named template does not exist.
+var ErrTemplateNotFound = errors.New("template not found")
+
+// TemplateItem is one task in a template. Children become subtasks of the
+// todo created from this item.
+type TemplateItem struct {
+	Title    string         `json:"title"`
+	Body     string         `json:"body,omitempty"`
+	Priority int            `json:"priority"`
+	Tags     []string       `json:"tags,omitempty"`
+	Children []TemplateItem `json:"children,omitempty"`
+}
+
+// Template is a named, reusable set of todos.
+type Template struct {
+	ID        int
+	Name      string
+	Items     []TemplateItem
+	CreatedAt time.Time
+}
+
+// Count returns the total number of todos the template creates.
+func (t Template) Count() int {
+	return countItems(t.Items)
+}
+
+func countItems(items []TemplateItem) int {
+	n := len(items)
+	for _, it := range items {
+		n += countItems(it.Children)
+	}
+	return n
+}
+
+// SaveTemplate stores a new template. Names are case-insensitive and unique.
+func (s *Store) SaveTemplate(name string, items []TemplateItem) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("template name cannot be empty")
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("template %q has no items", name)
+	}
+	payload, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("encoding template: %w", err)
+	}
+	_, err = s.db.Exec(`INSERT INTO todo_templates (name, items) VALUES (?, ?)`, name, string(payload))
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return fmt.Errorf("template %q already exists", name)
+	}
+	return err
+}
+
+// GetTemplate returns the template with the given name.
+func (s *Store) GetTemplate(name string) (*Template, error) {
+	var t Template
+	var payload, createdAt string
+	err := s.db.QueryRow(
+		`SELECT id, name, items, created_at FROM todo_templates WHERE name = ? COLLATE NOCASE`,
+		strings.TrimSpace(name),
+	).Scan(&t.ID, &t.Name, &payload, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(payload), &t.Items); err != nil {
+		return nil, fmt.Errorf("decoding template %q: %w", t.Name, err)
+	}
+	t.CreatedAt = parseTimestamp(createdAt)
+	return &t, nil
+}
+
+// ListTemplates returns all templates ordered by name.
+func (s *Store) ListTemplates() ([]Template, error) {
+	rows, err := s.db.Query(`SELECT id, name, items, created_at FROM todo_templates ORDER BY name COLLATE NOCASE`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Template
+	for rows.Next() {
+		var t Template
+		var payload, createdAt string
+		if err := rows.Scan(&t.ID, &t.Name, &payload, &createdAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(payload), &t.Items); err != nil {
+			return nil, fmt.Errorf("decoding template %q: %w", t.Name, err)
+		}
+		t.CreatedAt = parseTimestamp(createdAt)
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// DeleteTemplate removes a template by name.
+func (s *Store) DeleteTemplate(name string) error {
+	res, err := s.db.Exec(`DELETE FROM todo_templates WHERE name = ? COLLATE NOCASE`, strings.TrimSpace(name))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: %q", ErrTemplateNotFound, name)
+	}
+	return nil
+}
+
+// TemplateFromTodo captures a todo and its subtasks (recursively) as a
+// template item, so an existing checklist can be saved for reuse.
+func (s *Store) TemplateFromTodo(id int) (TemplateItem, error) {
+	t, err := s.Get(id)
+	if err != nil {
+		return TemplateItem{}, err
+	}
+	return s.templateItem(*t, map[int]bool{})
+}
+
+func (s *Store) templateItem(t Todo, seen map[int]bool) (TemplateItem, error) {
+	seen[t.ID] = true
+	item := TemplateItem{Title: t.Title, Body: t.Body, Priority: t.Priority, Tags: t.Tags}
+	subs, err := s.Subtasks(t.ID)
+	if err != nil {
+		return TemplateItem{}, err
+	}
+	for _, c := range subs {
+		if seen[c.ID] {
+			continue
+		}
+		child, err := s.templateItem(c, seen)
+		if err != nil {
+			return TemplateItem{}, err
+		}
+		item.Children = append(item.Children, child)
+	}
+	return item, nil
+}
+
+// ApplyTemplate creates todos from a template, linking children as subtasks.
+// All created todos are assigned to projectPath (nil for global).
+// Returns the IDs of the created top-level todos.
+func (s *Store) ApplyTemplate(tpl *Template, projectPath *string) ([]int, error) {
+	var roots []int
+	for _, it := range tpl.Items {
+		id, err := s.applyItem(it, nil, projectPath)
+		if err != nil {
+			return roots, err
+		}
+		roots = append(roots, id)
+	}
+	return roots, nil
+}
+
+func (s *Store) applyItem(it TemplateItem, parentID *int, projectPath *string) (int, error) {
+	prio := it.Priority
+	if prio == 0 {
+		prio = PrioMedium
+	}
+	id, err := s.Add(it.Title, it.Body, prio, it.Tags, nil, projectPath, ScheduleLater, RecurrenceNone)
+	if err != nil {
+		return 0, fmt.Errorf("creating %q: %w", it.Title, err)
+	}
+	if parentID != nil {
+		if err := s.SetParent(id, parentID); err != nil {
+			return 0, err
+		}
+	}
+	for _, c := range it.Children {
+		if _, err := s.applyItem(c, &id, projectPath); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,110 @@
+package todo
+
+import (
+	"errors"
+	"testing"
+)
+
+func sampleTemplate() []TemplateItem {
+	return []TemplateItem{{
+		Title:    "Release",
+		Priority: PrioHigh,
+		Tags:     []string{"release"},
+		Children: []TemplateItem{
+			{Title: "Bump version", Priority: PrioMedium},
+			{Title: "Publish", Priority: PrioCrit, Children: []TemplateItem{{Title: "Announce"}}},
+		},
+	}}
+}
+
+func TestTemplate_SaveGetListDelete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	if err := s.SaveTemplate("release", sampleTemplate()); err != nil {
+		t.Fatalf("SaveTemplate: %v", err)
+	}
+	if err := s.SaveTemplate("Release", sampleTemplate()); err == nil {
+		t.Error("expected duplicate name (case-insensitive) to be rejected")
+	}
+	if err := s.SaveTemplate("empty", nil); err == nil {
+		t.Error("expected empty template to be rejected")
+	}
+
+	tpl, err := s.GetTemplate("RELEASE")
+	if err != nil {
+		t.Fatalf("GetTemplate: %v", err)
+	}
+	if tpl.Name != "release" || tpl.Count() != 4 {
+		t.Errorf("got name=%q count=%d, want release/4", tpl.Name, tpl.Count())
+	}
+
+	list, err := s.ListTemplates()
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ListTemplates = %v, %v", list, err)
+	}
+
+	if err := s.DeleteTemplate("release"); err != nil {
+		t.Fatalf("DeleteTemplate: %v", err)
+	}
+	if _, err := s.GetTemplate("release"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("expected ErrTemplateNotFound after delete, got %v", err)
+	}
+	if err := s.DeleteTemplate("release"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("expected ErrTemplateNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestApplyTemplate_CreatesSubtaskTree(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	proj := "/projects/app"
+	roots, err := s.ApplyTemplate(&Template{Name: "release", Items: sampleTemplate()}, &proj)
+	if err != nil {
+		t.Fatalf("ApplyTemplate: %v", err)
+	}
+	if len(roots) != 1 {
+		t.Fatalf("expected 1 root, got %d", len(roots))
+	}
+
+	root, _ := s.Get(roots[0])
+	if root.Title != "Release" || root.Priority != PrioHigh || len(root.Tags) != 1 {
+		t.Errorf("unexpected root: %+v", root)
+	}
+	if root.ProjectPath == nil || *root.ProjectPath != proj {
+		t.Errorf("expected root scoped to %s, got %v", proj, root.ProjectPath)
+	}
+
+	subs, _ := s.Subtasks(root.ID)
+	if len(subs) != 2 || subs[1].Title != "Publish" || subs[1].Priority != PrioCrit {
+		t.Fatalf("unexpected subtasks: %+v", subs)
+	}
+	grand, _ := s.Subtasks(subs[1].ID)
+	if len(grand) != 1 || grand[0].Title != "Announce" || grand[0].Priority != PrioMedium {
+		t.Errorf("unexpected grandchildren: %+v", grand)
+	}
+}
+
+func TestTemplateFromTodo_CapturesSubtasks(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	parent, _ := s.Add("Onboard", "notes", PrioHigh, []string{"hr"}, nil, nil, ScheduleLater, RecurrenceNone)
+	child, _ := s.Add("Laptop", "", PrioLow, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	s.SetParent(child, &parent)
+
+	item, err := s.TemplateFromTodo(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Title != "Onboard" || item.Body != "notes" || item.Priority != PrioHigh {
+		t.Errorf("unexpected item: %+v", item)
+	}
+	if len(item.Children) != 1 || item.Children[0].Title != "Laptop" || item.Children[0].Priority != PrioLow {
+		t.Errorf("unexpected children: %+v", item.Children)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/rnwolfe/mine/internal/ui"
 )
 
 // Priority levels.
@@ -41,12 +43,15 @@ type Note struct {
 
 // Todo represents a single task.
 type Todo struct {
-	ID          int
-	Title       string
-	Body        string
-	Priority    int
-	Done        bool
-	DueDate     *time.Time
+	ID       int
+	Title    string
+	Body     string
+	Priority int
+	Done     bool
+	DueDate  *time.Time
+	// DueHasTime reports whether DueDate carries a time of day (in local time).
+	// When false, DueDate is a plain calendar date.
+	DueHasTime  bool
 	Tags        []string
 	ProjectPath *string
 	Schedule    string
@@ -54,8 +59,30 @@ type Todo struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	CompletedAt *time.Time
+	// ParentID links a subtask to its parent todo. nil for top-level todos.
+	ParentID *int
+	// Context is the GTD context ("home", "work", ...) without the leading "@".
+	// Empty means no context.
+	Context string
+	// Estimate is the expected effort, rounded to whole minutes. Zero means
+	// no estimate.
+	Estimate time.Duration
+	// Pinned todos sort above everything else in listings while open.
+	Pinned bool
+	// WaitingOn names the person this todo is delegated to. Empty means it's
+	// actionable by you.
+	WaitingOn string
+	// BlockedBy lists the IDs of open todos this one depends on.
+	// Populated by Get() and List(); empty means the todo is actionable.
+	BlockedBy []int
 	// Notes is populated only by GetWithNotes(), not List(), for performance.
 	Notes []Note
+	// Links lists external tracker items (e.g. GitHub issues) this todo
+	// mirrors. Populated only by GetWithNotes().
+	Links []Link
+	// Attachments lists files and URLs attached with "mine todo attach".
+	// Populated only by GetWithNotes().
+	Attachments []Attachment
 }
 
 // SortMode controls the sort order returned by List.
@@ -80,6 +107,16 @@ type ListOptions struct {
 	ProjectPath *string
 	// AllProjects returns todos from all projects and global.
 	AllProjects bool
+	// ProjectPaths, when non-nil, limits the result to todos in any of these
+	// projects, without global todos. It takes precedence over ProjectPath;
+	// AllProjects still wins.
+	ProjectPaths []string
+	// ExcludeBlocked drops todos that still have open dependencies.
+	ExcludeBlocked bool
+	// ExcludeWaiting drops todos delegated to someone else.
+	ExcludeWaiting bool
+	// Context filters to todos in this GTD context. Empty means any context.
+	Context string
 	// Sort controls the sort order. Default (zero value) is SortUrgency.
 	Sort SortMode
 	// CurrentProjectPath is the active project for urgency scoring.
@@ -115,6 +152,9 @@ func PriorityLabel(p int) string {
 
 // PriorityIcon returns a colored icon for the priority.
 func PriorityIcon(p int) string {
+	if ui.ASCII() {
+		return priorityASCII(p)
+	}
 	switch p {
 	case PrioCrit:
 		return "🔴"
@@ -129,6 +169,23 @@ func PriorityIcon(p int) string {
 	}
 }
 
+// priorityASCII is PriorityIcon for terminals without emoji: the same two
+// columns, louder for higher priorities.
+func priorityASCII(p int) string {
+	switch p {
+	case PrioCrit:
+		return "!!"
+	case PrioHigh:
+		return "! "
+	case PrioMedium:
+		return "- "
+	case PrioLow:
+		return ". "
+	default:
+		return "  "
+	}
+}
+
 // ParseSchedule validates and normalizes a schedule bucket string.
 // Accepts full names and short aliases: t=today, s=soon, l=later, sd=someday.
 func ParseSchedule(s string) (string, error) {
@@ -147,20 +204,28 @@ func ParseSchedule(s string) (string, error) {
 }
 
 // ParseRecurrence validates and normalizes a recurrence string.
-// Accepts short aliases: d/day/daily, wd/weekday, w/week/weekly, m/month/monthly.
+// Accepts short aliases: d/day/daily, wd/weekday, w/week/weekly, m/month/monthly,
+// plus custom rules such as "2 weeks", "mon,wed,fri", and "1st of month",
+// which are stored as RRULE-like strings (e.g. "FREQ=WEEKLY;INTERVAL=2").
 func ParseRecurrence(s string) (string, error) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "d", "day", "daily":
 		return RecurrenceDaily, nil
-	case "wd", "weekday":
+	case "wd", "weekday", "weekdays":
 		return RecurrenceWeekday, nil
 	case "w", "week", "weekly":
 		return RecurrenceWeekly, nil
 	case "m", "month", "monthly":
 		return RecurrenceMonthly, nil
-	default:
-		return "", fmt.Errorf("invalid recurrence %q — valid values: day (d), weekday (wd), week (w), month (m)", s)
 	}
+	rule, err := parseCustomRecurrence(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid recurrence %q — valid values: day (d), weekday (wd), week (w), month (m), or rules like \"2 weeks\", \"mon,wed,fri\", \"1st of month\"", s)
+	}
+	if l := rule.legacy(); l != "" {
+		return l, nil
+	}
+	return rule.String(), nil
 }
 
 // RecurrenceLabel returns a short display label for a recurrence value.
@@ -174,9 +239,13 @@ func RecurrenceLabel(r string) string {
 		return "weekly"
 	case RecurrenceMonthly:
 		return "monthly"
-	default:
+	case RecurrenceNone, "":
 		return ""
 	}
+	if rule, err := parseRRule(r); err == nil {
+		return rule.Label()
+	}
+	return ""
 }
 
 // nextDueDate computes the next due date based on the recurrence frequency.
@@ -203,9 +272,11 @@ func nextDueDate(base time.Time, recurrence string) time.Time {
 			d = lastDay
 		}
 		return time.Date(nextMonth.Year(), nextMonth.Month(), d, 0, 0, 0, 0, base.Location())
-	default:
-		return base
 	}
+	if rule, err := parseRRule(recurrence); err == nil {
+		return rule.next(base)
+	}
+	return base
 }
 
 // ScheduleLabel returns a short display label for a schedule bucket.
@@ -227,6 +298,8 @@ func ScheduleLabel(schedule string) string {
 // Store handles todo persistence.
 type Store struct {
 	db *sql.DB
+	// batch groups journaled mutations into one undo step; see BeginBatch.
+	batch string
 }
 
 // NewStore creates a new todo store.
@@ -236,14 +309,12 @@ func NewStore(db *sql.DB) *Store {
 
 // Add creates a new todo and returns its ID.
 // body sets the initial description/context for the todo (may be empty).
+// A due value with a non-midnight clock time is stored with its time of day;
+// midnight means a date-only due date.
 // recurrence is one of the Recurrence* constants (or "" / "none" for non-recurring).
 func (s *Store) Add(title string, body string, priority int, tags []string, due *time.Time, projectPath *string, schedule string, recurrence string) (int, error) {
 	tagStr := strings.Join(tags, ",")
-	var dueStr *string
-	if due != nil {
-		d := due.Format("2006-01-02")
-		dueStr = &d
-	}
+	dueStr, dueTimeStr := formatDue(due)
 	if schedule == "" {
 		schedule = ScheduleLater
 	}
@@ -252,8 +323,8 @@ func (s *Store) Add(title string, body string, priority int, tags []string, due
 	}
 
 	res, err := s.db.Exec(
-		`INSERT INTO todos (title, body, priority, tags, due_date, project_path, schedule, recurrence) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		title, body, priority, tagStr, dueStr, projectPath, schedule, recurrence,
+		`INSERT INTO todos (title, body, priority, tags, due_date, due_time, project_path, schedule, recurrence) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		title, body, priority, tagStr, dueStr, dueTimeStr, projectPath, schedule, recurrence,
 	)
 	if err != nil {
 		return 0, err
@@ -263,23 +334,40 @@ func (s *Store) Add(title string, body string, priority int, tags []string, due
 	return int(id), nil
 }
 
+// formatDue splits a due time into its stored date ("2006-01-02") and
+// optional time-of-day ("15:04") columns. Midnight in the value's own
+// location is a date-only due date (time.Parse yields UTC midnight, so the
+// calendar date is kept as written); any other clock time is stored in local time.
+func formatDue(due *time.Time) (dateStr *string, timeStr *string) {
+	if due == nil {
+		return nil, nil
+	}
+	if due.Hour() == 0 && due.Minute() == 0 {
+		d := due.Format("2006-01-02")
+		return &d, nil
+	}
+	local := due.In(time.Local)
+	d := local.Format("2006-01-02")
+	tm := local.Format("15:04")
+	return &d, &tm
+}
+
 // SetSchedule updates the schedule bucket for a todo.
 func (s *Store) SetSchedule(id int, schedule string) error {
 	if _, err := ParseSchedule(schedule); err != nil {
 		return err
 	}
-	res, err := s.db.Exec(
-		`UPDATE todos SET schedule = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
-		schedule, id,
-	)
+	snap, err := s.snapshot(id, false)
 	if err != nil {
 		return err
 	}
-	n, _ := res.RowsAffected()
-	if n == 0 {
-		return fmt.Errorf("todo #%d not found", id)
+	if _, err := s.db.Exec(
+		`UPDATE todos SET schedule = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		schedule, id,
+	); err != nil {
+		return err
 	}
-	return nil
+	return s.journal(HistorySchedule, id, snap)
 }
 
 // Complete marks a todo as done. For recurring tasks it also spawns the next occurrence.
@@ -290,6 +378,10 @@ func (s *Store) Complete(id int) (spawnedID int, spawnedDue *time.Time, err erro
 	if err != nil {
 		return 0, nil, err
 	}
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	res, execErr := s.db.Exec(
 		`UPDATE todos SET done = 1, completed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND done = 0`,
@@ -312,13 +404,34 @@ func (s *Store) Complete(id int) (spawnedID int, spawnedDue *time.Time, err erro
 		// Strip time component — only keep date.
 		base = time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, base.Location())
 		next := nextDueDate(base, t.Recurrence)
+		// Carry a due time of day over to the next occurrence.
+		if t.DueHasTime && t.DueDate != nil {
+			next = time.Date(next.Year(), next.Month(), next.Day(), t.DueDate.Hour(), t.DueDate.Minute(), 0, 0, time.Local)
+		}
 		spawnedID, err = s.Add(t.Title, t.Body, t.Priority, t.Tags, &next, t.ProjectPath, ScheduleToday, t.Recurrence)
 		if err != nil {
 			return 0, nil, fmt.Errorf("spawning next occurrence: %w", err)
 		}
+		if t.Estimate > 0 {
+			if _, err := s.db.Exec(`UPDATE todos SET estimate_mins = ? WHERE id = ?`, estimateMinutes(t.Estimate), spawnedID); err != nil {
+				return 0, nil, fmt.Errorf("spawning next occurrence: %w", err)
+			}
+		}
+		if t.Context != "" {
+			if _, err := s.db.Exec(`UPDATE todos SET context = ? WHERE id = ?`, t.Context, spawnedID); err != nil {
+				return 0, nil, fmt.Errorf("spawning next occurrence: %w", err)
+			}
+		}
+		snap.SpawnedID = spawnedID
+		if err := s.journal(HistoryDone, id, snap); err != nil {
+			return 0, nil, err
+		}
 		return spawnedID, &next, nil
 	}
 
+	if err := s.journal(HistoryDone, id, snap); err != nil {
+		return 0, nil, err
+	}
 	return 0, nil, nil
 }
 
@@ -331,17 +444,17 @@ func (s *Store) Uncomplete(id int) error {
 	return err
 }
 
-// Delete removes a todo.
+// Delete removes a todo. The removed row, its notes, and its dependency and
+// subtask links are journaled so Undo can bring it back.
 func (s *Store) Delete(id int) error {
-	res, err := s.db.Exec(`DELETE FROM todos WHERE id = ?`, id)
+	snap, err := s.snapshot(id, true)
 	if err != nil {
 		return err
 	}
-	n, _ := res.RowsAffected()
-	if n == 0 {
-		return fmt.Errorf("todo #%d not found", id)
+	if _, err := s.db.Exec(`DELETE FROM todos WHERE id = ?`, id); err != nil {
+		return err
 	}
-	return nil
+	return s.journal(HistoryRemove, id, snap)
 }
 
 // parseTimestamp parses a timestamp string from SQLite, handling RFC3339,
@@ -360,6 +473,9 @@ func parseTimestamp(s string) time.Time {
 	return time.Time{}
 }
 
+// todoColumns is the column list expected by scanTodoRow, in scan order.
+const todoColumns = `id, title, body, priority, done, due_date, due_time, tags, project_path, schedule, recurrence, created_at, updated_at, completed_at, parent_id, context, estimate_mins, pinned, waiting_on`
+
 // rowScanner is satisfied by both *sql.Row and *sql.Rows, allowing a single
 // scan helper to work with both QueryRow and Query result sets.
 type rowScanner interface {
@@ -368,22 +484,32 @@ type rowScanner interface {
 
 // scanTodoRow reads one Todo from a rowScanner (*sql.Row or *sql.Rows).
 // It handles due date parsing, tag splitting, project path deref,
-// schedule/recurrence defaults, and timestamp parsing.
+// schedule/recurrence defaults, parent linkage, context, estimate, pinning,
+// delegation, and timestamp parsing.
 func scanTodoRow(sc rowScanner) (Todo, error) {
 	var t Todo
 	var doneInt int
-	var dueStr, tagStr, projPath, scheduleStr, recurrenceStr sql.NullString
+	var dueStr, dueTimeStr, tagStr, projPath, scheduleStr, recurrenceStr, contextStr, waitingOn sql.NullString
 	var completedAt sql.NullTime
+	var parentID, estimateMins, pinnedInt sql.NullInt64
 	var createdStr, updatedStr string
 
-	if err := sc.Scan(&t.ID, &t.Title, &t.Body, &t.Priority, &doneInt, &dueStr, &tagStr, &projPath, &scheduleStr, &recurrenceStr, &createdStr, &updatedStr, &completedAt); err != nil {
+	if err := sc.Scan(&t.ID, &t.Title, &t.Body, &t.Priority, &doneInt, &dueStr, &dueTimeStr, &tagStr, &projPath, &scheduleStr, &recurrenceStr, &createdStr, &updatedStr, &completedAt, &parentID, &contextStr, &estimateMins, &pinnedInt, &waitingOn); err != nil {
 		return Todo{}, err
 	}
 
 	t.Done = doneInt == 1
 	if dueStr.Valid && dueStr.String != "" {
-		if parsed, err := time.Parse("2006-01-02", dueStr.String); err == nil {
-			t.DueDate = &parsed
+		if dueTimeStr.Valid && dueTimeStr.String != "" {
+			if parsed, err := time.ParseInLocation("2006-01-02 15:04", dueStr.String+" "+dueTimeStr.String, time.Local); err == nil {
+				t.DueDate = &parsed
+				t.DueHasTime = true
+			}
+		}
+		if t.DueDate == nil {
+			if parsed, err := time.Parse("2006-01-02", dueStr.String); err == nil {
+				t.DueDate = &parsed
+			}
 		}
 	}
 	if tagStr.Valid && tagStr.String != "" {
@@ -406,6 +532,14 @@ func scanTodoRow(sc rowScanner) (Todo, error) {
 	if completedAt.Valid {
 		t.CompletedAt = &completedAt.Time
 	}
+	if parentID.Valid {
+		pid := int(parentID.Int64)
+		t.ParentID = &pid
+	}
+	t.Context = contextStr.String
+	t.Estimate = time.Duration(estimateMins.Int64) * time.Minute
+	t.Pinned = pinnedInt.Int64 == 1
+	t.WaitingOn = waitingOn.String
 	t.CreatedAt = parseTimestamp(createdStr)
 	t.UpdatedAt = parseTimestamp(updatedStr)
 
@@ -414,7 +548,7 @@ func scanTodoRow(sc rowScanner) (Todo, error) {
 
 // List returns todos matching the given options.
 func (s *Store) List(opts ListOptions) ([]Todo, error) {
-	query := `SELECT id, title, body, priority, done, due_date, tags, project_path, schedule, recurrence, created_at, updated_at, completed_at FROM todos`
+	query := `SELECT ` + todoColumns + ` FROM todos`
 
 	var conditions []string
 	var args []any
@@ -428,7 +562,11 @@ func (s *Store) List(opts ListOptions) ([]Todo, error) {
 	}
 
 	if !opts.AllProjects {
-		if opts.ProjectPath != nil {
+		if opts.ProjectPaths != nil {
+			cond, pathArgs := projectPathsCondition(opts.ProjectPaths)
+			conditions = append(conditions, cond)
+			args = append(args, pathArgs...)
+		} else if opts.ProjectPath != nil {
 			// Show this project's todos plus global (null project_path) todos.
 			conditions = append(conditions, "(project_path = ? OR project_path IS NULL)")
 			args = append(args, *opts.ProjectPath)
@@ -438,6 +576,15 @@ func (s *Store) List(opts ListOptions) ([]Todo, error) {
 		}
 	}
 
+	if opts.Context != "" {
+		conditions = append(conditions, "context = ?")
+		args = append(args, opts.Context)
+	}
+
+	if opts.ExcludeWaiting {
+		conditions = append(conditions, "COALESCE(waiting_on, '') = ''")
+	}
+
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
@@ -461,6 +608,22 @@ func (s *Store) List(opts ListOptions) ([]Todo, error) {
 		}
 		todos = append(todos, t)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachBlockers(todos); err != nil {
+		return nil, err
+	}
+	if opts.ExcludeBlocked {
+		actionable := todos[:0]
+		for _, t := range todos {
+			if len(t.BlockedBy) == 0 {
+				actionable = append(actionable, t)
+			}
+		}
+		todos = actionable
+	}
 
 	// Apply urgency sort (default) in Go after fetching.
 	if opts.Sort == SortUrgency {
@@ -475,15 +638,22 @@ func (s *Store) List(opts ListOptions) ([]Todo, error) {
 		}
 		SortByUrgency(todos, ref, opts.CurrentProjectPath, *w)
 	}
+	pinFirst(todos)
 
 	return todos, nil
 }
 
+// overdueCondition matches todos past due: a date-only due date before today,
+// or a due time earlier today. Bind args: today, today, current "15:04" clock.
+const overdueCondition = `due_date IS NOT NULL AND (due_date < ? OR (due_date = ? AND due_time IS NOT NULL AND due_time < ?))`
+
 // Count returns the number of open and total todos, optionally scoped to a project.
 // projectPath nil returns counts across all todos (no project filter).
 // projectPath non-nil scopes to that project plus global (null project_path) todos.
 func (s *Store) Count(projectPath *string) (open int, total int, overdue int, err error) {
-	today := time.Now().Format("2006-01-02")
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	clock := now.Format("15:04")
 
 	if projectPath != nil {
 		p := *projectPath
@@ -500,8 +670,8 @@ func (s *Store) Count(projectPath *string) (open int, total int, overdue int, er
 			return
 		}
 		err = s.db.QueryRow(
-			`SELECT COUNT(*) FROM todos WHERE done = 0 AND due_date IS NOT NULL AND due_date < ? AND (project_path = ? OR project_path IS NULL)`,
-			today, p,
+			`SELECT COUNT(*) FROM todos WHERE done = 0 AND `+overdueCondition+` AND (project_path = ? OR project_path IS NULL)`,
+			today, today, clock, p,
 		).Scan(&overdue)
 		return
 	}
@@ -514,20 +684,81 @@ func (s *Store) Count(projectPath *string) (open int, total int, overdue int, er
 	if err != nil {
 		return
 	}
-	err = s.db.QueryRow(`SELECT COUNT(*) FROM todos WHERE done = 0 AND due_date IS NOT NULL AND due_date < ?`, today).Scan(&overdue)
+	err = s.db.QueryRow(`SELECT COUNT(*) FROM todos WHERE done = 0 AND `+overdueCondition, today, today, clock).Scan(&overdue)
 	return
 }
 
+// projectPathsCondition builds a WHERE clause matching todos in any of
+// paths. No paths matches nothing.
+func projectPathsCondition(paths []string) (string, []any) {
+	if len(paths) == 0 {
+		return "0", nil
+	}
+	args := make([]any, len(paths))
+	for i, p := range paths {
+		args[i] = p
+	}
+	return "project_path IN (" + strings.TrimSuffix(strings.Repeat("?,", len(paths)), ",") + ")", args
+}
+
+// OpenCountsByProject returns the number of open todos per project path.
+// Global todos are not counted.
+func (s *Store) OpenCountsByProject() (map[string]int, error) {
+	rows, err := s.db.Query(`SELECT project_path, COUNT(*) FROM todos WHERE done = 0 AND project_path IS NOT NULL GROUP BY project_path`)
+	if err != nil {
+		return nil, fmt.Errorf("count open todos: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var path string
+		var n int
+		if err := rows.Scan(&path, &n); err != nil {
+			return nil, err
+		}
+		counts[path] = n
+	}
+	return counts, rows.Err()
+}
+
+// MoveProject re-points every todo, live or archived, from oldPath to
+// newPath and returns how many were moved. Dig sessions and stats follow,
+// since they reach projects through their todos.
+func (s *Store) MoveProject(oldPath, newPath string) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	moved := 0
+	for _, table := range []string{"todos", "todos_archive"} {
+		res, err := tx.Exec(`UPDATE `+table+` SET project_path = ? WHERE project_path = ?`, newPath, oldPath)
+		if err != nil {
+			return 0, fmt.Errorf("move %s: %w", table, err)
+		}
+		n, _ := res.RowsAffected()
+		moved += int(n)
+	}
+	return moved, tx.Commit()
+}
+
 // Get returns a single todo by ID.
 func (s *Store) Get(id int) (*Todo, error) {
 	row := s.db.QueryRow(
-		`SELECT id, title, body, priority, done, due_date, tags, project_path, schedule, recurrence, created_at, updated_at, completed_at FROM todos WHERE id = ?`,
+		`SELECT `+todoColumns+` FROM todos WHERE id = ?`,
 		id,
 	)
 	t, err := scanTodoRow(row)
 	if err != nil {
 		return nil, fmt.Errorf("todo #%d not found", id)
 	}
+	blockers, err := s.openBlockers([]int{id})
+	if err != nil {
+		return nil, err
+	}
+	t.BlockedBy = blockers[id]
 	return &t, nil
 }
 
@@ -548,12 +779,34 @@ func (s *Store) Edit(id int, title *string, priority *int) error {
 		return nil
 	}
 
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return err
+	}
+
 	sets = append(sets, "updated_at = CURRENT_TIMESTAMP")
 	args = append(args, id)
 
 	query := fmt.Sprintf("UPDATE todos SET %s WHERE id = ?", strings.Join(sets, ", "))
-	_, err := s.db.Exec(query, args...)
-	return err
+	if _, err := s.db.Exec(query, args...); err != nil {
+		return err
+	}
+	return s.journal(HistoryEdit, id, snap)
+}
+
+// SetBody replaces a todo's body. An empty body clears it.
+func (s *Store) SetBody(id int, body string) error {
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(
+		`UPDATE todos SET body = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		body, id,
+	); err != nil {
+		return err
+	}
+	return s.journal(HistoryEdit, id, snap)
 }
 
 // AddNote appends a timestamped annotation to an existing todo.
@@ -676,13 +929,20 @@ func (s *Store) GetWithNotes(id int) (*Todo, error) {
 		return nil, err
 	}
 
+	if t.Links, err = s.LinksForTodo(id); err != nil {
+		return nil, err
+	}
+	if t.Attachments, err = s.Attachments(id); err != nil {
+		return nil, err
+	}
+
 	return t, nil
 }
 
 // ListRecurring returns all open todos that have a recurrence set (i.e. recurrence != 'none').
 func (s *Store) ListRecurring() ([]Todo, error) {
 	rows, err := s.db.Query(
-		`SELECT id, title, body, priority, done, due_date, tags, project_path, schedule, recurrence, created_at, updated_at, completed_at
+		`SELECT ` + todoColumns + `
 		 FROM todos WHERE done = 0 AND recurrence IS NOT NULL AND recurrence != 'none'
 		 ORDER BY created_at ASC`,
 	)
@@ -716,3 +976,17 @@ func (s *Store) DemoteProject(projectPath string) (int, error) {
 	n, _ := res.RowsAffected()
 	return int(n), nil
 }
+
+// IsOverdue reports whether an open todo is past due at now. Date-only due
+// dates become overdue the day after; due times become overdue the minute after.
+func (t Todo) IsOverdue(now time.Time) bool {
+	if t.DueDate == nil || t.Done {
+		return false
+	}
+	if t.DueHasTime {
+		return t.DueDate.Before(now)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dueDay := time.Date(t.DueDate.Year(), t.DueDate.Month(), t.DueDate.Day(), 0, 0, 0, 0, now.Location())
+	return dueDay.Before(today)
+}
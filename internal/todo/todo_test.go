@@ -26,13 +26,19 @@ func setupTestDB(t *testing.T) *sql.DB {
 		priority INTEGER DEFAULT 2,
 		done INTEGER DEFAULT 0,
 		due_date TEXT,
+		due_time TEXT,
 		tags TEXT DEFAULT '',
 		project_path TEXT,
 		schedule TEXT DEFAULT 'later',
 		recurrence TEXT DEFAULT 'none',
 		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-		completed_at DATETIME
+		completed_at DATETIME,
+		parent_id INTEGER REFERENCES todos(id) ON DELETE SET NULL,
+		context TEXT,
+		estimate_mins INTEGER,
+		pinned INTEGER DEFAULT 0,
+		waiting_on TEXT
 	)`)
 	if err != nil {
 		t.Fatal(err)
@@ -48,6 +54,129 @@ func setupTestDB(t *testing.T) *sql.DB {
 		t.Fatal(err)
 	}
 
+	_, err = db.Exec(`CREATE TABLE todo_deps (
+		todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		depends_on INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (todo_id, depends_on)
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todo_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		batch TEXT NOT NULL,
+		action TEXT NOT NULL,
+		todo_id INTEGER NOT NULL,
+		snapshot TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todos_archive (
+		id INTEGER PRIMARY KEY,
+		title TEXT NOT NULL,
+		body TEXT DEFAULT '',
+		priority INTEGER DEFAULT 2,
+		done INTEGER DEFAULT 1,
+		due_date TEXT,
+		due_time TEXT,
+		tags TEXT DEFAULT '',
+		project_path TEXT,
+		schedule TEXT DEFAULT 'later',
+		recurrence TEXT DEFAULT 'none',
+		created_at DATETIME,
+		updated_at DATETIME,
+		completed_at DATETIME,
+		parent_id INTEGER,
+		archived_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		context TEXT,
+		estimate_mins INTEGER,
+		pinned INTEGER DEFAULT 0,
+		waiting_on TEXT
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todo_notes_archive (
+		id INTEGER PRIMARY KEY,
+		todo_id INTEGER NOT NULL,
+		body TEXT NOT NULL,
+		created_at DATETIME
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todo_templates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+		items TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todo_attachments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		target TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todo_attachments_archive (
+		id INTEGER PRIMARY KEY,
+		todo_id INTEGER NOT NULL,
+		target TEXT NOT NULL,
+		created_at DATETIME
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todo_filters (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+		expr TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todo_reminders (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		todo_id INTEGER NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+		kind TEXT NOT NULL DEFAULT 'remind',
+		remind_at TEXT NOT NULL,
+		fired_at DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE todo_links (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		todo_id INTEGER REFERENCES todos(id) ON DELETE SET NULL,
+		provider TEXT NOT NULL,
+		external_id TEXT NOT NULL,
+		url TEXT DEFAULT '',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE(provider, external_id)
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	_, err = db.Exec(`CREATE TABLE dig_sessions (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		todo_id INTEGER REFERENCES todos(id) ON DELETE SET NULL,
@@ -189,6 +318,33 @@ func TestEdit(t *testing.T) {
 	}
 }
 
+func TestSetBody(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	id, _ := s.Add("Task", "old body", PrioLow, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	if err := s.SetBody(id, "line one\nline two"); err != nil {
+		t.Fatalf("SetBody failed: %v", err)
+	}
+	got, _ := s.Get(id)
+	if got.Body != "line one\nline two" {
+		t.Fatalf("body = %q", got.Body)
+	}
+
+	if _, err := s.Undo(); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	got, _ = s.Get(id)
+	if got.Body != "old body" {
+		t.Fatalf("body after undo = %q, want %q", got.Body, "old body")
+	}
+
+	if err := s.SetBody(999, "x"); err == nil {
+		t.Fatal("expected error for missing todo")
+	}
+}
+
 func TestPriorityLabel(t *testing.T) {
 	tests := []struct {
 		prio  int
@@ -268,6 +424,30 @@ func TestList_ProjectFilter(t *testing.T) {
 		}
 	})
 
+	t.Run("project_paths", func(t *testing.T) {
+		// ProjectPaths → only those projects, no global todos
+		todos, err := s.List(ListOptions{ProjectPaths: []string{projA, projB}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(todos) != 2 {
+			t.Fatalf("expected 2 project todos, got %d", len(todos))
+		}
+		for _, td := range todos {
+			if td.ProjectPath == nil {
+				t.Fatalf("global todo %q included", td.Title)
+			}
+		}
+
+		todos, err = s.List(ListOptions{ProjectPaths: []string{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(todos) != 0 {
+			t.Fatalf("expected no todos for empty paths, got %d", len(todos))
+		}
+	})
+
 	t.Run("all_projects", func(t *testing.T) {
 		// AllProjects → all 3
 		todos, err := s.List(ListOptions{AllProjects: true})
@@ -316,6 +496,59 @@ func TestCount_ProjectScoped(t *testing.T) {
 	}
 }
 
+func TestOpenCountsByProject(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	projA := "/projects/alpha"
+	s.Add("global task", "", PrioMedium, nil, nil, nil, ScheduleLater, RecurrenceNone)
+	s.Add("alpha one", "", PrioMedium, nil, nil, &projA, ScheduleLater, RecurrenceNone)
+	done, _ := s.Add("alpha done", "", PrioMedium, nil, nil, &projA, ScheduleLater, RecurrenceNone)
+	s.Add("alpha two", "", PrioMedium, nil, nil, &projA, ScheduleLater, RecurrenceNone)
+	s.Complete(done)
+
+	counts, err := s.OpenCountsByProject()
+	if err != nil {
+		t.Fatalf("OpenCountsByProject failed: %v", err)
+	}
+	if len(counts) != 1 || counts[projA] != 2 {
+		t.Fatalf("expected 2 open for alpha only, got %v", counts)
+	}
+}
+
+func TestMoveProject(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	s := NewStore(db)
+
+	oldPath, newPath, other := "/projects/alpha", "/work/alpha", "/projects/beta"
+	s.Add("alpha open", "", PrioMedium, nil, nil, &oldPath, ScheduleLater, RecurrenceNone)
+	done, _ := s.Add("alpha done", "", PrioMedium, nil, nil, &oldPath, ScheduleLater, RecurrenceNone)
+	s.Add("beta open", "", PrioMedium, nil, nil, &other, ScheduleLater, RecurrenceNone)
+	s.Complete(done)
+	if _, err := db.Exec(`INSERT INTO todos_archive (id, title, project_path) VALUES (99, 'archived alpha', ?)`, oldPath); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := s.MoveProject(oldPath, newPath)
+	if err != nil {
+		t.Fatalf("MoveProject failed: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 todos moved, got %d", n)
+	}
+	counts, _ := s.OpenCountsByProject()
+	if counts[newPath] != 1 || counts[oldPath] != 0 || counts[other] != 1 {
+		t.Fatalf("unexpected counts after move: %v", counts)
+	}
+	var archived string
+	db.QueryRow(`SELECT project_path FROM todos_archive WHERE id = 99`).Scan(&archived)
+	if archived != newPath {
+		t.Errorf("archived todo path = %q", archived)
+	}
+}
+
 func TestList_ShowDone_WithProject(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
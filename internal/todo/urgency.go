@@ -17,6 +17,10 @@ type UrgencyWeights struct {
 	PriorityLow   int // weight for low priority
 	AgeCap        int // maximum age bonus (days)
 	ProjectBoost  int // bonus when task belongs to the current project
+	ContextBoost  int // bonus when task matches ActiveContext
+	// ActiveContext is the GTD context the user is working in (e.g. "work").
+	// Empty disables the context boost.
+	ActiveContext string
 }
 
 // DefaultUrgencyWeights returns the default urgency scoring weights.
@@ -32,6 +36,7 @@ func DefaultUrgencyWeights() UrgencyWeights {
 		PriorityLow:   10,
 		AgeCap:        30,
 		ProjectBoost:  10,
+		ContextBoost:  15,
 	}
 }
 
@@ -42,12 +47,9 @@ func UrgencyScore(t Todo, now time.Time, currentProjectPath *string, w UrgencyWe
 	score := 0
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
-	// Overdue bonus: any task past its due date gets a large bonus.
-	if t.DueDate != nil {
-		dueDay := time.Date(t.DueDate.Year(), t.DueDate.Month(), t.DueDate.Day(), 0, 0, 0, 0, now.Location())
-		if dueDay.Before(today) {
-			score += w.Overdue
-		}
+	// Overdue bonus: any task past its due date (or due time) gets a large bonus.
+	if t.IsOverdue(now) {
+		score += w.Overdue
 	}
 
 	// Schedule weight.
@@ -88,6 +90,11 @@ func UrgencyScore(t Todo, now time.Time, currentProjectPath *string, w UrgencyWe
 		score += w.ProjectBoost
 	}
 
+	// Context boost: bonus if task matches the active GTD context.
+	if w.ActiveContext != "" && t.Context == w.ActiveContext {
+		score += w.ContextBoost
+	}
+
 	return score
 }
 
@@ -346,3 +346,21 @@ func TestUrgencyScore_FutureDueDate_NoOverdueBonus(t *testing.T) {
 		t.Errorf("future due date should not trigger overdue bonus: expected %d, got %d", expected, score)
 	}
 }
+
+func TestUrgencyScore_ContextBoost(t *testing.T) {
+	w := defaultWeights()
+
+	atWork := Todo{Priority: PrioMedium, Schedule: ScheduleLater, CreatedAt: baseTime, Context: "work"}
+	atHome := Todo{Priority: PrioMedium, Schedule: ScheduleLater, CreatedAt: baseTime, Context: "home"}
+
+	// No active context: no boost.
+	if UrgencyScore(atWork, baseTime, nil, w) != UrgencyScore(atHome, baseTime, nil, w) {
+		t.Error("without an active context, scores should be equal")
+	}
+
+	w.ActiveContext = "work"
+	diff := UrgencyScore(atWork, baseTime, nil, w) - UrgencyScore(atHome, baseTime, nil, w)
+	if diff != w.ContextBoost {
+		t.Errorf("expected context boost diff %d, got %d", w.ContextBoost, diff)
+	}
+}
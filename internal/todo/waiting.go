@@ -0,0 +1,71 @@
+package todo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseWaitingOn normalizes a delegation target such as "@alice" to
+// "alice". "none" (or "") clears it. Names may contain spaces but not commas.
+func ParseWaitingOn(s string) (string, error) {
+	who := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "@"))
+	if strings.EqualFold(who, "none") {
+		return "", nil
+	}
+	if strings.Contains(who, ",") {
+		return "", fmt.Errorf("invalid person %q — name one person, e.g. alice", s)
+	}
+	return who, nil
+}
+
+// SetWaitingOn marks a todo as delegated to who, or with "" as yours again.
+func (s *Store) SetWaitingOn(id int, who string) error {
+	who, err := ParseWaitingOn(who)
+	if err != nil {
+		return err
+	}
+	snap, err := s.snapshot(id, false)
+	if err != nil {
+		return err
+	}
+	var val any
+	if who != "" {
+		val = who
+	}
+	if _, err := s.db.Exec(
+		`UPDATE todos SET waiting_on = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		val, id,
+	); err != nil {
+		return err
+	}
+	return s.journal(HistoryEdit, id, snap)
+}
+
+// Waiting returns open todos delegated to someone, across all projects,
+// ordered by person and then age. A non-empty person narrows the result
+// to that person (case-insensitive).
+func (s *Store) Waiting(person string) ([]Todo, error) {
+	query := `SELECT ` + todoColumns + ` FROM todos WHERE done = 0 AND COALESCE(waiting_on, '') != ''`
+	var args []any
+	if person = strings.TrimSpace(strings.TrimPrefix(person, "@")); person != "" {
+		query += ` AND waiting_on = ? COLLATE NOCASE`
+		args = append(args, person)
+	}
+	query += ` ORDER BY waiting_on COLLATE NOCASE, created_at ASC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var todos []Todo
+	for rows.Next() {
+		t, err := scanTodoRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, t)
+	}
+	return todos, rows.Err()
+}
@@ -0,0 +1,76 @@
+package todo
+
+import "testing"
+
+func TestParseWaitingOn(t *testing.T) {
+	cases := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"alice", "alice", false},
+		{"@bob", "bob", false},
+		{"  Carol Smith ", "Carol Smith", false},
+		{"none", "", false},
+		{"", "", false},
+		{"alice,bob", "", true},
+	}
+	for _, c := range cases {
+		got, err := ParseWaitingOn(c.in)
+		if (err != nil) != c.wantErr || got != c.want {
+			t.Errorf("ParseWaitingOn(%q) = (%q, %v), want (%q, err=%v)", c.in, got, err, c.want, c.wantErr)
+		}
+	}
+}
+
+func TestWaiting_GroupsAndExcludesFromNext(t *testing.T) {
+	db := setupTestDB(t)
+	s := NewStore(db)
+
+	mine, _ := s.Add("mine", "", PrioMedium, nil, nil, nil, ScheduleToday, RecurrenceNone)
+	bob, _ := s.Add("bob's", "", PrioMedium, nil, nil, nil, ScheduleToday, RecurrenceNone)
+	alice, _ := s.Add("alice's", "", PrioMedium, nil, nil, nil, ScheduleToday, RecurrenceNone)
+	done, _ := s.Add("done", "", PrioMedium, nil, nil, nil, ScheduleToday, RecurrenceNone)
+	for id, who := range map[int]string{bob: "bob", alice: "@Alice", done: "alice"} {
+		if err := s.SetWaitingOn(id, who); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Complete(done)
+
+	all, err := s.Waiting("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].ID != alice || all[1].ID != bob {
+		t.Fatalf("Waiting() = %+v, want alice's then bob's", all)
+	}
+	if all[0].WaitingOn != "Alice" {
+		t.Errorf("WaitingOn = %q, want Alice", all[0].WaitingOn)
+	}
+
+	only, _ := s.Waiting("alice")
+	if len(only) != 1 || only[0].ID != alice {
+		t.Errorf("Waiting(alice) = %+v, want only #%d", only, alice)
+	}
+
+	open, err := s.List(ListOptions{ExcludeWaiting: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 1 || open[0].ID != mine {
+		t.Errorf("ExcludeWaiting list = %+v, want only #%d", open, mine)
+	}
+
+	if err := s.SetWaitingOn(bob, "none"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get(bob); got.WaitingOn != "" {
+		t.Errorf("WaitingOn after clear = %q", got.WaitingOn)
+	}
+	if _, err := s.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.Get(bob); got.WaitingOn != "bob" {
+		t.Errorf("WaitingOn after undo = %q, want bob", got.WaitingOn)
+	}
+}
@@ -0,0 +1,258 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+)
+
+// BoardColumns are the schedule buckets shown on the board, left to right.
+var BoardColumns = []string{todo.ScheduleToday, todo.ScheduleSoon, todo.ScheduleLater, todo.ScheduleSomeday}
+
+// boardMinColWidth keeps columns readable on narrow terminals.
+const boardMinColWidth = 16
+
+// BoardModel is a kanban board of open todos with one column per schedule
+// bucket. Moving a card between columns reschedules it.
+type BoardModel struct {
+	columns [][]todo.Todo
+	col     int   // focused column
+	rows    []int // cursor row per column
+
+	width  int
+	height int
+
+	// pending actions to apply after quitting
+	Actions []TodoAction
+
+	quitting bool
+}
+
+// NewBoardModel buckets open todos into board columns, keeping their order.
+// Done todos are left off the board.
+func NewBoardModel(todos []todo.Todo) *BoardModel {
+	m := &BoardModel{
+		columns: make([][]todo.Todo, len(BoardColumns)),
+		rows:    make([]int, len(BoardColumns)),
+		width:   100,
+		height:  24,
+	}
+	for _, t := range todos {
+		if t.Done {
+			continue
+		}
+		i := boardColumn(t.Schedule)
+		m.columns[i] = append(m.columns[i], t)
+	}
+	return m
+}
+
+// RunBoard launches the board TUI. Returns actions for the caller to apply.
+func RunBoard(todos []todo.Todo) ([]TodoAction, error) {
+	m := NewBoardModel(todos)
+	prog := tea.NewProgram(m, tea.WithAltScreen())
+	result, err := prog.Run()
+	if err != nil {
+		return nil, fmt.Errorf("board tui: %w", err)
+	}
+	return result.(*BoardModel).Actions, nil
+}
+
+// boardColumn maps a schedule to its column; unknown schedules land in later.
+func boardColumn(schedule string) int {
+	for i, s := range BoardColumns {
+		if s == schedule {
+			return i
+		}
+	}
+	return boardColumn(todo.ScheduleLater)
+}
+
+func (m *BoardModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *BoardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *BoardModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		m.quitting = true
+		return m, tea.Quit
+
+	case "left", "shift+tab":
+		if m.col > 0 {
+			m.col--
+		}
+
+	case "right", "tab":
+		if m.col < len(m.columns)-1 {
+			m.col++
+		}
+
+	case "j", "down":
+		if m.rows[m.col] < len(m.columns[m.col])-1 {
+			m.rows[m.col]++
+		}
+
+	case "k", "up":
+		if m.rows[m.col] > 0 {
+			m.rows[m.col]--
+		}
+
+	case "h":
+		m.moveCard(-1)
+
+	case "l":
+		m.moveCard(1)
+
+	case "x", " ", "enter":
+		t, ok := m.selected()
+		if !ok {
+			break
+		}
+		m.Actions = append(m.Actions, TodoAction{Type: "toggle", ID: t.ID})
+		m.removeSelected()
+	}
+	return m, nil
+}
+
+// selected returns the card under the cursor in the focused column.
+func (m *BoardModel) selected() (todo.Todo, bool) {
+	cards := m.columns[m.col]
+	if len(cards) == 0 {
+		return todo.Todo{}, false
+	}
+	return cards[m.rows[m.col]], true
+}
+
+// removeSelected drops the selected card and keeps the cursor in range.
+func (m *BoardModel) removeSelected() todo.Todo {
+	cards := m.columns[m.col]
+	row := m.rows[m.col]
+	t := cards[row]
+	m.columns[m.col] = append(cards[:row:row], cards[row+1:]...)
+	if m.rows[m.col] >= len(m.columns[m.col]) && m.rows[m.col] > 0 {
+		m.rows[m.col]--
+	}
+	return t
+}
+
+// moveCard moves the selected card one column left (-1) or right (+1),
+// rescheduling it, and follows it with the focus.
+func (m *BoardModel) moveCard(dir int) {
+	target := m.col + dir
+	if target < 0 || target >= len(m.columns) {
+		return
+	}
+	if _, ok := m.selected(); !ok {
+		return
+	}
+	t := m.removeSelected()
+	t.Schedule = BoardColumns[target]
+	m.columns[target] = append([]todo.Todo{t}, m.columns[target]...)
+	m.col = target
+	m.rows[target] = 0
+	m.Actions = append(m.Actions, TodoAction{Type: "schedule", ID: t.ID, Schedule: t.Schedule})
+}
+
+func (m *BoardModel) View() string {
+	var b strings.Builder
+
+	open := 0
+	for _, cards := range m.columns {
+		open += len(cards)
+	}
+	b.WriteString("  " + ui.Title.Render(ui.IconTodo+" Board") + ui.Muted.Render(fmt.Sprintf("  %d open", open)) + "\n\n")
+
+	colW := (m.width-2)/len(m.columns) - 1
+	if colW < boardMinColWidth {
+		colW = boardMinColWidth
+	}
+	visHeight := m.height - 8 // header, column titles, status, help
+	if visHeight < 3 {
+		visHeight = 3
+	}
+
+	cols := make([]string, len(m.columns))
+	for i := range m.columns {
+		cols[i] = m.renderColumn(i, colW, visHeight)
+	}
+	b.WriteString(lipgloss.NewStyle().MarginLeft(2).Render(lipgloss.JoinHorizontal(lipgloss.Top, cols...)) + "\n\n")
+
+	b.WriteString(ui.Muted.Render("  ←/→ column · j/k select · h/l move card · x done · q quit") + "\n")
+	return b.String()
+}
+
+func (m *BoardModel) renderColumn(i, width, visHeight int) string {
+	cards := m.columns[i]
+	focused := i == m.col
+
+	heading := fmt.Sprintf("%s (%d)", strings.ToUpper(todo.ScheduleLabel(BoardColumns[i])), len(cards))
+	headStyle := ui.Muted
+	if focused {
+		headStyle = ui.Accent
+	}
+	lines := []string{headStyle.Render(truncateWidth(heading, width-1)), ""}
+
+	if len(cards) == 0 {
+		lines = append(lines, ui.Muted.Render("—"))
+	}
+
+	offset := 0
+	if m.rows[i] >= visHeight {
+		offset = m.rows[i] - visHeight + 1
+	}
+	end := offset + visHeight
+	if end > len(cards) {
+		end = len(cards)
+	}
+	for r := offset; r < end; r++ {
+		lines = append(lines, m.renderCard(cards[r], focused && r == m.rows[i], width-1))
+	}
+	if end < len(cards) {
+		lines = append(lines, ui.Muted.Render(fmt.Sprintf("…%d more", len(cards)-end)))
+	}
+
+	return lipgloss.NewStyle().Width(width).MarginRight(1).Render(strings.Join(lines, "\n"))
+}
+
+func (m *BoardModel) renderCard(t todo.Todo, selected bool, width int) string {
+	id := fmt.Sprintf("#%d", t.ID)
+	prio := " " + todo.PriorityIcon(t.Priority) + " "
+	title := truncateWidth(t.Title, width-lipgloss.Width(id+prio))
+	if selected {
+		return lipgloss.NewStyle().Foreground(ui.Gold).Bold(true).Render(id + prio + title)
+	}
+	return ui.Muted.Render(id) + prio + title
+}
+
+// truncateWidth shortens s to at most width display cells, adding "…".
+func truncateWidth(s string, width int) string {
+	if width < 1 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func boardKey(m *BoardModel, keys ...string) {
+	for _, k := range keys {
+		switch k {
+		case "right":
+			m.Update(tea.KeyMsg{Type: tea.KeyRight})
+		case "left":
+			m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+		default:
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+}
+
+func boardTodos() []todo.Todo {
+	todos := makeTodos("ship release", "write notes", "plan offsite", "learn rust", "old done")
+	todos[0].Schedule = todo.ScheduleToday
+	todos[1].Schedule = todo.ScheduleToday
+	todos[2].Schedule = todo.ScheduleSoon
+	todos[3].Schedule = todo.ScheduleSomeday
+	todos[4].Schedule = todo.ScheduleToday
+	todos[4].Done = true
+	return todos
+}
+
+func TestNewBoardModel_BucketsOpenTodos(t *testing.T) {
+	m := NewBoardModel(boardTodos())
+
+	want := []int{2, 1, 0, 1}
+	for i, n := range want {
+		if len(m.columns[i]) != n {
+			t.Errorf("column %s has %d cards, want %d", BoardColumns[i], len(m.columns[i]), n)
+		}
+	}
+}
+
+func TestBoardModel_MoveCard(t *testing.T) {
+	m := NewBoardModel(boardTodos())
+
+	// Select "write notes" in today and move it right to soon.
+	boardKey(m, "j", "l")
+	if m.col != 1 || m.columns[1][0].ID != 2 || m.rows[1] != 0 {
+		t.Fatalf("expected #2 at top of soon with focus, got col %d: %+v", m.col, m.columns[1])
+	}
+	if len(m.columns[0]) != 1 || m.rows[0] != 0 {
+		t.Errorf("today should have 1 card with cursor clamped, got %d (row %d)", len(m.columns[0]), m.rows[0])
+	}
+	if len(m.Actions) != 1 || m.Actions[0] != (TodoAction{Type: "schedule", ID: 2, Schedule: todo.ScheduleSoon}) {
+		t.Errorf("unexpected actions: %+v", m.Actions)
+	}
+
+	// Can't move past the first column.
+	boardKey(m, "h", "h")
+	if m.col != 0 || len(m.Actions) != 2 {
+		t.Errorf("expected card back in today and left edge ignored, col %d, actions %+v", m.col, m.Actions)
+	}
+}
+
+func TestBoardModel_CompleteAndNavigateColumns(t *testing.T) {
+	m := NewBoardModel(boardTodos())
+
+	boardKey(m, "right", "right", "x") // later is empty: nothing to complete
+	if len(m.Actions) != 0 {
+		t.Fatalf("completing in an empty column should be a no-op, got %+v", m.Actions)
+	}
+
+	boardKey(m, "left", "x")
+	if len(m.columns[1]) != 0 || len(m.Actions) != 1 || m.Actions[0].Type != "toggle" || m.Actions[0].ID != 3 {
+		t.Errorf("expected #3 completed and removed, got %+v / %+v", m.columns[1], m.Actions)
+	}
+
+	boardKey(m, "right", "right", "right")
+	if m.col != len(BoardColumns)-1 {
+		t.Errorf("focus should clamp at the last column, got %d", m.col)
+	}
+}
+
+func TestBoardModel_View(t *testing.T) {
+	m := NewBoardModel(boardTodos())
+	m.width, m.height = 120, 30
+
+	out := m.View()
+	for _, want := range []string{"Board", "TODAY (2)", "SOON (1)", "LATER (0)", "SOMEDAY (1)", "ship release", "h/l move card"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in view:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "old done") {
+		t.Errorf("done todos should not be on the board:\n%s", out)
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	if got := truncateWidth("hello world", 6); got != "hello…" {
+		t.Errorf("truncateWidth = %q, want %q", got, "hello…")
+	}
+	if got := truncateWidth("short", 10); got != "short" {
+		t.Errorf("truncateWidth = %q, want unchanged", got)
+	}
+}
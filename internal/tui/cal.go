@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+)
+
+// calCellWidth fits a day number plus a "•NN" task count.
+const calCellWidth = 6
+
+// CalDays groups open todos with a due date by calendar day ("2006-01-02").
+func CalDays(todos []todo.Todo) map[string][]todo.Todo {
+	days := map[string][]todo.Todo{}
+	for _, t := range todos {
+		if t.Done || t.DueDate == nil {
+			continue
+		}
+		key := t.DueDate.Format("2006-01-02")
+		days[key] = append(days[key], t)
+	}
+	return days
+}
+
+// RenderCalendar draws a Monday-first month grid with the number of open
+// todos due each day. Days with overdue todos are red, today is gold, and
+// upcoming days with work are green. A non-zero selected day is highlighted.
+func RenderCalendar(month time.Time, days map[string][]todo.Todo, now time.Time, selected int) string {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	cell := lipgloss.NewStyle().Width(calCellWidth)
+
+	var b strings.Builder
+	b.WriteString("  " + ui.Title.Render(first.Format("January 2006")) + "\n")
+	var head []string
+	for _, d := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		head = append(head, cell.Render(ui.Muted.Render(d)))
+	}
+	b.WriteString("  " + strings.Join(head, "") + "\n")
+
+	// Monday-first offset of the 1st.
+	lead := (int(first.Weekday()) + 6) % 7
+	row := make([]string, 0, 7)
+	for i := 0; i < lead; i++ {
+		row = append(row, cell.Render(""))
+	}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		n := len(days[day.Format("2006-01-02")])
+		label := fmt.Sprintf("%2d", day.Day())
+		if n > 0 {
+			label += fmt.Sprintf("•%d", n)
+		}
+
+		style := lipgloss.NewStyle()
+		switch {
+		case n > 0 && day.Before(today):
+			style = ui.Error
+		case day.Equal(today):
+			style = ui.Accent
+		case n > 0:
+			style = ui.Success
+		case day.Weekday() == time.Saturday || day.Weekday() == time.Sunday:
+			style = ui.Muted
+		}
+		if day.Day() == selected {
+			style = style.Reverse(true)
+		}
+		row = append(row, cell.Render(style.Render(label)))
+
+		if len(row) == 7 {
+			b.WriteString("  " + strings.Join(row, "") + "\n")
+			row = row[:0]
+		}
+	}
+	if len(row) > 0 {
+		b.WriteString("  " + strings.Join(row, "") + "\n")
+	}
+	return b.String()
+}
+
+// CalModel is an interactive month calendar: arrow keys move between days
+// and the todos due on the selected day are listed under the grid.
+type CalModel struct {
+	days     map[string][]todo.Todo
+	selected time.Time
+	now      time.Time
+
+	width  int
+	height int
+}
+
+// NewCalModel opens the calendar on month, selecting today when it falls in
+// that month and the 1st otherwise.
+func NewCalModel(todos []todo.Todo, month, now time.Time) *CalModel {
+	sel := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, now.Location())
+	if now.Year() == month.Year() && now.Month() == month.Month() {
+		sel = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+	return &CalModel{
+		days:     CalDays(todos),
+		selected: sel,
+		now:      now,
+		width:    80,
+		height:   24,
+	}
+}
+
+// RunCal launches the interactive calendar.
+func RunCal(todos []todo.Todo, month time.Time) error {
+	prog := tea.NewProgram(NewCalModel(todos, month, time.Now()), tea.WithAltScreen())
+	if _, err := prog.Run(); err != nil {
+		return fmt.Errorf("calendar tui: %w", err)
+	}
+	return nil
+}
+
+func (m *CalModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *CalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "left", "h":
+			m.selected = m.selected.AddDate(0, 0, -1)
+		case "right", "l":
+			m.selected = m.selected.AddDate(0, 0, 1)
+		case "up", "k":
+			m.selected = m.selected.AddDate(0, 0, -7)
+		case "down", "j":
+			m.selected = m.selected.AddDate(0, 0, 7)
+		case "p", "[":
+			m.selected = m.selected.AddDate(0, -1, 0)
+		case "n", "]":
+			m.selected = m.selected.AddDate(0, 1, 0)
+		case "t":
+			m.selected = time.Date(m.now.Year(), m.now.Month(), m.now.Day(), 0, 0, 0, 0, m.now.Location())
+		}
+	}
+	return m, nil
+}
+
+func (m *CalModel) View() string {
+	var b strings.Builder
+	b.WriteString("\n" + RenderCalendar(m.selected, m.days, m.now, m.selected.Day()) + "\n")
+
+	due := m.days[m.selected.Format("2006-01-02")]
+	b.WriteString("  " + ui.Accent.Render(m.selected.Format("Mon, Jan 2")) + ui.Muted.Render(fmt.Sprintf("  %d due", len(due))) + "\n")
+
+	// Rows left for the day's list after the grid, heading, and help line.
+	room := m.height - 16
+	if room < 3 {
+		room = 3
+	}
+	for i, t := range due {
+		if i == room {
+			b.WriteString(ui.Muted.Render(fmt.Sprintf("    …and %d more", len(due)-i)) + "\n")
+			break
+		}
+		b.WriteString(RenderCalTodo(t, m.width) + "\n")
+	}
+	if len(due) == 0 {
+		b.WriteString("    " + ui.Muted.Render("Nothing due.") + "\n")
+	}
+
+	b.WriteString("\n" + ui.Muted.Render("  ←/→ day · ↑/↓ week · p/n month · t today · q quit") + "\n")
+	return b.String()
+}
+
+// RenderCalTodo renders one todo in a day's task list.
+func RenderCalTodo(t todo.Todo, width int) string {
+	id := lipgloss.NewStyle().Width(todo.ColWidthID).Render(ui.Muted.Render(fmt.Sprintf("#%d", t.ID)))
+	line := fmt.Sprintf("    %s %s %s", id, todo.FormatPriorityIcon(t.Priority), truncateWidth(t.Title, width-16))
+	if t.DueHasTime {
+		line += ui.Muted.Render(" " + t.DueDate.Format("3:04pm"))
+	}
+	return line
+}
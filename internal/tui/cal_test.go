@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+func calTodos() []todo.Todo {
+	todos := makeTodos("pay rent", "file taxes", "book flights", "done already")
+	due := func(d int) *time.Time {
+		t := time.Date(2026, 3, d, 0, 0, 0, 0, time.Local)
+		return &t
+	}
+	todos[0].DueDate = due(2)
+	todos[1].DueDate = due(2)
+	todos[2].DueDate = due(20)
+	todos[3].DueDate = due(20)
+	todos[3].Done = true
+	return todos
+}
+
+func TestCalDays(t *testing.T) {
+	days := CalDays(calTodos())
+	if len(days["2026-03-02"]) != 2 || len(days["2026-03-20"]) != 1 {
+		t.Errorf("unexpected grouping: %v", days)
+	}
+}
+
+func TestRenderCalendar(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	out := RenderCalendar(now, CalDays(calTodos()), now, 0)
+
+	for _, want := range []string{"March 2026", "Mo", "Su", " 2•2", "20•1", "31"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in calendar:\n%s", want, out)
+		}
+	}
+	// March 1st 2026 is a Sunday: six leading blanks in a Monday-first grid.
+	lines := strings.Split(out, "\n")
+	if !strings.HasPrefix(lines[2], strings.Repeat(" ", 2+6*calCellWidth)) || !strings.Contains(lines[2], " 1") {
+		t.Errorf("expected the 1st alone at the end of the first week, got %q", lines[2])
+	}
+}
+
+func TestCalModel_Navigation(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	m := NewCalModel(calTodos(), time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), now)
+	if m.selected.Day() != 10 {
+		t.Fatalf("expected today selected, got %v", m.selected)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if got := m.selected.Format("2006-01-02"); got != "2026-03-04" {
+		t.Errorf("selected = %s, want 2026-03-04", got)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if !strings.Contains(m.View(), "pay rent") || !strings.Contains(m.View(), "2 due") {
+		t.Errorf("expected Mar 2 tasks in view:\n%s", m.View())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if m.selected.Month() != time.April || !strings.Contains(m.View(), "Nothing due") {
+		t.Errorf("expected April with nothing due, got %v", m.selected)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if !m.selected.Equal(time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("t should jump back to today, got %v", m.selected)
+	}
+}
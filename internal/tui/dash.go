@@ -210,7 +210,6 @@ func renderTodosPanel(todos []todo.Todo, open, overdue, width int) string {
 	b.WriteString("  " + ui.Title.Render(ui.IconTodo+" Todos") + ui.Muted.Render(countStr) + "\n\n")
 
 	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
 	shown := todos
 	if len(shown) > 5 {
@@ -221,7 +220,7 @@ func renderTodosPanel(todos []todo.Todo, open, overdue, width int) string {
 		b.WriteString("  " + ui.Muted.Render("All clear! Press 't' to add a task.") + "\n")
 	} else {
 		for _, t := range shown {
-			b.WriteString(renderDashTodoItem(t, today, width) + "\n")
+			b.WriteString(renderDashTodoItem(t, now, width) + "\n")
 		}
 	}
 
@@ -233,7 +232,7 @@ func renderTodosPanel(todos []todo.Todo, open, overdue, width int) string {
 }
 
 // renderDashTodoItem renders a single read-only todo row for the dashboard.
-func renderDashTodoItem(t todo.Todo, today time.Time, width int) string {
+func renderDashTodoItem(t todo.Todo, now time.Time, width int) string {
 	id := lipgloss.NewStyle().Width(todo.ColWidthID).Render(ui.Muted.Render(fmt.Sprintf("#%d", t.ID)))
 	prio := todo.FormatPriorityIcon(t.Priority)
 	sched := todo.FormatScheduleTag(t.Schedule)
@@ -259,16 +258,18 @@ func renderDashTodoItem(t todo.Todo, today time.Time, width int) string {
 	line := fmt.Sprintf("  %s %s %s %s", id, prio, sched, title)
 
 	if t.DueDate != nil && !t.Done {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 		due := *t.DueDate
-		dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
+		dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, now.Location())
 		switch {
-		case dueDay.Before(today):
-			line += ui.Error.Render(fmt.Sprintf(" (overdue: %s)", due.Format("Jan 2")))
+		case t.IsOverdue(now):
+			line += ui.Error.Render(fmt.Sprintf(" (overdue: %s)", todo.DueLabel(t, "Jan 2")))
+		case dueDay.Equal(today) && t.DueHasTime:
+			line += ui.Warning.Render(fmt.Sprintf(" (due %s!)", due.Format("3:04pm")))
 		case dueDay.Equal(today):
 			line += ui.Warning.Render(" (due today!)")
 		}
 	}
-
 	return line
 }
 
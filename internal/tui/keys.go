@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TodoKeyActions are the remappable actions of the todo browser, in the
+// order they appear in the help footer. Ctrl+C and Esc are fixed.
+var TodoKeyActions = []string{
+	"down", "up", "top", "bottom",
+	"toggle", "details", "note", "schedule", "pin",
+	"mark", "markall", "tag", "sort", "group",
+	"add", "delete", "undo", "filter", "quit",
+}
+
+// DefaultTodoKeys are the built-in bindings per action.
+var DefaultTodoKeys = map[string][]string{
+	"down":     {"j", "down"},
+	"up":       {"k", "up"},
+	"top":      {"g"},
+	"bottom":   {"G"},
+	"toggle":   {"x", " "},
+	"details":  {"enter"},
+	"note":     {"n"},
+	"schedule": {"s"},
+	"pin":      {"p"},
+	"mark":     {"v"},
+	"markall":  {"V"},
+	"tag":      {"t"},
+	"sort":     {"o"},
+	"group":    {"O"},
+	"add":      {"a"},
+	"delete":   {"d"},
+	"undo":     {"u"},
+	"filter":   {"/"},
+	"quit":     {"q"},
+}
+
+// todoKeyHelp labels the actions shown in the help footer. Movement is
+// rendered separately as "down/up move"; top, bottom, and markall are
+// left out.
+var todoKeyHelp = map[string]string{
+	"toggle":   "toggle",
+	"details":  "details",
+	"note":     "note",
+	"schedule": "schedule",
+	"pin":      "pin",
+	"mark":     "mark",
+	"tag":      "tag",
+	"sort":     "sort",
+	"group":    "group",
+	"add":      "add",
+	"delete":   "delete",
+	"undo":     "undo",
+	"filter":   "filter",
+	"quit":     "quit",
+}
+
+// TodoKeyMap maps key sequences to todo browser actions. A sequence is a
+// single key as Bubble Tea names it ("x", "ctrl+d", "enter", " ") or a run
+// of plain characters typed in order ("dd", "gg").
+type TodoKeyMap struct {
+	bindings map[string][]string // action -> key sequences
+	lookup   map[string]string   // key sequence -> action
+}
+
+// ParseTodoKeys builds a key map from [tui.keys] overrides. Each value is a
+// comma-separated list of keys that replaces the action's defaults, e.g.
+// delete = "dd" or schedule = "s, b". Actions not mentioned keep their
+// defaults.
+func ParseTodoKeys(overrides map[string]string) (*TodoKeyMap, error) {
+	bindings := map[string][]string{}
+	for action, keys := range DefaultTodoKeys {
+		bindings[action] = keys
+	}
+
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		if _, ok := DefaultTodoKeys[action]; !ok {
+			return nil, fmt.Errorf("unknown action %q in [tui.keys] (valid: %s)", action, strings.Join(TodoKeyActions, ", "))
+		}
+		var keys []string
+		for _, k := range strings.Split(overrides[action], ",") {
+			k = strings.TrimSpace(k)
+			if k == "space" {
+				k = " "
+			}
+			if k == "" {
+				continue
+			}
+			keys = append(keys, k)
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("no keys given for %q in [tui.keys]", action)
+		}
+		bindings[action] = keys
+	}
+
+	km := &TodoKeyMap{bindings: bindings, lookup: map[string]string{}}
+	for _, action := range TodoKeyActions {
+		for _, k := range bindings[action] {
+			if other, ok := km.lookup[k]; ok {
+				return nil, fmt.Errorf("key %q is bound to both %q and %q in [tui.keys]", keyLabel(k), other, action)
+			}
+			km.lookup[k] = action
+		}
+	}
+	return km, nil
+}
+
+// defaultTodoKeyMap is the key map used when no overrides are configured.
+func defaultTodoKeyMap() *TodoKeyMap {
+	km, _ := ParseTodoKeys(nil)
+	return km
+}
+
+// Resolve matches a key sequence. It returns the bound action, or
+// prefix=true when seq starts a longer binding and more keys are needed.
+func (km *TodoKeyMap) Resolve(seq string) (action string, prefix bool) {
+	if a, ok := km.lookup[seq]; ok {
+		return a, false
+	}
+	for k := range km.lookup {
+		if len(k) > len(seq) && strings.HasPrefix(k, seq) && isSequence(k) {
+			return "", true
+		}
+	}
+	return "", false
+}
+
+// Help renders the footer hints, e.g. "j/k move · x toggle · ... · q quit".
+func (km *TodoKeyMap) Help() string {
+	parts := []string{keyLabel(km.bindings["down"][0]) + "/" + keyLabel(km.bindings["up"][0]) + " move"}
+	for _, action := range TodoKeyActions {
+		label, ok := todoKeyHelp[action]
+		if !ok {
+			continue
+		}
+		if action == "quit" {
+			parts = append(parts, "esc clear filter")
+		}
+		parts = append(parts, keyLabel(km.bindings[action][0])+" "+label)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// isSequence reports whether k is a run of plain characters rather than a
+// named key like "ctrl+d" or "enter".
+func isSequence(k string) bool {
+	if len([]rune(k)) < 2 {
+		return false
+	}
+	if strings.Contains(k, "+") {
+		return false
+	}
+	switch k {
+	case "enter", "tab", "esc", "backspace", "delete", "up", "down", "left", "right",
+		"home", "end", "pgup", "pgdown", "insert", "shift+tab":
+		return false
+	}
+	return true
+}
+
+func keyLabel(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
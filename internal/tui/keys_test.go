@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseTodoKeys_Defaults(t *testing.T) {
+	km, err := ParseTodoKeys(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{"j": "down", "down": "down", " ": "toggle", "enter": "details", "q": "quit"} {
+		if got, _ := km.Resolve(key); got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", key, got, want)
+		}
+	}
+	want := "j/k move · x toggle · enter details · n note · s schedule · p pin · v mark · t tag · o sort · O group · a add · d delete · u undo · / filter · esc clear filter · q quit"
+	if got := km.Help(); got != want {
+		t.Errorf("Help() =\n  %q\nwant\n  %q", got, want)
+	}
+}
+
+func TestParseTodoKeys_Overrides(t *testing.T) {
+	km, err := ParseTodoKeys(map[string]string{"delete": "dd", "schedule": "b, ctrl+s", "toggle": "space"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, prefix := km.Resolve("d"); a != "" || !prefix {
+		t.Errorf(`Resolve("d") = %q, %v; want prefix of "dd"`, a, prefix)
+	}
+	if a, _ := km.Resolve("dd"); a != "delete" {
+		t.Errorf(`Resolve("dd") = %q, want delete`, a)
+	}
+	if a, _ := km.Resolve("ctrl+s"); a != "schedule" {
+		t.Errorf(`Resolve("ctrl+s") = %q, want schedule`, a)
+	}
+	if a, _ := km.Resolve("x"); a != "" {
+		t.Errorf(`Resolve("x") = %q, want unbound after override`, a)
+	}
+	help := km.Help()
+	for _, want := range []string{"space toggle", "b schedule", "dd delete"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected %q in help %q", want, help)
+		}
+	}
+}
+
+func TestParseTodoKeys_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		want      string
+	}{
+		{"unknown action", map[string]string{"explode": "e"}, `unknown action "explode"`},
+		{"empty keys", map[string]string{"pin": " , "}, `no keys given for "pin"`},
+		{"conflict", map[string]string{"pin": "a"}, `key "a" is bound to both "pin" and "add"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTodoKeys(tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTodoModel_CustomKeySequence(t *testing.T) {
+	km, err := ParseTodoKeys(map[string]string{"delete": "dd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewTodoModel(makeTodos("one", "two"), WithKeys(km))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if len(m.Actions) != 0 {
+		t.Fatalf("a single d should wait for the sequence, got %+v", m.Actions)
+	}
+	// A key that breaks the sequence is handled on its own.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.cursor != 1 {
+		t.Fatalf("expected j to move after an abandoned sequence, cursor = %d", m.cursor)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if len(m.Actions) != 1 || m.Actions[0].Type != "delete" || m.Actions[0].ID != 2 {
+		t.Fatalf("expected dd to delete #2, got %+v", m.Actions)
+	}
+	if !strings.Contains(m.View(), "dd delete") {
+		t.Error("expected custom binding in help footer")
+	}
+}
@@ -40,12 +40,19 @@ func WithHeight(h int) PickerOption {
 	return func(p *Picker) { p.height = h }
 }
 
+// WithBoost adds boost(item) to each item's fuzzy match score, so that e.g.
+// recently used items win close matches. The unfiltered order is unchanged.
+func WithBoost(boost func(Item) int) PickerOption {
+	return func(p *Picker) { p.boost = boost }
+}
+
 // Picker is a reusable fuzzy-search list selector built on Bubbletea.
 // Use Run() for the common case, or create a Picker and drive it manually.
 type Picker struct {
 	title  string
 	prompt string
 	height int
+	boost  func(Item) int
 
 	items    []Item
 	filtered []scored
@@ -258,6 +265,9 @@ func (p *Picker) applyFilter() {
 	} else {
 		for _, item := range p.items {
 			if ok, sc := FuzzyMatch(p.query, item.FilterValue()); ok {
+				if p.boost != nil {
+					sc += p.boost(item)
+				}
 				p.filtered = append(p.filtered, scored{item: item, score: sc})
 			}
 		}
@@ -77,6 +77,27 @@ func TestPicker_FilteringByQuery(t *testing.T) {
 	}
 }
 
+func TestPicker_BoostReordersMatches(t *testing.T) {
+	p := NewPicker(items("api-gateway", "api"), WithBoost(func(it Item) int {
+		if it.Title() == "api-gateway" {
+			return 10
+		}
+		return 0
+	}))
+
+	p.query = "api"
+	p.applyFilter()
+	if len(p.filtered) != 2 || p.filtered[0].item.Title() != "api-gateway" {
+		t.Fatalf("boosted item should rank first, got %v", p.filtered)
+	}
+
+	p.query = ""
+	p.applyFilter()
+	if p.filtered[0].item.Title() != "api-gateway" || p.filtered[1].item.Title() != "api" {
+		t.Fatal("boost should not reorder the unfiltered list")
+	}
+}
+
 func TestPicker_Navigation(t *testing.T) {
 	p := NewPicker(items("one", "two", "three"))
 
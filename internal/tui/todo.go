@@ -8,17 +8,27 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/config"
 	"github.com/rnwolfe/mine/internal/todo"
 	"github.com/rnwolfe/mine/internal/ui"
 )
 
 // TodoAction represents an action taken in the todo TUI.
 type TodoAction struct {
-	Type        string // "toggle", "delete", "add", "schedule", "quit"
+	Type        string // "toggle", "delete", "add", "schedule", "pin", "unpin", "note", "tag", "quit"
 	ID          int
-	Text        string
+	Text        string  // title for "add", note body for "note", tag for "tag"
 	Schedule    string  // for "schedule" actions
 	ProjectPath *string // project context for "add" actions
+	Batch       int     // non-zero for actions applied to several marked todos at once
+}
+
+// TodoOption configures a TodoModel.
+type TodoOption func(*TodoModel)
+
+// WithKeys replaces the default key bindings.
+func WithKeys(keys *TodoKeyMap) TodoOption {
+	return func(m *TodoModel) { m.keys = keys }
 }
 
 // TodoModel is a full interactive Bubbletea model for managing todos.
@@ -32,9 +42,21 @@ type TodoModel struct {
 	// add mode state
 	addInput string
 
+	// note mode state: the todo being annotated and the text so far
+	noteID    int
+	noteInput string
+
+	// detail pane state
+	loadDetail func(id int) (*TodoDetail, error)
+	detail     *TodoDetail
+	detailErr  error
+
 	// project context for new todos added via TUI
 	projectPath *string
 
+	// depths maps todo ID to subtask nesting depth for indentation.
+	depths map[int]int
+
 	// showAll indicates the TUI is displaying todos across all projects,
 	// enabling @project annotations per task.
 	showAll bool
@@ -46,23 +68,63 @@ type TodoModel struct {
 	// pending actions to apply after quitting
 	Actions []TodoAction
 
+	// view: sort mode and grouping, toggled at runtime
+	sortMode string
+	group    string
+
+	// key bindings and the start of a multi-key sequence being typed
+	keys        *TodoKeyMap
+	pendingKeys string
+
+	// undo stack of toggle/delete/schedule steps taken this session; a
+	// batch action is one step
+	undo [][]todoUndo
+
+	// multi-select: marked todo IDs, the tag being typed for them, and a
+	// counter numbering batches of actions
+	marked   map[int]bool
+	tagInput string
+	batches  int
+	// status is a one-line message shown above the help line
+	status string
+
 	quitting bool
 }
 
+// todoUndo records enough to reverse one action before it is flushed:
+// the todo as it was and where it sat in the list.
+type todoUndo struct {
+	action TodoAction
+	prev   todo.Todo
+	index  int
+}
+
 type todoMode int
 
 const (
 	todoModeNormal todoMode = iota
 	todoModeFilter
 	todoModeAdd
+	todoModeNote
+	todoModeTag
 )
 
 // NewTodoModel creates a new TodoModel with the given todos.
-func NewTodoModel(todos []todo.Todo) *TodoModel {
+func NewTodoModel(todos []todo.Todo, opts ...TodoOption) *TodoModel {
+	todos, depths := todo.NestSubtasks(todos)
 	m := &TodoModel{
 		todos:  todos,
-		width:  80,
-		height: 24,
+		depths: depths,
+		keys:   defaultTodoKeyMap(),
+		marked: map[int]bool{},
+
+		sortMode: SortUrgency,
+		group:    GroupNone,
+		width:    80,
+		height:   24,
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
 	m.applyFilter()
 	return m
@@ -71,16 +133,34 @@ func NewTodoModel(todos []todo.Todo) *TodoModel {
 // RunTodo launches the interactive todo TUI. Returns actions for the caller to apply.
 // projectPath is the project context for new todos added via the TUI (may be nil).
 // showAll enables @project annotations when displaying todos across all projects.
-func RunTodo(todos []todo.Todo, projectPath *string, showAll bool) ([]TodoAction, error) {
-	m := NewTodoModel(todos)
+// Key bindings come from the [tui.keys] config section, and the sort and
+// grouping last used are restored from [tui] and saved back on exit.
+func RunTodo(todos []todo.Todo, projectPath *string, showAll bool, opts ...TodoOption) ([]TodoAction, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	keys, err := ParseTodoKeys(cfg.TUI.Keys)
+	if err != nil {
+		return nil, err
+	}
+	m := NewTodoModel(todos, append([]TodoOption{WithKeys(keys), WithView(cfg.TUI.Sort, cfg.TUI.Group)}, opts...)...)
 	m.projectPath = projectPath
 	m.showAll = showAll
+	sortMode, group := m.sortMode, m.group
 	prog := tea.NewProgram(m, tea.WithAltScreen())
 	result, err := prog.Run()
 	if err != nil {
 		return nil, fmt.Errorf("todo tui: %w", err)
 	}
 	final := result.(*TodoModel)
+
+	// Remember the last-used view for next time. Best effort: failing to
+	// save it shouldn't drop the session's actions.
+	if final.sortMode != sortMode || final.group != group {
+		cfg.TUI.Sort, cfg.TUI.Group = final.sortMode, final.group
+		_ = config.Save(cfg)
+	}
 	return final.Actions, nil
 }
 
@@ -107,85 +187,164 @@ func (m *TodoModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleFilterKey(msg)
 	case todoModeAdd:
 		return m.handleAddKey(msg)
+	case todoModeNote:
+		return m.handleNoteKey(msg)
+	case todoModeTag:
+		return m.handleTagKey(msg)
 	default:
 		return m.handleNormalKey(msg)
 	}
 }
 
 func (m *TodoModel) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
 	switch msg.String() {
-	case "ctrl+c", "q":
+	case "ctrl+c":
 		m.quitting = true
 		return m, tea.Quit
 
 	case "esc":
-		// Esc goes back one step: clear active filter if one is set, otherwise no-op.
-		if m.filter != "" {
+		// Esc goes back one step: drop a half-typed key sequence, clear the
+		// marks, close the detail pane, else clear an active filter,
+		// otherwise no-op.
+		if m.pendingKeys != "" {
+			m.pendingKeys = ""
+		} else if len(m.marked) > 0 {
+			m.marked = map[int]bool{}
+		} else if m.detail != nil || m.detailErr != nil {
+			m.closeDetail()
+		} else if m.filter != "" {
 			m.filter = ""
 			m.applyFilter()
 			m.cursor = 0
 		}
+		return m, nil
+	}
 
-	case "j", "down":
+	switch m.resolveKey(msg.String()) {
+	case "quit":
+		m.quitting = true
+		return m, tea.Quit
+
+	case "down":
 		if m.cursor < len(m.filtered)-1 {
 			m.cursor++
+			m.refreshDetail()
 		}
 
-	case "k", "up":
+	case "up":
 		if m.cursor > 0 {
 			m.cursor--
+			m.refreshDetail()
 		}
 
-	case "g":
+	case "top":
 		m.cursor = 0
+		m.refreshDetail()
 
-	case "G":
+	case "bottom":
 		if len(m.filtered) > 0 {
 			m.cursor = len(m.filtered) - 1
+			m.refreshDetail()
 		}
 
-	case "x", " ", "enter":
-		if len(m.filtered) > 0 {
+	case "details":
+		if m.loadDetail == nil {
+			return m.toggleSelected()
+		}
+		if m.detail != nil || m.detailErr != nil {
+			m.closeDetail()
+		} else {
+			m.openDetail()
+		}
+
+	case "note":
+		if len(m.filtered) > 0 && m.filtered[m.cursor].ID > 0 {
+			m.mode = todoModeNote
+			m.noteID = m.filtered[m.cursor].ID
+			m.noteInput = ""
+		}
+
+	case "toggle":
+		if len(m.marked) > 0 {
+			m.batchToggle()
+			break
+		}
+		return m.toggleSelected()
+
+	case "sort":
+		m.sortMode = cycleMode(SortModes, m.sortMode)
+		m.reorder()
+		m.status = "Sorted by " + m.sortMode
+
+	case "group":
+		m.group = cycleMode(GroupModes, m.group)
+		m.reorder()
+		m.status = "Grouped by " + m.group
+
+	case "mark":
+		m.toggleMark()
+
+	case "markall":
+		m.toggleMarkAll()
+
+	case "tag":
+		if len(m.marked) > 0 || (len(m.filtered) > 0 && m.filtered[m.cursor].ID > 0) {
+			m.mode = todoModeTag
+			m.tagInput = ""
+		}
+
+	case "undo":
+		m.undoLast()
+
+	case "schedule":
+		if len(m.marked) > 0 {
+			m.batchSchedule()
+		} else if len(m.filtered) > 0 {
 			t := m.filtered[m.cursor]
-			// Skip toggle for locally-added todos that haven't been persisted yet.
+			// Skip for locally-added todos that haven't been persisted yet.
 			if t.ID < 0 {
 				break
 			}
-			m.Actions = append(m.Actions, TodoAction{Type: "toggle", ID: t.ID})
-			// Toggle locally for immediate feedback
+			next := nextSchedule(t.Schedule)
+			// Update in-memory for immediate feedback.
 			for i, item := range m.todos {
 				if item.ID == t.ID {
-					m.todos[i].Done = !m.todos[i].Done
+					m.pushUndo(TodoAction{Type: "schedule", ID: t.ID, Schedule: next}, i)
+					m.todos[i].Schedule = next
 					break
 				}
 			}
 			m.applyFilter()
-			if m.cursor >= len(m.filtered) && m.cursor > 0 {
-				m.cursor = len(m.filtered) - 1
-			}
+			m.Actions = append(m.Actions, TodoAction{Type: "schedule", ID: t.ID, Schedule: next})
 		}
 
-	case "s":
+	case "pin":
 		if len(m.filtered) > 0 {
 			t := m.filtered[m.cursor]
 			// Skip for locally-added todos that haven't been persisted yet.
 			if t.ID < 0 {
 				break
 			}
-			next := nextSchedule(t.Schedule)
+			action := "pin"
+			if t.Pinned {
+				action = "unpin"
+			}
 			// Update in-memory for immediate feedback.
 			for i, item := range m.todos {
 				if item.ID == t.ID {
-					m.todos[i].Schedule = next
+					m.todos[i].Pinned = !t.Pinned
 					break
 				}
 			}
 			m.applyFilter()
-			m.Actions = append(m.Actions, TodoAction{Type: "schedule", ID: t.ID, Schedule: next})
+			m.Actions = append(m.Actions, TodoAction{Type: action, ID: t.ID})
 		}
 
-	case "d":
-		if len(m.filtered) > 0 {
+	case "delete":
+		if len(m.marked) > 0 {
+			m.batchDelete()
+		} else if len(m.filtered) > 0 {
 			t := m.filtered[m.cursor]
 			if t.ID < 0 {
 				// Locally-added todo that was never persisted — remove from
@@ -223,6 +382,7 @@ func (m *TodoModel) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				// Remove locally
 				for i, item := range m.todos {
 					if item.ID == t.ID {
+						m.pushUndo(TodoAction{Type: "delete", ID: t.ID}, i)
 						m.todos = append(m.todos[:i], m.todos[i+1:]...)
 						break
 					}
@@ -234,11 +394,11 @@ func (m *TodoModel) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "a":
+	case "add":
 		m.mode = todoModeAdd
 		m.addInput = ""
 
-	case "/":
+	case "filter":
 		m.mode = todoModeFilter
 		m.filter = ""
 		m.applyFilter()
@@ -248,6 +408,146 @@ func (m *TodoModel) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// reorder re-applies the view after a sort or grouping change, keeping the
+// cursor on the same todo.
+func (m *TodoModel) reorder() {
+	id := 0
+	if len(m.filtered) > 0 {
+		id = m.filtered[m.cursor].ID
+	}
+	m.applyFilter()
+	for i, t := range m.filtered {
+		if t.ID == id {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// resolveKey feeds one key press through the key map, buffering the start
+// of multi-key sequences like "dd". Returns the action, or "" when the key
+// is unbound or a sequence is still being typed.
+func (m *TodoModel) resolveKey(key string) string {
+	seq := m.pendingKeys + key
+	action, prefix := m.keys.Resolve(seq)
+	switch {
+	case action != "":
+		m.pendingKeys = ""
+		return action
+	case prefix:
+		m.pendingKeys = seq
+		return ""
+	case m.pendingKeys != "":
+		// The sequence went nowhere: start over from this key.
+		m.pendingKeys = ""
+		return m.resolveKey(key)
+	}
+	return ""
+}
+
+// toggleSelected flips the done state of the selected todo.
+func (m *TodoModel) toggleSelected() (tea.Model, tea.Cmd) {
+	if len(m.filtered) == 0 {
+		return m, nil
+	}
+	t := m.filtered[m.cursor]
+	// Skip toggle for locally-added todos that haven't been persisted yet.
+	if t.ID < 0 {
+		return m, nil
+	}
+	m.Actions = append(m.Actions, TodoAction{Type: "toggle", ID: t.ID})
+	// Toggle locally for immediate feedback
+	for i, item := range m.todos {
+		if item.ID == t.ID {
+			m.pushUndo(TodoAction{Type: "toggle", ID: t.ID}, i)
+			m.todos[i].Done = !m.todos[i].Done
+			break
+		}
+	}
+	m.applyFilter()
+	if m.cursor >= len(m.filtered) && m.cursor > 0 {
+		m.cursor = len(m.filtered) - 1
+	}
+	m.refreshDetail()
+	return m, nil
+}
+
+// pushUndo records the state of m.todos[i] before action changes it, as
+// an undo step of its own.
+func (m *TodoModel) pushUndo(action TodoAction, i int) {
+	m.undo = append(m.undo, []todoUndo{{action: action, prev: m.todos[i], index: i}})
+}
+
+// undoLast reverses the most recent toggle, delete, or schedule change, or
+// a whole batch of them: the todos are restored in place and their pending
+// actions are dropped, so nothing reaches the store.
+func (m *TodoModel) undoLast() {
+	if len(m.undo) == 0 {
+		m.status = "Nothing to undo"
+		return
+	}
+	step := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+
+	// Reverse order, so deleted todos slot back into the indexes they had.
+	for j := len(step) - 1; j >= 0; j-- {
+		u := step[j]
+		for i := len(m.Actions) - 1; i >= 0; i-- {
+			if m.Actions[i] == u.action {
+				m.removeAction(i)
+				break
+			}
+		}
+
+		switch u.action.Type {
+		case "delete":
+			idx := u.index
+			if idx > len(m.todos) {
+				idx = len(m.todos)
+			}
+			m.todos = append(m.todos[:idx], append([]todo.Todo{u.prev}, m.todos[idx:]...)...)
+		default:
+			for i, item := range m.todos {
+				if item.ID == u.prev.ID {
+					m.todos[i].Done = u.prev.Done
+					m.todos[i].Schedule = u.prev.Schedule
+					break
+				}
+			}
+		}
+	}
+	m.applyFilter()
+
+	// Put the cursor back on the (first) restored todo when it's visible.
+	first := step[0]
+	for i, item := range m.filtered {
+		if item.ID == first.prev.ID {
+			m.cursor = i
+			break
+		}
+	}
+	if m.cursor >= len(m.filtered) && m.cursor > 0 {
+		m.cursor = len(m.filtered) - 1
+	}
+	m.refreshDetail()
+	if len(step) == 1 {
+		m.status = fmt.Sprintf("Undid %s #%d", first.action.Type, first.prev.ID)
+	} else {
+		m.status = fmt.Sprintf("Undid %s of %d todos", first.action.Type, len(step))
+	}
+}
+
+// removeAction drops a pending action, shifting the temporary IDs of
+// locally-added todos whose "add" action came after it.
+func (m *TodoModel) removeAction(idx int) {
+	m.Actions = append(m.Actions[:idx], m.Actions[idx+1:]...)
+	for i := range m.todos {
+		if m.todos[i].ID < 0 && (-m.todos[i].ID-1) > idx {
+			m.todos[i].ID++
+		}
+	}
+}
+
 func (m *TodoModel) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -334,6 +634,7 @@ func (m *TodoModel) applyFilter() {
 			m.filtered = append(m.filtered, t)
 		}
 	}
+	m.orderFiltered()
 }
 
 func (m *TodoModel) View() string {
@@ -341,6 +642,9 @@ func (m *TodoModel) View() string {
 
 	// Header
 	header := ui.Title.Render("  " + ui.IconTodo + " Todo")
+	if !m.defaultView() {
+		header += ui.Muted.Render(fmt.Sprintf("  sort: %s · group: %s", m.sortMode, m.group))
+	}
 	if m.filter != "" {
 		header += ui.Muted.Render(fmt.Sprintf("  filter: %q", m.filter))
 	}
@@ -359,13 +663,32 @@ func (m *TodoModel) View() string {
 	}
 
 	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
+	pane := m.renderDetail()
+	split := pane != "" && m.width >= detailSplitWidth
+	if pane != "" && !split {
+		// Stacked below the list: give the pane part of the list's rows.
+		visHeight -= lipgloss.Height(pane) + 1
+		if visHeight < 3 {
+			visHeight = 3
+		}
+		if m.cursor >= visHeight {
+			offset = m.cursor - visHeight + 1
+		} else {
+			offset = 0
+		}
+	}
+
+	var list strings.Builder
 	if len(m.filtered) == 0 {
 		if m.filter != "" {
-			b.WriteString("  " + ui.Muted.Render("No matches. Press esc to clear filter.") + "\n")
+			list.WriteString("  " + ui.Muted.Render("No matches. Press esc to clear filter.") + "\n")
 		} else {
-			b.WriteString("  " + ui.Muted.Render("No todos. Press 'a' to add one.") + "\n")
+			list.WriteString("  " + ui.Muted.Render("No todos. Press 'a' to add one.") + "\n")
+		}
+	} else if m.group != GroupNone {
+		for _, line := range m.groupedLines(offset, visHeight, now) {
+			list.WriteString(line + "\n")
 		}
 	} else {
 		end := offset + visHeight
@@ -376,11 +699,22 @@ func (m *TodoModel) View() string {
 			t := m.filtered[i]
 			selected := i == m.cursor
 
-			line := m.renderTodoItem(t, selected, today)
-			b.WriteString(line + "\n")
+			line := m.renderTodoItem(t, selected, now)
+			list.WriteString(line + "\n")
 		}
 	}
 
+	switch {
+	case split:
+		listW := m.width - detailPaneWidth - 2
+		left := lipgloss.NewStyle().Width(listW).MaxWidth(listW).Render(strings.TrimRight(list.String(), "\n"))
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, "  ", pane) + "\n")
+	case pane != "":
+		b.WriteString(list.String() + "\n" + pane + "\n")
+	default:
+		b.WriteString(list.String())
+	}
+
 	b.WriteString("\n")
 
 	// Input area (filter or add mode)
@@ -391,6 +725,16 @@ func (m *TodoModel) View() string {
 	case todoModeAdd:
 		prompt := lipgloss.NewStyle().Foreground(ui.Emerald).Bold(true).Render("add:")
 		b.WriteString("  " + prompt + " " + m.addInput + blinkCursor() + "\n")
+	case todoModeNote:
+		prompt := lipgloss.NewStyle().Foreground(ui.Emerald).Bold(true).Render(fmt.Sprintf("note #%d:", m.noteID))
+		b.WriteString("  " + prompt + " " + m.noteInput + blinkCursor() + "\n")
+	case todoModeTag:
+		label := "tag:"
+		if n := len(m.marked); n > 0 {
+			label = fmt.Sprintf("tag %d todos:", n)
+		}
+		prompt := lipgloss.NewStyle().Foreground(ui.Emerald).Bold(true).Render(label)
+		b.WriteString("  " + prompt + " " + m.tagInput + blinkCursor() + "\n")
 	default:
 		b.WriteString("\n")
 	}
@@ -405,6 +749,12 @@ func (m *TodoModel) View() string {
 		}
 	}
 	countStr := ui.Muted.Render(fmt.Sprintf("  %d/%d shown · %d open", len(m.filtered), len(m.todos), open))
+	if n := len(m.marked); n > 0 {
+		countStr += ui.Accent.Render(fmt.Sprintf(" · %d marked", n))
+	}
+	if m.status != "" {
+		countStr += "  " + ui.Accent.Render(m.status)
+	}
 	b.WriteString(countStr + "\n")
 
 	// Help line
@@ -412,29 +762,58 @@ func (m *TodoModel) View() string {
 	switch m.mode {
 	case todoModeFilter:
 		help = ui.Muted.Render("  esc clear · enter confirm")
-	case todoModeAdd:
+	case todoModeAdd, todoModeNote, todoModeTag:
 		help = ui.Muted.Render("  enter save · esc cancel")
 	default:
-		help = ui.Muted.Render("  j/k move · x toggle · s schedule · a add · d delete · / filter · esc clear filter · q quit")
+		help = ui.Muted.Render("  " + m.keys.Help())
 	}
 	b.WriteString(help + "\n")
 
 	return b.String()
 }
 
-func (m *TodoModel) renderTodoItem(t todo.Todo, selected bool, today time.Time) string {
+// groupedLines renders up to height lines of the list with a heading above
+// each group, starting near offset and scrolling further if the headings
+// would push the cursor out of view.
+func (m *TodoModel) groupedLines(offset, height int, now time.Time) []string {
+	for start := offset; ; start++ {
+		var lines []string
+		cursorShown := false
+		for i := start; i < len(m.filtered) && len(lines) < height; i++ {
+			t := m.filtered[i]
+			if key := m.groupKey(t); i == start || key != m.groupKey(m.filtered[i-1]) {
+				if len(lines)+1 >= height && i != start {
+					break
+				}
+				lines = append(lines, "  "+ui.Accent.Render(key))
+			}
+			lines = append(lines, m.renderTodoItem(t, i == m.cursor, now))
+			cursorShown = cursorShown || i == m.cursor
+		}
+		if cursorShown || start >= m.cursor {
+			return lines
+		}
+	}
+}
+
+func (m *TodoModel) renderTodoItem(t todo.Todo, selected bool, now time.Time) string {
 	pointer := "  "
 	titleStyle := lipgloss.NewStyle()
 
 	if selected {
 		pointer = ui.Accent.Render(ui.IconArrow + " ")
 		titleStyle = lipgloss.NewStyle().Foreground(ui.Gold).Bold(true)
+	} else if m.marked[t.ID] {
+		pointer = ui.Accent.Render("• ")
+	}
+	if m.marked[t.ID] {
+		titleStyle = titleStyle.Foreground(ui.Gold)
 	}
 
 	// Done marker
 	marker := " "
 	if t.Done {
-		marker = ui.Success.Render("✓")
+		marker = ui.Success.Render(ui.IconCheck)
 	}
 
 	var idStr string
@@ -455,25 +834,26 @@ func (m *TodoModel) renderTodoItem(t todo.Todo, selected bool, today time.Time)
 
 	recurTag := ""
 	if t.Recurrence != "" && t.Recurrence != todo.RecurrenceNone {
-		recurTag = " " + ui.Muted.Render("↻")
+		recurTag = " " + ui.Muted.Render(ui.IconRecur)
+	}
+	// Indent subtasks only in the unfiltered, unsorted view, where parents
+	// are adjacent.
+	indent := ""
+	if m.filter == "" && m.defaultView() {
+		indent = todo.FormatSubtaskIndent(m.depths[t.ID])
+	}
+	line := fmt.Sprintf("  %s %s %s %s %s %s%s%s", pointer, marker, id, prio, schedTag, indent, title, recurTag)
+	line += todo.FormatPinnedTag(t.Pinned)
+	line += todo.FormatContextTag(t.Context)
+	line += todo.FormatWaitingTag(t.WaitingOn)
+	line += todo.FormatEstimateTag(t.Estimate)
+
+	if !t.Done {
+		line += todo.FormatBlockedTag(t.BlockedBy)
 	}
-	line := fmt.Sprintf("  %s %s %s %s %s %s%s", pointer, marker, id, prio, schedTag, title, recurTag)
 
 	// Due annotation
-	if t.DueDate != nil && !t.Done {
-		due := *t.DueDate
-		dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
-		switch {
-		case dueDay.Before(today):
-			line += ui.Error.Render(fmt.Sprintf(" (overdue: %s)", due.Format("Jan 2")))
-		case dueDay.Equal(today):
-			line += ui.Warning.Render(" (due today!)")
-		case dueDay.Before(today.AddDate(0, 0, 7)):
-			line += ui.Muted.Render(fmt.Sprintf(" (due %s)", due.Format("Mon")))
-		default:
-			line += ui.Muted.Render(fmt.Sprintf(" (due %s)", due.Format("Jan 2")))
-		}
-	}
+	line += todo.FormatDueAnnotation(t, now)
 
 	// Tags
 	if len(t.Tags) > 0 {
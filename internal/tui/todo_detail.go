@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rnwolfe/mine/internal/todo"
+	"github.com/rnwolfe/mine/internal/ui"
+)
+
+// TodoDetail is what the detail pane shows for the selected todo.
+type TodoDetail struct {
+	Todo  todo.Todo // with Notes populated
+	Focus time.Duration
+}
+
+// WithDetailLoader enables the detail pane: Enter loads the selected todo's
+// body, notes, and focus time through load. Without it, Enter toggles done.
+func WithDetailLoader(load func(id int) (*TodoDetail, error)) TodoOption {
+	return func(m *TodoModel) { m.loadDetail = load }
+}
+
+// openDetail loads the selected todo into the detail pane.
+func (m *TodoModel) openDetail() {
+	if len(m.filtered) == 0 || m.loadDetail == nil {
+		return
+	}
+	t := m.filtered[m.cursor]
+	if t.ID < 0 {
+		// Not persisted yet: nothing to load beyond what we have.
+		m.detail, m.detailErr = &TodoDetail{Todo: t}, nil
+		return
+	}
+	m.detail, m.detailErr = m.loadDetail(t.ID)
+	if m.detail != nil {
+		// Keep in-session edits (done, schedule, pin) visible in the pane.
+		m.detail.Todo.Done = t.Done
+		m.detail.Todo.Schedule = t.Schedule
+		m.detail.Todo.Pinned = t.Pinned
+		m.detail.Todo.Notes = append(m.detail.Todo.Notes, m.pendingNotes(t.ID)...)
+	}
+}
+
+// refreshDetail follows the cursor when the detail pane is open.
+func (m *TodoModel) refreshDetail() {
+	if m.detail != nil || m.detailErr != nil {
+		m.openDetail()
+	}
+}
+
+func (m *TodoModel) closeDetail() {
+	m.detail, m.detailErr = nil, nil
+}
+
+// pendingNotes returns notes added this session that haven't been saved yet.
+func (m *TodoModel) pendingNotes(id int) []todo.Note {
+	var notes []todo.Note
+	for _, a := range m.Actions {
+		if a.Type == "note" && a.ID == id {
+			notes = append(notes, todo.Note{Body: a.Text, CreatedAt: time.Now()})
+		}
+	}
+	return notes
+}
+
+func (m *TodoModel) handleNoteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = todoModeNormal
+		m.noteInput = ""
+
+	case "enter":
+		text := strings.TrimSpace(m.noteInput)
+		if text != "" {
+			m.Actions = append(m.Actions, TodoAction{Type: "note", ID: m.noteID, Text: text})
+			if m.detail != nil && m.detail.Todo.ID == m.noteID {
+				m.detail.Todo.Notes = append(m.detail.Todo.Notes, todo.Note{Body: text, CreatedAt: time.Now()})
+			}
+		}
+		m.mode = todoModeNormal
+		m.noteInput = ""
+
+	case "backspace":
+		if len(m.noteInput) > 0 {
+			runes := []rune(m.noteInput)
+			m.noteInput = string(runes[:len(runes)-1])
+		}
+
+	default:
+		if len(msg.Runes) > 0 {
+			m.noteInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// detailSplitWidth is the terminal width from which the detail pane sits
+// beside the list instead of below it.
+const (
+	detailSplitWidth = 100
+	detailPaneWidth  = 44
+)
+
+// renderDetail draws the detail pane for the open todo, or "" when closed.
+func (m *TodoModel) renderDetail() string {
+	if m.detail == nil && m.detailErr == nil {
+		return ""
+	}
+	width := detailPaneWidth
+	if m.width < detailSplitWidth {
+		width = m.width - 4
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.Gold).
+		Padding(0, 1).
+		Width(width - 2)
+	if m.width < detailSplitWidth {
+		box = box.MarginLeft(2)
+	}
+	if m.detailErr != nil {
+		return box.Render(ui.Error.Render(m.detailErr.Error()))
+	}
+
+	t := m.detail.Todo
+	var lines []string
+	lines = append(lines, ui.Title.Render(fmt.Sprintf("#%d %s", t.ID, t.Title)))
+
+	meta := []string{todo.ScheduleLabel(t.Schedule), todo.PriorityLabel(t.Priority)}
+	if due := todo.DueLabel(t, "Mon Jan 2"); due != "" {
+		meta = append(meta, "due "+due)
+	}
+	if t.Done {
+		meta = append(meta, "done")
+	}
+	lines = append(lines, ui.Muted.Render(strings.Join(meta, " · ")))
+	if m.detail.Focus > 0 {
+		lines = append(lines, ui.Muted.Render("Focus: "+formatDetailDuration(m.detail.Focus)))
+	}
+
+	if t.Body != "" {
+		lines = append(lines, "", t.Body)
+	}
+
+	lines = append(lines, "", ui.Accent.Render(fmt.Sprintf("Notes (%d)", len(t.Notes))))
+	if len(t.Notes) == 0 {
+		lines = append(lines, ui.Muted.Render("No notes. Press n to add one."))
+	}
+	for _, n := range t.Notes {
+		lines = append(lines, ui.Muted.Render(n.CreatedAt.Local().Format("Jan 2 15:04"))+" "+n.Body)
+	}
+	return box.Render(strings.Join(lines, "\n"))
+}
+
+// formatDetailDuration renders a focus duration as "Xh Ym" or "Ym".
+func formatDetailDuration(d time.Duration) string {
+	h := int(d.Hours())
+	mins := int(d.Minutes()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%dh %dm", h, mins)
+	}
+	return fmt.Sprintf("%dm", mins)
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+// markedIndexes returns the positions in m.todos of marked todos.
+func (m *TodoModel) markedIndexes() []int {
+	var idx []int
+	for i, t := range m.todos {
+		if m.marked[t.ID] {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// toggleMark marks or unmarks the selected todo and moves to the next one.
+func (m *TodoModel) toggleMark() {
+	if len(m.filtered) == 0 {
+		return
+	}
+	t := m.filtered[m.cursor]
+	// Locally-added todos have no ID to act on yet.
+	if t.ID < 0 {
+		return
+	}
+	if m.marked[t.ID] {
+		delete(m.marked, t.ID)
+	} else {
+		m.marked[t.ID] = true
+	}
+	if m.cursor < len(m.filtered)-1 {
+		m.cursor++
+		m.refreshDetail()
+	}
+}
+
+// toggleMarkAll marks every visible todo, or clears the marks when they are
+// all marked already.
+func (m *TodoModel) toggleMarkAll() {
+	all := true
+	for _, t := range m.filtered {
+		if t.ID > 0 && !m.marked[t.ID] {
+			all = false
+			break
+		}
+	}
+	if all {
+		m.marked = map[int]bool{}
+		return
+	}
+	for _, t := range m.filtered {
+		if t.ID > 0 {
+			m.marked[t.ID] = true
+		}
+	}
+}
+
+// nextBatch numbers a new group of actions applied together.
+func (m *TodoModel) nextBatch() int {
+	m.batches++
+	return m.batches
+}
+
+// finishBatch clears the marks and settles the list after a batch action.
+func (m *TodoModel) finishBatch(step []todoUndo, status string) {
+	if len(step) > 0 {
+		m.undo = append(m.undo, step)
+	}
+	m.marked = map[int]bool{}
+	m.applyFilter()
+	if m.cursor >= len(m.filtered) && m.cursor > 0 {
+		m.cursor = len(m.filtered) - 1
+	}
+	m.refreshDetail()
+	m.status = status
+}
+
+// batchToggle completes every marked todo, or reopens them all when none
+// of them is open.
+func (m *TodoModel) batchToggle() {
+	idx := m.markedIndexes()
+	anyOpen := false
+	for _, i := range idx {
+		if !m.todos[i].Done {
+			anyOpen = true
+			break
+		}
+	}
+	batch := m.nextBatch()
+	var step []todoUndo
+	for _, i := range idx {
+		if m.todos[i].Done == anyOpen {
+			continue
+		}
+		action := TodoAction{Type: "toggle", ID: m.todos[i].ID, Batch: batch}
+		step = append(step, todoUndo{action: action, prev: m.todos[i], index: i})
+		m.todos[i].Done = anyOpen
+		m.Actions = append(m.Actions, action)
+	}
+	verb := "Reopened"
+	if anyOpen {
+		verb = "Completed"
+	}
+	m.finishBatch(step, fmt.Sprintf("%s %d todos", verb, len(step)))
+}
+
+// batchDelete deletes every marked todo.
+func (m *TodoModel) batchDelete() {
+	idx := m.markedIndexes()
+	batch := m.nextBatch()
+	var step []todoUndo
+	// Back to front, so each recorded index is valid when it's removed.
+	for j := len(idx) - 1; j >= 0; j-- {
+		i := idx[j]
+		action := TodoAction{Type: "delete", ID: m.todos[i].ID, Batch: batch}
+		step = append(step, todoUndo{action: action, prev: m.todos[i], index: i})
+		m.todos = append(m.todos[:i], m.todos[i+1:]...)
+		m.Actions = append(m.Actions, action)
+	}
+	m.finishBatch(step, fmt.Sprintf("Deleted %d todos", len(step)))
+}
+
+// batchSchedule moves every marked todo to the bucket after the selected
+// todo's, so repeated presses cycle them all together.
+func (m *TodoModel) batchSchedule() {
+	idx := m.markedIndexes()
+	from := m.todos[idx[0]].Schedule
+	if len(m.filtered) > 0 && m.marked[m.filtered[m.cursor].ID] {
+		from = m.filtered[m.cursor].Schedule
+	}
+	next := nextSchedule(from)
+	batch := m.nextBatch()
+	var step []todoUndo
+	for _, i := range idx {
+		action := TodoAction{Type: "schedule", ID: m.todos[i].ID, Schedule: next, Batch: batch}
+		step = append(step, todoUndo{action: action, prev: m.todos[i], index: i})
+		m.todos[i].Schedule = next
+		m.Actions = append(m.Actions, action)
+	}
+	// Keep the marks so the next press cycles the same todos again.
+	marked := m.marked
+	m.finishBatch(step, fmt.Sprintf("Scheduled %d todos for %s", len(step), todo.ScheduleLabel(next)))
+	m.marked = marked
+}
+
+// applyTag tags the marked todos, or the selected one when nothing is marked.
+func (m *TodoModel) applyTag(tag string) {
+	idx := m.markedIndexes()
+	if len(idx) == 0 && len(m.filtered) > 0 {
+		for i, t := range m.todos {
+			if t.ID == m.filtered[m.cursor].ID {
+				idx = []int{i}
+				break
+			}
+		}
+	}
+	batch := 0
+	if len(idx) > 1 {
+		batch = m.nextBatch()
+	}
+	n := 0
+	for _, i := range idx {
+		t := &m.todos[i]
+		if t.ID < 0 || slices.Contains(t.Tags, tag) {
+			continue
+		}
+		t.Tags = append(slices.Clip(t.Tags), tag)
+		m.Actions = append(m.Actions, TodoAction{Type: "tag", ID: t.ID, Text: tag, Batch: batch})
+		n++
+	}
+	m.finishBatch(nil, fmt.Sprintf("Tagged %d todos #%s", n, tag))
+}
+
+func (m *TodoModel) handleTagKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = todoModeNormal
+		m.tagInput = ""
+
+	case "enter":
+		tag := strings.TrimPrefix(strings.TrimSpace(m.tagInput), "#")
+		if tag != "" && !strings.Contains(tag, ",") {
+			m.applyTag(tag)
+		}
+		m.mode = todoModeNormal
+		m.tagInput = ""
+
+	case "backspace":
+		if len(m.tagInput) > 0 {
+			runes := []rune(m.tagInput)
+			m.tagInput = string(runes[:len(runes)-1])
+		}
+
+	default:
+		if len(msg.Runes) > 0 {
+			m.tagInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
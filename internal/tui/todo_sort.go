@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rnwolfe/mine/internal/todo"
+)
+
+// Sort modes for the todo browser. Urgency keeps the order todos were
+// loaded in, which the store already ranks by urgency.
+const (
+	SortUrgency = "urgency"
+	SortCreated = "created"
+	SortDue     = "due"
+)
+
+// Grouping modes for the todo browser.
+const (
+	GroupNone    = "none"
+	GroupProject = "project"
+	GroupTag     = "tag"
+	GroupBucket  = "bucket"
+)
+
+// SortModes and GroupModes list the modes in the order the toggles cycle.
+var (
+	SortModes  = []string{SortUrgency, SortCreated, SortDue}
+	GroupModes = []string{GroupNone, GroupBucket, GroupProject, GroupTag}
+)
+
+// WithView sets the initial sort and grouping. Unknown modes fall back to
+// urgency order without groups.
+func WithView(sortMode, group string) TodoOption {
+	return func(m *TodoModel) {
+		if slices.Contains(SortModes, sortMode) {
+			m.sortMode = sortMode
+		}
+		if slices.Contains(GroupModes, group) {
+			m.group = group
+		}
+	}
+}
+
+// cycleMode returns the mode after current in modes, wrapping around.
+func cycleMode(modes []string, current string) string {
+	i := slices.Index(modes, current)
+	return modes[(i+1)%len(modes)]
+}
+
+// defaultView reports whether the list is in its loaded order, where
+// subtasks sit under their parents.
+func (m *TodoModel) defaultView() bool {
+	return m.sortMode == SortUrgency && m.group == GroupNone
+}
+
+// orderFiltered sorts m.filtered by group, then pinned first, then the sort
+// mode. The sort is stable, so ties keep urgency order.
+func (m *TodoModel) orderFiltered() {
+	if m.defaultView() {
+		return
+	}
+	sort.SliceStable(m.filtered, func(i, j int) bool {
+		a, b := m.filtered[i], m.filtered[j]
+		if ga, gb := m.groupRank(a), m.groupRank(b); ga != gb {
+			return ga < gb
+		}
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		switch m.sortMode {
+		case SortCreated:
+			return a.CreatedAt.After(b.CreatedAt)
+		case SortDue:
+			switch {
+			case a.DueDate == nil:
+				return false
+			case b.DueDate == nil:
+				return true
+			}
+			return a.DueDate.Before(*b.DueDate)
+		}
+		return false
+	})
+}
+
+// groupKey is the heading a todo is listed under in the current grouping,
+// or "" when not grouping.
+func (m *TodoModel) groupKey(t todo.Todo) string {
+	switch m.group {
+	case GroupProject:
+		if t.ProjectPath == nil {
+			return "(global)"
+		}
+		return filepath.Base(*t.ProjectPath)
+	case GroupTag:
+		if len(t.Tags) == 0 {
+			return "(untagged)"
+		}
+		return "#" + t.Tags[0]
+	case GroupBucket:
+		return todo.ScheduleLabel(t.Schedule)
+	}
+	return ""
+}
+
+// groupRank orders groups: buckets from today to someday, projects and tags
+// alphabetically with the catch-all group last.
+func (m *TodoModel) groupRank(t todo.Todo) string {
+	key := m.groupKey(t)
+	switch m.group {
+	case GroupBucket:
+		for i, s := range BoardColumns {
+			if s == t.Schedule {
+				return string(rune('0' + i))
+			}
+		}
+		return "9"
+	case GroupProject, GroupTag:
+		if strings.HasPrefix(key, "(") {
+			return "\xff"
+		}
+		return strings.ToLower(key)
+	}
+	return ""
+}
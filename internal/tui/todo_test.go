@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -581,3 +582,367 @@ func TestTodoModel_DoneItemsMuted(t *testing.T) {
 		t.Fatal("view should show check mark for done item")
 	}
 }
+
+func TestTodoModel_PinToggle(t *testing.T) {
+	todos := makeTodos("item one")
+	m := NewTodoModel(todos)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	if len(m.Actions) != 1 || m.Actions[0].Type != "pin" || m.Actions[0].ID != 1 {
+		t.Fatalf("expected pin action for #1, got %+v", m.Actions)
+	}
+	if !m.todos[0].Pinned {
+		t.Fatal("todo should be pinned locally")
+	}
+	if !strings.Contains(m.View(), "📌") {
+		t.Error("expected pin marker in view")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	if len(m.Actions) != 2 || m.Actions[1].Type != "unpin" {
+		t.Fatalf("expected unpin action, got %+v", m.Actions)
+	}
+	if m.todos[0].Pinned {
+		t.Fatal("todo should be unpinned locally")
+	}
+}
+
+func detailTestModel(t *testing.T) *TodoModel {
+	t.Helper()
+	return NewTodoModel(makeTodos("write report", "file taxes"), WithDetailLoader(func(id int) (*TodoDetail, error) {
+		td := makeTodos("write report", "file taxes")[id-1]
+		td.Body = "body of #" + fmt.Sprint(id)
+		td.Notes = []todo.Note{{Body: "first note", CreatedAt: time.Now()}}
+		return &TodoDetail{Todo: td, Focus: 90 * time.Minute}, nil
+	}))
+}
+
+func TestTodoModel_EnterOpensDetail(t *testing.T) {
+	m := detailTestModel(t)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.Actions) != 0 {
+		t.Fatalf("enter with a detail loader should not toggle, got %+v", m.Actions)
+	}
+	view := m.View()
+	for _, want := range []string{"body of #1", "first note", "Notes (1)", "Focus: 1h 30m"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in detail pane:\n%s", want, view)
+		}
+	}
+
+	// The pane follows the cursor.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if !strings.Contains(m.View(), "body of #2") {
+		t.Error("expected detail pane to follow the cursor")
+	}
+
+	// Esc closes the pane before anything else.
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if strings.Contains(m.View(), "body of #2") {
+		t.Error("expected esc to close the detail pane")
+	}
+}
+
+func TestTodoModel_EnterTogglesWithoutLoader(t *testing.T) {
+	m := NewTodoModel(makeTodos("buy milk"))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.Actions) != 1 || m.Actions[0].Type != "toggle" {
+		t.Fatalf("expected enter to toggle without a detail loader, got %+v", m.Actions)
+	}
+}
+
+func TestTodoModel_NoteEntry(t *testing.T) {
+	m := detailTestModel(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !strings.Contains(m.View(), "note #1:") {
+		t.Fatal("expected note prompt in view")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("called bob")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if len(m.Actions) != 1 || m.Actions[0].Type != "note" || m.Actions[0].ID != 1 || m.Actions[0].Text != "called bob" {
+		t.Fatalf("expected note action for #1, got %+v", m.Actions)
+	}
+	view := m.View()
+	if !strings.Contains(view, "called bob") || !strings.Contains(view, "Notes (2)") {
+		t.Errorf("expected new note in detail pane:\n%s", view)
+	}
+
+	// Esc cancels without recording anything.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("nope")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.Actions) != 1 {
+		t.Fatalf("expected cancelled note to be dropped, got %+v", m.Actions)
+	}
+}
+
+func TestTodoModel_UndoToggle(t *testing.T) {
+	m := NewTodoModel(makeTodos("buy milk", "walk dog"))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+
+	if len(m.Actions) != 0 {
+		t.Fatalf("expected toggle action dropped, got %+v", m.Actions)
+	}
+	if m.todos[0].Done {
+		t.Fatal("expected todo restored to open")
+	}
+	if !strings.Contains(m.View(), "Undid toggle #1") {
+		t.Error("expected undo status in view")
+	}
+}
+
+func TestTodoModel_UndoDeleteRestoresPosition(t *testing.T) {
+	m := NewTodoModel(makeTodos("one", "two", "three"))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if len(m.todos) != 2 {
+		t.Fatalf("expected 2 todos after delete, got %d", len(m.todos))
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+
+	if len(m.Actions) != 0 {
+		t.Fatalf("expected delete action dropped, got %+v", m.Actions)
+	}
+	if len(m.todos) != 3 || m.todos[1].Title != "two" {
+		t.Fatalf("expected #2 restored in place, got %+v", m.todos)
+	}
+	if m.cursor != 1 {
+		t.Errorf("expected cursor on restored todo, got %d", m.cursor)
+	}
+}
+
+func TestTodoModel_UndoScheduleIsLIFO(t *testing.T) {
+	todos := makeTodos("one")
+	todos[0].Schedule = todo.ScheduleToday
+	m := NewTodoModel(todos)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+
+	if len(m.Actions) != 1 || m.Actions[0].Schedule != todo.ScheduleSoon {
+		t.Fatalf("expected only the first schedule action left, got %+v", m.Actions)
+	}
+	if m.todos[0].Schedule != todo.ScheduleSoon {
+		t.Fatalf("schedule = %q, want soon", m.todos[0].Schedule)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if m.todos[0].Schedule != todo.ScheduleToday || len(m.Actions) != 0 {
+		t.Fatalf("expected schedule restored to today, got %q with %+v", m.todos[0].Schedule, m.Actions)
+	}
+	if !strings.Contains(m.View(), "Nothing to undo") {
+		t.Error("expected empty-stack message")
+	}
+}
+
+func TestTodoModel_UndoKeepsLocalAddIDs(t *testing.T) {
+	m := NewTodoModel(makeTodos("one"))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("new")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+
+	if len(m.Actions) != 1 || m.Actions[0].Type != "add" {
+		t.Fatalf("expected only the add action left, got %+v", m.Actions)
+	}
+	for _, item := range m.todos {
+		if item.Title == "new" && item.ID != -1 {
+			t.Fatalf("local todo ID = %d, want -1 after its action shifted", item.ID)
+		}
+	}
+}
+
+func TestTodoModel_MultiSelectBatchToggle(t *testing.T) {
+	m := NewTodoModel(makeTodos("one", "two", "three"))
+
+	// v marks and moves down: mark #1 and #3, skipping #2.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if !strings.Contains(m.View(), "2 marked") {
+		t.Fatal("expected marked count in status bar")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if len(m.Actions) != 2 {
+		t.Fatalf("expected 2 batched toggles, got %+v", m.Actions)
+	}
+	for _, a := range m.Actions {
+		if a.Type != "toggle" || a.Batch == 0 || a.Batch != m.Actions[0].Batch || a.ID == 2 {
+			t.Fatalf("unexpected batch actions: %+v", m.Actions)
+		}
+	}
+	if !m.todos[0].Done || m.todos[1].Done || !m.todos[2].Done {
+		t.Fatalf("expected #1 and #3 done, got %+v", m.todos)
+	}
+	if len(m.marked) != 0 {
+		t.Error("expected marks cleared after a batch action")
+	}
+
+	// One undo reverts the whole batch.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if len(m.Actions) != 0 || m.todos[0].Done || m.todos[2].Done {
+		t.Fatalf("expected batch undone, got %+v", m.Actions)
+	}
+}
+
+func TestTodoModel_MultiSelectBatchDeleteAndUndo(t *testing.T) {
+	m := NewTodoModel(makeTodos("one", "two", "three"))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}}) // unmark #1
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+
+	if len(m.todos) != 1 || m.todos[0].Title != "one" {
+		t.Fatalf("expected only #1 left, got %+v", m.todos)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	var titles []string
+	for _, item := range m.todos {
+		titles = append(titles, item.Title)
+	}
+	if strings.Join(titles, ",") != "one,two,three" || len(m.Actions) != 0 {
+		t.Fatalf("expected deletes undone in order, got %v with %+v", titles, m.Actions)
+	}
+}
+
+func TestTodoModel_MultiSelectScheduleAndTag(t *testing.T) {
+	m := NewTodoModel(makeTodos("one", "two"))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if m.todos[0].Schedule == "" || m.todos[0].Schedule != m.todos[1].Schedule {
+		t.Fatalf("expected all marked todos scheduled together, got %q and %q", m.todos[0].Schedule, m.todos[1].Schedule)
+	}
+	if len(m.marked) != 2 {
+		t.Fatal("expected marks kept so schedule can keep cycling")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#errands")})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	var tags []TodoAction
+	for _, a := range m.Actions {
+		if a.Type == "tag" {
+			tags = append(tags, a)
+		}
+	}
+	if len(tags) != 2 || tags[0].Text != "errands" || tags[0].Batch == 0 {
+		t.Fatalf("expected a batch of 2 tag actions, got %+v", m.Actions)
+	}
+	if len(m.todos[1].Tags) != 1 {
+		t.Errorf("expected local tags updated, got %v", m.todos[1].Tags)
+	}
+
+	// Esc clears marks before anything else.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.marked) != 0 {
+		t.Error("expected esc to clear marks")
+	}
+}
+
+func TestTodoModel_SortToggle(t *testing.T) {
+	todos := makeTodos("urgent", "old", "new")
+	now := time.Now()
+	todos[1].CreatedAt = now.AddDate(0, 0, -5)
+	todos[2].CreatedAt = now.AddDate(0, 0, 1)
+	due := now.AddDate(0, 0, 2)
+	todos[1].DueDate = &due
+	m := NewTodoModel(todos)
+
+	titles := func() string {
+		var out []string
+		for _, item := range m.filtered {
+			out = append(out, item.Title)
+		}
+		return strings.Join(out, ",")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if m.sortMode != SortCreated || titles() != "new,urgent,old" {
+		t.Fatalf("sort %q: got %s, want newest first", m.sortMode, titles())
+	}
+	if m.filtered[m.cursor].Title != "urgent" {
+		t.Errorf("expected cursor to stay on the same todo, got %q", m.filtered[m.cursor].Title)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if m.sortMode != SortDue || titles() != "old,urgent,new" {
+		t.Fatalf("sort %q: got %s, want dated first", m.sortMode, titles())
+	}
+	if !strings.Contains(m.View(), "sort: due") {
+		t.Error("expected sort mode in header")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if m.sortMode != SortUrgency || titles() != "urgent,old,new" {
+		t.Fatalf("sort %q: got %s, want loaded order", m.sortMode, titles())
+	}
+}
+
+func TestTodoModel_GroupByBucket(t *testing.T) {
+	todos := makeTodos("a", "b", "c")
+	todos[0].Schedule = todo.ScheduleSomeday
+	todos[1].Schedule = todo.ScheduleToday
+	todos[2].Schedule = todo.ScheduleSomeday
+	m := NewTodoModel(todos, WithView(SortUrgency, GroupBucket))
+
+	var titles []string
+	for _, item := range m.filtered {
+		titles = append(titles, item.Title)
+	}
+	if strings.Join(titles, ",") != "b,a,c" {
+		t.Fatalf("got %v, want today before someday", titles)
+	}
+	view := m.View()
+	if strings.Count(view, "someday") < 1 || strings.Index(view, "today") > strings.Index(view, "someday") {
+		t.Errorf("expected group headings in order:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	if m.group != GroupProject {
+		t.Errorf("group = %q, want project after bucket", m.group)
+	}
+	if !strings.Contains(m.View(), "(global)") {
+		t.Error("expected (global) heading for todos without a project")
+	}
+}
+
+func TestWithView_UnknownModesFallBack(t *testing.T) {
+	m := NewTodoModel(makeTodos("a"), WithView("bogus", "nope"))
+	if m.sortMode != SortUrgency || m.group != GroupNone {
+		t.Errorf("got %q/%q, want urgency/none", m.sortMode, m.group)
+	}
+}
+
+func TestTodoModel_GroupedViewKeepsCursorVisible(t *testing.T) {
+	var titles []string
+	for i := 0; i < 30; i++ {
+		titles = append(titles, fmt.Sprintf("task %d", i))
+	}
+	todos := makeTodos(titles...)
+	for i := range todos {
+		todos[i].Tags = []string{fmt.Sprintf("t%02d", i)}
+	}
+	m := NewTodoModel(todos, WithView(SortUrgency, GroupTag))
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+
+	if !strings.Contains(m.View(), "task 29") {
+		t.Error("expected the last todo visible with the cursor on it")
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import "strings"
+
+// blockTicks are the eighth-height block characters used by charts.
+var blockTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as one line of block characters scaled to the
+// largest value. Zero always draws the lowest tick and any non-zero value
+// draws at least the second, so quiet days stand apart from light ones.
+func Sparkline(values []int) string {
+	maxV := 0
+	for _, v := range values {
+		maxV = max(maxV, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		if v <= 0 || maxV == 0 {
+			b.WriteRune(blockTicks[0])
+			continue
+		}
+		// Map 1..max onto ticks 1..7.
+		i := 1 + (v*(len(blockTicks)-1)-1)/maxV
+		b.WriteRune(blockTicks[min(i, len(blockTicks)-1)])
+	}
+	return b.String()
+}
+
+// BarChart renders values as vertical bars height rows tall, scaled so the
+// largest value fills the chart. Rows are returned top first; each column
+// is one character wide.
+func BarChart(values []int, height int) []string {
+	maxV := 0
+	for _, v := range values {
+		maxV = max(maxV, v)
+	}
+	rows := make([]string, height)
+	for r := range height {
+		// r counts rows from the top; level is measured in eighths from the bottom.
+		floor := (height - 1 - r) * 8
+		var b strings.Builder
+		for _, v := range values {
+			level := 0
+			if maxV > 0 && v > 0 {
+				level = max(1, v*height*8/maxV)
+			}
+			fill := min(max(level-floor, 0), 8)
+			if fill == 0 {
+				b.WriteRune(' ')
+			} else {
+				b.WriteRune(blockTicks[fill-1])
+			}
+		}
+		rows[r] = b.String()
+	}
+	return rows
+}
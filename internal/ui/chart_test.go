@@ -0,0 +1,33 @@
+package ui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{[]int{0, 1, 7, 0}, "▁▂█▁"},
+		{[]int{0, 0}, "▁▁"},
+		{[]int{1, 100}, "▂█"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestBarChart(t *testing.T) {
+	got := BarChart([]int{4, 2, 0, 1}, 2)
+	want := []string{"█   ", "██ ▄"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
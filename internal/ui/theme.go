@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/termenv"
@@ -14,28 +17,138 @@ func init() {
 	}
 }
 
-// mine's color palette — warm and personal.
+// Palette is a named set of theme colors. Empty colors render as the
+// terminal's default foreground.
+type Palette struct {
+	Gold     lipgloss.Color
+	Amber    lipgloss.Color
+	Copper   lipgloss.Color
+	Stone    lipgloss.Color
+	Deep     lipgloss.Color
+	Emerald  lipgloss.Color
+	Ruby     lipgloss.Color
+	Sapphire lipgloss.Color
+	Dim      lipgloss.Color
+	Bright   lipgloss.Color
+	Subtle   lipgloss.Color
+}
+
+// Palettes are the built-in themes, selected with [ui.theme] name.
+var Palettes = map[string]Palette{
+	// default is mine's warm palette, tuned for dark terminals.
+	"default": {
+		Gold:     "#FFD700",
+		Amber:    "#FFBF00",
+		Copper:   "#B87333",
+		Stone:    "#8B8680",
+		Deep:     "#2D2D2D",
+		Emerald:  "#50C878",
+		Ruby:     "#E0115F",
+		Sapphire: "#0F52BA",
+		Dim:      "#666666",
+		Bright:   "#FFFFFF",
+		Subtle:   "#AAAAAA",
+	},
+	// light keeps the same hues, darkened to stay readable on white.
+	"light": {
+		Gold:     "#9A6B00",
+		Amber:    "#B35900",
+		Copper:   "#8B4513",
+		Stone:    "#5E5952",
+		Deep:     "#E8E8E8",
+		Emerald:  "#1B7A43",
+		Ruby:     "#B0003A",
+		Sapphire: "#0B3D91",
+		Dim:      "#6B6B6B",
+		Bright:   "#1A1A1A",
+		Subtle:   "#4D4D4D",
+	},
+	// mono drops colors and keeps bold and layout.
+	"mono": {},
+}
+
+// mine's color palette — warm and personal. Set by ApplyTheme.
 var (
 	// Primary colors
-	Gold     = lipgloss.Color("#FFD700")
-	Amber    = lipgloss.Color("#FFBF00")
-	Copper   = lipgloss.Color("#B87333")
-	Stone    = lipgloss.Color("#8B8680")
-	Deep     = lipgloss.Color("#2D2D2D")
-	Emerald  = lipgloss.Color("#50C878")
-	Ruby     = lipgloss.Color("#E0115F")
-	Sapphire = lipgloss.Color("#0F52BA")
-	Dim      = lipgloss.Color("#666666")
-	Bright   = lipgloss.Color("#FFFFFF")
-	Subtle   = lipgloss.Color("#AAAAAA")
+	Gold     lipgloss.Color
+	Amber    lipgloss.Color
+	Copper   lipgloss.Color
+	Stone    lipgloss.Color
+	Deep     lipgloss.Color
+	Emerald  lipgloss.Color
+	Ruby     lipgloss.Color
+	Sapphire lipgloss.Color
+	Dim      lipgloss.Color
+	Bright   lipgloss.Color
+	Subtle   lipgloss.Color
 
 	// Semantic styles
+	Title    lipgloss.Style
+	Subtitle lipgloss.Style
+	Success  lipgloss.Style
+	Error    lipgloss.Style
+	Warning  lipgloss.Style
+	Info     lipgloss.Style
+	Muted    lipgloss.Style
+	Accent   lipgloss.Style
+
+	// Component styles
+	Banner     lipgloss.Style
+	Tag        lipgloss.Style
+	KeyStyle   lipgloss.Style
+	ValueStyle lipgloss.Style
+
+	// Schedule bucket styles — for todo schedule tag rendering.
+	ScheduleTodayStyle lipgloss.Style
+	ScheduleSoonStyle  lipgloss.Style
+)
+
+func init() {
+	setPalette(Palettes["default"])
+	setIcons(false)
+}
+
+// ApplyTheme switches to a named palette. With asciiMode set, colors are
+// dropped entirely and icons fall back to plain ASCII, for terminals and
+// fonts without emoji. An empty name keeps the default palette.
+func ApplyTheme(name string, asciiMode bool) error {
+	if name == "" {
+		name = "default"
+	}
+	p, ok := Palettes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	setPalette(p)
+	setIcons(asciiMode)
+	if asciiMode {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	return nil
+}
+
+// ThemeNames returns the built-in theme names, sorted.
+func ThemeNames() []string {
+	names := make([]string, 0, len(Palettes))
+	for name := range Palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setPalette sets the colors and rebuilds every style from them.
+func setPalette(p Palette) {
+	Gold, Amber, Copper, Stone, Deep = p.Gold, p.Amber, p.Copper, p.Stone, p.Deep
+	Emerald, Ruby, Sapphire = p.Emerald, p.Ruby, p.Sapphire
+	Dim, Bright, Subtle = p.Dim, p.Bright, p.Subtle
+
 	Title = lipgloss.NewStyle().
 		Bold(true).
 		Foreground(Gold)
 
 	Subtitle = lipgloss.NewStyle().
-			Foreground(Amber)
+		Foreground(Amber)
 
 	Success = lipgloss.NewStyle().
 		Foreground(Emerald)
@@ -56,57 +169,122 @@ var (
 		Foreground(Gold).
 		Bold(true)
 
-	// Component styles
 	Banner = lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(Gold).
 		Padding(0, 1)
 
+	// Tag text sits on a copper background, so it stays white in every
+	// colored palette.
+	tagText := lipgloss.Color("")
+	if p.Copper != "" {
+		tagText = "#FFFFFF"
+	}
 	Tag = lipgloss.NewStyle().
-		Foreground(Bright).
+		Foreground(tagText).
 		Background(Copper).
 		Padding(0, 1).
 		Bold(true)
 
 	KeyStyle = lipgloss.NewStyle().
-			Foreground(Amber).
-			Bold(true)
+		Foreground(Amber).
+		Bold(true)
 
 	ValueStyle = lipgloss.NewStyle().
-			Foreground(Bright)
+		Foreground(Bright)
 
-	// Schedule bucket styles — for todo schedule tag rendering.
 	ScheduleTodayStyle = lipgloss.NewStyle().
-				Foreground(Gold).
-				Bold(true)
+		Foreground(Gold).
+		Bold(true)
 
 	ScheduleSoonStyle = lipgloss.NewStyle().
-				Foreground(Amber)
-)
+		Foreground(Amber)
+}
+
+// Icons — consistent emoji language. Set by ApplyTheme; ASCII mode swaps
+// in plain-text fallbacks.
+var (
+	IconMine     string
+	IconGem      string
+	IconGold     string
+	IconTodo     string
+	IconDone     string
+	IconOverdue  string
+	IconTools    string
+	IconPackage  string
+	IconVault    string
+	IconGrow     string
+	IconStar     string
+	IconFire     string
+	IconWarn     string
+	IconError    string
+	IconOk       string
+	IconArrow    string
+	IconDot      string
+	IconDig      string
+	IconProject  string
+	IconCalendar string
+	IconSettings string
+	IconParty    string
+	IconPick     string
 
-// Icon constants — consistent emoji language.
-const (
-	IconMine     = "▸ "
-	IconGem      = "✦"
-	IconGold     = "🏆"
-	IconTodo     = "📋"
-	IconDone     = "✅"
-	IconOverdue  = "🔴"
-	IconTools    = "🔧"
-	IconPackage  = "📦"
-	IconVault    = "🔑"
-	IconGrow     = "🌱"
-	IconStar     = "⭐"
-	IconFire     = "🔥"
-	IconWarn     = "⚠️ "
-	IconError    = "✗ "
-	IconOk       = "✓ "
-	IconArrow    = "→"
-	IconDot      = "·"
-	IconDig      = "🎯"
-	IconProject  = "📁"
-	IconCalendar = "📅"
-	IconSettings = "⚙️ "
-	IconParty    = "🎉"
-	IconPick     = "◆ "
+	// Todo list markers
+	IconCheck    string
+	IconRecur    string
+	IconPin      string
+	IconWaiting  string
+	IconBlocked  string
+	IconSubtask  string
+	IconSchedule string
 )
+
+// ascii reports whether ApplyTheme switched to ASCII mode.
+var ascii bool
+
+// ASCII reports whether plain ASCII output is in effect, for renderers
+// that pick their own symbols.
+func ASCII() bool {
+	return ascii
+}
+
+// setIcons picks the emoji icons, or their ASCII fallbacks.
+func setIcons(plain bool) {
+	ascii = plain
+	pick := func(emoji, plain string) string {
+		if ascii {
+			return plain
+		}
+		return emoji
+	}
+	IconMine = pick("▸ ", "> ")
+	IconGem = pick("✦", "*")
+	IconGold = pick("🏆", "[#1]")
+	IconTodo = pick("📋", "[todo]")
+	IconDone = pick("✅", "[x]")
+	IconOverdue = pick("🔴", "[!]")
+	IconTools = pick("🔧", "[tools]")
+	IconPackage = pick("📦", "[pkg]")
+	IconVault = pick("🔑", "[key]")
+	IconGrow = pick("🌱", "[grow]")
+	IconStar = pick("⭐", "*")
+	IconFire = pick("🔥", "[!]")
+	IconWarn = pick("⚠️ ", "! ")
+	IconError = pick("✗ ", "x ")
+	IconOk = pick("✓ ", "ok ")
+	IconArrow = pick("→", "->")
+	IconDot = pick("·", "-")
+	IconDig = pick("🎯", "[dig]")
+	IconProject = pick("📁", "[proj]")
+	IconCalendar = pick("📅", "[cal]")
+	IconSettings = pick("⚙️ ", "[cfg] ")
+	IconParty = pick("🎉", "!")
+	IconPick = pick("◆ ", "* ")
+
+	IconCheck = pick("✓", "x")
+	IconRecur = pick("↻", "(r)")
+	IconPin = pick("📌", "[pin]")
+	IconWaiting = pick("⏳", "waiting:")
+	IconBlocked = pick("⧗", "!")
+	IconSubtask = pick("↳ ", "- ")
+	IconSchedule = pick("▸", ">")
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// resetTheme restores the default palette and color profile after a test.
+func resetTheme(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		if err := ApplyTheme("", false); err != nil {
+			t.Fatal(err)
+		}
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	})
+}
+
+func TestApplyTheme_Light(t *testing.T) {
+	resetTheme(t)
+
+	if err := ApplyTheme("light", false); err != nil {
+		t.Fatal(err)
+	}
+	if Gold != Palettes["light"].Gold {
+		t.Errorf("Gold = %q, want light palette", Gold)
+	}
+	if got := Title.GetForeground(); got != Palettes["light"].Gold {
+		t.Errorf("Title foreground = %v, want styles rebuilt from the palette", got)
+	}
+	if IconTodo != "📋" {
+		t.Errorf("IconTodo = %q, want emoji outside ASCII mode", IconTodo)
+	}
+}
+
+func TestApplyTheme_ASCII(t *testing.T) {
+	resetTheme(t)
+
+	if err := ApplyTheme("default", true); err != nil {
+		t.Fatal(err)
+	}
+	if !ASCII() {
+		t.Error("expected ASCII mode on")
+	}
+	out := Success.Render(IconOk + "done")
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("expected no escape codes in ASCII mode, got %q", out)
+	}
+	for _, icon := range []string{IconOk, IconTodo, IconWarn, IconPin, IconSchedule} {
+		for _, r := range icon {
+			if r > 127 {
+				t.Errorf("icon %q is not ASCII", icon)
+				break
+			}
+		}
+	}
+}
+
+func TestApplyTheme_Unknown(t *testing.T) {
+	resetTheme(t)
+
+	err := ApplyTheme("neon", false)
+	if err == nil || !strings.Contains(err.Error(), "default, light, mono") {
+		t.Errorf("expected unknown-theme error listing themes, got %v", err)
+	}
+}